@@ -0,0 +1,162 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+)
+
+//batchStop describes a batch stop of the running containers matching a filter expression
+type batchStop struct {
+	kill   bool
+	filter string
+}
+
+//batchStopPrompt returns a prompt asking for a batch stop, prefilled with
+//the given filter expression
+func batchStopPrompt(filter string) *appui.Prompt {
+	return appui.NewPromptWithText(
+		"Stop containers matching: [action=stop|kill] name=<pattern> image=<pattern> label=<key>[=<value>]",
+		"action=stop "+filter)
+}
+
+//parseBatchStop parses the text typed on a batch stop prompt, the action,
+//stop unless given, is taken out and the rest is the filter expression
+func parseBatchStop(s string) (batchStop, docker.ContainerFilter, error) {
+	var stop batchStop
+	var terms []string
+	for _, term := range strings.Fields(s) {
+		if !strings.HasPrefix(term, "action=") {
+			terms = append(terms, term)
+			continue
+		}
+		switch action := strings.TrimPrefix(term, "action="); action {
+		case "stop":
+			stop.kill = false
+		case "kill":
+			stop.kill = true
+		default:
+			return stop, nil, fmt.Errorf("invalid action: %q", action)
+		}
+	}
+	stop.filter = strings.Join(terms, " ")
+	filter, err := docker.ParseContainerFilterExpression(stop.filter)
+	return stop, filter, err
+}
+
+//action returns the name of the action of this batch stop
+func (b batchStop) action() string {
+	if b.kill {
+		return "kill"
+	}
+	return "stop"
+}
+
+//participle returns the past participle of the action of this batch stop
+func (b batchStop) participle() string {
+	if b.kill {
+		return "killed"
+	}
+	return "stopped"
+}
+
+//preview describes the containers that this batch stop affects
+func (b batchStop) preview(containers []*docker.Container) string {
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "<yellow>%d running containers match</> <white>%s</>, they will be <red>%s</>.\n",
+		len(containers), b.filter, b.participle())
+	buf.WriteString("Press Esc or q to close this preview and confirm.\n\n")
+	w := tabwriter.NewWriter(buf, 0, 8, 3, ' ', 0)
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", ui.Blue("CONTAINER"), ui.Blue("NAME"), ui.Blue("IMAGE"), ui.Blue("STATUS"))
+	for _, c := range containers {
+		name := containerName(c)
+		if docker.IsContainerProtected(c) {
+			name += " " + ui.Red("(protected)")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", docker.TruncateID(c.ID), name, c.Image, c.Status)
+	}
+	w.Flush()
+	return buf.String()
+}
+
+//batchStopContainers asks for a filter expression, prefilled with the given
+//one, and stops, or kills, every running container matching it, after showing
+//a preview of the containers affected and asking for confirmation
+func (h *containersScreenEventHandler) batchStopContainers(filter string, f func(eventHandler)) {
+	dry := h.dry
+	prompt := batchStopPrompt(filter)
+	widgets.add(prompt)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+	go func() {
+		prompt.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(prompt)
+		text, canceled := prompt.Text()
+		if canceled {
+			f(h)
+			refreshScreen()
+			return
+		}
+		stop, filter, err := parseBatchStop(text)
+		if err != nil {
+			f(h)
+			refreshScreen()
+			dry.message("Cannot stop containers: " + err.Error())
+			return
+		}
+		containers := dry.dockerDaemon.Containers(
+			[]docker.ContainerFilter{docker.ContainerFilters.Running(), filter}, docker.SortByName)
+		if len(containers) == 0 {
+			f(h)
+			refreshScreen()
+			dry.message(fmt.Sprintf("No running containers match %s", stop.filter))
+			return
+		}
+		dry.pushView(NoView)
+		appui.Less(stop.preview(containers), h.screen, forwarder.events(), func() {
+			dry.popView()
+			h.confirmBatchStop(stop, containers, f)
+		})
+	}()
+}
+
+//confirmBatchStop asks for confirmation to stop the given containers, a
+//single confirmation is asked, overriding protection if any is protected
+func (h *containersScreenEventHandler) confirmBatchStop(stop batchStop, containers []*docker.Container, f func(eventHandler)) {
+	dry := h.dry
+	protected := false
+	var ids []string
+	for _, c := range containers {
+		protected = protected || docker.IsContainerProtected(c)
+		ids = append(ids, c.ID)
+	}
+	prompt := appui.NewPrompt(confirmationPrompt(
+		fmt.Sprintf("Do you want to %s %d containers matching %s?", stop.action(), len(containers), stop.filter),
+		protected))
+	widgets.add(prompt)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+
+	prompt.OnFocus(newEventSource(forwarder.events()))
+	widgets.remove(prompt)
+	conf, canceled := prompt.Text()
+	f(h)
+	defer refreshScreen()
+	if canceled || !isConfirmed(conf, protected) {
+		return
+	}
+	dry.message(fmt.Sprintf("<red>Going to %s %d containers</>", stop.action(), len(ids)))
+	count, err := dry.dockerDaemon.StopContainers(ids, stop.kill)
+	if err != nil {
+		dry.message(fmt.Sprintf("<red>%s</>", err.Error()))
+		return
+	}
+	dry.message(fmt.Sprintf("<red>%d containers %s</>", count, stop.participle()))
+}
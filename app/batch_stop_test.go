@@ -0,0 +1,33 @@
+package app
+
+import "testing"
+
+func Test_parseBatchStop(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    batchStop
+		wantErr bool
+	}{
+		{"action=stop name=web-*", batchStop{filter: "name=web-*"}, false},
+		{"label=env=prod action=kill", batchStop{kill: true, filter: "label=env=prod"}, false},
+		{"web-*", batchStop{filter: "web-*"}, false},
+		{"action=pause name=web-*", batchStop{}, true},
+		{"action=kill", batchStop{kill: true}, true},
+	}
+	for _, tt := range tests {
+		got, filter, err := parseBatchStop(tt.text)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseBatchStop(%q) error = %v, wantErr %v", tt.text, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseBatchStop(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+		if filter == nil {
+			t.Errorf("parseBatchStop(%q) returned no filter", tt.text)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	units "github.com/docker/go-units"
+	"github.com/moncho/dry/appui"
+)
+
+//parseKeepStorage parses the build cache storage to keep when pruning it,
+//i.e. 2GB, nothing is kept if empty
+func parseKeepStorage(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	size, err := units.RAMInBytes(s)
+	if err != nil || size < 0 {
+		return 0, fmt.Errorf("invalid storage to keep %q", s)
+	}
+	return size, nil
+}
+
+//pruneBuildCache asks for the build cache storage to keep and prunes the
+//build cache not in use
+func pruneBuildCache(dry *Dry, h eventHandler, f func(eventHandler)) {
+	prompt := appui.NewPrompt(
+		"Prune the build cache not in use, keeping at most (e.g. 2GB, empty keeps nothing):")
+	widgets.add(prompt)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+	go func() {
+		prompt.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(prompt)
+		text, canceled := prompt.Text()
+		f(h)
+		if canceled {
+			refreshScreen()
+			return
+		}
+		keep, err := parseKeepStorage(text)
+		if err != nil {
+			dry.message(fmt.Sprintf("<red>%s</>", err.Error()))
+			refreshScreen()
+			return
+		}
+		dry.message("<red>Pruning build cache</>")
+		refreshScreen()
+		report, err := dry.dockerDaemon.PruneBuildCache(keep)
+		if err != nil {
+			dry.message(fmt.Sprintf("<red>Error pruning build cache: %s</>", err.Error()))
+		} else {
+			dry.message(fmt.Sprintf("<red>Build cache pruned:</> <white>%d records removed, %s reclaimed</>",
+				len(report.CachesDeleted), units.HumanSize(float64(report.SpaceReclaimed))))
+			computeDiskUsage(dry)
+		}
+		refreshScreen()
+	}()
+}
@@ -0,0 +1,27 @@
+package app
+
+import "testing"
+
+func TestParseKeepStorage(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int64
+		wantErr bool
+	}{
+		{"", 0, false},
+		{" 512mb ", 512 * 1024 * 1024, false},
+		{"2GB", 2 * 1024 * 1024 * 1024, false},
+		{"lots", 0, true},
+		{"-1GB", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseKeepStorage(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseKeepStorage(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseKeepStorage(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
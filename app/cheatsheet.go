@@ -0,0 +1,50 @@
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"github.com/moncho/dry/ui"
+)
+
+//defaultCheatSheetFile is the file the cheat sheet is written to if no
+//other is given
+const defaultCheatSheetFile = "dry-keybindings.md"
+
+var helpSectionRegexp = regexp.MustCompile(`^<yellow>(.+)</>`)
+var helpKeybindRegexp = regexp.MustCompile(`^\t<white>(.+?)</>\s+(.+)$`)
+
+//cheatSheet returns dry keybindings as a markdown document. Keybindings
+//are taken from the given help screen.
+func cheatSheet(help string) string {
+	var buf bytes.Buffer
+	buf.WriteString("# dry keybindings\n")
+
+	scanner := bufio.NewScanner(strings.NewReader(help))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := helpSectionRegexp.FindStringSubmatch(line); m != nil {
+			fmt.Fprintf(&buf, "\n## %s\n\n", stripMarkup(m[1]))
+			buf.WriteString("| Key | Action |\n")
+			buf.WriteString("|-----|--------|\n")
+		} else if m := helpKeybindRegexp.FindStringSubmatch(line); m != nil {
+			fmt.Fprintf(&buf, "| `%s` | %s |\n",
+				stripMarkup(m[1]), strings.TrimSpace(stripMarkup(m[2])))
+		}
+	}
+	return buf.String()
+}
+
+//writeCheatSheet writes the keybindings cheat sheet of the given help screen
+//to the given file
+func writeCheatSheet(path string, help string) error {
+	return ioutil.WriteFile(path, []byte(cheatSheet(help)), 0644)
+}
+
+func stripMarkup(s string) string {
+	return ui.SupportedTags.ReplaceAllString(s, "")
+}
@@ -0,0 +1,26 @@
+package app
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheatSheet(t *testing.T) {
+	sheet := cheatSheet(help)
+
+	expected := []string{
+		"## Global keybinds",
+		"| `F7` | Toggles showing Docker daemon information |",
+		"## Container list keybinds",
+		"| `Ctrl+k` | Kills the selected container |",
+		"| `Q` | Quits dry |",
+	}
+	for _, e := range expected {
+		if !strings.Contains(sheet, e) {
+			t.Errorf("Cheat sheet does not contain %q:\n%s", e, sheet)
+		}
+	}
+	if strings.Contains(sheet, "<white>") || strings.Contains(sheet, "</>") {
+		t.Errorf("Cheat sheet contains markup:\n%s", sheet)
+	}
+}
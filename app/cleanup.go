@@ -0,0 +1,164 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	units "github.com/docker/go-units"
+	"github.com/moncho/dry/docker"
+)
+
+//defaultCleanupTime is when cleanups run if no schedule is given, 3 AM
+const defaultCleanupTime = 3 * time.Hour
+
+//cleanupSchedule is a cleanup of the Docker host run on a schedule, either
+//every given time or daily at a given time of the day
+type cleanupSchedule struct {
+	//enabled must be set for cleanups to run, so nothing is removed by mistake
+	enabled bool
+	//at is the time of the day, since midnight, daily cleanups run at
+	at time.Duration
+	//every is the time between cleanups, if not set they run daily
+	every time.Duration
+	opts  docker.CleanupOptions
+}
+
+//parseCleanup parses the settings of the cleanup section of the config file,
+//nil is returned if there are none
+func parseCleanup(settings map[string]string) (*cleanupSchedule, error) {
+	if len(settings) == 0 {
+		return nil, nil
+	}
+	s := &cleanupSchedule{at: -1}
+	for key, value := range settings {
+		var err error
+		switch key {
+		case "enabled":
+			s.enabled, err = strconv.ParseBool(value)
+		case "at":
+			s.at, err = parseTimeOfDay(value)
+		case "every":
+			s.every, err = time.ParseDuration(value)
+			if err == nil && s.every < time.Minute {
+				err = fmt.Errorf("cleanups must be at least a minute apart")
+			}
+		case "older_than":
+			s.opts.OlderThan, err = time.ParseDuration(value)
+		case "images":
+			switch value {
+			case "dangling":
+				s.opts.Images = true
+			case "unused":
+				s.opts.Images, s.opts.UnusedImages = true, true
+			case "none":
+			default:
+				err = fmt.Errorf("expected dangling, unused or none")
+			}
+		case "containers":
+			s.opts.Containers, err = strconv.ParseBool(value)
+		case "build_cache":
+			s.opts.BuildCache, err = strconv.ParseBool(value)
+		default:
+			return nil, fmt.Errorf("unknown cleanup setting %s", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid cleanup %s %q: %s", key, value, err)
+		}
+	}
+	if s.every > 0 && s.at >= 0 {
+		return nil, fmt.Errorf("invalid cleanup schedule, either at or every can be given")
+	}
+	if s.at < 0 {
+		s.at = defaultCleanupTime
+	}
+	if !s.opts.Images && !s.opts.Containers && !s.opts.BuildCache {
+		return nil, fmt.Errorf("invalid cleanup, nothing to prune: set images, containers or build_cache")
+	}
+	return s, nil
+}
+
+//parseTimeOfDay parses a time of the day as HH:MM
+func parseTimeOfDay(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("expected a time of the day as HH:MM")
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+//next returns when the cleanup after the given time runs
+func (s *cleanupSchedule) next(now time.Time) time.Time {
+	if s.every > 0 {
+		return now.Add(s.every)
+	}
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	next := midnight.Add(s.at)
+	if !next.After(now) {
+		next = midnight.AddDate(0, 0, 1).Add(s.at)
+	}
+	return next
+}
+
+//String describes what the cleanup prunes and when
+func (s *cleanupSchedule) String() string {
+	var what []string
+	if s.opts.Containers {
+		what = append(what, "stopped containers")
+	}
+	if s.opts.UnusedImages {
+		what = append(what, "unused images")
+	} else if s.opts.Images {
+		what = append(what, "dangling images")
+	}
+	if s.opts.BuildCache {
+		what = append(what, "build cache")
+	}
+	description := strings.Join(what, ", ")
+	if s.opts.OlderThan > 0 {
+		description += " older than " + s.opts.OlderThan.String()
+	}
+	if s.every > 0 {
+		return description + " every " + s.every.String()
+	}
+	return fmt.Sprintf("%s daily at %02d:%02d", description, int(s.at.Hours()), int(s.at.Minutes())%60)
+}
+
+//cleanupResult describes the result of a cleanup
+func cleanupResult(r docker.CleanupReport) string {
+	return fmt.Sprintf("%d containers, %d images and %d build cache records removed, %s reclaimed",
+		r.Containers, r.Images, r.BuildCache, units.HumanSize(float64(r.SpaceReclaimed)))
+}
+
+//runCleanups runs the given cleanup on schedule, results are reported as
+//messages. Nothing runs unless the cleanup is enabled and dry can change
+//the Docker host. The cleanup only runs on the Docker host dry is connected
+//to when it starts, it is skipped while dry is connected to another one.
+func runCleanups(dry *Dry, s *cleanupSchedule) {
+	if !s.enabled {
+		dry.message("<white>Scheduled cleanup is not enabled, set enabled: true on the cleanup section to run it</>")
+		return
+	}
+	if docker.IsReadOnly(dry.dockerDaemon) {
+		dry.message("<white>Scheduled cleanup disabled on read-only mode</>")
+		return
+	}
+	host := dry.dockerDaemon.DockerEnv().DockerHost
+	for {
+		now := docker.Now()
+		time.Sleep(s.next(now).Sub(now))
+		daemon := dry.dockerDaemon
+		if current := daemon.DockerEnv().DockerHost; current != host {
+			dry.message(fmt.Sprintf("<white>Scheduled cleanup of %s skipped, dry is connected to %s</>", host, current))
+			continue
+		}
+		report, err := daemon.Cleanup(s.opts)
+		if err != nil {
+			dry.message(fmt.Sprintf("<red>Scheduled cleanup of %s failed:</> %s", host, err.Error()))
+			continue
+		}
+		dry.message(fmt.Sprintf("<white>Scheduled cleanup of %s:</> %s", host, cleanupResult(report)))
+		refreshScreen()
+	}
+}
@@ -0,0 +1,59 @@
+package app
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCleanup(t *testing.T) {
+	if s, err := parseCleanup(nil); s != nil || err != nil {
+		t.Errorf("Unexpected cleanup without settings: %v, %v", s, err)
+	}
+	s, err := parseCleanup(map[string]string{"enabled": "true", "images": "dangling", "older_than": "72h"})
+	if err != nil {
+		t.Fatalf("Unexpected error parsing a cleanup: %s", err)
+	}
+	if !s.enabled || !s.opts.Images || s.opts.UnusedImages || s.opts.OlderThan != 72*time.Hour {
+		t.Errorf("Unexpected cleanup: %+v", s)
+	}
+	if description := s.String(); description != "dangling images older than 72h0m0s daily at 03:00" {
+		t.Errorf("Unexpected cleanup description: %s", description)
+	}
+
+	s, err = parseCleanup(map[string]string{"images": "unused", "containers": "true", "every": "12h"})
+	if err != nil {
+		t.Fatalf("Unexpected error parsing a cleanup: %s", err)
+	}
+	if s.enabled || s.String() != "stopped containers, unused images every 12h0m0s" {
+		t.Errorf("Unexpected cleanup: %s", s)
+	}
+
+	for _, invalid := range []map[string]string{
+		{"enabled": "true"},
+		{"images": "all"},
+		{"images": "dangling", "at": "25:00"},
+		{"images": "dangling", "at": "03:00", "every": "1h"},
+		{"images": "dangling", "every": "1s"},
+		{"images": "dangling", "when": "nightly"},
+	} {
+		if _, err := parseCleanup(invalid); err == nil {
+			t.Errorf("Invalid cleanup accepted: %v", invalid)
+		}
+	}
+}
+
+func TestCleanupScheduleNext(t *testing.T) {
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	daily := &cleanupSchedule{at: 3 * time.Hour}
+	if next := daily.next(now); !next.Equal(time.Date(2020, 1, 2, 3, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected next daily cleanup: %s", next)
+	}
+	evening := &cleanupSchedule{at: 22*time.Hour + 30*time.Minute}
+	if next := evening.next(now); !next.Equal(time.Date(2020, 1, 1, 22, 30, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected next daily cleanup: %s", next)
+	}
+	every := &cleanupSchedule{every: 6 * time.Hour}
+	if next := every.next(now); !next.Equal(now.Add(6 * time.Hour)) {
+		t.Errorf("Unexpected next cleanup: %s", next)
+	}
+}
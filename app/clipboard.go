@@ -0,0 +1,145 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+)
+
+//clipboardValue is a value of a Docker object that can be copied to the
+//clipboard, retrieved once chosen
+type clipboardValue struct {
+	name  string
+	value func() (string, error)
+}
+
+//copyToClipboard asks which of the given values of the given object to copy
+//to the clipboard, the first one if none is typed, and copies it
+func copyToClipboard(dry *Dry, h eventHandler, f func(eventHandler), object string, values []clipboardValue) {
+	names := make([]string, len(values))
+	for i, v := range values {
+		names[i] = v.name
+	}
+	prompt := appui.NewPromptWithText(
+		fmt.Sprintf("Copy to clipboard, of %s (%s)", object, strings.Join(names, "|")),
+		names[0])
+	widgets.add(prompt)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+
+	go func() {
+		prompt.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(prompt)
+		text, canceled := prompt.Text()
+		f(h)
+		defer refreshScreen()
+		if canceled {
+			return
+		}
+		name := strings.ToLower(strings.TrimSpace(text))
+		if name == "" {
+			name = names[0]
+		}
+		for _, v := range values {
+			if v.name != name {
+				continue
+			}
+			value, err := v.value()
+			if err == nil {
+				err = ui.CopyToClipboard(value)
+			}
+			if err != nil {
+				dry.message("Could not copy to clipboard: " + err.Error())
+				return
+			}
+			dry.message(fmt.Sprintf("The %s of %s copied to clipboard", name, object))
+			return
+		}
+		dry.message(fmt.Sprintf("Nothing to copy as %s, expected one of %s", name, strings.Join(names, ", ")))
+	}()
+}
+
+//containerClipboardValues are the values of the given container that can
+//be copied to the clipboard
+func containerClipboardValues(daemon docker.ContainerDaemon, c *docker.Container) []clipboardValue {
+	return []clipboardValue{
+		{"id", func() (string, error) { return c.ID, nil }},
+		{"name", func() (string, error) { return containerName(c), nil }},
+		{"ip", func() (string, error) { return containerIPs(c) }},
+		{"json", func() (string, error) {
+			inspected, err := daemon.Inspect(c.ID)
+			if err != nil {
+				return "", err
+			}
+			return indentedJSON(inspected)
+		}},
+	}
+}
+
+//imageClipboardValues are the values of the given image that can be copied
+//to the clipboard
+func imageClipboardValues(daemon docker.ContainerDaemon, image types.ImageSummary) []clipboardValue {
+	return []clipboardValue{
+		{"id", func() (string, error) { return image.ID, nil }},
+		{"name", func() (string, error) {
+			if name := imageNoteName(image); name != "" {
+				return name, nil
+			}
+			return "", errors.New("the image has no name")
+		}},
+		{"json", func() (string, error) {
+			inspected, err := daemon.InspectImage(image.ID)
+			if err != nil {
+				return "", err
+			}
+			return indentedJSON(inspected)
+		}},
+	}
+}
+
+//imageName returns the name of the given image, or its short id if it has no name
+func imageName(image types.ImageSummary) string {
+	if name := imageNoteName(image); name != "" {
+		return name
+	}
+	return docker.TruncateID(image.ID)
+}
+
+//containerIPs returns the IP addresses of the given container on the
+//networks it is connected to, space separated and sorted by network
+func containerIPs(c *docker.Container) (string, error) {
+	if c.Container.NetworkSettings == nil {
+		return "", errors.New("the container has no IP address")
+	}
+	var networks []string
+	for name, n := range c.Container.NetworkSettings.Networks {
+		if n != nil && n.IPAddress != "" {
+			networks = append(networks, name)
+		}
+	}
+	if len(networks) == 0 {
+		return "", errors.New("the container has no IP address")
+	}
+	sort.Strings(networks)
+	ips := make([]string, len(networks))
+	for i, name := range networks {
+		ips[i] = c.Container.NetworkSettings.Networks[name].IPAddress
+	}
+	return strings.Join(ips, " "), nil
+}
+
+func indentedJSON(v interface{}) (string, error) {
+	j, err := json.MarshalIndent(v, "", "    ")
+	if err != nil {
+		return "", err
+	}
+	return string(j), nil
+}
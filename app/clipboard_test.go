@@ -0,0 +1,33 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/network"
+	"github.com/moncho/dry/docker"
+)
+
+func Test_containerIPs(t *testing.T) {
+	c := &docker.Container{
+		Container: types.Container{
+			NetworkSettings: &types.SummaryNetworkSettings{
+				Networks: map[string]*network.EndpointSettings{
+					"frontend": {IPAddress: "172.18.0.2"},
+					"backend":  {IPAddress: "172.19.0.3"},
+					"none":     {},
+				},
+			},
+		},
+	}
+	ips, err := containerIPs(c)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if ips != "172.19.0.3 172.18.0.2" {
+		t.Errorf("Unexpected IP addresses, got %s", ips)
+	}
+	if _, err := containerIPs(&docker.Container{}); err == nil {
+		t.Error("Expected an error for a container without networks")
+	}
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+)
+
+//parseCloneOptions parses the options typed to clone a container, the
+//image to run goes after the options and before the command
+func parseCloneOptions(s string) (string, docker.RunOptions, error) {
+	opts, err := docker.ParseRunOptions(s)
+	if err != nil {
+		return "", opts, err
+	}
+	if len(opts.Command) == 0 {
+		return "", opts, errors.New("no image given")
+	}
+	image := opts.Command[0]
+	opts.Command = opts.Command[1:]
+	if len(opts.Command) == 0 {
+		opts.Command = nil
+	}
+	return image, opts, nil
+}
+
+//cloneContainer shows a form, filled with the settings of the given
+//container, to create a copy of it. Image, environment, ports and the rest
+//of settings on the form can be changed before creating the copy.
+func cloneContainer(dry *Dry, container *docker.Container, h eventHandler, f func(eventHandler)) error {
+	c, err := dry.dockerDaemon.Inspect(container.ID)
+	if err != nil {
+		return err
+	}
+	image, err := dry.dockerDaemon.InspectImage(c.Image)
+	if err != nil {
+		//without the image, the settings inherited from it are shown too
+		image.Config = nil
+	}
+	name := containerName(container)
+	prompt := appui.NewPromptWithText(
+		fmt.Sprintf("Clone %s: [--name NAME] [-p HOST:CONTAINER] [-e KEY=VALUE] [-d|-it] IMAGE [COMMAND]", name),
+		docker.CloneOptions(c, image.Config))
+	widgets.add(prompt)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+
+	go func() {
+		prompt.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(prompt)
+		text, canceled := prompt.Text()
+		f(h)
+		defer refreshScreen()
+		if canceled {
+			return
+		}
+		image, opts, err := parseCloneOptions(text)
+		if err != nil {
+			dry.message(fmt.Sprintf("<red>Error cloning container %s:</> %s", name, err.Error()))
+			return
+		}
+		dry.message(fmt.Sprintf("Cloning container <white>%s</>", name))
+		id, err := dry.dockerDaemon.CloneContainer(container.ID, image, opts)
+		if err != nil {
+			dry.message(fmt.Sprintf("<red>Error cloning container %s:</> %s", name, err.Error()))
+			return
+		}
+		clone := opts.Name
+		if clone == "" {
+			clone = docker.TruncateID(id)
+		}
+		dry.message(fmt.Sprintf("Container <white>%s</> cloned as <white>%s</>", name, clone))
+	}()
+	return nil
+}
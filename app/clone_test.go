@@ -0,0 +1,23 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCloneOptions(t *testing.T) {
+	image, opts, err := parseCloneOptions("--name web-2 -p 8081:80 nginx:1.26")
+	if err != nil {
+		t.Fatalf("Unexpected error parsing clone options: %s", err)
+	}
+	if image != "nginx:1.26" || opts.Name != "web-2" || !reflect.DeepEqual(opts.Ports, []string{"8081:80"}) || opts.Command != nil {
+		t.Errorf("Unexpected clone options: %s, %+v", image, opts)
+	}
+	image, opts, err = parseCloneOptions("redis:7 redis-server --appendonly yes")
+	if err != nil || image != "redis:7" || !reflect.DeepEqual(opts.Command, []string{"redis-server", "--appendonly", "yes"}) {
+		t.Errorf("Unexpected clone options: %s, %+v, %v", image, opts, err)
+	}
+	if _, _, err := parseCloneOptions("--name web-2"); err == nil {
+		t.Error("Expected an error cloning without an image")
+	}
+}
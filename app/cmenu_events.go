@@ -52,6 +52,19 @@ func (h *cMenuEventHandler) handle(event *tcell.EventKey, f func(eventHandler))
 	}
 }
 
+//showContainerMenu shows the command menu of the container with the given
+//id, closing the menu goes back to the active view
+func showContainerMenu(dry *Dry, id string, f func(eventHandler)) error {
+	widgets.ContainerMenu.ForContainer(id)
+	widgets.ContainerMenu.OnUnmount = func() error {
+		dry.goBack(f)
+		return nil
+	}
+	dry.pushView(ContainerMenu)
+	f(viewsToHandlers[ContainerMenu])
+	return refreshScreen()
+}
+
 func (h *cMenuEventHandler) handleCommand(id string, command docker.Command, f func(eventHandler)) {
 
 	dry := h.dry
@@ -60,8 +73,9 @@ func (h *cMenuEventHandler) handleCommand(id string, command docker.Command, f f
 	container := dry.dockerDaemon.ContainerByID(id)
 	switch command {
 	case docker.KILL:
+		protected := docker.IsContainerProtected(container)
 		prompt := appui.NewPrompt(
-			fmt.Sprintf("Do you want to kill container %s? (y/N)", id))
+			confirmationPrompt(fmt.Sprintf("Do you want to kill container %s?", id), protected))
 		widgets.add(prompt)
 		forwarder := newEventForwarder()
 		f(forwarder)
@@ -78,7 +92,7 @@ func (h *cMenuEventHandler) handleCommand(id string, command docker.Command, f f
 			conf, cancel := prompt.Text()
 			f(h)
 			widgets.remove(prompt)
-			if cancel || (conf != "y" && conf != "Y") {
+			if cancel || !isConfirmed(conf, protected) {
 				return
 			}
 
@@ -94,7 +108,7 @@ func (h *cMenuEventHandler) handleCommand(id string, command docker.Command, f f
 	case docker.RESTART:
 
 		prompt := appui.NewPrompt(
-			fmt.Sprintf("Do you want to restart container %s? (y/N)", id))
+			confirmationPrompt(fmt.Sprintf("Do you want to restart container %s?", id), false))
 		widgets.add(prompt)
 		forwarder := newEventForwarder()
 		f(forwarder)
@@ -111,7 +125,7 @@ func (h *cMenuEventHandler) handleCommand(id string, command docker.Command, f f
 			conf, cancel := prompt.Text()
 			f(h)
 			widgets.remove(prompt)
-			if cancel || (conf != "y" && conf != "Y") {
+			if cancel || !isConfirmed(conf, false) {
 
 				return
 			}
@@ -128,7 +142,7 @@ func (h *cMenuEventHandler) handleCommand(id string, command docker.Command, f f
 	case docker.STOP:
 
 		prompt := appui.NewPrompt(
-			fmt.Sprintf("Do you want to stop container %s? (y/N)", id))
+			confirmationPrompt(fmt.Sprintf("Do you want to stop container %s?", id), false))
 		widgets.add(prompt)
 		forwarder := newEventForwarder()
 		f(forwarder)
@@ -145,7 +159,7 @@ func (h *cMenuEventHandler) handleCommand(id string, command docker.Command, f f
 			conf, cancel := prompt.Text()
 			f(h)
 			widgets.remove(prompt)
-			if cancel || (conf != "y" && conf != "Y") {
+			if cancel || !isConfirmed(conf, false) {
 
 				return
 			}
@@ -175,29 +189,37 @@ func (h *cMenuEventHandler) handleCommand(id string, command docker.Command, f f
 			}
 			prompt.OnFocus(events)
 			widgets.remove(prompt)
-			since, canceled := prompt.Text()
+			text, canceled := prompt.Text()
 
 			if canceled {
 				f(h)
 				return
 			}
+			opts, err := parseLogsOptions(text, false)
+			if err != nil {
+				f(h)
+				h.dry.message("Error showing container logs: " + err.Error())
+				return
+			}
 
-			logs, err := h.dry.dockerDaemon.Logs(id, since, false)
-			if err == nil {
-				appui.Stream(logs, forwarder.events(),
-					func() {
-						h.dry.changeView(ContainerMenu)
-						f(h)
-						refreshScreen()
-					})
-			} else {
+			h.dry.pushView(NoView)
+			err = appui.StreamLogs(logsSource(h.dry.dockerDaemon.Logs, id, opts),
+				opts.Timestamps, opts.Follow, forwarder.events(),
+				func() {
+					h.dry.popView()
+					f(h)
+					refreshScreen()
+				})
+			if err != nil {
+				h.dry.popView()
 				f(h)
 				h.dry.message("Error showing container logs: " + err.Error())
 			}
 		}()
 	case docker.RM:
+		protected := docker.IsContainerProtected(container)
 		prompt := appui.NewPrompt(
-			fmt.Sprintf("Do you want to remove container %s? (y/N)", id))
+			confirmationPrompt(fmt.Sprintf("Do you want to remove container %s?", id), protected))
 		widgets.add(prompt)
 		forwarder := newEventForwarder()
 		f(forwarder)
@@ -214,51 +236,185 @@ func (h *cMenuEventHandler) handleCommand(id string, command docker.Command, f f
 			conf, cancel := prompt.Text()
 			f(h)
 			widgets.remove(prompt)
-			if cancel || (conf != "y" && conf != "Y") {
+			if cancel || !isConfirmed(conf, protected) {
 
 				return
 			}
 
-			dry.actionMessage(id, "Removing")
-			err := dry.dockerDaemon.Rm(id)
-			if err == nil {
-				dry.actionMessage(id, "removed")
-				widgets.ContainerMenu.Unmount()
-			} else {
-				dry.errorMessage(id, "removing", err)
-			}
+			daemon := dry.dockerDaemon
+			dry.removals.schedule("container "+docker.TruncateID(id), func() {
+				dry.actionMessage(id, "Removing")
+				err := daemon.Rm(id)
+				if err == nil {
+					dry.actionMessage(id, "removed")
+					widgets.ContainerMenu.Unmount()
+				} else {
+					dry.errorMessage(id, "removing", err)
+				}
+				refreshScreen()
+			})
 			refreshScreen()
 		}()
 
-	case docker.STATS:
+	case docker.RESTART_POLICY:
+		current := "no"
+		if policy, ok := docker.ContainerRestartPolicy(container); ok {
+			current = docker.FormatRestartPolicy(policy)
+		}
+		prompt := appui.NewPromptWithText(
+			"Restart policy (no, always, unless-stopped or on-failure[:<max retries>]):",
+			current)
+		widgets.add(prompt)
 		forwarder := newEventForwarder()
 		f(forwarder)
-		h.dry.changeView(NoView)
+		refreshScreen()
+
+		go func() {
+			prompt.OnFocus(newEventSource(forwarder.events()))
+			widgets.remove(prompt)
+			text, canceled := prompt.Text()
+			f(h)
+			defer refreshScreen()
+			if canceled {
+				return
+			}
+			policy, err := docker.ParseRestartPolicy(text)
+			if err != nil {
+				dry.message(err.Error())
+				return
+			}
+			if err := dry.dockerDaemon.UpdateRestartPolicy(id, policy); err != nil {
+				dry.message(err.Error())
+				return
+			}
+			dry.message(fmt.Sprintf("Container %s restart policy is now %s", docker.TruncateID(id), docker.FormatRestartPolicy(policy)))
+			widgets.ContainerMenu.ForContainer(id)
+		}()
+
+	case docker.STATS:
 		if statsChan, err := dry.dockerDaemon.StatsChannel(container); err != nil {
 			dry.message(
 				fmt.Sprintf("Error showing container stats: %s", err.Error()))
 		} else {
-			go statsScreen(container, statsChan, screen, forwarder.events(),
+			forwarder := newEventForwarder()
+			f(forwarder)
+			h.dry.pushView(NoView)
+			go statsScreen(container, dry.containerNote(container), statsChan, screen, forwarder.events(),
 				func() {
-					h.dry.changeView(ContainerMenu)
+					h.dry.popView()
 					f(h)
 					refreshScreen()
 				})
 		}
 
+	case docker.NOTE:
+		if container == nil {
+			dry.message(fmt.Sprintf("Container with id %s not found", id))
+			return
+		}
+		editNote(dry, containerNotes, containerName(container), id, h, f, func() {
+			widgets.ContainerMenu.ForContainer(id)
+		})
+
+	case docker.HEALTHCHECK:
+		if container == nil {
+			dry.message(fmt.Sprintf("Container with id %s not found", id))
+			return
+		}
+		runHealthcheck(dry, screen, container, h, f)
+	case docker.HEALTH_LOG:
+		if container == nil {
+			dry.message(fmt.Sprintf("Container with id %s not found", id))
+			return
+		}
+		if err := showHealthLog(dry, screen, container, h, f); err != nil {
+			dry.message(
+				fmt.Sprintf("Error showing the health log: %s", err.Error()))
+		}
+	case docker.EVENTS:
+		if container == nil {
+			dry.message(fmt.Sprintf("Container with id %s not found", id))
+			return
+		}
+		if err := showContainerHistory(dry, screen, container, h, f); err != nil {
+			dry.message(
+				fmt.Sprintf("Error showing the event history: %s", err.Error()))
+		}
+	case docker.CONFIGURATION:
+		if container == nil {
+			dry.message(fmt.Sprintf("Container with id %s not found", id))
+			return
+		}
+		if err := showConfiguration(dry, screen, container, h, f); err != nil {
+			dry.message(
+				fmt.Sprintf("Error showing the configuration: %s", err.Error()))
+		}
+	case docker.PORTS:
+		if container == nil {
+			dry.message(fmt.Sprintf("Container with id %s not found", id))
+			return
+		}
+		if err := showPorts(dry, screen, container, h, f); err != nil {
+			dry.message(
+				fmt.Sprintf("Error showing the ports: %s", err.Error()))
+		}
+	case docker.RECREATE:
+		if container == nil {
+			dry.message(fmt.Sprintf("Container with id %s not found", id))
+			return
+		}
+		recreateContainer(dry, container, h, f, func(id string) {
+			widgets.ContainerMenu.ForContainer(id)
+		})
+	case docker.RESOURCES:
+		if container == nil {
+			dry.message(fmt.Sprintf("Container with id %s not found", id))
+			return
+		}
+		if err := updateResources(dry, container, h, f, func() {
+			widgets.ContainerMenu.ForContainer(id)
+		}); err != nil {
+			dry.message(
+				fmt.Sprintf("Error updating container resources: %s", err.Error()))
+		}
+	case docker.CLONE:
+		if container == nil {
+			dry.message(fmt.Sprintf("Container with id %s not found", id))
+			return
+		}
+		if err := cloneContainer(dry, container, h, f); err != nil {
+			dry.message(
+				fmt.Sprintf("Error cloning container: %s", err.Error()))
+		}
+	case docker.RUN_COMMAND:
+		if container == nil {
+			dry.message(fmt.Sprintf("Container with id %s not found", id))
+			return
+		}
+		if err := showRunCommand(dry, screen, container, h, f); err != nil {
+			dry.message(
+				fmt.Sprintf("Error building the docker run command: %s", err.Error()))
+		}
+
+	case docker.FILES:
+		widgets.ContainerFiles.ForContainer(id)
+		dry.pushView(ContainerFiles)
+		f(viewsToHandlers[ContainerFiles])
+		refreshScreen()
+
 	case docker.INSPECT:
 		forwarder := newEventForwarder()
 		f(forwarder)
 		refreshScreen()
 
 		err := inspect(
+			h.dry,
 			h.screen,
 			forwarder.events(),
 			func(id string) (interface{}, error) {
 				return h.dry.dockerDaemon.Inspect(id)
 			},
 			func() {
-				h.dry.changeView(ContainerMenu)
 				f(h)
 				refreshScreen()
 			})(id)
@@ -275,9 +431,10 @@ func (h *cMenuEventHandler) handleCommand(id string, command docker.Command, f f
 			renderer := appui.NewDockerImageHistoryRenderer(history)
 			forwarder := newEventForwarder()
 			f(forwarder)
+			h.dry.pushView(NoView)
 			refreshScreen()
 			go appui.Less(renderer.String(), screen, forwarder.events(), func() {
-				h.dry.changeView(ContainerMenu)
+				h.dry.popView()
 				f(h)
 				refreshScreen()
 			})
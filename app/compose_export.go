@@ -0,0 +1,129 @@
+package app
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+)
+
+//composeExport describes how containers are exported as a compose file
+type composeExport struct {
+	path string
+	//shown exports the containers shown instead of the selected one
+	shown bool
+}
+
+func composeExportPrompt() *appui.Prompt {
+	return appui.NewPromptWithText(
+		"Export as compose file: file=<path> containers=<selected|shown>",
+		"file=docker-compose.yml containers=selected")
+}
+
+//parseComposeExport parses the export options typed on a compose export prompt,
+//a value given without key is taken as the file path
+func parseComposeExport(s string) (composeExport, error) {
+	var export composeExport
+	for _, field := range strings.Fields(s) {
+		key, value := "file", field
+		if i := strings.Index(field, "="); i >= 0 {
+			key, value = field[:i], field[i+1:]
+		}
+		switch key {
+		case "file":
+			export.path = value
+		case "containers":
+			switch value {
+			case "selected":
+				export.shown = false
+			case "shown":
+				export.shown = true
+			default:
+				return export, fmt.Errorf("invalid containers value: %q", value)
+			}
+		default:
+			return export, fmt.Errorf("unknown export option: %q", key)
+		}
+	}
+	if export.path == "" {
+		return export, fmt.Errorf("no file given")
+	}
+	return export, nil
+}
+
+//composeFile returns the compose file defining the given containers
+func composeFile(daemon docker.ContainerDaemon, containers []*docker.Container) ([]byte, error) {
+	var inspected []types.ContainerJSON
+	images := make(map[string]*container.Config)
+	for _, c := range containers {
+		json, err := daemon.Inspect(c.ID)
+		if err != nil {
+			return nil, err
+		}
+		inspected = append(inspected, json)
+		if _, ok := images[json.Image]; !ok {
+			//without the image, the settings inherited from it are exported too
+			if image, err := daemon.InspectImage(json.Image); err == nil {
+				images[json.Image] = image.Config
+			}
+		}
+	}
+	return docker.ComposeFile(inspected, images), nil
+}
+
+//exportCompose shows a prompt to export the selected container, or the ones
+//shown, as a compose file
+func (h *containersScreenEventHandler) exportCompose(f func(eventHandler)) {
+	dry := h.dry
+	prompt := composeExportPrompt()
+	widgets.add(prompt)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+	go func() {
+		prompt.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(prompt)
+		text, canceled := prompt.Text()
+		f(h)
+		defer refreshScreen()
+		if canceled {
+			return
+		}
+		export, err := parseComposeExport(text)
+		if err != nil {
+			dry.message("Error exporting containers: " + err.Error())
+			return
+		}
+		var containers []*docker.Container
+		if export.shown {
+			containers = widgets.ContainerList.Shown()
+		} else if err := h.widget.OnEvent(func(id string) error {
+			c := dry.dockerDaemon.ContainerByID(id)
+			if c == nil {
+				return fmt.Errorf("Container with id %s not found", id)
+			}
+			containers = append(containers, c)
+			return nil
+		}); err != nil {
+			dry.message("Error exporting containers: " + err.Error())
+			return
+		}
+		if len(containers) == 0 {
+			dry.message("There are no containers to export")
+			return
+		}
+		compose, err := composeFile(dry.dockerDaemon, containers)
+		if err == nil {
+			err = ioutil.WriteFile(export.path, compose, 0644)
+		}
+		if err != nil {
+			dry.message("Error exporting containers: " + err.Error())
+			return
+		}
+		dry.message(fmt.Sprintf("%d containers exported to %s", len(containers), export.path))
+	}()
+}
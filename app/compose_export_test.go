@@ -0,0 +1,27 @@
+package app
+
+import "testing"
+
+func TestParseComposeExport(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    composeExport
+		wantErr bool
+	}{
+		{"file=docker-compose.yml containers=selected", composeExport{path: "docker-compose.yml"}, false},
+		{"shop.yml containers=shown", composeExport{path: "shop.yml", shown: true}, false},
+		{"containers=shown", composeExport{}, true},
+		{"file=a.yml containers=all", composeExport{}, true},
+		{"file=a.yml services=web", composeExport{}, true},
+	}
+	for _, tt := range tests {
+		got, err := parseComposeExport(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseComposeExport(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("parseComposeExport(%q) = %+v, want %+v", tt.input, got, tt.want)
+		}
+	}
+}
@@ -1,16 +1,83 @@
 package app
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/moncho/dry/appui"
 	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
 )
 
 //Config dry initial configuration
 type Config struct {
-	DockerHost         string
+	//Context is the Docker CLI context to connect to, it takes precedence
+	//over DockerHost
+	Context    string
+	DockerHost string
+	//Hosts are Docker hosts, by name, dry can switch to besides Docker contexts
+	Hosts              map[string]string
 	DockerCertPath     string
 	DockerTLSVerify    bool
 	MonitorMode        bool
 	MonitorRefreshRate int
+	TmuxStatus         bool
+	LabelColumns       []string
+	//Monitor alert thresholds, as usage percentages, zero disables them
+	CPUAlertThreshold    float64
+	MemoryAlertThreshold float64
+	//AlertBell rings the terminal bell on monitor alerts
+	AlertBell bool
+	//ScalePresets are named replica counts by service, as <service>=<preset>:<replicas>,...
+	ScalePresets []string
+	//EventsFile is the file Docker events are appended to, as JSON lines, none if empty
+	EventsFile string
+	//EventsFileSize is the size, in bytes, the events file is rotated at
+	EventsFileSize int64
+	//StatsFile is the file the stats of running containers are appended to,
+	//as JSON lines, none if empty
+	StatsFile string
+	//StatsFileSize is the size, in bytes, the stats file is rotated at
+	StatsFileSize int64
+	//StatsInterval is the time between stats samples
+	StatsInterval time.Duration
+	//Hooks are actions run on Docker events, as <filter expression> => <action>
+	Hooks []string
+	//Notifications are the critical events notified, as <event>[=<method>,...]
+	Notifications []string
+	//DefaultView is the view shown on startup, the container list if empty
+	DefaultView string
+	//SortModes are the initial sort modes, as column names by list
+	SortModes map[string]string
+	//DiscardUIState disables saving the UI state on exit and restoring it on start
+	DiscardUIState bool
+	//ReadOnly disables every action changing the state of the Docker host
+	ReadOnly bool
+	//OwnerLabel is the label giving the owner of containers, images and
+	//volumes on the usage by owner report
+	OwnerLabel string
+	//UndoWindow is the time container and image removals wait before being
+	//done, so they can be undone, zero removes them right away
+	UndoWindow time.Duration
+	//RefreshUnfocused keeps refreshing as usual while the terminal is not
+	//focused, otherwise refreshes are reduced until it is focused again
+	RefreshUnfocused bool
+	//Confirmation is how operations are confirmed, default or strict
+	Confirmation string
+	//Theme is the name of the color theme, Colors overrides some of its colors
+	Theme  string
+	Colors map[string]string
+	//Themes are user-defined color themes, by name, as colors changed from
+	//the base theme, given as base
+	Themes map[string]map[string]string
+	//KeyBindings are the keys bound to actions, by action name
+	KeyBindings map[string]string
+	//UpdateCheckInterval is the time between checks of the registry for
+	//newer images of the local tags, zero only checks when asked to
+	UpdateCheckInterval time.Duration
+	//Cleanup are the settings of the scheduled cleanup of the Docker host, see parseCleanup
+	Cleanup map[string]string
 }
 
 func (c Config) dockerEnv() docker.Env {
@@ -20,3 +87,94 @@ func (c Config) dockerEnv() docker.Env {
 	env.DockerCertPath = c.DockerCertPath
 	return env
 }
+
+//ColorTheme returns the color theme of this config, dry theme if none is set
+func (c Config) ColorTheme() (*ui.ColorTheme, error) {
+	theme, err := c.namedColorTheme(c.Theme)
+	if err != nil {
+		return nil, err
+	}
+	return customColorTheme(theme, c.Colors)
+}
+
+//namedColorTheme returns the color theme with the given name, either one
+//defined by the user or one of dry themes
+func (c Config) namedColorTheme(name string) (*ui.ColorTheme, error) {
+	if name == "" {
+		return appui.DryTheme, nil
+	}
+	if colors, ok := c.Themes[name]; ok {
+		base := "dark"
+		custom := make(map[string]string)
+		for k, v := range colors {
+			if k == "base" {
+				base = v
+			} else {
+				custom[k] = v
+			}
+		}
+		theme, ok := appui.ColorThemeByName(base)
+		if !ok {
+			return nil, fmt.Errorf("invalid base theme %q of theme %s, expected %s", base, name, builtinThemes)
+		}
+		theme, err := customColorTheme(theme, custom)
+		if err != nil {
+			return nil, fmt.Errorf("invalid theme %s: %s", name, err)
+		}
+		return theme, nil
+	}
+	theme, ok := appui.ColorThemeByName(name)
+	if !ok {
+		return nil, fmt.Errorf("invalid theme %q, expected %s or a theme defined on themes", name, builtinThemes)
+	}
+	return theme, nil
+}
+
+const builtinThemes = "16, black, dark, light, solarized or monochrome"
+
+//customColorTheme returns a copy of the given theme with the given colors
+//changed, colors are given by name, markup tag colors as markup.<tag>
+func customColorTheme(theme *ui.ColorTheme, colors map[string]string) (*ui.ColorTheme, error) {
+	if len(colors) == 0 {
+		return theme, nil
+	}
+	custom := *theme
+	custom.Markup = make(map[string]ui.Color)
+	for tag, color := range theme.Markup {
+		custom.Markup[tag] = color
+	}
+	fields := map[string]*ui.Color{
+		"fg":          &custom.Fg,
+		"bg":          &custom.Bg,
+		"prompt":      &custom.Prompt,
+		"key":         &custom.Key,
+		"current":     &custom.Current,
+		"info":        &custom.Info,
+		"cursor":      &custom.Cursor,
+		"selected":    &custom.Selected,
+		"header":      &custom.Header,
+		"footer":      &custom.Footer,
+		"list_item":   &custom.ListItem,
+		"cursor_line": &custom.CursorLineBg,
+	}
+	for name, value := range colors {
+		color, err := ui.ParseColor(value)
+		if err != nil {
+			return nil, err
+		}
+		if strings.HasPrefix(name, "markup.") {
+			tag := strings.TrimPrefix(name, "markup.")
+			if tag == "b" || tag == "u" || tag == "r" || !ui.SupportedTags.MatchString("<"+tag+">") {
+				return nil, fmt.Errorf("unknown markup color tag %s", tag)
+			}
+			custom.Markup[tag] = color
+			continue
+		}
+		field, ok := fields[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown theme color %s", name)
+		}
+		*field = color
+	}
+	return &custom, nil
+}
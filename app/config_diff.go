@@ -0,0 +1,310 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	dockerswarm "github.com/docker/docker/api/types/swarm"
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/ui"
+	pkgError "github.com/pkg/errors"
+)
+
+const (
+	//diffContext is the number of unchanged lines shown around changes
+	diffContext = 3
+	//maxDiffCells bounds the lines compared line by line, once the common
+	//head and tail are left out, files differing more are shown as replaced
+	maxDiffCells = 4 * 1000 * 1000
+)
+
+type diffOp int
+
+const (
+	diffEqual diffOp = iota
+	diffDelete
+	diffInsert
+)
+
+//diffLine is a line kept, deleted from the first text or inserted from the second one
+type diffLine struct {
+	op   diffOp
+	text string
+}
+
+//lines splits the given text in lines, a trailing newline does not start a new line
+func lines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+//lineDiff returns the changes that turn the lines of a into the lines of b
+func lineDiff(a, b []string) []diffLine {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix &&
+		a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	var diff []diffLine
+	for _, l := range a[:prefix] {
+		diff = append(diff, diffLine{diffEqual, l})
+	}
+	diff = append(diff, lcsDiff(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, l := range a[len(a)-suffix:] {
+		diff = append(diff, diffLine{diffEqual, l})
+	}
+	return diff
+}
+
+//lcsDiff diffs the given lines keeping their longest common subsequence
+func lcsDiff(a, b []string) []diffLine {
+	var diff []diffLine
+	if len(a)*len(b) > maxDiffCells {
+		for _, l := range a {
+			diff = append(diff, diffLine{diffDelete, l})
+		}
+		for _, l := range b {
+			diff = append(diff, diffLine{diffInsert, l})
+		}
+		return diff
+	}
+	//lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int32, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			diff = append(diff, diffLine{diffEqual, a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			diff = append(diff, diffLine{diffDelete, a[i]})
+			i++
+		default:
+			diff = append(diff, diffLine{diffInsert, b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		diff = append(diff, diffLine{diffDelete, a[i]})
+	}
+	for ; j < len(b); j++ {
+		diff = append(diff, diffLine{diffInsert, b[j]})
+	}
+	return diff
+}
+
+//diffHunk is a group of changes of a diff with the unchanged lines around them
+type diffHunk struct {
+	//first and last are the positions on the diff of the first and last line of the hunk
+	first, last int
+	//aLine and bLine are the line numbers, on each text, of the first line of the hunk
+	aLine, bLine int
+	//aCount and bCount are the number of lines of the hunk on each text
+	aCount, bCount int
+}
+
+//diffHunks groups the changes of the given diff in hunks, with the lines
+//around them, changes less than two contexts apart go on the same hunk
+func diffHunks(diff []diffLine) []diffHunk {
+	//aLine and bLine are the line numbers, on each text, of every diff line
+	aLine, bLine := make([]int, len(diff)), make([]int, len(diff))
+	a, b := 1, 1
+	for i, l := range diff {
+		aLine[i], bLine[i] = a, b
+		if l.op != diffInsert {
+			a++
+		}
+		if l.op != diffDelete {
+			b++
+		}
+	}
+	var hunks []diffHunk
+	for start := 0; start < len(diff); {
+		if diff[start].op == diffEqual {
+			start++
+			continue
+		}
+		//a hunk goes from the context before a change to the context after
+		//the last change less than two contexts apart
+		end := start
+		for i := start; i < len(diff) && i <= end+2*diffContext; i++ {
+			if diff[i].op != diffEqual {
+				end = i
+			}
+		}
+		hunk := diffHunk{first: start - diffContext, last: end + diffContext}
+		if hunk.first < 0 {
+			hunk.first = 0
+		}
+		if hunk.last >= len(diff) {
+			hunk.last = len(diff) - 1
+		}
+		hunk.aLine, hunk.bLine = aLine[hunk.first], bLine[hunk.first]
+		for _, l := range diff[hunk.first : hunk.last+1] {
+			if l.op != diffInsert {
+				hunk.aCount++
+			}
+			if l.op != diffDelete {
+				hunk.bCount++
+			}
+		}
+		hunks = append(hunks, hunk)
+		start = hunk.last + 1
+	}
+	return hunks
+}
+
+//unifiedDiff renders the given diff in unified format, with markup, only
+//changes and the lines around them are shown
+func unifiedDiff(from, to string, diff []diffLine) string {
+	buffer := new(bytes.Buffer)
+	buffer.WriteString(fmt.Sprintf("<red>--- %s</>\n<green>+++ %s</>\n", from, to))
+	for _, hunk := range diffHunks(diff) {
+		buffer.WriteString(fmt.Sprintf("<cyan>@@ -%d,%d +%d,%d @@</>\n", hunk.aLine, hunk.aCount, hunk.bLine, hunk.bCount))
+		for _, l := range diff[hunk.first : hunk.last+1] {
+			switch l.op {
+			case diffDelete:
+				buffer.WriteString(ui.Red("-" + l.text))
+			case diffInsert:
+				buffer.WriteString("<green>+" + l.text + "</>")
+			default:
+				buffer.WriteString(" " + l.text)
+			}
+			buffer.WriteString("\n")
+		}
+	}
+	return buffer.String()
+}
+
+//parseConfigDiff parses the config and the file typed on the config diff
+//prompt, the config can be left out if the service only has one
+func parseConfigDiff(s string, configs []*dockerswarm.ConfigReference) (*dockerswarm.ConfigReference, string, error) {
+	fields := strings.Fields(s)
+	switch {
+	case len(fields) == 1 && len(configs) == 1:
+		return configs[0], fields[0], nil
+	case len(fields) != 2:
+		return nil, "", fmt.Errorf("expected a config and a file, got %q", s)
+	}
+	for _, c := range configs {
+		if c.ConfigName == fields[0] {
+			return c, fields[1], nil
+		}
+	}
+	return nil, "", fmt.Errorf("the service does not use config %s", fields[0])
+}
+
+//diffConfig asks for a config of the given service and a local file, and
+//shows the changes between the content of the config and the file
+func (h *servicesScreenEventHandler) diffConfig(serviceID string, f func(eventHandler)) error {
+	dry := h.dry
+	service, err := dry.dockerDaemon.Service(serviceID)
+	if err != nil {
+		return err
+	}
+	var configs []*dockerswarm.ConfigReference
+	if spec := service.Spec.TaskTemplate.ContainerSpec; spec != nil {
+		configs = spec.Configs
+	}
+	if len(configs) == 0 {
+		return fmt.Errorf("service %s does not use any config", service.Spec.Name)
+	}
+	var names []string
+	for _, c := range configs {
+		names = append(names, c.ConfigName)
+	}
+	prompt := appui.NewPromptWithText(
+		fmt.Sprintf("Diff a config of %s (%s) with a local file, as <config> <file>:",
+			service.Spec.Name, strings.Join(names, ", ")),
+		names[0]+" ")
+	widgets.add(prompt)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+	go func() {
+		prompt.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(prompt)
+		text, canceled := prompt.Text()
+		f(h)
+		refreshScreen()
+		if canceled {
+			return
+		}
+		config, file, err := parseConfigDiff(text, configs)
+		if err != nil {
+			dry.message("Could not diff config: " + err.Error())
+			return
+		}
+		diff, err := configDiff(dry, config, file)
+		if err != nil {
+			dry.message("Could not diff config: " + err.Error())
+			return
+		}
+		if diff == "" {
+			dry.message(fmt.Sprintf("<green>Config %s matches %s</>", config.ConfigName, file))
+			return
+		}
+		forwarder := newEventForwarder()
+		f(forwarder)
+		dry.pushView(NoView)
+		appui.Less(diff, h.screen, forwarder.events(), func() {
+			dry.goBack(f)
+		})
+	}()
+	return nil
+}
+
+//configDiff returns the changes between the deployed content of the given
+//config and the given local file, empty if there are none
+func configDiff(dry *Dry, config *dockerswarm.ConfigReference, file string) (string, error) {
+	deployed, err := dry.dockerDaemon.ConfigContent(config.ConfigID)
+	if err != nil {
+		return "", err
+	}
+	path, err := homedir.Expand(file)
+	if err != nil {
+		return "", err
+	}
+	local, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", pkgError.Wrap(err, "error reading local file")
+	}
+	if bytes.Equal(deployed, local) {
+		return "", nil
+	}
+	changes := lineDiff(lines(string(deployed)), lines(string(local)))
+	diff := unifiedDiff(
+		fmt.Sprintf("config %s (deployed)", config.ConfigName),
+		fmt.Sprintf("%s (local)", file),
+		changes)
+	for _, l := range changes {
+		if l.op != diffEqual {
+			return diff, nil
+		}
+	}
+	return diff + "\nThe contents only differ on the newline at the end.\n", nil
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	dockerswarm "github.com/docker/docker/api/types/swarm"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	deployed := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\n"
+	local := "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\nm\n"
+
+	diff := unifiedDiff("deployed", "local", lineDiff(lines(deployed), lines(local)))
+	expected := `<red>--- deployed</>
+<green>+++ local</>
+<cyan>@@ -1,5 +1,5 @@</>
+ a
+<red>-b</>
+<green>+B</>
+ c
+ d
+ e
+<cyan>@@ -10,3 +10,4 @@</>
+ j
+ k
+ l
+<green>+m</>
+`
+	if diff != expected {
+		t.Errorf("Unexpected diff, got:\n%s\nexpected:\n%s", diff, expected)
+	}
+
+	if diff := unifiedDiff("deployed", "local", lineDiff(lines(deployed), lines(deployed))); strings.Contains(diff, "@@") {
+		t.Errorf("Changes found diffing equal texts: %s", diff)
+	}
+}
+
+func TestLineDiff(t *testing.T) {
+	diff := lineDiff(lines("x\ny\nz"), lines("y\nz\nw"))
+	var got []string
+	for _, l := range diff {
+		got = append(got, []string{" ", "-", "+"}[l.op]+l.text)
+	}
+	if strings.Join(got, ",") != "-x, y, z,+w" {
+		t.Errorf("Unexpected diff: %v", got)
+	}
+}
+
+func TestParseConfigDiff(t *testing.T) {
+	nginx := &dockerswarm.ConfigReference{ConfigName: "nginx.conf"}
+	app := &dockerswarm.ConfigReference{ConfigName: "app.yml"}
+
+	if c, file, err := parseConfigDiff("nginx.conf ./nginx.conf", []*dockerswarm.ConfigReference{app, nginx}); err != nil || c != nginx || file != "./nginx.conf" {
+		t.Errorf("Unexpected config diff: %v %s %v", c, file, err)
+	}
+	if c, file, err := parseConfigDiff("./nginx.conf", []*dockerswarm.ConfigReference{nginx}); err != nil || c != nginx || file != "./nginx.conf" {
+		t.Errorf("Unexpected config diff with the only config: %v %s %v", c, file, err)
+	}
+	if _, _, err := parseConfigDiff("./nginx.conf", []*dockerswarm.ConfigReference{app, nginx}); err == nil {
+		t.Error("No error diffing without config on a service with several")
+	}
+	if _, _, err := parseConfigDiff("db.conf ./db.conf", []*dockerswarm.ConfigReference{app, nginx}); err == nil {
+		t.Error("No error diffing a config not used by the service")
+	}
+}
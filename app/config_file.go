@@ -0,0 +1,302 @@
+package app
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/moncho/dry/docker"
+	"github.com/pkg/errors"
+)
+
+//DefaultConfigFile is the file dry reads its configuration from, if it exists
+const DefaultConfigFile = "~/.config/dry/config.yaml"
+
+//ReadConfigFile reads dry configuration from the given YAML file.
+//
+//Only a subset of YAML is supported: settings and nested sections of
+//settings, with scalar values. I.e.:
+//
+//	refresh_rate: 1000
+//	view: images
+//	confirm: strict
+//	theme: light
+//	restore_state: false
+//	read_only: true
+//	owner_label: team
+//	update_check: 6h
+//	sort:
+//	  containers: name
+//	colors:
+//	  header: 31
+//	themes:
+//	  ocean:
+//	    base: light
+//	    markup:
+//	      white: 17
+//	keys:
+//	  containers.remove: d
+//	docker:
+//	  host: tcp://127.0.0.1:2376
+//	  cert_path: ~/.docker
+//	  tls_verify: true
+//	hosts:
+//	  staging: ssh://deploy@staging
+//	cleanup:
+//	  enabled: true
+//	  images: dangling
+//	  older_than: 72h
+func ReadConfigFile(path string) (Config, error) {
+	path, err := homedir.Expand(path)
+	if err != nil {
+		return Config{}, errors.Wrap(err, "error reading config file")
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return Config{}, errors.Wrap(err, "error reading config file")
+	}
+	defer f.Close()
+	cfg, err := parseConfigFile(f)
+	if err != nil {
+		return Config{}, errors.Wrapf(err, "invalid config file %s", path)
+	}
+	return cfg, nil
+}
+
+//configSetting is a setting read from a config file
+type configSetting struct {
+	line int
+	//sections are the names of the sections the setting is in, outermost first
+	sections []string
+	key      string
+	value    string
+}
+
+func (s configSetting) name() string {
+	return strings.Join(append(s.sections, s.key), ".")
+}
+
+//section returns the name of the outermost section of this setting, if any
+func (s configSetting) section() string {
+	if len(s.sections) == 0 {
+		return ""
+	}
+	return s.sections[0]
+}
+
+//parseConfigFile parses the configuration read from the given reader
+func parseConfigFile(r io.Reader) (Config, error) {
+	var cfg Config
+	settings, err := readConfigSettings(r)
+	if err != nil {
+		return cfg, err
+	}
+	for _, s := range settings {
+		if err := cfg.set(s); err != nil {
+			return Config{}, fmt.Errorf("line %d: %s", s.line, err)
+		}
+	}
+	return cfg, nil
+}
+
+//set sets on this config the given setting
+func (c *Config) set(s configSetting) error {
+	if len(s.sections) > 1 && s.section() != "themes" && s.section() != "colors" {
+		return fmt.Errorf("unknown setting %s", s.name())
+	}
+	switch s.section() {
+	case "":
+		switch s.key {
+		case "refresh_rate":
+			rate, err := strconv.Atoi(s.value)
+			if err != nil || rate <= 0 {
+				return fmt.Errorf("invalid refresh rate %q, expected a number of milliseconds", s.value)
+			}
+			c.MonitorRefreshRate = rate
+		case "view":
+			c.DefaultView = s.value
+		case "confirm":
+			c.Confirmation = s.value
+		case "theme":
+			c.Theme = s.value
+		case "restore_state":
+			restore, err := strconv.ParseBool(s.value)
+			if err != nil {
+				return fmt.Errorf("invalid restore_state value %q, expected true or false", s.value)
+			}
+			c.DiscardUIState = !restore
+		case "owner_label":
+			c.OwnerLabel = s.value
+		case "update_check":
+			interval, err := time.ParseDuration(s.value)
+			if err != nil || interval < time.Minute {
+				return fmt.Errorf("invalid update_check interval %q, expected a duration of at least a minute, i.e. 6h", s.value)
+			}
+			c.UpdateCheckInterval = interval
+		case "read_only":
+			readOnly, err := strconv.ParseBool(s.value)
+			if err != nil {
+				return fmt.Errorf("invalid read_only value %q, expected true or false", s.value)
+			}
+			c.ReadOnly = readOnly
+		default:
+			return fmt.Errorf("unknown setting %s", s.name())
+		}
+	case "sort":
+		if c.SortModes == nil {
+			c.SortModes = make(map[string]string)
+		}
+		c.SortModes[s.key] = s.value
+	case "colors":
+		if c.Colors == nil {
+			c.Colors = make(map[string]string)
+		}
+		c.Colors[strings.Join(append(s.sections[1:], s.key), ".")] = s.value
+	case "themes":
+		if len(s.sections) < 2 {
+			return fmt.Errorf("theme setting %s is not on a theme", s.name())
+		}
+		if c.Themes == nil {
+			c.Themes = make(map[string]map[string]string)
+		}
+		name := s.sections[1]
+		if c.Themes[name] == nil {
+			c.Themes[name] = make(map[string]string)
+		}
+		c.Themes[name][strings.Join(append(s.sections[2:], s.key), ".")] = s.value
+	case "keys":
+		if c.KeyBindings == nil {
+			c.KeyBindings = make(map[string]string)
+		}
+		c.KeyBindings[s.key] = s.value
+	case "cleanup":
+		if c.Cleanup == nil {
+			c.Cleanup = make(map[string]string)
+		}
+		c.Cleanup[s.key] = s.value
+	case "hosts":
+		if s.key == docker.DefaultContext {
+			return fmt.Errorf("invalid host name %q, it is the name of the Docker host given on startup", s.key)
+		}
+		if c.Hosts == nil {
+			c.Hosts = make(map[string]string)
+		}
+		c.Hosts[s.key] = s.value
+	case "docker":
+		switch s.key {
+		case "host":
+			c.DockerHost = s.value
+		case "cert_path":
+			c.DockerCertPath = s.value
+		case "tls_verify":
+			verify, err := strconv.ParseBool(s.value)
+			if err != nil {
+				return fmt.Errorf("invalid tls_verify value %q, expected true or false", s.value)
+			}
+			c.DockerTLSVerify = verify
+		case "context":
+			c.Context = s.value
+		default:
+			return fmt.Errorf("unknown setting %s", s.name())
+		}
+	default:
+		return fmt.Errorf("unknown section %s", s.section())
+	}
+	return nil
+}
+
+//readConfigSettings reads the settings on the given reader, settings in a
+//section are indented under the section name, sections can be nested
+func readConfigSettings(r io.Reader) ([]configSetting, error) {
+	type section struct {
+		indent int
+		name   string
+	}
+	var settings []configSetting
+	var sections []section
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := stripConfigComment(scanner.Text())
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		trimmed := strings.TrimLeft(text, " \t")
+		indent := text[:len(text)-len(trimmed)]
+		if strings.Contains(indent, "\t") {
+			return nil, fmt.Errorf("line %d: tabs are not allowed for indentation", line)
+		}
+		i := strings.Index(trimmed, ":")
+		if i <= 0 {
+			return nil, fmt.Errorf("line %d: expected <key>: <value>", line)
+		}
+		key := strings.TrimSpace(trimmed[:i])
+		value, err := unquoteConfigValue(strings.TrimSpace(trimmed[i+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", line, err)
+		}
+		for len(sections) > 0 && sections[len(sections)-1].indent >= len(indent) {
+			sections = sections[:len(sections)-1]
+		}
+		if len(indent) > 0 && len(sections) == 0 {
+			return nil, fmt.Errorf("line %d: unexpected indentation", line)
+		}
+		if value == "" {
+			sections = append(sections, section{len(indent), key})
+			continue
+		}
+		setting := configSetting{
+			line:  line,
+			key:   key,
+			value: value,
+		}
+		for _, s := range sections {
+			setting.sections = append(setting.sections, s.name)
+		}
+		settings = append(settings, setting)
+	}
+	return settings, scanner.Err()
+}
+
+//stripConfigComment removes the comment, if any, from the given line
+func stripConfigComment(line string) string {
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+//unquoteConfigValue returns the given value without quotes, if quoted
+func unquoteConfigValue(value string) (string, error) {
+	if len(value) < 2 {
+		return value, nil
+	}
+	switch value[0] {
+	case '"':
+		s, err := strconv.Unquote(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid quoted value %s", value)
+		}
+		return s, nil
+	case '\'':
+		if value[len(value)-1] != '\'' {
+			return "", fmt.Errorf("invalid quoted value %s", value)
+		}
+		return strings.Replace(value[1:len(value)-1], "''", "'", -1), nil
+	}
+	return value, nil
+}
@@ -0,0 +1,225 @@
+package app
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/ui"
+)
+
+func Test_parseConfigFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    Config
+		wantErr bool
+	}{
+		{
+			"empty file",
+			"",
+			Config{},
+			false,
+		},
+		{
+			"all settings",
+			`# dry configuration
+refresh_rate: 1000
+view: images # starts on the image list
+confirm: strict
+theme: light
+restore_state: false
+read_only: true
+owner_label: com.example.team
+update_check: 6h
+sort:
+  containers: name
+  images: 'size'
+colors:
+  header: 31
+  markup:
+    blue: 39
+themes:
+  ocean:
+    base: light
+    header: 31
+    markup.white: 17
+keys:
+  containers.remove: d
+  list.filter: '#'
+docker:
+  host: "tcp://127.0.0.1:2376"
+  cert_path: ~/.docker
+  tls_verify: true
+  context: staging
+hosts:
+  prod: tcp://prod:2376
+cleanup:
+  enabled: true
+  at: "03:00"
+`,
+			Config{
+				MonitorRefreshRate:  1000,
+				DefaultView:         "images",
+				Confirmation:        "strict",
+				Theme:               "light",
+				DiscardUIState:      true,
+				ReadOnly:            true,
+				OwnerLabel:          "com.example.team",
+				UpdateCheckInterval: 6 * time.Hour,
+				SortModes:           map[string]string{"containers": "name", "images": "size"},
+				Colors:              map[string]string{"header": "31", "markup.blue": "39"},
+				Themes:              map[string]map[string]string{"ocean": {"base": "light", "header": "31", "markup.white": "17"}},
+				KeyBindings:         map[string]string{"containers.remove": "d", "list.filter": "#"},
+				DockerHost:          "tcp://127.0.0.1:2376",
+				DockerCertPath:      "~/.docker",
+				DockerTLSVerify:     true,
+				Context:             "staging",
+				Hosts:               map[string]string{"prod": "tcp://prod:2376"},
+				Cleanup:             map[string]string{"enabled": "true", "at": "03:00"},
+			},
+			false,
+		},
+		{
+			"quoted hash is not a comment",
+			"view: '#images'",
+			Config{DefaultView: "#images"},
+			false,
+		},
+		{
+			"unknown setting",
+			"refresh: 1000",
+			Config{},
+			true,
+		},
+		{
+			"unknown section",
+			"filters:\n  name: web",
+			Config{},
+			true,
+		},
+		{
+			"invalid refresh rate",
+			"refresh_rate: fast",
+			Config{},
+			true,
+		},
+		{
+			"invalid tls verify",
+			"docker:\n  tls_verify: maybe",
+			Config{},
+			true,
+		},
+		{
+			"host named as the default context",
+			"hosts:\n  default: tcp://prod:2376",
+			Config{},
+			true,
+		},
+		{
+			"invalid restore state",
+			"restore_state: sometimes",
+			Config{},
+			true,
+		},
+		{
+			"invalid read only",
+			"read_only: yes please",
+			Config{},
+			true,
+		},
+		{
+			"invalid update check",
+			"update_check: 30s",
+			Config{},
+			true,
+		},
+		{
+			"nested section",
+			"docker:\n  tls:\n    verify: true",
+			Config{},
+			true,
+		},
+		{
+			"theme setting not on a theme",
+			"themes:\n  base: dark",
+			Config{},
+			true,
+		},
+		{
+			"indentation without section",
+			"  view: images",
+			Config{},
+			true,
+		},
+		{
+			"tab indentation",
+			"docker:\n\thost: tcp://127.0.0.1:2376",
+			Config{},
+			true,
+		},
+		{
+			"no key",
+			"images",
+			Config{},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseConfigFile(strings.NewReader(tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseConfigFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseConfigFile() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigColorTheme(t *testing.T) {
+	theme, err := Config{}.ColorTheme()
+	if err != nil || theme != appui.DryTheme {
+		t.Errorf("Unexpected theme with no configuration: %v, %v", theme, err)
+	}
+	theme, err = Config{Theme: "light", Colors: map[string]string{"header": "31", "footer": "red"}}.ColorTheme()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if theme == appui.Light256 {
+		t.Error("Theme colors overrides changed the light theme")
+	}
+	if theme.Header != ui.Color31 || theme.Footer != ui.ColorRed || theme.Bg != appui.Light256.Bg {
+		t.Errorf("Unexpected theme colors: %+v", theme)
+	}
+	theme, err = Config{
+		Theme: "ocean",
+		Themes: map[string]map[string]string{
+			"ocean": {"base": "solarized", "header": "31", "markup.blue": "39"},
+		}}.ColorTheme()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if theme.Header != ui.Color31 || theme.Markup["blue"] != ui.Color39 ||
+		theme.Markup["red"] != appui.Solarized256.Markup["red"] || appui.Solarized256.Markup["blue"] == ui.Color39 {
+		t.Errorf("Unexpected user-defined theme colors: %+v", theme)
+	}
+	if _, err := (Config{Theme: "ocean", Themes: map[string]map[string]string{"ocean": {"base": "ocean"}}}).ColorTheme(); err == nil {
+		t.Error("Expected an error on a user-defined theme not based on a dry theme")
+	}
+	if _, err := (Config{Colors: map[string]string{"markup.b": "31"}}).ColorTheme(); err == nil {
+		t.Error("Expected an error on a markup tag that is not a color")
+	}
+	if _, err := (Config{Theme: "pink"}).ColorTheme(); err == nil {
+		t.Error("Expected an error on an unknown theme")
+	}
+	if _, err := (Config{Colors: map[string]string{"border": "31"}}).ColorTheme(); err == nil {
+		t.Error("Expected an error on an unknown theme color")
+	}
+	if _, err := (Config{Colors: map[string]string{"header": "300"}}).ColorTheme(); err == nil {
+		t.Error("Expected an error on an invalid color")
+	}
+}
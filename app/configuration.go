@@ -0,0 +1,131 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+)
+
+//maskedValue replaces the values of secrets on the configuration view
+const maskedValue = "********"
+
+//secretKey matches the names of environment variables and labels whose
+//values are masked on the configuration view
+var secretKey = regexp.MustCompile(`(?i)SECRET|TOKEN|PASSWORD|PASSWD|API_?KEY|CREDENTIAL|PRIVATE_?KEY`)
+
+//showConfiguration shows the environment, mounts, devices and labels of the
+//given container, going back to the active view once closed
+func showConfiguration(dry *Dry, screen *ui.Screen, container *docker.Container, h eventHandler, f func(eventHandler)) error {
+	c, err := dry.dockerDaemon.Inspect(container.ID)
+	if err != nil {
+		return err
+	}
+	name := containerName(container)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	dry.pushView(NoView)
+	go appui.ContainerConfiguration(
+		func(masked bool) string {
+			return configurationReport(name, c, masked)
+		},
+		screen, forwarder.events(), func() {
+			dry.popView()
+			f(h)
+			refreshScreen()
+		})
+	return nil
+}
+
+//configurationReport describes the environment, mounts, devices and labels
+//of the given container, optionally masking the values of secrets
+func configurationReport(name string, c types.ContainerJSON, masked bool) string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "<yellow>Configuration of container</> <white>%s</>\n", name)
+
+	buf.WriteString("\n<blue><b>ENVIRONMENT</></>\n")
+	var env []string
+	if c.Config != nil {
+		env = c.Config.Env
+	}
+	if len(env) == 0 {
+		buf.WriteString("<darkgrey>No environment variables</>\n")
+	}
+	for _, v := range env {
+		key, value := v, ""
+		if i := strings.Index(v, "="); i >= 0 {
+			key, value = v[:i], v[i+1:]
+		}
+		fmt.Fprintf(&buf, "<white>%s</>=%s\n", key, maskSecret(key, value, masked))
+	}
+
+	buf.WriteString("\n<blue><b>MOUNTS</></>\n")
+	if len(c.Mounts) == 0 {
+		buf.WriteString("<darkgrey>No mounts</>\n")
+	}
+	for _, m := range c.Mounts {
+		source := m.Source
+		if m.Name != "" {
+			source = m.Name
+		}
+		mode := "rw"
+		if !m.RW {
+			mode = "ro"
+		}
+		fmt.Fprintf(&buf, "<white>%s</> %s -> %s (%s", m.Type, source, m.Destination, mode)
+		if m.Mode != "" {
+			fmt.Fprintf(&buf, ", %s", m.Mode)
+		}
+		if m.Propagation != "" {
+			fmt.Fprintf(&buf, ", %s", m.Propagation)
+		}
+		buf.WriteString(")\n")
+	}
+
+	buf.WriteString("\n<blue><b>DEVICES</></>\n")
+	var devices []string
+	if c.ContainerJSONBase != nil && c.HostConfig != nil {
+		for _, d := range c.HostConfig.Devices {
+			devices = append(devices, fmt.Sprintf("%s -> %s (%s)", d.PathOnHost, d.PathInContainer, d.CgroupPermissions))
+		}
+	}
+	if len(devices) == 0 {
+		buf.WriteString("<darkgrey>No devices</>\n")
+	}
+	for _, d := range devices {
+		buf.WriteString(d + "\n")
+	}
+
+	buf.WriteString("\n<blue><b>LABELS</></>\n")
+	var labels map[string]string
+	if c.Config != nil {
+		labels = c.Config.Labels
+	}
+	if len(labels) == 0 {
+		buf.WriteString("<darkgrey>No labels</>\n")
+	}
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(&buf, "<white>%s</>=%s\n", key, maskSecret(key, labels[key], masked))
+	}
+	return buf.String()
+}
+
+//maskSecret returns the given value masked if masking is enabled and the
+//key names a secret
+func maskSecret(key, value string, masked bool) string {
+	if masked && value != "" && secretKey.MatchString(key) {
+		return maskedValue
+	}
+	return value
+}
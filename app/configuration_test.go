@@ -0,0 +1,53 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+)
+
+func TestConfigurationReport(t *testing.T) {
+	c := types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{
+			HostConfig: &container.HostConfig{Resources: container.Resources{
+				Devices: []container.DeviceMapping{{PathOnHost: "/dev/fuse", PathInContainer: "/dev/fuse", CgroupPermissions: "rwm"}},
+			}},
+		},
+		Mounts: []types.MountPoint{
+			{Type: mount.TypeVolume, Name: "data", Destination: "/var/lib/db", RW: true},
+			{Type: mount.TypeBind, Source: "/etc/app", Destination: "/config"},
+		},
+		Config: &container.Config{
+			Env:    []string{"PATH=/usr/bin", "DB_PASSWORD=hunter2", "github_token=abc", "EMPTY_SECRET="},
+			Labels: map[string]string{"app": "db", "vault.secret": "s3cr3t"},
+		},
+	}
+	report := configurationReport("db", c, true)
+	for _, want := range []string{
+		"PATH</>=/usr/bin", "DB_PASSWORD</>=" + maskedValue, "github_token</>=" + maskedValue, "EMPTY_SECRET</>=\n",
+		"volume</> data -> /var/lib/db (rw)", "bind</> /etc/app -> /config (ro)",
+		"/dev/fuse -> /dev/fuse (rwm)", "app</>=db", "vault.secret</>=" + maskedValue,
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Configuration report does not contain %q:\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "hunter2") || strings.Contains(report, "s3cr3t") {
+		t.Errorf("Secrets not masked:\n%s", report)
+	}
+
+	report = configurationReport("db", c, false)
+	if !strings.Contains(report, "hunter2") || !strings.Contains(report, "s3cr3t") {
+		t.Errorf("Secrets masked when masking is disabled:\n%s", report)
+	}
+
+	report = configurationReport("empty", types.ContainerJSON{}, true)
+	for _, want := range []string{"No environment variables", "No mounts", "No devices", "No labels"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Configuration report of an empty container does not contain %q:\n%s", want, report)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package app
 import (
 	"context"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/gdamore/tcell"
@@ -38,8 +39,9 @@ func (h *containersScreenEventHandler) handleCommand(command commandRunner, f fu
 
 	switch command.command {
 	case docker.KILL:
+		protected := docker.IsContainerProtected(command.container)
 		prompt := appui.NewPrompt(
-			fmt.Sprintf("Do you want to kill container %s? (y/N)", id))
+			confirmationPrompt(fmt.Sprintf("Do you want to kill container %s?", id), protected))
 		widgets.add(prompt)
 		forwarder := newEventForwarder()
 		f(forwarder)
@@ -56,7 +58,7 @@ func (h *containersScreenEventHandler) handleCommand(command commandRunner, f fu
 			conf, cancel := prompt.Text()
 			f(h)
 			widgets.remove(prompt)
-			if cancel || (conf != "y" && conf != "Y") {
+			if cancel || !isConfirmed(conf, protected) {
 
 				return
 			}
@@ -73,7 +75,7 @@ func (h *containersScreenEventHandler) handleCommand(command commandRunner, f fu
 
 	case docker.RESTART:
 		prompt := appui.NewPrompt(
-			fmt.Sprintf("Do you want to restart container %s? (y/N)", id))
+			confirmationPrompt(fmt.Sprintf("Do you want to restart container %s?", id), false))
 		widgets.add(prompt)
 		forwarder := newEventForwarder()
 		f(forwarder)
@@ -90,7 +92,7 @@ func (h *containersScreenEventHandler) handleCommand(command commandRunner, f fu
 			conf, cancel := prompt.Text()
 			f(h)
 			widgets.remove(prompt)
-			if cancel || (conf != "y" && conf != "Y") {
+			if cancel || !isConfirmed(conf, false) {
 
 				return
 			}
@@ -104,7 +106,7 @@ func (h *containersScreenEventHandler) handleCommand(command commandRunner, f fu
 
 	case docker.STOP:
 		prompt := appui.NewPrompt(
-			fmt.Sprintf("Do you want to stop container %s? (y/N)", id))
+			confirmationPrompt(fmt.Sprintf("Do you want to stop container %s?", id), false))
 		widgets.add(prompt)
 		forwarder := newEventForwarder()
 		f(forwarder)
@@ -121,7 +123,7 @@ func (h *containersScreenEventHandler) handleCommand(command commandRunner, f fu
 			conf, cancel := prompt.Text()
 			f(h)
 			widgets.remove(prompt)
-			if cancel || (conf != "y" && conf != "Y") {
+			if cancel || !isConfirmed(conf, false) {
 
 				return
 			}
@@ -136,8 +138,9 @@ func (h *containersScreenEventHandler) handleCommand(command commandRunner, f fu
 	case docker.LOGS:
 		h.showLogs(id, false, f)
 	case docker.RM:
+		protected := docker.IsContainerProtected(command.container)
 		prompt := appui.NewPrompt(
-			fmt.Sprintf("Do you want to remove container %s? (y/N)", id))
+			confirmationPrompt(fmt.Sprintf("Do you want to remove container %s?", id), protected))
 		widgets.add(prompt)
 		forwarder := newEventForwarder()
 		f(forwarder)
@@ -154,17 +157,22 @@ func (h *containersScreenEventHandler) handleCommand(command commandRunner, f fu
 			conf, cancel := prompt.Text()
 			f(h)
 			widgets.remove(prompt)
-			if cancel || (conf != "y" && conf != "Y") {
+			if cancel || !isConfirmed(conf, protected) {
 
 				return
 			}
 
-			err := dry.dockerDaemon.Rm(id)
-			if err == nil {
-				dry.actionMessage(id, "removed")
-			} else {
-				dry.errorMessage(id, "removing", err)
-			}
+			//removed from the host it was asked on, even after switching contexts
+			daemon := dry.dockerDaemon
+			dry.removals.schedule("container "+docker.TruncateID(id), func() {
+				err := daemon.Rm(id)
+				if err == nil {
+					dry.actionMessage(id, "removed")
+				} else {
+					dry.errorMessage(id, "removing", err)
+				}
+				refreshScreen()
+			})
 			refreshScreen()
 		}()
 
@@ -180,9 +188,9 @@ func (h *containersScreenEventHandler) handleCommand(command commandRunner, f fu
 			} else {
 				forwarder := newEventForwarder()
 				f(forwarder)
-				h.dry.changeView(NoView)
-				go statsScreen(command.container, statsChan, screen, forwarder.events(), func() {
-					h.dry.changeView(Main)
+				h.dry.pushView(NoView)
+				go statsScreen(command.container, dry.containerNote(command.container), statsChan, screen, forwarder.events(), func() {
+					h.dry.popView()
 					f(h)
 					refreshScreen()
 				})
@@ -193,13 +201,13 @@ func (h *containersScreenEventHandler) handleCommand(command commandRunner, f fu
 		forwarder := newEventForwarder()
 		f(forwarder)
 		err := inspect(
+			h.dry,
 			h.screen,
 			forwarder.events(),
 			func(id string) (interface{}, error) {
 				return h.dry.dockerDaemon.Inspect(id)
 			},
 			func() {
-				h.dry.changeView(Main)
 				f(h)
 				refreshScreen()
 			})(id)
@@ -210,6 +218,54 @@ func (h *containersScreenEventHandler) handleCommand(command commandRunner, f fu
 			return
 		}
 
+	case docker.HEALTHCHECK:
+		runHealthcheck(dry, screen, command.container, h, f)
+
+	case docker.HEALTH_LOG:
+		if err := showHealthLog(dry, screen, command.container, h, f); err != nil {
+			dry.message(
+				fmt.Sprintf("Error showing the health log: %s", err.Error()))
+		}
+
+	case docker.EVENTS:
+		if err := showContainerHistory(dry, screen, command.container, h, f); err != nil {
+			dry.message(
+				fmt.Sprintf("Error showing the event history: %s", err.Error()))
+		}
+
+	case docker.CONFIGURATION:
+		if err := showConfiguration(dry, screen, command.container, h, f); err != nil {
+			dry.message(
+				fmt.Sprintf("Error showing the configuration: %s", err.Error()))
+		}
+
+	case docker.PORTS:
+		if err := showPorts(dry, screen, command.container, h, f); err != nil {
+			dry.message(
+				fmt.Sprintf("Error showing the ports: %s", err.Error()))
+		}
+
+	case docker.RECREATE:
+		recreateContainer(dry, command.container, h, f, nil)
+
+	case docker.RESOURCES:
+		if err := updateResources(dry, command.container, h, f, nil); err != nil {
+			dry.message(
+				fmt.Sprintf("Error updating container resources: %s", err.Error()))
+		}
+
+	case docker.CLONE:
+		if err := cloneContainer(dry, command.container, h, f); err != nil {
+			dry.message(
+				fmt.Sprintf("Error cloning container: %s", err.Error()))
+		}
+
+	case docker.RUN_COMMAND:
+		if err := showRunCommand(dry, screen, command.container, h, f); err != nil {
+			dry.message(
+				fmt.Sprintf("Error building the docker run command: %s", err.Error()))
+		}
+
 	case docker.HISTORY:
 		history, err := dry.dockerDaemon.History(command.container.ImageID)
 
@@ -217,10 +273,11 @@ func (h *containersScreenEventHandler) handleCommand(command commandRunner, f fu
 			forwarder := newEventForwarder()
 			f(forwarder)
 			renderer := appui.NewDockerImageHistoryRenderer(history)
-
+			h.dry.pushView(NoView)
 			go appui.Less(renderer.String(), screen, forwarder.events(), func() {
-				h.dry.changeView(Main)
+				h.dry.popView()
 				f(h)
+				refreshScreen()
 			})
 		} else {
 			dry.message(
@@ -237,13 +294,9 @@ func (h *containersScreenEventHandler) handleCharacter(key rune, f func(eventHan
 	case '%': //filter containers
 		forwarder := newEventForwarder()
 		f(forwarder)
-		applyFilter := func(filter string, canceled bool) {
-			if !canceled {
-				h.widget.Filter(filter)
-			}
+		showIncrementalFilterInput(newEventSource(forwarder.events()), widgets.ContainerList, func() {
 			f(h)
-		}
-		showFilterInput(newEventSource(forwarder.events()), applyFilter)
+		})
 		refreshScreen()
 
 	case 'e', 'E': //remove
@@ -309,6 +362,239 @@ func (h *containersScreenEventHandler) handleCharacter(key rune, f func(eventHan
 			}); err != nil {
 			h.dry.message("There was an error showing stats: " + err.Error())
 		}
+	case 'B': //batch stop
+		h.batchStopContainers("", f)
+	case 'b': //batch stop containers of the selected container image
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				container := dry.dockerDaemon.ContainerByID(id)
+				if container == nil {
+					return fmt.Errorf("Container with id %s not found", id)
+				}
+				h.batchStopContainers("image="+container.Image, f)
+				return nil
+			}); err != nil {
+			h.dry.message("There was an error stopping the containers: " + err.Error())
+		}
+	case 'c': //logs of several containers
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				container := dry.dockerDaemon.ContainerByID(id)
+				if container == nil {
+					return fmt.Errorf("Container with id %s not found", id)
+				}
+				h.compareLogs(container, f)
+				return nil
+			}); err != nil {
+			h.dry.message("There was an error showing logs: " + err.Error())
+		}
+	case ' ': //collapse or expand the group of the selected container
+		if !widgets.ContainerList.ToggleGroupCollapsed() {
+			dry.message("Containers are not grouped by project")
+		}
+		refreshScreen()
+	case 'f': //filter by label
+		forwarder := newEventForwarder()
+		f(forwarder)
+		showLabelFilterInput(dry, widgets.ContainerList, newEventSource(forwarder.events()), func() {
+			f(h)
+			refreshScreen()
+		})
+		refreshScreen()
+	case 'y': //copy to clipboard
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				container := dry.dockerDaemon.ContainerByID(id)
+				if container == nil {
+					return fmt.Errorf("Container with id %s not found", id)
+				}
+				copyToClipboard(dry, h, f, "container "+containerName(container),
+					containerClipboardValues(dry.dockerDaemon, container))
+				return nil
+			}); err != nil {
+			h.dry.message("There was an error copying to clipboard: " + err.Error())
+		}
+	case 'w': //compare with another container
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				container := dry.dockerDaemon.ContainerByID(id)
+				if container == nil {
+					return fmt.Errorf("Container with id %s not found", id)
+				}
+				h.diffContainers(container, f)
+				return nil
+			}); err != nil {
+			h.dry.message("There was an error comparing containers: " + err.Error())
+		}
+	case 'p': //containers of the compose project of the selected container
+		toggleComposeProjectFilter(dry, widgets.ContainerList)
+		refreshScreen()
+	case 'n': //note
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				container := dry.dockerDaemon.ContainerByID(id)
+				if container == nil {
+					return fmt.Errorf("Container with id %s not found", id)
+				}
+				editNote(dry, containerNotes, containerName(container), id, h, f, nil)
+				return nil
+			}); err != nil {
+			h.dry.message("There was an error editing the note: " + err.Error())
+		}
+	case 't': //run healthcheck
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				container := dry.dockerDaemon.ContainerByID(id)
+				if container == nil {
+					return fmt.Errorf("Container with id %s not found", id)
+				}
+				h.handleCommand(commandRunner{
+					docker.HEALTHCHECK,
+					container,
+				}, f)
+				return nil
+			}); err != nil {
+			h.dry.message("There was an error running the healthcheck: " + err.Error())
+		}
+	case 'T': //health log
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				container := dry.dockerDaemon.ContainerByID(id)
+				if container == nil {
+					return fmt.Errorf("Container with id %s not found", id)
+				}
+				h.handleCommand(commandRunner{
+					docker.HEALTH_LOG,
+					container,
+				}, f)
+				return nil
+			}); err != nil {
+			h.dry.message("There was an error showing the health log: " + err.Error())
+		}
+	case 'a': //event history
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				container := dry.dockerDaemon.ContainerByID(id)
+				if container == nil {
+					return fmt.Errorf("Container with id %s not found", id)
+				}
+				h.handleCommand(commandRunner{
+					docker.EVENTS,
+					container,
+				}, f)
+				return nil
+			}); err != nil {
+			h.dry.message("There was an error showing the event history: " + err.Error())
+		}
+	case 'r': //docker run command
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				container := dry.dockerDaemon.ContainerByID(id)
+				if container == nil {
+					return fmt.Errorf("Container with id %s not found", id)
+				}
+				h.handleCommand(commandRunner{
+					docker.RUN_COMMAND,
+					container,
+				}, f)
+				return nil
+			}); err != nil {
+			h.dry.message("There was an error building the docker run command: " + err.Error())
+		}
+	case 'v': //configuration
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				container := dry.dockerDaemon.ContainerByID(id)
+				if container == nil {
+					return fmt.Errorf("Container with id %s not found", id)
+				}
+				h.handleCommand(commandRunner{
+					docker.CONFIGURATION,
+					container,
+				}, f)
+				return nil
+			}); err != nil {
+			h.dry.message("There was an error showing the configuration: " + err.Error())
+		}
+	case 'P': //ports
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				container := dry.dockerDaemon.ContainerByID(id)
+				if container == nil {
+					return fmt.Errorf("Container with id %s not found", id)
+				}
+				h.handleCommand(commandRunner{
+					docker.PORTS,
+					container,
+				}, f)
+				return nil
+			}); err != nil {
+			h.dry.message("There was an error showing the ports: " + err.Error())
+		}
+	case 'R': //recreate
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				container := dry.dockerDaemon.ContainerByID(id)
+				if container == nil {
+					return fmt.Errorf("Container with id %s not found", id)
+				}
+				h.handleCommand(commandRunner{
+					docker.RECREATE,
+					container,
+				}, f)
+				return nil
+			}); err != nil {
+			h.dry.message("There was an error recreating the container: " + err.Error())
+		}
+	case 'U': //update resources
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				container := dry.dockerDaemon.ContainerByID(id)
+				if container == nil {
+					return fmt.Errorf("Container with id %s not found", id)
+				}
+				h.handleCommand(commandRunner{
+					docker.RESOURCES,
+					container,
+				}, f)
+				return nil
+			}); err != nil {
+			h.dry.message("There was an error updating the container resources: " + err.Error())
+		}
+	case 'D': //clone
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				container := dry.dockerDaemon.ContainerByID(id)
+				if container == nil {
+					return fmt.Errorf("Container with id %s not found", id)
+				}
+				h.handleCommand(commandRunner{
+					docker.CLONE,
+					container,
+				}, f)
+				return nil
+			}); err != nil {
+			h.dry.message("There was an error cloning the container: " + err.Error())
+		}
+	case 'C': //export as a compose file
+		h.exportCompose(f)
+	case 'x', 'X': //export logs
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				container := dry.dockerDaemon.ContainerByID(id)
+				if container == nil {
+					return fmt.Errorf("Container with id %s not found", id)
+				}
+				//Logs of containers with a TTY are not multiplexed
+				multiplexed := container.Config == nil || !container.Config.Tty
+				exportLogs(dry, h, f, containerName(container), multiplexed,
+					func(opts docker.LogsOptions) (io.ReadCloser, error) {
+						return dry.dockerDaemon.Logs(id, opts)
+					})
+				return nil
+			}); err != nil {
+			h.dry.message("There was an error exporting logs: " + err.Error())
+		}
 	default:
 		handled = false
 	}
@@ -326,6 +612,14 @@ func (h *containersScreenEventHandler) handleKey(key tcell.Key, f func(eventHand
 		cursor.Reset()
 		widgets.ContainerList.ToggleShowAllContainers()
 		refreshScreen()
+	case tcell.KeyF3: //group by image
+		cursor.Reset()
+		widgets.ContainerList.ToggleGroupByImage()
+		refreshScreen()
+	case tcell.KeyF4: //group by compose project or stack
+		cursor.Reset()
+		widgets.ContainerList.ToggleGroupByProject()
+		refreshScreen()
 	case tcell.KeyF5: // refresh
 		h.dry.message("Refreshing container list")
 		h.dry.dockerDaemon.Refresh(func(e error) {
@@ -338,7 +632,7 @@ func (h *containersScreenEventHandler) handleKey(key tcell.Key, f func(eventHand
 		})
 	case tcell.KeyCtrlE: //remove all stopped
 		prompt := appui.NewPrompt(
-			"All stopped containers will be removed. Do you want to continue? (y/N) ")
+			confirmationPrompt("All stopped containers will be removed. Do you want to continue?", false))
 		widgets.add(prompt)
 		forwarder := newEventForwarder()
 		f(forwarder)
@@ -355,7 +649,7 @@ func (h *containersScreenEventHandler) handleKey(key tcell.Key, f func(eventHand
 			conf, cancel := prompt.Text()
 			f(h)
 			widgets.remove(prompt)
-			if cancel || (conf != "y" && conf != "Y") {
+			if cancel || !isConfirmed(conf, false) {
 				return
 			}
 			go func() {
@@ -430,17 +724,7 @@ func (h *containersScreenEventHandler) handleKey(key tcell.Key, f func(eventHand
 		}
 	case tcell.KeyEnter: //Container menu
 		showMenu := func(id string) error {
-			h.screen.Cursor().Reset()
-			widgets.ContainerMenu.ForContainer(id)
-			widgets.ContainerMenu.OnUnmount = func() error {
-				h.screen.Cursor().Reset()
-				h.dry.changeView(Main)
-				f(viewsToHandlers[Main])
-				return refreshScreen()
-			}
-			h.dry.changeView(ContainerMenu)
-			f(viewsToHandlers[ContainerMenu])
-			return refreshScreen()
+			return showContainerMenu(h.dry, id, f)
 		}
 		if err := h.widget.OnEvent(showMenu); err != nil {
 			h.dry.message(err.Error())
@@ -455,7 +739,7 @@ func (h *containersScreenEventHandler) handleKey(key tcell.Key, f func(eventHand
 
 //statsScreen shows container stats on the screen
 //TODO move to appui
-func statsScreen(container *docker.Container, stats *docker.StatsChannel, screen *ui.Screen, events <-chan *tcell.EventKey, closeCallback func()) {
+func statsScreen(container *docker.Container, note string, stats *docker.StatsChannel, screen *ui.Screen, events <-chan *tcell.EventKey, closeCallback func()) {
 	defer closeCallback()
 
 	if !docker.IsContainerRunning(container) {
@@ -463,7 +747,7 @@ func statsScreen(container *docker.Container, stats *docker.StatsChannel, screen
 	}
 	screen.ClearAndFlush()
 
-	info, infoLines := appui.NewContainerInfo(container)
+	info, infoLines := appui.NewContainerInfo(container, note)
 	screen.Render(1, info)
 	d := ui.ActiveScreen.Dimensions()
 
@@ -495,13 +779,20 @@ loop:
 					break loop
 				}
 				statsRow.Update(stat)
+				y := statsRow.Y + statsRow.Height + 2
+				details, detailsLines := appui.NewContainerStatsDetails(
+					stat, 0, y, h-y, w)
+				y += detailsLines + 1
 				top, _ := appui.NewDockerTop(
 					stat.ProcessList,
-					0, statsRow.Y+statsRow.Height+2,
-					h-infoLines-statsRow.GetHeight(),
+					0, y,
+					h-y,
 					w)
+				screen.Clear()
+				screen.Render(1, info)
 				screen.RenderBufferer(
 					header,
+					details,
 					top,
 					statsRow)
 				screen.Flush()
@@ -530,21 +821,27 @@ func (h *containersScreenEventHandler) showLogs(id string, withTimestamp bool, f
 		}
 		prompt.OnFocus(events)
 		widgets.remove(prompt)
-		since, canceled := prompt.Text()
+		text, canceled := prompt.Text()
 
 		if canceled {
 			f(h)
 			return
 		}
-		since = curateLogsDuration(since)
-		logs, err := h.dry.dockerDaemon.Logs(id, since, withTimestamp)
-		if err == nil {
-			appui.Stream(logs, forwarder.events(), func() {
-				h.dry.changeView(Main)
+		opts, err := parseLogsOptions(text, withTimestamp)
+		if err != nil {
+			f(h)
+			h.dry.message("Error showing container logs: " + err.Error())
+			return
+		}
+		h.dry.pushView(NoView)
+		err = appui.StreamLogs(logsSource(h.dry.dockerDaemon.Logs, id, opts),
+			opts.Timestamps, opts.Follow, forwarder.events(), func() {
+				h.dry.popView()
 				f(h)
 				refreshScreen()
 			})
-		} else {
+		if err != nil {
+			h.dry.popView()
 			f(h)
 			h.dry.message("Error showing container logs: " + err.Error())
 		}
@@ -0,0 +1,92 @@
+package app
+
+import (
+	"path"
+
+	"github.com/gdamore/tcell"
+	"github.com/moncho/dry/appui"
+)
+
+//maxViewableFileSize is the size of the biggest file that can be shown on the pager
+const maxViewableFileSize = 1024 * 1024
+
+type containerFilesEventHandler struct {
+	baseEventHandler
+	widget *appui.ContainerFilesWidget
+}
+
+func (h *containerFilesEventHandler) handle(event *tcell.EventKey, f func(eventHandler)) {
+	handled := true
+	switch event.Key() {
+	case tcell.KeyEsc:
+		h.dry.goBack(f)
+	case tcell.KeyF5: // refresh
+		h.dry.message("Refreshing the file list")
+		h.widget.Unmount()
+		refreshScreen()
+	case tcell.KeyBackspace, tcell.KeyBackspace2: //parent directory
+		h.changeDir(path.Dir(h.widget.Dir()))
+	case tcell.KeyEnter:
+		file, err := h.widget.Selected()
+		if err != nil {
+			h.dry.message(err.Error())
+			return
+		}
+		if file.IsDir() {
+			h.changeDir(file.Path)
+			return
+		}
+		//Links to directories are followed, other links are shown as files
+		if file.LinkTarget != "" {
+			if _, err := h.dry.dockerDaemon.ContainerFiles(h.widget.ContainerID(), file.Path); err == nil {
+				h.changeDir(file.Path)
+				return
+			}
+		}
+		h.showFile(file.Path, f)
+	default:
+		handled = false
+	}
+	if !handled {
+		switch event.Rune() {
+		case '%':
+			handled = true
+			forwarder := newEventForwarder()
+			f(forwarder)
+			refreshScreen()
+			applyFilter := func(filter string, canceled bool) {
+				if !canceled {
+					h.widget.Filter(filter)
+				}
+				f(h)
+			}
+			showFilterInput(newEventSource(forwarder.events()), applyFilter)
+		}
+	}
+	if !handled {
+		h.baseEventHandler.handle(event, f)
+	}
+}
+
+func (h *containerFilesEventHandler) changeDir(dir string) {
+	h.screen.Cursor().Reset()
+	h.widget.ChangeDir(dir)
+	refreshScreen()
+}
+
+//showFile shows the content of the given file on the pager, if it is a small text file
+func (h *containerFilesEventHandler) showFile(file string, f func(eventHandler)) {
+	content, err := h.dry.dockerDaemon.ContainerFileContent(h.widget.ContainerID(), file, maxViewableFileSize)
+	if err != nil {
+		h.dry.message("Cannot show file: " + err.Error())
+		return
+	}
+	forwarder := newEventForwarder()
+	f(forwarder)
+	h.dry.pushView(NoView)
+	go appui.PlainLess(content, h.screen, forwarder.events(), func() {
+		h.dry.popView()
+		f(h)
+		refreshScreen()
+	})
+}
@@ -0,0 +1,31 @@
+package app
+
+import (
+	"time"
+
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+)
+
+//showContainerHistory shows the events of the given container since it was
+//created, as reported by the daemon and seen on the events stream, followed
+//by new ones as they happen, going back to the active view once closed
+func showContainerHistory(dry *Dry, screen *ui.Screen, container *docker.Container, h eventHandler, f func(eventHandler)) error {
+	//a second earlier, the creation time is truncated to seconds
+	since := time.Unix(container.Container.Created, 0).Add(-time.Second)
+	history, err := dry.dockerDaemon.ContainerEvents(container.ID, since)
+	if err != nil {
+		return err
+	}
+	forwarder := newEventForwarder()
+	f(forwarder)
+	dry.pushView(NoView)
+	go appui.StreamContainerHistory(containerName(container), container.ID, history,
+		dry.dockerDaemon.EventLog(), forwarder.events(), func() {
+			dry.popView()
+			f(h)
+			refreshScreen()
+		})
+	return nil
+}
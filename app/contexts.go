@@ -0,0 +1,129 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+)
+
+//contextNames returns the names of the Docker contexts and configured
+//hosts dry can switch to, the default one first
+func (d *Dry) contextNames() ([]string, error) {
+	contexts, err := docker.Contexts(docker.ConfigDir())
+	if err != nil {
+		return nil, err
+	}
+	names := []string{docker.DefaultContext}
+	for _, c := range contexts {
+		if c.Name != docker.DefaultContext {
+			if _, ok := d.config.Hosts[c.Name]; !ok {
+				names = append(names, c.Name)
+			}
+		}
+	}
+	var hosts []string
+	for name := range d.config.Hosts {
+		hosts = append(hosts, name)
+	}
+	sort.Strings(hosts)
+	return append(names, hosts...), nil
+}
+
+//contextEnv returns the environment to connect to the Docker host of the
+//given context or configured host, the default context is the Docker host
+//dry was started with. Configured hosts are connected to with the TLS
+//settings of the default context.
+func (d *Dry) contextEnv(name string) (docker.Env, error) {
+	if name == docker.DefaultContext {
+		return d.defaultEnv, nil
+	}
+	if host, ok := d.config.Hosts[name]; ok {
+		env := d.defaultEnv
+		env.DockerHost = host
+		return env, nil
+	}
+	c, err := docker.ContextByName(docker.ConfigDir(), name)
+	if err != nil {
+		return docker.Env{}, err
+	}
+	return c.Env(), nil
+}
+
+//switchContext connects dry to the Docker host of the given context or
+//configured host, the state of the UI is kept. Nothing changes if the connection fails.
+func (d *Dry) switchContext(name string) error {
+	env, err := d.contextEnv(name)
+	if err != nil {
+		return err
+	}
+	daemon, err := connectToDaemon(env, d.readOnly)
+	if err != nil {
+		return err
+	}
+	if err := d.connectKeepingUI(daemon); err != nil {
+		daemon.Close()
+		return err
+	}
+	d.context = name
+	if d.title != nil {
+		d.title.setHost(env.DockerHost)
+	}
+	if _, unsupported := d.unsupportedFeature(d.viewMode()); unsupported {
+		d.switchView(Main)
+	}
+	return nil
+}
+
+//connectKeepingUI connects dry to the given Docker daemon, the state of
+//the UI is kept. Nothing changes if the connection fails.
+func (d *Dry) connectKeepingUI(daemon docker.ContainerDaemon) error {
+	state := d.uiState()
+	if err := d.connect(daemon); err != nil {
+		return err
+	}
+	widgets.Monitor.Unmount()
+	d.configureWidgets(d.config)
+	d.restoreUIState(state)
+	return nil
+}
+
+//showContextSwitcher asks for the Docker context to switch to
+func showContextSwitcher(dry *Dry, f func(eventHandler)) {
+	names, err := dry.contextNames()
+	if err != nil {
+		dry.message(fmt.Sprintf("<red>Error reading Docker contexts:</> %s", err.Error()))
+		return
+	}
+	prompt := appui.NewPromptWithText(
+		fmt.Sprintf("Docker context or host (%s):", strings.Join(names, ", ")),
+		dry.context)
+	widgets.add(prompt)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+
+	go func() {
+		prompt.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(prompt)
+		name, canceled := prompt.Text()
+		name = strings.TrimSpace(name)
+		if canceled || name == "" || name == dry.context {
+			f(viewsToHandlers[dry.viewMode()])
+			refreshScreen()
+			return
+		}
+		dry.message(fmt.Sprintf("<white>Connecting to Docker context %s</>", name))
+		if err := dry.switchContext(name); err != nil {
+			dry.message(fmt.Sprintf("<red>Error switching to Docker context %s:</> %s", name, err.Error()))
+		} else if notice := podmanNotice(dry.dockerDaemon); notice != "" {
+			dry.message(notice)
+		} else {
+			dry.message(fmt.Sprintf("<white>Switched to Docker context %s</>", name))
+		}
+		f(viewsToHandlers[dry.viewMode()])
+		refreshScreen()
+	}()
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/moncho/dry/docker"
+)
+
+const (
+	//healthCheckInterval is the time between pings to the Docker daemon
+	healthCheckInterval = 5 * time.Second
+	//slowLatency is the round-trip time from which the Docker daemon is
+	//shown as slow
+	slowLatency = 500 * time.Millisecond
+)
+
+//daemonHealth is the last known health of the Docker daemon
+type daemonHealth struct {
+	sync.RWMutex
+	status  docker.DaemonStatus
+	checked bool
+}
+
+func (h *daemonHealth) set(status docker.DaemonStatus) {
+	h.Lock()
+	defer h.Unlock()
+	h.status = status
+	h.checked = true
+}
+
+//statusLine renders the health of the Docker daemon for the status line,
+//empty until the daemon is pinged
+func (h *daemonHealth) statusLine() string {
+	h.RLock()
+	defer h.RUnlock()
+	if !h.checked {
+		return ""
+	}
+	s := h.status
+	if !s.Reachable() {
+		return "<red>● Docker daemon unreachable</>"
+	}
+	color := "green"
+	if s.Latency >= slowLatency {
+		color = "yellow"
+	}
+	return fmt.Sprintf("<%s>●</> API %s <%s>%s</>",
+		color, s.APIVersion, color, formatLatency(s.Latency))
+}
+
+//formatLatency rounds the given latency to a precision that makes sense
+//to show, i.e. 1.2ms or 35ms
+func formatLatency(latency time.Duration) string {
+	switch {
+	case latency < 10*time.Millisecond:
+		return latency.Round(100 * time.Microsecond).String()
+	case latency < time.Second:
+		return latency.Round(time.Millisecond).String()
+	default:
+		return latency.Round(100 * time.Millisecond).String()
+	}
+}
+
+//checkDaemonHealth pings the Docker daemon dry is connected to every
+//healthCheckInterval, the status line shows the result
+func checkDaemonHealth(dry *Dry) {
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+	for {
+		dry.health.set(dry.dockerDaemon.Ping())
+		dry.whenFocused(NoView, func() {
+			refreshScreen()
+		})
+		<-ticker.C
+	}
+}
@@ -0,0 +1,28 @@
+package app
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/moncho/dry/docker"
+)
+
+func TestDaemonHealthStatusLine(t *testing.T) {
+	var h daemonHealth
+	if line := h.statusLine(); line != "" {
+		t.Errorf("Status shown before pinging the daemon: %s", line)
+	}
+	h.set(docker.DaemonStatus{APIVersion: "1.40", Latency: 1234 * time.Microsecond})
+	if line := h.statusLine(); line != "<green>●</> API 1.40 <green>1.2ms</>" {
+		t.Errorf("Unexpected status line: %s", line)
+	}
+	h.set(docker.DaemonStatus{APIVersion: "1.40", Latency: 1500 * time.Millisecond})
+	if line := h.statusLine(); line != "<yellow>●</> API 1.40 <yellow>1.5s</>" {
+		t.Errorf("Unexpected status line of a slow daemon: %s", line)
+	}
+	h.set(docker.DaemonStatus{Err: errors.New("connection refused")})
+	if line := h.statusLine(); line != "<red>● Docker daemon unreachable</>" {
+		t.Errorf("Unexpected status line of an unreachable daemon: %s", line)
+	}
+}
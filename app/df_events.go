@@ -1,15 +1,18 @@
 package app
 
 import (
+	"context"
 	"fmt"
 
+	units "github.com/docker/go-units"
+	"github.com/gdamore/tcell"
 	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
 	"github.com/moncho/dry/ui"
-	"github.com/gdamore/tcell"
 )
 
 const (
-	confirmation = `WARNING! This will remove all unused data. Are you sure you want to continue? [y/N]`
+	confirmation = `WARNING! This will remove all unused data. Are you sure you want to continue?`
 )
 
 type diskUsageScreenEventHandler struct {
@@ -18,17 +21,48 @@ type diskUsageScreenEventHandler struct {
 
 func (h *diskUsageScreenEventHandler) handle(event *tcell.EventKey, f func(eventHandler)) {
 
+	du := widgets.DiskUsage
 	handled := false
 	switch event.Key() {
-	case tcell.KeyUp | tcell.KeyDown:
-		//To avoid the base handler handling this
+	case tcell.KeyUp, tcell.KeyCtrlP:
+		handled = true
+		du.MoveSelection(-1)
+	case tcell.KeyDown, tcell.KeyCtrlN:
+		handled = true
+		du.MoveSelection(1)
+	case tcell.KeyPgUp:
+		handled = true
+		du.MoveSelection(-10)
+	case tcell.KeyPgDn:
+		handled = true
+		du.MoveSelection(10)
+	case tcell.KeyTab:
+		handled = true
+		du.CycleCategory()
+	case tcell.KeyCtrlE:
 		handled = true
+		h.removeSelected(f)
+	case tcell.KeyF5: // refresh
+		handled = true
+		computeDiskUsage(h.dry)
 	}
 	switch event.Rune() {
+	case 'k':
+		handled = true
+		du.MoveSelection(-1)
+	case 'j':
+		handled = true
+		du.MoveSelection(1)
+	case 'b':
+		handled = true
+		pruneBuildCache(h.dry, h, f)
+	case 'o':
+		handled = true
+		showOwnership(h.dry, f)
 	case 'p', 'P':
 		handled = true
 
-		rw := appui.NewPrompt(confirmation)
+		rw := appui.NewPrompt(confirmationPrompt(confirmation, false))
 		widgets.add(rw)
 		forwarder := newEventForwarder()
 		f(forwarder)
@@ -44,17 +78,16 @@ func (h *diskUsageScreenEventHandler) handle(event *tcell.EventKey, f func(event
 
 			rw.OnFocus(events)
 			widgets.remove(rw)
-			confirmation, canceled := rw.Text()
+			answer, canceled := rw.Text()
 			f(h)
-			if canceled || (confirmation != "y" && confirmation != "Y") {
+			if canceled || !isConfirmed(answer, false) {
 				return
 			}
 
 			pr, err := h.dry.dockerDaemon.Prune()
 			if err == nil {
-				if du, err := h.dry.dockerDaemon.DiskUsage(); err == nil {
-					widgets.DiskUsage.PrepareToRender(&du, pr)
-				}
+				widgets.DiskUsage.SetPruneReport(pr)
+				computeDiskUsage(h.dry)
 			} else {
 				h.dry.message(
 					fmt.Sprintf(
@@ -63,8 +96,82 @@ func (h *diskUsageScreenEventHandler) handle(event *tcell.EventKey, f func(event
 			refreshScreen()
 		}()
 	}
-	if !handled {
+	if handled {
+		refreshScreen()
+	} else {
 		h.baseEventHandler.handle(event, f)
 	}
 
 }
+
+//removeSelected asks for confirmation and removes the object selected on
+//the list below the disk usage totals, objects in use are not removed
+func (h *diskUsageScreenEventHandler) removeSelected(f func(eventHandler)) {
+	item, ok := widgets.DiskUsage.Selected()
+	if !ok || item.Category == appui.DiskUsageTotals {
+		return
+	}
+	if item.Active {
+		h.dry.message(fmt.Sprintf("<red>%s is in use, it cannot be removed</>", item.Name))
+		return
+	}
+	protected := docker.IsProtected(item.Labels)
+	prompt := appui.NewPrompt(
+		confirmationPrompt(
+			fmt.Sprintf("Do you want to remove %s %s, %s?",
+				diskUsageItemKind(item.Category), item.Name, units.HumanSize(float64(item.Size))),
+			protected))
+	widgets.add(prompt)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+	go func() {
+		prompt.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(prompt)
+		conf, cancel := prompt.Text()
+		f(h)
+		if cancel || !isConfirmed(conf, protected) {
+			refreshScreen()
+			return
+		}
+		if err := removeDiskUsageItem(h.dry.dockerDaemon, item); err != nil {
+			h.dry.message(fmt.Sprintf("<red>Error removing %s </><white>%s: %s</>", diskUsageItemKind(item.Category), item.Name, err.Error()))
+		} else {
+			h.dry.message(fmt.Sprintf("<red>Removed %s:</> <white>%s</>", diskUsageItemKind(item.Category), item.Name))
+			computeDiskUsage(h.dry)
+		}
+		refreshScreen()
+	}()
+}
+
+//removeDiskUsageItem removes the given object listed on the disk usage view
+func removeDiskUsageItem(daemon docker.ContainerDaemon, item appui.DiskUsageItem) error {
+	switch item.Category {
+	case appui.DiskUsageImages:
+		_, err := daemon.Rmi(item.ID, false)
+		return err
+	case appui.DiskUsageContainers:
+		return daemon.Rm(item.ID)
+	case appui.DiskUsageVolumes:
+		return daemon.VolumeRemove(context.Background(), item.ID, false)
+	case appui.DiskUsageBuildCache:
+		_, err := daemon.RemoveBuildCache(item.ID)
+		return err
+	}
+	return fmt.Errorf("%s cannot be removed", item.Name)
+}
+
+//diskUsageItemKind returns the kind of objects of the given category, in singular
+func diskUsageItemKind(c appui.DiskUsageCategory) string {
+	switch c {
+	case appui.DiskUsageImages:
+		return "image"
+	case appui.DiskUsageContainers:
+		return "container"
+	case appui.DiskUsageVolumes:
+		return "volume"
+	case appui.DiskUsageBuildCache:
+		return "build cache record"
+	}
+	return c.String()
+}
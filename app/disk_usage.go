@@ -0,0 +1,52 @@
+package app
+
+import (
+	"time"
+)
+
+//diskUsageSpinnerRate is how often the disk usage view is refreshed while
+//the disk usage is computed, so the spinner moves
+const diskUsageSpinnerRate = 100 * time.Millisecond
+
+//computeDiskUsage computes the Docker disk usage in the background, the disk
+//usage view shows a spinner until it is done. Computing the sizes is expensive,
+//so only one computation runs at a time, requests made while it runs are
+//served by a single computation once it finishes.
+func computeDiskUsage(dry *Dry) {
+	du := widgets.DiskUsage
+	if !du.StartComputing() {
+		return
+	}
+	go func() {
+		done := make(chan struct{})
+		go func() {
+			ticker := time.NewTicker(diskUsageSpinnerRate)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-done:
+					return
+				case <-ticker.C:
+					refreshIfView(DiskUsage)
+				}
+			}
+		}()
+		for {
+			usage, err := dry.dockerDaemon.DiskUsage()
+			if !du.DoneComputing(&usage, err) {
+				break
+			}
+		}
+		close(done)
+		refreshIfView(DiskUsage)
+	}()
+}
+
+//showDiskUsage changes to the disk usage view, the disk usage is only
+//computed the first time it is shown, later it has to be refreshed explicitly
+func showDiskUsage(dry *Dry) {
+	dry.switchView(DiskUsage)
+	if !widgets.DiskUsage.Computed() {
+		computeDiskUsage(dry)
+	}
+}
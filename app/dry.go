@@ -18,15 +18,37 @@ import (
 
 //Dry resources and state
 type Dry struct {
+	config           Config
+	connection       connectionState
+	context          string
+	defaultEnv       docker.Env
 	dockerDaemon     docker.ContainerDaemon
 	dockerEvents     <-chan events.Message
 	dockerEventsDone chan<- struct{}
+	eventListeners   []func(events.Message)
+	eventsFile       *docker.EventsFile
+	focus            focusState
+	health           daemonHealth
+	keepUIState      bool
+	keys             *keyMap
+	nav              *navigation
+	notes            *noteStore
 	output           chan string
+	//podman is set if the Docker host is Podman, through its Docker-compatible API
+	podman           bool
+	readOnly         bool
+	refreshUnfocused bool
+	removals         *removalQueue
+	replicaHistory   *docker.ReplicaHistory
+	scalePresets     map[string][]scalePreset
+	cleanup          *cleanupSchedule
+	imageUpdates     imageUpdates
 	screen           *ui.Screen
 	showHeader       bool
-
-	sync.RWMutex
-	view viewMode
+	statsFile        *docker.StatsFile
+	statsFilePath    string
+	statsRecording   chan struct{}
+	title            *terminalTitle
 }
 
 func (d *Dry) showingHeader() bool {
@@ -40,7 +62,20 @@ func (d *Dry) toggleShowHeader() {
 //Close closes dry, releasing any resources held by it
 func (d *Dry) Close() {
 	close(d.dockerEventsDone)
+	d.dockerDaemon.Close()
 	close(d.output)
+	if d.eventsFile != nil {
+		d.eventsFile.Close()
+	}
+	if d.statsFile != nil {
+		close(d.statsRecording)
+		d.statsFile.Close()
+	}
+}
+
+//help returns the help screen, showing the keys bound by the user
+func (d *Dry) help() string {
+	return d.keys.helpText(help)
 }
 
 //OuputChannel returns the channel where dry messages are written
@@ -53,17 +88,40 @@ func (d *Dry) Ok() (bool, error) {
 	return d.dockerDaemon.Ok()
 }
 
-//changeView changes the active view mode
+//changeView changes the active view mode, without changing the navigation history
 func (d *Dry) changeView(v viewMode) {
-	d.Lock()
-	defer d.Unlock()
+	d.nav.replace(v)
+}
+
+//switchView changes to the given top level view, discarding the navigation history
+func (d *Dry) switchView(v viewMode) {
+	d.nav.switchTo(v)
+}
 
-	d.view = v
+//pushView changes to the given view, going back from it returns to the active view
+func (d *Dry) pushView(v viewMode) {
+	d.nav.push(v)
 }
 
+//popView goes back to the view the active view was reached from, and returns it
+func (d *Dry) popView() viewMode {
+	return d.nav.pop()
+}
+
+//goBack goes back to the view the active view was reached from, its handler
+//handles the next events
+func (d *Dry) goBack(f func(eventHandler)) {
+	f(viewsToHandlers[d.popView()])
+	refreshScreen()
+}
+
+//showDockerEvents shows the Docker events as they arrive, reconnecting if
+//the events stream ends because the Docker daemon went away
 func (d *Dry) showDockerEvents() {
+	connection := d.connection.current()
+	dockerEvents := d.dockerEvents
 	go func() {
-		for event := range d.dockerEvents {
+		for event := range dockerEvents {
 			//exec_ messages are sent continuously if docker is checking
 			//a container's health, so they are ignored
 			if strings.Contains(event.Action, "exec_") {
@@ -75,6 +133,7 @@ func (d *Dry) showDockerEvents() {
 			}
 			d.message(fmt.Sprintf("Docker: %s %s", event.Action, event.ID))
 		}
+		d.connectionLost(connection)
 	}()
 }
 
@@ -96,9 +155,7 @@ func (d *Dry) errorMessage(cid interface{}, action string, err error) {
 }
 
 func (d *Dry) viewMode() viewMode {
-	d.RLock()
-	defer d.RUnlock()
-	return d.view
+	return d.nav.active()
 }
 
 //initRegistry creates a widget registry with its widget ready to be used
@@ -114,77 +171,263 @@ func initRegistry(dry *Dry) *widgetRegistry {
 	di.SetWidth(width)
 	widgetScreen := &screen{mainScreen, dry}
 	w := widgetRegistry{
-		DockerInfo:    di,
-		ContainerList: appui.NewContainersWidget(daemon, widgetScreen),
-		ContainerMenu: appui.NewContainerMenuWidget(daemon, widgetScreen),
-		ImageList:     appui.NewDockerImagesWidget(daemon.Images, widgetScreen),
-		DiskUsage:     appui.NewDockerDiskUsageRenderer(height),
-		Monitor:       appui.NewMonitor(daemon, widgetScreen),
-		Networks:      appui.NewDockerNetworksWidget(daemon, widgetScreen),
-		Nodes:         swarm.NewNodesWidget(daemon, widgetScreen),
-		NodeTasks:     swarm.NewNodeTasksWidget(daemon, widgetScreen),
-		ServiceTasks:  swarm.NewServiceTasksWidget(daemon, widgetScreen),
-		ServiceList:   swarm.NewServicesWidget(daemon, widgetScreen),
-		Stacks:        swarm.NewStacksWidget(daemon, widgetScreen),
-		StackTasks:    swarm.NewStacksTasksWidget(daemon, widgetScreen),
-		widgets:       make(map[string]termui.Widget),
-		MessageBar:    ui.NewExpiringMessageWidget(0, mainScreen),
-		Volumes:       appui.NewVolumesWidget(daemon, widgetScreen),
-	}
-
-	refreshOnContainerEvent(w.ContainerList, daemon)
-	refreshOnDockerEvent(docker.ImageSource, w.ImageList, Images)
-	refreshOnDockerEvent(docker.NetworkSource, w.Networks, Networks)
-	refreshOnDockerEvent(docker.NodeSource, w.Nodes, Nodes)
-	refreshOnDockerEvent(docker.ServiceSource, w.ServiceList, Services)
-	refreshOnDockerEvent(docker.ServiceSource, w.Stacks, Stacks)
-	refreshOnDockerEvent(docker.VolumeSource, w.Volumes, Volumes)
+		DockerInfo:        di,
+		ContainerList:     appui.NewContainersWidget(daemon, widgetScreen),
+		ContainerFiles:    appui.NewContainerFilesWidget(daemon, widgetScreen),
+		ContainerMenu:     appui.NewContainerMenuWidget(daemon, widgetScreen),
+		ImageList:         appui.NewDockerImagesWidget(daemon.Images, widgetScreen),
+		DiskUsage:         appui.NewDockerDiskUsageRenderer(height),
+		Monitor:           appui.NewMonitor(daemon, widgetScreen),
+		Networks:          appui.NewDockerNetworksWidget(daemon, widgetScreen),
+		Ownership:         appui.NewOwnershipRenderer(docker.DefaultOwnerLabel),
+		Hosts:             appui.NewHostsRenderer(),
+		ImageLayers:       appui.NewImageLayersRenderer(height),
+		NetworkContainers: appui.NewNetworkContainersRenderer(height),
+		Plugins:           appui.NewPluginsWidget(daemon, widgetScreen),
+		Nodes:             swarm.NewNodesWidget(daemon, widgetScreen),
+		NodeTasks:         swarm.NewNodeTasksWidget(daemon, widgetScreen),
+		ServiceTasks:      swarm.NewServiceTasksWidget(daemon, widgetScreen),
+		ServiceList:       swarm.NewServicesWidget(daemon, widgetScreen),
+		Stacks:            swarm.NewStacksWidget(daemon, widgetScreen),
+		StackTasks:        swarm.NewStacksTasksWidget(daemon, widgetScreen),
+		StatsPlayback:     appui.NewStatsPlayback(),
+		SwarmManagement:   appui.NewSwarmManagementRenderer(),
+		widgets:           make(map[string]termui.Widget),
+		MessageBar:        ui.NewExpiringMessageWidget(0, mainScreen),
+		Volumes:           appui.NewVolumesWidget(daemon, widgetScreen),
+	}
+
+	w.ContainerMenu.Note = dry.containerNote
+	w.ImageList.UnusedSince = unusedSince(daemon, docker.ImageSource)
+	w.ImageList.InUse = imagesInUse(daemon)
+	w.ImageList.Outdated = dry.imageUpdates.isOutdated
+	w.ContainerList.Outdated = dry.imageUpdates.isOutdated
+	w.Networks.UnusedSince = unusedSince(daemon, docker.NetworkSource)
+	w.Volumes.UnusedSince = unusedSince(daemon, docker.VolumeSource)
+
+	w.ContainerList.HighlightChanges(func() { refreshIfView(Main) })
+	refreshOnContainerEvent(dry, w.ContainerList, daemon)
+	markContainerEvents(w.Monitor)
+	refreshOnDockerEvent(dry, docker.ImageSource, w.ImageList, Images)
+	refreshOnDockerEvent(dry, docker.NetworkSource, w.Networks, Networks)
+	refreshOnDockerEvent(dry, docker.NodeSource, w.Nodes, Nodes)
+	refreshOnDockerEvent(dry, docker.PluginSource, w.Plugins, Plugins)
+	refreshOnDockerEvent(dry, docker.ServiceSource, w.ServiceList, Services)
+	refreshOnDockerEvent(dry, docker.ServiceSource, w.Stacks, Stacks)
+	refreshOnDockerEvent(dry, docker.VolumeSource, w.Volumes, Volumes)
 
 	return &w
 }
 
-func newDry(screen *ui.Screen, d *docker.DockerDaemon) (*Dry, error) {
-	dockerEvents, dockerEventsDone, err := d.Events()
-	if err != nil {
-		return nil, err
+//unusedSince returns a func to know since when objects of the given source are unused
+func unusedSince(daemon docker.ContainerDaemon, source docker.SourceType) func(string) (time.Time, bool) {
+	return func(id string) (time.Time, bool) {
+		return daemon.UnusedSince(source, id)
 	}
+}
 
+//imagesInUse returns a func to know the images containers, running or
+//stopped, were created from
+func imagesInUse(daemon docker.ContainerDaemon) func() map[string]bool {
+	return func() map[string]bool {
+		used := make(map[string]bool)
+		for _, c := range daemon.Containers(nil, docker.NoSort) {
+			used[c.ImageID] = true
+		}
+		return used
+	}
+}
+
+func newDry(screen *ui.Screen, d docker.ContainerDaemon) (*Dry, error) {
 	dry := &Dry{}
 	dry.showHeader = true
-	dry.dockerDaemon = d
 	dry.output = make(chan string)
-	dry.dockerEvents = dockerEvents
-	dry.dockerEventsDone = dockerEventsDone
 	dry.screen = screen
-
-	widgets = initRegistry(dry)
-	viewsToHandlers = initHandlers(dry, screen)
-	dry.showDockerEvents()
+	dry.nav = newNavigation(Main, screen.Cursor())
+	dry.notes = newNoteStore(notesFile)
+	dry.removals = newRemovalQueue(0, "", dry.message)
+	if err := dry.connect(d); err != nil {
+		return nil, err
+	}
+	initViewHooks(dry)
 	return dry, nil
 
 }
 
+//connect makes dry work with the given Docker daemon, the widgets showing
+//Docker objects are created again and Docker events are listened to
+func (d *Dry) connect(daemon docker.ContainerDaemon) error {
+	dockerEvents, dockerEventsDone, err := daemon.Events()
+	if err != nil {
+		return err
+	}
+	d.connection.connected()
+	if d.dockerEventsDone != nil {
+		close(d.dockerEventsDone)
+	}
+	docker.GlobalRegistry.Reset()
+	//the daemon dry used before, if any, is no longer needed
+	if d.dockerDaemon != nil && d.dockerDaemon != daemon {
+		d.dockerDaemon.Close()
+	}
+	d.dockerDaemon = daemon
+	d.podman = docker.IsPodman(daemon)
+	d.dockerEvents = dockerEvents
+	d.dockerEventsDone = dockerEventsDone
+	d.replicaHistory = docker.NewReplicaHistory()
+	docker.GlobalRegistry.Register(docker.ServiceSource, d.replicaHistory.Record)
+	for _, listener := range d.eventListeners {
+		daemon.EventLog().Listen(listener)
+	}
+
+	widgets = initRegistry(d)
+	viewsToHandlers = initHandlers(d, d.screen)
+	d.showDockerEvents()
+	return nil
+}
+
+//listen makes the given listener receive the Docker events, also after
+//connecting to another Docker daemon
+func (d *Dry) listen(listener func(events.Message)) {
+	d.eventListeners = append(d.eventListeners, listener)
+	d.dockerDaemon.EventLog().Listen(listener)
+}
+
+//connectToDaemon connects to the Docker daemon of the given environment,
+//actions changing the Docker host are disabled if readOnly is set
+func connectToDaemon(env docker.Env, readOnly bool) (docker.ContainerDaemon, error) {
+	d, err := docker.ConnectToDaemon(env)
+	if err != nil {
+		return nil, err
+	}
+	if readOnly {
+		return docker.ReadOnly(d), nil
+	}
+	return d, nil
+}
+
 //NewDry creates a new dry application
 func NewDry(screen *ui.Screen, cfg Config) (*Dry, error) {
-
-	d, err := docker.ConnectToDaemon(cfg.dockerEnv())
+	env := cfg.dockerEnv()
+	contextName := docker.DefaultContext
+	if cfg.Context != "" && cfg.Context != docker.DefaultContext {
+		c, err := docker.ContextByName(docker.ConfigDir(), cfg.Context)
+		if err != nil {
+			return nil, err
+		}
+		env = c.Env()
+		contextName = c.Name
+	}
+	daemon, err := connectToDaemon(env, cfg.ReadOnly)
 	if err != nil {
 		return nil, err
 	}
-	dry, err := newDry(screen, d)
+	dry, err := newDry(screen, daemon)
 	if err != nil {
 		return nil, err
 	}
+	dry.config = cfg
+	dry.context = contextName
+	dry.defaultEnv = cfg.dockerEnv()
+	dry.title = newTerminalTitle(env.DockerHost, cfg.TmuxStatus)
+	if dry.scalePresets, err = parseScalePresets(cfg.ScalePresets); err != nil {
+		return nil, err
+	}
+	if dry.cleanup, err = parseCleanup(cfg.Cleanup); err != nil {
+		return nil, err
+	}
+	hooks, err := parseHooks(cfg.Hooks)
+	if err != nil {
+		return nil, err
+	}
+	if len(hooks) > 0 {
+		dry.listen(runHooks(dry, hooks))
+	}
+	notifications, err := parseNotifications(cfg.Notifications)
+	if err != nil {
+		return nil, err
+	}
+	if len(notifications) > 0 {
+		notifier := newCriticalEventNotifier(notifications, notifyCriticalEvent(dry))
+		dry.listen(notifier.onEvent)
+	}
+	if cfg.EventsFile != "" {
+		if dry.eventsFile, err = docker.NewEventsFile(cfg.EventsFile, cfg.EventsFileSize); err != nil {
+			return nil, err
+		}
+		dry.listen(recordEvents(dry, dry.eventsFile))
+	}
+	if cfg.StatsFile != "" {
+		if cfg.StatsInterval <= 0 {
+			return nil, fmt.Errorf("invalid stats interval: %s", cfg.StatsInterval)
+		}
+		if dry.statsFile, err = docker.NewStatsFile(cfg.StatsFile, cfg.StatsFileSize); err != nil {
+			return nil, err
+		}
+		dry.statsFilePath = cfg.StatsFile
+		dry.statsRecording = make(chan struct{})
+		go recordStats(dry, cfg.StatsInterval)
+	}
+	if dry.keys, err = newKeyMap(cfg.KeyBindings); err != nil {
+		return nil, err
+	}
+	dry.readOnly = cfg.ReadOnly
+	dry.refreshUnfocused = cfg.RefreshUnfocused
+	dry.removals = newRemovalQueue(cfg.UndoWindow, dry.keys.keyName("global.undo"), dry.message)
+	if confirmationMode, err = parseConfirmation(cfg.Confirmation); err != nil {
+		return nil, err
+	}
+	if err = dry.configureWidgets(cfg); err != nil {
+		return nil, err
+	}
+	if cfg.DefaultView != "" {
+		view, err := parseStartupView(cfg.DefaultView)
+		if err != nil {
+			return nil, err
+		}
+		if dry.supports(view) {
+			dry.changeView(view)
+		}
+	}
+	if !cfg.DiscardUIState {
+		dry.keepUIState = true
+		if state, err := readUIState(stateFile); err == nil {
+			dry.restoreUIState(state)
+		}
+	}
 	if cfg.MonitorMode {
 		dry.changeView(Monitor)
-		widgets.Monitor.RefreshRate(cfg.MonitorRefreshRate)
 	}
 	return dry, nil
 }
 
+//configureWidgets configures the widgets as the given configuration says
+func (d *Dry) configureWidgets(cfg Config) error {
+	if len(cfg.LabelColumns) > 0 {
+		widgets.ContainerList.SetLabelColumns(cfg.LabelColumns)
+		widgets.ServiceList.SetLabelColumns(cfg.LabelColumns)
+	}
+	alerts := appui.AlertThresholds{
+		CPU:    cfg.CPUAlertThreshold,
+		Memory: cfg.MemoryAlertThreshold,
+	}
+	if alerts.Enabled() {
+		widgets.Monitor.SetAlertThresholds(alerts, monitorAlerts(d, cfg.AlertBell))
+	}
+	if cfg.OwnerLabel != "" {
+		widgets.Ownership.SetLabel(cfg.OwnerLabel)
+	}
+	if cfg.MonitorRefreshRate > 0 {
+		widgets.Monitor.RefreshRate(cfg.MonitorRefreshRate)
+	}
+	return setSortModes(sortableLists(), cfg.SortModes)
+}
+
 var refreshInterval = 250 * time.Millisecond // time to wait before next refresh
 
-func refreshOnDockerEvent(source docker.SourceType, w termui.Widget, view viewMode) {
+//refreshOnDockerEvent refreshes the given widget when Docker reports events
+//of the given source, put off while the terminal is not focused
+func refreshOnDockerEvent(dry *Dry, source docker.SourceType, w termui.Widget, view viewMode) {
 	last := time.Now()
 	var lock sync.Mutex
 	docker.GlobalRegistry.Register(
@@ -196,14 +439,18 @@ func refreshOnDockerEvent(source docker.SourceType, w termui.Widget, view viewMo
 				return nil
 			}
 			last = time.Now()
-			err := w.Unmount()
-			if err != nil {
-				return err
-			}
-			return refreshIfView(view)
+			dry.whenFocused(view, func() {
+				if err := w.Unmount(); err == nil {
+					refreshIfView(view)
+				}
+			})
+			return nil
 		})
 }
-func refreshOnContainerEvent(w termui.Widget, daemon docker.ContainerDaemon) {
+
+//refreshOnContainerEvent reloads the container list when Docker reports
+//container events, put off while the terminal is not focused
+func refreshOnContainerEvent(dry *Dry, w termui.Widget, daemon docker.ContainerDaemon) {
 	last := time.Now()
 	var lock sync.Mutex
 	docker.GlobalRegistry.Register(
@@ -215,13 +462,15 @@ func refreshOnContainerEvent(w termui.Widget, daemon docker.ContainerDaemon) {
 				return nil
 			}
 			last = time.Now()
-			daemon.Refresh(func(e error) {
-				err := w.Unmount()
-				if err != nil {
-					return
-				}
-
-				refreshIfView(Main)
+			dry.whenFocused(Main, func() {
+				daemon.Refresh(func(e error) {
+					err := w.Unmount()
+					if err != nil {
+						return
+					}
+
+					refreshIfView(Main)
+				})
 			})
 			return nil
 		})
@@ -246,3 +495,78 @@ func (s *screen) Bounds() image.Rectangle {
 func (s *screen) Cursor() *ui.Cursor {
 	return s.Screen.Cursor()
 }
+
+//monitorAlerts returns an alert handler that shows monitor alerts as messages,
+//ringing the terminal bell if asked to
+func monitorAlerts(dry *Dry, bell bool) appui.AlertHandler {
+	return func(container, exceeded string, alerting bool) {
+		if !alerting {
+			dry.message(fmt.Sprintf("<green>%s</> <white>is back under the alert thresholds</>", container))
+			return
+		}
+		dry.message(fmt.Sprintf("<red>Alert:</> <white>%s</> <red>%s</>", container, exceeded))
+		if bell {
+			ui.Bell()
+		}
+	}
+}
+
+//recordEvents returns an event listener that records events on the given
+//file, only the first error recording events is shown
+func recordEvents(dry *Dry, file *docker.EventsFile) func(events.Message) {
+	var once sync.Once
+	return func(message events.Message) {
+		if err := file.Record(message); err != nil {
+			once.Do(func() {
+				dry.message(fmt.Sprintf("<red>Error recording Docker events:</> %s", err.Error()))
+			})
+		}
+	}
+}
+
+//notifyCriticalEvent returns a func that notifies critical events with the
+//given methods, besides showing them as a message. Desktop notifications are
+//shown on their own goroutine, only the first error showing them is shown.
+func notifyCriticalEvent(dry *Dry) func(string, notificationMethods) {
+	var once sync.Once
+	return func(description string, methods notificationMethods) {
+		dry.message(ui.Red(description))
+		if methods.bell {
+			ui.Bell()
+		}
+		if methods.desktop {
+			go func() {
+				if err := ui.DesktopNotification("dry", description); err != nil {
+					once.Do(func() {
+						dry.message(fmt.Sprintf("<red>Error showing desktop notification:</> %s", err.Error()))
+					})
+				}
+			}()
+		}
+	}
+}
+
+//warnAboutDaemon shows the daemon warnings, if any, as a message, or the
+//features not available if the daemon is Podman
+func warnAboutDaemon(dry *Dry) {
+	if notice := podmanNotice(dry.dockerDaemon); notice != "" {
+		dry.message(notice)
+		return
+	}
+	warnings, err := dry.dockerDaemon.DaemonWarnings()
+	if err != nil || len(warnings) == 0 {
+		return
+	}
+	dry.message(fmt.Sprintf("<red>WARNING:</> <white>%s</> (F10 for details)", strings.Join(warnings, "; ")))
+}
+
+//markContainerEvents marks container events on the monitor, so they can be
+//correlated with resource usage
+func markContainerEvents(m *appui.Monitor) {
+	docker.GlobalRegistry.Register(
+		docker.ContainerSource,
+		func(ctx context.Context, message events.Message) error {
+			m.MarkEvent(message.Actor.ID, message.Action)
+			return nil
+		})
+}
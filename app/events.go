@@ -33,45 +33,37 @@ func (b *baseEventHandler) handle(event *tcell.EventKey, f func(eventHandler)) {
 		cursor.ScrollCursorUp()
 	case tcell.KeyDown, tcell.KeyCtrlN: // cursor down
 		cursor.ScrollCursorDown()
+	case tcell.KeyF6: // switch Docker context
+		refresh = false
+		showContextSwitcher(dry, f)
 	case tcell.KeyF7: // toggle show header
 		dry.toggleShowHeader()
 	case tcell.KeyF8: // disk usage
 		f(viewsToHandlers[DiskUsage])
-		dry.changeView(DiskUsage)
-		if du, err := b.dry.dockerDaemon.DiskUsage(); err == nil {
-			widgets.DiskUsage.PrepareToRender(&du, nil)
-		}
+		showDiskUsage(dry)
 	case tcell.KeyF9: // docker events
 		refresh = false
-		view := dry.viewMode()
-		dry.changeView(EventsMode)
+		dry.pushView(EventsMode)
 		eh := newEventForwarder()
 		f(eh)
 
-		renderer := appui.NewDockerEventsRenderer(dry.dockerDaemon.EventLog().Events())
-
-		go appui.Less(renderer.String(), screen, eh.events(), func() {
-			dry.changeView(view)
-			f(viewsToHandlers[view])
-			refreshScreen()
+		go appui.StreamEvents(dry.dockerDaemon.EventLog(), eh.events(), func() {
+			dry.goBack(f)
 		})
 	case tcell.KeyF10: // docker info
 		refresh = false
 
-		view := dry.viewMode()
-		dry.changeView(InfoMode)
-
 		info, err := dry.dockerDaemon.Info()
 		if err == nil {
+			dry.pushView(InfoMode)
 			eh := newEventForwarder()
 			f(eh)
 
-			renderer := appui.NewDockerInfoRenderer(info)
+			warnings, _ := dry.dockerDaemon.DaemonWarnings()
+			renderer := appui.NewDockerInfoRendererWithWarnings(info, warnings)
 
 			go appui.Less(renderer.String(), screen, eh.events(), func() {
-				dry.changeView(view)
-				f(viewsToHandlers[view])
-				refreshScreen()
+				dry.goBack(f)
 			})
 		} else {
 			dry.message(
@@ -87,50 +79,86 @@ func (b *baseEventHandler) handle(event *tcell.EventKey, f func(eventHandler)) {
 	case '?', 'h', 'H': //help
 		refresh = false
 
-		view := dry.viewMode()
+		dry.pushView(HelpMode)
 		eh := newEventForwarder()
 		f(eh)
-		go appui.Less(help, screen, eh.events(), func() {
-			dry.changeView(view)
-			f(viewsToHandlers[view])
-			refreshScreen()
+		go appui.Less(dry.help(), screen, eh.events(), func() {
+			dry.goBack(f)
 		})
 	case '1':
-		cursor.Reset()
 		f(viewsToHandlers[Main])
-		dry.changeView(Main)
+		dry.switchView(Main)
 	case '2':
-		cursor.Reset()
 		f(viewsToHandlers[Images])
-		dry.changeView(Images)
+		dry.switchView(Images)
 	case '3':
-		cursor.Reset()
 		f(viewsToHandlers[Networks])
-		dry.changeView(Networks)
+		dry.switchView(Networks)
 	case '4':
-		cursor.Reset()
 		f(viewsToHandlers[Volumes])
-		dry.changeView(Volumes)
+		dry.switchView(Volumes)
 	case '5':
-		cursor.Reset()
-		f(viewsToHandlers[Nodes])
-		dry.changeView(Nodes)
+		if dry.supports(Nodes) {
+			f(viewsToHandlers[Nodes])
+			dry.switchView(Nodes)
+		}
 	case '6':
-		cursor.Reset()
-		f(viewsToHandlers[Services])
-		dry.changeView(Services)
+		if dry.supports(Services) {
+			f(viewsToHandlers[Services])
+			dry.switchView(Services)
+		}
 	case '7':
-		cursor.Reset()
-		f(viewsToHandlers[Stacks])
-		dry.changeView(Stacks)
+		if dry.supports(Stacks) {
+			f(viewsToHandlers[Stacks])
+			dry.switchView(Stacks)
+		}
+	case '8':
+		if dry.supports(SwarmManagement) {
+			f(viewsToHandlers[SwarmManagement])
+			dry.switchView(SwarmManagement)
+		}
+	case '9':
+		if dry.supports(Plugins) {
+			f(viewsToHandlers[Plugins])
+			dry.switchView(Plugins)
+		}
+	case '0':
+		refresh = false
+		showHosts(dry, f)
 	case 'm', 'M': //monitor mode
-		cursor.Reset()
 		f(viewsToHandlers[Monitor])
-		dry.changeView(Monitor)
+		dry.switchView(Monitor)
 	case 'g': //Cursor to the top
 		cursor.Reset()
 	case 'G': //Cursor to the bottom
 		cursor.Bottom()
+	case 'u': //undo the last removal
+		dry.undoRemoval()
+	case 'K': //export keybindings
+		refresh = false
+		rw := appui.NewPrompt(
+			fmt.Sprintf("Export keybindings to file? (blank for %s)", defaultCheatSheetFile))
+		widgets.add(rw)
+		forwarder := newEventForwarder()
+		f(forwarder)
+		refreshScreen()
+		go func() {
+			rw.OnFocus(newEventSource(forwarder.events()))
+			widgets.remove(rw)
+			path, canceled := rw.Text()
+			f(viewsToHandlers[dry.viewMode()])
+			if !canceled {
+				if path == "" {
+					path = defaultCheatSheetFile
+				}
+				if err := writeCheatSheet(path, dry.help()); err != nil {
+					dry.message("There was an error exporting keybindings: " + err.Error())
+				} else {
+					dry.message(fmt.Sprintf("Keybindings exported to %s", path))
+				}
+			}
+			refreshScreen()
+		}()
 	}
 	if refresh {
 		refreshScreen()
@@ -146,6 +174,13 @@ func initHandlers(dry *Dry, screen *ui.Screen) map[viewMode]eventHandler {
 				screen: screen,
 			},
 		},
+		ContainerFiles: &containerFilesEventHandler{
+			baseEventHandler{
+				dry:    dry,
+				screen: screen,
+			},
+			widgets.ContainerFiles,
+		},
 		Images: &imagesScreenEventHandler{
 			baseEventHandler{
 				dry:    dry,
@@ -166,6 +201,36 @@ func initHandlers(dry *Dry, screen *ui.Screen) map[viewMode]eventHandler {
 				screen: screen,
 			},
 		},
+		Ownership: &ownershipScreenEventHandler{
+			baseEventHandler{
+				dry:    dry,
+				screen: screen,
+			},
+		},
+		Hosts: &hostsScreenEventHandler{
+			baseEventHandler{
+				dry:    dry,
+				screen: screen,
+			},
+		},
+		ImageLayers: &imageLayersScreenEventHandler{
+			baseEventHandler{
+				dry:    dry,
+				screen: screen,
+			},
+		},
+		NetworkContainers: &networkContainersScreenEventHandler{
+			baseEventHandler{
+				dry:    dry,
+				screen: screen,
+			},
+		},
+		StatsPlayback: &statsPlaybackScreenEventHandler{
+			baseEventHandler{
+				dry:    dry,
+				screen: screen,
+			},
+		},
 		Main: &containersScreenEventHandler{
 			baseEventHandler{
 				dry:    dry,
@@ -222,6 +287,20 @@ func initHandlers(dry *Dry, screen *ui.Screen) map[viewMode]eventHandler {
 			},
 			widgets.StackTasks,
 		},
+		SwarmManagement: &swarmScreenEventHandler{
+			baseEventHandler{
+				dry:    dry,
+				screen: screen,
+			},
+			widgets.SwarmManagement,
+		},
+		Plugins: &pluginsScreenEventHandler{
+			baseEventHandler{
+				dry:    dry,
+				screen: screen,
+			},
+			widgets.Plugins,
+		},
 		Volumes: &volumesScreenEventHandler{
 			baseEventHandler{
 				dry:    dry,
@@ -3,6 +3,7 @@ package app
 import (
 	"fmt"
 
+	"github.com/gdamore/tcell"
 	"github.com/moncho/dry/appui"
 	"github.com/moncho/dry/ui"
 )
@@ -19,3 +20,30 @@ func showFilterInput(es ui.EventSource, onDone func(string, bool)) {
 		onDone(rw.Text())
 	}()
 }
+
+//showIncrementalFilterInput shows a prompt that filters the given widget
+//as the filter is typed on it, the filter that was in place is restored if
+//the prompt is canceled
+func showIncrementalFilterInput(es ui.EventSource, w appui.IncrementalFilterableWidget, onDone func()) {
+	current := w.FilterPattern()
+	rw := appui.NewPromptWithText("Filter? (blank to remove current filter)", current)
+	widgets.add(rw)
+	refresh := es.EventHandledCallback
+	es.EventHandledCallback = func(e *tcell.EventKey) error {
+		if e.Key() == tcell.KeyEsc {
+			w.Filter(current)
+		} else {
+			filter, _ := rw.Text()
+			w.Filter(filter)
+		}
+		return refresh(e)
+	}
+	go func() {
+		err := rw.OnFocus(es)
+		if err != nil {
+			fmt.Println(err)
+		}
+		widgets.remove(rw)
+		onDone()
+	}()
+}
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"sync"
+)
+
+//focusState tracks if the terminal dry runs on is focused, refreshes
+//triggered in the background are put off while it is not
+type focusState struct {
+	sync.Mutex
+	unfocused bool
+	//pending are the refreshes put off, by the view they refresh
+	pending map[viewMode]func()
+}
+
+//whenFocused runs the given refresh of the given view right away if the
+//terminal is focused, otherwise it is run once the terminal is focused
+//again, replacing any other refresh of the view put off before
+func (d *Dry) whenFocused(v viewMode, refresh func()) {
+	d.focus.Lock()
+	if d.focus.unfocused {
+		if d.focus.pending == nil {
+			d.focus.pending = make(map[viewMode]func())
+		}
+		d.focus.pending[v] = refresh
+		d.focus.Unlock()
+		return
+	}
+	d.focus.Unlock()
+	refresh()
+}
+
+//setFocused changes the focus state of the terminal, refreshes put off
+//while it was not focused are run as soon as it is focused
+func (d *Dry) setFocused(focused bool) {
+	if d.refreshUnfocused {
+		return
+	}
+	d.focus.Lock()
+	d.focus.unfocused = !focused
+	pending := d.focus.pending
+	if focused {
+		d.focus.pending = nil
+	}
+	d.focus.Unlock()
+
+	widgets.Monitor.SetIdle(!focused)
+	if !focused {
+		if d.viewMode() == Monitor {
+			widgets.Monitor.OnEvent(nil)
+		}
+		return
+	}
+	for _, refresh := range pending {
+		refresh()
+	}
+	refreshScreen()
+}
@@ -0,0 +1,27 @@
+package app
+
+import "testing"
+
+func TestWhenFocused(t *testing.T) {
+	d := &Dry{}
+	var refreshed []string
+	d.whenFocused(Main, func() { refreshed = append(refreshed, "containers") })
+	if len(refreshed) != 1 {
+		t.Fatalf("Refresh not run right away while focused: %v", refreshed)
+	}
+
+	d.focus.unfocused = true
+	d.whenFocused(Main, func() { refreshed = append(refreshed, "containers") })
+	d.whenFocused(Images, func() { refreshed = append(refreshed, "images") })
+	d.whenFocused(Images, func() { refreshed = append(refreshed, "images again") })
+	if len(refreshed) != 1 {
+		t.Errorf("Refreshes run while not focused: %v", refreshed)
+	}
+	if len(d.focus.pending) != 2 {
+		t.Fatalf("Unexpected refreshes put off: %d", len(d.focus.pending))
+	}
+	d.focus.pending[Images]()
+	if refreshed[len(refreshed)-1] != "images again" {
+		t.Errorf("The last refresh of a view put off is not the one kept: %v", refreshed)
+	}
+}
@@ -0,0 +1,116 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+)
+
+//runHealthcheck runs the healthcheck of the given container and shows its
+//result, going back to the active view once the result is closed
+func runHealthcheck(dry *Dry, screen *ui.Screen, container *docker.Container, h eventHandler, f func(eventHandler)) {
+	name := containerName(container)
+	dry.message(fmt.Sprintf("Running healthcheck of container <white>%s</>", name))
+	go func() {
+		result, err := dry.dockerDaemon.RunHealthcheck(container.ID)
+		if err != nil {
+			dry.message(fmt.Sprintf("<red>Error running healthcheck of container %s: %s</>", name, err.Error()))
+			return
+		}
+		forwarder := newEventForwarder()
+		f(forwarder)
+		dry.pushView(NoView)
+		appui.Less(healthcheckReport(name, result), screen, forwarder.events(), func() {
+			dry.popView()
+			f(h)
+			refreshScreen()
+		})
+	}()
+}
+
+//healthcheckReport describes the given healthcheck result
+func healthcheckReport(name string, result docker.HealthcheckResult) string {
+	var buf bytes.Buffer
+	status := "<green>healthy</>"
+	if !result.Healthy() {
+		status = "<red>unhealthy</>"
+	}
+	fmt.Fprintf(&buf, "<yellow>Healthcheck of container</> <white>%s</>: %s\n", name, status)
+	fmt.Fprintf(&buf, "<yellow>Exit code:</> %d\n", result.ExitCode)
+	fmt.Fprintf(&buf, "<yellow>Duration:</> %s\n\n", result.Duration.Round(time.Millisecond))
+	if output := strings.TrimRight(result.Output, "\n"); output != "" {
+		buf.WriteString(output)
+	} else {
+		buf.WriteString("<darkgrey>No output</>")
+	}
+	return buf.String()
+}
+
+//showHealthLog shows the last healthcheck results Docker recorded for the
+//given container, going back to the active view once closed
+func showHealthLog(dry *Dry, screen *ui.Screen, container *docker.Container, h eventHandler, f func(eventHandler)) error {
+	c, err := dry.dockerDaemon.Inspect(container.ID)
+	if err != nil {
+		return err
+	}
+	var health *types.Health
+	if c.ContainerJSONBase != nil && c.State != nil {
+		health = c.State.Health
+	}
+	forwarder := newEventForwarder()
+	f(forwarder)
+	dry.pushView(NoView)
+	go appui.Less(healthLogReport(containerName(container), health), screen, forwarder.events(), func() {
+		dry.popView()
+		f(h)
+		refreshScreen()
+	})
+	return nil
+}
+
+//healthLogReport describes the given health, with the healthcheck results
+//recorded, the latest first
+func healthLogReport(name string, health *types.Health) string {
+	var buf bytes.Buffer
+	if health == nil || health.Status == "" || health.Status == types.NoHealthcheck {
+		fmt.Fprintf(&buf, "<yellow>Container</> <white>%s</> has no healthcheck", name)
+		return buf.String()
+	}
+	status := "<yellow>" + health.Status + "</>"
+	switch health.Status {
+	case types.Healthy:
+		status = "<green>" + health.Status + "</>"
+	case types.Unhealthy:
+		status = "<red>" + health.Status + "</>"
+	}
+	fmt.Fprintf(&buf, "<yellow>Health of container</> <white>%s</>: %s\n", name, status)
+	fmt.Fprintf(&buf, "<yellow>Failing streak:</> %d\n", health.FailingStreak)
+	if len(health.Log) == 0 {
+		buf.WriteString("\n<darkgrey>No healthcheck run yet</>")
+		return buf.String()
+	}
+	for i := len(health.Log) - 1; i >= 0; i-- {
+		result := health.Log[i]
+		if result == nil {
+			continue
+		}
+		exitCode := fmt.Sprintf("<green>%d</>", result.ExitCode)
+		if result.ExitCode != 0 {
+			exitCode = fmt.Sprintf("<red>%d</>", result.ExitCode)
+		}
+		fmt.Fprintf(&buf, "\n<white>%s</> <yellow>exit code:</> %s <yellow>duration:</> %s\n",
+			result.Start.Format(time.RFC3339), exitCode, result.End.Sub(result.Start).Round(time.Millisecond))
+		if output := strings.TrimRight(result.Output, "\n"); output != "" {
+			buf.WriteString(output + "\n")
+		} else {
+			buf.WriteString("<darkgrey>No output</>\n")
+		}
+	}
+	return buf.String()
+}
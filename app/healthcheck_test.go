@@ -0,0 +1,33 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+)
+
+func TestHealthLogReport(t *testing.T) {
+	if report := healthLogReport("web", nil); !strings.Contains(report, "has no healthcheck") {
+		t.Errorf("Unexpected report of a container without healthcheck: %s", report)
+	}
+	start := time.Date(2020, 4, 1, 10, 0, 0, 0, time.UTC)
+	health := &types.Health{
+		Status:        types.Unhealthy,
+		FailingStreak: 1,
+		Log: []*types.HealthcheckResult{
+			{Start: start, End: start.Add(20 * time.Millisecond), ExitCode: 0, Output: "ok\n"},
+			{Start: start.Add(time.Minute), End: start.Add(time.Minute + time.Second), ExitCode: 1},
+		},
+	}
+	report := healthLogReport("web", health)
+	for _, want := range []string{"<red>unhealthy</>", "Failing streak:</> 1", "exit code:</> <red>1</>", "duration:</> 20ms", "ok\n", "No output"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Health report does not contain %q:\n%s", want, report)
+		}
+	}
+	if strings.Index(report, "2020-04-01T10:01:00Z") > strings.Index(report, "2020-04-01T10:00:00Z") {
+		t.Errorf("The latest healthcheck result is not shown first:\n%s", report)
+	}
+}
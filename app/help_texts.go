@@ -21,9 +21,12 @@ A tool to interact with a Docker Daemon from the terminal.
 Visit <blue>http://moncho.github.io/dry/</> for more information.
 
 <yellow>Global keybinds</>
+	<white>F6</>        Switches to another Docker context or configured host, reconnecting to it
 	<white>F7</>        Toggles showing Docker daemon information
-	<white>F8</>        Shows Docker disk usage
-	<white>F9</>        Shows the last 10 events reported by Docker
+	<white>F8</>        Shows Docker disk usage, F5 on it computes it again and o shows it by owner.
+	          Tab lists, biggest first, images, containers, volumes or build cache, Ctrl+e removes the selected one.
+	          b prunes the build cache not in use, optionally keeping the most recently used up to a given size
+	<white>F9</>        Shows the events reported by Docker as they arrive
 	<white>F10</>       Inspects Docker
 	<white>1</>         To container list
 	<white>2</>         To image list
@@ -32,8 +35,13 @@ Visit <blue>http://moncho.github.io/dry/</> for more information.
 	<white>5</>         To node list (in Swarm mode)
 	<white>6</>         To service list (in Swarm mode)
 	<white>7</>         To stack list (in Swarm mode)
+	<white>8</>         To swarm management
+	<white>9</>         To plugin list
+	<white>0</>         To the Docker hosts dashboard, with the state of every context and configured host
 	<white>m</>         Show container monitor mode
 	<white>h</>         Shows this help screen
+	<white>K</>         Exports keybindings as a cheat sheet to a file
+	<white>u</>         Undoes the last container or image removal, while it is still pending
 	<white>Ctrl+c</>    Quits <white>dry</> immediately
 	<white>Q</>         Quits <white>dry</>
 	<white>esc</>       Goes back to the main screen
@@ -41,59 +49,194 @@ Visit <blue>http://moncho.github.io/dry/</> for more information.
 <yellow>Global list keybinds</>	
 	<white>F1</>        Cycles through sort modes
 	<white>F5</>        Refreshes the list
-	<white>%</>         Filter
+	<white>%</>         Filter, containers, images, networks, plugins, services and nodes are fuzzy filtered as you type
 
 <yellow>Container list keybinds</>
 	<white>F2</>        Toggles showing all containers (default shows just running)
+	<white>F3</>        Toggles grouping containers by image, showing how many containers run each image
+	<white>F4</>        Toggles grouping containers by compose project or stack, showing how many of each group run
+	<white>Space</>     Collapses, or expands, the group of the selected container
 	<white>e</>         Removes the selected container
 	<white>Ctrl+e</>    Removes all stopped containers
 	<white>Ctrl+k</>    Kills the selected container
 	<white>l</>         Displays the logs of the selected container
+	<white>c</>         Displays the logs of several containers, up to 4, on stacked panes
 	<white>Ctrl+r</>    Restarts selected container
+	<white>R</>         Recreates the selected container with the same configuration, answering with 'pull' added pulls its image first
+	<white>U</>         Changes the CPU shares and quota, memory limit and restart policy of the selected container while it runs
+	<white>D</>         Clones the selected container, its image, environment, ports and command can be changed first
 	<white>s</>         Displays a live stream of the selected container resource usage statistics
 	<white>Ctrl+t</>    Stops selected container (noop if it is not running)
+	<white>B</>         Stops, or kills, every running container matching a filter expression, i.e.
+	          name=web-* image=nginx:* label=env=prod action=kill, after a preview
+	<white>b</>         Stops, or kills, every running container of the image of the selected container
+	<white>n</>         Attaches a note to the selected container, shown on its command menu
+	<white>f</>         Shows only the containers with a label, given as key or key=value
+	<white>p</>         Shows only the containers of the Docker Compose project of the selected container, again to show all
+	<white>t</>         Runs the healthcheck of the selected container now, showing its output and exit code
+	<white>T</>         Shows the last healthcheck results of the selected container, with their output and exit code
+	<white>a</>         Shows the events of the selected container since it was created (create, start, die, restart,
+	          health_status...) in chronological order, followed by new ones as they happen
+	<white>r</>         Shows the docker run command recreating the selected container, copying it to the clipboard
+	<white>P</>         Shows the ports of the selected container, the host address of each one can be copied
+	          to the clipboard with 'c', and the ports published by other containers too
+	<white>C</>         Exports the selected container, or the ones shown, as a compose file with its networks and volumes
+	<white>x</>         Exports the logs of the selected container to a file
 	<white>Enter</>     Shows low-level information of the selected container
+	<white>v</>         Shows the environment, mounts, devices and labels of the selected container, values of
+	          secrets (keys with SECRET, TOKEN, PASSWORD and the like) are masked until 'v' is pressed
+	<white>y</>         Copies the ID, name, IP addresses or inspect JSON of the selected container to the clipboard
+	<white>w</>         Compares the inspect documents of the selected container and another one, side by side
+
+<yellow>Container files keybinds</> (Browse files, on the container commands menu)
+	<white>Enter</>     Opens the selected directory, or shows the selected text file
+	<white>Backspace</> Goes to the parent directory
+	<white>esc</>       Goes back to the container commands menu
 
 <yellow>Image list keybinds</>
+	<white>F2</>        Shows all, dangling or unused images in turn, with the disk space their removal reclaims
 	<white>Ctrl+d</>    Removes dangling images
-	<white>Ctrl+e</>    Removes the selected image
-	<white>Ctrl+f</>    Forces removal of the selected image
+	<white>Ctrl+e</>    Removes the selected image, or the marked images if any, warning if containers use them
+	<white>Ctrl+f</>    Forces removal of the selected image, or of the marked images if any, warning if containers use them
 	<white>Ctrl+u</>    Removes unused images
-	<white>i</>         Shows image history
+	<white>i</>         Shows image history, layer by layer, with the other images sharing each layer,
+	          Enter on a layer shows its details and full command
+	<white>c</>         Lists the containers, running and stopped, created from the selected image
+	<white>r</>         Runs a new container from the selected image, options are given as docker run does:
+	          --name NAME, -p HOST:CONTAINER, -e KEY=VALUE, -d or -it, and the command
+	<white>p</>         Pulls an image, showing its download size and asking for credentials if the registry requires them
+	<white>O</>         Checks the registry for newer images of the local tags, marking with ↑ the outdated
+	          images and the containers running them
+	<white>U</>         Pulls the newer image of the outdated tags of the selected image
+	<white>Space</>     Marks or unmarks the selected image for removal or export
+	<white>n</>         Attaches a note to the selected image, shown when inspecting it
+	<white>x</>         Exports the selected image, or the marked images if any, as a docker save tar or an OCI image layout
+	<white>y</>         Copies the ID, name or inspect JSON of the selected image to the clipboard
+	<white>w</>         Compares the inspect documents of the selected image and another one, a marked one is suggested
 	<white>Enter</>     Shows low-level information of the selected image
 
 <yellow>Network list keybinds</>
-	<white>Enter</>     Shows low-level information of the selected network
+	<white>n</>         Creates a network, given as docker network create takes it: name, driver, subnet, gateway,
+	          internal, attachable and labels
+	<white>Enter</>     Shows the selected network and the containers attached to it, Enter on a container shows it on
+	          the container list. 'i' inspects the network: 'c' connects a container to it, asking for its
+	          aliases and static IP, and 'd' disconnects a container from it
+
+<yellow>Plugin list keybinds</>
+	<white>e</>         Enables the selected plugin
+	<white>d</>         Disables the selected plugin
+	<white>D</>         Disables the selected plugin, even if it is in use
+	<white>Enter</>     Shows low-level information of the selected plugin
 
 <yellow>Node list keybinds</>
 	<white>Enter</>     Shows the list of tasks running on the selected node
+	<white>Ctrl+A</>    Changes the availability of the selected node (active, pause or drain)
+	<white>Ctrl+O</>    Changes the role of the selected node (manager or worker)
+	<white>i</>         Shows the engine description, plugins and resources of the selected node
+	<white>L</>         Edits the labels of the selected node
+	<white>P</>         Pulls an image on every node, or on the nodes with the given label, using a helper global service
+	<white>C</>         Rotates the root CA of the swarm, renewing the certificates of every node
 
 <yellow>Service list keybinds</>
 	<white>Enter</>     Shows the list of tasks that are part of the selected service
-	<white>l</>         Displays the logs of the selected service
+	<white>l</>         Displays the logs of the selected service, prefixed with the task slot and node of each line,
+	          'T' shows only the output of one task
+	<white>L</>         Edits the labels of the selected service
+	<white>P</>         Edits the placement constraints and preferences of the selected service
+	<white>D</>         Resolves the selected service names (VIP and DNSRR records) from one of its networks
+	<white>C</>         Diffs the content of a config of the selected service with a local file
 	<white>Ctrl+R</>    Removes the selected service
-	<white>Ctrl+S</>    Scales the selected service
+	<white>Ctrl+S</>    Scales the selected service, to a number of replicas or to one of its scale presets
+	<white>R</>         Shows the replica history of the selected service
 	<white>Ctrl+U</>    Forces an update of the selected service
+	<white>x</>         Exports the logs of the selected service to a file
 
 <yellow>Stack list keybinds</>
 	<white>Enter</>     Shows the list of services of the selected stack
 	<white>Ctrl+R</>    Removes the selected stack
 	
+<yellow>Task list keybinds</>
+	<white>F2</>        Cycles through all, desired running, running, failed and shutdown tasks
+	<white>Enter</>     Shows low-level information of the selected task
+	<white>l</>         Displays the logs of the container of the selected task, if its node is reachable
+	
+<yellow>Swarm management keybinds</>
+	<white>i</>         Initializes a swarm
+	<white>j</>         Joins a swarm, given a join token and a manager address
+	<white>l</>         Leaves the swarm
+	<white>r</>         Rotates the worker join token
+	<white>R</>         Rotates the manager join token
+	<white>c</>         Copies the command to join as worker to the clipboard
+	<white>C</>         Copies the command to join as manager to the clipboard
+
 <yellow>Move around in lists</>
 	<white>ArrowUp</>   Moves the cursor one line up
 	<white>ArrowDown</> Moves the cursor one line down
 	<white>g</>         Moves the cursor to the beginning of the list
 	<white>G</>         Moves the cursor to the end of the list
 
-<yellow>Move around in logs/inspect buffers</>
+<yellow>Move around in logs buffers</>
 	<white>/</>         Searches for a pattern
 	<white>F</>         Only show lines that matches a pattern
+	<white>f</>         Toggles follow mode, scrolling as new lines arrive
+	<white>a</>         Toggles rendering ANSI colors, escape codes are removed otherwise
+	<white>t</>         Toggles showing timestamps on logs, logs are requested again
+	<white>j</>         Toggles pretty-printing lines with JSON objects
+	<white>J</>         Only show JSON lines with the given field value, e.g. level=error
+	<white>e</>         Only show events matching a filter, e.g. type=container action=die name=web*
+	<white>p</>         Pauses the events stream, or resumes it showing the events held meanwhile
+	<white>#</>         Toggles showing line numbers
+	<white>:</>         Goes to the given line number or percentage of the buffer
 	<white>g</>         Moves the cursor to the beginning
 	<white>G</>         Moves the cursor until the end
 	<white>n</>         After a search, it moves forwards to the next search hit
 	<white>N</>         After a search, it moves backwards to the previous search hit
+	<white>w</>         Saves the loaded buffer to a file
 	<white>pg up</>     Moves the cursor "screen size" lines up
 	<white>pg down</>   Moves the cursor "screen size" lines down
+	<white>Tab</>       Moves the focus to the next pane when the logs of several containers are shown
+
+<yellow>Move around in inspect views</>
+	<white>ArrowUp</>   Selects the previous line, <white>ArrowDown</> the next one
+	<white>Enter</>     Expands or collapses the selected object or array
+	<white>ArrowRight</> Expands the selected object or array, <white>ArrowLeft</> collapses it or selects its parent
+	<white>E</>         Expands every object and array, <white>C</> collapses them
+	<white>/</>         Searches keys and values, expanding what is needed to show the match
+	<white>n</>         After a search, it moves forwards to the next match, <white>N</> backwards
+	<white>p</>         Copies the path of the selected value (i.e. .NetworkSettings.Networks.bridge.IPAddress) to the clipboard
+	<white>v</>         Copies the selected value to the clipboard
+	<white>y</>         Copies the whole JSON to the clipboard
+	<white>f</>         Formats the inspected object with a Go template, as docker inspect --format does
+	          (i.e. {{.NetworkSettings.IPAddress}}), showing the result below and copying it to the clipboard
+
+In monitor mode, <white>CPU HISTORY</>, <white>MEM HISTORY</> and <white>NET HISTORY</> show the recent
+usage of each container, network usage is relative to the highest rate seen. The history is kept
+while the container runs, even when moving to other views. Restarts, OOM kills and health changes
+are marked on the CPU history with <white>┃</>. <white>F1</> sorts containers by CPU, memory, network I/O
+or block I/O usage, heaviest first, and <white>%</> filters them by name or label (i.e. <white>env=prod</>).
+<white>f</> monitors only the containers with a label and <white>p</> those of the Docker Compose project of
+the selected container, pressing it again monitors every container.
+<white>x</> exports the metrics of the containers shown, either the current values or the samples of
+a time window (i.e. <white>window=10m</>), to a CSV or JSON file.
+<white>r</> plays back the stats recorded with <white>--stats-file</>, moving back and forth between samples
+with the arrow keys, to see what the containers were doing when something went wrong.
+
+When the container list refreshes, containers created or started since the previous refresh are
+highlighted in <green>green</> for a few seconds, and those that exited or were removed in <red>red</>.
+
+The container commands menu shows the restart policy of the container, <white>Set restart policy</> changes
+it (i.e. <white>on-failure:5</>) without recreating the container.
+
+Containers and images labeled with <white>dry.protect=true</> are protected, they are left out
+of prunes and bulk removals, and removing or killing them requires typing <white>override</> when asked.
+
+On read-only mode (<white>--read-only</>), shown next to the Docker host, actions changing the Docker host,
+like killing or removing containers, pulling or removing images, pruning or updating services, are disabled.
+
+On Podman, through its Docker-compatible API, shown next to the Docker host, Swarm and plugin views are not available.
+
+If the Docker daemon goes away, dry shows it as unreachable and reconnects once it is back. The right end of
+the status line shows the API version used and the round-trip time to the Docker daemon, pinged every 5 seconds.
 
 <r> Press ESC to exit help. </r>
 `
@@ -101,39 +244,60 @@ Visit <blue>http://moncho.github.io/dry/</> for more information.
 const (
 	commonMappings = "<b>[H]:<darkgrey>Help</> <b>[Q]:<darkgrey>Quit</> <blue>|</> "
 	keyMappings    = commonMappings +
-		"<b>[F1]:<darkgrey>Sort</> <b>[F2]:<darkgrey>Toggle Show Containers</> <b>[F5]:<darkgrey>Refresh</> <b>[%]:<darkgrey>Filter</> <blue>|</> " +
+		"<b>[F1]:<darkgrey>Sort</> <b>[F2]:<darkgrey>Toggle Show Containers</> <b>[F3]:<darkgrey>Group by Image</> <b>[F4]:<darkgrey>Group by Project</> <b>[F5]:<darkgrey>Refresh</> <b>[%]:<darkgrey>Filter</> <b>[f]:<darkgrey>Label Filter</> <b>[p]:<darkgrey>Compose Project</> <blue>|</> " +
 		"<b>[m]:<darkgrey>Monitor mode</> <b>[2]:<darkgrey>Images</> <b>[3]:<darkgrey>Networks</> <b>[4]:<darkgrey>Volumes</> <b>[5]:<darkgrey>Nodes</> <b>[6]:<darkgrey>Services</> <b>[7]:<darkgrey>Stacks</> <blue>|</> <b>[Enter]:<darkgrey>Commands</></>"
 
 	monitorMapping = commonMappings +
-		"<b>[m]:<darkgrey>Monitor mode</> <b>[1]:<darkgrey>Containers</> <b>[2]:<darkgrey>Images</> <b>[3]:<darkgrey>Networks</> <b>[4]:<darkgrey>Volumes</> <b>[5]:<darkgrey>Nodes</> <b>[6]:<darkgrey>Services</> <b>[7]:<darkgrey>Stacks</> <blue>|</> <b>[s]:<darkgrey>Set refresh rate</></>"
+		"<b>[m]:<darkgrey>Monitor mode</> <b>[1]:<darkgrey>Containers</> <b>[2]:<darkgrey>Images</> <b>[3]:<darkgrey>Networks</> <b>[4]:<darkgrey>Volumes</> <b>[5]:<darkgrey>Nodes</> <b>[6]:<darkgrey>Services</> <b>[7]:<darkgrey>Stacks</> <blue>|</> <b>[F1]:<darkgrey>Sort</> <b>[%]:<darkgrey>Filter</> <b>[f]:<darkgrey>Label Filter</> <b>[p]:<darkgrey>Compose Project</> <b>[s]:<darkgrey>Set refresh rate</> <b>[x]:<darkgrey>Export</> <b>[r]:<darkgrey>Recorded Stats</></>"
 
 	swarmMapping = commonMappings +
 		"<b>[m]:<darkgrey>Monitor mode</> <b>[1]:<darkgrey>Containers</> <b>[2]:<darkgrey>Images</> <b>[3]:<darkgrey>Networks</> <b>[4]:<darkgrey>Volumes</> <b>[5]:<darkgrey>Nodes</> <b>[6]:<darkgrey>Services</> <b>[7]:<darkgrey>Stacks</>"
 
 	imagesKeyMappings = commonMappings +
-		"<b>[F1]:<darkgrey>Sort</> <b>[F5]:<darkgrey>Refresh</> <blue>|</> " +
+		"<b>[F1]:<darkgrey>Sort</> <b>[F2]:<darkgrey>All/Dangling/Unused</> <b>[F5]:<darkgrey>Refresh</> <blue>|</> " +
 		"<b>[1]:<darkgrey>Containers</> <b>[3]:<darkgrey>Networks</> <b>[4]:<darkgrey>Volumes</> <b>[5]:<darkgrey>Nodes</> <b>[6]:<darkgrey>Services</> <b>[7]:<darkgrey>Stacks</> <blue>|</>" +
-		"<b>[Ctrl+D]:<darkgrey>Remove Dangling</> <b>[Ctrl+E]:<darkgrey>Remove</> <b>[Ctrl+F]:<darkgrey>Force Remove</> <b>[Ctrl+U]:<darkgrey>Remove Unused</> <b>[I]:<darkgrey>History</>"
+		"<b>[Ctrl+D]:<darkgrey>Remove Dangling</> <b>[Ctrl+E]:<darkgrey>Remove</> <b>[Ctrl+F]:<darkgrey>Force Remove</> <b>[Ctrl+U]:<darkgrey>Remove Unused</> <b>[I]:<darkgrey>History</> <b>[C]:<darkgrey>Containers</> <b>[P]:<darkgrey>Pull</> <b>[O]:<darkgrey>Check Updates</> <b>[U]:<darkgrey>Pull Newer</> <b>[x]:<darkgrey>Export</>"
 
 	networkKeyMappings = commonMappings +
 		"<b>[F1]:<darkgrey>Sort</> <b>[F5]:<darkgrey>Refresh</> <blue>|</> " +
 		"<b>[1]:<darkgrey>Containers</> <b>[2]:<darkgrey>Images</> <b>[4]:<darkgrey>Volumes</> <b>[5]:<darkgrey>Nodes</> <b>[6]:<darkgrey>Services</> <b>[7]:<darkgrey>Stacks</> <blue>|</>" +
-		"<b>[Ctrl+E]:<darkgrey>Remove</> <b>[Enter]:<darkgrey>Inspect</>"
+		"<b>[n]:<darkgrey>Create</> <b>[Ctrl+E]:<darkgrey>Remove</> <b>[Enter]:<darkgrey>Inspect</>"
 
 	volumesKeyMappings = commonMappings +
 		"<b>[F1]:<darkgrey>Sort</> <b>[F5]:<darkgrey>Refresh</> <blue>|</> " +
 		"<b>[1]:<darkgrey>Containers</> <b>[2]:<darkgrey>Images</> <b>[3]:<darkgrey>Networks</> <b>[5]:<darkgrey>Nodes</> <b>[6]:<darkgrey>Services</> <b>[7]:<darkgrey>Stacks</> <blue>|</>" +
 		"<b>[Ctrl+A]:<darkgrey>Remove All</> <b>[Ctrl+E]:<darkgrey>Remove</> <b>[Ctrl+F]:<darkgrey>Force Remove</> <b>[Ctrl+U]:<darkgrey>Remove Unused</> <b>[Enter]:<darkgrey>Inspect</>"
 
+	pluginsKeyMappings = commonMappings +
+		"<b>[F1]:<darkgrey>Sort</> <b>[F5]:<darkgrey>Refresh</> <b>[%]:<darkgrey>Filter</> <blue>|</> " +
+		"<b>[1]:<darkgrey>Containers</> <b>[2]:<darkgrey>Images</> <b>[3]:<darkgrey>Networks</> <b>[4]:<darkgrey>Volumes</> <blue>|</>" +
+		"<b>[e]:<darkgrey>Enable</> <b>[d]:<darkgrey>Disable</> <b>[D]:<darkgrey>Force Disable</> <b>[Enter]:<darkgrey>Inspect</>"
+
 	diskUsageKeyMappings = commonMappings +
 		"<b>[1]:<darkgrey>Containers</> <b>[2]:<darkgrey>Images</><blue>|</> <b>[3]:<darkgrey>Networks</> <b>[4]:<darkgrey>Volumes</> <b>[5]:<darkgrey>Nodes</> <b>[6]:<darkgrey>Services</> <b>[7]:<darkgrey>Stacks</> <blue>|</>" +
-		"<b>[p]:<darkgrey>Prune</>"
+		"<b>[F5]:<darkgrey>Refresh</> <b>[p]:<darkgrey>Prune</> <b>[b]:<darkgrey>Prune Build Cache</> <b>[o]:<darkgrey>Usage by Owner</> <b>[Tab]:<darkgrey>Images/Containers/Volumes/Build Cache</> <b>[Ctrl+E]:<darkgrey>Remove</>"
 
-	serviceKeyMappings = swarmMapping + "<blue>|</> <b>[F1]:<darkgrey>Sort</> <b>[F5]:<darkgrey>Refresh</> <b>[%]:<darkgrey>Filter</> <blue>|</> <b>[l]:<darkgrey>Service logs</> <b>[Ctrl+R]:<darkgrey>Remove Service</> <b>[Ctrl+S]:<darkgrey>Scale service</><b>[Ctrl+U]:<darkgrey>Update service</>"
+	serviceKeyMappings = swarmMapping + "<blue>|</> <b>[F1]:<darkgrey>Sort</> <b>[F5]:<darkgrey>Refresh</> <b>[%]:<darkgrey>Filter</> <blue>|</> <b>[l]:<darkgrey>Service logs</> <b>[L]:<darkgrey>Labels</> <b>[P]:<darkgrey>Placement</> <b>[x]:<darkgrey>Export logs</> <b>[D]:<darkgrey>DNS lookup</> <b>[C]:<darkgrey>Diff config</> <b>[Ctrl+R]:<darkgrey>Remove Service</> <b>[Ctrl+S]:<darkgrey>Scale service</> <b>[R]:<darkgrey>Replica history</><b>[Ctrl+U]:<darkgrey>Update service</>"
 
 	stackKeyMappings = swarmMapping + "<blue>|</> <b>[F1]:<darkgrey>Sort</> <b>[F5]:<darkgrey>Refresh</> <b>[%]:<darkgrey>Filter</> <blue>|</> <b>[Ctrl+R]:<darkgrey>Remove Stack</>"
 
-	nodeKeyMappings = swarmMapping + " <blue>|</> <b>[F1]:<darkgrey>Sort</> <b>[F5]:<darkgrey>Refresh</> <blue>|</>  <b>[Enter]:<darkgrey>Show Node Tasks</> <b>[Ctrl+A]:<darkgrey>Set Availability</>"
+	nodeKeyMappings = swarmMapping + " <blue>|</> <b>[F1]:<darkgrey>Sort</> <b>[F5]:<darkgrey>Refresh</> <blue>|</>  <b>[Enter]:<darkgrey>Show Node Tasks</> <b>[Ctrl+A]:<darkgrey>Set Availability</> <b>[Ctrl+O]:<darkgrey>Set Role</> <b>[i]:<darkgrey>Info</> <b>[L]:<darkgrey>Labels</> <b>[P]:<darkgrey>Pre-pull Image</> <b>[C]:<darkgrey>Rotate CA</>"
+
+	taskKeyMappings = swarmMapping + " <blue>|</> <b>[F1]:<darkgrey>Sort</> <b>[F2]:<darkgrey>State Filter</> <b>[F5]:<darkgrey>Refresh</> <b>[%]:<darkgrey>Filter</> <blue>|</> <b>[Enter]:<darkgrey>Inspect</> <b>[l]:<darkgrey>Task logs</>"
+
+	swarmManagementKeyMappings = swarmMapping + " <blue>|</> <b>[F5]:<darkgrey>Refresh</> <blue>|</> <b>[i]:<darkgrey>Init</> <b>[j]:<darkgrey>Join</> <b>[l]:<darkgrey>Leave</> <b>[r/R]:<darkgrey>Rotate Token</> <b>[c/C]:<darkgrey>Copy Join Command</>"
+
+	ownershipKeyMappings = "<b>[Esc]:<darkgrey>Back</> <b>[F5]:<darkgrey>Refresh</> <b>[x]:<darkgrey>Export</>"
+
+	imageLayersKeyMappings = "<b>[Esc]:<darkgrey>Back</> <b>[Up/Down]:<darkgrey>Select Layer</> <b>[Enter]:<darkgrey>Layer Details</>"
+
+	networkContainersKeyMappings = "<b>[Esc]:<darkgrey>Back</> <b>[F5]:<darkgrey>Refresh</> <b>[Up/Down]:<darkgrey>Select Container</> <b>[Enter]:<darkgrey>Show Container</> <b>[i]:<darkgrey>Inspect</>"
+
+	hostsKeyMappings = "<b>[Esc]:<darkgrey>Back</> <b>[F5]:<darkgrey>Refresh</> <b>[Enter]:<darkgrey>Switch To Host</>"
+
+	statsPlaybackKeyMappings = "<b>[Esc]:<darkgrey>Back</> <b>[Left/Right]:<darkgrey>Previous/Next Sample</> <b>[PgUp/PgDn]:<darkgrey>10 Samples Back/Forward</> <b>[Home/End]:<darkgrey>Oldest/Latest</> <b>[F5]:<darkgrey>Reload</>"
+
+	containerFilesKeyMappings = "<b>[Esc]:<darkgrey>Back</> <b>[F5]:<darkgrey>Refresh</> <b>[%]:<darkgrey>Filter</> <blue>|</> <b>[Enter]:<darkgrey>Open</> <b>[Backspace]:<darkgrey>Parent Directory</>"
 
 	commandsMenuBar = "<b>[Esc]:<darkgrey>Back</> <b>[Up]:<darkgrey>Cursor Up</> <b>[Down]:<darkgrey>Cursor Down</> <b>[Enter]:<darkgrey>Execute Command</>"
 )
@@ -0,0 +1,194 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/events"
+	"github.com/moncho/dry/docker"
+)
+
+//hookAction is what a hook does when an event matching its filter arrives
+type hookAction string
+
+const (
+	//hookRefresh refreshes the lists shown by dry
+	hookRefresh = hookAction("refresh")
+	//hookNotify shows the event as a message
+	hookNotify = hookAction("notify")
+	//hookRun runs a command, with the event on its environment and stdin
+	hookRun = hookAction("run")
+
+	//hookTimeout is how long the command of a hook is allowed to run
+	hookTimeout = time.Minute
+	//hookRefreshDelay is how long a refresh waits for more events, so a
+	//burst of events causes a single refresh
+	hookRefreshDelay = 500 * time.Millisecond
+)
+
+//hook is an action run when a Docker event matching a filter arrives
+type hook struct {
+	expression string
+	filter     docker.EventFilter
+	action     hookAction
+	command    string
+}
+
+//parseHooks parses hooks given as <filter expression> => <action>, the action
+//being one of refresh, notify or run <command>. The filter expression is the
+//one used to filter events, i.e. type=container action=die label=env=prod
+func parseHooks(values []string) ([]hook, error) {
+	var hooks []hook
+	for _, value := range values {
+		i := strings.Index(value, "=>")
+		if i < 0 {
+			return nil, fmt.Errorf("invalid hook %q, expected <filter> => <action>", value)
+		}
+		h := hook{expression: strings.TrimSpace(value[:i])}
+		filter, err := docker.ParseEventFilterExpression(h.expression)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hook %q: %s", value, err)
+		}
+		h.filter = filter
+		action := strings.TrimSpace(value[i+2:])
+		switch {
+		case action == string(hookRefresh), action == string(hookNotify):
+			h.action = hookAction(action)
+		case strings.HasPrefix(action, string(hookRun)+" "):
+			h.action = hookRun
+			h.command = strings.TrimSpace(strings.TrimPrefix(action, string(hookRun)))
+		default:
+			return nil, fmt.Errorf("invalid hook action %q, expected refresh, notify or run <command>", action)
+		}
+		hooks = append(hooks, h)
+	}
+	return hooks, nil
+}
+
+//matches returns true if the given event matches the filter of this hook
+func (h hook) matches(message events.Message) bool {
+	return h.filter == nil || h.filter(message)
+}
+
+//runHooks returns an event listener that runs the hooks matching each event,
+//hooks run on their own goroutine since listeners must not block
+func runHooks(dry *Dry, hooks []hook) func(events.Message) {
+	refresher := &hookRefresher{
+		delay:   hookRefreshDelay,
+		refresh: func() { refreshLists(dry) },
+	}
+	return func(message events.Message) {
+		for _, h := range hooks {
+			if h.matches(message) {
+				go h.run(dry, message, refresher)
+			}
+		}
+	}
+}
+
+//hookRefresher coalesces the refreshes asked for by hooks
+type hookRefresher struct {
+	delay   time.Duration
+	refresh func()
+	//scheduled is true while a refresh is waiting to happen
+	scheduled bool
+	sync.Mutex
+}
+
+//request asks for a refresh, it happens after the delay of the refresher
+//unless one is already waiting, which refreshes for this request as well
+func (r *hookRefresher) request() {
+	r.Lock()
+	defer r.Unlock()
+	if r.scheduled {
+		return
+	}
+	r.scheduled = true
+	time.AfterFunc(r.delay, func() {
+		r.Lock()
+		r.scheduled = false
+		r.Unlock()
+		r.refresh()
+	})
+}
+
+//refreshLists refreshes the Docker objects and the lists showing them
+func refreshLists(dry *Dry) {
+	dry.dockerDaemon.Refresh(func(err error) {
+		if err != nil {
+			dry.message("There was an error refreshing: " + err.Error())
+			return
+		}
+		widgets.ContainerList.Unmount()
+		widgets.ImageList.Unmount()
+		widgets.Networks.Unmount()
+		widgets.Volumes.Unmount()
+		widgets.Plugins.Unmount()
+		widgets.Nodes.Unmount()
+		widgets.ServiceList.Unmount()
+		widgets.Stacks.Unmount()
+		refreshScreen()
+	})
+}
+
+//run runs the action of this hook for the given event, refreshes are
+//requested to the given refresher
+func (h hook) run(dry *Dry, message events.Message, refresher *hookRefresher) {
+	switch h.action {
+	case hookRefresh:
+		refresher.request()
+	case hookNotify:
+		dry.message(fmt.Sprintf("<yellow>Event:</> <white>%s</>", describeEvent(message)))
+	case hookRun:
+		ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+		defer cancel()
+		cmd, err := hookCommand(ctx, h.command, message)
+		if err == nil {
+			err = cmd.Run()
+		}
+		if err != nil {
+			dry.message(fmt.Sprintf("<red>Hook %q failed on %s:</> %s", h.command, describeEvent(message), err.Error()))
+		}
+	}
+}
+
+//hookCommand returns the command to run the given command line through
+//the shell, the event is given on the environment and as JSON on stdin
+func hookCommand(ctx context.Context, command string, message events.Message) (*exec.Cmd, error) {
+	event, err := json.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	cmd.Env = append(os.Environ(),
+		"DRY_EVENT_TYPE="+message.Type,
+		"DRY_EVENT_ACTION="+message.Action,
+		"DRY_EVENT_ID="+message.Actor.ID,
+		"DRY_EVENT_NAME="+message.Actor.Attributes["name"],
+		"DRY_EVENT_IMAGE="+message.Actor.Attributes["image"])
+	cmd.Stdin = bytes.NewReader(event)
+	return cmd, nil
+}
+
+//describeEvent describes the given event as its type, the name of its actor, or
+//its id if there is no name, and its action
+func describeEvent(message events.Message) string {
+	actor := message.Actor.Attributes["name"]
+	if actor == "" {
+		actor = docker.TruncateID(message.Actor.ID)
+	}
+	return fmt.Sprintf("%s %s %s", message.Type, actor, message.Action)
+}
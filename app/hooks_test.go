@@ -0,0 +1,100 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/events"
+)
+
+func Test_parseHooks(t *testing.T) {
+	die := events.Message{Type: events.ContainerEventType, Action: "die",
+		Actor: events.Actor{ID: "1", Attributes: map[string]string{"name": "web", "team": "core"}}}
+	start := events.Message{Type: events.ContainerEventType, Action: "start",
+		Actor: events.Actor{ID: "1", Attributes: map[string]string{"name": "web", "team": "core"}}}
+
+	tests := []struct {
+		value      string
+		action     hookAction
+		command    string
+		matchDie   bool
+		matchStart bool
+		wantErr    bool
+	}{
+		{"action=die label=team=core => run ~/notify.sh --all", hookRun, "~/notify.sh --all", true, false, false},
+		{"type=container=>refresh", hookRefresh, "", true, true, false},
+		{" => notify", hookNotify, "", true, true, false},
+		{"action=die", "", "", false, false, true},
+		{"action=die => restart", "", "", false, false, true},
+		{"action=die => run", "", "", false, false, true},
+		{"status=exited => notify", "", "", false, false, true},
+	}
+	for _, tt := range tests {
+		hooks, err := parseHooks([]string{tt.value})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseHooks(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		h := hooks[0]
+		if h.action != tt.action || h.command != tt.command {
+			t.Errorf("parseHooks(%q) = %s %q, want %s %q", tt.value, h.action, h.command, tt.action, tt.command)
+		}
+		if h.matches(die) != tt.matchDie || h.matches(start) != tt.matchStart {
+			t.Errorf("Hook %q matches die: %v, start: %v", tt.value, h.matches(die), h.matches(start))
+		}
+	}
+}
+
+func Test_hookCommand(t *testing.T) {
+	message := events.Message{Type: events.ContainerEventType, Action: "die",
+		Actor: events.Actor{ID: "1234", Attributes: map[string]string{"name": "web", "image": "nginx"}}}
+	cmd, err := hookCommand(context.Background(), "notify.sh", message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cmd.Args[len(cmd.Args)-1] != "notify.sh" {
+		t.Errorf("Unexpected command: %v", cmd.Args)
+	}
+	env := strings.Join(cmd.Env, "\n")
+	for _, expected := range []string{"DRY_EVENT_TYPE=container", "DRY_EVENT_ACTION=die", "DRY_EVENT_ID=1234", "DRY_EVENT_NAME=web", "DRY_EVENT_IMAGE=nginx"} {
+		if !strings.Contains(env, expected) {
+			t.Errorf("Command environment does not contain %s", expected)
+		}
+	}
+	stdin, _ := ioutil.ReadAll(cmd.Stdin)
+	var got events.Message
+	if err := json.Unmarshal(stdin, &got); err != nil || got.Actor.ID != "1234" {
+		t.Errorf("Event was not given on stdin: %s", stdin)
+	}
+}
+
+func TestHookRefresherCoalescesRefreshes(t *testing.T) {
+	refreshed := make(chan struct{}, 10)
+	refresher := &hookRefresher{
+		delay:   10 * time.Millisecond,
+		refresh: func() { refreshed <- struct{}{} },
+	}
+	for i := 0; i < 5; i++ {
+		refresher.request()
+	}
+	<-refreshed
+	select {
+	case <-refreshed:
+		t.Error("A burst of refresh requests caused more than one refresh")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	refresher.request()
+	select {
+	case <-refreshed:
+	case <-time.After(time.Second):
+		t.Error("A request after the refresh was not refreshed")
+	}
+}
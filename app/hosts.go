@@ -0,0 +1,140 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell"
+	"github.com/moncho/dry/docker"
+)
+
+//hostCheckTimeout is how long the hosts dashboard waits for a Docker host
+const hostCheckTimeout = 10 * time.Second
+
+//ParseNamedHost parses a Docker host given as <name>=<host>
+func ParseNamedHost(s string) (string, string, error) {
+	i := strings.Index(s, "=")
+	if i <= 0 || i == len(s)-1 {
+		return "", "", fmt.Errorf("invalid host %q, expected <name>=<host>", s)
+	}
+	name, host := strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+1:])
+	if name == docker.DefaultContext {
+		return "", "", fmt.Errorf("invalid host name %q, it is the name of the Docker host given on startup", name)
+	}
+	return name, host, nil
+}
+
+//hostEnvs returns the environments of every Docker context and configured
+//host dry can switch to, by name
+func (d *Dry) hostEnvs() (map[string]docker.Env, error) {
+	names, err := d.contextNames()
+	if err != nil {
+		return nil, err
+	}
+	envs := make(map[string]docker.Env)
+	for _, name := range names {
+		env, err := d.contextEnv(name)
+		if err != nil {
+			return nil, err
+		}
+		envs[name] = env
+	}
+	return envs, nil
+}
+
+//checkHosts checks the state of every Docker host in the background
+func checkHosts(dry *Dry) {
+	hosts := widgets.Hosts
+	envs, err := dry.hostEnvs()
+	if err != nil {
+		dry.message(fmt.Sprintf("<red>Error reading Docker hosts:</> %s", err.Error()))
+		return
+	}
+	if !hosts.StartComputing() {
+		return
+	}
+	refreshIfView(Hosts)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), hostCheckTimeout)
+		defer cancel()
+		hosts.DoneComputing(docker.SummarizeHosts(ctx, envs))
+		refreshIfView(Hosts)
+	}()
+}
+
+//showHosts shows the hosts dashboard, checking the state of the hosts
+func showHosts(dry *Dry, f func(eventHandler)) {
+	f(viewsToHandlers[Hosts])
+	dry.pushView(Hosts)
+	widgets.Hosts.SetCurrent(dry.context)
+	checkHosts(dry)
+	refreshScreen()
+}
+
+type hostsScreenEventHandler struct {
+	baseEventHandler
+}
+
+func (h *hostsScreenEventHandler) handle(event *tcell.EventKey, f func(eventHandler)) {
+	handled := true
+	switch event.Key() {
+	case tcell.KeyEsc:
+		h.dry.goBack(f)
+		return
+	case tcell.KeyUp, tcell.KeyCtrlP:
+		widgets.Hosts.MoveSelection(-1)
+	case tcell.KeyDown, tcell.KeyCtrlN:
+		widgets.Hosts.MoveSelection(1)
+	case tcell.KeyF5:
+		checkHosts(h.dry)
+	case tcell.KeyEnter:
+		h.switchToSelected(f)
+		return
+	default:
+		switch event.Rune() {
+		case 'k':
+			widgets.Hosts.MoveSelection(-1)
+		case 'j':
+			widgets.Hosts.MoveSelection(1)
+		default:
+			handled = false
+		}
+	}
+	if handled {
+		refreshScreen()
+	} else {
+		h.baseEventHandler.handle(event, f)
+	}
+}
+
+//switchToSelected connects to the selected host, showing its containers
+func (h *hostsScreenEventHandler) switchToSelected(f func(eventHandler)) {
+	name, ok := widgets.Hosts.Selected()
+	if !ok {
+		return
+	}
+	dry := h.dry
+	if name == dry.context {
+		f(viewsToHandlers[Main])
+		dry.switchView(Main)
+		refreshScreen()
+		return
+	}
+	dry.message(fmt.Sprintf("<white>Connecting to Docker host %s</>", name))
+	go func() {
+		if err := dry.switchContext(name); err != nil {
+			dry.message(fmt.Sprintf("<red>Error switching to Docker host %s:</> %s", name, err.Error()))
+			return
+		}
+		if notice := podmanNotice(dry.dockerDaemon); notice != "" {
+			dry.message(notice)
+		} else {
+			dry.message(fmt.Sprintf("<white>Switched to Docker host %s</>", name))
+		}
+		f(viewsToHandlers[Main])
+		dry.switchView(Main)
+		refreshScreen()
+	}()
+}
@@ -0,0 +1,57 @@
+package app
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/moncho/dry/docker"
+)
+
+func TestParseNamedHost(t *testing.T) {
+	name, host, err := ParseNamedHost("staging=ssh://deploy@staging")
+	if err != nil || name != "staging" || host != "ssh://deploy@staging" {
+		t.Errorf("Unexpected host: %s, %s, %v", name, host, err)
+	}
+	for _, invalid := range []string{"staging", "=tcp://prod:2376", "prod=", "default=tcp://prod:2376"} {
+		if _, _, err := ParseNamedHost(invalid); err == nil {
+			t.Errorf("Invalid host %q parsed", invalid)
+		}
+	}
+}
+
+func TestHostEnvs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dry-hosts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer os.Setenv("DOCKER_CONFIG", os.Getenv("DOCKER_CONFIG"))
+	os.Setenv("DOCKER_CONFIG", dir)
+
+	d := &Dry{
+		config: Config{Hosts: map[string]string{
+			"staging": "ssh://deploy@staging",
+			"prod":    "tcp://prod:2376",
+		}},
+		defaultEnv: docker.Env{DockerHost: "unix:///var/run/docker.sock", DockerCertPath: "/certs"},
+	}
+	names, err := d.contextNames()
+	if err != nil {
+		t.Fatalf("Unexpected error reading hosts: %s", err)
+	}
+	if expected := []string{docker.DefaultContext, "prod", "staging"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("Unexpected host names, got %v, expected %v", names, expected)
+	}
+	envs, err := d.hostEnvs()
+	if err != nil {
+		t.Fatalf("Unexpected error reading hosts: %s", err)
+	}
+	if len(envs) != 3 || envs[docker.DefaultContext].DockerHost != "unix:///var/run/docker.sock" {
+		t.Errorf("Unexpected environments: %+v", envs)
+	}
+	if prod := envs["prod"]; prod.DockerHost != "tcp://prod:2376" || prod.DockerCertPath != "/certs" {
+		t.Errorf("Unexpected environment of a configured host: %+v", prod)
+	}
+}
@@ -2,8 +2,10 @@ package app
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/go-units"
 	"github.com/gdamore/tcell"
 	"github.com/moncho/dry/appui"
 	drydocker "github.com/moncho/dry/docker"
@@ -33,29 +35,12 @@ func (h *imagesScreenEventHandler) handleKeyEvent(key tcell.Key, f func(eventHan
 	switch key {
 	case tcell.KeyF1: //sort
 		h.widget.Sort()
+	case tcell.KeyF2: //cycle through all, dangling and unused images
+		h.dry.message(fmt.Sprintf("<white>Showing %s images</>", h.widget.CycleUsageFilter()))
 	case tcell.KeyF5: // refresh
 		h.widget.Unmount()
 	case tcell.KeyCtrlD: //remove dangling images
-		prompt := appui.NewPrompt("Do you want to remove dangling images? (y/N)")
-		widgets.add(prompt)
-		forwarder := newEventForwarder()
-		f(forwarder)
-		refreshScreen()
-		go func() {
-			events := ui.EventSource{
-				Events: forwarder.events(),
-				EventHandledCallback: func(e *tcell.EventKey) error {
-					return refreshScreen()
-				},
-			}
-			prompt.OnFocus(events)
-			conf, cancel := prompt.Text()
-			f(h)
-			widgets.remove(prompt)
-			if cancel || (conf != "y" && conf != "Y") {
-				return
-			}
-
+		askConfirmation(h, f, "Do you want to remove dangling images?", false, func() {
 			h.dry.message("<red>Removing dangling images</>")
 			if count, err := h.dry.dockerDaemon.RemoveDanglingImages(); err == nil {
 				h.dry.message(fmt.Sprintf("<red>Removed %d dangling images</>", count))
@@ -65,12 +50,16 @@ func (h *imagesScreenEventHandler) handleKeyEvent(key tcell.Key, f func(eventHan
 						"<red>Error removing dangling images: %s</>", err))
 			}
 			refreshScreen()
-
-		}()
+		})
 
 	case tcell.KeyCtrlE: //remove image
-
-		prompt := appui.NewPrompt("Do you want to remove the selected image? (y/N)")
+		if marked := h.widget.Marked(); len(marked) > 0 {
+			h.removeImages(marked, false, f)
+			break
+		}
+		protected := h.isSelectedImageProtected()
+		prompt := appui.NewPrompt(
+			confirmationPrompt("Do you want to remove the selected image?"+h.selectedImageUsage(), protected))
 		widgets.add(prompt)
 		forwarder := newEventForwarder()
 		f(forwarder)
@@ -84,19 +73,31 @@ func (h *imagesScreenEventHandler) handleKeyEvent(key tcell.Key, f func(eventHan
 			}
 			prompt.OnFocus(events)
 			conf, cancel := prompt.Text()
-			f(h)
+			defer f(h)
 			widgets.remove(prompt)
-			if cancel || (conf != "y" && conf != "Y") {
+			if cancel || !isConfirmed(conf, protected) {
 				return
 			}
 
 			rmImage := func(id string) error {
-				shortID := drydocker.TruncateID(id)
-				if _, err := h.dry.dockerDaemon.Rmi(id, false); err == nil {
-					h.dry.message(fmt.Sprintf("<red>Removed image:</> <white>%s</>", shortID))
-				} else {
-					h.dry.message(fmt.Sprintf("<red>Error removing image </><white>%s: %s</>", shortID, err.Error()))
+				containers, ok := h.containersToRemoveWith([]string{id}, forwarder)
+				if !ok {
+					return nil
 				}
+				shortID := drydocker.TruncateID(id)
+				daemon := h.dry.dockerDaemon
+				h.dry.removals.schedule("image "+shortID+containersDescription(containers), func() {
+					if !removeContainers(h.dry, daemon, containers) {
+						refreshScreen()
+						return
+					}
+					if _, err := daemon.Rmi(id, false); err == nil {
+						h.dry.message(fmt.Sprintf("<red>Removed image:</> <white>%s</>", shortID))
+					} else {
+						h.dry.message(fmt.Sprintf("<red>Error removing image </><white>%s: %s</>", shortID, err.Error()))
+					}
+					refreshScreen()
+				})
 				return nil
 			}
 			if err := h.widget.OnEvent(rmImage); err != nil {
@@ -108,7 +109,13 @@ func (h *imagesScreenEventHandler) handleKeyEvent(key tcell.Key, f func(eventHan
 		}()
 
 	case tcell.KeyCtrlF: //force remove image
-		prompt := appui.NewPrompt("Do you want to remove the selected image? (y/N)")
+		if marked := h.widget.Marked(); len(marked) > 0 {
+			h.removeImages(marked, true, f)
+			break
+		}
+		protected := h.isSelectedImageProtected()
+		prompt := appui.NewPrompt(
+			confirmationPrompt("Do you want to remove the selected image?"+h.selectedImageUsage(), protected))
 		widgets.add(prompt)
 		forwarder := newEventForwarder()
 		f(forwarder)
@@ -124,17 +131,21 @@ func (h *imagesScreenEventHandler) handleKeyEvent(key tcell.Key, f func(eventHan
 			conf, cancel := prompt.Text()
 			f(h)
 			widgets.remove(prompt)
-			if cancel || (conf != "y" && conf != "Y") {
+			if cancel || !isConfirmed(conf, protected) {
 				return
 			}
 
 			rmImage := func(id string) error {
 				shortID := drydocker.TruncateID(id)
-				if _, err := h.dry.dockerDaemon.Rmi(id, true); err == nil {
-					h.dry.message(fmt.Sprintf("<red>Removed image:</> <white>%s</>", shortID))
-				} else {
-					h.dry.message(fmt.Sprintf("<red>Error removing image </><white>%s: %s</>", shortID, err.Error()))
-				}
+				daemon := h.dry.dockerDaemon
+				h.dry.removals.schedule("image "+shortID, func() {
+					if _, err := daemon.Rmi(id, true); err == nil {
+						h.dry.message(fmt.Sprintf("<red>Removed image:</> <white>%s</>", shortID))
+					} else {
+						h.dry.message(fmt.Sprintf("<red>Error removing image </><white>%s: %s</>", shortID, err.Error()))
+					}
+					refreshScreen()
+				})
 				return nil
 			}
 			if err := h.widget.OnEvent(rmImage); err != nil {
@@ -146,26 +157,7 @@ func (h *imagesScreenEventHandler) handleKeyEvent(key tcell.Key, f func(eventHan
 		}()
 
 	case tcell.KeyCtrlU: //remove unused images
-		prompt := appui.NewPrompt("Do you want to remove all unused images? (y/N)")
-		widgets.add(prompt)
-		forwarder := newEventForwarder()
-		f(forwarder)
-		refreshScreen()
-		go func() {
-			events := ui.EventSource{
-				Events: forwarder.events(),
-				EventHandledCallback: func(e *tcell.EventKey) error {
-					return refreshScreen()
-				},
-			}
-			prompt.OnFocus(events)
-			conf, cancel := prompt.Text()
-			f(h)
-			widgets.remove(prompt)
-			if cancel || (conf != "y" && conf != "Y") {
-				return
-			}
-
+		askConfirmation(h, f, "Do you want to remove all unused images?", false, func() {
 			h.dry.message("<red>Removing unused images</>")
 			if count, err := h.dry.dockerDaemon.RemoveUnusedImages(); err == nil {
 				h.dry.message(fmt.Sprintf("<red>Removed %d images</>", count))
@@ -175,23 +167,28 @@ func (h *imagesScreenEventHandler) handleKeyEvent(key tcell.Key, f func(eventHan
 						"<red>Error removing unused images: %s</>", err))
 			}
 			refreshScreen()
-
-		}()
+		})
 
 	case tcell.KeyEnter: //inspect image
 		forwarder := newEventForwarder()
 		f(forwarder)
-		inspectImage := inspect(
-			h.screen,
-			forwarder.events(),
-			func(id string) (interface{}, error) {
-				return h.dry.dockerDaemon.InspectImage(id)
-			},
-			func() {
-				h.dry.changeView(Images)
+		inspectImage := func(id string) error {
+			inspected, err := h.dry.dockerDaemon.InspectImage(id)
+			if err != nil {
+				return err
+			}
+			note := ""
+			if image, err := h.dry.dockerDaemon.ImageByID(id); err == nil {
+				note = h.dry.notes.note(imageNotes, imageNoteName(image), id)
+			}
+			h.dry.pushView(NoView)
+			go appui.Inspect(noteHeader(note), inspected, h.screen, forwarder.events(), func() {
+				h.dry.popView()
 				f(h)
 				refreshScreen()
 			})
+			return nil
+		}
 
 		if err := h.widget.OnEvent(inspectImage); err != nil {
 			h.dry.message(
@@ -212,23 +209,10 @@ func (h *imagesScreenEventHandler) handleChEvent(ch rune, f func(eventHandler))
 
 	case 'i', 'I': //image history
 
-		showHistory := func(id string) error {
-			history, err := dry.dockerDaemon.History(id)
-
-			if err == nil {
-				forwarder := newEventForwarder()
-				f(forwarder)
-				renderer := appui.NewDockerImageHistoryRenderer(history)
-
-				go appui.Less(renderer.String(), h.screen, forwarder.events(), func() {
-					h.dry.changeView(Images)
-					f(h)
-					refreshScreen()
-				})
-			}
-			return err
+		showLayers := func(id string) error {
+			return showImageLayers(dry, id, f)
 		}
-		if err := h.widget.OnEvent(showHistory); err != nil {
+		if err := h.widget.OnEvent(showLayers); err != nil {
 			dry.message(err.Error())
 		}
 	case 'r', 'R': //Run container
@@ -257,16 +241,32 @@ func (h *imagesScreenEventHandler) handleChEvent(ch rune, f func(eventHandler))
 				if canceled {
 					return
 				}
-				if err := dry.dockerDaemon.RunImage(image, runCommand); err != nil {
+				opts, err := drydocker.ParseRunOptions(runCommand)
+				if err != nil {
+					dry.message("Error running image: " + err.Error())
+					refreshScreen()
+					return
+				}
+				if id, err := dry.dockerDaemon.RunImage(image, opts); err != nil {
 					dry.message(err.Error())
 				} else {
 					var repo string
 					if len(image.RepoTags) > 0 {
 						repo = image.RepoTags[0]
 					}
-					dry.message(
-						fmt.Sprintf(
-							"Image %s run successfully", repo))
+					name := opts.Name
+					if name == "" {
+						name = drydocker.TruncateID(id)
+					}
+					if opts.Interactive {
+						dry.message(
+							fmt.Sprintf(
+								"Image %s run successfully as container %s, docker attach %s attaches to it", repo, name, name))
+					} else {
+						dry.message(
+							fmt.Sprintf(
+								"Image %s run successfully as container %s", repo, name))
+					}
 				}
 				refreshScreen()
 
@@ -277,18 +277,302 @@ func (h *imagesScreenEventHandler) handleChEvent(ch rune, f func(eventHandler))
 			dry.message(
 				fmt.Sprintf("Error running image: %s", err.Error()))
 		}
+	case 'n': //note
+		if err := h.widget.OnEvent(func(id string) error {
+			image, err := dry.dockerDaemon.ImageByID(id)
+			if err != nil {
+				return err
+			}
+			editNote(dry, imageNotes, imageNoteName(image), id, h, f, nil)
+			return nil
+		}); err != nil {
+			dry.message("There was an error editing the note: " + err.Error())
+		}
+	case 'y': //copy to clipboard
+		if err := h.widget.OnEvent(func(id string) error {
+			image, err := dry.dockerDaemon.ImageByID(id)
+			if err != nil {
+				return err
+			}
+			copyToClipboard(dry, h, f, "image "+imageName(image), imageClipboardValues(dry.dockerDaemon, image))
+			return nil
+		}); err != nil {
+			dry.message("There was an error copying to clipboard: " + err.Error())
+		}
+	case 'w': //compare with another image
+		if err := h.widget.OnEvent(func(id string) error {
+			return h.diffImages(id, f)
+		}); err != nil {
+			dry.message("There was an error comparing images: " + err.Error())
+		}
+	case 'c': //containers using the image
+		if err := h.widget.OnEvent(func(id string) error {
+			return h.showImageUsage(id, f)
+		}); err != nil {
+			dry.message("There was an error looking for the containers using the image: " + err.Error())
+		}
+	case 'O': //check the registry for newer images
+		dry.message("Checking the registry for newer images")
+		go checkImageUpdates(dry)
+	case 'U': //pull the newer image of the outdated tags
+		if err := h.widget.OnEvent(func(id string) error {
+			pullNewer(dry, id)
+			return nil
+		}); err != nil {
+			dry.message("There was an error pulling the newer image: " + err.Error())
+		}
+	case ' ': //mark image
+		h.widget.ToggleMark()
+		h.screen.Cursor().ScrollCursorDown()
+	case 'x': //export images
+		images := h.widget.Marked()
+		if len(images) == 0 {
+			if err := h.widget.OnEvent(func(id string) error {
+				image, err := dry.dockerDaemon.ImageByID(id)
+				if err != nil {
+					return err
+				}
+				images = append(images, image)
+				return nil
+			}); err != nil {
+				dry.message("There was an error exporting the image: " + err.Error())
+				break
+			}
+		}
+		h.exportImages(images, f)
+	case 'p', 'P': //pull image
+		h.pullImage(f)
 	case '%':
 		forwarder := newEventForwarder()
 		f(forwarder)
-		applyFilter := func(filter string, canceled bool) {
-			if !canceled {
-				h.widget.Filter(filter)
-			}
+		showIncrementalFilterInput(newEventSource(forwarder.events()), h.widget, func() {
 			f(h)
-		}
-		showFilterInput(newEventSource(forwarder.events()), applyFilter)
+		})
 	default:
 		handled = false
 	}
 	return handled
 }
+
+//isSelectedImageProtected returns true if the selected image is protected
+func (h *imagesScreenEventHandler) isSelectedImageProtected() bool {
+	protected := false
+	h.widget.OnEvent(func(id string) error {
+		image, err := h.dry.dockerDaemon.InspectImage(id)
+		if err == nil && image.Config != nil {
+			protected = drydocker.IsProtected(image.Config.Labels)
+		}
+		return nil
+	})
+	return protected
+}
+
+//selectedImageUsage returns the warning to add when asking to remove the
+//selected image if containers use it
+func (h *imagesScreenEventHandler) selectedImageUsage() string {
+	warning := ""
+	h.widget.OnEvent(func(id string) error {
+		warning = usageWarning(containersUsing(h.dry.dockerDaemon, []string{id}))
+		return nil
+	})
+	return warning
+}
+
+//pullImage asks for the image to pull and, once the download size is estimated,
+//for confirmation before pulling it. If the registry refuses access, it asks
+//for credentials and retries.
+func (h *imagesScreenEventHandler) pullImage(f func(eventHandler)) {
+	dry := h.dry
+	askWith := func(prompt *appui.Prompt) (string, bool) {
+		widgets.add(prompt)
+		forwarder := newEventForwarder()
+		f(forwarder)
+		refreshScreen()
+		prompt.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(prompt)
+		f(h)
+		refreshScreen()
+		return prompt.Text()
+	}
+	ask := func(question string) (string, bool) {
+		return askWith(appui.NewPrompt(question))
+	}
+	go func() {
+		image, canceled := ask("Image to pull? (e.g. alpine:latest)")
+		image = strings.TrimSpace(image)
+		if canceled || image == "" {
+			return
+		}
+		dry.message(fmt.Sprintf("Estimating download size of %s", image))
+		var question string
+		if estimate, err := dry.dockerDaemon.PullEstimate(image); err == nil {
+			question = fmt.Sprintf("Pulling %s downloads %s in %d layers, continue? (y/N)",
+				estimate.Image, units.HumanSize(float64(estimate.Size)), estimate.Layers)
+		} else {
+			question = fmt.Sprintf("Could not estimate download size (%s), pull %s anyway? (y/N)",
+				err.Error(), image)
+		}
+		conf, canceled := ask(question)
+		if canceled || (conf != "y" && conf != "Y") {
+			return
+		}
+		dry.message(fmt.Sprintf("Pulling %s", image))
+		var auth *drydocker.RegistryAuth
+		err := dry.dockerDaemon.PullImage(image)
+		if drydocker.IsUnauthorized(err) {
+			auth, err = pullWithCredentials(dry, image, askWith)
+		}
+		if err != nil {
+			dry.message(err.Error())
+		} else {
+			dry.message(fmt.Sprintf("Image %s pulled", image))
+			h.widget.Unmount()
+		}
+		refreshScreen()
+		if auth != nil {
+			saveCredentials(dry, *auth, askWith)
+			refreshScreen()
+		}
+	}()
+}
+
+//maxListedContainers is the number of containers named when asking to
+//remove the containers using an image
+const maxListedContainers = 5
+
+//stoppedContainersUsing returns the stopped containers created from any of
+//the given images, the daemon refuses to remove an image while they exist
+func stoppedContainersUsing(daemon drydocker.ContainerDaemon, ids []string) []*drydocker.Container {
+	return containersUsing(daemon, ids, drydocker.ContainerFilters.NotRunning())
+}
+
+//containersToRemoveWith checks if stopped containers were created from the
+//given images and, if so, shows them and asks whether to remove them along
+//with the images. It reads keys from the given forwarder and returns the
+//containers to remove first, and true if the removal of the images can go on.
+func (h *imagesScreenEventHandler) containersToRemoveWith(ids []string, forwarder eventHandlerForwarder) ([]*drydocker.Container, bool) {
+	containers := stoppedContainersUsing(h.dry.dockerDaemon, ids)
+	if len(containers) == 0 {
+		return nil, true
+	}
+	var names []string
+	protected := false
+	for _, c := range containers {
+		if len(names) < maxListedContainers {
+			names = append(names, containerName(c))
+		}
+		protected = protected || drydocker.IsProtected(c.Labels)
+	}
+	if more := len(containers) - len(names); more > 0 {
+		names = append(names, fmt.Sprintf("%d more", more))
+	}
+	prompt := appui.NewPrompt(
+		confirmationPrompt(
+			fmt.Sprintf("In use by stopped containers: %s. Remove them first?", strings.Join(names, ", ")),
+			protected))
+	widgets.add(prompt)
+	refreshScreen()
+	prompt.OnFocus(newEventSource(forwarder.events()))
+	widgets.remove(prompt)
+	conf, cancel := prompt.Text()
+	if cancel || !isConfirmed(conf, protected) {
+		h.dry.message(fmt.Sprintf("<red>Image not removed, it is used by %d stopped containers</>", len(containers)))
+		return nil, false
+	}
+	return containers, true
+}
+
+//containersDescription describes the given containers removed along with images
+func containersDescription(containers []*drydocker.Container) string {
+	switch len(containers) {
+	case 0:
+		return ""
+	case 1:
+		return " and 1 container"
+	}
+	return fmt.Sprintf(" and %d containers", len(containers))
+}
+
+//removeContainers removes the given containers, it returns false if any
+//of them could not be removed
+func removeContainers(dry *Dry, daemon drydocker.ContainerDaemon, containers []*drydocker.Container) bool {
+	for _, c := range containers {
+		if err := daemon.Rm(c.ID); err != nil {
+			dry.message(fmt.Sprintf("<red>Error removing container </><white>%s: %s</>", containerName(c), err.Error()))
+			return false
+		}
+	}
+	return true
+}
+
+//removeImages asks for confirmation and removes the given images, once the
+//undo window has passed, showing the progress on a panel
+func (h *imagesScreenEventHandler) removeImages(images []types.ImageSummary, force bool, f func(eventHandler)) {
+	dry := h.dry
+	protected := false
+	for _, image := range images {
+		protected = protected || drydocker.IsProtected(image.Labels)
+	}
+	ids := make([]string, len(images))
+	for i, image := range images {
+		ids[i] = image.ID
+	}
+	prompt := appui.NewPrompt(
+		confirmationPrompt(
+			fmt.Sprintf("Do you want to remove %d marked images?", len(images))+usageWarning(containersUsing(dry.dockerDaemon, ids)),
+			protected))
+	widgets.add(prompt)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+	go func() {
+		prompt.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(prompt)
+		conf, cancel := prompt.Text()
+		if cancel || !isConfirmed(conf, protected) {
+			f(h)
+			refreshScreen()
+			return
+		}
+		var containers []*drydocker.Container
+		if !force {
+			var ok bool
+			if containers, ok = h.containersToRemoveWith(ids, forwarder); !ok {
+				f(h)
+				refreshScreen()
+				return
+			}
+		}
+		f(h)
+		refreshScreen()
+		daemon := dry.dockerDaemon
+		widget := h.widget
+		description := fmt.Sprintf("%d images%s", len(images), containersDescription(containers))
+		dry.removals.schedule(description, func() {
+			if !removeContainers(dry, daemon, containers) {
+				refreshScreen()
+				return
+			}
+			panel := appui.NewImageRemovalPanel(images)
+			widgets.add(panel)
+			refreshScreen()
+			var failure error
+			daemon.RemoveImages(ids, force, func(id string, err error) {
+				if err != nil && failure == nil {
+					failure = err
+				}
+				panel.Removed(id, err)
+				refreshScreen()
+			})
+			widgets.remove(panel)
+			if failed := panel.Failed(); failed > 0 {
+				dry.message(fmt.Sprintf("<red>Removed %d images, %d could not be removed:</> %s", len(images)-failed, failed, failure.Error()))
+			} else {
+				dry.message(fmt.Sprintf("<red>Removed %d images</>", len(images)))
+			}
+			widget.ClearMarks()
+			widget.Unmount()
+			refreshScreen()
+		})
+	}()
+}
@@ -0,0 +1,112 @@
+package app
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+)
+
+//imageExport describes how images are exported
+type imageExport struct {
+	path   string
+	format docker.ImageExportFormat
+}
+
+func imageExportPrompt(name string) *appui.Prompt {
+	return appui.NewPromptWithText(
+		"Export images: file=<path> format=<docker|oci> (oci writes an image-layout directory)",
+		fmt.Sprintf("file=%s.tar format=docker", name))
+}
+
+//parseImageExport parses the export options typed on an image export prompt, a
+//value given without key is taken as the path
+func parseImageExport(s string) (imageExport, error) {
+	export := imageExport{format: docker.DockerArchive}
+	for _, field := range strings.Fields(s) {
+		key, value := "file", field
+		if i := strings.Index(field, "="); i >= 0 {
+			key, value = field[:i], field[i+1:]
+		}
+		switch key {
+		case "file":
+			export.path = value
+		case "format":
+			switch format := docker.ImageExportFormat(value); format {
+			case docker.DockerArchive, docker.OCILayout:
+				export.format = format
+			default:
+				return export, fmt.Errorf("invalid format value: %q", value)
+			}
+		default:
+			return export, fmt.Errorf("unknown export option: %q", key)
+		}
+	}
+	if export.path == "" {
+		return export, fmt.Errorf("no file given")
+	}
+	return export, nil
+}
+
+//imageRefs returns the references used to export the given images, tags
+//are used so they are kept on the export, untagged images are given by id
+func imageRefs(images []types.ImageSummary) []string {
+	var refs []string
+	for _, image := range images {
+		tagged := false
+		for _, tag := range image.RepoTags {
+			if tag != "<none>:<none>" {
+				refs = append(refs, tag)
+				tagged = true
+			}
+		}
+		if !tagged {
+			refs = append(refs, image.ID)
+		}
+	}
+	return refs
+}
+
+//exportName returns the default export name for the given images
+func exportName(images []types.ImageSummary) string {
+	refs := imageRefs(images)
+	if len(refs) != 1 || strings.HasPrefix(refs[0], "sha256:") {
+		return "images"
+	}
+	name := path.Base(refs[0])
+	return strings.Replace(name, ":", "_", -1)
+}
+
+//exportImages asks where and how to export the given images, then exports them
+func (h *imagesScreenEventHandler) exportImages(images []types.ImageSummary, f func(eventHandler)) {
+	dry := h.dry
+	prompt := imageExportPrompt(exportName(images))
+	widgets.add(prompt)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+	go func() {
+		prompt.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(prompt)
+		text, canceled := prompt.Text()
+		f(h)
+		defer refreshScreen()
+		if canceled {
+			return
+		}
+		export, err := parseImageExport(text)
+		if err != nil {
+			dry.message("Images not exported: " + err.Error())
+			return
+		}
+		dry.message(fmt.Sprintf("Exporting %d images to %s, this might take a while", len(images), export.path))
+		if err := dry.dockerDaemon.ExportImages(imageRefs(images), export.path, export.format); err != nil {
+			dry.message("There was an error exporting images: " + err.Error())
+			return
+		}
+		dry.message(fmt.Sprintf("%d images exported to %s", len(images), export.path))
+	}()
+}
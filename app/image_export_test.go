@@ -0,0 +1,50 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/moncho/dry/docker"
+)
+
+func TestParseImageExport(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    imageExport
+		wantErr bool
+	}{
+		{"file=nginx.tar format=docker", imageExport{"nginx.tar", docker.DockerArchive}, false},
+		{"nginx-oci format=oci", imageExport{"nginx-oci", docker.OCILayout}, false},
+		{"images.tar", imageExport{"images.tar", docker.DockerArchive}, false},
+		{"file=x format=zip", imageExport{}, true},
+		{"format=oci", imageExport{}, true},
+	}
+	for _, tt := range tests {
+		got, err := parseImageExport(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseImageExport(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("parseImageExport(%q) = %+v, want %+v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestImageRefs(t *testing.T) {
+	images := []types.ImageSummary{
+		{ID: "sha256:1", RepoTags: []string{"nginx:1.19", "nginx:latest"}},
+		{ID: "sha256:2", RepoTags: []string{"<none>:<none>"}},
+	}
+	want := []string{"nginx:1.19", "nginx:latest", "sha256:2"}
+	if got := imageRefs(images); !reflect.DeepEqual(got, want) {
+		t.Errorf("imageRefs() = %v, want %v", got, want)
+	}
+	if name := exportName(images[:1]); name != "images" {
+		t.Errorf("Unexpected export name for several tags: %s", name)
+	}
+	if name := exportName([]types.ImageSummary{{RepoTags: []string{"moncho/dry:latest"}}}); name != "dry_latest" {
+		t.Errorf("Unexpected export name: %s", name)
+	}
+}
@@ -0,0 +1,104 @@
+package app
+
+import (
+	"github.com/docker/docker/api/types"
+	"github.com/gdamore/tcell"
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+)
+
+//showImageLayers shows the layers of the image with the given id, the
+//other images sharing them are looked for in the background
+func showImageLayers(dry *Dry, id string, f func(eventHandler)) error {
+	history, err := dry.dockerDaemon.History(id)
+	if err != nil {
+		return err
+	}
+	img, err := dry.dockerDaemon.InspectImage(id)
+	if err != nil {
+		return err
+	}
+	name := docker.ImageName(img)
+	widgets.ImageLayers.SetImage(name, docker.ImageLayers(history, img, nil))
+	f(viewsToHandlers[ImageLayers])
+	dry.pushView(ImageLayers)
+	refreshScreen()
+
+	go func() {
+		others := inspectImages(dry.dockerDaemon)
+		widgets.ImageLayers.SetSharedLayers(name, docker.ImageLayers(history, img, others))
+		refreshIfView(ImageLayers)
+	}()
+	return nil
+}
+
+//inspectImages inspects every local image, images that cannot be inspected
+//are left out
+func inspectImages(daemon docker.ContainerDaemon) []types.ImageInspect {
+	images, err := daemon.Images()
+	if err != nil {
+		return nil
+	}
+	var inspected []types.ImageInspect
+	for _, i := range images {
+		if img, err := daemon.InspectImage(i.ID); err == nil {
+			inspected = append(inspected, img)
+		}
+	}
+	return inspected
+}
+
+type imageLayersScreenEventHandler struct {
+	baseEventHandler
+}
+
+func (h *imageLayersScreenEventHandler) handle(event *tcell.EventKey, f func(eventHandler)) {
+	layers := widgets.ImageLayers
+	handled := true
+	switch event.Key() {
+	case tcell.KeyEsc:
+		h.dry.goBack(f)
+		return
+	case tcell.KeyUp, tcell.KeyCtrlP:
+		layers.MoveSelection(-1)
+	case tcell.KeyDown, tcell.KeyCtrlN:
+		layers.MoveSelection(1)
+	case tcell.KeyPgUp:
+		layers.MoveSelection(-10)
+	case tcell.KeyPgDn:
+		layers.MoveSelection(10)
+	case tcell.KeyEnter:
+		h.showDetails(f)
+		return
+	default:
+		switch event.Rune() {
+		case 'k':
+			layers.MoveSelection(-1)
+		case 'j':
+			layers.MoveSelection(1)
+		default:
+			handled = false
+		}
+	}
+	if handled {
+		refreshScreen()
+	} else {
+		h.baseEventHandler.handle(event, f)
+	}
+}
+
+//showDetails shows the details of the selected layer
+func (h *imageLayersScreenEventHandler) showDetails(f func(eventHandler)) {
+	layer, ok := widgets.ImageLayers.Selected()
+	if !ok {
+		return
+	}
+	forwarder := newEventForwarder()
+	f(forwarder)
+	go appui.Less(
+		appui.ImageLayerDetails(widgets.ImageLayers.Image(), layer),
+		h.screen, forwarder.events(), func() {
+			f(h)
+			refreshScreen()
+		})
+}
@@ -0,0 +1,114 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+)
+
+//imagePrePull describes an image to be pulled on swarm nodes
+type imagePrePull struct {
+	image string
+	//nodeLabel selects the nodes to pull the image on, as <key>=<value>, every node if empty
+	nodeLabel string
+}
+
+func imagePrePullPrompt() *appui.Prompt {
+	return appui.NewPrompt("Pre-pull image on nodes: <image> [label=<key>=<value>]")
+}
+
+//parseImagePrePull parses the text typed on an image pre-pull prompt
+func parseImagePrePull(s string) (imagePrePull, error) {
+	var prePull imagePrePull
+	for _, field := range strings.Fields(s) {
+		if strings.HasPrefix(field, "label=") {
+			prePull.nodeLabel = strings.TrimPrefix(field, "label=")
+			if !strings.Contains(prePull.nodeLabel, "=") {
+				return prePull, fmt.Errorf("invalid node label %q, expected <key>=<value>", prePull.nodeLabel)
+			}
+			continue
+		}
+		if prePull.image != "" {
+			return prePull, fmt.Errorf("only one image can be pre-pulled at a time")
+		}
+		prePull.image = field
+	}
+	if prePull.image == "" {
+		return prePull, fmt.Errorf("no image given")
+	}
+	return prePull, nil
+}
+
+//nodes describes the nodes the image is pulled on
+func (p imagePrePull) nodes() string {
+	if p.nodeLabel == "" {
+		return "every node"
+	}
+	return "nodes labeled " + p.nodeLabel
+}
+
+//prePullImage asks for an image, and optionally a node label, and pulls the
+//image on the swarm nodes, showing the result on each node as it completes
+func (h *nodesScreenEventHandler) prePullImage(f func(eventHandler)) {
+	dry := h.dry
+	prompt := imagePrePullPrompt()
+	widgets.add(prompt)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+	go func() {
+		prompt.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(prompt)
+		text, canceled := prompt.Text()
+		if canceled {
+			f(h)
+			refreshScreen()
+			return
+		}
+		prePull, err := parseImagePrePull(text)
+		if err != nil {
+			f(h)
+			refreshScreen()
+			dry.message("Cannot pre-pull image: " + err.Error())
+			return
+		}
+		r, w := io.Pipe()
+		go prePull.run(dry.dockerDaemon, w, dry.message)
+		dry.pushView(NoView)
+		appui.StreamText(r, forwarder.events(), func() {
+			dry.popView()
+			f(h)
+			refreshScreen()
+		})
+	}()
+}
+
+//run pulls the image on the nodes, the progress is written to the given
+//writer, which is closed once done, and the outcome given to message. Since
+//the progress view might be closed before that, write errors are ignored.
+func (p imagePrePull) run(daemon docker.SwarmAPI, w io.WriteCloser, message func(string)) {
+	defer w.Close()
+	start := time.Now()
+	fmt.Fprintf(w, "<white>Pulling</> <blue>%s</> <white>on %s, press Esc to keep it running in the background</>\n\n", p.image, p.nodes())
+	pulled, failed := 0, 0
+	err := daemon.ImagePrePull(p.image, p.nodeLabel, func(result docker.PrePullResult) {
+		elapsed := time.Since(start).Round(time.Second)
+		if result.Err != nil {
+			failed++
+			fmt.Fprintf(w, "<red>✗</> %s failed after %s: %s\n", result.Node, elapsed, result.Err.Error())
+			return
+		}
+		pulled++
+		fmt.Fprintf(w, "<green>✓</> %s done after %s\n", result.Node, elapsed)
+	})
+	summary := fmt.Sprintf("%s pulled on %d nodes, %d failed", p.image, pulled, failed)
+	if err != nil {
+		summary = fmt.Sprintf("%s, error: %s", summary, err.Error())
+	}
+	fmt.Fprintf(w, "\n<white>%s</>\n", summary)
+	message(summary)
+}
@@ -0,0 +1,76 @@
+package app
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/mocks"
+)
+
+func Test_parseImagePrePull(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    imagePrePull
+		wantErr bool
+	}{
+		{"nginx:1.17", imagePrePull{image: "nginx:1.17"}, false},
+		{"nginx:1.17 label=zone=a", imagePrePull{image: "nginx:1.17", nodeLabel: "zone=a"}, false},
+		{"label=zone=a nginx", imagePrePull{image: "nginx", nodeLabel: "zone=a"}, false},
+		{"", imagePrePull{}, true},
+		{"label=zone=a", imagePrePull{}, true},
+		{"nginx label=zone", imagePrePull{}, true},
+		{"nginx redis", imagePrePull{}, true},
+	}
+	for _, tt := range tests {
+		got, err := parseImagePrePull(tt.text)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseImagePrePull(%q) error = %v, wantErr %v", tt.text, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("parseImagePrePull(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+type prePullDaemon struct {
+	mocks.DockerDaemonMock
+}
+
+func (d *prePullDaemon) ImagePrePull(image, nodeLabel string, report func(docker.PrePullResult)) error {
+	report(docker.PrePullResult{Node: "node-1"})
+	report(docker.PrePullResult{Node: "node-2", Err: errors.New("No such image")})
+	return nil
+}
+
+type closingBuffer struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (b *closingBuffer) Close() error {
+	b.closed = true
+	return nil
+}
+
+func Test_imagePrePull_run(t *testing.T) {
+	w := &closingBuffer{}
+	var message string
+	imagePrePull{image: "nginx"}.run(&prePullDaemon{}, w, func(m string) { message = m })
+
+	if !w.closed {
+		t.Error("Progress writer was not closed")
+	}
+	progress := w.String()
+	for _, expected := range []string{"node-1 done", "node-2 failed", "No such image"} {
+		if !strings.Contains(progress, expected) {
+			t.Errorf("Progress does not contain %q:\n%s", expected, progress)
+		}
+	}
+	if message != "nginx pulled on 1 nodes, 1 failed" {
+		t.Errorf("Unexpected outcome message: %s", message)
+	}
+}
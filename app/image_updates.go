@@ -0,0 +1,141 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/moncho/dry/docker"
+)
+
+//imageUpdates keeps the result of the last check of the registry for newer
+//images of the local tags
+type imageUpdates struct {
+	//outdated are the tags pointing to a newer image on the registry, by image id
+	outdated map[string][]string
+	checking bool
+	sync.RWMutex
+}
+
+//start marks a check as running, it returns false if one is already running
+func (u *imageUpdates) start() bool {
+	u.Lock()
+	defer u.Unlock()
+	if u.checking {
+		return false
+	}
+	u.checking = true
+	return true
+}
+
+//set sets the result of a check, it returns the number of outdated tags
+//and of tags that could be checked
+func (u *imageUpdates) set(updates []docker.ImageUpdate) (outdated, checked int) {
+	u.Lock()
+	defer u.Unlock()
+	u.checking = false
+	u.outdated = make(map[string][]string)
+	for _, update := range updates {
+		if update.Err != nil {
+			continue
+		}
+		checked++
+		if update.Outdated() {
+			outdated++
+			u.outdated[update.ImageID] = append(u.outdated[update.ImageID], update.Tag)
+		}
+	}
+	return outdated, checked
+}
+
+//pulled forgets the given outdated tag once the newer image is pulled
+func (u *imageUpdates) pulled(id, tag string) {
+	u.Lock()
+	defer u.Unlock()
+	var tags []string
+	for _, t := range u.outdated[id] {
+		if t != tag {
+			tags = append(tags, t)
+		}
+	}
+	if len(tags) == 0 {
+		delete(u.outdated, id)
+	} else {
+		u.outdated[id] = tags
+	}
+}
+
+//outdatedTags returns the tags of the image with the given id pointing to a
+//newer image on the registry
+func (u *imageUpdates) outdatedTags(id string) []string {
+	u.RLock()
+	defer u.RUnlock()
+	return append([]string(nil), u.outdated[id]...)
+}
+
+//isOutdated returns true if a tag of the image with the given id points to
+//a newer image on the registry
+func (u *imageUpdates) isOutdated(id string) bool {
+	u.RLock()
+	defer u.RUnlock()
+	return len(u.outdated[id]) > 0
+}
+
+//checkImageUpdates checks the registry for newer images of the local tags,
+//images and containers using an outdated tag are marked once done
+func checkImageUpdates(dry *Dry) {
+	if !dry.imageUpdates.start() {
+		dry.message("Already checking for newer images")
+		return
+	}
+	images, err := dry.dockerDaemon.Images()
+	if err != nil {
+		dry.imageUpdates.set(nil)
+		dry.message(fmt.Sprintf("<red>Error checking for newer images:</> %s", err.Error()))
+		return
+	}
+	outdated, checked := dry.imageUpdates.set(dry.dockerDaemon.CheckImageUpdates(images))
+	if outdated > 0 {
+		dry.message(fmt.Sprintf("<yellow>%d of %d tags checked have a newer image on the registry</>", outdated, checked))
+	} else {
+		dry.message(fmt.Sprintf("<white>The %d tags checked are up to date</>", checked))
+	}
+	widgets.ImageList.Unmount()
+	widgets.ContainerList.Unmount()
+	refreshScreen()
+}
+
+//scheduleImageUpdateChecks checks for newer images every given time
+func scheduleImageUpdateChecks(dry *Dry, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		checkImageUpdates(dry)
+		<-ticker.C
+	}
+}
+
+//pullNewer pulls the newer images of the outdated tags of the image with
+//the given id
+func pullNewer(dry *Dry, id string) {
+	tags := dry.imageUpdates.outdatedTags(id)
+	if len(tags) == 0 {
+		dry.message("No newer image found for the selected image, O checks the registry again")
+		return
+	}
+	go func() {
+		for _, tag := range tags {
+			dry.message(fmt.Sprintf("Pulling newer %s", tag))
+			if err := dry.dockerDaemon.PullImage(tag); err != nil {
+				dry.message(fmt.Sprintf("<red>Error pulling %s:</> %s", tag, err.Error()))
+				return
+			}
+			dry.imageUpdates.pulled(id, tag)
+		}
+		dry.message(fmt.Sprintf("Pulled newer %s, recreate the containers using it to run it", strings.Join(tags, ", ")))
+		widgets.ImageList.Unmount()
+		widgets.ContainerList.Unmount()
+		refreshScreen()
+	}()
+}
@@ -0,0 +1,38 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/moncho/dry/docker"
+)
+
+func TestImageUpdates(t *testing.T) {
+	var u imageUpdates
+	if !u.start() || u.start() {
+		t.Fatal("Only one check at a time expected")
+	}
+	outdated, checked := u.set([]docker.ImageUpdate{
+		{Tag: "nginx:latest", ImageID: "1", LocalDigest: "sha256:a", RemoteDigest: "sha256:b"},
+		{Tag: "nginx:1.25", ImageID: "1", LocalDigest: "sha256:a", RemoteDigest: "sha256:c"},
+		{Tag: "redis:7", ImageID: "2", LocalDigest: "sha256:d", RemoteDigest: "sha256:d"},
+		{Tag: "private/app:1", ImageID: "3", Err: errors.New("unauthorized")},
+	})
+	if outdated != 2 || checked != 3 {
+		t.Errorf("Unexpected result of the check: %d outdated of %d checked", outdated, checked)
+	}
+	if !u.isOutdated("1") || u.isOutdated("2") || u.isOutdated("3") {
+		t.Error("Unexpected outdated images")
+	}
+	if !u.start() {
+		t.Error("A check could not start once the previous one finished")
+	}
+	u.pulled("1", "nginx:latest")
+	if tags := u.outdatedTags("1"); len(tags) != 1 || tags[0] != "nginx:1.25" {
+		t.Errorf("Unexpected outdated tags once one is pulled: %v", tags)
+	}
+	u.pulled("1", "nginx:1.25")
+	if u.isOutdated("1") {
+		t.Error("Image still outdated once its tags are pulled")
+	}
+}
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+)
+
+//containersUsing returns the containers, running and stopped, created from
+//any of the given images, the given filters are applied on top
+func containersUsing(daemon docker.ContainerDaemon, ids []string, filters ...docker.ContainerFilter) []*docker.Container {
+	var containers []*docker.Container
+	for _, id := range ids {
+		containers = append(containers, daemon.Containers(
+			append([]docker.ContainerFilter{docker.ContainerFilters.ByImageID(id)}, filters...),
+			docker.SortByName)...)
+	}
+	return containers
+}
+
+//usageWarning returns the warning added when asking to remove images used
+//by the given containers, it is empty if there are none
+func usageWarning(containers []*docker.Container) string {
+	if len(containers) == 0 {
+		return ""
+	}
+	running := 0
+	for _, c := range containers {
+		if docker.IsContainerRunning(c) {
+			running++
+		}
+	}
+	return fmt.Sprintf(" Warning: %d containers use it, %d running.", len(containers), running)
+}
+
+//showImageUsage shows the containers created from the image with the given id
+func (h *imagesScreenEventHandler) showImageUsage(id string, f func(eventHandler)) error {
+	image, err := h.dry.dockerDaemon.ImageByID(id)
+	if err != nil {
+		return err
+	}
+	name := imageNoteName(image)
+	if name == "" {
+		name = docker.ShortImageID(id)
+	}
+	forwarder := newEventForwarder()
+	f(forwarder)
+	h.dry.pushView(NoView)
+	go appui.Less(
+		appui.ImageUsage(name, containersUsing(h.dry.dockerDaemon, []string{id})),
+		h.screen, forwarder.events(), func() {
+			h.dry.popView()
+			f(h)
+			refreshScreen()
+		})
+	return nil
+}
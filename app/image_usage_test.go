@@ -0,0 +1,29 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/mocks"
+)
+
+func TestContainersUsing(t *testing.T) {
+	daemon := &mocks.DockerDaemonMock{}
+	if containers := containersUsing(daemon, []string{"sha256:unknown"}); len(containers) != 0 {
+		t.Errorf("Unexpected containers using an unknown image: %d", len(containers))
+	}
+	//Containers of the mock have no image id
+	all := containersUsing(daemon, []string{""})
+	if len(all) != 20 {
+		t.Fatalf("Unexpected containers using the image: %d", len(all))
+	}
+	if stopped := stoppedContainersUsing(daemon, []string{""}); len(stopped) != 10 {
+		t.Errorf("Unexpected stopped containers using the image: %d", len(stopped))
+	}
+	if warning := usageWarning(all); warning != " Warning: 20 containers use it, 10 running." {
+		t.Errorf("Unexpected warning: %q", warning)
+	}
+	if warning := usageWarning([]*docker.Container{}); warning != "" {
+		t.Errorf("Unexpected warning without containers: %q", warning)
+	}
+}
@@ -0,0 +1,200 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+)
+
+//inspectedObject is a Docker object whose inspect document can be compared
+type inspectedObject struct {
+	name    string
+	inspect func() (interface{}, error)
+}
+
+//diffInspected asks for the object to compare the given one with, the given
+//suggestion if none is typed, and shows the differences between the inspect
+//documents of both side by side
+func diffInspected(dry *Dry, h eventHandler, f func(eventHandler), kind string,
+	selected inspectedObject, suggestion string, resolve func(ref string) (inspectedObject, error)) {
+	prompt := appui.NewPromptWithText(
+		fmt.Sprintf("Compare %s %s with (name or ID)", kind, selected.name), suggestion)
+	widgets.add(prompt)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+
+	go func() {
+		prompt.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(prompt)
+		text, canceled := prompt.Text()
+		f(h)
+		refreshScreen()
+		if canceled || strings.TrimSpace(text) == "" {
+			return
+		}
+		other, err := resolve(strings.TrimSpace(text))
+		if err != nil {
+			dry.message("Could not compare: " + err.Error())
+			return
+		}
+		diff, err := inspectDiff(selected, other, dry.screen.Dimensions().Width)
+		if err != nil {
+			dry.message("Could not compare: " + err.Error())
+			return
+		}
+		if diff == "" {
+			dry.message(fmt.Sprintf("<green>The inspect documents of %s %s and %s are the same</>", kind, selected.name, other.name))
+			return
+		}
+		forwarder := newEventForwarder()
+		f(forwarder)
+		dry.pushView(NoView)
+		appui.Less(diff, dry.screen, forwarder.events(), func() {
+			dry.goBack(f)
+		})
+	}()
+}
+
+//inspectDiff returns the differences between the inspect documents of the
+//given objects side by side, to fit in the given width, empty if there are none
+func inspectDiff(a, b inspectedObject, width int) (string, error) {
+	aJSON, err := inspectedJSON(a)
+	if err != nil {
+		return "", err
+	}
+	bJSON, err := inspectedJSON(b)
+	if err != nil {
+		return "", err
+	}
+	if aJSON == bJSON {
+		return "", nil
+	}
+	return sideBySideDiff(a.name, b.name, lineDiff(lines(aJSON), lines(bJSON)), width), nil
+}
+
+func inspectedJSON(o inspectedObject) (string, error) {
+	inspected, err := o.inspect()
+	if err != nil {
+		return "", err
+	}
+	j, err := json.MarshalIndent(inspected, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(j), nil
+}
+
+//sideBySideDiff renders the given diff with markup, the lines of the first
+//text on the left and those of the second one on the right, only changes
+//and the lines around them are shown
+func sideBySideDiff(from, to string, diff []diffLine, width int) string {
+	column := (width - 3) / 2
+	if column < 10 {
+		column = 10
+	}
+	buffer := new(bytes.Buffer)
+	row := func(left, right, leftColor, rightColor string) {
+		right = diffCell(right, column, rightColor)
+		if rightColor == "" {
+			//no need to pad the end of the line
+			right = strings.TrimRight(right, " ")
+		}
+		buffer.WriteString(diffCell(left, column, leftColor) + " │ " + right + "\n")
+	}
+	row(from, to, "red", "green")
+	for _, hunk := range diffHunks(diff) {
+		row(fmt.Sprintf("@@ line %d", hunk.aLine), fmt.Sprintf("@@ line %d", hunk.bLine), "cyan", "cyan")
+		changes := diff[hunk.first : hunk.last+1]
+		for i := 0; i < len(changes); {
+			if changes[i].op == diffEqual {
+				row(changes[i].text, changes[i].text, "", "")
+				i++
+				continue
+			}
+			//lines changed are paired, so what replaced a line is shown next to it
+			var deleted, inserted []string
+			for ; i < len(changes) && changes[i].op != diffEqual; i++ {
+				if changes[i].op == diffDelete {
+					deleted = append(deleted, changes[i].text)
+				} else {
+					inserted = append(inserted, changes[i].text)
+				}
+			}
+			for j := 0; j < len(deleted) || j < len(inserted); j++ {
+				var left, right string
+				if j < len(deleted) {
+					left = deleted[j]
+				}
+				if j < len(inserted) {
+					right = inserted[j]
+				}
+				row(left, right, "red", "green")
+			}
+		}
+	}
+	return buffer.String()
+}
+
+//diffCell returns the given text cut or padded to the given width, colored
+//with the given markup color, if any
+func diffCell(text string, width int, color string) string {
+	cell := runewidth.FillRight(runewidth.Truncate(text, width, "…"), width)
+	if color == "" {
+		return cell
+	}
+	return "<" + color + ">" + cell + "</>"
+}
+
+//diffContainers asks for the container to compare the given one with and
+//shows the differences between their inspect documents
+func (h *containersScreenEventHandler) diffContainers(selected *docker.Container, f func(eventHandler)) {
+	daemon := h.dry.dockerDaemon
+	inspected := func(c *docker.Container) inspectedObject {
+		return inspectedObject{
+			name:    containerName(c),
+			inspect: func() (interface{}, error) { return daemon.Inspect(c.ID) },
+		}
+	}
+	diffInspected(h.dry, h, f, "container", inspected(selected), "",
+		func(ref string) (inspectedObject, error) {
+			c, err := resolveContainer(daemon.Containers(nil, docker.NoSort), ref)
+			if err != nil {
+				return inspectedObject{}, err
+			}
+			return inspected(c), nil
+		})
+}
+
+//diffImages asks for the image to compare the given one with, a marked image
+//is suggested, and shows the differences between their inspect documents
+func (h *imagesScreenEventHandler) diffImages(id string, f func(eventHandler)) error {
+	daemon := h.dry.dockerDaemon
+	selected, err := daemon.ImageByID(id)
+	if err != nil {
+		return err
+	}
+	var suggestion string
+	for _, marked := range h.widget.Marked() {
+		if marked.ID != selected.ID {
+			suggestion = imageName(marked)
+			break
+		}
+	}
+	inspected := func(name, ref string) inspectedObject {
+		return inspectedObject{
+			name:    name,
+			inspect: func() (interface{}, error) { return daemon.InspectImage(ref) },
+		}
+	}
+	diffInspected(h.dry, h, f, "image", inspected(imageName(selected), selected.ID), suggestion,
+		func(ref string) (inspectedObject, error) {
+			return inspected(ref, ref), nil
+		})
+	return nil
+}
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSideBySideDiff(t *testing.T) {
+	staging := "a\nb\nc\nd\ne\nf\n"
+	prod := "a\nB\nc\nd\ne\nf\ng\n"
+
+	diff := sideBySideDiff("staging", "prod", lineDiff(lines(staging), lines(prod)), 27)
+	expected := `<red>staging     </> │ <green>prod        </>
+<cyan>@@ line 1   </> │ <cyan>@@ line 1   </>
+a            │ a
+<red>b           </> │ <green>B           </>
+c            │ c
+d            │ d
+e            │ e
+f            │ f
+<red>            </> │ <green>g           </>
+`
+	if diff != expected {
+		t.Errorf("Unexpected diff, got:\n%s\nexpected:\n%s", diff, expected)
+	}
+}
+
+func TestInspectDiff(t *testing.T) {
+	object := func(name string, inspected interface{}, err error) inspectedObject {
+		return inspectedObject{name, func() (interface{}, error) { return inspected, err }}
+	}
+	staging := object("staging", map[string]string{"Image": "web:1.0", "Name": "web"}, nil)
+	prod := object("prod", map[string]string{"Image": "web:1.1", "Name": "web"}, nil)
+
+	diff, err := inspectDiff(staging, prod, 80)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if diff == "" {
+		t.Error("Expected differences between staging and prod")
+	}
+	if diff, _ := inspectDiff(staging, staging, 80); diff != "" {
+		t.Errorf("Expected no differences comparing an object with itself, got:\n%s", diff)
+	}
+	if _, err := inspectDiff(staging, object("gone", nil, errors.New("not found")), 80); err == nil {
+		t.Error("Expected an error when an object cannot be inspected")
+	}
+}
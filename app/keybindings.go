@@ -0,0 +1,519 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell"
+)
+
+//keyPress identifies a key, runes have tcell.KeyRune as key
+type keyPress struct {
+	key tcell.Key
+	r   rune
+}
+
+//noKey is the key events are translated to when they must be ignored
+var noKey = keyPress{}
+
+func keyPressOf(event *tcell.EventKey) keyPress {
+	if event.Key() == tcell.KeyRune {
+		return keyPress{tcell.KeyRune, event.Rune()}
+	}
+	return keyPress{key: event.Key()}
+}
+
+func (k keyPress) event() *tcell.EventKey {
+	return tcell.NewEventKey(k.key, k.r, tcell.ModNone)
+}
+
+//namedKeys are the keys, other than runes and Ctrl+<letter>, that can be bound
+var namedKeys = map[string]tcell.Key{
+	"enter":      tcell.KeyEnter,
+	"esc":        tcell.KeyEsc,
+	"tab":        tcell.KeyTab,
+	"backspace":  tcell.KeyBackspace2,
+	"delete":     tcell.KeyDelete,
+	"insert":     tcell.KeyInsert,
+	"home":       tcell.KeyHome,
+	"end":        tcell.KeyEnd,
+	"pgup":       tcell.KeyPgUp,
+	"pgdn":       tcell.KeyPgDn,
+	"arrowup":    tcell.KeyUp,
+	"arrowdown":  tcell.KeyDown,
+	"arrowleft":  tcell.KeyLeft,
+	"arrowright": tcell.KeyRight,
+	"up":         tcell.KeyUp,
+	"down":       tcell.KeyDown,
+	"left":       tcell.KeyLeft,
+	"right":      tcell.KeyRight,
+}
+
+//parseKey parses a key given as a character, Space, Ctrl+<letter>,
+//F1 to F12 or one of the named keys (i.e. Enter, PgUp, ArrowUp)
+func parseKey(s string) (keyPress, error) {
+	if utf8.RuneCountInString(s) == 1 {
+		r, _ := utf8.DecodeRuneInString(s)
+		return keyPress{tcell.KeyRune, r}, nil
+	}
+	lower := strings.ToLower(s)
+	if lower == "space" {
+		return keyPress{tcell.KeyRune, ' '}, nil
+	}
+	if key, ok := namedKeys[lower]; ok {
+		return keyPress{key: key}, nil
+	}
+	if strings.HasPrefix(lower, "ctrl+") && len(lower) == len("ctrl+")+1 {
+		if c := lower[len(lower)-1]; c >= 'a' && c <= 'z' {
+			return keyPress{key: tcell.KeyCtrlA + tcell.Key(c-'a')}, nil
+		}
+	}
+	if strings.HasPrefix(lower, "f") {
+		if n, err := strconv.Atoi(lower[1:]); err == nil && n >= 1 && n <= 12 {
+			return keyPress{key: tcell.KeyF1 + tcell.Key(n-1)}, nil
+		}
+	}
+	return noKey, fmt.Errorf("invalid key %q", s)
+}
+
+//keyScope is a set of views sharing keybindings
+type keyScope struct {
+	views []viewMode
+	//section is the help section describing the keybindings of the scope
+	section string
+}
+
+var (
+	allViews = []viewMode{
+		Main, Images, Networks, Volumes, Plugins, Nodes, Services, Stacks, Tasks, ServiceTasks,
+		StackTasks, Monitor, DiskUsage, SwarmManagement, ContainerMenu, ContainerFiles, StatsPlayback,
+		Ownership, Hosts, ImageLayers, NetworkContainers}
+	listViews = []viewMode{
+		Main, Images, Networks, Volumes, Plugins, Nodes, Services, Stacks, Tasks, ServiceTasks,
+		StackTasks, Monitor}
+)
+
+//keyScopes are the scopes of keybindings, by the name actions are prefixed with
+var keyScopes = map[string]keyScope{
+	"global":     {allViews, "Global keybinds"},
+	"list":       {listViews, "Global list keybinds"},
+	"move":       {allViews, "Move around in lists"},
+	"containers": {[]viewMode{Main}, "Container list keybinds"},
+	"images":     {[]viewMode{Images}, "Image list keybinds"},
+	"networks":   {[]viewMode{Networks}, "Network list keybinds"},
+	"volumes":    {[]viewMode{Volumes}, ""},
+	"plugins":    {[]viewMode{Plugins}, "Plugin list keybinds"},
+	"nodes":      {[]viewMode{Nodes}, "Node list keybinds"},
+	"services":   {[]viewMode{Services}, "Service list keybinds"},
+	"stacks":     {[]viewMode{Stacks}, "Stack list keybinds"},
+	"tasks":      {[]viewMode{Tasks, ServiceTasks, StackTasks}, "Task list keybinds"},
+	"swarm":      {[]viewMode{SwarmManagement}, "Swarm management keybinds"},
+	"monitor":    {[]viewMode{Monitor}, ""},
+	"df":         {[]viewMode{DiskUsage}, ""},
+	"playback":   {[]viewMode{StatsPlayback}, ""},
+	"owners":     {[]viewMode{Ownership}, ""},
+	"hosts":      {[]viewMode{Hosts}, ""},
+	"layers":     {[]viewMode{ImageLayers}, ""},
+	"network":    {[]viewMode{NetworkContainers}, ""},
+	"menu":       {[]viewMode{ContainerMenu}, ""},
+	"files":      {[]viewMode{ContainerFiles}, ""},
+}
+
+//keyAction is an action that is triggered by pressing a key
+type keyAction struct {
+	//name of the action, as <scope>.<action>
+	name string
+	//keys triggering the action, the first one is the one shown on help
+	keys []string
+}
+
+func (a keyAction) scope() keyScope {
+	return keyScopes[a.name[:strings.Index(a.name, ".")]]
+}
+
+//keyActions are the actions whose keys can be changed
+var keyActions = []keyAction{
+	{"global.context", []string{"F6"}},
+	{"global.header", []string{"F7"}},
+	{"global.disk-usage", []string{"F8"}},
+	{"global.events", []string{"F9"}},
+	{"global.info", []string{"F10"}},
+	{"global.containers", []string{"1"}},
+	{"global.images", []string{"2"}},
+	{"global.networks", []string{"3"}},
+	{"global.volumes", []string{"4"}},
+	{"global.nodes", []string{"5"}},
+	{"global.services", []string{"6"}},
+	{"global.stacks", []string{"7"}},
+	{"global.swarm", []string{"8"}},
+	{"global.plugins", []string{"9"}},
+	{"global.hosts", []string{"0"}},
+	{"global.monitor", []string{"m", "M"}},
+	{"global.help", []string{"h", "H", "?"}},
+	{"global.export-keybindings", []string{"K"}},
+	{"global.undo", []string{"u"}},
+	{"global.quit", []string{"Q"}},
+	{"list.sort", []string{"F1"}},
+	{"list.refresh", []string{"F5"}},
+	{"list.filter", []string{"%"}},
+	{"move.up", []string{"ArrowUp", "Ctrl+p", "k"}},
+	{"move.down", []string{"ArrowDown", "Ctrl+n", "j"}},
+	{"move.top", []string{"g"}},
+	{"move.bottom", []string{"G"}},
+	{"containers.show-all", []string{"F2"}},
+	{"containers.group-by-image", []string{"F3"}},
+	{"containers.group-by-project", []string{"F4"}},
+	{"containers.collapse-group", []string{"Space"}},
+	{"containers.remove", []string{"e", "E"}},
+	{"containers.remove-stopped", []string{"Ctrl+e"}},
+	{"containers.kill", []string{"Ctrl+k"}},
+	{"containers.logs", []string{"l", "L"}},
+	{"containers.logs-timestamps", []string{"Ctrl+l"}},
+	{"containers.compare-logs", []string{"c"}},
+	{"containers.restart", []string{"Ctrl+r"}},
+	{"containers.recreate", []string{"R"}},
+	{"containers.clone", []string{"D"}},
+	{"containers.resources", []string{"U"}},
+	{"containers.stats", []string{"s", "S"}},
+	{"containers.stop", []string{"Ctrl+t"}},
+	{"containers.batch-stop", []string{"B"}},
+	{"containers.stop-image", []string{"b"}},
+	{"containers.note", []string{"n"}},
+	{"containers.label-filter", []string{"f"}},
+	{"containers.compose-project", []string{"p"}},
+	{"containers.healthcheck", []string{"t"}},
+	{"containers.health-log", []string{"T"}},
+	{"containers.events", []string{"a"}},
+	{"containers.run-command", []string{"r"}},
+	{"containers.ports", []string{"P"}},
+	{"containers.export-logs", []string{"x", "X"}},
+	{"containers.export-compose", []string{"C"}},
+	{"containers.inspect", []string{"i", "I"}},
+	{"containers.configuration", []string{"v"}},
+	{"containers.copy", []string{"y"}},
+	{"containers.compare", []string{"w"}},
+	{"containers.commands", []string{"Enter"}},
+	{"images.usage-filter", []string{"F2"}},
+	{"images.remove-dangling", []string{"Ctrl+d"}},
+	{"images.remove", []string{"Ctrl+e"}},
+	{"images.force-remove", []string{"Ctrl+f"}},
+	{"images.remove-unused", []string{"Ctrl+u"}},
+	{"images.history", []string{"i", "I"}},
+	{"images.containers", []string{"c"}},
+	{"images.pull", []string{"p", "P"}},
+	{"images.check-updates", []string{"O"}},
+	{"images.pull-newer", []string{"U"}},
+	{"images.run", []string{"r", "R"}},
+	{"images.mark", []string{"Space"}},
+	{"images.note", []string{"n"}},
+	{"images.export", []string{"x"}},
+	{"images.copy", []string{"y"}},
+	{"images.compare", []string{"w"}},
+	{"images.inspect", []string{"Enter"}},
+	{"networks.inspect", []string{"Enter"}},
+	{"networks.remove", []string{"Ctrl+E"}},
+	{"networks.create", []string{"n"}},
+	{"volumes.remove-all", []string{"Ctrl+A"}},
+	{"volumes.remove", []string{"Ctrl+E"}},
+	{"volumes.force-remove", []string{"Ctrl+F"}},
+	{"volumes.remove-unused", []string{"Ctrl+U"}},
+	{"volumes.inspect", []string{"Enter"}},
+	{"plugins.enable", []string{"e", "E"}},
+	{"plugins.disable", []string{"d"}},
+	{"plugins.force-disable", []string{"D"}},
+	{"plugins.inspect", []string{"Enter"}},
+	{"nodes.tasks", []string{"Enter"}},
+	{"nodes.availability", []string{"Ctrl+A"}},
+	{"nodes.role", []string{"Ctrl+O"}},
+	{"nodes.info", []string{"i"}},
+	{"nodes.labels", []string{"L"}},
+	{"nodes.prepull", []string{"P"}},
+	{"nodes.rotate-ca", []string{"C"}},
+	{"services.tasks", []string{"Enter"}},
+	{"services.logs", []string{"l"}},
+	{"services.logs-timestamps", []string{"Ctrl+L"}},
+	{"services.labels", []string{"L"}},
+	{"services.placement", []string{"P"}},
+	{"services.dns", []string{"D"}},
+	{"services.diff-config", []string{"C"}},
+	{"services.remove", []string{"Ctrl+R"}},
+	{"services.scale", []string{"Ctrl+S"}},
+	{"services.replicas", []string{"R"}},
+	{"services.update", []string{"Ctrl+U"}},
+	{"services.export-logs", []string{"x"}},
+	{"services.inspect", []string{"i"}},
+	{"stacks.services", []string{"Enter"}},
+	{"stacks.remove", []string{"Ctrl+R"}},
+	{"tasks.state-filter", []string{"F2"}},
+	{"tasks.inspect", []string{"Enter"}},
+	{"tasks.logs", []string{"l"}},
+	{"tasks.back", []string{"Esc"}},
+	{"swarm.init", []string{"i"}},
+	{"swarm.join", []string{"j"}},
+	{"swarm.leave", []string{"l"}},
+	{"swarm.rotate-worker-token", []string{"r"}},
+	{"swarm.rotate-manager-token", []string{"R"}},
+	{"swarm.copy-worker-join", []string{"c"}},
+	{"swarm.copy-manager-join", []string{"C"}},
+	{"swarm.refresh", []string{"F5"}},
+	{"monitor.refresh-rate", []string{"s"}},
+	{"monitor.export", []string{"x"}},
+	{"monitor.label-filter", []string{"f"}},
+	{"monitor.compose-project", []string{"p"}},
+	{"monitor.commands", []string{"Enter"}},
+	{"monitor.playback", []string{"r"}},
+	{"df.prune", []string{"p", "P"}},
+	{"df.refresh", []string{"F5"}},
+	{"df.owners", []string{"o"}},
+	{"df.category", []string{"Tab"}},
+	{"df.prune-build-cache", []string{"b"}},
+	{"df.remove", []string{"Ctrl+e"}},
+	{"df.page-up", []string{"PgUp"}},
+	{"df.page-down", []string{"PgDn"}},
+	{"playback.reload", []string{"F5"}},
+	{"owners.refresh", []string{"F5"}},
+	{"owners.export", []string{"x"}},
+	{"hosts.switch", []string{"Enter"}},
+	{"hosts.refresh", []string{"F5"}},
+	{"layers.details", []string{"Enter"}},
+	{"network.show-container", []string{"Enter"}},
+	{"network.inspect", []string{"i"}},
+	{"network.refresh", []string{"F5"}},
+	{"menu.run", []string{"Enter"}},
+	{"menu.close", []string{"Esc"}},
+	{"files.open", []string{"Enter"}},
+	{"files.parent", []string{"Backspace", "Ctrl+h"}},
+	{"files.refresh", []string{"F5"}},
+	{"files.filter", []string{"%"}},
+	{"files.back", []string{"Esc"}},
+}
+
+//boundAction is an action and the key it has been bound to
+type boundAction struct {
+	keyAction
+	defaults []keyPress
+	//key is the key the action is bound to, noKey if it keeps its defaults
+	key     keyPress
+	keyName string
+}
+
+//effectiveKeys returns the keys that trigger this action
+func (a boundAction) effectiveKeys() []keyPress {
+	if a.key != noKey {
+		return []keyPress{a.key}
+	}
+	return a.defaults
+}
+
+//keyMap translates the keys pressed to the keys of the actions they are
+//bound to, the zero value (and nil) keeps the default keybindings
+type keyMap struct {
+	actions []boundAction
+	//translations are the keys translated on each view, to noKey if the
+	//key no longer triggers the action it triggered by default
+	translations map[viewMode]map[keyPress]keyPress
+}
+
+//newKeyMap creates a keyMap binding the given actions to the given keys,
+//an error is returned if any action is unknown, any key invalid, or a key
+//is bound to more than one action available on the same view
+func newKeyMap(bindings map[string]string) (*keyMap, error) {
+	km := &keyMap{translations: make(map[viewMode]map[keyPress]keyPress)}
+	known := make(map[string]bool)
+	for _, action := range keyActions {
+		bound := boundAction{keyAction: action}
+		for _, k := range action.keys {
+			key, err := parseKey(k)
+			if err != nil {
+				return nil, err
+			}
+			bound.defaults = append(bound.defaults, key)
+		}
+		if name, ok := bindings[action.name]; ok {
+			key, err := parseKey(name)
+			if err != nil {
+				return nil, fmt.Errorf("invalid key for %s: %s", action.name, err)
+			}
+			bound.key = key
+			bound.keyName = name
+		}
+		known[action.name] = true
+		km.actions = append(km.actions, bound)
+	}
+	for name := range bindings {
+		if !known[name] {
+			return nil, fmt.Errorf("unknown action %q", name)
+		}
+	}
+	if err := km.checkConflicts(); err != nil {
+		return nil, err
+	}
+	for _, v := range allViews {
+		km.translations[v] = km.viewTranslations(v)
+	}
+	return km, nil
+}
+
+//viewActions returns the actions available on the given view
+func (km *keyMap) viewActions(v viewMode) []boundAction {
+	var actions []boundAction
+	for _, a := range km.actions {
+		for _, view := range a.scope().views {
+			if view == v {
+				actions = append(actions, a)
+				break
+			}
+		}
+	}
+	return actions
+}
+
+//keyName returns the name of the key triggering the given action
+func (km *keyMap) keyName(action string) string {
+	if km != nil {
+		for _, a := range km.actions {
+			if a.name == action && a.key != noKey {
+				return a.keyName
+			}
+		}
+	}
+	for _, a := range keyActions {
+		if a.name == action {
+			return a.keys[0]
+		}
+	}
+	return ""
+}
+
+//checkConflicts checks that the keys bound are not triggering other actions
+//available on the same view
+func (km *keyMap) checkConflicts() error {
+	for _, v := range allViews {
+		actions := km.viewActions(v)
+		for i, a := range actions {
+			if a.key == noKey {
+				continue
+			}
+			for j, other := range actions {
+				if i == j {
+					continue
+				}
+				for _, k := range other.effectiveKeys() {
+					if k == a.key {
+						return fmt.Errorf("key %s of %s is also bound to %s", a.keyName, a.name, other.name)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+//viewTranslations returns the translations of the keys pressed on the given view
+func (km *keyMap) viewTranslations(v viewMode) map[keyPress]keyPress {
+	actions := km.viewActions(v)
+	bound := make(map[keyPress]bool)
+	for _, a := range actions {
+		for _, k := range a.effectiveKeys() {
+			bound[k] = true
+		}
+	}
+	translations := make(map[keyPress]keyPress)
+	for _, a := range actions {
+		if a.key == noKey {
+			continue
+		}
+		for _, k := range a.defaults {
+			if !bound[k] {
+				translations[k] = noKey
+			}
+		}
+	}
+	for _, a := range actions {
+		if a.key != noKey && a.key != a.defaults[0] {
+			translations[a.key] = a.defaults[0]
+		}
+	}
+	return translations
+}
+
+//translate returns the event that triggers, on the given view, the action the
+//given event is bound to, nil if the event must be ignored
+func (km *keyMap) translate(v viewMode, event *tcell.EventKey) *tcell.EventKey {
+	if km == nil {
+		return event
+	}
+	k, ok := km.translations[v][keyPressOf(event)]
+	if !ok {
+		return event
+	}
+	if k == noKey {
+		return nil
+	}
+	return k.event()
+}
+
+var footerKeyRegexp = regexp.MustCompile(`\[([^\]]+)\]:`)
+
+//footer returns the given footer of the given view showing the keys bound
+func (km *keyMap) footer(v viewMode, footer string) string {
+	if km == nil {
+		return footer
+	}
+	actions := km.viewActions(v)
+	return footerKeyRegexp.ReplaceAllStringFunc(footer, func(s string) string {
+		name := footerKeyRegexp.FindStringSubmatch(s)[1]
+		key, err := parseKey(name)
+		if err != nil {
+			return s
+		}
+		for _, a := range actions {
+			if a.key != noKey && a.defaults[0] == key {
+				return "[" + a.keyName + "]:"
+			}
+		}
+		return s
+	})
+}
+
+//helpText returns the given help text showing the keys bound
+func (km *keyMap) helpText(help string) string {
+	if km == nil {
+		return help
+	}
+	keys := make(map[string]map[string]string)
+	for _, a := range km.actions {
+		if a.key == noKey || a.scope().section == "" {
+			continue
+		}
+		section := a.scope().section
+		if keys[section] == nil {
+			keys[section] = make(map[string]string)
+		}
+		keys[section][a.keys[0]] = a.keyName
+	}
+	lines := strings.Split(help, "\n")
+	section := ""
+	for i, line := range lines {
+		if m := helpSectionRegexp.FindStringSubmatch(line); m != nil {
+			section = stripMarkup(m[1])
+		} else if m := helpKeybindRegexp.FindStringSubmatch(line); m != nil {
+			if key, ok := keys[section][m[1]]; ok {
+				lines[i] = fmt.Sprintf("\t<white>%s</>%s%s",
+					key, strings.Repeat(" ", helpKeyPadding(key)), m[2])
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+//helpKeyPadding returns the number of spaces after the given key on a help line
+func helpKeyPadding(key string) int {
+	if n := 10 - utf8.RuneCountInString(key); n > 0 {
+		return n
+	}
+	return 1
+}
@@ -0,0 +1,240 @@
+package app
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell"
+)
+
+func Test_parseKey(t *testing.T) {
+	tests := []struct {
+		key     string
+		want    keyPress
+		wantErr bool
+	}{
+		{"e", keyPress{tcell.KeyRune, 'e'}, false},
+		{"%", keyPress{tcell.KeyRune, '%'}, false},
+		{"Space", keyPress{tcell.KeyRune, ' '}, false},
+		{"Ctrl+e", keyPress{key: tcell.KeyCtrlE}, false},
+		{"Ctrl+E", keyPress{key: tcell.KeyCtrlE}, false},
+		{"F10", keyPress{key: tcell.KeyF10}, false},
+		{"enter", keyPress{key: tcell.KeyEnter}, false},
+		{"ArrowUp", keyPress{key: tcell.KeyUp}, false},
+		{"F13", noKey, true},
+		{"Ctrl+1", noKey, true},
+		{"Alt+e", noKey, true},
+		{"", noKey, true},
+	}
+	for _, tt := range tests {
+		got, err := parseKey(tt.key)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseKey(%q) error = %v, wantErr %v", tt.key, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("parseKey(%q) = %v, want %v", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestDefaultKeyMap(t *testing.T) {
+	km, err := newKeyMap(nil)
+	if err != nil {
+		t.Fatalf("Unexpected error creating the default key map: %s", err)
+	}
+	event := tcell.NewEventKey(tcell.KeyRune, 'e', tcell.ModNone)
+	if got := km.translate(Main, event); got != event {
+		t.Errorf("Default key map translated %v to %v", event, got)
+	}
+	if km.helpText(help) != help {
+		t.Error("Default key map changed the help text")
+	}
+	if km.footer(Main, keyMappings) != keyMappings {
+		t.Error("Default key map changed the footer")
+	}
+	var nilKeyMap *keyMap
+	if got := nilKeyMap.translate(Main, event); got != event {
+		t.Errorf("Nil key map translated %v to %v", event, got)
+	}
+}
+
+func TestKeyMapTranslate(t *testing.T) {
+	km, err := newKeyMap(map[string]string{
+		"containers.remove": "d",
+		"containers.kill":   "Ctrl+x",
+		"global.monitor":    "M",
+		"move.down":         "J",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error creating the key map: %s", err)
+	}
+	tests := []struct {
+		view  viewMode
+		event *tcell.EventKey
+		want  *tcell.EventKey
+	}{
+		{Main, tcell.NewEventKey(tcell.KeyRune, 'd', tcell.ModNone), tcell.NewEventKey(tcell.KeyRune, 'e', tcell.ModNone)},
+		{Main, tcell.NewEventKey(tcell.KeyRune, 'e', tcell.ModNone), nil},
+		{Main, tcell.NewEventKey(tcell.KeyRune, 'E', tcell.ModNone), nil},
+		{Main, tcell.NewEventKey(tcell.KeyCtrlX, 0, tcell.ModCtrl), tcell.NewEventKey(tcell.KeyCtrlK, 0, tcell.ModNone)},
+		{Main, tcell.NewEventKey(tcell.KeyCtrlK, 0, tcell.ModCtrl), nil},
+		//containers keys are not bound on other views
+		{Images, tcell.NewEventKey(tcell.KeyRune, 'd', tcell.ModNone), tcell.NewEventKey(tcell.KeyRune, 'd', tcell.ModNone)},
+		//a default key can be bound to the same action
+		{Images, tcell.NewEventKey(tcell.KeyRune, 'M', tcell.ModNone), tcell.NewEventKey(tcell.KeyRune, 'm', tcell.ModNone)},
+		{Images, tcell.NewEventKey(tcell.KeyRune, 'm', tcell.ModNone), nil},
+		{Images, tcell.NewEventKey(tcell.KeyRune, 'J', tcell.ModNone), tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone)},
+		{Images, tcell.NewEventKey(tcell.KeyRune, 'j', tcell.ModNone), nil},
+		//keys of other actions of the view are still theirs
+		{SwarmManagement, tcell.NewEventKey(tcell.KeyRune, 'j', tcell.ModNone), tcell.NewEventKey(tcell.KeyRune, 'j', tcell.ModNone)},
+	}
+	for _, tt := range tests {
+		got := km.translate(tt.view, tt.event)
+		if (got == nil) != (tt.want == nil) {
+			t.Errorf("On %s, translate(%s) = %v, want %v", tt.view, tt.event.Name(), got, tt.want)
+			continue
+		}
+		if got != nil && (got.Key() != tt.want.Key() || got.Rune() != tt.want.Rune()) {
+			t.Errorf("On %s, translate(%s) = %s, want %s", tt.view, tt.event.Name(), got.Name(), tt.want.Name())
+		}
+	}
+}
+
+func TestKeyMapErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		bindings map[string]string
+	}{
+		{"unknown action", map[string]string{"containers.explode": "x"}},
+		{"invalid key", map[string]string{"containers.remove": "Alt+e"}},
+		{"bound to an action of the view", map[string]string{"containers.remove": "l"}},
+		{"bound to a global action", map[string]string{"containers.remove": "m"}},
+		{"bound to two actions", map[string]string{"images.run": "z", "images.pull": "z"}},
+		{"global bound to an action of a view", map[string]string{"global.help": "B"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newKeyMap(tt.bindings); err == nil {
+				t.Errorf("Expected an error creating a key map with %v", tt.bindings)
+			}
+		})
+	}
+}
+
+func TestKeyMapHelpAndFooter(t *testing.T) {
+	km, err := newKeyMap(map[string]string{
+		"containers.remove": "d",
+		"list.sort":         "o",
+		"nodes.role":        "Ctrl+w",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error creating the key map: %s", err)
+	}
+	helpText := km.helpText(help)
+	for _, want := range []string{
+		"\t<white>d</>         Removes the selected container",
+		"\t<white>o</>         Cycles through sort modes",
+		"\t<white>Ctrl+w</>    Changes the role of the selected node",
+	} {
+		if !strings.Contains(helpText, want) {
+			t.Errorf("Help does not show the keys bound, %q not found", want)
+		}
+	}
+	//the key of the buffers is not changed
+	if !strings.Contains(helpText, "\t<white>e</>         Only show events matching a filter") {
+		t.Error("Help changed a key that was not bound")
+	}
+	if footer := km.footer(Main, keyMappings); !strings.Contains(footer, "[o]:<darkgrey>Sort") {
+		t.Errorf("Footer does not show the keys bound: %s", footer)
+	}
+	if footer := km.footer(Nodes, nodeKeyMappings); !strings.Contains(footer, "[Ctrl+w]:<darkgrey>Set Role") {
+		t.Errorf("Footer does not show the keys bound: %s", footer)
+	}
+}
+
+//eventFiles are the files handling the keys pressed on each view
+var eventFiles = map[string]viewMode{
+	"cmenu_events.go":           ContainerMenu,
+	"container_events.go":       Main,
+	"container_files_events.go": ContainerFiles,
+	"df_events.go":              DiskUsage,
+	"image_events.go":           Images,
+	"monitor_events.go":         Monitor,
+	"network_events.go":         Networks,
+	"node_events.go":            Nodes,
+	"nodetasks_events.go":       Tasks,
+	"plugin_events.go":          Plugins,
+	"service_events.go":         Services,
+	"servicetasks_events.go":    ServiceTasks,
+	"stack_events.go":           Stacks,
+	"stacktasks_events.go":      StackTasks,
+	"swarm_events.go":           SwarmManagement,
+	"volume_events.go":          Volumes,
+}
+
+//handledKeys returns the keys on the case clauses of the given file
+func handledKeys(t *testing.T, file string) []keyPress {
+	f, err := parser.ParseFile(token.NewFileSet(), file, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var keys []keyPress
+	ast.Inspect(f, func(n ast.Node) bool {
+		clause, ok := n.(*ast.CaseClause)
+		if !ok {
+			return true
+		}
+		for _, expr := range clause.List {
+			switch e := expr.(type) {
+			case *ast.BasicLit:
+				if e.Kind == token.CHAR {
+					r, _, _, err := strconv.UnquoteChar(e.Value[1:len(e.Value)-1], '\'')
+					if err != nil {
+						t.Fatal(err)
+					}
+					keys = append(keys, keyPress{tcell.KeyRune, r})
+				}
+			case *ast.SelectorExpr:
+				if pkg, ok := e.X.(*ast.Ident); !ok || pkg.Name != "tcell" {
+					continue
+				}
+				found := false
+				for key, name := range tcell.KeyNames {
+					if "Key"+strings.Replace(name, "-", "", -1) == e.Sel.Name {
+						keys = append(keys, keyPress{key: key})
+						found = true
+					}
+				}
+				if !found {
+					t.Errorf("Unknown key %s on %s", e.Sel.Name, file)
+				}
+			}
+		}
+		return true
+	})
+	return keys
+}
+
+func TestEveryHandledKeyCanBeRemapped(t *testing.T) {
+	km, err := newKeyMap(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for file, view := range eventFiles {
+		bound := make(map[keyPress]bool)
+		for _, a := range km.viewActions(view) {
+			for _, k := range a.defaults {
+				bound[k] = true
+			}
+		}
+		for _, k := range handledKeys(t, file) {
+			if !bound[k] {
+				t.Errorf("Key %s handled on %s has no action on the key map", k.event().Name(), file)
+			}
+		}
+	}
+}
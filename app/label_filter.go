@@ -0,0 +1,73 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+)
+
+//labelFilterable is a container widget that can be filtered by label
+type labelFilterable interface {
+	FilterByLabel(*docker.LabelFilter)
+	LabelFilter() *docker.LabelFilter
+	OnEvent(appui.EventCommand) error
+}
+
+//showLabelFilterInput asks for the label filter, as key or key=value, to
+//apply to the given widget, a blank filter removes the current one
+func showLabelFilterInput(dry *Dry, w labelFilterable, es ui.EventSource, onDone func()) {
+	current := ""
+	if filter := w.LabelFilter(); filter != nil {
+		current = filter.String()
+	}
+	prompt := appui.NewPromptWithText("Filter by label? (key or key=value, blank to remove current filter)", current)
+	widgets.add(prompt)
+	go func() {
+		defer onDone()
+		prompt.OnFocus(es)
+		widgets.remove(prompt)
+		input, canceled := prompt.Text()
+		if canceled {
+			return
+		}
+		if input == "" {
+			w.FilterByLabel(nil)
+			return
+		}
+		filter, err := docker.ParseLabelFilter(input)
+		if err != nil {
+			dry.message(fmt.Sprintf("Error filtering by label: %s", err.Error()))
+			return
+		}
+		w.FilterByLabel(&filter)
+	}()
+}
+
+//toggleComposeProjectFilter filters the given widget by the Docker Compose
+//project of the selected container, if the widget is already filtered by a
+//project the filter is removed instead
+func toggleComposeProjectFilter(dry *Dry, w labelFilterable) {
+	if filter := w.LabelFilter(); filter != nil && filter.Key == docker.ComposeProjectLabel {
+		w.FilterByLabel(nil)
+		dry.message("Compose project filter removed")
+		return
+	}
+	err := w.OnEvent(func(id string) error {
+		container := dry.dockerDaemon.ContainerByID(id)
+		if container == nil {
+			return fmt.Errorf("Container with id %s not found", id)
+		}
+		filter, ok := docker.ComposeProjectFilter(container)
+		if !ok {
+			return fmt.Errorf("container %s does not belong to a Docker Compose project", docker.TruncateID(id))
+		}
+		w.FilterByLabel(&filter)
+		dry.message(fmt.Sprintf("Showing containers of compose project <white>%s</>", filter.Value))
+		return nil
+	})
+	if err != nil {
+		dry.message(fmt.Sprintf("There was an error filtering by compose project: %s", err.Error()))
+	}
+}
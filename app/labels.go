@@ -0,0 +1,53 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+)
+
+//editLabels shows a prompt to edit the given labels. If the labels are
+//changed, the new labels are given to the apply func and the changes
+//are reported on the dry output channel.
+func editLabels(
+	dry *Dry,
+	h eventHandler,
+	f func(eventHandler),
+	object string,
+	labels map[string]string,
+	apply func(map[string]string) error) {
+
+	prompt := appui.NewPromptWithText(
+		fmt.Sprintf("Labels of %s (key=value, space separated, values with spaces double-quoted)", object),
+		docker.LabelsString(labels))
+	widgets.add(prompt)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+	go func() {
+		prompt.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(prompt)
+		text, canceled := prompt.Text()
+		f(h)
+		defer refreshScreen()
+		if canceled {
+			return
+		}
+		newLabels, err := docker.ParseLabels(text)
+		if err != nil {
+			dry.message(fmt.Sprintf("Labels of %s not changed: %s", object, err.Error()))
+			return
+		}
+		changes := docker.LabelChanges(labels, newLabels)
+		if len(changes) == 0 {
+			return
+		}
+		if err := apply(newLabels); err != nil {
+			dry.message(fmt.Sprintf("Could not change labels of %s: %s", object, err.Error()))
+			return
+		}
+		dry.message(fmt.Sprintf("Labels of %s changed: %s", object, strings.Join(changes, " ")))
+	}()
+}
@@ -0,0 +1,147 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+	pkgError "github.com/pkg/errors"
+)
+
+//stderrSuffix is appended to the export file name to write stderr when streams are separated
+const stderrSuffix = ".stderr"
+
+//logsExport describes how logs are exported to a file
+type logsExport struct {
+	path            string
+	timestamps      bool
+	separateStreams bool
+}
+
+func logsExportPrompt(name string) *appui.Prompt {
+	return appui.NewPromptWithText(
+		"Export logs: file=<path> timestamps=<true|false> streams=<combined|separate>",
+		fmt.Sprintf("file=%s.log timestamps=false streams=combined", name))
+}
+
+//parseLogsExport parses the export options typed on a logs export prompt, a
+//value given without key is taken as the file path
+func parseLogsExport(s string) (logsExport, error) {
+	var export logsExport
+	for _, field := range strings.Fields(s) {
+		key, value := "file", field
+		if i := strings.Index(field, "="); i >= 0 {
+			key, value = field[:i], field[i+1:]
+		}
+		switch key {
+		case "file":
+			export.path = value
+		case "timestamps":
+			timestamps, err := strconv.ParseBool(value)
+			if err != nil {
+				return export, fmt.Errorf("invalid timestamps value: %q", value)
+			}
+			export.timestamps = timestamps
+		case "streams":
+			switch value {
+			case "combined":
+				export.separateStreams = false
+			case "separate":
+				export.separateStreams = true
+			default:
+				return export, fmt.Errorf("invalid streams value: %q", value)
+			}
+		default:
+			return export, fmt.Errorf("unknown export option: %q", key)
+		}
+	}
+	if export.path == "" {
+		return export, fmt.Errorf("no file given")
+	}
+	return export, nil
+}
+
+//logsOptions returns the options to retrieve the full logs for this export
+func (e logsExport) logsOptions() docker.LogsOptions {
+	return docker.LogsOptions{Tail: "all", Timestamps: e.timestamps}
+}
+
+//write writes the given logs to the export file. Logs of containers without
+//a TTY are multiplexed, if streams are separated stderr goes to a second file
+//named after the export file. Returns the names of the files written.
+func (e logsExport) write(logs io.Reader, multiplexed bool) ([]string, error) {
+	stdout, err := os.Create(e.path)
+	if err != nil {
+		return nil, pkgError.Wrap(err, "error creating export file")
+	}
+	defer stdout.Close()
+	files := []string{e.path}
+
+	if !multiplexed {
+		_, err = io.Copy(stdout, logs)
+		return files, pkgError.Wrap(err, "error exporting logs")
+	}
+	stderr := io.Writer(stdout)
+	if e.separateStreams {
+		f, err := os.Create(e.path + stderrSuffix)
+		if err != nil {
+			return files, pkgError.Wrap(err, "error creating export file")
+		}
+		defer f.Close()
+		stderr = f
+		files = append(files, f.Name())
+	}
+	_, err = stdcopy.StdCopy(stdout, stderr, logs)
+	return files, pkgError.Wrap(err, "error exporting logs")
+}
+
+//exportLogs shows a prompt to export the logs retrieved with the given func
+//to a file, once done the given handler handles events again
+func exportLogs(dry *Dry, h eventHandler, f func(eventHandler), name string, multiplexed bool,
+	fetch func(docker.LogsOptions) (io.ReadCloser, error)) {
+	prompt := logsExportPrompt(name)
+	widgets.add(prompt)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+	go func() {
+		prompt.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(prompt)
+		text, canceled := prompt.Text()
+		f(h)
+		defer refreshScreen()
+		if canceled {
+			return
+		}
+		export, err := parseLogsExport(text)
+		if err != nil {
+			dry.message("Error exporting logs: " + err.Error())
+			return
+		}
+		logs, err := fetch(export.logsOptions())
+		if err != nil {
+			dry.message("Error exporting logs: " + err.Error())
+			return
+		}
+		defer logs.Close()
+		files, err := export.write(logs, multiplexed)
+		if err != nil {
+			dry.message(err.Error())
+			return
+		}
+		dry.message("Logs exported to " + strings.Join(files, ", "))
+	}()
+}
+
+//containerName returns the name of the given container, or its short id if it has no name
+func containerName(c *docker.Container) string {
+	if len(c.Names) > 0 {
+		return strings.TrimPrefix(c.Names[0], "/")
+	}
+	return docker.TruncateID(c.ID)
+}
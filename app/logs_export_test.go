@@ -0,0 +1,87 @@
+package app
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+func Test_parseLogsExport(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    logsExport
+		wantErr bool
+	}{
+		{
+			"file only",
+			"web.log",
+			logsExport{path: "web.log"},
+			false,
+		},
+		{
+			"all options",
+			"file=web.log timestamps=true streams=separate",
+			logsExport{path: "web.log", timestamps: true, separateStreams: true},
+			false,
+		},
+		{
+			"no file",
+			"timestamps=true",
+			logsExport{timestamps: true},
+			true,
+		},
+		{
+			"invalid streams",
+			"file=web.log streams=both",
+			logsExport{path: "web.log"},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseLogsExport(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseLogsExport() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("parseLogsExport() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_logsExport_write(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dry")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	logs := new(bytes.Buffer)
+	stdcopy.NewStdWriter(logs, stdcopy.Stdout).Write([]byte("out\n"))
+	stdcopy.NewStdWriter(logs, stdcopy.Stderr).Write([]byte("err\n"))
+
+	export := logsExport{path: filepath.Join(dir, "logs"), separateStreams: true}
+	files, err := export.write(logs, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("Expected logs to be exported to 2 files, got %v", files)
+	}
+	for i, want := range []string{"out\n", "err\n"} {
+		got, err := ioutil.ReadFile(files[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != want {
+			t.Errorf("Unexpected content on %s, got %q, want %q", files[i], got, want)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+)
+
+//maxLogPanes is the maximum number of containers whose logs are shown at once
+const maxLogPanes = 4
+
+//resolveLogContainers returns, from the given containers, the ones with the
+//given names or IDs, an ID prefix is enough to identify a container
+func resolveLogContainers(containers []*docker.Container, refs []string) ([]*docker.Container, error) {
+	if len(refs) == 0 {
+		return nil, fmt.Errorf("no containers given")
+	}
+	if len(refs) > maxLogPanes {
+		return nil, fmt.Errorf("logs of up to %d containers can be shown at once", maxLogPanes)
+	}
+	var result []*docker.Container
+	for _, ref := range refs {
+		c, err := resolveContainer(containers, ref)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, c)
+	}
+	return result, nil
+}
+
+//resolveContainer returns, from the given containers, the one with the given
+//name or ID, an ID prefix is enough to identify a container
+func resolveContainer(containers []*docker.Container, ref string) (*docker.Container, error) {
+	var found []*docker.Container
+	for _, c := range containers {
+		if containerName(c) == ref || c.ID == ref {
+			return c, nil
+		}
+		if strings.HasPrefix(c.ID, ref) {
+			found = append(found, c)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("container %s not found", ref)
+	case 1:
+		return found[0], nil
+	default:
+		return nil, fmt.Errorf("%s matches %d containers", ref, len(found))
+	}
+}
+
+//compareLogs asks for the containers whose logs are shown, starting with the
+//given one, and for the logs options, then it shows the logs of each container
+//on its own pane
+func (h *containersScreenEventHandler) compareLogs(selected *docker.Container, f func(eventHandler)) {
+	dry := h.dry
+	forwarder := newEventForwarder()
+	ask := func(prompt *appui.Prompt) (string, bool) {
+		widgets.add(prompt)
+		f(forwarder)
+		refreshScreen()
+		prompt.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(prompt)
+		return prompt.Text()
+	}
+	closeLogs := func(err error) {
+		f(h)
+		if err != nil {
+			dry.message("Error showing container logs: " + err.Error())
+		}
+		refreshScreen()
+	}
+	go func() {
+		text, canceled := ask(appui.NewPromptWithText(
+			fmt.Sprintf("Show logs of (up to %d container names or IDs)", maxLogPanes),
+			containerName(selected)+" "))
+		if canceled {
+			closeLogs(nil)
+			return
+		}
+		containers, err := resolveLogContainers(
+			dry.dockerDaemon.Containers(nil, docker.NoSort), strings.Fields(text))
+		if err != nil {
+			closeLogs(err)
+			return
+		}
+		text, canceled = ask(logsPrompt())
+		if canceled {
+			closeLogs(nil)
+			return
+		}
+		opts, err := parseLogsOptions(text, false)
+		if err != nil {
+			closeLogs(err)
+			return
+		}
+		panes := make([]appui.LogsPane, len(containers))
+		for i, c := range containers {
+			panes[i] = appui.LogsPane{
+				Title:  containerName(c),
+				Source: logsSource(dry.dockerDaemon.Logs, c.ID, opts),
+			}
+		}
+		dry.pushView(NoView)
+		err = appui.StreamLogsPanes(panes, opts.Timestamps, opts.Follow, forwarder.events(), func() {
+			dry.popView()
+			closeLogs(nil)
+		})
+		if err != nil {
+			dry.popView()
+			closeLogs(err)
+		}
+	}()
+}
@@ -0,0 +1,47 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/moncho/dry/docker"
+)
+
+func TestResolveLogContainers(t *testing.T) {
+	container := func(id, name string) *docker.Container {
+		return &docker.Container{Container: types.Container{ID: id, Names: []string{"/" + name}}}
+	}
+	containers := []*docker.Container{
+		container("abc123", "web"),
+		container("abd456", "db"),
+		container("fff789", "cache"),
+	}
+	tests := []struct {
+		refs    []string
+		want    []string
+		wantErr bool
+	}{
+		{[]string{"web", "db"}, []string{"abc123", "abd456"}, false},
+		{[]string{"fff", "abc123"}, []string{"fff789", "abc123"}, false},
+		{[]string{"ab"}, nil, true},
+		{[]string{"web", "nope"}, nil, true},
+		{[]string{"web", "db", "cache", "web", "db"}, nil, true},
+		{nil, nil, true},
+	}
+	for _, tt := range tests {
+		got, err := resolveLogContainers(containers, tt.refs)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("resolveLogContainers(%v) error = %v, wantErr %v", tt.refs, err, tt.wantErr)
+			continue
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("resolveLogContainers(%v) returned %d containers, want %d", tt.refs, len(got), len(tt.want))
+			continue
+		}
+		for i, c := range got {
+			if c.ID != tt.want[i] {
+				t.Errorf("resolveLogContainers(%v)[%d] = %s, want %s", tt.refs, i, c.ID, tt.want[i])
+			}
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/gdamore/tcell"
+	"github.com/moncho/dry/docker"
 	"github.com/moncho/dry/ui"
 	log "github.com/sirupsen/logrus"
 )
@@ -60,6 +61,9 @@ func RenderLoop(dry *Dry) {
 	}()
 
 	refreshScreen()
+	if dry.viewMode() == DiskUsage {
+		computeDiskUsage(dry)
+	}
 
 	go func() {
 		statusBar := widgets.MessageBar
@@ -76,6 +80,20 @@ func RenderLoop(dry *Dry) {
 		}
 	}()
 
+	go warnAboutDaemon(dry)
+	go checkDaemonHealth(dry)
+	if dry.cleanup != nil {
+		go runCleanups(dry, dry.cleanup)
+	}
+	if dry.config.UpdateCheckInterval > 0 {
+		go scheduleImageUpdateChecks(dry, dry.config.UpdateCheckInterval)
+	}
+
+	if !dry.refreshUnfocused {
+		ui.EnableFocusReporting()
+		defer ui.DisableFocusReporting()
+	}
+
 	handler := viewsToHandlers[dry.viewMode()]
 	//main loop that handles termui events
 loop:
@@ -85,13 +103,29 @@ loop:
 			break loop
 		case *tcell.EventKey:
 			//Ctrl+C breaks the loop (and exits dry) no matter what
-			if ev.Key() == tcell.KeyCtrlC || ev.Rune() == 'Q' {
+			if ev.Key() == tcell.KeyCtrlC {
+				break loop
+			}
+			//keys are bound to actions on views, not on prompts or buffers
+			if _, forwarding := handler.(eventHandlerForwarder); !forwarding {
+				if ev = dry.keys.translate(dry.viewMode(), ev); ev == nil {
+					continue
+				}
+				//actions changing the Docker host are refused before asking for confirmation
+				if dry.readOnly && dry.keys.mutates(dry.viewMode(), ev) {
+					dry.message(ui.Red(docker.ErrReadOnly.Error()))
+					continue
+				}
+			}
+			if ev.Rune() == 'Q' {
 				break loop
 			}
 			handler.handle(ev, func(eh eventHandler) {
 				handler = eh
 			})
 
+		case *ui.EventFocus:
+			dry.setFocused(ev.Focused)
 		case *tcell.EventResize:
 			screen.Resize()
 			//Reload dry ui elements
@@ -114,4 +148,8 @@ loop:
 	close(renderChan)
 	//Wait for the rendering goroutine to exit
 	wg.Wait()
+
+	if dry.title != nil {
+		dry.title.restore()
+	}
 }
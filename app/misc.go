@@ -1,15 +1,66 @@
 package app
 
 import (
+	"fmt"
+	"io"
+	"strconv"
 	"strings"
 
 	"github.com/gdamore/tcell"
 	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
 	"github.com/moncho/dry/ui"
 )
 
+//defaultLogsTail is the number of lines shown by default from the end of the logs
+const defaultLogsTail = "500"
+
 func logsPrompt() *appui.Prompt {
-	return appui.NewPrompt("Show logs since timestamp (e.g. 2013-01-02T13:23:37) or relative (e.g. 42m for 42 minutes) or leave empty")
+	return appui.NewPromptWithText(
+		"Show logs: since=<timestamp (e.g. 2013-01-02T13:23:37) or relative (e.g. 42m)> tail=<lines|all> follow=<true|false>",
+		fmt.Sprintf("tail=%s follow=true", defaultLogsTail))
+}
+
+//parseLogsOptions parses the logs options typed on a logs prompt, a value
+//given without key is taken as the since value
+func parseLogsOptions(s string, withTimestamps bool) (docker.LogsOptions, error) {
+	opts := docker.LogsOptions{
+		Tail:       "all",
+		Timestamps: withTimestamps,
+	}
+	for _, field := range strings.Fields(s) {
+		key, value := "since", field
+		if i := strings.Index(field, "="); i >= 0 {
+			key, value = field[:i], field[i+1:]
+		}
+		switch key {
+		case "since":
+			opts.Since = curateLogsDuration(value)
+		case "tail":
+			if _, err := strconv.Atoi(value); err != nil && value != "all" {
+				return opts, fmt.Errorf("invalid tail value: %q", value)
+			}
+			opts.Tail = value
+		case "follow":
+			follow, err := strconv.ParseBool(value)
+			if err != nil {
+				return opts, fmt.Errorf("invalid follow value: %q", value)
+			}
+			opts.Follow = follow
+		default:
+			return opts, fmt.Errorf("unknown logs option: %q", key)
+		}
+	}
+	return opts, nil
+}
+
+//logsSource returns a source of the logs of the object with the given id,
+//retrieved with the given func and options
+func logsSource(logs func(string, docker.LogsOptions) (io.ReadCloser, error), id string, opts docker.LogsOptions) appui.LogsSource {
+	return func(timestamps bool) (io.ReadCloser, error) {
+		opts.Timestamps = timestamps
+		return logs(id, opts)
+	}
 }
 
 func newEventSource(events <-chan *tcell.EventKey) ui.EventSource {
@@ -21,7 +72,10 @@ func newEventSource(events <-chan *tcell.EventKey) ui.EventSource {
 	}
 }
 
+//inspect returns a func showing the object with the given id as inspected by
+//the given func, the view is pushed on the navigation stack until closed
 func inspect(
+	dry *Dry,
 	screen *ui.Screen,
 	events <-chan *tcell.EventKey,
 	inspect func(id string) (interface{}, error),
@@ -31,8 +85,11 @@ func inspect(
 		if err != nil {
 			return err
 		}
-		renderer := appui.NewJSONRenderer(inspected)
-		go appui.Less(renderer.String(), screen, events, onClose)
+		dry.pushView(NoView)
+		go appui.Inspect("", inspected, screen, events, func() {
+			dry.popView()
+			onClose()
+		})
 		return nil
 	}
 }
@@ -1,6 +1,11 @@
 package app
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+
+	"github.com/moncho/dry/docker"
+)
 
 func Test_curateLogsDuration(t *testing.T) {
 	type args struct {
@@ -34,3 +39,55 @@ func Test_curateLogsDuration(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseLogsOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    docker.LogsOptions
+		wantErr bool
+	}{
+		{
+			"empty, all logs are shown",
+			"",
+			docker.LogsOptions{Tail: "all"},
+			false,
+		},
+		{
+			"since without key",
+			"-42m",
+			docker.LogsOptions{Since: "42m", Tail: "all"},
+			false,
+		},
+		{
+			"tail, since and follow",
+			"since=10m tail=500 follow=true",
+			docker.LogsOptions{Since: "10m", Tail: "500", Follow: true},
+			false,
+		},
+		{
+			"invalid tail",
+			"tail=some",
+			docker.LogsOptions{},
+			true,
+		},
+		{
+			"unknown option",
+			"lines=4",
+			docker.LogsOptions{},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseLogsOptions(tt.text, false)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseLogsOptions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseLogsOptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
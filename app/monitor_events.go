@@ -33,18 +33,7 @@ func (h *monitorScreenEventHandler) handle(event *tcell.EventKey, f func(eventHa
 		h.widget.OnEvent(nil)
 	case tcell.KeyEnter: //Container menu
 		showMenu := func(id string) error {
-			h.widget.Unmount()
-			h.screen.Cursor().Reset()
-			widgets.ContainerMenu.ForContainer(id)
-			widgets.ContainerMenu.OnUnmount = func() error {
-				h.screen.Cursor().Reset()
-				h.dry.changeView(Monitor)
-				f(h)
-				return refreshScreen()
-			}
-			h.dry.changeView(ContainerMenu)
-			f(viewsToHandlers[ContainerMenu])
-			return refreshScreen()
+			return showContainerMenu(h.dry, id, f)
 		}
 		if err := h.widget.OnEvent(showMenu); err != nil {
 			h.dry.message(err.Error())
@@ -61,17 +50,60 @@ func (h *monitorScreenEventHandler) handle(event *tcell.EventKey, f func(eventHa
 			handled = true
 			cursor.Bottom()
 			h.widget.OnEvent(nil)
-		case 's': // Set the delay between updates to <delay> seconds.
-			//widget is mounted on render, dont Mount here
+		case '%':
+			handled = true
+			//same as with 's', the monitor would render over the prompt
+			forwarder := newEventForwarder()
+			f(forwarder)
+			h.dry.pushView(NoView)
+			refreshScreen()
+			applyFilter := func(filter string, canceled bool) {
+				h.dry.popView()
+				if !canceled {
+					cursor.Reset()
+					h.widget.Filter(filter)
+				}
+				f(h)
+				refreshScreen()
+			}
+			showFilterInput(newEventSource(forwarder.events()), applyFilter)
+		case 'f': //filter by label
+			handled = true
+			//same as with '%'
+			forwarder := newEventForwarder()
+			f(forwarder)
+			h.dry.pushView(NoView)
+			refreshScreen()
+			showLabelFilterInput(h.dry, h.widget, newEventSource(forwarder.events()), func() {
+				h.dry.popView()
+				cursor.Reset()
+				f(h)
+				refreshScreen()
+			})
+		case 'p': //containers of the compose project of the selected container
+			handled = true
+			toggleComposeProjectFilter(h.dry, h.widget)
+			//the label filter is applied when the monitor is mounted again
 			h.widget.Unmount()
+			cursor.Reset()
+			refreshScreen()
+		case 'x':
+			handled = true
+			h.exportMetrics(f)
+		case 'r':
+			handled = true
+			showStatsPlayback(h.dry, f)
+		case 's': // Set the delay between updates to <delay> seconds.
+			//the monitor is unmounted while the prompt is shown, so it does
+			//not render over it, it is mounted again on render, once back
 			prompt := appui.NewPrompt("Set the delay between updates (in milliseconds)")
 			widgets.add(prompt)
 			forwarder := newEventForwarder()
 			f(forwarder)
-			h.dry.changeView(NoView)
+			h.dry.pushView(NoView)
 			refreshScreen()
 			go func() {
-				defer h.dry.changeView(Monitor)
+				defer h.dry.popView()
 				defer f(h)
 				events := ui.EventSource{
 					Events: forwarder.events(),
@@ -0,0 +1,172 @@
+package app
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moncho/dry/appui"
+	pkgError "github.com/pkg/errors"
+)
+
+//metricsExport describes how monitor metrics are exported to a file
+type metricsExport struct {
+	path   string
+	format string
+	//window is how far back samples are exported, zero exports the current values
+	window time.Duration
+}
+
+var metricsCSVHeader = []string{
+	"time", "container_id", "name",
+	"cpu_percentage", "memory", "memory_limit", "memory_percentage",
+	"network_rx", "network_tx", "block_read", "block_write", "pids"}
+
+func metricsExportPrompt() *appui.Prompt {
+	return appui.NewPromptWithText(
+		"Export metrics: file=<path> format=<csv|json> window=<current|duration, i.e. 5m>",
+		fmt.Sprintf("file=dry-metrics-%s.csv format=csv window=current", time.Now().Format("20060102-150405")))
+}
+
+//parseMetricsExport parses the export options typed on a metrics export prompt,
+//a value given without key is taken as the file path. If no format is given
+//it is taken from the file extension.
+func parseMetricsExport(s string) (metricsExport, error) {
+	var export metricsExport
+	for _, field := range strings.Fields(s) {
+		key, value := "file", field
+		if i := strings.Index(field, "="); i >= 0 {
+			key, value = field[:i], field[i+1:]
+		}
+		switch key {
+		case "file":
+			export.path = value
+		case "format":
+			if value != "csv" && value != "json" {
+				return export, fmt.Errorf("invalid format: %q", value)
+			}
+			export.format = value
+		case "window":
+			if value == "current" {
+				export.window = 0
+				continue
+			}
+			window, err := time.ParseDuration(value)
+			if err != nil || window < 0 {
+				return export, fmt.Errorf("invalid window: %q", value)
+			}
+			export.window = window
+		default:
+			return export, fmt.Errorf("unknown export option: %q", key)
+		}
+	}
+	if export.path == "" {
+		return export, fmt.Errorf("no file given")
+	}
+	if export.format == "" {
+		export.format = "csv"
+		if strings.EqualFold(filepath.Ext(export.path), ".json") {
+			export.format = "json"
+		}
+	}
+	return export, nil
+}
+
+//write writes the given metrics to the export file
+func (e metricsExport) write(metrics []appui.ContainerMetrics) error {
+	f, err := os.Create(e.path)
+	if err != nil {
+		return pkgError.Wrap(err, "error creating export file")
+	}
+	if e.format == "json" {
+		err = writeMetricsJSON(f, metrics)
+	} else {
+		err = writeMetricsCSV(f, metrics)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return pkgError.Wrap(err, "error exporting metrics")
+}
+
+//writeMetricsCSV writes a line for each sample of the given metrics
+func writeMetricsCSV(w io.Writer, metrics []appui.ContainerMetrics) error {
+	formatFloat := func(f float64) string {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write(metricsCSVHeader); err != nil {
+		return err
+	}
+	for _, m := range metrics {
+		for _, s := range m.Samples {
+			record := []string{
+				s.Time.Format(time.RFC3339Nano), m.ID, m.Name,
+				formatFloat(s.CPUPercentage),
+				formatFloat(s.Memory),
+				formatFloat(s.MemoryLimit),
+				formatFloat(s.MemoryPercentage),
+				formatFloat(s.NetworkRx),
+				formatFloat(s.NetworkTx),
+				formatFloat(s.BlockRead),
+				formatFloat(s.BlockWrite),
+				strconv.FormatUint(s.Pids, 10),
+			}
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+//writeMetricsJSON writes the given metrics as a JSON array of containers, with their samples
+func writeMetricsJSON(w io.Writer, metrics []appui.ContainerMetrics) error {
+	if metrics == nil {
+		metrics = []appui.ContainerMetrics{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(metrics)
+}
+
+//exportMetrics shows a prompt to export the metrics of the given monitor
+//to a file, once done the monitor is shown again
+func (h *monitorScreenEventHandler) exportMetrics(f func(eventHandler)) {
+	//same as with 's', the monitor would render over the prompt
+	prompt := metricsExportPrompt()
+	widgets.add(prompt)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	h.dry.pushView(NoView)
+	refreshScreen()
+	go func() {
+		defer refreshScreen()
+		defer h.dry.popView()
+		defer f(h)
+		prompt.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(prompt)
+		text, canceled := prompt.Text()
+		if canceled {
+			return
+		}
+		export, err := parseMetricsExport(text)
+		if err != nil {
+			h.dry.message("Error exporting metrics: " + err.Error())
+			return
+		}
+		metrics := h.widget.Metrics(export.window)
+		if err := export.write(metrics); err != nil {
+			h.dry.message(err.Error())
+			return
+		}
+		h.dry.message(fmt.Sprintf("Metrics of %d containers exported to %s", len(metrics), export.path))
+	}()
+}
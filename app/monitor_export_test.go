@@ -0,0 +1,107 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moncho/dry/appui"
+)
+
+func Test_parseMetricsExport(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    metricsExport
+		wantErr bool
+	}{
+		{
+			"file only",
+			"metrics.csv",
+			metricsExport{path: "metrics.csv", format: "csv"},
+			false,
+		},
+		{
+			"format from extension",
+			"file=metrics.json window=current",
+			metricsExport{path: "metrics.json", format: "json"},
+			false,
+		},
+		{
+			"all options",
+			"file=metrics.out format=json window=5m",
+			metricsExport{path: "metrics.out", format: "json", window: 5 * time.Minute},
+			false,
+		},
+		{
+			"invalid window",
+			"file=metrics.csv window=yesterday",
+			metricsExport{path: "metrics.csv"},
+			true,
+		},
+		{
+			"invalid format",
+			"file=metrics.csv format=xml",
+			metricsExport{path: "metrics.csv"},
+			true,
+		},
+		{
+			"no file",
+			"format=csv",
+			metricsExport{format: "csv"},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseMetricsExport(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseMetricsExport() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("parseMetricsExport() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_writeMetrics(t *testing.T) {
+	now := time.Date(2020, 3, 1, 10, 0, 0, 0, time.UTC)
+	metrics := []appui.ContainerMetrics{
+		{
+			ID:   "1234",
+			Name: "web",
+			Samples: []appui.MetricsSample{
+				{Time: now, CPUPercentage: 1.5, Memory: 1024, Pids: 3},
+				{Time: now.Add(time.Second), CPUPercentage: 2.5, Memory: 2048, Pids: 4},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := writeMetricsCSV(&buf, metrics); err != nil {
+		t.Fatalf("Unexpected error writing CSV: %s", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Unexpected number of CSV lines, got %d, expected 3", len(lines))
+	}
+	expected := "2020-03-01T10:00:01Z,1234,web,2.5,2048,0,0,0,0,0,0,4"
+	if lines[2] != expected {
+		t.Errorf("Unexpected CSV line, got %s, expected %s", lines[2], expected)
+	}
+
+	buf.Reset()
+	if err := writeMetricsJSON(&buf, metrics); err != nil {
+		t.Fatalf("Unexpected error writing JSON: %s", err)
+	}
+	var decoded []appui.ContainerMetrics
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Unexpected error reading JSON: %s", err)
+	}
+	if len(decoded) != 1 || len(decoded[0].Samples) != 2 || decoded[0].Samples[1].Memory != 2048 {
+		t.Errorf("Unexpected JSON content: %s", buf.String())
+	}
+}
@@ -0,0 +1,156 @@
+package app
+
+import (
+	"sync"
+
+	"github.com/moncho/dry/ui"
+)
+
+//viewHooks are run when a view becomes, or stops being, the active view
+type viewHooks struct {
+	onEnter []func()
+	onLeave []func()
+}
+
+//navigationEntry is a view on the navigation stack
+type navigationEntry struct {
+	view viewMode
+	//cursor is the cursor position on the view when another view was
+	//pushed on top of it, restored when going back to it
+	cursor int
+}
+
+//navigation is the stack of views that were gone through to reach the
+//active view, which is on top of the stack.
+//
+//Top level views, those reached from anywhere, replace the whole stack.
+//Drill-down views and screens shown over a view (help, inspect, etc.) are
+//pushed on the stack and going back pops them, so going back from a view
+//always returns to the view it was reached from, with the cursor where it was.
+type navigation struct {
+	sync.RWMutex
+	cursor *ui.Cursor
+	stack  []navigationEntry
+	hooks  map[viewMode]*viewHooks
+}
+
+func newNavigation(root viewMode, cursor *ui.Cursor) *navigation {
+	return &navigation{
+		cursor: cursor,
+		stack:  []navigationEntry{{view: root}},
+		hooks:  make(map[viewMode]*viewHooks),
+	}
+}
+
+//active returns the active view
+func (n *navigation) active() viewMode {
+	n.RLock()
+	defer n.RUnlock()
+	return n.stack[len(n.stack)-1].view
+}
+
+//depth returns the number of views on the stack
+func (n *navigation) depth() int {
+	n.RLock()
+	defer n.RUnlock()
+	return len(n.stack)
+}
+
+//onEnter registers a func to be run every time the given view becomes the active one
+func (n *navigation) onEnter(v viewMode, hook func()) {
+	n.Lock()
+	defer n.Unlock()
+	n.viewHooks(v).onEnter = append(n.viewHooks(v).onEnter, hook)
+}
+
+//onLeave registers a func to be run every time the given view stops being the active one
+func (n *navigation) onLeave(v viewMode, hook func()) {
+	n.Lock()
+	defer n.Unlock()
+	n.viewHooks(v).onLeave = append(n.viewHooks(v).onLeave, hook)
+}
+
+func (n *navigation) viewHooks(v viewMode) *viewHooks {
+	hooks, ok := n.hooks[v]
+	if !ok {
+		hooks = &viewHooks{}
+		n.hooks[v] = hooks
+	}
+	return hooks
+}
+
+//switchTo makes the given top level view the active one, the navigation
+//history is discarded and the cursor moved to the top
+func (n *navigation) switchTo(v viewMode) {
+	n.transition(func() {
+		n.stack = []navigationEntry{{view: v}}
+		n.cursor.Reset()
+	})
+}
+
+//push makes the given view the active one, the cursor is moved to the top
+//and its position on the view being left is kept to restore it on pop
+func (n *navigation) push(v viewMode) {
+	n.transition(func() {
+		n.stack[len(n.stack)-1].cursor = n.cursor.Position()
+		n.stack = append(n.stack, navigationEntry{view: v})
+		n.cursor.Reset()
+	})
+}
+
+//pop goes back to the view the active view was reached from, restoring
+//the cursor position on it. The view at the bottom of the stack is never
+//popped. It returns the view that becomes active.
+func (n *navigation) pop() viewMode {
+	n.transition(func() {
+		if len(n.stack) == 1 {
+			return
+		}
+		n.stack = n.stack[:len(n.stack)-1]
+		n.cursor.ScrollTo(n.stack[len(n.stack)-1].cursor)
+	})
+	return n.active()
+}
+
+//replace changes the active view with the given one, without changing
+//the navigation history
+func (n *navigation) replace(v viewMode) {
+	n.transition(func() {
+		n.stack[len(n.stack)-1].view = v
+	})
+}
+
+//transition changes the navigation stack using the given func and runs
+//the hooks of the views left and entered, if the active view changes
+func (n *navigation) transition(change func()) {
+	n.Lock()
+	from := n.stack[len(n.stack)-1].view
+	change()
+	to := n.stack[len(n.stack)-1].view
+	var hooks []func()
+	if from != to {
+		if h, ok := n.hooks[from]; ok {
+			hooks = append(hooks, h.onLeave...)
+		}
+		if h, ok := n.hooks[to]; ok {
+			hooks = append(hooks, h.onEnter...)
+		}
+	}
+	n.Unlock()
+	//hooks are run without holding the lock, so they can navigate
+	for _, hook := range hooks {
+		hook()
+	}
+}
+
+//initViewHooks registers the hooks run when views are entered or left
+func initViewHooks(dry *Dry) {
+	//the monitor streams container stats while mounted, so it is
+	//unmounted as soon as it is left
+	dry.nav.onLeave(Monitor, func() {
+		widgets.Monitor.Unmount()
+	})
+	dry.nav.onEnter(SwarmManagement, func() {
+		loadSwarm(dry)
+	})
+}
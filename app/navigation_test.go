@@ -0,0 +1,60 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/moncho/dry/ui"
+)
+
+func TestNavigation(t *testing.T) {
+	cursor := ui.NewCursor()
+	n := newNavigation(Main, cursor)
+
+	var transitions []string
+	n.onEnter(ContainerMenu, func() { transitions = append(transitions, "enter menu") })
+	n.onLeave(ContainerMenu, func() { transitions = append(transitions, "leave menu") })
+	n.onLeave(Main, func() { transitions = append(transitions, "leave containers") })
+
+	cursor.ScrollTo(4)
+	n.push(ContainerMenu)
+	if n.active() != ContainerMenu || n.depth() != 2 {
+		t.Errorf("Unexpected navigation after push, active: %s, depth: %d", n.active(), n.depth())
+	}
+	if cursor.Position() != 0 {
+		t.Errorf("Cursor not moved to the top of the pushed view: %d", cursor.Position())
+	}
+
+	cursor.ScrollTo(2)
+	n.push(ContainerFiles)
+	n.replace(ContainerFiles)
+	if got := n.pop(); got != ContainerMenu {
+		t.Errorf("Unexpected view after pop: %s", got)
+	}
+	if cursor.Position() != 2 {
+		t.Errorf("Cursor position on the container menu not restored: %d", cursor.Position())
+	}
+	if got := n.pop(); got != Main {
+		t.Errorf("Unexpected view after pop: %s", got)
+	}
+	if cursor.Position() != 4 {
+		t.Errorf("Cursor position on the container list not restored: %d", cursor.Position())
+	}
+	if got := n.pop(); got != Main || n.depth() != 1 {
+		t.Errorf("The root view was popped, active: %s, depth: %d", got, n.depth())
+	}
+
+	want := []string{"leave containers", "enter menu", "leave menu", "enter menu", "leave menu"}
+	if !reflect.DeepEqual(transitions, want) {
+		t.Errorf("Unexpected hooks run, got %v, want %v", transitions, want)
+	}
+
+	n.push(Services)
+	n.push(ServiceTasks)
+	cursor.ScrollTo(3)
+	n.switchTo(Images)
+	if n.active() != Images || n.depth() != 1 || cursor.Position() != 0 {
+		t.Errorf("Unexpected navigation after switching views, active: %s, depth: %d, cursor: %d",
+			n.active(), n.depth(), cursor.Position())
+	}
+}
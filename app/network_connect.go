@@ -0,0 +1,42 @@
+package app
+
+import (
+	"github.com/gdamore/tcell"
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+)
+
+//networkConnector connects and disconnects containers to a network
+type networkConnector struct {
+	daemon    docker.ContainerDaemon
+	networkID string
+}
+
+func (c networkConnector) Connect(container string, aliases []string, ip string) error {
+	return c.daemon.ConnectToNetwork(c.networkID, container, aliases, ip)
+}
+
+func (c networkConnector) Disconnect(container string) error {
+	return c.daemon.DisconnectFromNetwork(c.networkID, container)
+}
+
+//inspectNetwork returns a func that shows the low-level information of the
+//network with the given id, from where containers can be connected to and
+//disconnected from the network
+func inspectNetwork(dry *Dry, screen *ui.Screen, events <-chan *tcell.EventKey, onClose func()) func(id string) error {
+	return func(id string) error {
+		render := func() (string, error) {
+			network, err := dry.dockerDaemon.NetworkInspect(id)
+			if err != nil {
+				return "", err
+			}
+			return appui.NewJSONRenderer(network).String(), nil
+		}
+		if _, err := render(); err != nil {
+			return err
+		}
+		go appui.NetworkInspect(render, networkConnector{dry.dockerDaemon, id}, screen, events, onClose)
+		return nil
+	}
+}
@@ -0,0 +1,110 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell"
+)
+
+//showNetworkContainers shows the network with the given id and the
+//containers attached to it
+func showNetworkContainers(dry *Dry, id string, f func(eventHandler)) error {
+	network, err := dry.dockerDaemon.NetworkInspect(id)
+	if err != nil {
+		return err
+	}
+	widgets.NetworkContainers.SetNetwork(network)
+	f(viewsToHandlers[NetworkContainers])
+	dry.pushView(NetworkContainers)
+	refreshScreen()
+	return nil
+}
+
+type networkContainersScreenEventHandler struct {
+	baseEventHandler
+}
+
+func (h *networkContainersScreenEventHandler) handle(event *tcell.EventKey, f func(eventHandler)) {
+	containers := widgets.NetworkContainers
+	handled := true
+	switch event.Key() {
+	case tcell.KeyEsc:
+		h.dry.goBack(f)
+		return
+	case tcell.KeyUp, tcell.KeyCtrlP:
+		containers.MoveSelection(-1)
+	case tcell.KeyDown, tcell.KeyCtrlN:
+		containers.MoveSelection(1)
+	case tcell.KeyPgUp:
+		containers.MoveSelection(-10)
+	case tcell.KeyPgDn:
+		containers.MoveSelection(10)
+	case tcell.KeyF5:
+		h.reload()
+	case tcell.KeyEnter:
+		h.showContainer(f)
+		return
+	default:
+		switch event.Rune() {
+		case 'k':
+			containers.MoveSelection(-1)
+		case 'j':
+			containers.MoveSelection(1)
+		case 'i':
+			h.inspect(f)
+			return
+		default:
+			handled = false
+		}
+	}
+	if handled {
+		refreshScreen()
+	} else {
+		h.baseEventHandler.handle(event, f)
+	}
+}
+
+//reload inspects the network again, containers may have been attached
+//or detached since
+func (h *networkContainersScreenEventHandler) reload() {
+	network, err := h.dry.dockerDaemon.NetworkInspect(widgets.NetworkContainers.Network().ID)
+	if err != nil {
+		h.dry.message("Error inspecting network: " + err.Error())
+		return
+	}
+	widgets.NetworkContainers.SetNetwork(network)
+}
+
+//showContainer shows the selected container on the container list
+func (h *networkContainersScreenEventHandler) showContainer(f func(eventHandler)) {
+	selected, ok := widgets.NetworkContainers.Selected()
+	if !ok {
+		return
+	}
+	if h.dry.dockerDaemon.ContainerByID(selected.ID) == nil {
+		h.dry.message(fmt.Sprintf("Container %s not found", selected.Name))
+		return
+	}
+	h.dry.switchView(Main)
+	f(viewsToHandlers[Main])
+	if !widgets.ContainerList.Select(selected.ID) {
+		h.dry.message(fmt.Sprintf("Container %s is not on the list, it is filtered out or stopped (F2 shows all)", selected.Name))
+	}
+	refreshScreen()
+}
+
+//inspect shows the low-level information of the network, containers can
+//be connected to or disconnected from it there
+func (h *networkContainersScreenEventHandler) inspect(f func(eventHandler)) {
+	forwarder := newEventForwarder()
+	f(forwarder)
+	show := inspectNetwork(h.dry, h.screen, forwarder.events(), func() {
+		h.reload()
+		f(h)
+		refreshScreen()
+	})
+	if err := show(widgets.NetworkContainers.Network().ID); err != nil {
+		f(h)
+		h.dry.message("Error inspecting network: " + err.Error())
+	}
+}
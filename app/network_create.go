@@ -0,0 +1,44 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+)
+
+//networkCreateForm describes the options of the network creation form
+const networkCreateForm = "Create network: NAME [-d bridge|overlay|macvlan] [--subnet CIDR] [--gateway IP] [--internal] [--attachable] [--label KEY=VALUE]..."
+
+//createNetwork asks for the options of a network to create it, the
+//networks widget is refreshed once created
+func createNetwork(dry *Dry, widget *appui.DockerNetworksWidget, h eventHandler, f func(eventHandler)) {
+	prompt := appui.NewPrompt(networkCreateForm)
+	widgets.add(prompt)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+
+	go func() {
+		prompt.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(prompt)
+		text, canceled := prompt.Text()
+		f(h)
+		defer refreshScreen()
+		if canceled {
+			return
+		}
+		opts, err := docker.ParseNetworkOptions(text)
+		if err != nil {
+			dry.message("Error creating network: " + err.Error())
+			return
+		}
+		id, err := dry.dockerDaemon.CreateNetwork(opts)
+		if err != nil {
+			dry.message(err.Error())
+			return
+		}
+		dry.message(fmt.Sprintf("Network <white>%s</> created: %s", opts.Name, docker.TruncateID(id)))
+		widget.Unmount()
+	}()
+}
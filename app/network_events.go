@@ -16,7 +16,6 @@ type networksScreenEventHandler struct {
 
 func (h *networksScreenEventHandler) handle(event *tcell.EventKey, f func(eh eventHandler)) {
 	dry := h.dry
-	screen := h.screen
 	handled := true
 	switch event.Key() {
 	case tcell.KeyF1: //sort
@@ -27,26 +26,17 @@ func (h *networksScreenEventHandler) handle(event *tcell.EventKey, f func(eh eve
 		h.widget.Unmount()
 		refreshScreen()
 	case tcell.KeyEnter: //inspect
-		forwarder := newEventForwarder()
-		f(forwarder)
-		inspectNetwork := inspect(screen, forwarder.events(),
-			func(id string) (interface{}, error) {
-				return h.dry.dockerDaemon.NetworkInspect(id)
-			},
-			func() {
-				h.dry.changeView(Networks)
-				f(h)
-				refreshScreen()
-			})
-
-		if err := h.widget.OnEvent(inspectNetwork); err != nil {
+		showContainers := func(id string) error {
+			return showNetworkContainers(dry, id, f)
+		}
+		if err := h.widget.OnEvent(showContainers); err != nil {
 			dry.message(
 				fmt.Sprintf("Error inspecting network: %s", err.Error()))
 		}
 
 	case tcell.KeyCtrlE: //remove network
 
-		prompt := appui.NewPrompt("Do you want to remove the selected network? (y/N)")
+		prompt := appui.NewPrompt(confirmationPrompt("Do you want to remove the selected network?", false))
 		widgets.add(prompt)
 		forwarder := newEventForwarder()
 		f(forwarder)
@@ -62,7 +52,7 @@ func (h *networksScreenEventHandler) handle(event *tcell.EventKey, f func(eh eve
 			conf, cancel := prompt.Text()
 			f(h)
 			widgets.remove(prompt)
-			if cancel || (conf != "y" && conf != "Y") {
+			if cancel || !isConfirmed(conf, false) {
 				return
 			}
 
@@ -92,18 +82,17 @@ func (h *networksScreenEventHandler) handle(event *tcell.EventKey, f func(eh eve
 		case '3':
 			//already in network screen
 			handled = true
+		case 'n': //create network
+			handled = true
+			createNetwork(dry, h.widget, h, f)
 		case '%':
 			handled = true
 			forwarder := newEventForwarder()
 			f(forwarder)
 			refreshScreen()
-			applyFilter := func(filter string, canceled bool) {
-				if !canceled {
-					h.widget.Filter(filter)
-				}
+			showIncrementalFilterInput(newEventSource(forwarder.events()), h.widget, func() {
 				f(h)
-			}
-			showFilterInput(newEventSource(forwarder.events()), applyFilter)
+			})
 		}
 	}
 	if !handled {
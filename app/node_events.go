@@ -64,6 +64,50 @@ func (h *nodesScreenEventHandler) handle(event *tcell.EventKey, f func(eventHand
 					dry.message(fmt.Sprintf("Could not change node availability, error %s", err.Error()))
 					return err
 				}
+				h.widget.Unmount()
+				return refreshScreen()
+			}
+			h.widget.OnEvent(changeNode)
+		}()
+	case tcell.KeyCtrlO:
+		dry := h.dry
+		rw := appui.NewPrompt("Changing node role, please type one of ('manager'|'worker')")
+		forwarder := newEventForwarder()
+		f(forwarder)
+		refreshScreen()
+		handled = true
+		widgets.add(rw)
+		go func() {
+			events := ui.EventSource{
+				Events: forwarder.events(),
+				EventHandledCallback: func(e *tcell.EventKey) error {
+					return refreshScreen()
+				},
+			}
+			rw.OnFocus(events)
+			widgets.remove(rw)
+			role, canceled := rw.Text()
+			f(h)
+			if canceled {
+				return
+			}
+			if role != "manager" && role != "worker" {
+				dry.message(fmt.Sprintf("Invalid role: %s", role))
+				return
+			}
+
+			changeNode := func(nodeID string) error {
+				err := dry.dockerDaemon.NodeChangeRole(
+					nodeID,
+					docker.NewNodeRole(role))
+
+				if err == nil {
+					dry.message(fmt.Sprintf("Node %s role is now %s", nodeID, role))
+				} else {
+					dry.message(fmt.Sprintf("Could not change node role, error %s", err.Error()))
+					return err
+				}
+				h.widget.Unmount()
 				return refreshScreen()
 			}
 			h.widget.OnEvent(changeNode)
@@ -71,9 +115,8 @@ func (h *nodesScreenEventHandler) handle(event *tcell.EventKey, f func(eventHand
 
 	case tcell.KeyEnter:
 		showServices := func(nodeID string) error {
-			h.screen.Cursor().Reset()
 			widgets.NodeTasks.ForNode(nodeID)
-			h.dry.changeView(Tasks)
+			h.dry.pushView(Tasks)
 			f(viewsToHandlers[Tasks])
 			return refreshScreen()
 		}
@@ -86,13 +129,44 @@ func (h *nodesScreenEventHandler) handle(event *tcell.EventKey, f func(eventHand
 			handled = true
 			forwarder := newEventForwarder()
 			f(forwarder)
-			applyFilter := func(filter string, canceled bool) {
-				if !canceled {
-					h.widget.Filter(filter)
-				}
+			showIncrementalFilterInput(newEventSource(forwarder.events()), h.widget, func() {
 				f(h)
+			})
+		case 'L':
+			handled = true
+			dry := h.dry
+			editNodeLabels := func(nodeID string) error {
+				node, err := dry.dockerDaemon.Node(nodeID)
+				if err != nil {
+					return err
+				}
+				editLabels(dry, h, f,
+					"node "+node.Description.Hostname,
+					node.Spec.Labels,
+					func(labels map[string]string) error {
+						if err := dry.dockerDaemon.NodeChangeLabels(nodeID, labels); err != nil {
+							return err
+						}
+						return h.widget.Unmount()
+					})
+				return nil
+			}
+			if err := h.widget.OnEvent(editNodeLabels); err != nil {
+				dry.message("There was an error editing node labels: " + err.Error())
+			}
+		case 'P':
+			handled = true
+			h.prePullImage(f)
+		case 'C':
+			handled = true
+			h.rotateCA(f)
+		case 'i':
+			handled = true
+			if err := h.widget.OnEvent(func(nodeID string) error {
+				return h.showNodeInfo(nodeID, f)
+			}); err != nil {
+				h.dry.message("There was an error showing node information: " + err.Error())
 			}
-			showFilterInput(newEventSource(forwarder.events()), applyFilter)
 		}
 	}
 	if !handled {
@@ -101,3 +175,54 @@ func (h *nodesScreenEventHandler) handle(event *tcell.EventKey, f func(eventHand
 		refreshScreen()
 	}
 }
+
+//rotateCA asks for confirmation and rotates the root CA of the swarm, the
+//certificates of every node are renewed afterwards
+func (h *nodesScreenEventHandler) rotateCA(f func(eventHandler)) {
+	dry := h.dry
+	prompt := appui.NewPrompt(confirmationPrompt("Rotate the swarm root CA? Every node certificate will be renewed.", false))
+	widgets.add(prompt)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+	go func() {
+		prompt.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(prompt)
+		confirmation, canceled := prompt.Text()
+		f(h)
+		if canceled || !isConfirmed(confirmation, false) {
+			refreshScreen()
+			return
+		}
+		if err := dry.dockerDaemon.SwarmRotateCA(); err != nil {
+			dry.message("Could not rotate the swarm root CA: " + err.Error())
+		} else {
+			dry.message("Rotating the swarm root CA, node certificates are being renewed")
+			h.widget.Unmount()
+		}
+		refreshScreen()
+	}()
+}
+
+//showNodeInfo shows the engine description, plugins and resources of the
+//node with the given id
+func (h *nodesScreenEventHandler) showNodeInfo(nodeID string, f func(eventHandler)) error {
+	dry := h.dry
+	node, err := dry.dockerDaemon.Node(nodeID)
+	if err != nil {
+		return err
+	}
+	tasks, err := dry.dockerDaemon.NodeTasks(nodeID)
+	if err != nil {
+		return err
+	}
+	forwarder := newEventForwarder()
+	f(forwarder)
+	dry.pushView(NoView)
+	go appui.Less(swarm.NewNodeInfoRenderer(node, tasks).String(), h.screen, forwarder.events(), func() {
+		dry.popView()
+		f(h)
+		refreshScreen()
+	})
+	return nil
+}
@@ -3,8 +3,8 @@ package app
 import (
 	"fmt"
 
-	"github.com/moncho/dry/appui/swarm"
 	"github.com/gdamore/tcell"
+	"github.com/moncho/dry/appui/swarm"
 )
 
 type taskScreenEventHandler struct {
@@ -17,10 +17,11 @@ func (h *taskScreenEventHandler) handle(event *tcell.EventKey, f func(eventHandl
 	handled := true
 	switch event.Key() {
 	case tcell.KeyEsc:
-		f(viewsToHandlers[Nodes])
-		h.dry.changeView(Nodes)
+		h.dry.goBack(f)
 	case tcell.KeyF1: //sort
 		widgets.NodeTasks.Sort()
+	case tcell.KeyF2: //cycle through the task state filters
+		h.dry.message(fmt.Sprintf("<white>Showing %s tasks</>", h.widget.CycleStateFilter()))
 	case tcell.KeyF5: // refresh
 		h.widget.Unmount()
 	case tcell.KeyEnter:
@@ -28,13 +29,13 @@ func (h *taskScreenEventHandler) handle(event *tcell.EventKey, f func(eventHandl
 		f(forwarder)
 		if err := h.widget.OnEvent(
 			inspect(
+				h.dry,
 				h.screen,
 				forwarder.events(),
 				func(id string) (interface{}, error) {
 					return h.dry.dockerDaemon.Task(id)
 				},
 				func() {
-					h.dry.changeView(Tasks)
 					f(h)
 					refreshScreen()
 				})); err != nil {
@@ -58,6 +59,13 @@ func (h *taskScreenEventHandler) handle(event *tcell.EventKey, f func(eventHandl
 				f(h)
 			}
 			showFilterInput(newEventSource(forwarder.events()), applyFilter)
+		case 'l':
+			handled = true
+			if err := h.widget.OnEvent(func(taskID string) error {
+				return showTaskLogs(h.dry, h, taskID, f)
+			}); err != nil {
+				h.dry.message("There was an error showing task logs: " + err.Error())
+			}
 		}
 	}
 	if !handled {
@@ -0,0 +1,173 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+	pkgError "github.com/pkg/errors"
+)
+
+//noteKind is the kind of Docker object a note is attached to
+type noteKind string
+
+const (
+	containerNotes noteKind = "containers"
+	imageNotes     noteKind = "images"
+)
+
+//notesFile is where the notes attached to containers and images are stored
+var notesFile string
+
+func init() {
+	notesFile, _ = homedir.Expand("~/.dry/notes.json")
+}
+
+//noteStore keeps the free-text notes users attach to containers and images.
+//Notes are kept by name when the object has one, so they survive containers
+//being recreated, or by ID otherwise, and they are persisted so they survive
+//dry restarts.
+type noteStore struct {
+	path string
+	sync.RWMutex
+	//kind -> name or id -> note
+	notes map[noteKind]map[string]string
+}
+
+func newNoteStore(path string) *noteStore {
+	s := &noteStore{
+		path:  path,
+		notes: make(map[noteKind]map[string]string),
+	}
+	if path == "" {
+		return s
+	}
+	if data, err := ioutil.ReadFile(path); err == nil {
+		json.Unmarshal(data, &s.notes)
+	}
+	return s
+}
+
+//note returns the note of the object of the given kind with the given name
+//or ID, the name takes precedence. Both a nil store and an unknown object
+//have an empty note.
+func (s *noteStore) note(kind noteKind, name, id string) string {
+	if s == nil {
+		return ""
+	}
+	s.RLock()
+	defer s.RUnlock()
+	if note, ok := s.notes[kind][name]; ok && name != "" {
+		return note
+	}
+	return s.notes[kind][id]
+}
+
+//setNote sets the note of the object of the given kind with the given name
+//and ID, an empty note removes it
+func (s *noteStore) setNote(kind noteKind, name, id, note string) error {
+	s.Lock()
+	defer s.Unlock()
+	notes, ok := s.notes[kind]
+	if !ok {
+		notes = make(map[string]string)
+		s.notes[kind] = notes
+	}
+	delete(notes, name)
+	delete(notes, id)
+	if note = strings.TrimSpace(note); note != "" {
+		key := name
+		if key == "" {
+			key = id
+		}
+		notes[key] = note
+	}
+	return s.save()
+}
+
+func (s *noteStore) save() error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(s.notes, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return pkgError.Wrap(err, "error creating notes directory")
+	}
+	return pkgError.Wrap(ioutil.WriteFile(s.path, data, 0600), "error saving notes")
+}
+
+//containerNote returns the note attached to the given container
+func (d *Dry) containerNote(c *docker.Container) string {
+	return d.notes.note(containerNotes, containerName(c), c.ID)
+}
+
+//imageNoteName returns the name an image note is kept by, empty if the
+//image is not tagged
+func imageNoteName(image types.ImageSummary) string {
+	for _, tag := range image.RepoTags {
+		if tag != "<none>:<none>" {
+			return tag
+		}
+	}
+	return ""
+}
+
+//editNote asks for the note of the object of the given kind with the given
+//name and ID, showing the current one, and saves it. Once done the given
+//handler handles events again.
+func editNote(dry *Dry, kind noteKind, name, id string, h eventHandler, f func(eventHandler), saved func()) {
+	shownName := name
+	if shownName == "" {
+		shownName = docker.TruncateID(id)
+	}
+	prompt := appui.NewPromptWithText(
+		fmt.Sprintf("Note for %s (empty to remove it)", shownName),
+		dry.notes.note(kind, name, id))
+	widgets.add(prompt)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+
+	go func() {
+		prompt.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(prompt)
+		text, canceled := prompt.Text()
+		f(h)
+		defer refreshScreen()
+		if canceled {
+			return
+		}
+		if err := dry.notes.setNote(kind, name, id, text); err != nil {
+			dry.message(err.Error())
+			return
+		}
+		if strings.TrimSpace(text) == "" {
+			dry.message(fmt.Sprintf("Note for %s removed", shownName))
+		} else {
+			dry.message(fmt.Sprintf("Note for %s saved", shownName))
+		}
+		if saved != nil {
+			saved()
+		}
+	}()
+}
+
+//noteHeader returns the given note formatted to be shown above the details
+//of the object it is attached to, empty if there is no note
+func noteHeader(note string) string {
+	if note == "" {
+		return ""
+	}
+	return fmt.Sprintf("<blue>Note:</> <white>%s</>\n\n", note)
+}
@@ -0,0 +1,56 @@
+package app
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNoteStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dry-notes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "notes.json")
+
+	s := newNoteStore(path)
+	if err := s.setNote(containerNotes, "web", "abc123", " restarted by ops, see INC-42 "); err != nil {
+		t.Fatalf("Unexpected error saving a note: %s", err)
+	}
+	if err := s.setNote(imageNotes, "", "sha256:fff", "do not remove"); err != nil {
+		t.Fatalf("Unexpected error saving a note: %s", err)
+	}
+
+	//notes are read from disk and found by name first, then by ID
+	s = newNoteStore(path)
+	tests := []struct {
+		kind     noteKind
+		name, id string
+		want     string
+	}{
+		{containerNotes, "web", "abc123", "restarted by ops, see INC-42"},
+		{containerNotes, "web", "recreated", "restarted by ops, see INC-42"},
+		{containerNotes, "db", "abc123", ""},
+		{imageNotes, "", "sha256:fff", "do not remove"},
+		{imageNotes, "web", "abc123", ""},
+	}
+	for _, tt := range tests {
+		if got := s.note(tt.kind, tt.name, tt.id); got != tt.want {
+			t.Errorf("note(%s, %q, %q) = %q, want %q", tt.kind, tt.name, tt.id, got, tt.want)
+		}
+	}
+
+	if err := s.setNote(containerNotes, "web", "abc123", ""); err != nil {
+		t.Fatalf("Unexpected error removing a note: %s", err)
+	}
+	if got := newNoteStore(path).note(containerNotes, "web", "abc123"); got != "" {
+		t.Errorf("Removed note is still there: %q", got)
+	}
+
+	var nilStore *noteStore
+	if got := nilStore.note(containerNotes, "web", "abc123"); got != "" {
+		t.Errorf("Nil store returned a note: %q", got)
+	}
+}
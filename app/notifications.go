@@ -0,0 +1,126 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/events"
+)
+
+//criticalEvent is a kind of Docker event worth a notification
+type criticalEvent string
+
+const (
+	//criticalDie is a container dying unexpectedly, with a non-zero exit
+	//code and without being stopped or killed
+	criticalDie = criticalEvent("die")
+	//criticalOOM is a container running out of memory
+	criticalOOM = criticalEvent("oom")
+	//criticalNodeDown is a swarm node going down
+	criticalNodeDown = criticalEvent("node-down")
+
+	//expectedDeathWindow is how long after being stopped or killed the death
+	//of a container is expected
+	expectedDeathWindow = time.Minute
+)
+
+//notificationMethods are the ways the user is notified of a critical event
+type notificationMethods struct {
+	bell    bool
+	desktop bool
+}
+
+//parseNotifications parses the critical events to notify, given as
+//<event>[=<method>,...], events being die, oom or node-down and methods bell
+//or desktop. If no method is given the terminal bell is rung.
+func parseNotifications(values []string) (map[criticalEvent]notificationMethods, error) {
+	notifications := make(map[criticalEvent]notificationMethods)
+	for _, value := range values {
+		fields := strings.SplitN(value, "=", 2)
+		event := criticalEvent(strings.TrimSpace(fields[0]))
+		switch event {
+		case criticalDie, criticalOOM, criticalNodeDown:
+		default:
+			return nil, fmt.Errorf("invalid notification event %q, expected die, oom or node-down", event)
+		}
+		methods := notificationMethods{bell: true}
+		if len(fields) == 2 {
+			methods.bell = false
+			for _, method := range strings.Split(fields[1], ",") {
+				switch strings.TrimSpace(method) {
+				case "bell":
+					methods.bell = true
+				case "desktop":
+					methods.desktop = true
+				default:
+					return nil, fmt.Errorf("invalid notification method %q of %s, expected bell or desktop", method, event)
+				}
+			}
+		}
+		notifications[event] = methods
+	}
+	return notifications, nil
+}
+
+//criticalEventNotifier notifies the critical events it is configured to
+type criticalEventNotifier struct {
+	methods map[criticalEvent]notificationMethods
+	//notify notifies the user with the given methods
+	notify func(description string, methods notificationMethods)
+	//stopped keeps when containers were last stopped or killed
+	stopped map[string]time.Time
+	sync.Mutex
+}
+
+func newCriticalEventNotifier(methods map[criticalEvent]notificationMethods, notify func(string, notificationMethods)) *criticalEventNotifier {
+	return &criticalEventNotifier{
+		methods: methods,
+		notify:  notify,
+		stopped: make(map[string]time.Time),
+	}
+}
+
+//onEvent notifies the given event if it is a critical event to notify,
+//it can be used as an event listener
+func (n *criticalEventNotifier) onEvent(message events.Message) {
+	event, description, ok := n.classify(message)
+	if !ok {
+		return
+	}
+	if methods, ok := n.methods[event]; ok {
+		n.notify(description, methods)
+	}
+}
+
+//classify returns the critical event the given event is, if any, and its description
+func (n *criticalEventNotifier) classify(message events.Message) (criticalEvent, string, bool) {
+	name := message.Actor.Attributes["name"]
+	switch {
+	case message.Type == events.ContainerEventType:
+		n.Lock()
+		defer n.Unlock()
+		switch message.Action {
+		case "kill", "stop":
+			n.stopped[message.Actor.ID] = time.Now()
+		case "destroy":
+			delete(n.stopped, message.Actor.ID)
+		case "oom":
+			return criticalOOM, fmt.Sprintf("Container %s ran out of memory", name), true
+		case "die":
+			exitCode := message.Actor.Attributes["exitCode"]
+			stopped, ok := n.stopped[message.Actor.ID]
+			delete(n.stopped, message.Actor.ID)
+			if exitCode == "0" || (ok && time.Since(stopped) < expectedDeathWindow) {
+				return "", "", false
+			}
+			return criticalDie, fmt.Sprintf("Container %s died with exit code %s", name, exitCode), true
+		}
+	case message.Type == events.NodeEventType && message.Action == "update":
+		if message.Actor.Attributes["state.new"] == "down" {
+			return criticalNodeDown, fmt.Sprintf("Node %s is down", name), true
+		}
+	}
+	return "", "", false
+}
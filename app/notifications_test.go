@@ -0,0 +1,77 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/events"
+)
+
+func Test_parseNotifications(t *testing.T) {
+	got, err := parseNotifications([]string{"die", "oom=desktop", "node-down=bell,desktop"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[criticalEvent]notificationMethods{
+		criticalDie:      {bell: true},
+		criticalOOM:      {desktop: true},
+		criticalNodeDown: {bell: true, desktop: true},
+	}
+	if len(got) != len(expected) {
+		t.Errorf("Unexpected notifications: %v", got)
+	}
+	for event, methods := range expected {
+		if got[event] != methods {
+			t.Errorf("Notification of %s: got %v, expected %v", event, got[event], methods)
+		}
+	}
+	for _, invalid := range []string{"restart", "die=email", "oom=bell,"} {
+		if _, err := parseNotifications([]string{invalid}); err == nil {
+			t.Errorf("Notification %q was not rejected", invalid)
+		}
+	}
+}
+
+func Test_criticalEventNotifier(t *testing.T) {
+	container := func(id, action, exitCode string) events.Message {
+		attributes := map[string]string{"name": "web-" + id}
+		if exitCode != "" {
+			attributes["exitCode"] = exitCode
+		}
+		return events.Message{Type: events.ContainerEventType, Action: action,
+			Actor: events.Actor{ID: id, Attributes: attributes}}
+	}
+	node := func(state string) events.Message {
+		return events.Message{Type: events.NodeEventType, Action: "update",
+			Actor: events.Actor{ID: "n1", Attributes: map[string]string{"name": "worker-1", "state.new": state}}}
+	}
+
+	var notified []string
+	n := newCriticalEventNotifier(
+		map[criticalEvent]notificationMethods{
+			criticalDie:      {bell: true},
+			criticalNodeDown: {desktop: true},
+		},
+		func(description string, methods notificationMethods) {
+			notified = append(notified, description)
+		})
+	for _, message := range []events.Message{
+		container("1", "die", "0"),
+		container("2", "kill", ""),
+		container("2", "die", "137"),
+		container("3", "die", "1"),
+		container("4", "oom", ""),
+		node("ready"),
+		node("down"),
+	} {
+		n.onEvent(message)
+	}
+	expected := []string{"Container web-3 died with exit code 1", "Node worker-1 is down"}
+	if len(notified) != len(expected) {
+		t.Fatalf("Unexpected notifications: %v", notified)
+	}
+	for i := range expected {
+		if notified[i] != expected[i] {
+			t.Errorf("Unexpected notification: %s, expected %s", notified[i], expected[i])
+		}
+	}
+}
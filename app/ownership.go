@@ -0,0 +1,146 @@
+package app
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gdamore/tcell"
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+	pkgError "github.com/pkg/errors"
+)
+
+var ownershipCSVHeader = []string{
+	"owner", "containers", "running", "cpu_seconds", "memory",
+	"container_disk", "images", "image_disk", "volumes", "volume_disk"}
+
+//computeOwnership computes the usage by owner in the background
+func computeOwnership(dry *Dry) {
+	ownership := widgets.Ownership
+	if !ownership.StartComputing() {
+		return
+	}
+	refreshIfView(Ownership)
+	go func() {
+		du, err := dry.dockerDaemon.DiskUsage()
+		var usage []docker.OwnerUsage
+		if err == nil {
+			usage = docker.OwnershipReport(ownership.Label(), du, dry.dockerDaemon.SampleStats())
+		}
+		ownership.DoneComputing(usage, err)
+		refreshIfView(Ownership)
+	}()
+}
+
+//showOwnership shows the usage by owner, computing it the first time it is shown
+func showOwnership(dry *Dry, f func(eventHandler)) {
+	f(viewsToHandlers[Ownership])
+	dry.pushView(Ownership)
+	if widgets.Ownership.Usage() == nil {
+		computeOwnership(dry)
+	}
+	refreshScreen()
+}
+
+//writeOwnershipCSV writes a line for each owner of the given usage
+func writeOwnershipCSV(w io.Writer, usage []docker.OwnerUsage) error {
+	formatInt := func(i int64) string {
+		return strconv.FormatInt(i, 10)
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write(ownershipCSVHeader); err != nil {
+		return err
+	}
+	for _, u := range usage {
+		record := []string{
+			u.Owner,
+			strconv.Itoa(u.Containers),
+			strconv.Itoa(u.Running),
+			strconv.FormatFloat(u.CPUSeconds, 'f', -1, 64),
+			strconv.FormatFloat(u.Memory, 'f', -1, 64),
+			formatInt(u.ContainerDisk),
+			strconv.Itoa(u.Images),
+			formatInt(u.ImageDisk),
+			strconv.Itoa(u.Volumes),
+			formatInt(u.VolumeDisk),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+//exportOwnership writes the given usage to the given file, as CSV
+func exportOwnership(path string, usage []docker.OwnerUsage) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return pkgError.Wrap(err, "error creating export file")
+	}
+	err = writeOwnershipCSV(f, usage)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return pkgError.Wrap(err, "error exporting usage by owner")
+}
+
+type ownershipScreenEventHandler struct {
+	baseEventHandler
+}
+
+func (h *ownershipScreenEventHandler) handle(event *tcell.EventKey, f func(eventHandler)) {
+	handled := false
+	switch event.Key() {
+	case tcell.KeyEsc:
+		h.dry.goBack(f)
+		return
+	case tcell.KeyUp, tcell.KeyDown:
+		//To avoid the base handler handling this
+		handled = true
+	case tcell.KeyF5:
+		handled = true
+		computeOwnership(h.dry)
+	}
+	if event.Rune() == 'x' {
+		handled = true
+		h.export(f)
+	}
+	if !handled {
+		h.baseEventHandler.handle(event, f)
+	}
+}
+
+//export asks for the file to export the usage by owner to
+func (h *ownershipScreenEventHandler) export(f func(eventHandler)) {
+	usage := widgets.Ownership.Usage()
+	if usage == nil {
+		h.dry.message("The usage by owner has not been computed yet")
+		return
+	}
+	prompt := appui.NewPromptWithText("Export usage by owner to",
+		fmt.Sprintf("dry-owners-%s.csv", time.Now().Format("20060102-150405")))
+	widgets.add(prompt)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+	go func() {
+		defer refreshScreen()
+		defer f(h)
+		prompt.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(prompt)
+		path, canceled := prompt.Text()
+		if canceled || path == "" {
+			return
+		}
+		if err := exportOwnership(path, usage); err != nil {
+			h.dry.message(err.Error())
+			return
+		}
+		h.dry.message(fmt.Sprintf("Usage of %d owners exported to %s", len(usage), path))
+	}()
+}
@@ -0,0 +1,125 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell"
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/ui"
+)
+
+type pluginsScreenEventHandler struct {
+	baseEventHandler
+	widget *appui.PluginsWidget
+}
+
+func (h *pluginsScreenEventHandler) handle(event *tcell.EventKey, f func(eh eventHandler)) {
+	dry := h.dry
+	screen := h.screen
+	handled := true
+	switch event.Key() {
+	case tcell.KeyF1: //sort
+		h.widget.Sort()
+		refreshScreen()
+	case tcell.KeyF5: // refresh
+		h.dry.message("Refreshing plugin list")
+		h.widget.Unmount()
+		refreshScreen()
+	case tcell.KeyEnter: //inspect
+		forwarder := newEventForwarder()
+		f(forwarder)
+		inspectPlugin := inspect(h.dry, screen, forwarder.events(),
+			func(name string) (interface{}, error) {
+				return h.dry.dockerDaemon.PluginInspect(name)
+			},
+			func() {
+				f(h)
+				refreshScreen()
+			})
+
+		if err := h.widget.OnEvent(inspectPlugin); err != nil {
+			dry.message(
+				fmt.Sprintf("Error inspecting plugin: %s", err.Error()))
+		}
+	default:
+		handled = false
+	}
+	if !handled {
+		handled = true
+		switch event.Rune() {
+		case '9':
+			//already in plugins screen
+		case 'e', 'E': //enable
+			enablePlugin := func(name string) error {
+				if err := h.dry.dockerDaemon.PluginEnable(name); err == nil {
+					h.dry.message(fmt.Sprintf("Plugin <white>%s</> enabled", name))
+					h.widget.Unmount()
+				} else {
+					h.dry.message(fmt.Sprintf("<red>Error enabling plugin </><white>%s: %s</>", name, err.Error()))
+				}
+				return nil
+			}
+			if err := h.widget.OnEvent(enablePlugin); err != nil {
+				dry.message(
+					fmt.Sprintf("Error enabling plugin: %s", err.Error()))
+			}
+			refreshScreen()
+		case 'd', 'D': //disable, D forces it
+			h.disablePlugin(event.Rune() == 'D', f)
+		case '%':
+			forwarder := newEventForwarder()
+			f(forwarder)
+			refreshScreen()
+			showIncrementalFilterInput(newEventSource(forwarder.events()), h.widget, func() {
+				f(h)
+			})
+		default:
+			handled = false
+		}
+	}
+	if !handled {
+		h.baseEventHandler.handle(event, f)
+	}
+}
+
+//disablePlugin disables the selected plugin once confirmed, if force is
+//true the plugin is disabled even if it is in use
+func (h *pluginsScreenEventHandler) disablePlugin(force bool, f func(eh eventHandler)) {
+	plugin := h.widget.Selected()
+	if plugin == nil {
+		h.dry.message("There is no plugin selected")
+		return
+	}
+	title := fmt.Sprintf("Do you want to disable plugin %s?", plugin.Name)
+	if force {
+		title = fmt.Sprintf("Do you want to disable plugin %s, even if it is in use?", plugin.Name)
+	}
+	prompt := appui.NewPrompt(confirmationPrompt(title, false))
+	widgets.add(prompt)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+	go func() {
+		events := ui.EventSource{
+			Events: forwarder.events(),
+			EventHandledCallback: func(e *tcell.EventKey) error {
+				return refreshScreen()
+			},
+		}
+		prompt.OnFocus(events)
+		conf, cancel := prompt.Text()
+		f(h)
+		widgets.remove(prompt)
+		if cancel || !isConfirmed(conf, false) {
+			refreshScreen()
+			return
+		}
+		if err := h.dry.dockerDaemon.PluginDisable(plugin.Name, force); err != nil {
+			h.dry.message(fmt.Sprintf("<red>Error disabling plugin </><white>%s: %s</>", plugin.Name, err.Error()))
+		} else {
+			h.dry.message(fmt.Sprintf("Plugin <white>%s</> disabled", plugin.Name))
+			h.widget.Unmount()
+		}
+		refreshScreen()
+	}()
+}
@@ -0,0 +1,54 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/moncho/dry/docker"
+)
+
+//podmanUnsupported are the views showing features Podman does not support,
+//with the feature shown
+var podmanUnsupported = map[viewMode]string{
+	Nodes:           "Swarm",
+	Services:        "Swarm",
+	ServiceTasks:    "Swarm",
+	Stacks:          "Swarm",
+	StackTasks:      "Swarm",
+	Tasks:           "Swarm",
+	SwarmManagement: "Swarm",
+	Plugins:         "Plugins",
+}
+
+//unsupportedFeature returns the feature shown on the given view, if the
+//Docker host dry is connected to does not support it
+func (d *Dry) unsupportedFeature(v viewMode) (string, bool) {
+	if !d.podman {
+		return "", false
+	}
+	feature, ok := podmanUnsupported[v]
+	return feature, ok
+}
+
+//supports returns true if the Docker host dry is connected to supports
+//what the given view shows, the user is told otherwise
+func (d *Dry) supports(v viewMode) bool {
+	if feature, ok := d.unsupportedFeature(v); ok {
+		d.message(fmt.Sprintf("<red>%s is not supported by Podman</>", feature))
+		return false
+	}
+	return true
+}
+
+//podmanNotice returns the notice shown when connecting to Podman, empty if
+//the given daemon is not Podman
+func podmanNotice(daemon docker.ContainerDaemon) string {
+	v, err := daemon.Version()
+	if err != nil {
+		return ""
+	}
+	version, ok := docker.PodmanVersion(v)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("<white>Connected to Podman %s</>, Swarm and plugins are not available", version)
+}
@@ -0,0 +1,23 @@
+package app
+
+import "testing"
+
+func TestSupports(t *testing.T) {
+	d := &Dry{output: make(chan string, 1)}
+	if !d.supports(Services) || !d.supports(Plugins) {
+		t.Error("Views not supported on Docker")
+	}
+	d.podman = true
+	if !d.supports(Main) || !d.supports(Images) {
+		t.Error("Views not supported on Podman")
+	}
+	if d.supports(Stacks) {
+		t.Error("Swarm views supported on Podman")
+	}
+	if msg := <-d.output; msg != "<red>Swarm is not supported by Podman</>" {
+		t.Errorf("Unexpected message: %s", msg)
+	}
+	if _, unsupported := d.unsupportedFeature(Plugins); !unsupported {
+		t.Error("Plugins supported on Podman")
+	}
+}
@@ -0,0 +1,81 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+)
+
+//showPorts shows the port mappings of the given container and the
+//conflicts with the ports other containers publish, going back to the
+//active view once closed
+func showPorts(dry *Dry, screen *ui.Screen, container *docker.Container, h eventHandler, f func(eventHandler)) error {
+	c, err := dry.dockerDaemon.Inspect(container.ID)
+	if err != nil {
+		return err
+	}
+	mappings := docker.ContainerPortMappings(c)
+	conflicts := docker.PortConflicts(
+		container.ID, mappings, dry.dockerDaemon.Containers(nil, docker.NoSort))
+	report, addresses := portsReport(
+		containerName(container), dry.dockerDaemon.DockerEnv().DockerHost, mappings, conflicts)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	dry.pushView(NoView)
+	go appui.ContainerPorts(report, addresses, screen, forwarder.events(), func() {
+		dry.popView()
+		f(h)
+		refreshScreen()
+	})
+	return nil
+}
+
+//portsReport describes the given port mappings of a container, numbering
+//the ones published on the host, and the conflicts found. The host
+//addresses of the ports published are returned too, in the same order.
+func portsReport(name, dockerHost string, mappings []docker.PortMapping, conflicts []docker.PortConflict) (string, []string) {
+	var buf bytes.Buffer
+	var addresses []string
+	listed := make(map[string]bool)
+	fmt.Fprintf(&buf, "<yellow>Ports of container</> <white>%s</>\n\n", name)
+	if len(mappings) == 0 {
+		buf.WriteString("<darkgrey>No ports exposed or published</>\n")
+		return buf.String(), nil
+	}
+	for _, m := range mappings {
+		if !m.Published() {
+			fmt.Fprintf(&buf, "     %-12s <darkgrey>not published</>\n", m.Port)
+			continue
+		}
+		address := m.HostAddress(dockerHost)
+		//ports published on every IPv4 and IPv6 interface are listed once
+		if listed[string(m.Port)+" "+address] {
+			continue
+		}
+		listed[string(m.Port)+" "+address] = true
+		addresses = append(addresses, address)
+		fmt.Fprintf(&buf, "<white>%3d</>  %-12s -> %s", len(addresses), m.Port, address)
+		if m.HostIP != "" && address != m.HostIP+":"+m.HostPort {
+			fmt.Fprintf(&buf, " <darkgrey>(%s:%s)</>", m.HostIP, m.HostPort)
+		}
+		buf.WriteString("\n")
+	}
+	buf.WriteString("\n")
+	if len(conflicts) == 0 {
+		buf.WriteString("<green>No other container publishes these host ports</>\n")
+		return buf.String(), addresses
+	}
+	buf.WriteString("<red>Conflicts</>\n")
+	for _, c := range conflicts {
+		when := "publishes"
+		if !c.Running {
+			when = "publishes once started"
+		}
+		fmt.Fprintf(&buf, "  host port <white>%s/%s</> (%s): container <white>%s</> %s it too\n",
+			c.Mapping.HostPort, c.Mapping.Port.Proto(), c.Mapping.Port, c.Container, when)
+	}
+	return buf.String(), addresses
+}
@@ -0,0 +1,32 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/moncho/dry/docker"
+)
+
+func TestPortsReport(t *testing.T) {
+	mappings := []docker.PortMapping{
+		{Port: "80/tcp", HostIP: "0.0.0.0", HostPort: "8080"},
+		{Port: "80/tcp", HostIP: "::", HostPort: "8080"},
+		{Port: "443/tcp", HostIP: "127.0.0.1", HostPort: "8443"},
+		{Port: "9090/tcp"},
+	}
+	conflicts := []docker.PortConflict{{Mapping: mappings[0], Container: "web", Running: true}}
+	report, addresses := portsReport("proxy", "tcp://prod:2376", mappings, conflicts)
+	if len(addresses) != 2 || addresses[0] != "prod:8080" || addresses[1] != "127.0.0.1:8443" {
+		t.Errorf("Unexpected host addresses: %v", addresses)
+	}
+	for _, want := range []string{"proxy", "prod:8080", "(0.0.0.0:8080)", "9090/tcp", "not published", "Conflicts", "web"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Ports report does not contain %q:\n%s", want, report)
+		}
+	}
+
+	report, addresses = portsReport("db", "", nil, nil)
+	if len(addresses) != 0 || !strings.Contains(report, "No ports") {
+		t.Errorf("Unexpected report of a container without ports: %v\n%s", addresses, report)
+	}
+}
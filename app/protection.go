@@ -0,0 +1,92 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+)
+
+//overrideAnswer is the answer that confirms an operation on a protected object
+const overrideAnswer = "override"
+
+//confirmationStyle defines how operations on non-protected objects are confirmed
+type confirmationStyle string
+
+//supported confirmations
+const (
+	//defaultConfirmation confirms with a y
+	defaultConfirmation confirmationStyle = "default"
+	//strictConfirmation confirms only if yes is typed
+	strictConfirmation confirmationStyle = "strict"
+)
+
+//confirmationMode is the active confirmationStyle
+var confirmationMode = defaultConfirmation
+
+//parseConfirmation returns the confirmationStyle with the given name, the default
+//one if the name is empty
+func parseConfirmation(s string) (confirmationStyle, error) {
+	switch c := confirmationStyle(s); c {
+	case "":
+		return defaultConfirmation, nil
+	case defaultConfirmation, strictConfirmation:
+		return c, nil
+	}
+	return "", fmt.Errorf("invalid confirmation mode %q, expected %s or %s",
+		s, defaultConfirmation, strictConfirmation)
+}
+
+//confirmationPrompt returns the text of a prompt asking the given question,
+//operations on protected objects must be explicitly overridden
+func confirmationPrompt(question string, protected bool) string {
+	if protected {
+		return fmt.Sprintf("%s It is protected (%s=true), type '%s' to continue",
+			question, docker.ProtectionLabel, overrideAnswer)
+	}
+	if confirmationMode == strictConfirmation {
+		return question + " Type 'yes' to continue"
+	}
+	return question + " (y/N)"
+}
+
+//isConfirmed returns true if the given answer to a confirmation prompt
+//allows the operation to continue
+func isConfirmed(answer string, protected bool) bool {
+	if protected {
+		return answer == overrideAnswer
+	}
+	if confirmationMode == strictConfirmation {
+		return answer == "yes"
+	}
+	return answer == "y" || answer == "Y"
+}
+
+//askFor shows a prompt with the given title, once closed the text typed and
+//whether the prompt was canceled are given to the given func, which runs on
+//its own goroutine
+var askFor = func(h eventHandler, f func(eventHandler), title string, onText func(string, bool)) {
+	prompt := appui.NewPrompt(title)
+	widgets.add(prompt)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+	go func() {
+		prompt.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(prompt)
+		text, canceled := prompt.Text()
+		f(h)
+		refreshScreen()
+		onText(text, canceled)
+	}()
+}
+
+//askConfirmation asks the given question, the given func only runs if the
+//answer confirms the operation
+func askConfirmation(h eventHandler, f func(eventHandler), question string, protected bool, onConfirmed func()) {
+	askFor(h, f, confirmationPrompt(question, protected), func(answer string, canceled bool) {
+		if !canceled && isConfirmed(answer, protected) {
+			onConfirmed()
+		}
+	})
+}
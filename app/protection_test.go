@@ -0,0 +1,93 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell"
+	"github.com/moncho/dry/mocks"
+)
+
+func Test_isConfirmed(t *testing.T) {
+	tests := []struct {
+		answer    string
+		protected bool
+		want      bool
+	}{
+		{"y", false, true},
+		{"Y", false, true},
+		{"n", false, false},
+		{"y", true, false},
+		{"override", true, true},
+	}
+	for _, tt := range tests {
+		if got := isConfirmed(tt.answer, tt.protected); got != tt.want {
+			t.Errorf("isConfirmed(%s, %v) = %v, want %v", tt.answer, tt.protected, got, tt.want)
+		}
+	}
+}
+
+func Test_isConfirmedStrict(t *testing.T) {
+	confirmationMode = strictConfirmation
+	defer func() { confirmationMode = defaultConfirmation }()
+
+	tests := []struct {
+		answer    string
+		protected bool
+		want      bool
+	}{
+		{"y", false, false},
+		{"yes", false, true},
+		{"yes", true, false},
+		{"override", true, true},
+	}
+	for _, tt := range tests {
+		if got := isConfirmed(tt.answer, tt.protected); got != tt.want {
+			t.Errorf("isConfirmed(%s, %v) = %v, want %v", tt.answer, tt.protected, got, tt.want)
+		}
+	}
+}
+
+type danglingImagesDaemon struct {
+	mocks.DockerDaemonMock
+	removed bool
+}
+
+func (d *danglingImagesDaemon) RemoveDanglingImages() (int, error) {
+	d.removed = true
+	return 0, nil
+}
+
+func TestStrictConfirmationOfBulkRemovals(t *testing.T) {
+	confirmationMode = strictConfirmation
+	defer func() { confirmationMode = defaultConfirmation }()
+	defer func(ask func(eventHandler, func(eventHandler), string, func(string, bool)), refresh func() error) {
+		askFor = ask
+		refreshScreen = refresh
+	}(askFor, refreshScreen)
+	refreshScreen = func() error { return nil }
+
+	for _, tt := range []struct {
+		answer string
+		want   bool
+	}{
+		{"y", false},
+		{"Y", false},
+		{"yes", true},
+	} {
+		daemon := &danglingImagesDaemon{}
+		h := &imagesScreenEventHandler{baseEventHandler: baseEventHandler{dry: &Dry{dockerDaemon: daemon}}}
+		var title string
+		askFor = func(_ eventHandler, _ func(eventHandler), t string, onText func(string, bool)) {
+			title = t
+			onText(tt.answer, false)
+		}
+		h.handleKeyEvent(tcell.KeyCtrlD, func(eventHandler) {})
+		if !strings.HasSuffix(title, "Type 'yes' to continue") {
+			t.Errorf("Unexpected prompt on strict mode: %q", title)
+		}
+		if daemon.removed != tt.want {
+			t.Errorf("Answering %q removed dangling images: %v, want %v", tt.answer, daemon.removed, tt.want)
+		}
+	}
+}
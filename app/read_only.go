@@ -0,0 +1,77 @@
+package app
+
+import (
+	"github.com/gdamore/tcell"
+)
+
+//mutatingActions are the actions that change the state of the Docker host,
+//disabled on read-only mode
+var mutatingActions = map[string]bool{
+	"containers.remove":          true,
+	"containers.remove-stopped":  true,
+	"containers.kill":            true,
+	"containers.recreate":        true,
+	"containers.clone":           true,
+	"containers.healthcheck":     true,
+	"containers.resources":       true,
+	"containers.restart":         true,
+	"containers.stop":            true,
+	"containers.batch-stop":      true,
+	"containers.stop-image":      true,
+	"images.remove-dangling":     true,
+	"images.remove":              true,
+	"images.force-remove":        true,
+	"images.remove-unused":       true,
+	"images.pull":                true,
+	"images.pull-newer":          true,
+	"images.run":                 true,
+	"networks.remove":            true,
+	"networks.create":            true,
+	"volumes.remove-all":         true,
+	"volumes.remove":             true,
+	"volumes.force-remove":       true,
+	"volumes.remove-unused":      true,
+	"plugins.enable":             true,
+	"plugins.disable":            true,
+	"plugins.force-disable":      true,
+	"nodes.availability":         true,
+	"nodes.role":                 true,
+	"nodes.labels":               true,
+	"nodes.prepull":              true,
+	"nodes.rotate-ca":            true,
+	"services.dns":               true,
+	"services.labels":            true,
+	"services.placement":         true,
+	"services.remove":            true,
+	"services.scale":             true,
+	"services.update":            true,
+	"stacks.remove":              true,
+	"swarm.init":                 true,
+	"swarm.join":                 true,
+	"swarm.leave":                true,
+	"swarm.rotate-worker-token":  true,
+	"swarm.rotate-manager-token": true,
+	"df.prune":                   true,
+	"df.remove":                  true,
+	"df.prune-build-cache":       true,
+}
+
+//mutates returns true if the given event triggers, on the given view, an action
+//changing the state of the Docker host, the event must have been translated already
+func (km *keyMap) mutates(v viewMode, event *tcell.EventKey) bool {
+	if km == nil {
+		return false
+	}
+	pressed := keyPressOf(event)
+	for _, a := range km.viewActions(v) {
+		if !mutatingActions[a.name] {
+			continue
+		}
+		for _, k := range a.defaults {
+			if k == pressed {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/mocks"
+)
+
+func TestMutatingActions(t *testing.T) {
+	known := make(map[string]bool)
+	for _, a := range keyActions {
+		known[a.name] = true
+	}
+	for name := range mutatingActions {
+		if !known[name] {
+			t.Errorf("Unknown mutating action %s", name)
+		}
+	}
+}
+
+func TestKeyMapMutates(t *testing.T) {
+	km, err := newKeyMap(map[string]string{"containers.remove": "d"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		view  viewMode
+		event *tcell.EventKey
+		want  bool
+	}{
+		{Main, tcell.NewEventKey(tcell.KeyCtrlK, 0, tcell.ModNone), true},
+		{Main, tcell.NewEventKey(tcell.KeyRune, 'l', tcell.ModNone), false},
+		{Main, tcell.NewEventKey(tcell.KeyRune, 'd', tcell.ModNone), true},
+		{Images, tcell.NewEventKey(tcell.KeyRune, 'p', tcell.ModNone), true},
+		{Images, tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), false},
+		{DiskUsage, tcell.NewEventKey(tcell.KeyRune, 'P', tcell.ModNone), true},
+		{Monitor, tcell.NewEventKey(tcell.KeyCtrlK, 0, tcell.ModNone), false},
+	}
+	for _, tt := range tests {
+		event := km.translate(tt.view, tt.event)
+		if got := event != nil && km.mutates(tt.view, event); got != tt.want {
+			t.Errorf("Key %s on %s mutates: %v, want %v", tt.event.Name(), tt.view, got, tt.want)
+		}
+	}
+}
+
+func TestReadOnlyDaemon(t *testing.T) {
+	daemon := docker.ReadOnly(&mocks.DockerDaemonMock{})
+	if !docker.IsReadOnly(daemon) || docker.IsReadOnly(&mocks.DockerDaemonMock{}) {
+		t.Error("Read-only daemon not identified")
+	}
+	if err := daemon.Kill("id"); err != docker.ErrReadOnly {
+		t.Errorf("Container killed on read-only mode, error: %v", err)
+	}
+	if _, err := daemon.Rmi("id", true); err != docker.ErrReadOnly {
+		t.Errorf("Image removed on read-only mode, error: %v", err)
+	}
+	if err := daemon.ServiceUpdate("id"); err != docker.ErrReadOnly {
+		t.Errorf("Service updated on read-only mode, error: %v", err)
+	}
+	if _, err := daemon.RunHealthcheck("id"); err != docker.ErrReadOnly {
+		t.Errorf("Healthcheck run on read-only mode, error: %v", err)
+	}
+	if _, err := daemon.ServiceDNSLookup("id", "network"); err != docker.ErrReadOnly {
+		t.Errorf("Helper service created on read-only mode, error: %v", err)
+	}
+	var removed []string
+	daemon.RemoveImages([]string{"a", "b"}, false, func(id string, err error) {
+		if err == docker.ErrReadOnly {
+			removed = append(removed, id)
+		}
+	})
+	if len(removed) != 2 {
+		t.Errorf("Image removals not refused on read-only mode: %v", removed)
+	}
+	if _, err := daemon.Info(); err != nil {
+		t.Errorf("Unexpected error getting Docker info on read-only mode: %s", err)
+	}
+}
@@ -0,0 +1,140 @@
+package app
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/moncho/dry/docker"
+)
+
+const (
+	//minReconnectDelay is the time waited before the first attempt to
+	//reconnect to an unreachable Docker daemon
+	minReconnectDelay = time.Second
+	//maxReconnectDelay is the longest time waited between attempts
+	maxReconnectDelay = 30 * time.Second
+)
+
+//connectionState tracks the connection to the Docker daemon
+type connectionState struct {
+	sync.Mutex
+	//id identifies the connection, it changes every time dry connects
+	id          int
+	unreachable bool
+	attempt     int
+	nextAttempt time.Time
+}
+
+//connected starts a new connection and returns its id
+func (c *connectionState) connected() int {
+	c.Lock()
+	defer c.Unlock()
+	c.id++
+	c.unreachable = false
+	c.attempt = 0
+	return c.id
+}
+
+//current returns the id of the current connection
+func (c *connectionState) current() int {
+	c.Lock()
+	defer c.Unlock()
+	return c.id
+}
+
+//lost marks the daemon of the given connection as unreachable, it returns
+//false if dry has connected again since then
+func (c *connectionState) lost(id int) bool {
+	c.Lock()
+	defer c.Unlock()
+	if id != c.id || c.unreachable {
+		return false
+	}
+	c.unreachable = true
+	return true
+}
+
+//retrying records the given attempt to reconnect, made after the given
+//delay, it returns false if dry has connected again since the given
+//connection was lost
+func (c *connectionState) retrying(id, attempt int, delay time.Duration) bool {
+	c.Lock()
+	defer c.Unlock()
+	if id != c.id {
+		return false
+	}
+	c.attempt = attempt
+	c.nextAttempt = time.Now().Add(delay)
+	return true
+}
+
+//banner returns the banner shown while the Docker daemon is unreachable,
+//empty if it is reachable
+func (c *connectionState) banner() string {
+	c.Lock()
+	defer c.Unlock()
+	if !c.unreachable {
+		return ""
+	}
+	wait := time.Until(c.nextAttempt).Round(time.Second)
+	if wait < 0 {
+		wait = 0
+	}
+	return fmt.Sprintf(
+		"<red>Docker daemon unreachable</>, reconnecting in %s (attempt %d)", wait, c.attempt+1)
+}
+
+//reconnectDelay returns the time to wait before the given attempt to
+//reconnect, doubling on each attempt up to maxReconnectDelay
+func reconnectDelay(attempt int) time.Duration {
+	if attempt >= 16 {
+		return maxReconnectDelay
+	}
+	delay := minReconnectDelay << uint(attempt)
+	if delay > maxReconnectDelay {
+		return maxReconnectDelay
+	}
+	return delay
+}
+
+//connectionLost reconnects to the Docker daemon of the given connection,
+//whose events stream has ended, unless dry has connected again since
+func (d *Dry) connectionLost(id int) {
+	if !d.connection.lost(id) {
+		return
+	}
+	go d.reconnect(id, d.dockerDaemon)
+}
+
+//reconnect waits for the given daemon to come back, with exponential
+//backoff, and connects to it again. It gives up if dry connects to a
+//Docker daemon meanwhile, i.e. the user switches to another context.
+func (d *Dry) reconnect(id int, daemon docker.ContainerDaemon) {
+	for attempt := 0; ; attempt++ {
+		delay := reconnectDelay(attempt)
+		if !d.connection.retrying(id, attempt, delay) {
+			return
+		}
+		//the banner counts down to the next attempt
+		for waited := time.Duration(0); waited < delay; waited += time.Second {
+			refreshScreen()
+			time.Sleep(time.Second)
+		}
+		if _, err := daemon.Info(); err != nil {
+			continue
+		}
+		if d.connection.current() != id {
+			return
+		}
+		if err := d.connectKeepingUI(daemon); err != nil {
+			continue
+		}
+		daemon.Refresh(func(error) {
+			refreshScreen()
+		})
+		d.message("<white>Reconnected to the Docker daemon</>")
+		refreshScreen()
+		return
+	}
+}
@@ -0,0 +1,49 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReconnectDelay(t *testing.T) {
+	expected := []time.Duration{
+		time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second,
+		maxReconnectDelay, maxReconnectDelay}
+	for attempt, delay := range expected {
+		if got := reconnectDelay(attempt); got != delay {
+			t.Errorf("Unexpected delay before attempt %d, got %s, expected %s", attempt, got, delay)
+		}
+	}
+	if got := reconnectDelay(100); got != maxReconnectDelay {
+		t.Errorf("Unexpected delay after many attempts: %s", got)
+	}
+}
+
+func TestConnectionState(t *testing.T) {
+	var c connectionState
+	id := c.connected()
+	if c.banner() != "" {
+		t.Errorf("Banner shown while connected: %s", c.banner())
+	}
+	if !c.lost(id) || c.lost(id) {
+		t.Error("Connection lost not reported once")
+	}
+	if !c.retrying(id, 2, 4*time.Second) {
+		t.Error("Reconnection given up while not connected again")
+	}
+	if banner := c.banner(); !strings.Contains(banner, "unreachable") || !strings.Contains(banner, "attempt 3") {
+		t.Errorf("Unexpected banner: %s", banner)
+	}
+
+	next := c.connected()
+	if c.banner() != "" {
+		t.Errorf("Banner shown after connecting again: %s", c.banner())
+	}
+	if c.retrying(id, 3, time.Second) || c.lost(id) {
+		t.Error("Reconnection to a replaced connection not given up")
+	}
+	if !c.lost(next) {
+		t.Error("New connection lost not reported")
+	}
+}
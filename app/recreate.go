@@ -0,0 +1,66 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+)
+
+//pullAnswer is added to the answer to recreate a container to pull its image first
+const pullAnswer = "pull"
+
+//parseRecreateAnswer splits the answer to recreate a container into the
+//confirmation and whether the image is pulled first
+func parseRecreateAnswer(answer string) (string, bool) {
+	fields := strings.Fields(answer)
+	if len(fields) > 0 && fields[len(fields)-1] == pullAnswer {
+		return strings.Join(fields[:len(fields)-1], " "), true
+	}
+	return strings.TrimSpace(answer), false
+}
+
+//recreateContainer asks to recreate the given container with the same
+//configuration, the given func is run once recreated
+func recreateContainer(dry *Dry, container *docker.Container, h eventHandler, f func(eventHandler), onRecreated func(id string)) {
+	name := containerName(container)
+	protected := docker.IsContainerProtected(container)
+	prompt := appui.NewPrompt(
+		confirmationPrompt(
+			fmt.Sprintf("Do you want to recreate container %s with the same configuration? Add '%s' to the answer to pull its image first.", name, pullAnswer),
+			protected))
+	widgets.add(prompt)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+
+	go func() {
+		prompt.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(prompt)
+		text, canceled := prompt.Text()
+		f(h)
+		defer refreshScreen()
+		conf, pull := parseRecreateAnswer(text)
+		if canceled || !isConfirmed(conf, protected) {
+			return
+		}
+		if pull {
+			dry.message(fmt.Sprintf("Pulling image %s and recreating container <white>%s</>", container.Image, name))
+		} else {
+			dry.message(fmt.Sprintf("Recreating container <white>%s</>", name))
+		}
+		id, err := dry.dockerDaemon.RecreateContainer(container.ID, pull)
+		if err != nil {
+			dry.message(fmt.Sprintf("<red>Error recreating container %s:</> %s", name, err.Error()))
+			if id == "" {
+				return
+			}
+		} else {
+			dry.message(fmt.Sprintf("Container <white>%s</> recreated", name))
+		}
+		if onRecreated != nil {
+			onRecreated(id)
+		}
+	}()
+}
@@ -0,0 +1,23 @@
+package app
+
+import "testing"
+
+func TestParseRecreateAnswer(t *testing.T) {
+	tests := []struct {
+		answer string
+		conf   string
+		pull   bool
+	}{
+		{"y", "y", false},
+		{"y pull", "y", true},
+		{" yes  pull ", "yes", true},
+		{"pull", "", true},
+		{"n", "n", false},
+	}
+	for _, tt := range tests {
+		conf, pull := parseRecreateAnswer(tt.answer)
+		if conf != tt.conf || pull != tt.pull {
+			t.Errorf("parseRecreateAnswer(%q) = %q, %v, want %q, %v", tt.answer, conf, pull, tt.conf, tt.pull)
+		}
+	}
+}
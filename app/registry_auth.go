@@ -0,0 +1,60 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/moncho/dry/appui"
+	drydocker "github.com/moncho/dry/docker"
+)
+
+//pullWithCredentials asks for the credentials of the registry of the given
+//image, whose pull was refused, and retries the pull with them. It returns the
+//credentials used if the image was pulled.
+func pullWithCredentials(dry *Dry, image string, ask func(*appui.Prompt) (string, bool)) (*drydocker.RegistryAuth, error) {
+	registry, err := drydocker.RegistryOf(image)
+	if err != nil {
+		return nil, err
+	}
+	username, canceled := ask(appui.NewPrompt(
+		fmt.Sprintf("%s requires authentication, username?", registry)))
+	if canceled || username == "" {
+		return nil, fmt.Errorf("%s requires authentication, pull of %s canceled", registry, image)
+	}
+	password, canceled := ask(appui.NewPasswordPrompt(
+		fmt.Sprintf("Password of %s on %s?", username, registry)))
+	if canceled {
+		return nil, fmt.Errorf("%s requires authentication, pull of %s canceled", registry, image)
+	}
+	auth := drydocker.RegistryAuth{
+		ServerAddress: registry,
+		Username:      username,
+		Password:      password,
+	}
+	dry.message(fmt.Sprintf("Pulling %s as %s", image, username))
+	if err := dry.dockerDaemon.PullImageWithAuth(image, auth); err != nil {
+		if drydocker.IsUnauthorized(err) {
+			return nil, fmt.Errorf("%s refused the credentials of %s", registry, username)
+		}
+		return nil, err
+	}
+	return &auth, nil
+}
+
+//saveCredentials asks whether to save the given credentials and saves them
+//as docker login would, so later pulls do not ask for them
+func saveCredentials(dry *Dry, auth drydocker.RegistryAuth, ask func(*appui.Prompt) (string, bool)) {
+	registry := auth.ServerAddress
+	conf, canceled := ask(appui.NewPrompt(
+		fmt.Sprintf("Save the credentials of %s on %s? (y/N)", auth.Username, registry)))
+	if canceled || (conf != "y" && conf != "Y") {
+		return
+	}
+	helper, err := drydocker.StoreRegistryAuth(auth)
+	if err != nil {
+		dry.message(err.Error())
+	} else if helper != "" {
+		dry.message(fmt.Sprintf("Credentials of %s saved with docker-credential-%s", registry, helper))
+	} else {
+		dry.message(fmt.Sprintf("Credentials of %s saved on the Docker configuration file", registry))
+	}
+}
@@ -14,6 +14,10 @@ func render(d *Dry, screen *ui.Screen) {
 
 	var bufferers []gizaktermui.Bufferer
 
+	if d.title != nil {
+		d.title.update(d.viewMode())
+	}
+
 	if d.showingHeader() {
 		bufferers = append(bufferers, widgets.DockerInfo)
 	}
@@ -33,6 +37,15 @@ func render(d *Dry, screen *ui.Screen) {
 			keymap = commandsMenuBar
 
 		}
+	case ContainerFiles:
+		{
+			files := widgets.ContainerFiles
+			if err := files.Mount(); err != nil {
+				screen.Render(1, err.Error())
+			}
+			bufferers = append(bufferers, files)
+			keymap = containerFilesKeyMappings
+		}
 	case Main:
 		{
 			containersWidget := widgets.ContainerList
@@ -89,7 +102,7 @@ func render(d *Dry, screen *ui.Screen) {
 				screen.Render(1, err.Error())
 			}
 			bufferers = append(bufferers, tasks)
-			keymap = swarmMapping
+			keymap = taskKeyMappings
 		}
 	case ServiceTasks:
 		{
@@ -98,7 +111,7 @@ func render(d *Dry, screen *ui.Screen) {
 				screen.Render(1, err.Error())
 			}
 			bufferers = append(bufferers, tasks)
-			keymap = swarmMapping
+			keymap = taskKeyMappings
 		}
 	case Stacks:
 		{
@@ -129,6 +142,36 @@ func render(d *Dry, screen *ui.Screen) {
 			monitor.Mount()
 			keymap = monitorMapping
 		}
+	case Ownership:
+		{
+			viewRenderer = widgets.Ownership
+			keymap = ownershipKeyMappings
+		}
+	case Hosts:
+		{
+			viewRenderer = widgets.Hosts
+			keymap = hostsKeyMappings
+		}
+	case ImageLayers:
+		{
+			viewRenderer = widgets.ImageLayers
+			keymap = imageLayersKeyMappings
+		}
+	case NetworkContainers:
+		{
+			viewRenderer = widgets.NetworkContainers
+			keymap = networkContainersKeyMappings
+		}
+	case StatsPlayback:
+		{
+			viewRenderer = widgets.StatsPlayback
+			keymap = statsPlaybackKeyMappings
+		}
+	case SwarmManagement:
+		{
+			viewRenderer = widgets.SwarmManagement
+			keymap = swarmManagementKeyMappings
+		}
 	case Volumes:
 		{
 			volumes := widgets.Volumes
@@ -138,11 +181,27 @@ func render(d *Dry, screen *ui.Screen) {
 			bufferers = append(bufferers, volumes)
 			keymap = volumesKeyMappings
 		}
+	case Plugins:
+		{
+			plugins := widgets.Plugins
+			if err := plugins.Mount(); err != nil {
+				screen.Render(1, err.Error())
+			}
+			bufferers = append(bufferers, plugins)
+			keymap = pluginsKeyMappings
+		}
 
 	}
-	bufferers = append(bufferers, footer(keymap))
+	bufferers = append(bufferers, footer(d.keys.footer(d.viewMode(), keymap)))
 
-	widgets.MessageBar.Render()
+	if banner := d.connection.banner(); banner != "" {
+		screen.RenderLine(0, 0, banner)
+	} else {
+		widgets.MessageBar.Render()
+	}
+	if status := d.health.statusLine(); status != "" {
+		screen.RenderLine(screen.Dimensions().Width-ui.TextWidth(status)-1, 0, status)
+	}
 	screen.RenderBufferer(bufferers...)
 	if viewRenderer != nil {
 		screen.Render(appui.MainScreenHeaderSize, viewRenderer.String())
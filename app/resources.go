@@ -0,0 +1,133 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/docker/go-units"
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+)
+
+func resourcesPrompt(name string, r docker.ContainerResources) *appui.Prompt {
+	return appui.NewPromptWithText(
+		fmt.Sprintf("Resources of %s, cpu-quota=0 removes the quota: cpu-shares=<weight> cpu-quota=<µs> cpu-period=<µs> memory=<size> restart=<policy>", name),
+		formatResources(r))
+}
+
+//formatResources formats the given resources as parseResources expects them
+func formatResources(r docker.ContainerResources) string {
+	return fmt.Sprintf("cpu-shares=%d cpu-quota=%d cpu-period=%d memory=%s restart=%s",
+		r.CPUShares, r.CPUQuota, r.CPUPeriod, formatMemoryLimit(r.Memory), docker.FormatRestartPolicy(r.RestartPolicy))
+}
+
+//formatMemoryLimit formats the given memory limit with the biggest unit
+//it can be given with exactly, so it is not changed if typed back
+func formatMemoryLimit(bytes int64) string {
+	for _, unit := range []struct {
+		size   int64
+		suffix string
+	}{{units.GiB, "g"}, {units.MiB, "m"}, {units.KiB, "k"}} {
+		if bytes != 0 && bytes%unit.size == 0 {
+			return fmt.Sprintf("%d%s", bytes/unit.size, unit.suffix)
+		}
+	}
+	return strconv.FormatInt(bytes, 10)
+}
+
+//parseResources parses the resources typed on a resources prompt, the
+//resources not typed keep the given current values
+func parseResources(s string, current docker.ContainerResources) (docker.ContainerResources, error) {
+	r := current
+	for _, field := range strings.Fields(s) {
+		i := strings.Index(field, "=")
+		if i < 0 {
+			return r, fmt.Errorf("invalid resource %q, expected <resource>=<value>", field)
+		}
+		key, value := field[:i], field[i+1:]
+		switch key {
+		case "cpu-shares", "cpu-quota", "cpu-period":
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || n < 0 {
+				return r, fmt.Errorf("invalid %s value: %q", key, value)
+			}
+			switch key {
+			case "cpu-shares":
+				if n == 0 && current.CPUShares != 0 {
+					return r, errors.New("cpu-shares cannot be removed once set, 1024 is the default")
+				}
+				r.CPUShares = n
+			case "cpu-quota":
+				r.CPUQuota = n
+			default:
+				if n == 0 && current.CPUPeriod != 0 {
+					return r, errors.New("cpu-period cannot be removed once set, 100000 is the default")
+				}
+				r.CPUPeriod = n
+			}
+		case "memory":
+			memory, err := units.RAMInBytes(value)
+			if err != nil || memory < 0 {
+				return r, fmt.Errorf("invalid memory value: %q", value)
+			}
+			if memory == 0 && current.Memory != 0 {
+				return r, errors.New("memory cannot be removed once set, only changed")
+			}
+			r = r.WithMemory(memory)
+		case "restart":
+			policy, err := docker.ParseRestartPolicy(value)
+			if err != nil {
+				return r, err
+			}
+			r.RestartPolicy = policy
+		default:
+			return r, fmt.Errorf("unknown resource: %q", key)
+		}
+	}
+	return r, nil
+}
+
+//updateResources shows a form with the current resources of the given
+//container to change them, the given func is run once changed
+func updateResources(dry *Dry, container *docker.Container, h eventHandler, f func(eventHandler), onUpdated func()) error {
+	current, ok := docker.ResourcesOf(container)
+	if !ok {
+		return fmt.Errorf("the resources of container %s are not known", containerName(container))
+	}
+	name := containerName(container)
+	prompt := resourcesPrompt(name, current)
+	widgets.add(prompt)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+
+	go func() {
+		prompt.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(prompt)
+		text, canceled := prompt.Text()
+		f(h)
+		defer refreshScreen()
+		if canceled {
+			return
+		}
+		r, err := parseResources(text, current)
+		if err != nil {
+			dry.message(err.Error())
+			return
+		}
+		if r == current {
+			return
+		}
+		if err := dry.dockerDaemon.UpdateResources(container.ID, r); err != nil {
+			dry.message(err.Error())
+			return
+		}
+		dry.message(fmt.Sprintf("Container %s resources are now %s", name, formatResources(r)))
+		if onUpdated != nil {
+			onUpdated()
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/moncho/dry/docker"
+)
+
+func TestParseResources(t *testing.T) {
+	current := docker.ContainerResources{
+		CPUShares:     1024,
+		Memory:        512 * 1024 * 1024,
+		RestartPolicy: container.RestartPolicy{Name: "always"},
+	}
+	if text := formatResources(current); text != "cpu-shares=1024 cpu-quota=0 cpu-period=0 memory=512m restart=always" {
+		t.Errorf("Unexpected resources text: %s", text)
+	}
+	if r, err := parseResources(formatResources(current), current); err != nil || r != current {
+		t.Errorf("Resources changed when typed back: %+v, %v", r, err)
+	}
+	r, err := parseResources("cpu-quota=50000 cpu-period=100000 memory=1g restart=on-failure:3", current)
+	if err != nil {
+		t.Fatalf("Unexpected error parsing resources: %s", err)
+	}
+	want := docker.ContainerResources{
+		CPUShares:     1024,
+		CPUQuota:      50000,
+		CPUPeriod:     100000,
+		Memory:        1024 * 1024 * 1024,
+		MemorySwap:    2 * 1024 * 1024 * 1024,
+		RestartPolicy: container.RestartPolicy{Name: "on-failure", MaximumRetryCount: 3},
+	}
+	if r != want {
+		t.Errorf("Unexpected resources: %+v", r)
+	}
+	if r, err := parseResources("cpu-quota=0", want); err != nil || r.CPUQuota != 0 {
+		t.Errorf("CPU quota not removed: %+v, %v", r, err)
+	}
+	for _, invalid := range []string{"memory=lots", "cpu-shares=-1", "cpus=2", "restart=sometimes", "1024",
+		"memory=0", "cpu-shares=0"} {
+		if _, err := parseResources(invalid, current); err == nil {
+			t.Errorf("Expected an error parsing %q", invalid)
+		}
+	}
+}
+
+func TestFormatMemoryLimit(t *testing.T) {
+	tests := map[int64]string{
+		0:                      "0",
+		1536 * 1024 * 1024:     "1536m",
+		2 * 1024 * 1024 * 1024: "2g",
+		4096 + 1:               "4097",
+	}
+	for bytes, want := range tests {
+		if got := formatMemoryLimit(bytes); got != want {
+			t.Errorf("formatMemoryLimit(%d) = %s, want %s", bytes, got, want)
+		}
+	}
+}
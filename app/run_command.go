@@ -0,0 +1,40 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+)
+
+//showRunCommand shows the docker run command that recreates the given
+//container, copying it to the clipboard too
+func showRunCommand(dry *Dry, screen *ui.Screen, container *docker.Container, h eventHandler, f func(eventHandler)) error {
+	c, err := dry.dockerDaemon.Inspect(container.ID)
+	if err != nil {
+		return err
+	}
+	image, err := dry.dockerDaemon.InspectImage(c.Image)
+	if err != nil {
+		//without the image, the settings inherited from it are shown too
+		image.Config = nil
+	}
+	cmd := docker.RunCommand(c, image.Config)
+	if err := ui.CopyToClipboard(cmd); err != nil {
+		dry.message("Could not copy to clipboard: " + err.Error())
+	} else {
+		dry.message(fmt.Sprintf("docker run command of container %s copied to clipboard", containerName(container)))
+	}
+	forwarder := newEventForwarder()
+	f(forwarder)
+	dry.pushView(NoView)
+	go appui.Less(
+		fmt.Sprintf("<yellow>docker run command of container</> <white>%s</>\n\n%s", containerName(container), cmd),
+		screen, forwarder.events(), func() {
+			dry.popView()
+			f(h)
+			refreshScreen()
+		})
+	return nil
+}
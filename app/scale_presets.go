@@ -0,0 +1,64 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//scalePreset is a named number of replicas a service can be scaled to
+type scalePreset struct {
+	name     string
+	replicas uint64
+}
+
+//parseScalePresets parses scale presets given as <service>=<preset>:<replicas>,...
+//(i.e. web=night:2,day:10), returns the presets by service name
+func parseScalePresets(values []string) (map[string][]scalePreset, error) {
+	presets := make(map[string][]scalePreset)
+	for _, value := range values {
+		i := strings.Index(value, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid scale presets %q, expected <service>=<preset>:<replicas>,...", value)
+		}
+		service := value[:i]
+		for _, p := range strings.Split(value[i+1:], ",") {
+			fields := strings.SplitN(strings.TrimSpace(p), ":", 2)
+			if len(fields) != 2 || strings.TrimSpace(fields[0]) == "" {
+				return nil, fmt.Errorf("invalid scale preset %q of service %s, expected <preset>:<replicas>", p, service)
+			}
+			replicas, err := strconv.ParseUint(strings.TrimSpace(fields[1]), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid replicas on scale preset %q of service %s", p, service)
+			}
+			presets[service] = append(presets[service],
+				scalePreset{name: strings.TrimSpace(fields[0]), replicas: replicas})
+		}
+	}
+	return presets, nil
+}
+
+//scaleReplicas returns the number of replicas given on a scale prompt, either
+//as a number or as the name of one of the given presets
+func scaleReplicas(input string, presets []scalePreset) (uint64, error) {
+	input = strings.TrimSpace(input)
+	for _, p := range presets {
+		if p.name == input {
+			return p.replicas, nil
+		}
+	}
+	replicas, err := strconv.ParseUint(input, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number of replicas or preset: %s", input)
+	}
+	return replicas, nil
+}
+
+//describeScalePresets describes the given presets as a list of name: replicas
+func describeScalePresets(presets []scalePreset) string {
+	var s []string
+	for _, p := range presets {
+		s = append(s, fmt.Sprintf("%s: %d", p.name, p.replicas))
+	}
+	return strings.Join(s, ", ")
+}
@@ -0,0 +1,52 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_parseScalePresets(t *testing.T) {
+	presets, err := parseScalePresets([]string{"web=night:2, day:10", "db=ha:3"})
+	if err != nil {
+		t.Fatalf("Unexpected error parsing scale presets: %s", err)
+	}
+	expected := map[string][]scalePreset{
+		"web": {{"night", 2}, {"day", 10}},
+		"db":  {{"ha", 3}},
+	}
+	if !reflect.DeepEqual(presets, expected) {
+		t.Errorf("Unexpected scale presets, got %v, expected %v", presets, expected)
+	}
+	if describeScalePresets(presets["web"]) != "night: 2, day: 10" {
+		t.Errorf("Unexpected scale presets description: %s", describeScalePresets(presets["web"]))
+	}
+
+	for _, invalid := range []string{"web", "=night:2", "web=night", "web=night:many", "web=:2"} {
+		if _, err := parseScalePresets([]string{invalid}); err == nil {
+			t.Errorf("Expected an error parsing %q", invalid)
+		}
+	}
+}
+
+func Test_scaleReplicas(t *testing.T) {
+	presets := []scalePreset{{"night", 2}, {"day", 10}}
+	tests := []struct {
+		input   string
+		want    uint64
+		wantErr bool
+	}{
+		{"day", 10, false},
+		{" 5 ", 5, false},
+		{"weekend", 0, true},
+		{"-1", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := scaleReplicas(tt.input, presets)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("scaleReplicas(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("scaleReplicas(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
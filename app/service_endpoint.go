@@ -0,0 +1,39 @@
+package app
+
+import (
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/appui/swarm"
+)
+
+//inspectService shows the endpoint of the service with the given id, its
+//virtual IPs and published ports and the ports of the routing mesh, above
+//its low-level information
+func (h *servicesScreenEventHandler) inspectService(id string, f func(eventHandler)) error {
+	dry := h.dry
+	service, err := dry.dockerDaemon.Service(id)
+	if err != nil {
+		return err
+	}
+	networkNames := make(map[string]string)
+	for _, vip := range service.Endpoint.VirtualIPs {
+		if network, err := dry.dockerDaemon.NetworkInspect(vip.NetworkID); err == nil {
+			networkNames[vip.NetworkID] = network.Name
+		}
+	}
+	//without the rest of the services, only the ports of this one are known
+	services, err := dry.dockerDaemon.Services()
+	if err != nil {
+		services = append(services, *service)
+	}
+	forwarder := newEventForwarder()
+	f(forwarder)
+	dry.pushView(NoView)
+	go appui.Inspect(
+		swarm.ServiceEndpoint(*service, networkNames, services), service,
+		h.screen, forwarder.events(), func() {
+			dry.popView()
+			f(h)
+			refreshScreen()
+		})
+	return nil
+}
@@ -2,11 +2,14 @@ package app
 
 import (
 	"fmt"
-	"strconv"
+	"io"
+	"strings"
 
+	dockerswarm "github.com/docker/docker/api/types/swarm"
 	"github.com/gdamore/tcell"
 	"github.com/moncho/dry/appui"
 	"github.com/moncho/dry/appui/swarm"
+	"github.com/moncho/dry/docker"
 	"github.com/moncho/dry/ui"
 )
 
@@ -31,78 +34,53 @@ func (h *servicesScreenEventHandler) handle(event *tcell.EventKey, f func(eventH
 		h.showLogs(true, f)
 
 	case tcell.KeyCtrlR:
-		rw := appui.NewPrompt("The selected service will be removed. Do you want to proceed? y/N")
-		widgets.add(rw)
-		forwarder := newEventForwarder()
-		f(forwarder)
-		refreshScreen()
-		go func() {
-			events := ui.EventSource{
-				Events: forwarder.events(),
-				EventHandledCallback: func(e *tcell.EventKey) error {
-					return refreshScreen()
-				},
-			}
-			rw.OnFocus(events)
-			widgets.remove(rw)
-			confirmation, canceled := rw.Text()
-			f(h)
-			if canceled || (confirmation != "y" && confirmation != "Y") {
-				return
-			}
-			removeService := func(serviceID string) error {
-				err := dry.dockerDaemon.ServiceRemove(serviceID)
+		confirmRemoval := func(serviceID string) error {
+			service, err := dry.dockerDaemon.Service(serviceID)
+			if err != nil {
 				return err
 			}
-			if err := h.widget.OnEvent(removeService); err != nil {
-				h.dry.message("There was an error removing the service: " + err.Error())
-			}
+			rw := appui.NewPrompt(
+				confirmationPrompt(fmt.Sprintf("Remove service %s (%s)?",
+					service.Spec.Name, h.serviceSummary(service)), false))
+			widgets.add(rw)
+			forwarder := newEventForwarder()
+			f(forwarder)
 			refreshScreen()
-		}()
+			go func() {
+				events := ui.EventSource{
+					Events: forwarder.events(),
+					EventHandledCallback: func(e *tcell.EventKey) error {
+						return refreshScreen()
+					},
+				}
+				rw.OnFocus(events)
+				widgets.remove(rw)
+				confirmation, canceled := rw.Text()
+				f(h)
+				if canceled || !isConfirmed(confirmation, false) {
+					return
+				}
+				if err := dry.dockerDaemon.ServiceRemove(serviceID); err != nil {
+					h.dry.message("There was an error removing the service: " + err.Error())
+				} else {
+					h.dry.message(fmt.Sprintf("Service %s removed", service.Spec.Name))
+				}
+				refreshScreen()
+			}()
+			return nil
+		}
+		if err := h.widget.OnEvent(confirmRemoval); err != nil {
+			h.dry.message("There was an error removing the service: " + err.Error())
+		}
 
 	case tcell.KeyCtrlS:
-
-		rw := appui.NewPrompt("Scale service. Number of replicas?")
-		widgets.add(rw)
-		forwarder := newEventForwarder()
-		f(forwarder)
-		refreshScreen()
-		go func() {
-			events := ui.EventSource{
-				Events: forwarder.events(),
-				EventHandledCallback: func(e *tcell.EventKey) error {
-					return refreshScreen()
-				},
-			}
-			rw.OnFocus(events)
-			widgets.remove(rw)
-			replicas, canceled := rw.Text()
-			f(h)
-			if canceled {
-				return
-			}
-			scaleTo, err := strconv.Atoi(replicas)
-			if err != nil || scaleTo < 0 {
-				dry.message(
-					fmt.Sprintf("Cannot scale service, invalid number of replicas: %s", replicas))
-				return
-			}
-
-			scaleService := func(serviceID string) error {
-				err := dry.dockerDaemon.ServiceScale(serviceID, uint64(scaleTo))
-
-				if err == nil {
-					dry.message(fmt.Sprintf("Service %s scaled to %d replicas", serviceID, scaleTo))
-				}
-				return err
-			}
-			if err := h.widget.OnEvent(scaleService); err != nil {
-				h.dry.message("There was an error scaling the service: " + err.Error())
-			}
-			refreshScreen()
-		}()
+		if err := h.widget.OnEvent(func(serviceID string) error {
+			return h.scale(serviceID, f)
+		}); err != nil {
+			h.dry.message("There was an error scaling the service: " + err.Error())
+		}
 	case tcell.KeyCtrlU: //Update service
-		rw := appui.NewPrompt("The selected service will be updated. Do you want to proceed? y/N")
+		rw := appui.NewPrompt(confirmationPrompt("The selected service will be updated. Do you want to proceed?", false))
 		widgets.add(rw)
 		forwarder := newEventForwarder()
 		f(forwarder)
@@ -118,7 +96,7 @@ func (h *servicesScreenEventHandler) handle(event *tcell.EventKey, f func(eventH
 			widgets.remove(rw)
 			confirmation, canceled := rw.Text()
 			f(h)
-			if canceled || (confirmation != "y" && confirmation != "Y") {
+			if canceled || !isConfirmed(confirmation, false) {
 				return
 			}
 			removeService := func(serviceID string) error {
@@ -132,10 +110,9 @@ func (h *servicesScreenEventHandler) handle(event *tcell.EventKey, f func(eventH
 		}()
 	case tcell.KeyEnter:
 		showTasks := func(serviceID string) error {
-			h.screen.Cursor().Reset()
 			widgets.ServiceTasks.ForService(serviceID)
 			f(viewsToHandlers[ServiceTasks])
-			dry.changeView(ServiceTasks)
+			dry.pushView(ServiceTasks)
 			return refreshScreen()
 		}
 		h.widget.OnEvent(showTasks)
@@ -148,36 +125,84 @@ func (h *servicesScreenEventHandler) handle(event *tcell.EventKey, f func(eventH
 		forwarder := newEventForwarder()
 		f(forwarder)
 		refreshScreen()
-		applyFilter := func(filter string, canceled bool) {
-			if !canceled {
-				h.widget.Filter(filter)
-			}
+		showIncrementalFilterInput(newEventSource(forwarder.events()), h.widget, func() {
 			f(h)
-		}
-		showFilterInput(newEventSource(forwarder.events()), applyFilter)
+		})
 	case 'i' | 'I':
 		handled = true
-		forwarder := newEventForwarder()
-		f(forwarder)
-		inspectService := inspect(
-			h.screen,
-			forwarder.events(),
-			func(id string) (interface{}, error) {
-				return h.dry.dockerDaemon.Service(id)
-			},
-			func() {
-				h.dry.changeView(Services)
-				f(h)
-				refreshScreen()
-			})
-
-		if err := h.widget.OnEvent(inspectService); err != nil {
+		if err := h.widget.OnEvent(func(serviceID string) error {
+			return h.inspectService(serviceID, f)
+		}); err != nil {
 			h.dry.message("There was an error inspecting the service: " + err.Error())
 		}
 
 	case 'l':
 		handled = true
 		h.showLogs(false, f)
+	case 'x':
+		handled = true
+		if err := h.widget.OnEvent(func(serviceID string) error {
+			service, err := dry.dockerDaemon.Service(serviceID)
+			if err != nil {
+				return err
+			}
+			exportLogs(dry, h, f, service.Spec.Name, true,
+				func(opts docker.LogsOptions) (io.ReadCloser, error) {
+					return dry.dockerDaemon.ServiceLogs(serviceID, opts)
+				})
+			return nil
+		}); err != nil {
+			h.dry.message("There was an error exporting service logs: " + err.Error())
+		}
+	case 'L':
+		handled = true
+		editServiceLabels := func(serviceID string) error {
+			service, err := dry.dockerDaemon.Service(serviceID)
+			if err != nil {
+				return err
+			}
+			editLabels(dry, h, f,
+				"service "+service.Spec.Name,
+				service.Spec.Labels,
+				func(labels map[string]string) error {
+					if err := dry.dockerDaemon.ServiceChangeLabels(serviceID, labels); err != nil {
+						return err
+					}
+					return h.widget.Unmount()
+				})
+			return nil
+		}
+		if err := h.widget.OnEvent(editServiceLabels); err != nil {
+			h.dry.message("There was an error editing service labels: " + err.Error())
+		}
+	case 'P':
+		handled = true
+		if err := h.widget.OnEvent(func(serviceID string) error {
+			return h.editPlacement(serviceID, f)
+		}); err != nil {
+			h.dry.message("There was an error editing service placement: " + err.Error())
+		}
+	case 'R':
+		handled = true
+		if err := h.widget.OnEvent(func(serviceID string) error {
+			return h.showReplicaHistory(serviceID, f)
+		}); err != nil {
+			h.dry.message("There was an error showing the replica history: " + err.Error())
+		}
+	case 'D':
+		handled = true
+		if err := h.widget.OnEvent(func(serviceID string) error {
+			return h.lookupDNS(serviceID, f)
+		}); err != nil {
+			h.dry.message("There was an error resolving the service names: " + err.Error())
+		}
+	case 'C':
+		handled = true
+		if err := h.widget.OnEvent(func(serviceID string) error {
+			return h.diffConfig(serviceID, f)
+		}); err != nil {
+			h.dry.message("There was an error diffing the service config: " + err.Error())
+		}
 	}
 	if !handled {
 		h.baseEventHandler.handle(event, f)
@@ -193,24 +218,34 @@ func (h *servicesScreenEventHandler) showLogs(withTimestamp bool, f func(eventHa
 	go func() {
 		prompt.OnFocus(newEventSource(forwarder.events()))
 		widgets.remove(prompt)
-		since, canceled := prompt.Text()
+		text, canceled := prompt.Text()
 
 		if canceled {
 			f(h)
 			return
 		}
+		opts, err := parseLogsOptions(text, withTimestamp)
+		if err != nil {
+			f(h)
+			h.dry.message("There was an error showing service logs: " + err.Error())
+			return
+		}
 
 		showServiceLogs := func(serviceID string) error {
-			since = curateLogsDuration(since)
-			logs, err := h.dry.dockerDaemon.ServiceLogs(serviceID, since, withTimestamp)
-			if err == nil {
-				appui.Stream(logs, forwarder.events(),
-					func() {
-						h.dry.changeView(Services)
-						f(h)
-						refreshScreen()
-					})
-				return nil
+			source, err := serviceLogsSource(h.dry.dockerDaemon, serviceID, opts)
+			if err != nil {
+				return err
+			}
+			h.dry.pushView(NoView)
+			err = appui.StreamServiceLogs(source,
+				opts.Timestamps, opts.Follow, forwarder.events(),
+				func() {
+					h.dry.popView()
+					f(h)
+					refreshScreen()
+				})
+			if err != nil {
+				h.dry.popView()
 			}
 			return err
 		}
@@ -220,3 +255,231 @@ func (h *servicesScreenEventHandler) showLogs(withTimestamp bool, f func(eventHa
 		}
 	}()
 }
+
+//serviceLogsSource returns the source of the logs of the tasks of the given
+//service, prefixed with the task and node each line comes from
+func serviceLogsSource(daemon docker.ContainerDaemon, serviceID string, opts docker.LogsOptions) (appui.ServiceLogsSource, error) {
+	service, err := daemon.Service(serviceID)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := daemon.ServiceTasks(serviceID)
+	if err != nil {
+		return nil, err
+	}
+	//without nodes, lines are prefixed with node ids
+	nodes, _ := daemon.Nodes()
+	prefixer := docker.NewServiceLogsPrefixer(service.Spec.Name, tasks, nodes)
+	return func(task string) appui.LogsSource {
+		return func(timestamps bool) (io.ReadCloser, error) {
+			opts.Timestamps = timestamps
+			logs, err := daemon.ServiceLogs(serviceID, opts)
+			if err != nil {
+				return nil, err
+			}
+			return prefixer.Prefix(logs, task), nil
+		}
+	}, nil
+}
+
+//editPlacement shows a prompt to edit the placement constraints and preferences
+//of the given service
+func (h *servicesScreenEventHandler) editPlacement(serviceID string, f func(eventHandler)) error {
+	dry := h.dry
+	service, err := dry.dockerDaemon.Service(serviceID)
+	if err != nil {
+		return err
+	}
+	prompt := appui.NewPromptWithText(
+		fmt.Sprintf("Placement of %s (e.g. node.role==manager; spread=node.labels.zone)", service.Spec.Name),
+		docker.PlacementString(service.Spec.TaskTemplate.Placement))
+	widgets.add(prompt)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+	go func() {
+		prompt.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(prompt)
+		text, canceled := prompt.Text()
+		f(h)
+		defer refreshScreen()
+		if canceled {
+			return
+		}
+		constraints, preferences, err := docker.ParsePlacement(text)
+		if err != nil {
+			dry.message(fmt.Sprintf("Placement of %s not changed: %s", service.Spec.Name, err.Error()))
+			return
+		}
+		nodes, err := dry.dockerDaemon.Nodes()
+		if err == nil {
+			err = docker.ValidatePlacement(constraints, preferences, nodes)
+		}
+		if err != nil {
+			dry.message(fmt.Sprintf("Placement of %s not changed: %s", service.Spec.Name, err.Error()))
+			return
+		}
+		if err := dry.dockerDaemon.ServiceChangePlacement(serviceID, constraints, preferences); err != nil {
+			dry.message(fmt.Sprintf("Could not change placement of %s: %s", service.Spec.Name, err.Error()))
+			return
+		}
+		dry.message(fmt.Sprintf("Placement of %s changed to: %s", service.Spec.Name, text))
+	}()
+	return nil
+}
+
+//scale shows a prompt to scale the given service, the number of replicas can
+//be given as a number or as the name of one of the scale presets of the service
+func (h *servicesScreenEventHandler) scale(serviceID string, f func(eventHandler)) error {
+	dry := h.dry
+	service, err := dry.dockerDaemon.Service(serviceID)
+	if err != nil {
+		return err
+	}
+	presets := dry.scalePresets[service.Spec.Name]
+	message := fmt.Sprintf("Scale service %s. Number of replicas?", service.Spec.Name)
+	if len(presets) > 0 {
+		message = fmt.Sprintf("Scale service %s. Number of replicas or preset (%s)?",
+			service.Spec.Name, describeScalePresets(presets))
+	}
+	rw := appui.NewPrompt(message)
+	widgets.add(rw)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+	go func() {
+		rw.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(rw)
+		replicas, canceled := rw.Text()
+		f(h)
+		defer refreshScreen()
+		if canceled {
+			return
+		}
+		scaleTo, err := scaleReplicas(replicas, presets)
+		if err != nil {
+			dry.message("Cannot scale service: " + err.Error())
+			return
+		}
+		if err := dry.dockerDaemon.ServiceScale(serviceID, scaleTo); err != nil {
+			dry.message("There was an error scaling the service: " + err.Error())
+			return
+		}
+		dry.message(fmt.Sprintf("Service %s scaled to %d replicas", service.Spec.Name, scaleTo))
+	}()
+	return nil
+}
+
+//showReplicaHistory shows the replica changes of the given service seen
+//since dry started, along with its running tasks and scale presets
+func (h *servicesScreenEventHandler) showReplicaHistory(serviceID string, f func(eventHandler)) error {
+	dry := h.dry
+	service, err := dry.dockerDaemon.Service(serviceID)
+	if err != nil {
+		return err
+	}
+	tasks, err := dry.dockerDaemon.ServiceTasks(serviceID)
+	if err != nil {
+		return err
+	}
+	renderer := swarm.NewReplicaHistoryRenderer(
+		service,
+		tasks,
+		dry.replicaHistory.Changes(serviceID),
+		describeScalePresets(dry.scalePresets[service.Spec.Name]))
+	forwarder := newEventForwarder()
+	f(forwarder)
+	dry.pushView(NoView)
+	go appui.Less(renderer.String(), h.screen, forwarder.events(), func() {
+		dry.popView()
+		f(h)
+		refreshScreen()
+	})
+	return nil
+}
+
+//lookupDNS asks for one of the networks of the given service and resolves
+//the service names from it, using a helper task, showing the result once done
+func (h *servicesScreenEventHandler) lookupDNS(serviceID string, f func(eventHandler)) error {
+	dry := h.dry
+	service, err := dry.dockerDaemon.Service(serviceID)
+	if err != nil {
+		return err
+	}
+	networks := make(map[string]string)
+	var names []string
+	for _, n := range service.Spec.TaskTemplate.Networks {
+		name := n.Target
+		if network, err := dry.dockerDaemon.NetworkInspect(n.Target); err == nil {
+			name = network.Name
+		}
+		networks[name] = n.Target
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("service %s is not attached to any network", service.Spec.Name)
+	}
+	prompt := appui.NewPromptWithText(
+		fmt.Sprintf("Resolve %s names from network (%s):", service.Spec.Name, strings.Join(names, ", ")),
+		names[0])
+	widgets.add(prompt)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+	go func() {
+		prompt.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(prompt)
+		text, canceled := prompt.Text()
+		f(h)
+		refreshScreen()
+		if canceled {
+			return
+		}
+		name := strings.TrimSpace(text)
+		networkID, ok := networks[name]
+		if !ok {
+			dry.message(fmt.Sprintf("Service %s is not attached to network %s", service.Spec.Name, name))
+			return
+		}
+		dry.message(fmt.Sprintf("Resolving %s names from network %s, this might take a while", service.Spec.Name, name))
+		report, err := dry.dockerDaemon.ServiceDNSLookup(serviceID, networkID)
+		if err != nil {
+			dry.message(fmt.Sprintf("Could not resolve %s names: %s", service.Spec.Name, err.Error()))
+			return
+		}
+		renderer := swarm.NewServiceDNSReportRenderer(report, name)
+		forwarder := newEventForwarder()
+		f(forwarder)
+		dry.pushView(NoView)
+		appui.Less(renderer.String(), h.screen, forwarder.events(), func() {
+			dry.popView()
+			f(h)
+			refreshScreen()
+		})
+	}()
+	return nil
+}
+
+//serviceSummary describes the replicas and networks of the given service
+func (h *servicesScreenEventHandler) serviceSummary(service *dockerswarm.Service) string {
+	var networks []string
+	for _, n := range service.Spec.TaskTemplate.Networks {
+		name := n.Target
+		if network, err := h.dry.dockerDaemon.NetworkInspect(n.Target); err == nil {
+			name = network.Name
+		}
+		networks = append(networks, name)
+	}
+	return describeService(service, networks)
+}
+
+func describeService(service *dockerswarm.Service, networks []string) string {
+	replicas := "global"
+	if service.Spec.Mode.Replicated != nil && service.Spec.Mode.Replicated.Replicas != nil {
+		replicas = fmt.Sprintf("%d replicas", *service.Spec.Mode.Replicated.Replicas)
+	}
+	if len(networks) == 0 {
+		return replicas + ", no networks"
+	}
+	return fmt.Sprintf("%s, networks: %s", replicas, strings.Join(networks, ", "))
+}
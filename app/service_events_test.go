@@ -0,0 +1,42 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/swarm"
+)
+
+func Test_describeService(t *testing.T) {
+	replicas := uint64(3)
+	replicated := &swarm.Service{}
+	replicated.Spec.Mode.Replicated = &swarm.ReplicatedService{Replicas: &replicas}
+	global := &swarm.Service{}
+	global.Spec.Mode.Global = &swarm.GlobalService{}
+
+	tests := []struct {
+		name     string
+		service  *swarm.Service
+		networks []string
+		want     string
+	}{
+		{
+			"replicated service with networks",
+			replicated,
+			[]string{"front", "back"},
+			"3 replicas, networks: front, back",
+		},
+		{
+			"global service without networks",
+			global,
+			nil,
+			"global, no networks",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := describeService(tt.service, tt.networks); got != tt.want {
+				t.Errorf("describeService() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,8 +3,8 @@ package app
 import (
 	"fmt"
 
-	"github.com/moncho/dry/appui/swarm"
 	"github.com/gdamore/tcell"
+	"github.com/moncho/dry/appui/swarm"
 )
 
 type serviceTasksScreenEventHandler struct {
@@ -17,11 +17,11 @@ func (h *serviceTasksScreenEventHandler) handle(event *tcell.EventKey, f func(ev
 
 	switch event.Key() {
 	case tcell.KeyEsc:
-		f(viewsToHandlers[Services])
-		h.dry.changeView(Services)
-		refreshScreen()
+		h.dry.goBack(f)
 	case tcell.KeyF1: //sort
 		widgets.ServiceTasks.Sort()
+	case tcell.KeyF2: //cycle through the task state filters
+		h.dry.message(fmt.Sprintf("<white>Showing %s tasks</>", h.widget.CycleStateFilter()))
 	case tcell.KeyF5: // refresh
 		h.widget.Unmount()
 	case tcell.KeyEnter:
@@ -29,13 +29,13 @@ func (h *serviceTasksScreenEventHandler) handle(event *tcell.EventKey, f func(ev
 		f(forwarder)
 		if err := h.widget.OnEvent(
 			inspect(
+				h.dry,
 				h.screen,
 				forwarder.events(),
 				func(id string) (interface{}, error) {
 					return h.dry.dockerDaemon.Task(id)
 				},
 				func() {
-					h.dry.changeView(ServiceTasks)
 					f(h)
 					refreshScreen()
 				})); err != nil {
@@ -60,6 +60,13 @@ func (h *serviceTasksScreenEventHandler) handle(event *tcell.EventKey, f func(ev
 				f(h)
 			}
 			showFilterInput(newEventSource(forwarder.events()), applyFilter)
+		case 'l':
+			handled = true
+			if err := h.widget.OnEvent(func(taskID string) error {
+				return showTaskLogs(h.dry, h, taskID, f)
+			}); err != nil {
+				h.dry.message("There was an error showing task logs: " + err.Error())
+			}
 		}
 	}
 	if !handled {
@@ -0,0 +1,69 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/moncho/dry/docker"
+)
+
+//sortable is a list whose sort mode can be set
+type sortable interface {
+	SetSortMode(docker.SortMode)
+	SortMode() docker.SortMode
+}
+
+//sortModes are the sort modes that can be configured, by list and column name
+var sortModes = map[string]map[string]docker.SortMode{
+	"containers": {
+		"id":     docker.SortByContainerID,
+		"image":  docker.SortByImage,
+		"status": docker.SortByStatus,
+		"name":   docker.SortByName,
+	},
+	"images": {
+		"id":      docker.SortImagesByID,
+		"repo":    docker.SortImagesByRepo,
+		"size":    docker.SortImagesBySize,
+		"created": docker.SortImagesByCreationDate,
+	},
+	"networks": {
+		"id":         docker.SortNetworksByID,
+		"name":       docker.SortNetworksByName,
+		"driver":     docker.SortNetworksByDriver,
+		"containers": docker.SortNetworksByContainerCount,
+		"services":   docker.SortNetworksByServiceCount,
+		"subnet":     docker.SortNetworksBySubnet,
+	},
+}
+
+//setSortModes sets the given sort modes, given as column names by list name,
+//on the lists
+func setSortModes(lists map[string]sortable, modes map[string]string) error {
+	for list, column := range modes {
+		columns, ok := sortModes[list]
+		if !ok {
+			return fmt.Errorf("invalid sort list %q, expected containers, images or networks", list)
+		}
+		mode, ok := columns[column]
+		if !ok {
+			return fmt.Errorf("invalid sort column %q for %s", column, list)
+		}
+		lists[list].SetSortMode(mode)
+	}
+	return nil
+}
+
+//sortModeNames returns the sort modes of the given lists, as column names by
+//list name, lists sorted by a column that cannot be configured are left out
+func sortModeNames(lists map[string]sortable) map[string]string {
+	names := make(map[string]string)
+	for list, l := range lists {
+		mode := l.SortMode()
+		for column, m := range sortModes[list] {
+			if m == mode {
+				names[list] = column
+			}
+		}
+	}
+	return names
+}
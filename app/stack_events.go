@@ -25,13 +25,13 @@ func (h *stacksScreenEventHandler) handle(event *tcell.EventKey, f func(eventHan
 	case tcell.KeyEnter: //inspect
 		showTasks := func(stack string) error {
 			widgets.StackTasks.ForStack(stack)
-			h.dry.changeView(StackTasks)
+			h.dry.pushView(StackTasks)
 			f(viewsToHandlers[StackTasks])
 			return refreshScreen()
 		}
 		h.widget.OnEvent(showTasks)
 	case tcell.KeyCtrlR: //remove stack
-		rw := appui.NewPrompt("The selected stack will be removed. Do you want to proceed? y/N")
+		rw := appui.NewPrompt(confirmationPrompt("The selected stack will be removed. Do you want to proceed?", false))
 		widgets.add(rw)
 		forwarder := newEventForwarder()
 		f(forwarder)
@@ -47,7 +47,7 @@ func (h *stacksScreenEventHandler) handle(event *tcell.EventKey, f func(eventHan
 			widgets.remove(rw)
 			confirmation, canceled := rw.Text()
 			f(h)
-			if canceled || (confirmation != "y" && confirmation != "Y") {
+			if canceled || !isConfirmed(confirmation, false) {
 				return
 			}
 			removeStack := func(stack string) error {
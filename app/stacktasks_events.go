@@ -3,8 +3,8 @@ package app
 import (
 	"fmt"
 
-	"github.com/moncho/dry/appui/swarm"
 	"github.com/gdamore/tcell"
+	"github.com/moncho/dry/appui/swarm"
 )
 
 type stackTasksScreenEventHandler struct {
@@ -17,10 +17,11 @@ func (h *stackTasksScreenEventHandler) handle(event *tcell.EventKey, f func(even
 
 	switch event.Key() {
 	case tcell.KeyEsc:
-		f(viewsToHandlers[Stacks])
-		h.dry.changeView(Stacks)
+		h.dry.goBack(f)
 	case tcell.KeyF1: //sort
 		h.widget.Sort()
+	case tcell.KeyF2: //cycle through the task state filters
+		h.dry.message(fmt.Sprintf("<white>Showing %s tasks</>", h.widget.CycleStateFilter()))
 	case tcell.KeyF5: // refresh
 		h.dry.message("Refreshing stack tasks list")
 		h.widget.Unmount()
@@ -29,13 +30,13 @@ func (h *stackTasksScreenEventHandler) handle(event *tcell.EventKey, f func(even
 		f(forwarder)
 		if err := h.widget.OnEvent(
 			inspect(
+				h.dry,
 				h.screen,
 				forwarder.events(),
 				func(id string) (interface{}, error) {
 					return h.dry.dockerDaemon.Task(id)
 				},
 				func() {
-					h.dry.changeView(StackTasks)
 					f(h)
 					refreshScreen()
 				})); err != nil {
@@ -0,0 +1,98 @@
+package app
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell"
+	"github.com/moncho/dry/docker"
+)
+
+//recordStats samples the stats of the running containers every given
+//interval and appends them to the stats file, until dry is closed. Only
+//the first error recording them is shown.
+func recordStats(dry *Dry, interval time.Duration) {
+	var once sync.Once
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-dry.statsRecording:
+			return
+		case <-ticker.C:
+			if err := dry.statsFile.Record(dry.dockerDaemon.SampleStats()); err != nil {
+				once.Do(func() {
+					dry.message(fmt.Sprintf("<red>Error recording container stats:</> %s", err.Error()))
+				})
+			}
+		}
+	}
+}
+
+//loadRecordedStats reads the stats file and passes its samples to the playback widget
+func loadRecordedStats(dry *Dry) error {
+	records, err := docker.ReadStatsFile(dry.statsFilePath)
+	if err != nil {
+		return err
+	}
+	widgets.StatsPlayback.Load(dry.statsFilePath, records)
+	return nil
+}
+
+//showStatsPlayback shows the stats recorded so far, starting from the latest sample
+func showStatsPlayback(dry *Dry, f func(eventHandler)) {
+	if dry.statsFilePath == "" {
+		dry.message("Container stats are not being recorded, use --stats-file to record them")
+		return
+	}
+	if err := loadRecordedStats(dry); err != nil {
+		dry.message(fmt.Sprintf("<red>Error reading recorded stats:</> %s", err.Error()))
+		return
+	}
+	f(viewsToHandlers[StatsPlayback])
+	dry.pushView(StatsPlayback)
+	refreshScreen()
+}
+
+//statsPlaybackSkip is the number of samples PgUp and PgDn move
+const statsPlaybackSkip = 10
+
+type statsPlaybackScreenEventHandler struct {
+	baseEventHandler
+}
+
+func (h *statsPlaybackScreenEventHandler) handle(event *tcell.EventKey, f func(eventHandler)) {
+	playback := widgets.StatsPlayback
+	handled := true
+	switch event.Key() {
+	case tcell.KeyEsc:
+		h.dry.goBack(f)
+		return
+	case tcell.KeyLeft:
+		playback.Move(-1)
+	case tcell.KeyRight:
+		playback.Move(1)
+	case tcell.KeyPgUp:
+		playback.Move(-statsPlaybackSkip)
+	case tcell.KeyPgDn:
+		playback.Move(statsPlaybackSkip)
+	case tcell.KeyHome:
+		playback.First()
+	case tcell.KeyEnd:
+		playback.Last()
+	case tcell.KeyF5:
+		if err := loadRecordedStats(h.dry); err != nil {
+			h.dry.message(fmt.Sprintf("<red>Error reading recorded stats:</> %s", err.Error()))
+		}
+	case tcell.KeyUp, tcell.KeyDown:
+		//To avoid the base handler handling this
+	default:
+		handled = false
+	}
+	if handled {
+		refreshScreen()
+		return
+	}
+	h.baseEventHandler.handle(event, f)
+}
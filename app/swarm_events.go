@@ -0,0 +1,171 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	dockerswarm "github.com/docker/docker/api/types/swarm"
+	"github.com/gdamore/tcell"
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/ui"
+)
+
+type swarmScreenEventHandler struct {
+	baseEventHandler
+	renderer *appui.SwarmManagementRenderer
+}
+
+func (h *swarmScreenEventHandler) handle(event *tcell.EventKey, f func(eventHandler)) {
+	dry := h.dry
+	handled := false
+	switch event.Key() {
+	case tcell.KeyUp, tcell.KeyDown:
+		//To avoid the base handler handling this
+		handled = true
+	case tcell.KeyF5: // refresh
+		handled = true
+		loadSwarm(dry)
+	}
+	if !handled {
+		switch event.Rune() {
+		case 'i':
+			handled = true
+			h.prompt("Initialize a swarm, advertised address? (blank to let Docker choose one)", f,
+				func(addr string) {
+					if _, err := dry.dockerDaemon.SwarmInit(addr); err != nil {
+						dry.message("Could not initialize swarm: " + err.Error())
+						return
+					}
+					dry.message("Swarm initialized, this node is now a manager")
+				})
+		case 'j':
+			handled = true
+			h.prompt("Join a swarm, please type the join token and the manager address (or paste the join command)", f,
+				func(text string) {
+					token, addr, err := parseJoinCommand(text)
+					if err != nil {
+						dry.message("Could not join swarm: " + err.Error())
+						return
+					}
+					if err := dry.dockerDaemon.SwarmJoin(token, addr); err != nil {
+						dry.message("Could not join swarm: " + err.Error())
+						return
+					}
+					dry.message("Joined swarm through manager " + addr)
+				})
+		case 'l':
+			handled = true
+			h.prompt(confirmationPrompt("Leave the swarm? (type 'force' to leave even if this node is a manager)", false), f,
+				func(confirmation string) {
+					force := confirmation == "force"
+					if !force && !isConfirmed(confirmation, false) {
+						return
+					}
+					if err := dry.dockerDaemon.SwarmLeave(force); err != nil {
+						dry.message("Could not leave swarm: " + err.Error())
+						return
+					}
+					dry.message("This node left the swarm")
+				})
+		case 'r', 'R':
+			handled = true
+			manager := event.Rune() == 'R'
+			role := tokenRole(manager)
+			h.prompt(confirmationPrompt(fmt.Sprintf("Rotate %s join token?", role), false), f,
+				func(confirmation string) {
+					if !isConfirmed(confirmation, false) {
+						return
+					}
+					if err := dry.dockerDaemon.SwarmRotateJoinToken(manager); err != nil {
+						dry.message(fmt.Sprintf("Could not rotate %s join token: %s", role, err.Error()))
+						return
+					}
+					dry.message(fmt.Sprintf("The %s join token was rotated", role))
+				})
+		case 'c', 'C':
+			handled = true
+			manager := event.Rune() == 'C'
+			role := tokenRole(manager)
+			cmd, ok := h.renderer.JoinCommand(manager)
+			if !ok {
+				dry.message(fmt.Sprintf("There is no %s join token to copy, is this node a swarm manager?", role))
+				break
+			}
+			if err := ui.CopyToClipboard(cmd); err != nil {
+				dry.message("Could not copy to clipboard: " + err.Error())
+			} else {
+				dry.message(fmt.Sprintf("Command to join as %s copied to clipboard", role))
+			}
+		}
+	}
+	if !handled {
+		h.baseEventHandler.handle(event, f)
+	} else {
+		refreshScreen()
+	}
+}
+
+//prompt shows a prompt with the given title, the text typed is given to the
+//given func unless the prompt is canceled. Swarm state is reloaded afterwards.
+func (h *swarmScreenEventHandler) prompt(title string, f func(eventHandler), onText func(string)) {
+	rw := appui.NewPrompt(title)
+	widgets.add(rw)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+	go func() {
+		rw.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(rw)
+		text, canceled := rw.Text()
+		f(h)
+		if !canceled {
+			onText(strings.TrimSpace(text))
+			loadSwarm(h.dry)
+		}
+		refreshScreen()
+	}()
+}
+
+//loadSwarm retrieves the swarm state of the Docker host and passes it to
+//the swarm management renderer
+func loadSwarm(dry *Dry) {
+	info, err := dry.dockerDaemon.Info()
+	if err != nil {
+		dry.message("There was an error retrieving Docker information: " + err.Error())
+		return
+	}
+	var sw *dockerswarm.Swarm
+	if info.Swarm.ControlAvailable {
+		if s, err := dry.dockerDaemon.SwarmInspect(); err == nil {
+			sw = &s
+		} else {
+			dry.message("There was an error retrieving swarm information: " + err.Error())
+		}
+	}
+	widgets.SwarmManagement.PrepareToRender(info, sw)
+}
+
+//parseJoinCommand parses the join token and the manager address from the given
+//text, either "<token> <address>" or a full "docker swarm join" command
+func parseJoinCommand(text string) (string, string, error) {
+	var args []string
+	for _, field := range strings.Fields(text) {
+		switch field {
+		case "docker", "swarm", "join", "--token":
+			continue
+		}
+		args = append(args, field)
+	}
+	if len(args) != 2 {
+		return "", "", errors.New("expected a join token and a manager address")
+	}
+	return args[0], args[1], nil
+}
+
+func tokenRole(manager bool) string {
+	if manager {
+		return "manager"
+	}
+	return "worker"
+}
@@ -0,0 +1,47 @@
+package app
+
+import "testing"
+
+func Test_parseJoinCommand(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		wantToken string
+		wantAddr  string
+		wantErr   bool
+	}{
+		{
+			"token and address",
+			"SWMTKN-1-abc 10.0.0.1:2377",
+			"SWMTKN-1-abc",
+			"10.0.0.1:2377",
+			false,
+		},
+		{
+			"join command",
+			"docker swarm join --token SWMTKN-1-abc 10.0.0.1:2377",
+			"SWMTKN-1-abc",
+			"10.0.0.1:2377",
+			false,
+		},
+		{
+			"missing address",
+			"SWMTKN-1-abc",
+			"",
+			"",
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, addr, err := parseJoinCommand(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseJoinCommand() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if token != tt.wantToken || addr != tt.wantAddr {
+				t.Errorf("parseJoinCommand() = %s, %s, want %s, %s", token, addr, tt.wantToken, tt.wantAddr)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/moncho/dry/appui"
+)
+
+//showTaskLogs asks for the logs options and shows the logs of the container
+//of the given task, going back to the active view once the logs are closed
+func showTaskLogs(dry *Dry, h eventHandler, taskID string, f func(eventHandler)) error {
+	task, err := dry.dockerDaemon.Task(taskID)
+	if err != nil {
+		return err
+	}
+	if task.NodeID == "" {
+		return errors.New("the task was not scheduled on any node")
+	}
+	node, err := dry.dockerDaemon.Node(task.NodeID)
+	if err != nil {
+		return err
+	}
+	if err := taskLogsReachable(task, *node); err != nil {
+		return err
+	}
+	prompt := logsPrompt()
+	widgets.add(prompt)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+	go func() {
+		prompt.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(prompt)
+		text, canceled := prompt.Text()
+		if canceled {
+			f(h)
+			return
+		}
+		opts, err := parseLogsOptions(text, false)
+		if err != nil {
+			f(h)
+			dry.message("There was an error showing task logs: " + err.Error())
+			return
+		}
+		dry.pushView(NoView)
+		if err := appui.StreamLogs(logsSource(dry.dockerDaemon.TaskLogs, taskID, opts),
+			opts.Timestamps, opts.Follow, forwarder.events(),
+			func() {
+				dry.popView()
+				f(h)
+				refreshScreen()
+			}); err != nil {
+			dry.popView()
+			f(h)
+			dry.message("There was an error showing task logs: " + err.Error())
+		}
+	}()
+	return nil
+}
+
+//taskLogsReachable returns an error if the logs of the container of the
+//given task cannot be retrieved from the given node, the one the task was
+//scheduled on
+func taskLogsReachable(task swarm.Task, node swarm.Node) error {
+	if task.Status.ContainerStatus == nil || task.Status.ContainerStatus.ContainerID == "" {
+		return errors.New("the task has no container")
+	}
+	if node.Status.State != swarm.NodeStateReady {
+		return fmt.Errorf("node %s is %s, the logs of the task cannot be retrieved", nodeName(node), node.Status.State)
+	}
+	return nil
+}
+
+func nodeName(node swarm.Node) string {
+	if node.Description.Hostname != "" {
+		return node.Description.Hostname
+	}
+	return node.ID
+}
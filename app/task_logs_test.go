@@ -0,0 +1,39 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/swarm"
+)
+
+func Test_taskLogsReachable(t *testing.T) {
+	withContainer := swarm.Task{
+		NodeID: "node1",
+		Status: swarm.TaskStatus{
+			ContainerStatus: &swarm.ContainerStatus{ContainerID: "c1"},
+		},
+	}
+	ready := swarm.Node{ID: "node1", Status: swarm.NodeStatus{State: swarm.NodeStateReady}}
+	down := swarm.Node{
+		ID:          "node1",
+		Description: swarm.NodeDescription{Hostname: "worker1"},
+		Status:      swarm.NodeStatus{State: swarm.NodeStateDown},
+	}
+	tests := []struct {
+		name    string
+		task    swarm.Task
+		node    swarm.Node
+		wantErr bool
+	}{
+		{"node ready", withContainer, ready, false},
+		{"node down", withContainer, down, true},
+		{"rejected task without container", swarm.Task{NodeID: "node1"}, ready, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := taskLogsReachable(tt.task, tt.node); (err != nil) != tt.wantErr {
+				t.Errorf("taskLogsReachable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
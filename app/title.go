@@ -0,0 +1,75 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/moncho/dry/ui"
+)
+
+//terminalTitle keeps the terminal title, and optionally the tmux status,
+//in sync with the Docker host dry is connected to and the active view
+type terminalTitle struct {
+	host  string
+	tmux  bool
+	last  string
+	saved bool
+	sync.Mutex
+}
+
+func newTerminalTitle(host string, tmux bool) *terminalTitle {
+	return &terminalTitle{
+		host: host,
+		tmux: tmux && ui.InTmux(),
+	}
+}
+
+//setHost changes the Docker host shown, on the next update
+func (t *terminalTitle) setHost(host string) {
+	t.Lock()
+	defer t.Unlock()
+	t.host = host
+}
+
+//update sets the title for the given view, nothing is done if the title
+//has not changed since the last update
+func (t *terminalTitle) update(view viewMode) {
+	t.Lock()
+	defer t.Unlock()
+	status := titleFor(t.host, view)
+	if status == t.last {
+		return
+	}
+	if !t.saved {
+		ui.SaveTerminalTitle()
+		t.saved = true
+	}
+	t.last = status
+	ui.SetTerminalTitle("dry " + status)
+	if t.tmux {
+		ui.SetTmuxStatus(status)
+	}
+}
+
+//restore restores the terminal title found when dry started
+func (t *terminalTitle) restore() {
+	t.Lock()
+	defer t.Unlock()
+	if t.saved {
+		ui.RestoreTerminalTitle()
+	}
+	if t.tmux {
+		ui.SetTmuxStatus("")
+	}
+}
+
+//titleFor returns the title for the given host and view, i.e.
+//"[myhost:2376] Containers"
+func titleFor(host string, view viewMode) string {
+	host = strings.TrimPrefix(host, "tcp://")
+	if name := view.String(); name != "" {
+		return fmt.Sprintf("[%s] %s", host, name)
+	}
+	return fmt.Sprintf("[%s]", host)
+}
@@ -0,0 +1,20 @@
+package app
+
+import "testing"
+
+func Test_titleFor(t *testing.T) {
+	tests := []struct {
+		host string
+		view viewMode
+		want string
+	}{
+		{"tcp://myhost:2376", Main, "[myhost:2376] Containers"},
+		{"unix:///var/run/docker.sock", Nodes, "[unix:///var/run/docker.sock] Nodes"},
+		{"tcp://myhost:2376", NoView, "[myhost:2376]"},
+	}
+	for _, tt := range tests {
+		if got := titleFor(tt.host, tt.view); got != tt.want {
+			t.Errorf("titleFor(%s, %d) = %s, want %s", tt.host, tt.view, got, tt.want)
+		}
+	}
+}
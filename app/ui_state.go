@@ -0,0 +1,133 @@
+package app
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+	pkgError "github.com/pkg/errors"
+)
+
+//stateFile is where the UI state is saved on exit, next to the default config file
+var stateFile string
+
+func init() {
+	stateFile, _ = homedir.Expand(filepath.Join(filepath.Dir(DefaultConfigFile), "state.json"))
+}
+
+//uiState is the state of the UI that is restored on the next start
+type uiState struct {
+	//View is the active view, by the name used to configure the startup view
+	View string `json:"view,omitempty"`
+	//Cursor is the cursor position on the active view
+	Cursor int `json:"cursor,omitempty"`
+	//SortModes are the sort modes, as column names by list
+	SortModes map[string]string `json:"sort,omitempty"`
+	//Filters are the filters applied to lists, by list
+	Filters map[string]string `json:"filters,omitempty"`
+	//LabelFilter is the label filter of the container list, as key or key=value
+	LabelFilter string `json:"label_filter,omitempty"`
+}
+
+//sortableLists returns the lists whose sort mode can be configured, by name
+func sortableLists() map[string]sortable {
+	return map[string]sortable{
+		"containers": widgets.ContainerList,
+		"images":     widgets.ImageList,
+		"networks":   widgets.Networks,
+	}
+}
+
+//filterableLists returns the lists whose filter is kept between sessions, by name
+func filterableLists() map[string]appui.IncrementalFilterableWidget {
+	return map[string]appui.IncrementalFilterableWidget{
+		"containers": widgets.ContainerList,
+		"images":     widgets.ImageList,
+		"networks":   widgets.Networks,
+		"plugins":    widgets.Plugins,
+		"services":   widgets.ServiceList,
+		"nodes":      widgets.Nodes,
+	}
+}
+
+//readUIState reads the UI state saved on the given file
+func readUIState(path string) (uiState, error) {
+	var state uiState
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return state, pkgError.Wrap(err, "error reading UI state")
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, pkgError.Wrapf(err, "invalid UI state file %s", path)
+	}
+	return state, nil
+}
+
+//writeUIState saves the given UI state on the given file
+func writeUIState(path string, state uiState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return pkgError.Wrap(err, "error creating UI state directory")
+	}
+	return pkgError.Wrap(ioutil.WriteFile(path, data, 0600), "error saving UI state")
+}
+
+//uiState returns the current state of the UI
+func (d *Dry) uiState() uiState {
+	state := uiState{
+		SortModes: sortModeNames(sortableLists()),
+		Filters:   make(map[string]string),
+	}
+	view := d.viewMode()
+	for name, v := range startupViews {
+		if v == view {
+			state.View = name
+			state.Cursor = d.screen.Cursor().Position()
+		}
+	}
+	for name, w := range filterableLists() {
+		if pattern := w.FilterPattern(); pattern != "" {
+			state.Filters[name] = pattern
+		}
+	}
+	if filter := widgets.ContainerList.LabelFilter(); filter != nil {
+		state.LabelFilter = filter.String()
+	}
+	return state
+}
+
+//restoreUIState sets the given state on the UI, parts of the state that
+//are no longer valid are ignored
+func (d *Dry) restoreUIState(state uiState) {
+	setSortModes(sortableLists(), state.SortModes)
+	lists := filterableLists()
+	for name, pattern := range state.Filters {
+		if w, ok := lists[name]; ok {
+			w.Filter(pattern)
+		}
+	}
+	if state.LabelFilter != "" {
+		if filter, err := docker.ParseLabelFilter(state.LabelFilter); err == nil {
+			widgets.ContainerList.FilterByLabel(&filter)
+		}
+	}
+	if view, err := parseStartupView(state.View); err == nil && d.supports(view) {
+		d.changeView(view)
+		d.screen.Cursor().ScrollTo(state.Cursor)
+	}
+}
+
+//SaveUIState saves the current state of the UI, to be restored on the next start
+func (d *Dry) SaveUIState() error {
+	if !d.keepUIState || stateFile == "" {
+		return nil
+	}
+	return writeUIState(stateFile, d.uiState())
+}
@@ -0,0 +1,85 @@
+package app
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/moncho/dry/docker"
+)
+
+type sortableList struct {
+	mode docker.SortMode
+}
+
+func (l *sortableList) SetSortMode(mode docker.SortMode) {
+	l.mode = mode
+}
+
+func (l *sortableList) SortMode() docker.SortMode {
+	return l.mode
+}
+
+func TestUIState(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dry-state")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "dry", "state.json")
+
+	if _, err := readUIState(path); err == nil {
+		t.Error("Reading a missing UI state file did not fail")
+	}
+
+	state := uiState{
+		View:        "images",
+		Cursor:      3,
+		SortModes:   map[string]string{"images": "size"},
+		Filters:     map[string]string{"images": "ngx", "containers": "web"},
+		LabelFilter: "env=prod",
+	}
+	if err := writeUIState(path, state); err != nil {
+		t.Fatalf("Unexpected error saving the UI state: %s", err)
+	}
+	got, err := readUIState(path)
+	if err != nil {
+		t.Fatalf("Unexpected error reading the UI state: %s", err)
+	}
+	if got.View != state.View || got.Cursor != state.Cursor || got.LabelFilter != state.LabelFilter {
+		t.Errorf("UI state not restored, got %+v, want %+v", got, state)
+	}
+	if got.SortModes["images"] != "size" {
+		t.Errorf("Sort modes not restored, got %v", got.SortModes)
+	}
+	if got.Filters["images"] != "ngx" || got.Filters["containers"] != "web" {
+		t.Errorf("Filters not restored, got %v", got.Filters)
+	}
+}
+
+func TestSortModeNames(t *testing.T) {
+	lists := map[string]sortable{
+		"containers": &sortableList{docker.SortByStatus},
+		"images":     &sortableList{docker.SortImagesBySize},
+		"networks":   &sortableList{docker.NoSort},
+	}
+	names := sortModeNames(lists)
+	if len(names) != 2 || names["containers"] != "status" || names["images"] != "size" {
+		t.Errorf("Unexpected sort mode names: %v", names)
+	}
+
+	//names are set back as the same sort modes
+	restored := map[string]sortable{
+		"containers": &sortableList{},
+		"images":     &sortableList{},
+	}
+	if err := setSortModes(restored, names); err != nil {
+		t.Fatalf("Unexpected error setting sort modes: %s", err)
+	}
+	for name, l := range restored {
+		if l.SortMode() != lists[name].SortMode() {
+			t.Errorf("Sort mode of %s not restored, got %v, want %v", name, l.SortMode(), lists[name].SortMode())
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package app
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+//pendingRemoval is a removal waiting for the undo window to pass
+type pendingRemoval struct {
+	description string
+	cancel      chan struct{}
+}
+
+//removalQueue delays removals for a while, showing a countdown, so they
+//can be undone before being sent to the Docker daemon
+type removalQueue struct {
+	sync.Mutex
+	window time.Duration
+	//undoKey is the key undoing removals, shown on the countdown
+	undoKey string
+	notify  func(string)
+	pending []*pendingRemoval
+}
+
+//newRemovalQueue creates a removalQueue delaying removals the given time,
+//removals are not delayed if it is zero
+func newRemovalQueue(window time.Duration, undoKey string, notify func(string)) *removalQueue {
+	return &removalQueue{
+		window:  window,
+		undoKey: undoKey,
+		notify:  notify,
+	}
+}
+
+//schedule runs the given removal once the undo window has passed, unless
+//it is undone before, the description names what is removed
+func (q *removalQueue) schedule(description string, remove func()) {
+	if q.window <= 0 {
+		remove()
+		return
+	}
+	p := &pendingRemoval{description: description, cancel: make(chan struct{})}
+	q.Lock()
+	q.pending = append(q.pending, p)
+	q.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for left := q.window; left > 0; left -= time.Second {
+			q.notify(fmt.Sprintf("<red>Removing %s in %s,</> <white>press %s to undo</>",
+				description, left.Round(time.Second), q.undoKey))
+			select {
+			case <-p.cancel:
+				return
+			case <-ticker.C:
+			}
+		}
+		if q.dequeue(p) {
+			remove()
+		}
+	}()
+}
+
+//undo cancels the last removal scheduled that has not been run yet, it
+//returns its description, false if there was none
+func (q *removalQueue) undo() (string, bool) {
+	q.Lock()
+	defer q.Unlock()
+	if len(q.pending) == 0 {
+		return "", false
+	}
+	p := q.pending[len(q.pending)-1]
+	q.pending = q.pending[:len(q.pending)-1]
+	close(p.cancel)
+	return p.description, true
+}
+
+//dequeue removes the given removal from the pending ones, it returns
+//false if it was undone
+func (q *removalQueue) dequeue(p *pendingRemoval) bool {
+	q.Lock()
+	defer q.Unlock()
+	for i, pending := range q.pending {
+		if pending == p {
+			q.pending = append(q.pending[:i], q.pending[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+//undoRemoval undoes the last removal pending
+func (d *Dry) undoRemoval() {
+	if description, ok := d.removals.undo(); ok {
+		d.message(fmt.Sprintf("<white>Removal of %s undone</>", description))
+	} else {
+		d.message("Nothing to undo")
+	}
+}
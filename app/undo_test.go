@@ -0,0 +1,57 @@
+package app
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRemovalQueue(t *testing.T) {
+	removed := make(chan string, 2)
+	remove := func(name string) func() {
+		return func() { removed <- name }
+	}
+
+	q := newRemovalQueue(0, "u", func(string) {})
+	q.schedule("container a", remove("a"))
+	if got := <-removed; got != "a" {
+		t.Errorf("Removal not run right away without an undo window, got %s", got)
+	}
+	if _, ok := q.undo(); ok {
+		t.Error("A removal run right away was undone")
+	}
+
+	notified := make(chan string, 10)
+	q = newRemovalQueue(time.Second, "u", func(m string) { notified <- m })
+	q.schedule("container b", remove("b"))
+	q.schedule("container c", remove("c"))
+	description, ok := q.undo()
+	if !ok || description != "container c" {
+		t.Errorf("Unexpected removal undone: %s, %v", description, ok)
+	}
+	select {
+	case got := <-removed:
+		if got != "b" {
+			t.Errorf("Unexpected removal run: %s", got)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("Removal not run after the undo window")
+	}
+	select {
+	case got := <-removed:
+		t.Errorf("Undone removal was run: %s", got)
+	case <-time.After(100 * time.Millisecond):
+	}
+	if _, ok := q.undo(); ok {
+		t.Error("A removal already run was undone")
+	}
+
+	want := "<red>Removing container b in 1s,</> <white>press u to undo</>"
+	for found := false; !found; {
+		select {
+		case m := <-notified:
+			found = m == want
+		default:
+			t.Fatalf("Countdown of the removal not shown: %q", want)
+		}
+	}
+}
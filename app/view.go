@@ -1,5 +1,7 @@
 package app
 
+import "fmt"
+
 //viewMode represents dry possible views
 type viewMode uint16
 
@@ -20,6 +22,68 @@ const (
 	StackTasks
 	Tasks
 	ContainerMenu
+	ContainerFiles
 	Volumes
+	SwarmManagement
+	Plugins
+	StatsPlayback
+	Ownership
+	Hosts
+	ImageLayers
+	NetworkContainers
 	NoView
 )
+
+//viewNames are the names of the views, as shown to the user
+var viewNames = map[viewMode]string{
+	Main:              "Containers",
+	DiskUsage:         "Disk usage",
+	Images:            "Images",
+	Monitor:           "Monitor",
+	Networks:          "Networks",
+	EventsMode:        "Events",
+	HelpMode:          "Help",
+	InfoMode:          "Info",
+	Nodes:             "Nodes",
+	Services:          "Services",
+	ServiceTasks:      "Service tasks",
+	Stacks:            "Stacks",
+	StackTasks:        "Stack tasks",
+	Tasks:             "Node tasks",
+	ContainerMenu:     "Container commands",
+	ContainerFiles:    "Container files",
+	Volumes:           "Volumes",
+	SwarmManagement:   "Swarm",
+	Plugins:           "Plugins",
+	StatsPlayback:     "Recorded stats",
+	Ownership:         "Usage by owner",
+	Hosts:             "Docker hosts",
+	ImageLayers:       "Image layers",
+	NetworkContainers: "Network containers",
+}
+
+func (v viewMode) String() string {
+	return viewNames[v]
+}
+
+//startupViews are the views dry can start on, by the name used to configure them
+var startupViews = map[string]viewMode{
+	"containers": Main,
+	"images":     Images,
+	"networks":   Networks,
+	"volumes":    Volumes,
+	"plugins":    Plugins,
+	"nodes":      Nodes,
+	"services":   Services,
+	"stacks":     Stacks,
+	"monitor":    Monitor,
+}
+
+//parseStartupView returns the startup view with the given name
+func parseStartupView(name string) (viewMode, error) {
+	if v, ok := startupViews[name]; ok {
+		return v, nil
+	}
+	return NoView, fmt.Errorf(
+		"invalid view %q, expected containers, images, networks, volumes, plugins, nodes, services, stacks or monitor", name)
+}
@@ -29,12 +29,11 @@ func (h *volumesScreenEventHandler) handle(event *tcell.EventKey, f func(eh even
 	case tcell.KeyEnter: //inspect
 		forwarder := newEventForwarder()
 		f(forwarder)
-		inspect := inspect(screen, forwarder.events(),
+		inspect := inspect(h.dry, screen, forwarder.events(),
 			func(id string) (interface{}, error) {
 				return h.dry.dockerDaemon.VolumeInspect(context.Background(), id)
 			},
 			func() {
-				h.dry.changeView(Volumes)
 				f(h)
 				refreshScreen()
 			})
@@ -45,7 +44,7 @@ func (h *volumesScreenEventHandler) handle(event *tcell.EventKey, f func(eh even
 		}
 	case tcell.KeyCtrlA: //remove all
 
-		prompt := appui.NewPrompt("Do you want to remove all volumes? (y/N)")
+		prompt := appui.NewPrompt(confirmationPrompt("Do you want to remove all volumes?", false))
 		widgets.add(prompt)
 		forwarder := newEventForwarder()
 		f(forwarder)
@@ -61,7 +60,7 @@ func (h *volumesScreenEventHandler) handle(event *tcell.EventKey, f func(eh even
 			conf, cancel := prompt.Text()
 			f(h)
 			widgets.remove(prompt)
-			if cancel || (conf != "y" && conf != "Y") {
+			if cancel || !isConfirmed(conf, false) {
 				return
 			}
 
@@ -79,7 +78,7 @@ func (h *volumesScreenEventHandler) handle(event *tcell.EventKey, f func(eh even
 
 	case tcell.KeyCtrlE: //remove volume
 
-		prompt := appui.NewPrompt("Do you want to remove the selected volume? (y/N)")
+		prompt := appui.NewPrompt(confirmationPrompt("Do you want to remove the selected volume?", false))
 		widgets.add(prompt)
 		forwarder := newEventForwarder()
 		f(forwarder)
@@ -95,7 +94,7 @@ func (h *volumesScreenEventHandler) handle(event *tcell.EventKey, f func(eh even
 			conf, cancel := prompt.Text()
 			f(h)
 			widgets.remove(prompt)
-			if cancel || (conf != "y" && conf != "Y") {
+			if cancel || !isConfirmed(conf, false) {
 				return
 			}
 
@@ -118,7 +117,7 @@ func (h *volumesScreenEventHandler) handle(event *tcell.EventKey, f func(eh even
 		}()
 	case tcell.KeyCtrlF: //force volume removal
 
-		prompt := appui.NewPrompt("Do you want to remove the selected volume? (y/N)")
+		prompt := appui.NewPrompt(confirmationPrompt("Do you want to remove the selected volume?", false))
 		widgets.add(prompt)
 		forwarder := newEventForwarder()
 		f(forwarder)
@@ -134,7 +133,7 @@ func (h *volumesScreenEventHandler) handle(event *tcell.EventKey, f func(eh even
 			conf, cancel := prompt.Text()
 			f(h)
 			widgets.remove(prompt)
-			if cancel || (conf != "y" && conf != "Y") {
+			if cancel || !isConfirmed(conf, false) {
 				return
 			}
 
@@ -156,7 +155,7 @@ func (h *volumesScreenEventHandler) handle(event *tcell.EventKey, f func(eh even
 
 		}()
 	case tcell.KeyCtrlU: //remove unused volumes
-		prompt := appui.NewPrompt("Do you want to remove unused volumes? (y/N)")
+		prompt := appui.NewPrompt(confirmationPrompt("Do you want to remove unused volumes?", false))
 		widgets.add(prompt)
 		forwarder := newEventForwarder()
 		f(forwarder)
@@ -172,7 +171,7 @@ func (h *volumesScreenEventHandler) handle(event *tcell.EventKey, f func(eh even
 			conf, cancel := prompt.Text()
 			f(h)
 			widgets.remove(prompt)
-			if cancel || (conf != "y" && conf != "Y") {
+			if cancel || !isConfirmed(conf, false) {
 				return
 			}
 
@@ -16,21 +16,29 @@ import (
 // * a set of widgets to be rendered on the next rendering phase.
 //
 type widgetRegistry struct {
-	ContainerList *appui.ContainersWidget
-	ContainerMenu *appui.ContainerMenuWidget
-	DiskUsage     *appui.DockerDiskUsageRenderer
-	DockerInfo    *appui.DockerInfo
-	ImageList     *appui.DockerImagesWidget
-	MessageBar    *ui.ExpiringMessageWidget
-	Monitor       *appui.Monitor
-	Networks      *appui.DockerNetworksWidget
-	Nodes         *swarm.NodesWidget
-	NodeTasks     *swarm.NodeTasksWidget
-	ServiceTasks  *swarm.ServiceTasksWidget
-	ServiceList   *swarm.ServicesWidget
-	Stacks        *swarm.StacksWidget
-	StackTasks    *swarm.StacksTasksWidget
-	Volumes       *appui.VolumesWidget
+	ContainerList     *appui.ContainersWidget
+	ContainerFiles    *appui.ContainerFilesWidget
+	ContainerMenu     *appui.ContainerMenuWidget
+	DiskUsage         *appui.DockerDiskUsageRenderer
+	DockerInfo        *appui.DockerInfo
+	ImageList         *appui.DockerImagesWidget
+	MessageBar        *ui.ExpiringMessageWidget
+	Monitor           *appui.Monitor
+	Networks          *appui.DockerNetworksWidget
+	Ownership         *appui.OwnershipRenderer
+	Hosts             *appui.HostsRenderer
+	ImageLayers       *appui.ImageLayersRenderer
+	NetworkContainers *appui.NetworkContainersRenderer
+	Nodes             *swarm.NodesWidget
+	NodeTasks         *swarm.NodeTasksWidget
+	Plugins           *appui.PluginsWidget
+	ServiceTasks      *swarm.ServiceTasksWidget
+	ServiceList       *swarm.ServicesWidget
+	Stacks            *swarm.StacksWidget
+	StackTasks        *swarm.StacksTasksWidget
+	StatsPlayback     *appui.StatsPlayback
+	SwarmManagement   *appui.SwarmManagementRenderer
+	Volumes           *appui.VolumesWidget
 	sync.RWMutex
 	widgets map[string]termui.Widget
 }
@@ -14,7 +14,7 @@ var newLine = []byte{'\n'}
 
 //NewContainerInfo returns detailed container information. Returned int value
 //is the number of lines.
-func NewContainerInfo(container *docker.Container) (string, int) {
+func NewContainerInfo(container *docker.Container, note string) (string, int) {
 	var buffer bytes.Buffer
 	var status string
 	if docker.IsContainerRunning(container) {
@@ -28,6 +28,9 @@ func NewContainerInfo(container *docker.Container) (string, int) {
 		{ui.Blue("Command:"), ui.Yellow(container.Command)},
 		{ui.Blue("Port mapping:"), ui.Yellow(formatter.DisplayablePorts(container.Ports))},
 	}
+	if policy, ok := docker.ContainerRestartPolicy(container); ok {
+		data = append(data, []string{ui.Blue("Restart policy:"), ui.Yellow(docker.FormatRestartPolicy(policy))})
+	}
 	var networkNames []string
 	var networkIps []string
 	for k, v := range container.Container.NetworkSettings.Networks {
@@ -41,6 +44,9 @@ func NewContainerInfo(container *docker.Container) (string, int) {
 
 	data = append(data, []string{ui.Blue("Labels"), ui.Yellow(
 		strconv.Itoa(len(container.Labels)))})
+	if note != "" {
+		data = append(data, []string{ui.Blue("Note:"), ui.White(note)})
+	}
 
 	table := tablewriter.NewWriter(&buffer)
 	table.SetBorder(false)
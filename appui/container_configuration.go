@@ -0,0 +1,36 @@
+package appui
+
+import (
+	"io"
+
+	"github.com/gdamore/tcell"
+	"github.com/moncho/dry/ui"
+)
+
+//ContainerConfiguration shows the configuration of a container as given by
+//the render func, with secret values masked. 'v' shows or masks them.
+func ContainerConfiguration(render func(masked bool) string, screen *ui.Screen, events <-chan *tcell.EventKey, onDone func()) {
+	less := ui.NewLess(DryTheme)
+	less.MarkupSupport()
+	masked := true
+	show := func() {
+		less.Clear()
+		io.WriteString(less, render(masked))
+		if masked {
+			less.SetStatus("Secrets: masked (v: show)")
+		} else {
+			less.SetStatus("Secrets: shown (v: mask)")
+		}
+	}
+	less.Bind('v', func() {
+		masked = !masked
+		show()
+	})
+	defer onDone()
+	screen.ClearAndFlush()
+	show()
+	less.Focus(events)
+	screen.HideCursor()
+	screen.ClearAndFlush()
+	screen.Sync()
+}
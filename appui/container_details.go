@@ -12,9 +12,10 @@ type ContainerDetailsWidget struct {
 	drytermui.SizableBufferer
 }
 
-//NewContainerDetailsWidget creates ContainerDetailsWidget with information about the service with the given ID
-func NewContainerDetailsWidget(container *docker.Container, y int) *ContainerDetailsWidget {
-	info, lines := NewContainerInfo(container)
+//NewContainerDetailsWidget creates ContainerDetailsWidget with information about the
+//given container and the note attached to it, if any
+func NewContainerDetailsWidget(container *docker.Container, note string, y int) *ContainerDetailsWidget {
+	info, lines := NewContainerInfo(container, note)
 
 	cInfo := drytermui.NewParFromMarkupText(DryTheme, info)
 	cInfo.Y = y
@@ -39,7 +39,7 @@ func TestNewContainerDetailsWidget(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			w := NewContainerDetailsWidget(tt.args.container, tt.args.y)
+			w := NewContainerDetailsWidget(tt.args.container, "", tt.args.y)
 
 			if w == nil {
 				t.Error("ContainerDetailsWidget was not created")
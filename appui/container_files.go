@@ -0,0 +1,342 @@
+package appui
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"sync"
+
+	units "github.com/docker/go-units"
+	gizaktermui "github.com/gizak/termui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/docker/formatter"
+	"github.com/moncho/dry/ui/termui"
+)
+
+type containerFilesService interface {
+	ContainerByID(id string) *docker.Container
+	ContainerFiles(id, dir string) ([]docker.ContainerFile, error)
+}
+
+//ContainerFilesWidget shows the files of a directory of a container filesystem
+type ContainerFilesWidget struct {
+	service              containerFilesService
+	cID                  string
+	cName                string
+	dir                  string
+	totalRows            []*ContainerFileRow
+	filteredRows         []*ContainerFileRow
+	header               *termui.TableHeader
+	filterPattern        string
+	selectedIndex        int
+	startIndex, endIndex int
+	screen               Screen
+
+	sync.RWMutex
+	mounted bool
+}
+
+//NewContainerFilesWidget creates a ContainerFilesWidget
+func NewContainerFilesWidget(service containerFilesService, s Screen) *ContainerFilesWidget {
+	return &ContainerFilesWidget{
+		header:  containerFilesTableHeader(),
+		service: service,
+		screen:  s,
+		dir:     "/",
+	}
+}
+
+//Buffer returns the content of this widget as a termui.Buffer
+func (s *ContainerFilesWidget) Buffer() gizaktermui.Buffer {
+	s.Lock()
+	defer s.Unlock()
+	buf := gizaktermui.NewBuffer()
+
+	if !s.mounted {
+		return buf
+	}
+	s.prepareForRendering()
+	y := s.screen.Bounds().Min.Y
+	widgetHeader := NewWidgetHeader()
+	widgetHeader.HeaderEntry("Container", s.cName)
+	widgetHeader.HeaderEntry("Directory", s.dir)
+	widgetHeader.HeaderEntry("Files", strconv.Itoa(s.RowCount()))
+	if s.filterPattern != "" {
+		widgetHeader.HeaderEntry("Active filter", s.filterPattern)
+	}
+	widgetHeader.Y = y
+	buf.Merge(widgetHeader.Buffer())
+	y += widgetHeader.GetHeight()
+	//Empty line between the header and the rest of the content
+	y++
+	s.header.SetY(y)
+	buf.Merge(s.header.Buffer())
+
+	y += s.header.GetHeight()
+
+	selected := s.selectedIndex - s.startIndex
+
+	for i, file := range s.visibleRows() {
+		file.SetY(y)
+		y += file.GetHeight()
+		if i != selected {
+			file.NotHighlighted()
+		} else {
+			file.Highlighted()
+		}
+		buf.Merge(file.Buffer())
+	}
+
+	return buf
+}
+
+//ChangeDir sets the directory whose files are shown
+func (s *ContainerFilesWidget) ChangeDir(dir string) {
+	s.Lock()
+	defer s.Unlock()
+	s.dir = path.Clean("/" + dir)
+	s.filterPattern = ""
+	s.mounted = false
+}
+
+//Dir returns the directory whose files are shown
+func (s *ContainerFilesWidget) Dir() string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.dir
+}
+
+//ContainerID returns the id of the container whose files are shown
+func (s *ContainerFilesWidget) ContainerID() string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.cID
+}
+
+//Filter applies the given filter to the file list
+func (s *ContainerFilesWidget) Filter(filter string) {
+	s.Lock()
+	defer s.Unlock()
+	s.filterPattern = filter
+}
+
+//ForContainer sets the container whose files are shown, starting from the root directory
+func (s *ContainerFilesWidget) ForContainer(cID string) {
+	s.Lock()
+	defer s.Unlock()
+	s.cID = cID
+	s.dir = "/"
+	s.filterPattern = ""
+	s.mounted = false
+}
+
+//Mount tells this widget to be ready for rendering
+func (s *ContainerFilesWidget) Mount() error {
+	s.Lock()
+	defer s.Unlock()
+	if s.mounted {
+		s.align()
+		return nil
+	}
+	s.mounted = true
+	s.cName = s.cID
+	if c := s.service.ContainerByID(s.cID); c != nil {
+		s.cName = formatter.NewContainerFormatter(c, true).Names()
+	}
+	s.totalRows = nil
+	files, err := s.service.ContainerFiles(s.cID, s.dir)
+	if err != nil {
+		return fmt.Errorf("could not list %s: %s", s.dir, err.Error())
+	}
+	for _, f := range files {
+		s.totalRows = append(s.totalRows, NewContainerFileRow(f, s.header))
+	}
+	s.align()
+	return nil
+}
+
+//Name returns this widget name
+func (s *ContainerFilesWidget) Name() string {
+	return "ContainerFilesWidget"
+}
+
+//OnEvent runs the given command on the path of the selected file
+func (s *ContainerFilesWidget) OnEvent(event EventCommand) error {
+	file, err := s.Selected()
+	if err != nil {
+		return err
+	}
+	return event(file.Path)
+}
+
+//RowCount returns the number of rows of this widget.
+func (s *ContainerFilesWidget) RowCount() int {
+	return len(s.filteredRows)
+}
+
+//Selected returns the selected file
+func (s *ContainerFilesWidget) Selected() (docker.ContainerFile, error) {
+	s.RLock()
+	defer s.RUnlock()
+	if s.RowCount() <= 0 {
+		return docker.ContainerFile{}, errors.New("the directory is empty")
+	} else if s.selectedIndex >= s.RowCount() {
+		return docker.ContainerFile{}, fmt.Errorf("there is no file on pos %d", s.selectedIndex)
+	}
+	return s.filteredRows[s.selectedIndex].file, nil
+}
+
+//Sort is a noop for this widget, directories are shown first, then files, by name
+func (s *ContainerFilesWidget) Sort() {
+}
+
+//Unmount this widget
+func (s *ContainerFilesWidget) Unmount() error {
+	s.Lock()
+	defer s.Unlock()
+	s.mounted = false
+	return nil
+}
+
+func (s *ContainerFilesWidget) align() {
+	x := s.screen.Bounds().Min.X
+	width := s.screen.Bounds().Dx()
+
+	s.header.SetWidth(width)
+	s.header.SetX(x)
+
+	for _, file := range s.totalRows {
+		file.SetX(x)
+		file.SetWidth(width)
+	}
+}
+
+func (s *ContainerFilesWidget) filterRows() {
+	if s.filterPattern != "" {
+		var rows []*ContainerFileRow
+		for _, row := range s.totalRows {
+			if RowFilters.ByPattern(s.filterPattern)(row) {
+				rows = append(rows, row)
+			}
+		}
+		s.filteredRows = rows
+	} else {
+		s.filteredRows = s.totalRows
+	}
+}
+
+func (s *ContainerFilesWidget) prepareForRendering() {
+	s.filterRows()
+	s.screen.Cursor().Max(s.RowCount() - 1)
+
+	index := s.screen.Cursor().Position()
+	if index < 0 {
+		index = 0
+	} else if index > s.RowCount() {
+		index = s.RowCount() - 1
+	}
+	s.selectedIndex = index
+	s.calculateVisibleRows()
+}
+
+func (s *ContainerFilesWidget) visibleRows() []*ContainerFileRow {
+	return s.filteredRows[s.startIndex:s.endIndex]
+}
+
+func (s *ContainerFilesWidget) calculateVisibleRows() {
+
+	height := s.screen.Bounds().Dy() - widgetHeaderLength
+
+	count := s.RowCount()
+	//no screen
+	if height < 0 || count == 0 {
+		s.startIndex = 0
+		s.endIndex = 0
+		return
+	}
+	selected := s.selectedIndex
+	//everything fits
+	if count <= height {
+		s.startIndex = 0
+		s.endIndex = count
+		return
+	}
+	//at the the start
+	if selected == 0 {
+		s.startIndex = 0
+		s.endIndex = height
+	} else if selected >= count-1 { //at the end
+		s.startIndex = count - height
+		s.endIndex = count
+	} else if selected == s.endIndex { //scroll down by one
+		s.startIndex++
+		s.endIndex++
+	} else if selected <= s.startIndex { //scroll up by one
+		s.startIndex--
+		s.endIndex--
+	} else if selected > s.endIndex { // scroll
+		s.startIndex = selected - height
+		s.endIndex = selected
+	}
+}
+
+func containerFilesTableHeader() *termui.TableHeader {
+	header := termui.NewHeader(DryTheme)
+	header.ColumnSpacing = DefaultColumnSpacing
+	header.AddFixedWidthColumn("MODE", 11)
+	header.AddFixedWidthColumn("SIZE", 10)
+	header.AddFixedWidthColumn("MODIFIED", 16)
+	header.AddColumn("NAME")
+	return header
+}
+
+//ContainerFileRow is a Grid row showing information about a file of a container
+type ContainerFileRow struct {
+	file     docker.ContainerFile
+	Mode     *termui.ParColumn
+	Size     *termui.ParColumn
+	Modified *termui.ParColumn
+	FileName *termui.ParColumn
+	Row
+}
+
+//NewContainerFileRow creates a ContainerFileRow widget
+func NewContainerFileRow(file docker.ContainerFile, table termui.Table) *ContainerFileRow {
+	name := file.Name
+	size := units.HumanSize(float64(file.Size))
+	if file.IsDir() {
+		name += "/"
+		size = "-"
+	} else if file.LinkTarget != "" {
+		name += " -> " + file.LinkTarget
+	}
+	row := &ContainerFileRow{
+		file:     file,
+		Mode:     termui.NewThemedParColumn(DryTheme, file.Mode.String()),
+		Size:     termui.NewThemedParColumn(DryTheme, size),
+		Modified: termui.NewThemedParColumn(DryTheme, file.ModTime.Format("2006-01-02 15:04")),
+		FileName: termui.NewThemedParColumn(DryTheme, name),
+	}
+	row.Height = 1
+	row.Table = table
+	//Columns are rendered following the slice order
+	row.Columns = []gizaktermui.GridBufferer{
+		row.Mode,
+		row.Size,
+		row.Modified,
+		row.FileName,
+	}
+	row.ParColumns = []*termui.ParColumn{
+		row.Mode,
+		row.Size,
+		row.Modified,
+		row.FileName,
+	}
+	return row
+}
+
+//ColumnsForFilter returns the columns that are used to filter
+func (row *ContainerFileRow) ColumnsForFilter() []*termui.ParColumn {
+	return []*termui.ParColumn{row.FileName}
+}
@@ -0,0 +1,143 @@
+package appui
+
+import (
+	"fmt"
+	"sort"
+
+	termui "github.com/gizak/termui"
+	"github.com/moncho/dry/docker"
+	drytermui "github.com/moncho/dry/ui/termui"
+)
+
+const (
+	expandedGroupSymbol  = "▾"
+	collapsedGroupSymbol = "▸"
+	//noProject is how the group of containers not belonging to any
+	//project is shown
+	noProject = "(no project)"
+)
+
+//newContainerGroupRow creates the row heading a group of containers that
+//belong to the given project, showing how many of them are running. The row
+//has no container.
+func newContainerGroupRow(project string, rows []*ContainerRow, collapsed bool, table drytermui.Table, labels ...string) *ContainerRow {
+	running := 0
+	for _, row := range rows {
+		if docker.IsContainerRunning(row.container) {
+			running++
+		}
+	}
+	symbol := expandedGroupSymbol
+	if collapsed {
+		symbol = collapsedGroupSymbol
+	}
+	name := project
+	if name == "" {
+		name = noProject
+	}
+	row := &ContainerRow{
+		container:   &docker.Container{},
+		groupHeader: true,
+		project:     project,
+		Indicator:   drytermui.NewThemedParColumn(DryTheme, symbol),
+		ID:          drytermui.NewThemedParColumn(DryTheme, ""),
+		Image:       drytermui.NewThemedParColumn(DryTheme, name),
+		Command:     drytermui.NewThemedParColumn(DryTheme, ""),
+		Status:      drytermui.NewThemedParColumn(DryTheme, fmt.Sprintf("%d/%d running", running, len(rows))),
+		Ports:       drytermui.NewThemedParColumn(DryTheme, ""),
+		Names:       drytermui.NewThemedParColumn(DryTheme, fmt.Sprintf("%d containers", len(rows))),
+		Labels:      NewLabelColumns(nil, labels),
+	}
+	row.Height = 1
+	row.Table = table
+	row.Columns = []termui.GridBufferer{
+		row.Indicator,
+		row.ID,
+		row.Image,
+		row.Command,
+		row.Status,
+		row.Ports,
+		row.Names,
+	}
+	for _, c := range row.Labels {
+		row.Columns = append(row.Columns, c)
+	}
+	return row
+}
+
+//groupRowsByProject sorts the rows by project, keeping the current order on
+//each project, containers not belonging to any project go last
+func (s *ContainersWidget) groupRowsByProject() {
+	rows := s.totalRows
+	sort.SliceStable(rows, func(i, j int) bool {
+		pi, pj := docker.ProjectOf(rows[i].container), docker.ProjectOf(rows[j].container)
+		if pi == "" || pj == "" {
+			return pj == "" && pi != ""
+		}
+		return pi < pj
+	})
+}
+
+//nestProjectGroups puts a row heading each group of containers of the same
+//project on the filtered rows, that must be grouped by project. The rows of
+//collapsed groups are left out.
+func (s *ContainersWidget) nestProjectGroups() {
+	var rows []*ContainerRow
+	groups := 0
+	for start := 0; start < len(s.filteredRows); {
+		project := docker.ProjectOf(s.filteredRows[start].container)
+		end := start + 1
+		for end < len(s.filteredRows) && docker.ProjectOf(s.filteredRows[end].container) == project {
+			end++
+		}
+		collapsed := s.collapsedGroups[project]
+		group := newContainerGroupRow(project, s.filteredRows[start:end], collapsed, s.header, s.labelColumns...)
+		if s.showHealth {
+			group.addHealthColumn()
+		}
+		group.SetX(s.screen.Bounds().Min.X)
+		group.SetWidth(s.screen.Bounds().Dx())
+		rows = append(rows, group)
+		if !collapsed {
+			rows = append(rows, s.filteredRows[start:end]...)
+		}
+		groups++
+		start = end
+	}
+	s.filteredRows = rows
+	s.projectGroups = groups
+}
+
+//ToggleGroupByProject toggles nesting containers under the Docker Compose
+//project, or the stack, they belong to. Grouping by project and by image
+//are exclusive.
+func (s *ContainersWidget) ToggleGroupByProject() {
+	s.Lock()
+	defer s.Unlock()
+	s.groupByProject = !s.groupByProject
+	if s.groupByProject {
+		s.groupByImage = false
+	}
+}
+
+//ToggleGroupCollapsed collapses, or expands, the group of containers of the
+//selected row, it returns false if containers are not grouped by project
+func (s *ContainersWidget) ToggleGroupCollapsed() bool {
+	s.Lock()
+	defer s.Unlock()
+	if !s.groupByProject || s.selectedIndex < 0 || s.selectedIndex >= len(s.filteredRows) {
+		return false
+	}
+	//the group is collapsed from its header row, so the cursor goes there
+	header := s.selectedIndex
+	for !s.filteredRows[header].groupHeader {
+		header--
+	}
+	project := s.filteredRows[header].project
+	if s.collapsedGroups == nil {
+		s.collapsedGroups = make(map[string]bool)
+	}
+	s.collapsedGroups[project] = !s.collapsedGroups[project]
+	s.screen.Cursor().ScrollTo(header)
+	return true
+}
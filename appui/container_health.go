@@ -0,0 +1,67 @@
+package appui
+
+import (
+	dockerTypes "github.com/docker/docker/api/types"
+	"github.com/gizak/termui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+	drytermui "github.com/moncho/dry/ui/termui"
+)
+
+//healthColumnWidth is the width of the health column, wide enough for unhealthy
+const healthColumnWidth = 9
+
+//noHealth is shown on the health column of containers without healthcheck
+const noHealth = "-"
+
+//containerHealth returns the health state of the given container, starting,
+//healthy or unhealthy, noHealth if it has no healthcheck
+func containerHealth(c *docker.Container) string {
+	health := docker.ContainerHealth(c)
+	if health == nil || health.Status == "" || health.Status == dockerTypes.NoHealthcheck {
+		return noHealth
+	}
+	return health.Status
+}
+
+//anyHealthcheck returns true if any of the given containers has a healthcheck
+func anyHealthcheck(containers []*docker.Container) bool {
+	for _, c := range containers {
+		if containerHealth(c) != noHealth {
+			return true
+		}
+	}
+	return false
+}
+
+//healthColor returns the color the given health state is shown with
+func healthColor(health string) termui.Attribute {
+	switch health {
+	case "healthy":
+		return Running
+	case "unhealthy":
+		return NotRunning
+	case "starting":
+		return termui.Attribute(ui.ColorYellow)
+	}
+	return inactiveRowColor
+}
+
+//addHealthColumn adds to this row a column with the health of its
+//container, after the status column
+func (row *ContainerRow) addHealthColumn() {
+	health := ""
+	if !row.groupHeader {
+		health = containerHealth(row.container)
+	}
+	row.Health = drytermui.NewThemedParColumn(DryTheme, health)
+	row.Health.TextFgColor = healthColor(health)
+	for i, c := range row.Columns {
+		if c == row.Status {
+			columns := append([]termui.GridBufferer{}, row.Columns[:i+1]...)
+			columns = append(columns, row.Health)
+			row.Columns = append(columns, row.Columns[i+1:]...)
+			return
+		}
+	}
+}
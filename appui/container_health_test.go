@@ -0,0 +1,35 @@
+package appui
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/moncho/dry/docker"
+)
+
+func TestContainerHealthColumn(t *testing.T) {
+	healthy := &docker.Container{
+		Container: types.Container{ID: "1", State: "running"},
+		ContainerJSON: types.ContainerJSON{ContainerJSONBase: &types.ContainerJSONBase{
+			State: &types.ContainerState{Health: &types.Health{Status: types.Healthy}},
+		}},
+	}
+	plain := &docker.Container{Container: types.Container{ID: "2", State: "running"}}
+
+	if anyHealthcheck([]*docker.Container{plain}) || !anyHealthcheck([]*docker.Container{plain, healthy}) {
+		t.Error("Unexpected containers with healthcheck found")
+	}
+	for _, tt := range []struct {
+		c    *docker.Container
+		want string
+	}{{healthy, types.Healthy}, {plain, noHealth}} {
+		row := NewContainerRow(tt.c, containerTableHeader(true))
+		row.addHealthColumn()
+		if row.Columns[5] != row.Health || row.Health.Text != tt.want {
+			t.Errorf("Unexpected health column of container %s: %q", tt.c.ID, row.Health.Text)
+		}
+		if row.Health.TextFgColor != healthColor(tt.want) {
+			t.Errorf("Unexpected color of the health of container %s", tt.c.ID)
+		}
+	}
+}
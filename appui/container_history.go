@@ -0,0 +1,117 @@
+package appui
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/events"
+	"github.com/gdamore/tcell"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+)
+
+//containerHistory writes the events of the history of a container to a Less
+//view, each event once, no matter how many sources report it
+type containerHistory struct {
+	v      *ui.Less
+	filter docker.EventFilter
+	seen   map[string]bool
+	empty  bool
+	sync.Mutex
+}
+
+//StreamContainerHistory shows on screen the given events of the history of
+//the container with the given name and id, in chronological order, followed
+//by the events of the container the given source notifies from then on.
+func StreamContainerHistory(name, id string, history []events.Message, source EventsSource, keyboardQueue <-chan *tcell.EventKey, done func()) {
+	defer done()
+	ui.ActiveScreen.ClearAndFlush()
+	v := ui.NewLess(DryTheme)
+	v.MarkupSupport()
+	v.Follow(true)
+
+	h := &containerHistory{
+		v:      v,
+		filter: docker.ContainerHistoryFilter(id),
+		seen:   make(map[string]bool),
+	}
+	//events are taken from the source before locking the history, since the
+	//source keeps itself locked while notifying events to it
+	history = docker.MergeEvents(history, source.Events())
+	h.Lock()
+	stop := source.Listen(h.add)
+	fmt.Fprintf(v, "\n<blue><b>EVENTS OF CONTAINER</></> <white>%s</>\n\n", name)
+	h.empty = true
+	for _, event := range history {
+		h.write(event)
+	}
+	if h.empty {
+		io.WriteString(v, "<darkgrey>Docker daemon has not reported events of the container, new ones are shown as they happen.</>\n")
+	}
+	h.Unlock()
+
+	v.SetStatus("Events: Live")
+	v.Focus(keyboardQueue)
+
+	stop()
+	ui.ActiveScreen.HideCursor()
+	ui.ActiveScreen.ClearAndFlush()
+	ui.ActiveScreen.Sync()
+}
+
+//add adds the given event to the history, if it is an event of the container
+func (h *containerHistory) add(event events.Message) {
+	h.Lock()
+	defer h.Unlock()
+	h.write(event)
+}
+
+//write writes the given event to the view, unless it is not an event of the
+//container or it was already written
+func (h *containerHistory) write(event events.Message) {
+	if !h.filter(event) {
+		return
+	}
+	key := docker.EventKey(event)
+	if h.seen[key] {
+		return
+	}
+	h.seen[key] = true
+	h.empty = false
+	io.WriteString(h.v, containerEventLine(event)+"\n")
+}
+
+//containerEventLine describes the given event of the history of a container
+func containerEventLine(event events.Message) string {
+	when := time.Unix(event.Time, 0)
+	if event.TimeNano != 0 {
+		when = time.Unix(0, event.TimeNano)
+	}
+	line := fmt.Sprintf("<white>%s</> ", when.Format("2006-01-02 15:04:05.000"))
+	attrs := event.Actor.Attributes
+	switch event.Action {
+	case "health_status: healthy":
+		return line + "<green>health_status: healthy</>"
+	case "health_status: unhealthy":
+		return line + "<red>health_status: unhealthy</>"
+	case "die":
+		if code := attrs["exitCode"]; code == "0" {
+			return line + "<blue>die</> (exit code 0)"
+		} else if code != "" {
+			return line + fmt.Sprintf("<red>die</> (exit code %s)", code)
+		}
+	case "oom":
+		return line + "<red>oom</>"
+	case "kill":
+		if signal := attrs["signal"]; signal != "" {
+			return line + fmt.Sprintf("<blue>kill</> (signal %s)", signal)
+		}
+	case "rename":
+		if old := attrs["oldName"]; old != "" {
+			return line + fmt.Sprintf("<blue>rename</> (from %s)", old)
+		}
+	}
+	return line + "<blue>" + event.Action + "</>"
+}
@@ -0,0 +1,41 @@
+package appui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/events"
+)
+
+func TestContainerEventLine(t *testing.T) {
+	when := time.Date(2020, 3, 9, 21, 45, 5, 0, time.Local)
+	tests := []struct {
+		action string
+		attrs  map[string]string
+		want   string
+	}{
+		{"start", nil, "<blue>start</>"},
+		{"die", map[string]string{"exitCode": "137"}, "<red>die</> (exit code 137)"},
+		{"die", map[string]string{"exitCode": "0"}, "<blue>die</> (exit code 0)"},
+		{"die", nil, "<blue>die</>"},
+		{"kill", map[string]string{"signal": "9"}, "<blue>kill</> (signal 9)"},
+		{"health_status: unhealthy", nil, "<red>health_status: unhealthy</>"},
+		{"health_status: healthy", nil, "<green>health_status: healthy</>"},
+	}
+	for _, tt := range tests {
+		event := events.Message{
+			Type:     events.ContainerEventType,
+			Action:   tt.action,
+			Actor:    events.Actor{ID: "web", Attributes: tt.attrs},
+			TimeNano: when.UnixNano(),
+		}
+		got := containerEventLine(event)
+		if !strings.HasPrefix(got, "<white>2020-03-09 21:45:05.000</> ") {
+			t.Errorf("%s: unexpected time on %q", tt.action, got)
+		}
+		if !strings.HasSuffix(got, " "+tt.want) {
+			t.Errorf("%s: got %q, want it to end with %q", tt.action, got, tt.want)
+		}
+	}
+}
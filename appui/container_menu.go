@@ -20,6 +20,8 @@ type ContainerMenuWidget struct {
 	selectedIndex int
 	screen        Screen
 	OnUnmount     func() error
+	//Note returns the note attached to the given container, if any
+	Note func(*docker.Container) string
 
 	sync.RWMutex
 	mounted bool
@@ -90,7 +92,11 @@ func (s *ContainerMenuWidget) Mount() error {
 
 	c := s.dockerDaemon.ContainerByID(s.cID)
 	if c != nil {
-		s.cInfo = NewContainerDetailsWidget(c, s.screen.Bounds().Min.Y)
+		note := ""
+		if s.Note != nil {
+			note = s.Note(c)
+		}
+		s.cInfo = NewContainerDetailsWidget(c, note, s.screen.Bounds().Min.Y)
 	}
 	rows := make([]*Row, len(docker.CommandDescriptions))
 	for i, command := range docker.CommandDescriptions {
@@ -0,0 +1,60 @@
+package appui
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell"
+	"github.com/moncho/dry/ui"
+)
+
+const copyAddressPrompt = "Copy the host address of port number (empty for the first one): "
+
+//ContainerPorts shows the given report of the ports of a container, 'c'
+//copies to the clipboard the host address of the given port number, the
+//addresses are numbered from 1 on the report
+func ContainerPorts(report string, addresses []string, screen *ui.Screen, events <-chan *tcell.EventKey, onDone func()) {
+	less := ui.NewLess(DryTheme)
+	less.MarkupSupport()
+	less.BindInput('c', copyAddressPrompt, func(input string) {
+		address, err := hostAddress(addresses, input)
+		if err != nil {
+			less.Message(err.Error())
+			return
+		}
+		if err := ui.CopyToClipboard(address); err != nil {
+			less.Message("Could not copy to clipboard: " + err.Error())
+			return
+		}
+		less.Message(address + " copied to clipboard")
+	})
+	if len(addresses) > 0 {
+		less.SetStatus("c: copy host address")
+	}
+	defer onDone()
+	screen.ClearAndFlush()
+	io.WriteString(less, report)
+	less.Focus(events)
+	screen.HideCursor()
+	screen.ClearAndFlush()
+	screen.Sync()
+}
+
+//hostAddress returns the address with the given number, starting from 1,
+//the first one if no number is given
+func hostAddress(addresses []string, input string) (string, error) {
+	if len(addresses) == 0 {
+		return "", fmt.Errorf("No port is published on the host")
+	}
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return addresses[0], nil
+	}
+	i, err := strconv.Atoi(input)
+	if err != nil || i < 1 || i > len(addresses) {
+		return "", fmt.Errorf("Invalid port number %q, it goes from 1 to %d", input, len(addresses))
+	}
+	return addresses[i-1], nil
+}
@@ -0,0 +1,20 @@
+package appui
+
+import "testing"
+
+func TestHostAddress(t *testing.T) {
+	addresses := []string{"localhost:8080", "localhost:8443"}
+	for input, want := range map[string]string{"": "localhost:8080", " 2 ": "localhost:8443"} {
+		if got, err := hostAddress(addresses, input); err != nil || got != want {
+			t.Errorf("hostAddress(%q): got %q, %v, want %q", input, got, err, want)
+		}
+	}
+	for _, input := range []string{"0", "3", "web"} {
+		if _, err := hostAddress(addresses, input); err == nil {
+			t.Errorf("hostAddress(%q) did not fail", input)
+		}
+	}
+	if _, err := hostAddress(nil, ""); err == nil {
+		t.Error("hostAddress without addresses did not fail")
+	}
+}
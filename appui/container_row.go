@@ -2,6 +2,7 @@ package appui
 
 import (
 	"image"
+	"strings"
 
 	termui "github.com/gizak/termui"
 	"github.com/moncho/dry/docker"
@@ -11,6 +12,8 @@ import (
 
 const (
 	statusSymbol = string('\u25A3')
+	//oomStatus replaces Exited on the status of containers killed for running out of memory
+	oomStatus = "OOM killed"
 )
 
 //ContainerRow is a Grid row showing runtime information about a container
@@ -21,14 +24,25 @@ type ContainerRow struct {
 	Image     *drytermui.ParColumn
 	Command   *drytermui.ParColumn
 	Status    *drytermui.ParColumn
-	Ports     *drytermui.ParColumn
-	Names     *drytermui.ParColumn
-	running   bool
+	//Health is only set if the list shows the health of containers
+	Health *drytermui.ParColumn
+	//statusFg, if set, is the color of the status of containers that failed
+	statusFg termui.Attribute
+	Ports    *drytermui.ParColumn
+	Names    *drytermui.ParColumn
+	Labels   []*drytermui.ParColumn
+	running  bool
+	//image is the text of the image column when not grouped
+	image string
+	//groupHeader is true if this row heads the group of containers of project
+	groupHeader bool
+	project     string
 	drytermui.Row
 }
 
-//NewContainerRow creates a new ContainerRow widget
-func NewContainerRow(container *docker.Container, table drytermui.Table) *ContainerRow {
+//NewContainerRow creates a new ContainerRow widget, the values of the given
+//labels are shown as extra columns
+func NewContainerRow(container *docker.Container, table drytermui.Table, labels ...string) *ContainerRow {
 	cf := formatter.NewContainerFormatter(container, true)
 
 	row := &ContainerRow{
@@ -40,6 +54,8 @@ func NewContainerRow(container *docker.Container, table drytermui.Table) *Contai
 		Status:    drytermui.NewThemedParColumn(DryTheme, cf.Status()),
 		Ports:     drytermui.NewThemedParColumn(DryTheme, cf.Ports()),
 		Names:     drytermui.NewThemedParColumn(DryTheme, cf.Names()),
+		Labels:    NewLabelColumns(container.Labels, labels),
+		image:     cf.Image(),
 	}
 	row.Height = 1
 	row.Table = table
@@ -53,8 +69,12 @@ func NewContainerRow(container *docker.Container, table drytermui.Table) *Contai
 		row.Ports,
 		row.Names,
 	}
+	for _, c := range row.Labels {
+		row.Columns = append(row.Columns, c)
+	}
 	if !docker.IsContainerRunning(container) {
 		row.markAsNotRunning()
+		row.markExitState()
 	} else {
 		row.markAsRunning()
 	}
@@ -79,7 +99,7 @@ func (row *ContainerRow) Buffer() termui.Buffer {
 
 //ColumnsForFilter returns the columns that are used to filter
 func (row *ContainerRow) ColumnsForFilter() []*drytermui.ParColumn {
-	return []*drytermui.ParColumn{row.ID, row.Image, row.Names, row.Command}
+	return append([]*drytermui.ParColumn{row.ID, row.Image, row.Names, row.Command}, row.Labels...)
 }
 
 //Highlighted marks this rows as being highlighted
@@ -92,7 +112,9 @@ func (row *ContainerRow) Highlighted() {
 //NotHighlighted marks this rows as being not highlighted
 func (row *ContainerRow) NotHighlighted() {
 	var fg termui.Attribute
-	if !docker.IsContainerRunning(row.container) {
+	if row.groupHeader {
+		fg = termui.Attribute(DryTheme.Info)
+	} else if !docker.IsContainerRunning(row.container) {
 		fg = inactiveRowColor
 	} else {
 		fg = termui.Attribute(DryTheme.ListItem)
@@ -113,10 +135,21 @@ func (row *ContainerRow) changeTextColor(fg, bg termui.Attribute) {
 	row.Command.TextBgColor = bg
 	row.Status.TextFgColor = fg
 	row.Status.TextBgColor = bg
+	if row.statusFg != 0 {
+		row.Status.TextFgColor = row.statusFg
+	}
+	if row.Health != nil {
+		//the health keeps its color
+		row.Health.TextBgColor = bg
+	}
 	row.Ports.TextFgColor = fg
 	row.Ports.TextBgColor = bg
 	row.Names.TextFgColor = fg
 	row.Names.TextBgColor = bg
+	for _, c := range row.Labels {
+		c.TextFgColor = fg
+		c.TextBgColor = bg
+	}
 }
 
 //markAsNotRunning
@@ -128,9 +161,30 @@ func (row *ContainerRow) markAsNotRunning() {
 	row.Status.TextFgColor = inactiveRowColor
 	row.Ports.TextFgColor = inactiveRowColor
 	row.Names.TextFgColor = inactiveRowColor
+	for _, c := range row.Labels {
+		c.TextFgColor = inactiveRowColor
+	}
 	row.running = false
 }
 
+//markExitState makes the status of containers that exited with a non-zero
+//exit code stand out, those killed for running out of memory are shown as such
+func (row *ContainerRow) markExitState() {
+	exitCode, oomKilled, exited := docker.ExitState(row.container)
+	if !exited {
+		return
+	}
+	if oomKilled {
+		row.Status.Text = oomStatus + strings.TrimPrefix(row.Status.Text, "Exited")
+		row.statusFg = NotRunning | termui.AttrBold
+	} else if exitCode != 0 {
+		row.statusFg = NotRunning
+	}
+	if row.statusFg != 0 {
+		row.Status.TextFgColor = row.statusFg
+	}
+}
+
 //markAsRunning
 func (row *ContainerRow) markAsRunning() {
 	row.Indicator.TextFgColor = Running
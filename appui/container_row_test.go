@@ -0,0 +1,43 @@
+package appui
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/gizak/termui"
+	"github.com/moncho/dry/docker"
+)
+
+func exitedContainer(id string, exitCode int, oomKilled bool) *docker.Container {
+	return &docker.Container{
+		Container: types.Container{ID: id, State: "exited", Status: "Exited (137) 2 minutes ago"},
+		ContainerJSON: types.ContainerJSON{ContainerJSONBase: &types.ContainerJSONBase{
+			State: &types.ContainerState{ExitCode: exitCode, OOMKilled: oomKilled},
+		}},
+	}
+}
+
+func TestContainerRowExitState(t *testing.T) {
+	for _, tt := range []struct {
+		c          *docker.Container
+		wantStatus string
+		wantFg     termui.Attribute
+	}{
+		{exitedContainer("1", 0, false), "Exited (137) 2 minutes ago", 0},
+		{exitedContainer("2", 137, false), "Exited (137) 2 minutes ago", NotRunning},
+		{exitedContainer("3", 137, true), oomStatus + " (137) 2 minutes ago", NotRunning | termui.AttrBold},
+	} {
+		row := NewContainerRow(tt.c, containerTableHeader(false))
+		if row.Status.Text != tt.wantStatus {
+			t.Errorf("Unexpected status of container %s: %q", tt.c.ID, row.Status.Text)
+		}
+		if row.statusFg != tt.wantFg {
+			t.Errorf("Unexpected status color of container %s: %v", tt.c.ID, row.statusFg)
+		}
+		row.Highlighted()
+		row.NotHighlighted()
+		if tt.wantFg != 0 && row.Status.TextFgColor != tt.wantFg {
+			t.Errorf("Status color of container %s lost after highlighting", tt.c.ID)
+		}
+	}
+}
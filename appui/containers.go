@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/moncho/dry/docker"
 	"github.com/moncho/dry/ui/termui"
@@ -17,7 +18,7 @@ import (
 // the default length of a widget header
 const widgetHeaderLength = 4
 
-var defaultContainerTableHeader = containerTableHeader()
+var defaultContainerTableHeader = containerTableHeader(false)
 
 var containerTableHeaders = []SortableColumnHeader{
 	{``, SortMode(docker.NoSort)},
@@ -31,16 +32,39 @@ var containerTableHeaders = []SortableColumnHeader{
 
 //ContainersWidget shows information containers
 type ContainersWidget struct {
-	dockerDaemon         docker.ContainerAPI
-	totalRows            []*ContainerRow
-	filteredRows         []*ContainerRow
-	header               *termui.TableHeader
+	dockerDaemon   docker.ContainerAPI
+	totalRows      []*ContainerRow
+	filteredRows   []*ContainerRow
+	containerCount int
+	header         *termui.TableHeader
+	//showHealth is true if the health of containers is shown, it is only
+	//shown if a container has a healthcheck
+	showHealth           bool
 	filterPattern        string
 	selectedIndex        int
 	startIndex, endIndex int
 	sortMode             docker.SortMode
 	screen               Screen
 	showAllContainers    bool
+	labelColumns         []string
+	//labelFilter, if set, keeps only the containers having a label
+	labelFilter *docker.LabelFilter
+	//groupByImage nests containers under the image they run
+	groupByImage bool
+	imageGroups  int
+	//groupByProject nests containers under the compose project, or stack,
+	//they belong to, collapsedGroups are the projects whose containers are hidden
+	groupByProject  bool
+	projectGroups   int
+	collapsedGroups map[string]bool
+	//changes tracks the rows that changed on each refresh, removed rows
+	//are kept as ghosts while highlighted
+	changes       rowChanges
+	ghosts        map[string]*ContainerRow
+	onChangesGone func()
+	//Outdated, if set, tells if the image with the given id has a newer
+	//version on the registry
+	Outdated func(imageID string) bool
 
 	sync.RWMutex
 	mounted bool
@@ -66,10 +90,19 @@ func (s *ContainersWidget) Buffer() gizaktermui.Buffer {
 		s.prepareForRendering()
 		y := s.screen.Bounds().Min.Y
 		widgetHeader := NewWidgetHeader()
-		widgetHeader.HeaderEntry("Containers", strconv.Itoa(s.RowCount()))
+		widgetHeader.HeaderEntry("Containers", strconv.Itoa(s.containerCount))
+		if s.groupByImage {
+			widgetHeader.HeaderEntry("Images", strconv.Itoa(s.imageGroups))
+		}
+		if s.groupByProject {
+			widgetHeader.HeaderEntry("Projects", strconv.Itoa(s.projectGroups))
+		}
 		if s.filterPattern != "" {
 			widgetHeader.HeaderEntry("Active filter", s.filterPattern)
 		}
+		if s.labelFilter != nil {
+			widgetHeader.HeaderEntry("Label filter", s.labelFilter.String())
+		}
 		widgetHeader.Buffer()
 		widgetHeader.Y = y
 		buf.Merge(widgetHeader.Buffer())
@@ -83,12 +116,15 @@ func (s *ContainersWidget) Buffer() gizaktermui.Buffer {
 		y += s.header.GetHeight()
 
 		selected := s.selectedIndex - s.startIndex
-
+		now := docker.Now()
 		for i, containerRow := range s.visibleRows() {
 			containerRow.SetY(y)
 			y += containerRow.GetHeight()
 			if i != selected {
 				containerRow.NotHighlighted()
+				if color, ok := s.changes.change(containerRow.container.ID, now).color(); ok {
+					containerRow.changeTextColor(color, gizaktermui.Attribute(DryTheme.Bg))
+				}
 			} else {
 				containerRow.Highlighted()
 			}
@@ -106,6 +142,13 @@ func (s *ContainersWidget) Filter(filter string) {
 
 }
 
+//FilterPattern returns the filter applied to this widget
+func (s *ContainersWidget) FilterPattern() string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.filterPattern
+}
+
 //Mount tells this widget to be ready for rendering
 func (s *ContainersWidget) Mount() error {
 	s.Lock()
@@ -120,19 +163,70 @@ func (s *ContainersWidget) Mount() error {
 	} else {
 		filters = append(filters, docker.ContainerFilters.Running())
 	}
+	if s.labelFilter != nil {
+		filters = append(filters, s.labelFilter.Filter())
+	}
 	dockerContainers := s.dockerDaemon.Containers(filters, s.sortMode)
+	if health := anyHealthcheck(dockerContainers); health != s.showHealth {
+		s.showHealth = health
+		s.header = containerTableHeader(health, s.labelColumns...)
+	}
 
 	rows := make([]*ContainerRow, len(dockerContainers))
+	states := make(map[string]rowState, len(dockerContainers))
 	for i, container := range dockerContainers {
-		rows[i] = NewContainerRow(container, s.header)
+		rows[i] = NewContainerRow(container, s.header, s.labelColumns...)
+		if s.showHealth {
+			rows[i].addHealthColumn()
+		}
+		if s.Outdated != nil && s.Outdated(container.ImageID) {
+			rows[i].image += NewerImageMark
+			rows[i].Image.Text = rows[i].image
+		}
+		states[container.ID] = rowState{state: container.State, running: docker.IsContainerRunning(container)}
 	}
-	s.totalRows = rows
+	s.totalRows = append(rows, s.trackChanges(states)...)
 	s.mounted = true
 	s.align()
 
 	return nil
 }
 
+//Shown returns the containers shown, filters applied, leaving out the
+//removed ones still highlighted
+func (s *ContainersWidget) Shown() []*docker.Container {
+	s.RLock()
+	defer s.RUnlock()
+	var containers []*docker.Container
+	for _, row := range s.filteredRows {
+		if row.groupHeader {
+			continue
+		}
+		if _, removed := s.ghosts[row.container.ID]; !removed {
+			containers = append(containers, row.container)
+		}
+	}
+	return containers
+}
+
+//Select selects the container with the given id, it returns false if the
+//container is not shown
+func (s *ContainersWidget) Select(id string) bool {
+	if err := s.Mount(); err != nil {
+		return false
+	}
+	s.Lock()
+	defer s.Unlock()
+	s.prepareForRendering()
+	for i, row := range s.filteredRows {
+		if !row.groupHeader && row.container.ID == id {
+			s.screen.Cursor().ScrollTo(i)
+			return true
+		}
+	}
+	return false
+}
+
 //Name returns this widget name
 func (s *ContainersWidget) Name() string {
 	return "ContainersWidget"
@@ -144,6 +238,8 @@ func (s *ContainersWidget) OnEvent(event EventCommand) error {
 		return errors.New("The container list is empty")
 	} else if s.filteredRows[s.selectedIndex] == nil {
 		return fmt.Errorf("The container list does not have an element on pos %d", s.selectedIndex)
+	} else if s.filteredRows[s.selectedIndex].groupHeader {
+		return errors.New("A group of containers is selected, not a container")
 	}
 	return event(s.filteredRows[s.selectedIndex].container.ID)
 }
@@ -171,6 +267,82 @@ func (s *ContainersWidget) Sort() {
 	}
 }
 
+//SetSortMode sets the sort mode of this widget
+func (s *ContainersWidget) SetSortMode(mode docker.SortMode) {
+	s.Lock()
+	defer s.Unlock()
+	s.sortMode = mode
+	s.mounted = false
+}
+
+//SortMode returns the sort mode of this widget
+func (s *ContainersWidget) SortMode() docker.SortMode {
+	s.RLock()
+	defer s.RUnlock()
+	return s.sortMode
+}
+
+//SetLabelColumns sets the labels whose values are shown as extra columns
+func (s *ContainersWidget) SetLabelColumns(labels []string) {
+	s.Lock()
+	defer s.Unlock()
+	s.labelColumns = labels
+	s.header = containerTableHeader(s.showHealth, labels...)
+	s.mounted = false
+	s.resetChanges()
+}
+
+//HighlightChanges sets the func called once the rows that changed on a
+//refresh are no longer highlighted, so the list can be rendered again
+func (s *ContainersWidget) HighlightChanges(onChangesGone func()) {
+	s.Lock()
+	defer s.Unlock()
+	s.onChangesGone = onChangesGone
+}
+
+//trackChanges tracks the changes since the previous mount, given the container
+//states, returns the rows of removed containers that are still highlighted.
+//Must be called before replacing the rows of the previous mount.
+func (s *ContainersWidget) trackChanges(states map[string]rowState) []*ContainerRow {
+	now := docker.Now()
+	changed, removed := s.changes.update(states, now)
+	if s.ghosts == nil {
+		s.ghosts = make(map[string]*ContainerRow)
+	}
+	for _, row := range s.totalRows {
+		for _, id := range removed {
+			if row.container.ID == id {
+				s.ghosts[id] = row
+			}
+		}
+	}
+	var ghosts []*ContainerRow
+	for id, row := range s.ghosts {
+		if s.changes.change(id, now) != rowRemoved {
+			delete(s.ghosts, id)
+			continue
+		}
+		ghosts = append(ghosts, row)
+	}
+	if changed && s.onChangesGone != nil {
+		time.AfterFunc(changeHighlightDuration, func() {
+			s.Unmount()
+			s.onChangesGone()
+		})
+	}
+	return ghosts
+}
+
+//ToggleGroupByImage toggles grouping containers by the image they run
+func (s *ContainersWidget) ToggleGroupByImage() {
+	s.Lock()
+	defer s.Unlock()
+	s.groupByImage = !s.groupByImage
+	if s.groupByImage {
+		s.groupByProject = false
+	}
+}
+
 //ToggleShowAllContainers toggles the show-all-containers state
 func (s *ContainersWidget) ToggleShowAllContainers() {
 	s.Lock()
@@ -178,6 +350,25 @@ func (s *ContainersWidget) ToggleShowAllContainers() {
 
 	s.showAllContainers = !s.showAllContainers
 	s.mounted = false
+	s.resetChanges()
+}
+
+//FilterByLabel shows only the containers matching the given label filter,
+//nil shows every container
+func (s *ContainersWidget) FilterByLabel(filter *docker.LabelFilter) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.labelFilter = filter
+	s.mounted = false
+	s.resetChanges()
+}
+
+//LabelFilter returns the label filter applied to this widget, if any
+func (s *ContainersWidget) LabelFilter() *docker.LabelFilter {
+	s.RLock()
+	defer s.RUnlock()
+	return s.labelFilter
 }
 
 //Unmount this widget
@@ -188,6 +379,13 @@ func (s *ContainersWidget) Unmount() error {
 	return nil
 }
 
+//resetChanges forgets the changes seen, so rows that show up because of
+//changes on what is listed are not taken as changes
+func (s *ContainersWidget) resetChanges() {
+	s.changes.reset()
+	s.ghosts = nil
+}
+
 //Align aligns rows
 func (s *ContainersWidget) align() {
 	x := s.screen.Bounds().Min.X
@@ -204,26 +402,35 @@ func (s *ContainersWidget) align() {
 }
 
 func (s *ContainersWidget) filterRows() {
-
-	if s.filterPattern != "" {
-		var rows []*ContainerRow
-
-		for _, row := range s.totalRows {
-			if RowFilters.ByPattern(s.filterPattern)(row) {
-				rows = append(rows, row)
-			}
+	filter := RowFilters.ByFuzzyPattern(s.filterPattern)
+	var rows []*ContainerRow
+	for _, row := range s.totalRows {
+		if filter(row) {
+			rows = append(rows, row)
 		}
-		s.filteredRows = rows
-	} else {
-		s.filteredRows = s.totalRows
 	}
+	s.filteredRows = rows
 }
 
 // prepareForRendering sets the internal state of this widget so it is ready for
 // rendering(i.e. Buffer()).
 func (s *ContainersWidget) prepareForRendering() {
+	for _, row := range s.totalRows {
+		row.Image.Text = row.image
+	}
 	s.sortRows()
+	if s.groupByImage {
+		s.groupRows()
+	} else if s.groupByProject {
+		s.groupRowsByProject()
+	}
 	s.filterRows()
+	s.containerCount = len(s.filteredRows)
+	if s.groupByImage {
+		s.labelImageGroups()
+	} else if s.groupByProject {
+		s.nestProjectGroups()
+	}
 	s.screen.Cursor().Max(s.RowCount() - 1)
 
 	index := s.screen.Cursor().Position()
@@ -291,6 +498,54 @@ func (s *ContainersWidget) sortRows() {
 	sort.SliceStable(rows, sortAlg)
 }
 
+//groupRows sorts the rows by image, keeping the current order on each image
+func (s *ContainersWidget) groupRows() {
+	rows := s.totalRows
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].container.Image < rows[j].container.Image
+	})
+}
+
+//labelImageGroups shows on the image column of the filtered rows, that must
+//be grouped by image, the image of each group and how many containers run it
+//on its first row, and the nesting of the rest of rows of the group
+func (s *ContainersWidget) labelImageGroups() {
+	var labels []string
+	labels, s.imageGroups = imageGroupLabels(s.filteredRows)
+	for i, row := range s.filteredRows {
+		if !strings.HasPrefix(labels[i], row.image) {
+			//matches of the filter are not shown on nested rows
+			row.Image.Highlight(nil, 0)
+		}
+		row.Image.Text = labels[i]
+	}
+}
+
+//imageGroupLabels returns the image column labels of the given rows, grouped
+//by image, and the number of groups found
+func imageGroupLabels(rows []*ContainerRow) ([]string, int) {
+	labels := make([]string, len(rows))
+	groups := 0
+	for start := 0; start < len(rows); {
+		image := rows[start].container.Image
+		end := start + 1
+		for end < len(rows) && rows[end].container.Image == image {
+			end++
+		}
+		labels[start] = fmt.Sprintf("%s (%d)", rows[start].image, end-start)
+		for i := start + 1; i < end; i++ {
+			if i == end-1 {
+				labels[i] = " \u2514\u2500"
+			} else {
+				labels[i] = " \u251C\u2500"
+			}
+		}
+		groups++
+		start = end
+	}
+	return labels, groups
+}
+
 func (s *ContainersWidget) visibleRows() []*ContainerRow {
 	return s.filteredRows[s.startIndex:s.endIndex]
 }
@@ -332,7 +587,7 @@ func (s *ContainersWidget) calculateVisibleRows() {
 	}
 }
 
-func containerTableHeader() *termui.TableHeader {
+func containerTableHeader(health bool, labels ...string) *termui.TableHeader {
 
 	header := termui.NewHeader(DryTheme)
 	header.ColumnSpacing = DefaultColumnSpacing
@@ -341,8 +596,12 @@ func containerTableHeader() *termui.TableHeader {
 	header.AddColumn(containerTableHeaders[2].Title)
 	header.AddColumn(containerTableHeaders[3].Title)
 	header.AddFixedWidthColumn(containerTableHeaders[4].Title, 18)
+	if health {
+		header.AddFixedWidthColumn(`HEALTH`, healthColumnWidth)
+	}
 	header.AddColumn(containerTableHeaders[5].Title)
 	header.AddColumn(containerTableHeaders[6].Title)
+	AddLabelColumns(header, labels)
 
 	return header
 }
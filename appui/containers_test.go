@@ -1,9 +1,12 @@
 package appui
 
 import (
+	"reflect"
 	"sort"
+	"strings"
 	"testing"
 
+	"github.com/docker/docker/api/types"
 	"github.com/moncho/dry/docker"
 	"github.com/moncho/dry/mocks"
 	"github.com/moncho/dry/ui"
@@ -132,6 +135,34 @@ func TestContainersWidget_ToggleShowAllContainers(t *testing.T) {
 	}
 }
 
+func TestContainersWidget_FilterByLabel(t *testing.T) {
+	daemon := &mocks.DockerDaemonMock{}
+	w := NewContainersWidget(daemon, &testScreen{cursor: &ui.Cursor{}, y1: 20, x1: 40})
+	w.Mount()
+	w.prepareForRendering()
+	if w.RowCount() != 10 {
+		t.Fatalf("Expected 10 running containers, got %d", w.RowCount())
+	}
+
+	//DockerDaemonMock containers have no labels
+	w.FilterByLabel(&docker.LabelFilter{Key: docker.ComposeProjectLabel, Value: "shop"})
+	if w.mounted {
+		t.Error("Widget is still mounted after changing its label filter")
+	}
+	w.Mount()
+	w.prepareForRendering()
+	if w.RowCount() != 0 {
+		t.Errorf("No container was expected to match the label filter, got %d", w.RowCount())
+	}
+
+	w.FilterByLabel(nil)
+	w.Mount()
+	w.prepareForRendering()
+	if w.RowCount() != 10 || w.LabelFilter() != nil {
+		t.Errorf("Label filter was not removed, got %d containers", w.RowCount())
+	}
+}
+
 func TestContainersWidget_sortRows(t *testing.T) {
 	type fields struct {
 		totalRows []*ContainerRow
@@ -245,3 +276,164 @@ func TestContainersWidget_filterRows(t *testing.T) {
 		})
 	}
 }
+
+func TestContainersWidget_groupByImage(t *testing.T) {
+	newRow := func(id, image string) *ContainerRow {
+		c := &docker.Container{
+			Container: types.Container{ID: id, Image: image, Names: []string{id}}}
+		return NewContainerRow(c, containerTableHeader(false))
+	}
+	s := &ContainersWidget{
+		totalRows: []*ContainerRow{
+			newRow("1", "redis"),
+			newRow("2", "nginx"),
+			newRow("3", "redis"),
+			newRow("4", "nginx"),
+			newRow("5", "nginx"),
+			newRow("6", "postgres"),
+		},
+	}
+	s.ToggleGroupByImage()
+	s.groupRows()
+	s.filterRows()
+	s.labelImageGroups()
+
+	var ids, labels []string
+	for _, row := range s.filteredRows {
+		ids = append(ids, row.container.ID)
+		labels = append(labels, row.Image.Text)
+	}
+	if want := []string{"2", "4", "5", "6", "1", "3"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("Unexpected grouped rows, got %v, want %v", ids, want)
+	}
+	wantLabels := []string{"nginx (3)", " \u251C\u2500", " \u2514\u2500", "postgres (1)", "redis (2)", " \u2514\u2500"}
+	if !reflect.DeepEqual(labels, wantLabels) {
+		t.Errorf("Unexpected image group labels, got %q, want %q", labels, wantLabels)
+	}
+	if s.imageGroups != 3 {
+		t.Errorf("Unexpected number of image groups, got %d, want 3", s.imageGroups)
+	}
+}
+
+func TestContainersWidget_groupByProject(t *testing.T) {
+	newRow := func(id, status string, labels map[string]string) *ContainerRow {
+		c := &docker.Container{
+			Container: types.Container{ID: id, Status: status, Names: []string{id}, Labels: labels}}
+		return NewContainerRow(c, containerTableHeader(false))
+	}
+	shop := map[string]string{docker.ComposeProjectLabel: "shop"}
+	billing := map[string]string{docker.LabelNamespace: "billing"}
+	s := &ContainersWidget{
+		header: containerTableHeader(false),
+		screen: &testScreen{cursor: &ui.Cursor{}, x1: 100, y1: 20},
+		totalRows: []*ContainerRow{
+			newRow("1", "Up 2 hours", nil),
+			newRow("2", "Up 2 hours", shop),
+			newRow("3", "Exited (0) 1 hour ago", billing),
+			newRow("4", "Exited (1) 1 hour ago", shop),
+		},
+	}
+	s.ToggleGroupByProject()
+	s.prepareForRendering()
+
+	var rows []string
+	for _, row := range s.filteredRows {
+		if row.groupHeader {
+			rows = append(rows, row.Image.Text+" "+row.Status.Text)
+		} else {
+			rows = append(rows, row.container.ID)
+		}
+	}
+	want := []string{"billing 0/1 running", "3", "shop 1/2 running", "2", "4", noProject + " 1/1 running", "1"}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("Unexpected grouped rows, got %q, want %q", rows, want)
+	}
+	if s.projectGroups != 3 || s.containerCount != 4 {
+		t.Errorf("Unexpected number of groups or containers, got %d and %d", s.projectGroups, s.containerCount)
+	}
+	if err := s.OnEvent(func(string) error { return nil }); err == nil {
+		t.Error("A group header was selected, no container was expected")
+	}
+
+	//collapses the shop group from one of its containers
+	s.screen.Cursor().ScrollTo(4)
+	s.prepareForRendering()
+	if !s.ToggleGroupCollapsed() {
+		t.Fatal("Containers are grouped by project, groups can be collapsed")
+	}
+	s.prepareForRendering()
+	if s.RowCount() != 5 || s.selectedIndex != 2 || !s.filteredRows[2].groupHeader {
+		t.Errorf("Shop group was not collapsed, %d rows, selected row: %d", s.RowCount(), s.selectedIndex)
+	}
+	if s.filteredRows[2].Indicator.Text != collapsedGroupSymbol {
+		t.Errorf("Unexpected collapsed group symbol: %s", s.filteredRows[2].Indicator.Text)
+	}
+
+	s.ToggleGroupByImage()
+	if s.groupByProject {
+		t.Error("Grouping by image and by project are exclusive")
+	}
+}
+
+func TestContainersWidget_LabelColumns(t *testing.T) {
+	daemon := &mocks.DockerDaemonMock{}
+	screen := &testScreen{
+		cursor: &ui.Cursor{},
+		y1:     9, x1: 40,
+	}
+	w := NewContainersWidget(daemon, screen)
+	w.SetLabelColumns([]string{"com.example.team"})
+
+	if err := w.Mount(); err != nil {
+		t.Errorf("There was an error mounting the widget %v", err)
+	}
+	header := w.header.Columns[len(w.header.Columns)-1]
+	if header.Text != "COM.EXAMPLE.TEAM" {
+		t.Errorf("Unexpected label column header: %s", header.Text)
+	}
+	for _, row := range w.totalRows {
+		if len(row.Labels) != 1 {
+			t.Fatalf("Expected one label column, got %d", len(row.Labels))
+		}
+		if row.Labels[0].Text != "-" {
+			t.Errorf("Unexpected label column value for a container without the label: %s", row.Labels[0].Text)
+		}
+	}
+}
+
+func TestContainersWidget_Outdated(t *testing.T) {
+	daemon := &mocks.DockerDaemonMock{}
+	w := NewContainersWidget(daemon, &testScreen{cursor: &ui.Cursor{}, y1: 20, x1: 40})
+	w.Outdated = func(string) bool { return true }
+	w.Mount()
+	w.prepareForRendering()
+	for _, row := range w.filteredRows {
+		if !strings.HasSuffix(row.Image.Text, NewerImageMark) {
+			t.Errorf("Container running an outdated image not marked: %q", row.Image.Text)
+		}
+	}
+}
+
+func TestContainersWidget_Select(t *testing.T) {
+	daemon := &mocks.DockerDaemonMock{}
+	screen := &testScreen{
+		cursor: &ui.Cursor{},
+		y1:     20, x1: 40,
+	}
+	w := NewContainersWidget(daemon, screen)
+	if !w.Select("7") {
+		t.Fatal("A container on the list was not selected")
+	}
+	w.prepareForRendering()
+	var selected string
+	w.OnEvent(func(id string) error {
+		selected = id
+		return nil
+	})
+	if selected != "7" {
+		t.Errorf("Unexpected container selected: %s", selected)
+	}
+	if w.Select("not-listed") {
+		t.Error("A container not on the list was selected")
+	}
+}
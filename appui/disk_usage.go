@@ -17,8 +17,15 @@ import (
 
 const (
 	defaultDiskUsageTableFormat = "{{.Type}}\t{{.TotalCount}}\t{{.Active}}\t{{.Size}}\t{{.Reclaimable}}"
+	//spinnerInterval is the time each frame of the spinner is shown
+	spinnerInterval = 100 * time.Millisecond
+	//diskUsageTemplateLines is the number of lines the disk usage template
+	//adds to what it renders, plus those of the header and footer of the screen
+	diskUsageTemplateLines = 8
 )
 
+var spinnerFrames = []string{"|", "/", "-", "\\"}
+
 //DockerDiskUsageRenderer renderer for Docker disk usage
 type DockerDiskUsageRenderer struct {
 	columns                []string
@@ -27,6 +34,17 @@ type DockerDiskUsageRenderer struct {
 	pruneReport            *docker.PruneReport
 	lastPrune              time.Time
 	height                 int
+	//computing is true while the disk usage is being computed, pending is
+	//true if another computation was requested meanwhile
+	computing    bool
+	pending      bool
+	computeStart time.Time
+	computedAt   time.Time
+	err          error
+	//category is the category of objects listed below the totals, one of
+	//them is selected
+	category DiskUsageCategory
+	selected int
 	sync.RWMutex
 }
 
@@ -53,8 +71,58 @@ func (r *DockerDiskUsageRenderer) PrepareToRender(diskUsage *types.DiskUsage, re
 	r.diskUsage = diskUsage
 	if report != nil {
 		r.pruneReport = report
-		r.lastPrune = time.Now()
+		r.lastPrune = docker.Now()
+	}
+	r.Unlock()
+}
+
+//StartComputing marks the disk usage as being computed. It returns false if
+//a computation is already running, in which case another one is done once
+//the running one finishes.
+func (r *DockerDiskUsageRenderer) StartComputing() bool {
+	r.Lock()
+	defer r.Unlock()
+	if r.computing {
+		r.pending = true
+		return false
+	}
+	r.computing = true
+	r.computeStart = docker.Now()
+	return true
+}
+
+//DoneComputing sets the result of the running computation. It returns true
+//if another computation was requested meanwhile, in which case the renderer
+//is still computing.
+func (r *DockerDiskUsageRenderer) DoneComputing(diskUsage *types.DiskUsage, err error) bool {
+	r.Lock()
+	defer r.Unlock()
+	r.err = err
+	if err == nil {
+		r.diskUsage = diskUsage
+		r.computedAt = docker.Now()
+	}
+	if r.pending {
+		r.pending = false
+		r.computeStart = docker.Now()
+		return true
 	}
+	r.computing = false
+	return false
+}
+
+//Computed returns true if the disk usage was already computed
+func (r *DockerDiskUsageRenderer) Computed() bool {
+	r.RLock()
+	defer r.RUnlock()
+	return r.diskUsage != nil
+}
+
+//SetPruneReport sets the report of the last prune
+func (r *DockerDiskUsageRenderer) SetPruneReport(report *docker.PruneReport) {
+	r.Lock()
+	r.pruneReport = report
+	r.lastPrune = docker.Now()
 	r.Unlock()
 }
 
@@ -66,14 +134,25 @@ func (r *DockerDiskUsageRenderer) String() string {
 	if !r.lastPrune.IsZero() {
 		timeStamp = r.lastPrune.Format("2006-01-02 15:04:05")
 	}
+	diskUsageTable := ""
+	if r.diskUsage != nil || !r.computing {
+		diskUsageTable = r.diskUsageTable()
+	}
+	status := r.status()
+	pruneTable := r.pruneTable()
+	used := strings.Count(status+diskUsageTable+pruneTable, "\n") + diskUsageTemplateLines
 	vars := struct {
+		Status         string
 		DiskUsageTable string
+		Items          string
 		Timestamp      string
 		PruneTable     string
 	}{
-		r.diskUsageTable(),
+		status,
+		diskUsageTable,
+		r.itemsTable(r.height - used),
 		timeStamp,
-		r.pruneTable(),
+		pruneTable,
 	}
 
 	var buffer bytes.Buffer
@@ -82,6 +161,21 @@ func (r *DockerDiskUsageRenderer) String() string {
 	return buffer.String()
 }
 
+//status describes the state of the computation of the disk usage, it is
+//empty if the disk usage was never computed
+func (r *DockerDiskUsageRenderer) status() string {
+	switch {
+	case r.computing:
+		frame := int(docker.Since(r.computeStart)/spinnerInterval) % len(spinnerFrames)
+		return fmt.Sprintf("<green>%s</> Computing disk usage", spinnerFrames[frame])
+	case r.err != nil:
+		return fmt.Sprintf("<red>Error computing disk usage: %s</>", r.err)
+	case !r.computedAt.IsZero():
+		return fmt.Sprintf("Computed at %s", r.computedAt.Format("15:04:05"))
+	}
+	return ""
+}
+
 func (r *DockerDiskUsageRenderer) diskUsageTable() string {
 	var buffer bytes.Buffer
 	t := tabwriter.NewWriter(&buffer, 22, 0, 1, ' ', 0)
@@ -123,8 +217,10 @@ func (r *DockerDiskUsageRenderer) formattedDiskUsage() string {
 
 func buildDiskUsageTableTemplate() *template.Template {
 	markup :=
-		`{{.DiskUsageTable}}
-{{if .Timestamp}}Docker system prune executed on {{.Timestamp}}, results:{{end}}
+		`{{if .Status}}{{.Status}}
+{{end}}{{.DiskUsageTable}}
+{{if .Items}}{{.Items}}
+{{end}}{{if .Timestamp}}Docker system prune executed on {{.Timestamp}}, results:{{end}}
 
 {{.PruneTable}}
 `
@@ -166,6 +262,7 @@ func diskUsage(diskUsage *types.DiskUsage) ([]byte, error) {
 		},
 		&diskUsageBuilderContext{
 			builderSize: diskUsage.BuilderSize,
+			records:     diskUsage.BuildCache,
 		},
 	}
 	for _, d := range data {
@@ -340,8 +437,11 @@ func (c *diskUsageVolumesContext) Reclaimable() string {
 	return units.HumanSize(float64(reclaimable))
 }
 
+//diskUsageBuilderContext is the usage of the build cache, from its records
+//if the daemon reports them or else from the total size of the builder
 type diskUsageBuilderContext struct {
 	builderSize int64
+	records     []*types.BuildCache
 }
 
 func (c *diskUsageBuilderContext) Type() string {
@@ -349,17 +449,51 @@ func (c *diskUsageBuilderContext) Type() string {
 }
 
 func (c *diskUsageBuilderContext) TotalCount() string {
-	return ""
+	if len(c.records) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d", len(c.records))
 }
 
 func (c *diskUsageBuilderContext) Active() string {
-	return ""
+	if len(c.records) == 0 {
+		return ""
+	}
+	used := 0
+	for _, r := range c.records {
+		if r.InUse {
+			used++
+		}
+	}
+	return fmt.Sprintf("%d", used)
 }
 
+//Size returns the size of the build cache, shared records are counted once
+//as part of the images using them
 func (c *diskUsageBuilderContext) Size() string {
-	return units.HumanSize(float64(c.builderSize))
+	if len(c.records) == 0 {
+		return units.HumanSize(float64(c.builderSize))
+	}
+	var size int64
+	for _, r := range c.records {
+		if !r.Shared {
+			size += r.Size
+		}
+	}
+	return units.HumanSize(float64(size))
 }
 
+//Reclaimable returns the size of the records a build cache prune removes,
+//those neither in use nor shared
 func (c *diskUsageBuilderContext) Reclaimable() string {
-	return c.Size()
+	if len(c.records) == 0 {
+		return c.Size()
+	}
+	var reclaimable int64
+	for _, r := range c.records {
+		if !r.InUse && !r.Shared {
+			reclaimable += r.Size
+		}
+	}
+	return units.HumanSize(float64(reclaimable))
 }
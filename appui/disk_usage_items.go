@@ -0,0 +1,255 @@
+package appui
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/docker/docker/api/types"
+	units "github.com/docker/go-units"
+	"github.com/moncho/dry/docker"
+)
+
+//diskUsageItemsMinHeight is the least number of items listed on a drill-down
+const diskUsageItemsMinHeight = 3
+
+//DiskUsageCategory is the category of objects listed below the disk usage totals
+type DiskUsageCategory int
+
+const (
+	//DiskUsageTotals shows just the totals
+	DiskUsageTotals DiskUsageCategory = iota
+	//DiskUsageImages lists images
+	DiskUsageImages
+	//DiskUsageContainers lists containers
+	DiskUsageContainers
+	//DiskUsageVolumes lists local volumes
+	DiskUsageVolumes
+	//DiskUsageBuildCache lists build cache records
+	DiskUsageBuildCache
+)
+
+func (c DiskUsageCategory) String() string {
+	switch c {
+	case DiskUsageImages:
+		return "images"
+	case DiskUsageContainers:
+		return "containers"
+	case DiskUsageVolumes:
+		return "volumes"
+	case DiskUsageBuildCache:
+		return "build cache"
+	}
+	return "totals"
+}
+
+//DiskUsageItem is an object using disk, listed on a disk usage drill-down
+type DiskUsageItem struct {
+	Category DiskUsageCategory
+	ID       string
+	Name     string
+	Size     int64
+	//Active is true if the object is in use, i.e. a running container
+	Active bool
+	Detail string
+	Labels map[string]string
+}
+
+//CycleCategory rotates to the next category of objects listed and returns it.
+//Totals -> Images -> Containers -> Volumes -> Build cache -> Totals
+func (r *DockerDiskUsageRenderer) CycleCategory() DiskUsageCategory {
+	r.Lock()
+	defer r.Unlock()
+	r.category = (r.category + 1) % (DiskUsageBuildCache + 1)
+	r.selected = 0
+	return r.category
+}
+
+//Category returns the category of objects listed
+func (r *DockerDiskUsageRenderer) Category() DiskUsageCategory {
+	r.RLock()
+	defer r.RUnlock()
+	return r.category
+}
+
+//MoveSelection moves the selected object by the given number of positions
+func (r *DockerDiskUsageRenderer) MoveSelection(n int) {
+	r.Lock()
+	defer r.Unlock()
+	r.selected = clampSelection(r.selected+n, len(diskUsageItems(r.diskUsage, r.category)))
+}
+
+//Selected returns the selected object, false if no object is listed
+func (r *DockerDiskUsageRenderer) Selected() (DiskUsageItem, bool) {
+	r.RLock()
+	defer r.RUnlock()
+	items := diskUsageItems(r.diskUsage, r.category)
+	if len(items) == 0 {
+		return DiskUsageItem{}, false
+	}
+	return items[clampSelection(r.selected, len(items))], true
+}
+
+//itemsTable renders the objects of the current category, scrolled so the
+//selected one is shown on the given number of lines
+func (r *DockerDiskUsageRenderer) itemsTable(lines int) string {
+	if r.category == DiskUsageTotals || r.diskUsage == nil {
+		return ""
+	}
+	items := diskUsageItems(r.diskUsage, r.category)
+	var buffer bytes.Buffer
+	if len(items) == 0 {
+		buffer.WriteString(fmt.Sprintf("<white>No %s</>, Tab shows the next category\n", r.category))
+		return buffer.String()
+	}
+	buffer.WriteString(fmt.Sprintf("<white>%s by size</>, %d listed, Tab shows the next category\n\n",
+		strings.Title(r.category.String()), len(items)))
+	visible := lines - 3
+	if visible < diskUsageItemsMinHeight {
+		visible = diskUsageItemsMinHeight
+	}
+	selected := clampSelection(r.selected, len(items))
+	start := 0
+	if selected >= visible {
+		start = selected - visible + 1
+	}
+	end := start + visible
+	if end > len(items) {
+		end = len(items)
+	}
+
+	var table bytes.Buffer
+	t := tabwriter.NewWriter(&table, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(t, "  NAME\tID\tSIZE\tIN USE\tDETAIL")
+	for i := start; i < end; i++ {
+		item := items[i]
+		cursor := " "
+		if i == selected {
+			cursor = ">"
+		}
+		inUse := "no"
+		if item.Active {
+			inUse = "yes"
+		}
+		id := docker.ShortImageID(item.ID)
+		if item.ID == item.Name {
+			id = "-"
+		}
+		fmt.Fprintf(t, "%s %s\t%s\t%s\t%s\t%s\n",
+			cursor, cut(item.Name, createdByWidth/2), id,
+			units.HumanSize(float64(item.Size)), inUse, item.Detail)
+	}
+	t.Flush()
+	rows := strings.SplitN(table.String(), "\n", 2)
+	buffer.WriteString("<green>" + rows[0] + "</>\n")
+	if len(rows) > 1 {
+		buffer.WriteString(rows[1])
+	}
+	return buffer.String()
+}
+
+//diskUsageItems returns the objects of the given category on the given
+//disk usage, the biggest first
+func diskUsageItems(du *types.DiskUsage, category DiskUsageCategory) []DiskUsageItem {
+	if du == nil {
+		return nil
+	}
+	var items []DiskUsageItem
+	switch category {
+	case DiskUsageImages:
+		for _, i := range du.Images {
+			size := i.Size
+			detail := fmt.Sprintf("%d containers", i.Containers)
+			if i.SharedSize > 0 {
+				size -= i.SharedSize
+				detail += fmt.Sprintf(", %s shared", units.HumanSize(float64(i.SharedSize)))
+			}
+			items = append(items, DiskUsageItem{
+				Category: category,
+				ID:       i.ID,
+				Name:     diskUsageImageName(i),
+				Size:     size,
+				Active:   i.Containers > 0,
+				Detail:   detail,
+				Labels:   i.Labels,
+			})
+		}
+	case DiskUsageContainers:
+		containers := &diskUsageContainersContext{}
+		for _, c := range du.Containers {
+			name := docker.TruncateID(c.ID)
+			if len(c.Names) > 0 {
+				name = strings.TrimPrefix(c.Names[0], "/")
+			}
+			items = append(items, DiskUsageItem{
+				Category: category,
+				ID:       c.ID,
+				Name:     name,
+				Size:     c.SizeRw,
+				Active:   containers.isActive(*c),
+				Detail:   fmt.Sprintf("%s, %s", c.Image, c.State),
+				Labels:   c.Labels,
+			})
+		}
+	case DiskUsageVolumes:
+		for _, v := range du.Volumes {
+			item := DiskUsageItem{
+				Category: category,
+				ID:       v.Name,
+				Name:     v.Name,
+				Detail:   v.Driver,
+				Labels:   v.Labels,
+			}
+			if v.UsageData != nil {
+				item.Size = v.UsageData.Size
+				item.Active = v.UsageData.RefCount > 0
+				item.Detail = fmt.Sprintf("%d containers, %s", v.UsageData.RefCount, v.Driver)
+			}
+			items = append(items, item)
+		}
+	case DiskUsageBuildCache:
+		for _, b := range du.BuildCache {
+			detail := b.Type
+			if b.Shared {
+				detail += ", shared"
+			}
+			items = append(items, DiskUsageItem{
+				Category: category,
+				ID:       b.ID,
+				Name:     b.Description,
+				Size:     b.Size,
+				Active:   b.InUse,
+				Detail:   detail,
+			})
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Size != items[j].Size {
+			return items[i].Size > items[j].Size
+		}
+		return items[i].Name < items[j].Name
+	})
+	return items
+}
+
+//diskUsageImageName returns the first tag of the given image, <none> if untagged
+func diskUsageImageName(i *types.ImageSummary) string {
+	for _, tag := range i.RepoTags {
+		if tag != "<none>:<none>" {
+			return tag
+		}
+	}
+	return "<none>"
+}
+
+func clampSelection(selected, count int) int {
+	if selected >= count {
+		selected = count - 1
+	}
+	if selected < 0 {
+		selected = 0
+	}
+	return selected
+}
@@ -1,8 +1,10 @@
 package appui
 
 import (
+	"errors"
 	"io/ioutil"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -74,3 +76,105 @@ func TestDockerDiskUsageRenderer_Render(t *testing.T) {
 		})
 	}
 }
+
+func TestDockerDiskUsageRenderer_Computing(t *testing.T) {
+	r := NewDockerDiskUsageRenderer(screenHeight)
+	if r.Computed() {
+		t.Error("Disk usage is computed before computing it")
+	}
+	if !r.StartComputing() {
+		t.Fatal("Could not start computing the disk usage")
+	}
+	if !strings.Contains(r.String(), "Computing disk usage") {
+		t.Errorf("Renderer does not show that disk usage is being computed: %s", r.String())
+	}
+	if r.StartComputing() {
+		t.Error("A second computation was started while another was running")
+	}
+	//the computation requested meanwhile is still pending
+	if !r.DoneComputing(&types.DiskUsage{}, nil) {
+		t.Error("A computation requested while computing was lost")
+	}
+	if !r.Computed() {
+		t.Error("Disk usage is not computed after computing it")
+	}
+	if r.DoneComputing(nil, errors.New("timeout")) {
+		t.Error("A computation was pending after all were done")
+	}
+	rendered := r.String()
+	if !strings.Contains(rendered, "Error computing disk usage: timeout") || !strings.Contains(rendered, "Images") {
+		t.Errorf("Renderer does not keep the last disk usage on errors: %s", rendered)
+	}
+	if !r.StartComputing() {
+		t.Error("Could not start computing the disk usage once done")
+	}
+}
+
+func TestDiskUsageDrillDown(t *testing.T) {
+	r := NewDockerDiskUsageRenderer(40)
+	r.PrepareToRender(&types.DiskUsage{
+		Images: []*types.ImageSummary{
+			{ID: "sha256:0123456789abcdef", RepoTags: []string{"nginx:latest"}, Size: 300, SharedSize: 100, Containers: 1},
+			{ID: "sha256:fedcba9876543210", RepoTags: []string{"<none>:<none>"}, Size: 900, SharedSize: -1},
+		},
+		Containers: []*types.Container{
+			{ID: "c1", Names: []string{"/web"}, Image: "nginx", State: "running", SizeRw: 10},
+		},
+		BuildCache: []*types.BuildCache{
+			{ID: "b1", Description: "RUN make", Type: "regular", Size: 50},
+		},
+	}, nil)
+	if _, ok := r.Selected(); ok {
+		t.Error("An object is selected showing the totals")
+	}
+	if category := r.CycleCategory(); category != DiskUsageImages {
+		t.Fatalf("Unexpected category: %s", category)
+	}
+	rendered := r.String()
+	for _, expected := range []string{"Images by size", "<none>", "nginx:latest", "1 containers, 100B shared"} {
+		if !strings.Contains(rendered, expected) {
+			t.Errorf("%q not rendered: %s", expected, rendered)
+		}
+	}
+	if item, _ := r.Selected(); item.Name != "<none>" || item.Size != 900 {
+		t.Errorf("The biggest image is not selected first: %+v", item)
+	}
+	r.MoveSelection(5)
+	if item, _ := r.Selected(); item.Name != "nginx:latest" || item.Size != 200 || !item.Active {
+		t.Errorf("Unexpected selected image: %+v", item)
+	}
+
+	r.CycleCategory()
+	if item, _ := r.Selected(); item.Category != DiskUsageContainers || item.Name != "web" || !item.Active {
+		t.Errorf("Unexpected selected container: %+v", item)
+	}
+	r.CycleCategory()
+	if rendered := r.String(); !strings.Contains(rendered, "No volumes") {
+		t.Errorf("Missing volumes not rendered: %s", rendered)
+	}
+	r.CycleCategory()
+	if item, _ := r.Selected(); item.ID != "b1" || item.Detail != "regular" {
+		t.Errorf("Unexpected selected build cache record: %+v", item)
+	}
+	if category := r.CycleCategory(); category != DiskUsageTotals {
+		t.Errorf("Categories do not cycle back to totals: %s", category)
+	}
+}
+
+func TestDiskUsageBuildCache(t *testing.T) {
+	c := &diskUsageBuilderContext{builderSize: 42}
+	if c.TotalCount() != "" || c.Size() != "42B" || c.Reclaimable() != "42B" {
+		t.Errorf("Unexpected build cache usage without records: %s %s %s", c.TotalCount(), c.Size(), c.Reclaimable())
+	}
+	c.records = []*types.BuildCache{
+		{ID: "a", Size: 100, InUse: true},
+		{ID: "b", Size: 200},
+		{ID: "c", Size: 400, Shared: true},
+	}
+	if c.TotalCount() != "3" || c.Active() != "1" {
+		t.Errorf("Unexpected build cache records: %s total, %s active", c.TotalCount(), c.Active())
+	}
+	if c.Size() != "300B" || c.Reclaimable() != "200B" {
+		t.Errorf("Unexpected build cache size: %s, reclaimable: %s", c.Size(), c.Reclaimable())
+	}
+}
@@ -46,9 +46,16 @@ func dockerInfo(daemon docker.ContainerDaemon) string {
 
 	buffer := new(bytes.Buffer)
 
+	host := ui.Yellow(daemon.DockerEnv().DockerHost)
+	if podman, ok := docker.PodmanVersion(version); ok {
+		host += " " + ui.White("(Podman "+podman+")")
+	}
+	if docker.IsReadOnly(daemon) {
+		host += " " + ui.Red("(read-only)")
+	}
 	rows := [][]string{
 		{
-			ui.Blue("Docker Host:"), ui.Yellow(daemon.DockerEnv().DockerHost), "",
+			ui.Blue("Docker Host:"), host, "",
 			ui.Blue("Docker Version:"), ui.Yellow(version.Version)},
 		{
 			ui.Blue("Cert Path:"), ui.Yellow(daemon.DockerEnv().DockerCertPath), "",
@@ -60,6 +67,9 @@ func dockerInfo(daemon docker.ContainerDaemon) string {
 
 	rows = addHostInfo(rows, info)
 	rows = addSwarmInfo(rows, swarmInfo)
+	if warnings, err := daemon.DaemonWarnings(); err == nil {
+		rows = addWarnings(rows, warnings)
+	}
 	table := tablewriter.NewWriter(buffer)
 	table.SetBorder(false)
 	table.SetColumnSeparator("")
@@ -113,3 +123,15 @@ func addHostInfo(rows [][]string, info types.Info) [][]string {
 	return [][]string{firstRow, secondRow, thirdRow}
 
 }
+
+//addWarnings adds the number of daemon warnings, if any, to the first row,
+//warnings are shown on the info screen
+func addWarnings(rows [][]string, warnings []string) [][]string {
+	if len(warnings) == 0 {
+		return rows
+	}
+	rows[0] = append(rows[0],
+		ui.Red("Warnings:"),
+		ui.Red(strconv.Itoa(len(warnings))+" (F10)"))
+	return rows
+}
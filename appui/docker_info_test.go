@@ -1,8 +1,10 @@
 package appui
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/moncho/dry/docker"
 	"github.com/moncho/dry/mocks"
 )
 
@@ -58,3 +60,11 @@ func TestSwarmDockerInfoContent(t *testing.T) {
 		t.Errorf("Docker info output does not match. Expected: \n'%q'\n, got: \n'%q'", expectedDockerInfoWithSwarm, di)
 	}
 }
+
+func TestReadOnlyDockerInfoContent(t *testing.T) {
+	di := dockerInfo(docker.ReadOnly(&mocks.DockerDaemonMock{}))
+
+	if !strings.Contains(di, "<red>(read-only)</>") {
+		t.Errorf("Docker info does not show the read-only mode: %q", di)
+	}
+}
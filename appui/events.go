@@ -1,12 +1,10 @@
 package appui
 
 import (
-	"bytes"
 	"fmt"
 	"io"
 	"sort"
 	"strings"
-	"text/tabwriter"
 	"time"
 
 	"github.com/docker/docker/api/types/events"
@@ -19,35 +17,6 @@ const (
 	RFC3339NanoFixed = "2006-01-02T15:04:05.000000000Z07:00"
 )
 
-type eventsRenderer struct {
-	events []events.Message
-}
-
-//NewDockerEventsRenderer creates a renderer for docker events
-func NewDockerEventsRenderer(events []events.Message) fmt.Stringer {
-	return &eventsRenderer{
-		events: events,
-	}
-}
-
-func (r *eventsRenderer) String() string {
-	buf := bytes.NewBufferString("")
-
-	w := tabwriter.NewWriter(buf, 20, 1, 3, ' ', 0)
-	io.WriteString(w, "\n")
-
-	io.WriteString(w, "<blue><b>EVENTS - showing the last 10 events</></>\n\n")
-
-	if len(r.events) == 0 {
-		io.WriteString(w, "<red>Docker daemon has not reported events.</>\n\n")
-	}
-	for _, event := range r.events {
-		printEvent(w, event)
-	}
-	w.Flush()
-	return buf.String()
-}
-
 func printEvent(w io.Writer, event events.Message) {
 	io.WriteString(w, "<white>")
 
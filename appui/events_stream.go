@@ -0,0 +1,145 @@
+package appui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types/events"
+	"github.com/gdamore/tcell"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+)
+
+const (
+	eventsFilterPrompt = "Show events with type=, action=, name=, image=, label= (empty to show all): "
+	//maxPausedEvents is the number of events kept while the stream is paused
+	maxPausedEvents = 1000
+)
+
+//EventsSource gives the docker events seen so far and notifies new ones
+type EventsSource interface {
+	Events() []events.Message
+	Listen(func(events.Message)) func()
+}
+
+//eventsStream writes the events that match a filter to a Less view,
+//new events are held while the stream is paused
+type eventsStream struct {
+	v       *ui.Less
+	filter  docker.EventFilter
+	expr    string
+	paused  bool
+	pending []events.Message
+	dropped int
+	sync.Mutex
+}
+
+//StreamEvents shows on screen the events given by the source, as new events
+//arrive they are added at the bottom. Events can be filtered by type, action,
+//name, image and labels with 'e', the stream can be paused and resumed with 'p'.
+func StreamEvents(source EventsSource, keyboardQueue <-chan *tcell.EventKey, done func()) {
+	defer done()
+	ui.ActiveScreen.ClearAndFlush()
+	v := ui.NewLess(DryTheme)
+	v.MarkupSupport()
+	v.Follow(true)
+
+	s := &eventsStream{v: v}
+	//Events are taken from the source before locking the stream, since the
+	//source keeps itself locked while notifying events to the stream
+	stop := source.Listen(s.add)
+	s.reset(source.Events())
+
+	v.BindInput('e', eventsFilterPrompt, func(expr string) {
+		filter, err := docker.ParseEventFilterExpression(expr)
+		if err != nil {
+			v.Message(err.Error())
+			return
+		}
+		s.Lock()
+		s.filter, s.expr = filter, strings.TrimSpace(expr)
+		s.Unlock()
+		s.reset(source.Events())
+		v.SetStatus(s.status())
+	})
+	v.Bind('p', func() {
+		s.Lock()
+		defer s.Unlock()
+		s.paused = !s.paused
+		if !s.paused {
+			for _, event := range s.pending {
+				s.write(event)
+			}
+			if s.dropped > 0 {
+				fmt.Fprintf(v, "<red>%d events were dropped while paused</>\n\n", s.dropped)
+			}
+			s.pending, s.dropped = nil, 0
+		}
+		v.SetStatus(s.status())
+	})
+	v.SetStatus(s.status())
+	v.Focus(keyboardQueue)
+
+	stop()
+	ui.ActiveScreen.HideCursor()
+	ui.ActiveScreen.ClearAndFlush()
+	ui.ActiveScreen.Sync()
+}
+
+//add adds the given event to the stream, if paused the event is held
+func (s *eventsStream) add(event events.Message) {
+	s.Lock()
+	defer s.Unlock()
+	if !s.paused {
+		s.write(event)
+		return
+	}
+	if s.filter != nil && !s.filter(event) {
+		return
+	}
+	if len(s.pending) == maxPausedEvents {
+		s.pending = s.pending[1:]
+		s.dropped++
+	}
+	s.pending = append(s.pending, event)
+}
+
+//reset clears the view and writes the given events, events held
+//while paused are discarded, since the given events include them
+func (s *eventsStream) reset(events []events.Message) {
+	s.Lock()
+	defer s.Unlock()
+	s.v.Clear()
+	s.pending, s.dropped = nil, 0
+	io.WriteString(s.v, "\n<blue><b>EVENTS</></>")
+	if s.expr != "" {
+		fmt.Fprintf(s.v, " <blue>- showing events matching</> <white>%s</>", s.expr)
+	}
+	io.WriteString(s.v, "\n\n")
+	if len(events) == 0 {
+		io.WriteString(s.v, "<red>Docker daemon has not reported events.</>\n\n")
+	}
+	for _, event := range events {
+		s.write(event)
+	}
+}
+
+//write writes the given event to the view, if it matches the filter
+func (s *eventsStream) write(event events.Message) {
+	if s.filter == nil || s.filter(event) {
+		printEvent(s.v, event)
+	}
+}
+
+func (s *eventsStream) status() string {
+	status := "Events: Live"
+	if s.paused {
+		status = "Events: Paused"
+	}
+	if s.expr != "" {
+		status += " Filter: " + s.expr
+	}
+	return status
+}
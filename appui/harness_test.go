@@ -0,0 +1,152 @@
+package appui
+
+import (
+	"image"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gizak/termui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/mocks"
+	"github.com/moncho/dry/ui"
+	drytermui "github.com/moncho/dry/ui/termui"
+)
+
+//HarnessTime is the time the clock of a Harness is stopped at
+var HarnessTime = time.Date(2020, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+//Harness renders widgets deterministically so their output can be
+//compared against golden files: the screen has a fixed size, the clock
+//is stopped at HarnessTime and the Docker daemon is a mock.
+type Harness struct {
+	Daemon *mocks.DockerDaemonMock
+	screen *harnessScreen
+	//restoreClock restores the clock found when the harness was created
+	restoreClock func()
+}
+
+//NewHarness creates a Harness with a screen of the given size. Close
+//must be called once the harness is no longer needed.
+func NewHarness(width, height int) *Harness {
+	return &Harness{
+		Daemon: &mocks.DockerDaemonMock{},
+		screen: &harnessScreen{
+			cursor: ui.NewCursor(),
+			bounds: image.Rect(0, 0, width, height),
+		},
+		restoreClock: docker.SetClock(func() time.Time {
+			return HarnessTime
+		}),
+	}
+}
+
+//Close restores the clock
+func (h *Harness) Close() {
+	h.restoreClock()
+}
+
+//Screen returns the screen widgets are rendered on
+func (h *Harness) Screen() ScreenBuffererRender {
+	return h.screen
+}
+
+//Rendered returns what has been rendered on the harness screen, as text
+func (h *Harness) Rendered() (string, error) {
+	return Render(h.screen)
+}
+
+//Render returns the content of the given bufferer, as text
+func Render(b termui.Bufferer) (string, error) {
+	return drytermui.String(b)
+}
+
+//AssertGolden checks that the given bufferer renders the content of the
+//golden file testdata/<name>.golden, the file is updated instead if the
+//test runs with the -update flag.
+func AssertGolden(t testing.TB, name string, b termui.Bufferer) {
+	t.Helper()
+	got, err := Render(b)
+	if err != nil {
+		t.Fatalf("Error rendering %s: %s", name, err.Error())
+	}
+	golden := filepath.Join("testdata", strings.ReplaceAll(name, " ", "_")+".golden")
+	if *update {
+		if err := ioutil.WriteFile(golden, []byte(got), 0644); err != nil {
+			t.Fatalf("Error updating golden file %s: %s", golden, err.Error())
+		}
+	}
+	want, err := ioutil.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("Error reading golden file %s: %s", golden, err.Error())
+	}
+	if got != string(want) {
+		t.Errorf("%s does not match the golden file %s, got:\n%s\nwant:\n%s", name, golden, got, want)
+	}
+}
+
+//harnessScreen is a screen of a fixed size that keeps what is rendered on it
+type harnessScreen struct {
+	cursor    *ui.Cursor
+	bounds    image.Rectangle
+	bufferers []termui.Bufferer
+}
+
+func (s *harnessScreen) Bounds() image.Rectangle {
+	return s.bounds
+}
+
+func (s *harnessScreen) Cursor() *ui.Cursor {
+	return s.cursor
+}
+
+func (s *harnessScreen) Flush() *ui.Screen {
+	return nil
+}
+
+//RenderBufferer keeps the given bufferers, replacing those rendered before
+func (s *harnessScreen) RenderBufferer(bs ...termui.Bufferer) {
+	s.bufferers = bs
+}
+
+//Buffer returns the content rendered on this screen
+func (s *harnessScreen) Buffer() termui.Buffer {
+	buf := termui.NewBuffer()
+	for _, b := range s.bufferers {
+		buf.Merge(b.Buffer())
+	}
+	return buf
+}
+
+func TestHarness_containers(t *testing.T) {
+	h := NewHarness(120, 16)
+	defer h.Close()
+
+	w := NewContainersWidget(h.Daemon, h.Screen())
+	if err := w.Mount(); err != nil {
+		t.Fatalf("Error mounting containers widget: %s", err.Error())
+	}
+	AssertGolden(t, "TestHarness containers", w)
+
+	h.Screen().RenderBufferer(w)
+	rendered, err := h.Rendered()
+	if err != nil {
+		t.Fatalf("Error rendering the harness screen: %s", err.Error())
+	}
+	if want, _ := Render(w); rendered != want {
+		t.Errorf("Harness screen does not show what was rendered, got:\n%s\nwant:\n%s", rendered, want)
+	}
+}
+
+func TestHarness_images(t *testing.T) {
+	h := NewHarness(120, 10)
+	defer h.Close()
+
+	w := NewDockerImagesWidget(h.Daemon.Images, h.Screen())
+	if err := w.Mount(); err != nil {
+		t.Fatalf("Error mounting images widget: %s", err.Error())
+	}
+	AssertGolden(t, "TestHarness images", w)
+}
@@ -0,0 +1,158 @@
+package appui
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/moncho/dry/docker"
+)
+
+//HostsRenderer renders the state of every Docker host dry knows about,
+//one of them can be selected to switch to it
+type HostsRenderer struct {
+	hosts []docker.HostSummary
+	//current is the name of the host dry is connected to
+	current    string
+	selected   int
+	computing  bool
+	computedAt time.Time
+	sync.RWMutex
+}
+
+//NewHostsRenderer creates a HostsRenderer
+func NewHostsRenderer() *HostsRenderer {
+	return &HostsRenderer{}
+}
+
+//SetCurrent sets the name of the host dry is connected to
+func (r *HostsRenderer) SetCurrent(name string) {
+	r.Lock()
+	defer r.Unlock()
+	r.current = name
+}
+
+//StartComputing marks the state of the hosts as being computed, it returns
+//false if it is already being computed
+func (r *HostsRenderer) StartComputing() bool {
+	r.Lock()
+	defer r.Unlock()
+	if r.computing {
+		return false
+	}
+	r.computing = true
+	return true
+}
+
+//DoneComputing sets the state of the hosts, the selected host is kept if
+//it is still there
+func (r *HostsRenderer) DoneComputing(hosts []docker.HostSummary) {
+	r.Lock()
+	defer r.Unlock()
+	selected := ""
+	if r.selected < len(r.hosts) {
+		selected = r.hosts[r.selected].Name
+	}
+	r.computing = false
+	r.hosts = hosts
+	r.computedAt = docker.Now()
+	r.selected = 0
+	for i, h := range hosts {
+		if h.Name == selected || (selected == "" && h.Name == r.current) {
+			r.selected = i
+		}
+	}
+}
+
+//Hosts returns the last state computed of the hosts
+func (r *HostsRenderer) Hosts() []docker.HostSummary {
+	r.RLock()
+	defer r.RUnlock()
+	return r.hosts
+}
+
+//Selected returns the name of the selected host, false if there are no hosts
+func (r *HostsRenderer) Selected() (string, bool) {
+	r.RLock()
+	defer r.RUnlock()
+	if r.selected >= len(r.hosts) {
+		return "", false
+	}
+	return r.hosts[r.selected].Name, true
+}
+
+//MoveSelection moves the selection the given number of hosts, down if
+//positive, up if negative
+func (r *HostsRenderer) MoveSelection(n int) {
+	r.Lock()
+	defer r.Unlock()
+	r.selected += n
+	if r.selected >= len(r.hosts) {
+		r.selected = len(r.hosts) - 1
+	}
+	if r.selected < 0 {
+		r.selected = 0
+	}
+}
+
+//String renders the state of the hosts, with the totals of all of them
+func (r *HostsRenderer) String() string {
+	r.RLock()
+	defer r.RUnlock()
+	buffer := new(bytes.Buffer)
+	buffer.WriteString("<white>Docker hosts</>\n")
+	switch {
+	case r.computing:
+		buffer.WriteString("<green>Checking Docker hosts...</>\n")
+	case !r.computedAt.IsZero():
+		buffer.WriteString(fmt.Sprintf("Checked at %s\n", r.computedAt.Format("15:04:05")))
+	}
+	if r.hosts == nil {
+		return buffer.String()
+	}
+	buffer.WriteString("\n")
+
+	var total docker.HostSummary
+	healthy := 0
+	t := tabwriter.NewWriter(buffer, 8, 0, 2, ' ', 0)
+	fmt.Fprintln(t, "   NAME\tHOST\tHEALTH\tVERSION\tCONTAINERS\tRUNNING\tPAUSED\tSTOPPED")
+	for i, h := range r.hosts {
+		marker := " "
+		if h.Name == r.current {
+			marker = "*"
+		}
+		cursor := " "
+		if i == r.selected {
+			cursor = ">"
+		}
+		fmt.Fprintf(t, "%s%s %s\t%s\t%s\n", cursor, marker, h.Name, h.Host, hostStateColumns(h))
+		if h.Healthy() {
+			healthy++
+			total.Containers += h.Containers
+			total.Running += h.Running
+			total.Paused += h.Paused
+			total.Stopped += h.Stopped
+		}
+	}
+	fmt.Fprintf(t, "   TOTAL\t\t%d/%d up\t\t%d\t%d\t%d\t%d\n",
+		healthy, len(r.hosts), total.Containers, total.Running, total.Paused, total.Stopped)
+	t.Flush()
+	for _, h := range r.hosts {
+		if !h.Healthy() {
+			buffer.WriteString(fmt.Sprintf("\n <red>%s:</> %s", h.Name, h.Err))
+		}
+	}
+	buffer.WriteString("\n\n * is the Docker host dry is connected to, press <white>Enter</> to switch to the selected one.\n")
+	return buffer.String()
+}
+
+//hostStateColumns renders the health and the container counts of the given host
+func hostStateColumns(h docker.HostSummary) string {
+	if !h.Healthy() {
+		return "down\t-\t-\t-\t-\t-"
+	}
+	return fmt.Sprintf("up (%s)\t%s\t%d\t%d\t%d\t%d",
+		h.Latency.Round(time.Millisecond), h.Version, h.Containers, h.Running, h.Paused, h.Stopped)
+}
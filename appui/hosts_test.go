@@ -0,0 +1,45 @@
+package appui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/moncho/dry/docker"
+)
+
+func TestHostsRenderer(t *testing.T) {
+	r := NewHostsRenderer()
+	r.SetCurrent("staging")
+	if _, ok := r.Selected(); ok {
+		t.Error("A host is selected before the hosts are checked")
+	}
+	if !r.StartComputing() || r.StartComputing() {
+		t.Error("Hosts checked twice at the same time")
+	}
+	r.DoneComputing([]docker.HostSummary{
+		{Name: "default", Host: "unix:///var/run/docker.sock", Version: "19.03.5", Containers: 3, Running: 2, Stopped: 1},
+		{Name: "prod", Host: "tcp://prod:2376", Err: errors.New("connection refused")},
+		{Name: "staging", Host: "ssh://staging", Version: "19.03.1", Containers: 4, Running: 1, Paused: 1, Stopped: 2},
+	})
+	if selected, _ := r.Selected(); selected != "staging" {
+		t.Errorf("The current host is not selected at first, got %s", selected)
+	}
+	rendered := r.String()
+	for _, expected := range []string{">* staging", "connection refused", "2/3 up", "TOTAL"} {
+		if !strings.Contains(rendered, expected) {
+			t.Errorf("%q not rendered: %s", expected, rendered)
+		}
+	}
+
+	r.MoveSelection(-1)
+	r.MoveSelection(-5)
+	if selected, _ := r.Selected(); selected != "default" {
+		t.Errorf("Unexpected selected host after moving up, got %s", selected)
+	}
+	r.StartComputing()
+	r.DoneComputing([]docker.HostSummary{{Name: "dev"}, {Name: "default"}})
+	if selected, _ := r.Selected(); selected != "default" {
+		t.Errorf("The selected host is not kept after checking hosts again, got %s", selected)
+	}
+}
@@ -0,0 +1,221 @@
+package appui
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/docker/go-units"
+	"github.com/moncho/dry/docker"
+)
+
+const (
+	//imageLayersHeaderSize is the number of lines shown above the layers
+	imageLayersHeaderSize = 5
+	//createdByWidth is the width the command of a layer is cut to on the list
+	createdByWidth = 80
+)
+
+//ImageLayersRenderer renders the history of an image, one entry per line,
+//with the layer each entry created and the images sharing it. One of the
+//entries is selected to show its details.
+type ImageLayersRenderer struct {
+	image    string
+	layers   []docker.ImageLayer
+	sharing  bool
+	selected int
+	height   int
+	sync.RWMutex
+}
+
+//NewImageLayersRenderer creates an ImageLayersRenderer with room for the
+//given number of lines
+func NewImageLayersRenderer(height int) *ImageLayersRenderer {
+	return &ImageLayersRenderer{height: height}
+}
+
+//SetImage sets the image whose layers are rendered, the images sharing
+//them are yet to be known
+func (r *ImageLayersRenderer) SetImage(image string, layers []docker.ImageLayer) {
+	r.Lock()
+	defer r.Unlock()
+	r.image = image
+	r.layers = layers
+	r.sharing = true
+	r.selected = 0
+}
+
+//Image returns the name of the image whose layers are rendered
+func (r *ImageLayersRenderer) Image() string {
+	r.RLock()
+	defer r.RUnlock()
+	return r.image
+}
+
+//SetSharedLayers sets the layers of the given image once the images sharing
+//them are known, nothing changes if another image is rendered meanwhile
+func (r *ImageLayersRenderer) SetSharedLayers(image string, layers []docker.ImageLayer) {
+	r.Lock()
+	defer r.Unlock()
+	if image != r.image || len(layers) != len(r.layers) {
+		return
+	}
+	r.layers = layers
+	r.sharing = false
+}
+
+//Selected returns the selected entry, false if there are none
+func (r *ImageLayersRenderer) Selected() (docker.ImageLayer, bool) {
+	r.RLock()
+	defer r.RUnlock()
+	if r.selected >= len(r.layers) {
+		return docker.ImageLayer{}, false
+	}
+	return r.layers[r.selected], true
+}
+
+//MoveSelection moves the selection the given number of entries, down if
+//positive, up if negative
+func (r *ImageLayersRenderer) MoveSelection(n int) {
+	r.Lock()
+	defer r.Unlock()
+	r.selected += n
+	if r.selected >= len(r.layers) {
+		r.selected = len(r.layers) - 1
+	}
+	if r.selected < 0 {
+		r.selected = 0
+	}
+}
+
+//String renders the entries that fit, the selected one among them
+func (r *ImageLayersRenderer) String() string {
+	r.RLock()
+	defer r.RUnlock()
+	buffer := new(bytes.Buffer)
+
+	var size, shared int64
+	layers := 0
+	for _, l := range r.layers {
+		size += l.Size
+		if l.DiffID != "" {
+			layers++
+		}
+		if len(l.SharedWith) > 0 {
+			shared += l.Size
+		}
+	}
+	buffer.WriteString(fmt.Sprintf("<white>Layers of %s</>\n", r.image))
+	buffer.WriteString(fmt.Sprintf("%d history entries, %d layers, %s",
+		len(r.layers), layers, units.HumanSize(float64(size))))
+	if r.sharing {
+		buffer.WriteString(", <green>looking for images sharing its layers...</>\n\n")
+	} else {
+		buffer.WriteString(fmt.Sprintf(", %s shared with other images\n\n", units.HumanSize(float64(shared))))
+	}
+
+	start, end := r.visibleLayers()
+	t := tabwriter.NewWriter(buffer, 6, 0, 2, ' ', 0)
+	fmt.Fprintln(t, "  #\tLAYER\tCREATED\tSIZE\tSHARED WITH\tCREATED BY")
+	for i := start; i < end; i++ {
+		l := r.layers[i]
+		cursor := " "
+		if i == r.selected {
+			cursor = ">"
+		}
+		fmt.Fprintf(t, "%s %d\t%s\t%s\t%s\t%s\t%s\n",
+			cursor, len(r.layers)-i, layerID(l), docker.DurationForHumans(l.Created),
+			units.HumanSize(float64(l.Size)), sharedWith(l), cut(l.CreatedBy, createdByWidth))
+	}
+	t.Flush()
+	return buffer.String()
+}
+
+//visibleLayers returns the range of entries that fit, with the selected one
+func (r *ImageLayersRenderer) visibleLayers() (int, int) {
+	rows := r.height - imageLayersHeaderSize - MainScreenHeaderSize - MainScreenFooterLength
+	if rows < 1 {
+		rows = 1
+	}
+	start := 0
+	if r.selected >= rows {
+		start = r.selected - rows + 1
+	}
+	end := start + rows
+	if end > len(r.layers) {
+		end = len(r.layers)
+	}
+	return start, end
+}
+
+//ImageLayerDetails renders everything known about the given entry of the
+//history of an image, its command full-width
+func ImageLayerDetails(image string, l docker.ImageLayer) string {
+	buffer := new(bytes.Buffer)
+	buffer.WriteString(fmt.Sprintf("<white>Layer of %s</>\n\n", image))
+	writeKV(buffer, "Image", layerImage(l))
+	writeKV(buffer, "Created", docker.DurationForHumans(l.Created)+" ago")
+	writeKV(buffer, "Size", units.HumanSize(float64(l.Size)))
+	if l.DiffID != "" {
+		writeKV(buffer, "Layer", l.DiffID)
+	} else {
+		writeKV(buffer, "Layer", "none, or it could not be told")
+	}
+	if len(l.Tags) > 0 {
+		writeKV(buffer, "Tags", strings.Join(l.Tags, ", "))
+	}
+	if l.Comment != "" {
+		writeKV(buffer, "Comment", l.Comment)
+	}
+	buffer.WriteString("\n<white>Created by</>\n\n")
+	buffer.WriteString(l.CreatedBy)
+	buffer.WriteString("\n\n<white>Shared with</>\n\n")
+	if len(l.SharedWith) == 0 {
+		buffer.WriteString("No other local image has this layer.\n")
+	} else {
+		for _, name := range l.SharedWith {
+			buffer.WriteString(fmt.Sprintf(" %s\n", name))
+		}
+		buffer.WriteString(fmt.Sprintf(
+			"\nThe layer, and those below it, are stored once for the %d images.\n", len(l.SharedWith)+1))
+	}
+	return buffer.String()
+}
+
+//layerID returns the short digest of the layer of the given entry, if any
+func layerID(l docker.ImageLayer) string {
+	if l.DiffID == "" {
+		return "-"
+	}
+	return docker.ShortImageID(l.DiffID)
+}
+
+//layerImage returns the short id of the image of the given entry, if known
+func layerImage(l docker.ImageLayer) string {
+	if strings.HasPrefix(l.ID, "<") {
+		return l.ID
+	}
+	return docker.ShortImageID(l.ID)
+}
+
+func sharedWith(l docker.ImageLayer) string {
+	switch len(l.SharedWith) {
+	case 0:
+		return "-"
+	case 1:
+		return l.SharedWith[0]
+	default:
+		return fmt.Sprintf("%s and %d more", l.SharedWith[0], len(l.SharedWith)-1)
+	}
+}
+
+//cut cuts the given text to the given number of characters, on one line
+func cut(s string, width int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if runes := []rune(s); len(runes) > width {
+		return string(runes[:width-1]) + "…"
+	}
+	return s
+}
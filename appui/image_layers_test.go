@@ -0,0 +1,69 @@
+package appui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/image"
+	"github.com/moncho/dry/docker"
+)
+
+func TestImageLayersRenderer(t *testing.T) {
+	layers := []docker.ImageLayer{
+		{HistoryResponseItem: image.HistoryResponseItem{ID: "<missing>", CreatedBy: "CMD [\"app\"]"}},
+		{HistoryResponseItem: image.HistoryResponseItem{ID: "<missing>", CreatedBy: "COPY app /app", Size: 2000}, DiffID: "sha256:0123456789abcdef"},
+		{HistoryResponseItem: image.HistoryResponseItem{ID: "<missing>", CreatedBy: "ADD base /", Size: 5000}, DiffID: "sha256:fedcba9876543210"},
+	}
+	r := NewImageLayersRenderer(30)
+	if _, ok := r.Selected(); ok {
+		t.Error("A layer is selected before an image is set")
+	}
+	r.SetImage("app:latest", layers)
+	if rendered := r.String(); !strings.Contains(rendered, "looking for images") || !strings.Contains(rendered, "3 history entries, 2 layers") {
+		t.Errorf("Unexpected rendering: %s", rendered)
+	}
+
+	shared := append([]docker.ImageLayer(nil), layers...)
+	shared[2].SharedWith = []string{"other:1", "other:2"}
+	r.SetSharedLayers("other:latest", shared)
+	if l, _ := r.Selected(); l.SharedWith != nil {
+		t.Error("Layers of another image set")
+	}
+	r.SetSharedLayers("app:latest", shared)
+	rendered := r.String()
+	for _, expected := range []string{"5kB shared with other images", "other:1 and 1 more", "fedcba987654"} {
+		if !strings.Contains(rendered, expected) {
+			t.Errorf("%q not rendered: %s", expected, rendered)
+		}
+	}
+
+	r.MoveSelection(5)
+	l, _ := r.Selected()
+	if l.DiffID != "sha256:fedcba9876543210" {
+		t.Errorf("Unexpected selected layer: %+v", l)
+	}
+	details := ImageLayerDetails("app:latest", l)
+	for _, expected := range []string{"ADD base /", "other:2", "stored once for the 3 images"} {
+		if !strings.Contains(details, expected) {
+			t.Errorf("%q not in the details of a layer: %s", expected, details)
+		}
+	}
+}
+
+func TestImageLayersRendererScrolls(t *testing.T) {
+	var layers []docker.ImageLayer
+	for i := 0; i < 50; i++ {
+		layers = append(layers, docker.ImageLayer{
+			HistoryResponseItem: image.HistoryResponseItem{CreatedBy: "RUN step"}})
+	}
+	r := NewImageLayersRenderer(20)
+	r.SetImage("app:latest", layers)
+	r.MoveSelection(40)
+	rendered := r.String()
+	if !strings.Contains(rendered, "> 10\t") && !strings.Contains(rendered, "> 10 ") {
+		t.Errorf("Selected layer not rendered: %s", rendered)
+	}
+	if strings.Contains(rendered, " 50 ") {
+		t.Errorf("Layers out of the screen rendered: %s", rendered)
+	}
+}
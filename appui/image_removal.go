@@ -0,0 +1,115 @@
+package appui
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+	gtermui "github.com/gizak/termui"
+	"github.com/moncho/dry/docker/formatter"
+	"github.com/moncho/dry/ui"
+)
+
+//ImageRemovalPanel shows the progress of removing a set of images, including
+//the reason of failed removals
+type ImageRemovalPanel struct {
+	gtermui.List
+	ids     []string
+	names   map[string]string
+	results map[string]error
+	sync.RWMutex
+}
+
+//NewImageRemovalPanel creates a panel to show the progress of removing the given images
+func NewImageRemovalPanel(images []types.ImageSummary) *ImageRemovalPanel {
+	p := &ImageRemovalPanel{
+		List:    *gtermui.NewList(),
+		names:   make(map[string]string, len(images)),
+		results: make(map[string]error, len(images)),
+	}
+	for _, image := range images {
+		f := formatter.NewImageFormatter(image, true)
+		p.ids = append(p.ids, image.ID)
+		p.names[image.ID] = fmt.Sprintf("%s %s:%s", f.ID(), f.Repository(), f.Tag())
+	}
+	screen := ui.ActiveScreen.Dimensions()
+	p.Width = screen.Width * 3 / 4
+	p.Height = len(images) + 2
+	if max := screen.Height / 2; p.Height > max {
+		p.Height = max
+	}
+	p.X = (screen.Width - p.Width) / 2
+	p.Y = (screen.Height - p.Height) / 2
+	p.Bg = gtermui.Attribute(DryTheme.Bg)
+	p.ItemBgColor = gtermui.Attribute(DryTheme.Bg)
+	p.ItemFgColor = gtermui.Attribute(DryTheme.ListItem)
+	p.BorderLabelFg = gtermui.ColorWhite
+	return p
+}
+
+//Removed sets the result of removing the image with the given id
+func (p *ImageRemovalPanel) Removed(id string, err error) {
+	p.Lock()
+	defer p.Unlock()
+	p.results[id] = err
+}
+
+//Done returns true if the result of removing every image is known
+func (p *ImageRemovalPanel) Done() bool {
+	p.RLock()
+	defer p.RUnlock()
+	return len(p.results) == len(p.ids)
+}
+
+//Failed returns the number of images that could not be removed
+func (p *ImageRemovalPanel) Failed() int {
+	p.RLock()
+	defer p.RUnlock()
+	failed := 0
+	for _, err := range p.results {
+		if err != nil {
+			failed++
+		}
+	}
+	return failed
+}
+
+//Buffer returns the content of this panel as a termui.Buffer
+func (p *ImageRemovalPanel) Buffer() gtermui.Buffer {
+	p.RLock()
+	defer p.RUnlock()
+	items := make([]string, 0, len(p.ids))
+	//Failures first, so they are visible even if not every image fits
+	for _, id := range p.ids {
+		if err, ok := p.results[id]; ok && err != nil {
+			items = append(items, fmt.Sprintf("[%s failed: %s](fg-red)", p.names[id], err.Error()))
+		}
+	}
+	for _, id := range p.ids {
+		err, ok := p.results[id]
+		switch {
+		case !ok:
+			items = append(items, fmt.Sprintf("%s removing...", p.names[id]))
+		case err == nil:
+			items = append(items, fmt.Sprintf("[%s removed](fg-green)", p.names[id]))
+		}
+	}
+	p.Items = items
+	p.BorderLabel = fmt.Sprintf("Removing images: %d/%d", len(p.results), len(p.ids))
+	return p.List.Buffer()
+}
+
+//Mount callback
+func (p *ImageRemovalPanel) Mount() error {
+	return nil
+}
+
+//Unmount callback
+func (p *ImageRemovalPanel) Unmount() error {
+	return nil
+}
+
+//Name returns the widget name
+func (p *ImageRemovalPanel) Name() string {
+	return "ImageRemovalPanel"
+}
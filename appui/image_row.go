@@ -17,6 +17,7 @@ type ImageRow struct {
 	CreatedSince      *drytermui.ParColumn
 	SizeValue         int64
 	Size              *drytermui.ParColumn
+	UnusedFor         *drytermui.ParColumn
 
 	Row
 }
@@ -34,6 +35,7 @@ func NewImageRow(image types.ImageSummary, table drytermui.Table) *ImageRow {
 		CreatedSinceValue: image.Created,
 		Size:              drytermui.NewThemedParColumn(DryTheme, iformatter.Size()),
 		SizeValue:         image.VirtualSize,
+		UnusedFor:         drytermui.NewThemedParColumn(DryTheme, ""),
 	}
 	row.Height = 1
 	row.Table = table
@@ -44,6 +46,7 @@ func NewImageRow(image types.ImageSummary, table drytermui.Table) *ImageRow {
 		row.ID,
 		row.CreatedSince,
 		row.Size,
+		row.UnusedFor,
 	}
 	row.ParColumns = []*drytermui.ParColumn{
 		row.Repository,
@@ -51,6 +54,7 @@ func NewImageRow(image types.ImageSummary, table drytermui.Table) *ImageRow {
 		row.ID,
 		row.CreatedSince,
 		row.Size,
+		row.UnusedFor,
 	}
 
 	return row
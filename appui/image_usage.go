@@ -0,0 +1,44 @@
+package appui
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/moncho/dry/docker"
+)
+
+//ImageUsage renders the containers, running and stopped, created from the
+//given image
+func ImageUsage(image string, containers []*docker.Container) string {
+	buffer := new(bytes.Buffer)
+	buffer.WriteString(fmt.Sprintf("<white>Containers using %s</>\n\n", image))
+	if len(containers) == 0 {
+		buffer.WriteString("No container was created from this image, it can be removed.\n")
+		return buffer.String()
+	}
+	running := 0
+	w := tabwriter.NewWriter(buffer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tCONTAINER ID\tSTATUS")
+	for _, c := range containers {
+		if docker.IsContainerRunning(c) {
+			running++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", usageContainerName(c), docker.TruncateID(c.ID), c.Status)
+	}
+	w.Flush()
+	buffer.WriteString(fmt.Sprintf("\n%d containers, %d running.", len(containers), running))
+	if running > 0 {
+		buffer.WriteString(" The image cannot be removed while they run, unless forced.")
+	}
+	buffer.WriteString("\n")
+	return buffer.String()
+}
+
+func usageContainerName(c *docker.Container) string {
+	if len(c.Names) > 0 {
+		return strings.TrimPrefix(c.Names[0], "/")
+	}
+	return "-"
+}
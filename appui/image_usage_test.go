@@ -0,0 +1,25 @@
+package appui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/moncho/dry/docker"
+)
+
+func TestImageUsage(t *testing.T) {
+	if rendered := ImageUsage("app:latest", nil); !strings.Contains(rendered, "No container was created from this image") {
+		t.Errorf("Unexpected rendering of an unused image: %s", rendered)
+	}
+	containers := []*docker.Container{
+		{Container: types.Container{ID: "0123456789abcdef", Names: []string{"/web"}, Status: "Up 2 hours"}},
+		{Container: types.Container{ID: "fedcba9876543210", Names: []string{"/migrate"}, Status: "Exited (0) 1 day ago"}},
+	}
+	rendered := ImageUsage("app:latest", containers)
+	for _, expected := range []string{"Containers using app:latest", "web", "0123456789ab", "Exited (0) 1 day ago", "2 containers, 1 running", "unless forced"} {
+		if !strings.Contains(rendered, expected) {
+			t.Errorf("%q not rendered: %s", expected, rendered)
+		}
+	}
+}
@@ -5,14 +5,20 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/docker/docker/api/types"
+	units "github.com/docker/go-units"
 
 	gizaktermui "github.com/gizak/termui"
 	"github.com/moncho/dry/docker"
 	"github.com/moncho/dry/ui/termui"
 )
 
+//NewerImageMark marks images, and containers using them, whose tag points
+//to a newer image on the registry
+const NewerImageMark = " ↑"
+
 var defaultImageTableHeader = imageTableHeader()
 
 var imageTableHeaders = []SortableColumnHeader{
@@ -21,6 +27,29 @@ var imageTableHeaders = []SortableColumnHeader{
 	{`ID`, SortMode(docker.SortImagesByID)},
 	{`Created`, SortMode(docker.SortImagesByCreationDate)},
 	{`Size`, SortMode(docker.SortImagesBySize)},
+	{`UNUSED FOR`, SortMode(docker.NoSortImages)},
+}
+
+//ImageUsageFilter selects the images listed by how they are used
+type ImageUsageFilter int
+
+const (
+	//AllImages lists every image
+	AllImages ImageUsageFilter = iota
+	//DanglingImages lists images without a tag
+	DanglingImages
+	//UnusedImages lists images no container, running or stopped, was created from
+	UnusedImages
+)
+
+func (f ImageUsageFilter) String() string {
+	switch f {
+	case DanglingImages:
+		return "dangling"
+	case UnusedImages:
+		return "unused"
+	}
+	return "all"
 }
 
 //DockerImagesWidget knows how render a container list
@@ -34,6 +63,17 @@ type DockerImagesWidget struct {
 	startIndex, endIndex int
 	sortMode             docker.SortMode
 	screen               Screen
+	//UnusedSince, if set, is used to show for how long images have been unused
+	UnusedSince func(id string) (time.Time, bool)
+	//marked are the ids of the images marked for bulk operations
+	marked map[string]struct{}
+	//Outdated, if set, tells if the image with the given id has a newer
+	//version on the registry
+	Outdated func(id string) bool
+	//InUse, if set, returns the ids of the images containers were created from
+	InUse       func() map[string]bool
+	inUse       map[string]bool
+	usageFilter ImageUsageFilter
 
 	sync.RWMutex
 	mounted bool
@@ -61,6 +101,15 @@ func (s *DockerImagesWidget) Buffer() gizaktermui.Buffer {
 		if s.filterPattern != "" {
 			widgetHeader.HeaderEntry("Active filter", s.filterPattern)
 		}
+		if s.usageFilter != AllImages {
+			widgetHeader.HeaderEntry("Showing", s.usageFilter.String())
+		}
+		if s.InUse != nil {
+			widgetHeader.HeaderEntry("Reclaimable", units.HumanSize(float64(s.reclaimable())))
+		}
+		if len(s.marked) > 0 {
+			widgetHeader.HeaderEntry("Marked", strconv.Itoa(len(s.marked)))
+		}
 		widgetHeader.Y = y
 		buf.Merge(widgetHeader.Buffer())
 		y += widgetHeader.GetHeight()
@@ -81,6 +130,9 @@ func (s *DockerImagesWidget) Buffer() gizaktermui.Buffer {
 			} else {
 				imageRow.Highlighted()
 			}
+			if _, ok := s.marked[imageRow.image.ID]; ok {
+				imageRow.Marked()
+			}
 			buf.Merge(imageRow.Buffer())
 		}
 	}
@@ -94,6 +146,13 @@ func (s *DockerImagesWidget) Filter(filter string) {
 	s.filterPattern = filter
 }
 
+//FilterPattern returns the filter applied to this widget
+func (s *DockerImagesWidget) FilterPattern() string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.filterPattern
+}
+
 //Mount tells this widget to be ready for rendering
 func (s *DockerImagesWidget) Mount() error {
 	s.Lock()
@@ -109,9 +168,17 @@ func (s *DockerImagesWidget) Mount() error {
 	imageRows := make([]*ImageRow, len(images))
 	for i, image := range images {
 		imageRows[i] = NewImageRow(image, s.header)
+		imageRows[i].UnusedFor.Text = unusedFor(s.UnusedSince, image.ID)
+		if s.Outdated != nil && s.Outdated(image.ID) {
+			imageRows[i].Tag.Text += NewerImageMark
+		}
 	}
 	s.totalRows = imageRows
+	if s.InUse != nil {
+		s.inUse = s.InUse()
+	}
 	s.mounted = true
+	s.forgetRemovedMarks()
 	s.align()
 
 	return nil
@@ -130,6 +197,102 @@ func (s *DockerImagesWidget) OnEvent(event EventCommand) error {
 	return nil
 }
 
+//ToggleMark marks the selected image, or unmarks it if it was already marked
+func (s *DockerImagesWidget) ToggleMark() {
+	s.Lock()
+	defer s.Unlock()
+	if s.RowCount() == 0 {
+		return
+	}
+	id := s.filteredRows[s.selectedIndex].image.ID
+	if s.marked == nil {
+		s.marked = make(map[string]struct{})
+	}
+	if _, ok := s.marked[id]; ok {
+		delete(s.marked, id)
+	} else {
+		s.marked[id] = struct{}{}
+	}
+}
+
+//Marked returns the marked images
+func (s *DockerImagesWidget) Marked() []types.ImageSummary {
+	s.RLock()
+	defer s.RUnlock()
+	var images []types.ImageSummary
+	for _, row := range s.totalRows {
+		if _, ok := s.marked[row.image.ID]; ok {
+			images = append(images, row.image)
+		}
+	}
+	return images
+}
+
+//ClearMarks unmarks all the images
+func (s *DockerImagesWidget) ClearMarks() {
+	s.Lock()
+	defer s.Unlock()
+	s.marked = nil
+}
+
+//forgetRemovedMarks unmarks images that are not listed anymore
+func (s *DockerImagesWidget) forgetRemovedMarks() {
+	listed := make(map[string]struct{}, len(s.totalRows))
+	for _, row := range s.totalRows {
+		listed[row.image.ID] = struct{}{}
+	}
+	for id := range s.marked {
+		if _, ok := listed[id]; !ok {
+			delete(s.marked, id)
+		}
+	}
+}
+
+//CycleUsageFilter rotates to the next usage filter and returns it.
+//AllImages -> DanglingImages -> UnusedImages -> AllImages
+func (s *DockerImagesWidget) CycleUsageFilter() ImageUsageFilter {
+	s.Lock()
+	defer s.Unlock()
+	s.usageFilter = (s.usageFilter + 1) % (UnusedImages + 1)
+	s.screen.Cursor().Reset()
+	return s.usageFilter
+}
+
+//UsageFilter returns the usage filter applied to this widget
+func (s *DockerImagesWidget) UsageFilter() ImageUsageFilter {
+	s.RLock()
+	defer s.RUnlock()
+	return s.usageFilter
+}
+
+//matchesUsage returns true if the given image is listed with the given usage filter
+func (s *DockerImagesWidget) matchesUsage(image types.ImageSummary, filter ImageUsageFilter) bool {
+	switch filter {
+	case DanglingImages:
+		return docker.IsDangling(image)
+	case UnusedImages:
+		return !s.inUse[image.ID]
+	}
+	return true
+}
+
+//reclaimable returns the disk space freed by removing the images listed
+//with the current usage filter, on the list of every image it is the space
+//used by unused images
+func (s *DockerImagesWidget) reclaimable() int64 {
+	filter := s.usageFilter
+	if filter == AllImages {
+		filter = UnusedImages
+	}
+	var images []types.ImageSummary
+	for _, row := range s.totalRows {
+		if s.matchesUsage(row.image, filter) {
+			images = append(images, row.image)
+		}
+	}
+	return docker.ReclaimableSize(images)
+}
+
 //RowCount returns the number of rows of this widget.
 func (s *DockerImagesWidget) RowCount() int {
 	return len(s.filteredRows)
@@ -153,6 +316,21 @@ func (s *DockerImagesWidget) Sort() {
 	s.mounted = false
 }
 
+//SetSortMode sets the sort mode of this widget
+func (s *DockerImagesWidget) SetSortMode(mode docker.SortMode) {
+	s.Lock()
+	defer s.Unlock()
+	s.sortMode = mode
+	s.mounted = false
+}
+
+//SortMode returns the sort mode of this widget
+func (s *DockerImagesWidget) SortMode() docker.SortMode {
+	s.RLock()
+	defer s.RUnlock()
+	return s.sortMode
+}
+
 //Unmount tells this widget that it will not be rendering anymore
 func (s *DockerImagesWidget) Unmount() error {
 	s.RLock()
@@ -177,19 +355,14 @@ func (s *DockerImagesWidget) align() {
 }
 
 func (s *DockerImagesWidget) filterRows() {
-
-	if s.filterPattern != "" {
-		var rows []*ImageRow
-
-		for _, row := range s.totalRows {
-			if RowFilters.ByPattern(s.filterPattern)(row) {
-				rows = append(rows, row)
-			}
+	filter := RowFilters.ByFuzzyPattern(s.filterPattern)
+	var rows []*ImageRow
+	for _, row := range s.totalRows {
+		if filter(row) && s.matchesUsage(row.image, s.usageFilter) {
+			rows = append(rows, row)
 		}
-		s.filteredRows = rows
-	} else {
-		s.filteredRows = s.totalRows
 	}
+	s.filteredRows = rows
 }
 
 func (s *DockerImagesWidget) calculateVisibleRows() {
@@ -314,5 +487,6 @@ func imageTableHeader() *termui.TableHeader {
 	header.AddFixedWidthColumn(imageTableHeaders[2].Title, 12)
 	header.AddFixedWidthColumn(imageTableHeaders[3].Title, 12)
 	header.AddColumn(imageTableHeaders[4].Title)
+	header.AddFixedWidthColumn(imageTableHeaders[5].Title, 12)
 	return header
 }
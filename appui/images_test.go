@@ -111,3 +111,67 @@ func TestImagesToShowNoImages(t *testing.T) {
 		t.Error("Unexpected number of image rows, it should be 0")
 	}
 }
+
+func TestImagesWidget_Marks(t *testing.T) {
+	daemon := &mocks.DockerDaemonMock{}
+	cursor := ui.NewCursor()
+	screen := &testScreen{y1: 20, x1: 40, cursor: cursor}
+	w := NewDockerImagesWidget(daemon.Images, screen)
+	if err := w.Mount(); err != nil {
+		t.Fatalf("There was an error mounting the widget %v", err)
+	}
+
+	for _, position := range []int{0, 2, 0} {
+		cursor.ScrollTo(position)
+		w.prepareForRendering()
+		w.ToggleMark()
+	}
+	cursor.ScrollTo(2)
+	w.prepareForRendering()
+	selected := w.filteredRows[w.selectedIndex].image.ID
+
+	marked := w.Marked()
+	if len(marked) != 1 || marked[0].ID != selected {
+		t.Errorf("Expected only image %s to be marked, got %v", selected, marked)
+	}
+	w.ClearMarks()
+	if marked := w.Marked(); len(marked) != 0 {
+		t.Errorf("Expected no marked images, got %d", len(marked))
+	}
+}
+
+func TestImagesUsageFilter(t *testing.T) {
+	daemon := &mocks.DockerDaemonMock{}
+	screen := &testScreen{y1: 20, x1: 100, cursor: ui.NewCursor()}
+	renderer := NewDockerImagesWidget(daemon.Images, screen)
+	renderer.InUse = func() map[string]bool {
+		return map[string]bool{"8dfafdbc3a40": true, "541a0f4efc6f": true}
+	}
+	if err := renderer.Mount(); err != nil {
+		t.Fatalf("There was an error mounting the widget %v", err)
+	}
+	renderer.prepareForRendering()
+	if renderer.RowCount() != 5 {
+		t.Errorf("Unexpected images shown by default: %d", renderer.RowCount())
+	}
+	if reclaimable := renderer.reclaimable(); reclaimable != 44085 {
+		t.Errorf("Unexpected space reclaimed removing unused images: %d", reclaimable)
+	}
+
+	if filter := renderer.CycleUsageFilter(); filter != DanglingImages {
+		t.Errorf("Unexpected filter: %s", filter)
+	}
+	renderer.prepareForRendering()
+	if renderer.RowCount() != 0 {
+		t.Errorf("Unexpected dangling images shown: %d", renderer.RowCount())
+	}
+
+	renderer.CycleUsageFilter()
+	renderer.prepareForRendering()
+	if renderer.RowCount() != 3 {
+		t.Errorf("Unexpected unused images shown: %d", renderer.RowCount())
+	}
+	if filter := renderer.CycleUsageFilter(); filter != AllImages {
+		t.Errorf("Filters do not cycle back to all images: %s", filter)
+	}
+}
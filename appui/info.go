@@ -12,7 +12,8 @@ import (
 )
 
 type infoRenderer struct {
-	env dockerTypes.Info
+	env      dockerTypes.Info
+	warnings []string
 }
 
 //NewDockerInfoRenderer creates renderer for for docker info
@@ -22,12 +23,28 @@ func NewDockerInfoRenderer(env dockerTypes.Info) fmt.Stringer {
 	}
 }
 
+//NewDockerInfoRendererWithWarnings creates renderer for docker info that
+//shows first the given daemon warnings
+func NewDockerInfoRendererWithWarnings(env dockerTypes.Info, warnings []string) fmt.Stringer {
+	return &infoRenderer{
+		env:      env,
+		warnings: warnings,
+	}
+}
+
 //Render system-wide information
 func (r *infoRenderer) String() string {
 
 	buffer := new(bytes.Buffer)
 	info := r.env
 
+	for _, warning := range r.warnings {
+		fmt.Fprintf(buffer, "<red>WARNING:</> <white>%s</>\n", warning)
+	}
+	if len(r.warnings) > 0 {
+		buffer.WriteString("\n")
+	}
+
 	writeKV(buffer, "Containers", info.Containers)
 	writeKV(buffer, " Running", info.ContainersRunning)
 	writeKV(buffer, " Paused", info.ContainersPaused)
@@ -0,0 +1,28 @@
+package appui
+
+import (
+	"strings"
+
+	"github.com/moncho/dry/ui/termui"
+)
+
+//AddLabelColumns adds to the given header a column for each of the given labels
+func AddLabelColumns(header *termui.TableHeader, labels []string) {
+	for _, label := range labels {
+		header.AddColumn(strings.ToUpper(label))
+	}
+}
+
+//NewLabelColumns creates a column for each of the given labels, showing the
+//value the label has on the given label set
+func NewLabelColumns(values map[string]string, labels []string) []*termui.ParColumn {
+	columns := make([]*termui.ParColumn, len(labels))
+	for i, label := range labels {
+		value, ok := values[label]
+		if !ok {
+			value = "-"
+		}
+		columns[i] = termui.NewThemedParColumn(DryTheme, value)
+	}
+	return columns
+}
@@ -1,20 +1,76 @@
 package appui
 
 import (
+	"encoding/json"
 	"io"
+	"strings"
 
 	"github.com/gdamore/tcell"
 
+	"github.com/moncho/dry/docker/formatter"
 	"github.com/moncho/dry/ui"
 )
 
 //Less renders the given renderer output in a "less" buffer
 func Less(s string, screen *ui.Screen, events <-chan *tcell.EventKey, onDone func()) {
+	less := ui.NewLess(DryTheme)
+	less.MarkupSupport()
+	showLess(less, s, screen, events, onDone)
+}
+
+//PlainLess renders the given text in a "less" buffer, without interpreting
+//markup on it
+func PlainLess(s string, screen *ui.Screen, events <-chan *tcell.EventKey, onDone func()) {
+	showLess(ui.NewLess(DryTheme), s, screen, events, onDone)
+}
+
+const formatPrompt = "Go template, as in docker inspect --format (empty to clear): "
+
+//Inspect shows the given data as a JSON tree, below the given header, whose
+//objects and arrays can be expanded and collapsed. 'f' formats the data with
+//a Go template, the result is shown below the tree and copied to the clipboard.
+func Inspect(header string, data interface{}, screen *ui.Screen, events <-chan *tcell.EventKey, onDone func()) {
+	j, err := json.Marshal(data)
+	var tree *ui.JSONTree
+	if err == nil {
+		tree, err = ui.NewJSONTree(DryTheme, header, j)
+	}
+	if err != nil {
+		PlainLess("There was an error inspecting: "+err.Error(), screen, events, onDone)
+		return
+	}
+	tree.BindInput('f', formatPrompt, func(format string) {
+		if format = strings.TrimSpace(format); format == "" {
+			tree.SetFooter("")
+			return
+		}
+		result, err := formatter.Inspect(format, data)
+		if err != nil {
+			tree.Message(err.Error())
+			return
+		}
+		tree.SetFooter(result)
+		if err := ui.CopyToClipboard(result); err != nil {
+			tree.Message("Could not copy to clipboard: " + err.Error())
+			return
+		}
+		tree.Message("Template result copied to clipboard")
+	})
+	defer onDone()
+	screen.ClearAndFlush()
+
+	//Focus blocks until the tree is closed
+	tree.Focus(events)
+	screen.HideCursor()
+	screen.ClearAndFlush()
+
+	screen.Sync()
+}
+
+func showLess(less *ui.Less, s string, screen *ui.Screen, events <-chan *tcell.EventKey, onDone func()) {
 	defer onDone()
 	screen.ClearAndFlush()
 
-	less := ui.NewLess(DryTheme)
-	less.MarkupSupport()
 	io.WriteString(less, s)
 
 	//Focus blocks until less decides that it does not want focus any more
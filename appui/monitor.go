@@ -38,6 +38,9 @@ var monitorTableHeaders = map[string]SortMode{
 
 var defaultRefreshRate = 500 * time.Millisecond
 
+//idleRefreshRate is the refresh rate of an idle monitor
+var idleRefreshRate = 5 * time.Second
+
 //DockerMonitor interface.
 type DockerMonitor interface {
 	Containers(filters []docker.ContainerFilter, mode docker.SortMode) []*docker.Container
@@ -49,10 +52,17 @@ type DockerMonitor interface {
 type Monitor struct {
 	sync.RWMutex
 
+	alerts               AlertThresholds
 	cancel               func()
 	daemon               DockerMonitor
+	filterPattern        string
+	filteredRows         []*ContainerStatsRow
+	labelFilter          *docker.LabelFilter
 	header               *MonitorTableHeader
+	history              map[string]*StatsHistory
+	idle                 bool
 	offset               int
+	onAlert              AlertHandler
 	openChannels         []*docker.StatsChannel
 	refreshRate          time.Duration
 	rowChannels          map[*ContainerStatsRow]*docker.StatsChannel
@@ -69,6 +79,7 @@ func NewMonitor(daemon DockerMonitor, s ScreenBuffererRender) *Monitor {
 	m := Monitor{
 		header:        defaultMonitorTableHeader,
 		daemon:        daemon,
+		history:       make(map[string]*StatsHistory),
 		selectedIndex: 0,
 		offset:        0,
 		refreshRate:   defaultRefreshRate,
@@ -80,13 +91,26 @@ func NewMonitor(daemon DockerMonitor, s ScreenBuffererRender) *Monitor {
 
 //Buffer returns the content of this monitor as a termui.Buffer
 func (m *Monitor) Buffer() gizaktermui.Buffer {
-	m.RLock()
-	defer m.RUnlock()
+	m.Lock()
+	defer m.Unlock()
 	y := m.renderer.Bounds().Min.Y
 	buf := gizaktermui.NewBuffer()
 	widgetHeader := NewWidgetHeader()
-	widgetHeader.HeaderEntry("Running Containers", strconv.Itoa(m.RowCount()))
-	widgetHeader.HeaderEntry("Refresh rate", m.refreshRate.String())
+	m.filterRows()
+	m.renderer.Cursor().Max(m.RowCount() - 1)
+	widgetHeader.HeaderEntry("Running Containers", strconv.Itoa(len(m.rows)))
+	if m.filterPattern != "" {
+		widgetHeader.HeaderEntry("Active filter", m.filterPattern)
+		widgetHeader.HeaderEntry("Matching", strconv.Itoa(m.RowCount()))
+	}
+	if m.labelFilter != nil {
+		widgetHeader.HeaderEntry("Label filter", m.labelFilter.String())
+	}
+	if m.idle {
+		widgetHeader.HeaderEntry("Refresh rate", idleRefreshRate.String()+" (terminal not focused)")
+	} else {
+		widgetHeader.HeaderEntry("Refresh rate", m.refreshRate.String())
+	}
 
 	widgetHeader.Y = y
 
@@ -111,9 +135,29 @@ func (m *Monitor) Buffer() gizaktermui.Buffer {
 	return buf
 }
 
-//Filter filters the container list by the given filter
-func (m *Monitor) Filter(_ string) {
+//Filter filters the container list by the given pattern, containers are shown
+//if their name or any of their labels (as key=value) contain the pattern
+func (m *Monitor) Filter(pattern string) {
+	m.Lock()
+	defer m.Unlock()
+	m.filterPattern = pattern
+	m.filterRows()
+}
 
+//FilterByLabel monitors only the containers matching the given label filter,
+//nil monitors every running container. It takes effect the next time this
+//monitor is mounted.
+func (m *Monitor) FilterByLabel(filter *docker.LabelFilter) {
+	m.Lock()
+	defer m.Unlock()
+	m.labelFilter = filter
+}
+
+//LabelFilter returns the label filter applied to this monitor, if any
+func (m *Monitor) LabelFilter() *docker.LabelFilter {
+	m.RLock()
+	defer m.RUnlock()
+	return m.labelFilter
 }
 
 //Mount prepares this widget for rendering
@@ -124,25 +168,40 @@ func (m *Monitor) Mount() error {
 	}
 	m.Lock()
 	defer m.Unlock()
+	if m.idle && !m.alerts.Enabled() {
+		return nil
+	}
 	rowChannels := make(map[*ContainerStatsRow]*docker.StatsChannel)
-	containers := m.daemon.Containers(
-		[]docker.ContainerFilter{docker.ContainerFilters.Running()}, docker.SortByName)
+	filters := []docker.ContainerFilter{docker.ContainerFilters.Running()}
+	if m.labelFilter != nil {
+		filters = append(filters, m.labelFilter.Filter())
+	}
+	containers := m.daemon.Containers(filters, docker.SortByName)
 	var rows []*ContainerStatsRow
 	var channels []*docker.StatsChannel
+	//The history of containers no longer running is dropped
+	history := make(map[string]*StatsHistory, len(containers))
 	for _, c := range containers {
 		statsChan, err := m.daemon.StatsChannel(c)
 		if err != nil {
 			return fmt.Errorf("error mounting monitor widget: %w", err)
 		}
-		row := NewContainerStatsRow(c, defaultMonitorTableHeader)
+		h, ok := m.history[c.ID]
+		if !ok {
+			h = NewStatsHistory()
+		}
+		history[c.ID] = h
+		row := NewContainerStatsRowWithHistory(c, defaultMonitorTableHeader, h)
 		rows = append(rows, row)
 		channels = append(channels, statsChan)
 		rowChannels[row] = statsChan
 	}
 
 	m.rows = rows
+	m.filterRows()
 	m.openChannels = channels
 	m.rowChannels = rowChannels
+	m.history = history
 
 	m.align()
 	m.updateTableHeader()
@@ -167,16 +226,32 @@ func (m *Monitor) OnEvent(event EventCommand) error {
 	if event == nil {
 		return nil
 	}
-	rows := len(m.visibleRows())
-	if rows < 0 {
+	m.RLock()
+	rows := m.filteredRows
+	selected := m.selectedIndex
+	m.RUnlock()
+	if len(rows) == 0 {
 		return errors.New("there are no rows")
 	}
 
-	if m.selectedIndex >= rows {
-		return fmt.Errorf("there is no row on index %d", m.selectedIndex)
+	if selected >= len(rows) {
+		return fmt.Errorf("there is no row on index %d", selected)
 	}
 
-	return event(m.visibleRows()[m.selectedIndex].container.ID)
+	return event(rows[selected].container.ID)
+}
+
+//MarkEvent marks the given event action, emitted by the container with the
+//given id, on the container row
+func (m *Monitor) MarkEvent(containerID, action string) {
+	m.RLock()
+	defer m.RUnlock()
+	for _, row := range m.rows {
+		if row.container.ID == containerID {
+			row.MarkEvent(action)
+			return
+		}
+	}
 }
 
 //RefreshRate sets the refresh rate of this monitor to the given amount in
@@ -187,6 +262,25 @@ func (m *Monitor) RefreshRate(millis int) {
 	m.refreshRate = time.Duration(millis) * time.Millisecond
 }
 
+//SetIdle reduces the load of the monitor while nobody is looking at it, an
+//idle monitor is refreshed every few seconds and, unless alerts are enabled,
+//stops streaming stats until it is no longer idle and mounted again
+func (m *Monitor) SetIdle(idle bool) {
+	m.Lock()
+	defer m.Unlock()
+	m.idle = idle
+	if idle && !m.alerts.Enabled() && m.cancel != nil {
+		m.cancel()
+		m.cancel = nil
+	}
+}
+
+func (m *Monitor) isIdle() bool {
+	m.RLock()
+	defer m.RUnlock()
+	return m.idle
+}
+
 //refreshLoop signals this monitor to refresh itself until the given context is cancelled
 func (m *Monitor) refreshLoop(ctx context.Context) {
 	go func(rowChannels map[*ContainerStatsRow]*docker.StatsChannel) {
@@ -195,11 +289,16 @@ func (m *Monitor) refreshLoop(ctx context.Context) {
 			go func(row *ContainerStatsRow) {
 				for stat := range stats {
 					row.Update(stat)
+					m.checkAlerts(row, stat)
+				}
+				//streams are also closed when the monitor is unmounted
+				if ctx.Err() == nil {
+					row.markAsNotRunning()
 				}
-				row.markAsNotRunning()
 			}(row)
 		}
 		m.refresh()
+		lastRefresh := docker.Now()
 		refreshTimer := time.NewTicker(m.refreshRate)
 		for {
 			select {
@@ -207,16 +306,20 @@ func (m *Monitor) refreshLoop(ctx context.Context) {
 				refreshTimer.Stop()
 				return
 			case <-refreshTimer.C:
+				if m.isIdle() && docker.Since(lastRefresh) < idleRefreshRate {
+					continue
+				}
 				m.refresh()
+				lastRefresh = docker.Now()
 			}
 		}
 
 	}(m.rowChannels)
 }
 
-//RowCount returns the number of rows of this Monitor.
+//RowCount returns the number of rows of this Monitor, filtered rows are not counted.
 func (m *Monitor) RowCount() int {
-	return len(m.rows)
+	return len(m.filteredRows)
 }
 
 //Sort sorts the container list
@@ -263,12 +366,12 @@ func (m *Monitor) highlightSelectedRow() {
 		return
 	}
 	index := m.renderer.Cursor().Position()
-	if index > m.RowCount() {
+	if index >= m.RowCount() {
 		index = m.RowCount() - 1
 	}
 
 	m.selectedIndex = index
-	for i, im := range m.rows {
+	for i, im := range m.filteredRows {
 		if i != index {
 			im.NotHighlighted()
 		} else {
@@ -281,8 +384,22 @@ func (m *Monitor) refresh() {
 	m.renderer.Flush()
 }
 
+func (m *Monitor) filterRows() {
+	if m.filterPattern == "" {
+		m.filteredRows = m.rows
+		return
+	}
+	var rows []*ContainerStatsRow
+	for _, row := range m.rows {
+		if row.Matches(m.filterPattern) {
+			rows = append(rows, row)
+		}
+	}
+	m.filteredRows = rows
+}
+
 func (m *Monitor) sortRows() {
-	rows := m.rows
+	rows := m.filteredRows
 	mode := m.sortMode
 
 	var sortAlg func(i, j int) bool
@@ -298,19 +415,19 @@ func (m *Monitor) sortRows() {
 		}
 	case cpu:
 		sortAlg = func(i, j int) bool {
-			return rows[i].CPU.Percent > rows[j].CPU.Percent
+			return rows[i].CPUVal > rows[j].CPUVal
 		}
 	case mem:
 		sortAlg = func(i, j int) bool {
-			return rows[i].Memory.Percent > rows[j].Memory.Percent
+			return rows[i].MemVal > rows[j].MemVal
 		}
 	case netio:
 		sortAlg = func(i, j int) bool {
-			return rows[i].Net.Text > rows[j].Net.Text
+			return rows[i].NetVal > rows[j].NetVal
 		}
 	case blockio:
 		sortAlg = func(i, j int) bool {
-			return rows[i].Block.Text > rows[j].Block.Text
+			return rows[i].BlockVal > rows[j].BlockVal
 		}
 	case pids:
 		sortAlg = func(i, j int) bool {
@@ -345,7 +462,7 @@ func (m *Monitor) visibleRows() []*ContainerStatsRow {
 	if height < 0 {
 		return nil
 	}
-	rows := m.rows
+	rows := m.filteredRows
 	count := len(rows)
 	cursor := m.renderer.Cursor()
 	selected := cursor.Position()
@@ -377,3 +494,38 @@ func (m *Monitor) visibleRows() []*ContainerStatsRow {
 	end := m.endIndex + 1
 	return rows[start:end]
 }
+
+//ContainerMetrics are the metrics sampled from a container
+type ContainerMetrics struct {
+	ID      string          `json:"id"`
+	Name    string          `json:"name"`
+	Samples []MetricsSample `json:"samples"`
+}
+
+//Metrics returns the metrics sampled from the containers shown by this monitor
+//during the given window, a zero window returns only the latest sample
+func (m *Monitor) Metrics(window time.Duration) []ContainerMetrics {
+	m.RLock()
+	defer m.RUnlock()
+	var since time.Time
+	if window > 0 {
+		since = docker.Now().Add(-window)
+	}
+	var metrics []ContainerMetrics
+	for _, row := range m.filteredRows {
+		h, ok := m.history[row.container.ID]
+		if !ok {
+			continue
+		}
+		samples := h.Samples(since)
+		if len(samples) == 0 {
+			continue
+		}
+		metrics = append(metrics, ContainerMetrics{
+			ID:      row.container.ID,
+			Name:    row.Name.Text,
+			Samples: samples,
+		})
+	}
+	return metrics
+}
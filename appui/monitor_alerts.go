@@ -0,0 +1,87 @@
+package appui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gizak/termui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+)
+
+//alertRowColor is the color of the rows of containers exceeding an alert threshold
+const alertRowColor = termui.Attribute(ui.Color214)
+
+//AlertThresholds are the CPU and memory usage percentages that, once exceeded
+//by a container, raise an alert. Zero disables the threshold.
+type AlertThresholds struct {
+	CPU    float64
+	Memory float64
+}
+
+//Enabled returns true if any threshold is set
+func (t AlertThresholds) Enabled() bool {
+	return t.CPU > 0 || t.Memory > 0
+}
+
+//Exceeded describes the thresholds exceeded by the given stats, it returns
+//an empty string if none is exceeded
+func (t AlertThresholds) Exceeded(stat *docker.Stats) string {
+	if stat == nil {
+		return ""
+	}
+	var exceeded []string
+	if t.CPU > 0 && stat.CPUPercentage > t.CPU {
+		exceeded = append(exceeded,
+			fmt.Sprintf("CPU %.2f%% > %.2f%%", stat.CPUPercentage, t.CPU))
+	}
+	if t.Memory > 0 && stat.MemoryPercentage > t.Memory {
+		exceeded = append(exceeded,
+			fmt.Sprintf("memory %.2f%% > %.2f%%", stat.MemoryPercentage, t.Memory))
+	}
+	return strings.Join(exceeded, ", ")
+}
+
+//AlertHandler is notified when a container starts or stops exceeding the
+//alert thresholds of the monitor
+type AlertHandler func(container string, exceeded string, alerting bool)
+
+//SetAlertThresholds sets the thresholds that raise alerts and the handler
+//notified of them
+func (m *Monitor) SetAlertThresholds(thresholds AlertThresholds, handler AlertHandler) {
+	m.Lock()
+	defer m.Unlock()
+	m.alerts = thresholds
+	m.onAlert = handler
+}
+
+//checkAlerts checks the given stats, updated on the given row, against
+//the alert thresholds, the alert handler is only notified of changes
+func (m *Monitor) checkAlerts(row *ContainerStatsRow, stat *docker.Stats) {
+	m.RLock()
+	thresholds, handler := m.alerts, m.onAlert
+	m.RUnlock()
+	if !thresholds.Enabled() {
+		return
+	}
+	exceeded := thresholds.Exceeded(stat)
+	if row.setAlerting(exceeded != "") && handler != nil {
+		handler(row.Name.Text, exceeded, exceeded != "")
+	}
+}
+
+//setAlerting sets whether this row is alerting, returns true if it changed
+func (row *ContainerStatsRow) setAlerting(alerting bool) bool {
+	row.Lock()
+	defer row.Unlock()
+	changed := row.alerting != alerting
+	row.alerting = alerting
+	return changed
+}
+
+//Alerting returns true if the container of this row exceeds an alert threshold
+func (row *ContainerStatsRow) Alerting() bool {
+	row.RLock()
+	defer row.RUnlock()
+	return row.alerting
+}
@@ -12,7 +12,7 @@ type MonitorTableHeader struct {
 
 //NewMonitorTableHeader creates a table header for the monitor screen
 func NewMonitorTableHeader() *MonitorTableHeader {
-	fields := []string{"NAME", "CPU", "MEM", "NET RX/TX", "BLOCK I/O"}
+	fields := []string{"NAME", "CPU", "CPU HISTORY", "MEM", "MEM HISTORY", "NET RX/TX", "NET HISTORY", "BLOCK I/O"}
 
 	header := termui.NewHeader(DryTheme)
 	header.ColumnSpacing = DefaultColumnSpacing
@@ -4,6 +4,7 @@ import (
 	"image"
 	"testing"
 
+	"github.com/docker/docker/api/types"
 	termui "github.com/gizak/termui"
 	"github.com/moncho/dry/docker"
 	"github.com/moncho/dry/ui"
@@ -47,3 +48,63 @@ func TestMonitor_RepeatedUnmount(t *testing.T) {
 	m.Unmount()
 	m.Unmount()
 }
+
+func TestMonitor_FilterAndSort(t *testing.T) {
+	m := NewMonitor(dockerMonitor{}, screenBuffererRender{})
+	newRow := func(name string, labels map[string]string, net float64) *ContainerStatsRow {
+		c := &docker.Container{
+			Container: types.Container{ID: name, Names: []string{name}, Labels: labels}}
+		row := NewContainerStatsRow(c, NewMonitorTableHeader())
+		row.NetVal = net
+		return row
+	}
+	m.rows = []*ContainerStatsRow{
+		newRow("web", map[string]string{"env": "prod"}, 10),
+		newRow("db", map[string]string{"env": "prod"}, 300),
+		newRow("test-web", map[string]string{"env": "dev"}, 1000),
+	}
+
+	m.Filter("env=prod")
+	if m.RowCount() != 2 {
+		t.Fatalf("Unexpected number of rows after filtering by label, got %d, expected 2", m.RowCount())
+	}
+	m.sortMode = netio
+	m.sortRows()
+	if m.filteredRows[0].container.ID != "db" {
+		t.Errorf("Unexpected first row sorting by network I/O, got %s, expected db", m.filteredRows[0].container.ID)
+	}
+
+	m.Filter("web")
+	if m.RowCount() != 2 {
+		t.Errorf("Unexpected number of rows after filtering by name, got %d, expected 2", m.RowCount())
+	}
+	m.Filter("")
+	if m.RowCount() != 3 {
+		t.Errorf("Unexpected number of rows after removing the filter, got %d, expected 3", m.RowCount())
+	}
+}
+
+func TestMonitor_Alerts(t *testing.T) {
+	m := NewMonitor(dockerMonitor{}, screenBuffererRender{})
+	c := &docker.Container{
+		Container: types.Container{ID: "web", Names: []string{"web"}}}
+	row := NewContainerStatsRow(c, NewMonitorTableHeader())
+	var alerts []bool
+	m.SetAlertThresholds(AlertThresholds{CPU: 50}, func(container, exceeded string, alerting bool) {
+		alerts = append(alerts, alerting)
+	})
+
+	m.checkAlerts(row, &docker.Stats{CPUPercentage: 10, MemoryPercentage: 99})
+	m.checkAlerts(row, &docker.Stats{CPUPercentage: 60})
+	if !row.Alerting() {
+		t.Error("Expected row to be alerting")
+	}
+	m.checkAlerts(row, &docker.Stats{CPUPercentage: 70})
+	m.checkAlerts(row, &docker.Stats{CPUPercentage: 20})
+	if row.Alerting() {
+		t.Error("Expected row not to be alerting")
+	}
+	if len(alerts) != 2 || !alerts[0] || alerts[1] {
+		t.Errorf("Unexpected alerts: %v", alerts)
+	}
+}
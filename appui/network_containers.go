@@ -0,0 +1,162 @@
+package appui
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/docker/docker/api/types"
+	"github.com/moncho/dry/docker"
+)
+
+//networkContainersHeaderSize is the number of lines shown above the containers
+const networkContainersHeaderSize = 6
+
+//NetworkContainersRenderer renders a network and the containers attached
+//to it, one per line. One of the containers is selected.
+type NetworkContainersRenderer struct {
+	network    types.NetworkResource
+	containers []docker.NetworkContainer
+	selected   int
+	height     int
+	sync.RWMutex
+}
+
+//NewNetworkContainersRenderer creates a NetworkContainersRenderer with room
+//for the given number of lines
+func NewNetworkContainersRenderer(height int) *NetworkContainersRenderer {
+	return &NetworkContainersRenderer{height: height}
+}
+
+//SetNetwork sets the network rendered, the selected container is kept if
+//it is still attached to the network
+func (r *NetworkContainersRenderer) SetNetwork(n types.NetworkResource) {
+	r.Lock()
+	defer r.Unlock()
+	selected := ""
+	if n.ID == r.network.ID && r.selected < len(r.containers) {
+		selected = r.containers[r.selected].ID
+	}
+	r.network = n
+	r.containers = docker.NetworkContainers(n)
+	r.selected = 0
+	for i, c := range r.containers {
+		if c.ID == selected {
+			r.selected = i
+		}
+	}
+}
+
+//Network returns the network rendered
+func (r *NetworkContainersRenderer) Network() types.NetworkResource {
+	r.RLock()
+	defer r.RUnlock()
+	return r.network
+}
+
+//Selected returns the selected container, false if there are none
+func (r *NetworkContainersRenderer) Selected() (docker.NetworkContainer, bool) {
+	r.RLock()
+	defer r.RUnlock()
+	if r.selected >= len(r.containers) {
+		return docker.NetworkContainer{}, false
+	}
+	return r.containers[r.selected], true
+}
+
+//MoveSelection moves the selection the given number of containers, down if
+//positive, up if negative
+func (r *NetworkContainersRenderer) MoveSelection(n int) {
+	r.Lock()
+	defer r.Unlock()
+	r.selected += n
+	if r.selected >= len(r.containers) {
+		r.selected = len(r.containers) - 1
+	}
+	if r.selected < 0 {
+		r.selected = 0
+	}
+}
+
+//String renders the network and the containers that fit, the selected one among them
+func (r *NetworkContainersRenderer) String() string {
+	r.RLock()
+	defer r.RUnlock()
+	buffer := new(bytes.Buffer)
+	n := r.network
+	buffer.WriteString(fmt.Sprintf("<white>Network %s</> %s\n", n.Name, docker.TruncateID(n.ID)))
+	buffer.WriteString(fmt.Sprintf("Driver: %s, scope: %s, subnet: %s", n.Driver, n.Scope, networkSubnets(n)))
+	if n.Internal {
+		buffer.WriteString(", internal")
+	}
+	if n.Attachable {
+		buffer.WriteString(", attachable")
+	}
+	buffer.WriteString("\n\n")
+	if len(r.containers) == 0 {
+		buffer.WriteString("No container is attached to this network.\n")
+		return buffer.String()
+	}
+	buffer.WriteString(fmt.Sprintf("%d containers attached\n\n", len(r.containers)))
+
+	start, end := r.visibleContainers()
+	t := tabwriter.NewWriter(buffer, 6, 0, 2, ' ', 0)
+	fmt.Fprintln(t, "  NAME\tCONTAINER ID\tIPV4 ADDRESS\tIPV6 ADDRESS\tMAC ADDRESS")
+	for i := start; i < end; i++ {
+		c := r.containers[i]
+		cursor := " "
+		if i == r.selected {
+			cursor = ">"
+		}
+		fmt.Fprintf(t, "%s %s\t%s\t%s\t%s\t%s\n",
+			cursor, c.Name, docker.TruncateID(c.ID), orDash(c.IPv4Address), orDash(c.IPv6Address), orDash(c.MacAddress))
+	}
+	t.Flush()
+	return buffer.String()
+}
+
+//visibleContainers returns the range of containers that fit, with the selected one
+func (r *NetworkContainersRenderer) visibleContainers() (int, int) {
+	rows := r.height - networkContainersHeaderSize - MainScreenHeaderSize - MainScreenFooterLength
+	if rows < 1 {
+		rows = 1
+	}
+	start := 0
+	if r.selected >= rows {
+		start = r.selected - rows + 1
+	}
+	end := start + rows
+	if end > len(r.containers) {
+		end = len(r.containers)
+	}
+	return start, end
+}
+
+//networkSubnets returns the subnets of the given network, comma separated,
+//with the addresses used and available on each one
+func networkSubnets(n types.NetworkResource) string {
+	var subnets []string
+	for _, u := range docker.IPAMUsage(n) {
+		if u.Size < 0 {
+			subnets = append(subnets, fmt.Sprintf("%s (%d addresses used)", u.Subnet, u.Allocated))
+			continue
+		}
+		subnet := fmt.Sprintf("%s (%d of %d addresses used, %d available)", u.Subnet, u.Allocated, u.Size, u.Available())
+		if u.Usage() >= ipsUsedCritical {
+			subnet = "<red>" + subnet + "</>"
+		} else if u.Usage() >= ipsUsedWarning {
+			subnet = "<yellow>" + subnet + "</>"
+		}
+		subnets = append(subnets, subnet)
+	}
+	return orDash(strings.Join(subnets, ", "))
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,51 @@
+package appui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/network"
+)
+
+func TestNetworkContainersRenderer(t *testing.T) {
+	n := types.NetworkResource{
+		ID:       "0123456789abcdef",
+		Name:     "backend",
+		Driver:   "bridge",
+		Scope:    "local",
+		Internal: true,
+		IPAM:     network.IPAM{Config: []network.IPAMConfig{{Subnet: "172.20.0.0/16"}}},
+		Containers: map[string]types.EndpointResource{
+			"bbbbbbbbbbbbbbbb": {Name: "web", IPv4Address: "172.20.0.3/16", MacAddress: "02:42:ac:14:00:03"},
+			"aaaaaaaaaaaaaaaa": {Name: "db", IPv4Address: "172.20.0.2/16"},
+		},
+	}
+	r := NewNetworkContainersRenderer(30)
+	if _, ok := r.Selected(); ok {
+		t.Error("A container is selected before a network is set")
+	}
+	r.SetNetwork(n)
+	rendered := r.String()
+	for _, expected := range []string{"Network backend", "172.20.0.0/16 (2 of 65534 addresses used, 65532 available)", "internal", "2 containers attached", "> db", "172.20.0.3/16"} {
+		if !strings.Contains(rendered, expected) {
+			t.Errorf("%q not rendered: %s", expected, rendered)
+		}
+	}
+
+	r.MoveSelection(5)
+	if c, _ := r.Selected(); c.Name != "web" || c.ID != "bbbbbbbbbbbbbbbb" {
+		t.Errorf("Unexpected selected container: %+v", c)
+	}
+	//the selection is kept while the container is still attached
+	delete(n.Containers, "aaaaaaaaaaaaaaaa")
+	r.SetNetwork(n)
+	if c, _ := r.Selected(); c.Name != "web" {
+		t.Errorf("Selection not kept after reloading the network: %+v", c)
+	}
+
+	r.SetNetwork(types.NetworkResource{ID: "other", Name: "empty"})
+	if rendered := r.String(); !strings.Contains(rendered, "No container is attached") {
+		t.Errorf("Unexpected rendering of a network without containers: %s", rendered)
+	}
+}
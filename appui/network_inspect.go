@@ -0,0 +1,84 @@
+package appui
+
+import (
+	"io"
+	"strings"
+
+	"github.com/gdamore/tcell"
+	"github.com/moncho/dry/ui"
+)
+
+const (
+	connectContainerPrompt    = "Container to connect to the network: "
+	connectAliasesPrompt      = "Network aliases of the container, comma separated (empty for none): "
+	connectIPPrompt           = "Static IPv4 or IPv6 address of the container (empty for none): "
+	disconnectContainerPrompt = "Container to disconnect from the network: "
+)
+
+//NetworkConnector connects and disconnects containers, by name or id, to a network
+type NetworkConnector interface {
+	Connect(container string, aliases []string, ip string) error
+	Disconnect(container string) error
+}
+
+//NetworkInspect shows the network inspection given by the render func, 'c'
+//connects a container to the network, asking for its aliases and static IP,
+//'d' disconnects a container from the network. The inspection is rendered
+//again after each change.
+func NetworkInspect(render func() (string, error), connector NetworkConnector, screen *ui.Screen, events <-chan *tcell.EventKey, onDone func()) {
+	less := ui.NewLess(DryTheme)
+	less.MarkupSupport()
+	show := func() {
+		s, err := render()
+		if err != nil {
+			less.Message(err.Error())
+			return
+		}
+		less.Clear()
+		io.WriteString(less, s)
+	}
+	less.BindInput('c', connectContainerPrompt, func(container string) {
+		container = strings.TrimSpace(container)
+		if container == "" {
+			return
+		}
+		less.ReadInput(connectAliasesPrompt, func(input string) {
+			aliases := splitAliases(input)
+			less.ReadInput(connectIPPrompt, func(ip string) {
+				if err := connector.Connect(container, aliases, strings.TrimSpace(ip)); err != nil {
+					less.Message(err.Error())
+					return
+				}
+				show()
+				less.Message("Container " + container + " connected to the network")
+			})
+		})
+	})
+	less.BindInput('d', disconnectContainerPrompt, func(container string) {
+		container = strings.TrimSpace(container)
+		if container == "" {
+			return
+		}
+		if err := connector.Disconnect(container); err != nil {
+			less.Message(err.Error())
+			return
+		}
+		show()
+		less.Message("Container " + container + " disconnected from the network")
+	})
+	less.SetStatus("c: connect container d: disconnect container")
+	defer onDone()
+	screen.ClearAndFlush()
+	show()
+	less.Focus(events)
+	screen.HideCursor()
+	screen.ClearAndFlush()
+	screen.Sync()
+}
+
+//splitAliases splits the given comma or space separated aliases
+func splitAliases(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+}
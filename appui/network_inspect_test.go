@@ -0,0 +1,19 @@
+package appui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitAliases(t *testing.T) {
+	for input, want := range map[string][]string{
+		"":                nil,
+		"db":              {"db"},
+		"db, postgres":    {"db", "postgres"},
+		" db  postgres, ": {"db", "postgres"},
+	} {
+		if got := splitAliases(input); len(got) != len(want) || (len(want) > 0 && !reflect.DeepEqual(got, want)) {
+			t.Errorf("splitAliases(%q): got %v, want %v", input, got, want)
+		}
+	}
+}
@@ -1,12 +1,25 @@
 package appui
 
 import (
+	"fmt"
+
 	"github.com/docker/docker/api/types"
 	termui "github.com/gizak/termui"
+	"github.com/moncho/dry/docker"
 	"github.com/moncho/dry/docker/formatter"
+	"github.com/moncho/dry/ui"
 	drytermui "github.com/moncho/dry/ui/termui"
 )
 
+const (
+	//ipsUsedWarning is the percentage of the addresses of a subnet in use
+	//from which its usage is shown as a warning
+	ipsUsedWarning = 75
+	//ipsUsedCritical is the percentage of the addresses of a subnet in use
+	//from which it is shown as about to be exhausted
+	ipsUsedCritical = 90
+)
+
 //NetworkRow is a Grid row showing information about a Docker image
 type NetworkRow struct {
 	network    types.NetworkResource
@@ -17,7 +30,11 @@ type NetworkRow struct {
 	Services   *drytermui.ParColumn
 	Scope      *drytermui.ParColumn
 	Subnet     *drytermui.ParColumn
+	IPsUsed    *drytermui.ParColumn
 	Gateway    *drytermui.ParColumn
+	UnusedFor  *drytermui.ParColumn
+	//ipsUsedFg, if set, is the color of the addresses used of subnets running out of them
+	ipsUsedFg termui.Attribute
 	Row
 }
 
@@ -34,7 +51,9 @@ func NewNetworkRow(network types.NetworkResource, table drytermui.Table) *Networ
 		Services:   drytermui.NewThemedParColumn(DryTheme, networkFormatter.Services()),
 		Scope:      drytermui.NewThemedParColumn(DryTheme, networkFormatter.Scope()),
 		Subnet:     drytermui.NewThemedParColumn(DryTheme, networkFormatter.Subnet()),
+		IPsUsed:    drytermui.NewThemedParColumn(DryTheme, "-"),
 		Gateway:    drytermui.NewThemedParColumn(DryTheme, networkFormatter.Gateway()),
+		UnusedFor:  drytermui.NewThemedParColumn(DryTheme, ""),
 	}
 	row.Height = 1
 	row.Table = table
@@ -47,7 +66,9 @@ func NewNetworkRow(network types.NetworkResource, table drytermui.Table) *Networ
 		row.Services,
 		row.Scope,
 		row.Subnet,
+		row.IPsUsed,
 		row.Gateway,
+		row.UnusedFor,
 	}
 	row.ParColumns = []*drytermui.ParColumn{
 		row.ID,
@@ -57,7 +78,13 @@ func NewNetworkRow(network types.NetworkResource, table drytermui.Table) *Networ
 		row.Services,
 		row.Scope,
 		row.Subnet,
+		row.IPsUsed,
 		row.Gateway,
+		row.UnusedFor,
+	}
+	if usage := docker.IPAMUsage(network); len(usage) > 0 {
+		row.IPsUsed.Text = ipsUsed(usage[0])
+		row.ipsUsedFg = ipsUsedColor(usage[0])
 	}
 
 	return row
@@ -68,3 +95,33 @@ func NewNetworkRow(network types.NetworkResource, table drytermui.Table) *Networ
 func (row *NetworkRow) ColumnsForFilter() []*drytermui.ParColumn {
 	return []*drytermui.ParColumn{row.ID, row.Name, row.Driver, row.Services, row.Scope, row.Subnet, row.Gateway}
 }
+
+//NotHighlighted marks this rows as being not highlighted, subnets running
+//out of addresses stand out
+func (row *NetworkRow) NotHighlighted() {
+	row.Row.NotHighlighted()
+	if row.ipsUsedFg != 0 {
+		row.IPsUsed.TextFgColor = row.ipsUsedFg
+	}
+}
+
+//ipsUsed describes the given usage of a subnet, as allocated/size and the
+//percentage in use
+func ipsUsed(u docker.SubnetUsage) string {
+	if u.Size < 0 {
+		return u.String()
+	}
+	return fmt.Sprintf("%s %.0f%%", u.String(), u.Usage())
+}
+
+//ipsUsedColor returns the color of the given usage of a subnet, none if
+//the subnet is far from being exhausted
+func ipsUsedColor(u docker.SubnetUsage) termui.Attribute {
+	switch {
+	case u.Size >= 0 && u.Usage() >= ipsUsedCritical:
+		return NotRunning
+	case u.Size >= 0 && u.Usage() >= ipsUsedWarning:
+		return termui.Attribute(ui.ColorYellow)
+	}
+	return 0
+}
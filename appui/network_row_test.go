@@ -0,0 +1,34 @@
+package appui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/network"
+)
+
+func TestNetworkRowIPsUsed(t *testing.T) {
+	containers := make(map[string]types.EndpointResource)
+	for i := 2; i < 6; i++ {
+		containers[fmt.Sprint(i)] = types.EndpointResource{IPv4Address: fmt.Sprintf("10.0.0.%d/29", i)}
+	}
+	n := types.NetworkResource{
+		IPAM:       network.IPAM{Config: []network.IPAMConfig{{Subnet: "10.0.0.0/29", Gateway: "10.0.0.1"}}},
+		Containers: containers,
+	}
+	row := NewNetworkRow(n, defaultNetworkTableHeader)
+	if row.IPsUsed.Text != "4/5 80%" {
+		t.Errorf("Unexpected addresses used: %s", row.IPsUsed.Text)
+	}
+	row.NotHighlighted()
+	if row.IPsUsed.TextFgColor == row.Subnet.TextFgColor {
+		t.Error("A subnet running out of addresses does not stand out")
+	}
+
+	row = NewNetworkRow(types.NetworkResource{}, defaultNetworkTableHeader)
+	row.NotHighlighted()
+	if row.IPsUsed.Text != "-" || row.IPsUsed.TextFgColor != row.Subnet.TextFgColor {
+		t.Errorf("Unexpected addresses used of a network without subnets: %s", row.IPsUsed.Text)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	gizaktermui "github.com/gizak/termui"
 	"github.com/moncho/dry/docker"
@@ -21,7 +22,9 @@ var networkTableHeaders = []SortableColumnHeader{
 	{`SERVICES`, SortMode(docker.SortNetworksByServiceCount)},
 	{`SCOPE`, SortMode(docker.NoSortNetworks)},
 	{`SUBNET`, SortMode(docker.SortNetworksBySubnet)},
+	{`IPS USED`, SortMode(docker.NoSortNetworks)},
 	{`GATEWAY`, SortMode(docker.NoSortNetworks)},
+	{`UNUSED FOR`, SortMode(docker.NoSortNetworks)},
 }
 
 //DockerNetworksWidget knows how render a container list
@@ -35,6 +38,8 @@ type DockerNetworksWidget struct {
 	selectedIndex        int
 	startIndex, endIndex int
 	sortMode             docker.SortMode
+	//UnusedSince, if set, is used to show for how long networks have been unused
+	UnusedSince func(id string) (time.Time, bool)
 
 	sync.RWMutex
 	mounted bool
@@ -100,6 +105,13 @@ func (s *DockerNetworksWidget) Filter(filter string) {
 	s.filterPattern = filter
 }
 
+//FilterPattern returns the filter applied to this widget
+func (s *DockerNetworksWidget) FilterPattern() string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.filterPattern
+}
+
 //Mount tells this widget to be ready for rendering
 func (s *DockerNetworksWidget) Mount() error {
 	s.Lock()
@@ -115,6 +127,7 @@ func (s *DockerNetworksWidget) Mount() error {
 	networkRows := make([]*NetworkRow, len(networks))
 	for i, network := range networks {
 		networkRows[i] = NewNetworkRow(network, s.header)
+		networkRows[i].UnusedFor.Text = unusedFor(s.UnusedSince, network.ID)
 	}
 	s.totalRows = networkRows
 	s.mounted = true
@@ -163,6 +176,21 @@ func (s *DockerNetworksWidget) Sort() {
 	}
 }
 
+//SetSortMode sets the sort mode of this widget
+func (s *DockerNetworksWidget) SetSortMode(mode docker.SortMode) {
+	s.Lock()
+	defer s.Unlock()
+	s.sortMode = mode
+	s.mounted = false
+}
+
+//SortMode returns the sort mode of this widget
+func (s *DockerNetworksWidget) SortMode() docker.SortMode {
+	s.RLock()
+	defer s.RUnlock()
+	return s.sortMode
+}
+
 //Unmount tells this widget that it will not be rendering anymore
 func (s *DockerNetworksWidget) Unmount() error {
 	s.Lock()
@@ -187,19 +215,14 @@ func (s *DockerNetworksWidget) align() {
 }
 
 func (s *DockerNetworksWidget) filterRows() {
-
-	if s.filterPattern != "" {
-		var rows []*NetworkRow
-
-		for _, row := range s.totalRows {
-			if RowFilters.ByPattern(s.filterPattern)(row) {
-				rows = append(rows, row)
-			}
+	filter := RowFilters.ByFuzzyPattern(s.filterPattern)
+	var rows []*NetworkRow
+	for _, row := range s.totalRows {
+		if filter(row) {
+			rows = append(rows, row)
 		}
-		s.filteredRows = rows
-	} else {
-		s.filteredRows = s.totalRows
 	}
+	s.filteredRows = rows
 }
 
 func (s *DockerNetworksWidget) calculateVisibleRows() {
@@ -331,7 +354,9 @@ func networkTableHeader() *termui.TableHeader {
 	header.AddFixedWidthColumn(networkTableHeaders[4].Title, 12)
 	header.AddColumn(networkTableHeaders[5].Title)
 	header.AddColumn(networkTableHeaders[6].Title)
-	header.AddColumn(networkTableHeaders[7].Title)
+	header.AddFixedWidthColumn(networkTableHeaders[7].Title, 16)
+	header.AddColumn(networkTableHeaders[8].Title)
+	header.AddFixedWidthColumn(networkTableHeaders[9].Title, 12)
 
 	return header
 }
@@ -0,0 +1,147 @@
+package appui
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	units "github.com/docker/go-units"
+	"github.com/moncho/dry/docker"
+)
+
+//noOwner is how objects without the owner label are shown
+const noOwner = "(no owner)"
+
+//OwnershipRenderer renders the resource usage of containers, images and
+//volumes by owner, the owner being the value of a label
+type OwnershipRenderer struct {
+	label      string
+	usage      []docker.OwnerUsage
+	computing  bool
+	computedAt time.Time
+	err        error
+	sync.RWMutex
+}
+
+//NewOwnershipRenderer creates an OwnershipRenderer, owners are given by the given label
+func NewOwnershipRenderer(label string) *OwnershipRenderer {
+	return &OwnershipRenderer{label: label}
+}
+
+//Label returns the label whose value is the owner
+func (r *OwnershipRenderer) Label() string {
+	r.RLock()
+	defer r.RUnlock()
+	return r.label
+}
+
+//SetLabel sets the label whose value is the owner, the usage has to be computed again
+func (r *OwnershipRenderer) SetLabel(label string) {
+	r.Lock()
+	defer r.Unlock()
+	r.label = label
+	r.usage = nil
+	r.computedAt = time.Time{}
+}
+
+//StartComputing marks the usage as being computed, it returns false if it
+//is already being computed
+func (r *OwnershipRenderer) StartComputing() bool {
+	r.Lock()
+	defer r.Unlock()
+	if r.computing {
+		return false
+	}
+	r.computing = true
+	return true
+}
+
+//DoneComputing sets the result of the computation
+func (r *OwnershipRenderer) DoneComputing(usage []docker.OwnerUsage, err error) {
+	r.Lock()
+	defer r.Unlock()
+	r.computing = false
+	r.err = err
+	if err == nil {
+		r.usage = usage
+		r.computedAt = docker.Now()
+	}
+}
+
+//Usage returns the last usage computed
+func (r *OwnershipRenderer) Usage() []docker.OwnerUsage {
+	r.RLock()
+	defer r.RUnlock()
+	return r.usage
+}
+
+//String renders the usage by owner
+func (r *OwnershipRenderer) String() string {
+	r.RLock()
+	defer r.RUnlock()
+	buffer := new(bytes.Buffer)
+	buffer.WriteString(fmt.Sprintf("<white>Usage by owner</>, given by the <white>%s</> label\n", r.label))
+	switch {
+	case r.computing:
+		buffer.WriteString("<green>Computing usage by owner...</>\n")
+	case r.err != nil:
+		buffer.WriteString(fmt.Sprintf("<red>Error computing usage by owner: %s</>\n", r.err))
+	case !r.computedAt.IsZero():
+		buffer.WriteString(fmt.Sprintf("Computed at %s\n", r.computedAt.Format("15:04:05")))
+	}
+	if r.usage == nil {
+		return buffer.String()
+	}
+	buffer.WriteString("\n")
+
+	var total docker.OwnerUsage
+	for _, u := range r.usage {
+		total.CPUSeconds += u.CPUSeconds
+		total.Memory += u.Memory
+		total.Containers += u.Containers
+		total.Running += u.Running
+		total.ContainerDisk += u.ContainerDisk
+		total.Images += u.Images
+		total.ImageDisk += u.ImageDisk
+		total.Volumes += u.Volumes
+		total.VolumeDisk += u.VolumeDisk
+	}
+	t := tabwriter.NewWriter(buffer, 12, 0, 2, ' ', 0)
+	fmt.Fprintln(t, " OWNER\tCONTAINERS\tCPU TIME\tMEMORY\tCONTAINER DISK\tIMAGES\tIMAGE DISK\tVOLUMES\tVOLUME DISK\tTOTAL DISK")
+	for _, u := range r.usage {
+		owner := u.Owner
+		if owner == "" {
+			owner = noOwner
+		}
+		fmt.Fprintf(t, " %s\t%s\n", owner, ownerUsageRow(u, total))
+	}
+	fmt.Fprintf(t, " %s\t%s\n", "TOTAL", ownerUsageRow(total, total))
+	t.Flush()
+	buffer.WriteString("\n CPU time and memory are those of the running containers, CPU time since they started.\n")
+	buffer.WriteString(" Image disk only counts the layers an image does not share with other images.\n")
+	return buffer.String()
+}
+
+//ownerUsageRow renders the columns of the given usage, CPU time, memory and
+//total disk are shown with their share of the given total
+func ownerUsageRow(u, total docker.OwnerUsage) string {
+	return fmt.Sprintf("%d (%d running)\t%s (%s)\t%s (%s)\t%s\t%d\t%s\t%d\t%s\t%s (%s)",
+		u.Containers, u.Running,
+		(time.Duration(u.CPUSeconds) * time.Second).String(), share(u.CPUSeconds, total.CPUSeconds),
+		units.BytesSize(u.Memory), share(u.Memory, total.Memory),
+		units.HumanSize(float64(u.ContainerDisk)),
+		u.Images, units.HumanSize(float64(u.ImageDisk)),
+		u.Volumes, units.HumanSize(float64(u.VolumeDisk)),
+		units.HumanSize(float64(u.Disk())), share(float64(u.Disk()), float64(total.Disk())))
+}
+
+//share returns the percentage the given value is of the given total
+func share(value, total float64) string {
+	if total <= 0 {
+		return "0%"
+	}
+	return strconv.FormatFloat(value*100/total, 'f', 1, 64) + "%"
+}
@@ -0,0 +1,39 @@
+package appui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/moncho/dry/docker"
+)
+
+func TestOwnershipRenderer(t *testing.T) {
+	r := NewOwnershipRenderer("team")
+	if !r.StartComputing() || r.StartComputing() {
+		t.Error("Usage by owner computed twice at the same time")
+	}
+	if !strings.Contains(r.String(), "Computing") {
+		t.Errorf("Computation not shown: %s", r.String())
+	}
+	r.DoneComputing([]docker.OwnerUsage{
+		{Owner: "backend", Containers: 2, Running: 1, CPUSeconds: 90, Memory: 1024},
+		{Owner: "", Containers: 1, Running: 1, CPUSeconds: 30, Memory: 1024},
+	}, nil)
+	rendered := r.String()
+	for _, expected := range []string{"backend", noOwner, "TOTAL", "1m30s (75.0%)", "2 (1 running)"} {
+		if !strings.Contains(rendered, expected) {
+			t.Errorf("%q not rendered: %s", expected, rendered)
+		}
+	}
+
+	r.StartComputing()
+	r.DoneComputing(nil, errors.New("daemon gone"))
+	if rendered := r.String(); !strings.Contains(rendered, "daemon gone") || !strings.Contains(rendered, "backend") {
+		t.Errorf("Error computing the usage not rendered over the last usage: %s", rendered)
+	}
+	r.SetLabel("owner")
+	if r.Usage() != nil || r.Label() != "owner" {
+		t.Error("Usage kept after changing the owner label")
+	}
+}
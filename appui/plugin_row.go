@@ -0,0 +1,57 @@
+package appui
+
+import (
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	termui "github.com/gizak/termui"
+	"github.com/moncho/dry/docker"
+	drytermui "github.com/moncho/dry/ui/termui"
+)
+
+//PluginRow is a Grid row showing information about a Docker plugin
+type PluginRow struct {
+	plugin      *types.Plugin
+	Name        *drytermui.ParColumn
+	Enabled     *drytermui.ParColumn
+	Types       *drytermui.ParColumn
+	Description *drytermui.ParColumn
+	Row
+}
+
+//NewPluginRow creates a new PluginRow widget
+func NewPluginRow(plugin *types.Plugin, table drytermui.Table) *PluginRow {
+	enabled := "false"
+	if plugin.Enabled {
+		enabled = "true"
+	}
+	row := &PluginRow{
+		plugin:      plugin,
+		Name:        drytermui.NewThemedParColumn(DryTheme, plugin.Name),
+		Enabled:     drytermui.NewThemedParColumn(DryTheme, enabled),
+		Types:       drytermui.NewThemedParColumn(DryTheme, strings.Join(docker.PluginTypes(plugin), ", ")),
+		Description: drytermui.NewThemedParColumn(DryTheme, plugin.Config.Description),
+	}
+	row.Height = 1
+	row.Table = table
+	//Columns are rendered following the slice order
+	row.Columns = []termui.GridBufferer{
+		row.Name,
+		row.Enabled,
+		row.Types,
+		row.Description,
+	}
+	row.ParColumns = []*drytermui.ParColumn{
+		row.Name,
+		row.Enabled,
+		row.Types,
+		row.Description,
+	}
+
+	return row
+}
+
+//ColumnsForFilter returns the columns that are used to filter
+func (row *PluginRow) ColumnsForFilter() []*drytermui.ParColumn {
+	return []*drytermui.ParColumn{row.Name, row.Types, row.Description}
+}
@@ -0,0 +1,328 @@
+package appui
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+	"github.com/moncho/dry/ui/termui"
+
+	gizaktermui "github.com/gizak/termui"
+)
+
+type pluginsService interface {
+	Plugins() ([]*types.Plugin, error)
+}
+
+const (
+	byPluginName SortMode = iota + 1
+	byPluginEnabled
+	byPluginTypes
+)
+
+var pluginsTableHeaders = []SortableColumnHeader{
+	{``, 0},
+	{`NAME`, byPluginName},
+	{`ENABLED`, byPluginEnabled},
+	{`TYPES`, byPluginTypes},
+	{`DESCRIPTION`, 0},
+}
+
+//PluginsWidget shows information about the plugins installed on the Docker host
+type PluginsWidget struct {
+	service              pluginsService
+	totalRows            []*PluginRow
+	filteredRows         []*PluginRow
+	header               *termui.TableHeader
+	filterPattern        string
+	selectedIndex        int
+	startIndex, endIndex int
+	sortBy               SortMode
+	screen               Screen
+
+	sync.RWMutex
+	mounted bool
+}
+
+//NewPluginsWidget creates a PluginsWidget
+func NewPluginsWidget(service pluginsService, s Screen) *PluginsWidget {
+	return &PluginsWidget{
+		header:  pluginsTableHeader(),
+		service: service,
+		screen:  s,
+		sortBy:  byPluginName}
+}
+
+//Buffer returns the content of this widget as a termui.Buffer
+func (s *PluginsWidget) Buffer() gizaktermui.Buffer {
+	s.Lock()
+	defer s.Unlock()
+	buf := gizaktermui.NewBuffer()
+
+	if !s.mounted {
+		return buf
+	}
+	s.prepareForRendering()
+	y := s.screen.Bounds().Min.Y
+	widgetHeader := NewWidgetHeader()
+	widgetHeader.HeaderEntry("Plugins", strconv.Itoa(s.RowCount()))
+	if s.filterPattern != "" {
+		widgetHeader.HeaderEntry("Active filter", s.filterPattern)
+	}
+	widgetHeader.Y = y
+	buf.Merge(widgetHeader.Buffer())
+	y += widgetHeader.GetHeight()
+	//Empty line between the header and the rest of the content
+	y++
+	s.header.SetY(y)
+	s.updateTableHeader()
+	buf.Merge(s.header.Buffer())
+
+	y += s.header.GetHeight()
+
+	selected := s.selectedIndex - s.startIndex
+
+	for i, plugin := range s.visibleRows() {
+		plugin.SetY(y)
+		y += plugin.GetHeight()
+		if i != selected {
+			plugin.NotHighlighted()
+		} else {
+			plugin.Highlighted()
+		}
+		buf.Merge(plugin.Buffer())
+	}
+
+	return buf
+}
+
+//Filter applies the given filter to the plugin list
+func (s *PluginsWidget) Filter(filter string) {
+	s.Lock()
+	defer s.Unlock()
+	s.filterPattern = filter
+}
+
+//FilterPattern returns the filter applied to this widget
+func (s *PluginsWidget) FilterPattern() string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.filterPattern
+}
+
+//Mount tells this widget to be ready for rendering
+func (s *PluginsWidget) Mount() error {
+	s.Lock()
+	defer s.Unlock()
+	if s.mounted {
+		s.align()
+		return nil
+	}
+	s.mounted = true
+	var rows []*PluginRow
+	plugins, err := s.service.Plugins()
+	if err != nil {
+		return fmt.Errorf("could not retrieve plugins: %s", err.Error())
+	}
+	for _, p := range plugins {
+		rows = append(rows, NewPluginRow(p, s.header))
+	}
+	s.totalRows = rows
+	s.align()
+	return nil
+}
+
+//Name returns this widget name
+func (s *PluginsWidget) Name() string {
+	return "PluginsWidget"
+}
+
+//OnEvent runs the given command on the name of the selected plugin
+func (s *PluginsWidget) OnEvent(event EventCommand) error {
+	if s.RowCount() <= 0 {
+		return errors.New("The plugin list is empty")
+	} else if s.filteredRows[s.selectedIndex] == nil {
+		return fmt.Errorf("The plugin list does not have an element on pos %d", s.selectedIndex)
+	}
+	return event(s.filteredRows[s.selectedIndex].plugin.Name)
+}
+
+//Selected returns the selected plugin, nil if there is none
+func (s *PluginsWidget) Selected() *types.Plugin {
+	s.RLock()
+	defer s.RUnlock()
+	if s.selectedIndex < 0 || s.selectedIndex >= s.RowCount() {
+		return nil
+	}
+	return s.filteredRows[s.selectedIndex].plugin
+}
+
+//RowCount returns the number of rows of this widget.
+func (s *PluginsWidget) RowCount() int {
+	return len(s.filteredRows)
+}
+
+//Sort rotates to the next sort mode.
+func (s *PluginsWidget) Sort() {
+	s.Lock()
+	defer s.Unlock()
+	if s.sortBy == byPluginTypes {
+		s.sortBy = byPluginName
+	} else {
+		s.sortBy++
+	}
+}
+
+// Unmount this widget
+func (s *PluginsWidget) Unmount() error {
+	s.Lock()
+	defer s.Unlock()
+	s.mounted = false
+	return nil
+}
+
+//Align aligns rows
+func (s *PluginsWidget) align() {
+	x := s.screen.Bounds().Min.X
+	width := s.screen.Bounds().Dx()
+
+	s.header.SetWidth(width)
+	s.header.SetX(x)
+
+	for _, plugin := range s.totalRows {
+		plugin.SetX(x)
+		plugin.SetWidth(width)
+	}
+}
+
+func (s *PluginsWidget) filterRows() {
+	filter := RowFilters.ByFuzzyPattern(s.filterPattern)
+	var rows []*PluginRow
+	for _, row := range s.totalRows {
+		if filter(row) {
+			rows = append(rows, row)
+		}
+	}
+	s.filteredRows = rows
+}
+
+// prepareForRendering sets the internal state of this widget so it is ready for
+// rendering(i.e. Buffer()).
+func (s *PluginsWidget) prepareForRendering() {
+	s.sortRows()
+	s.filterRows()
+	s.screen.Cursor().Max(s.RowCount() - 1)
+
+	index := s.screen.Cursor().Position()
+	if index < 0 {
+		index = 0
+	} else if index > s.RowCount() {
+		index = s.RowCount() - 1
+	}
+	s.selectedIndex = index
+	s.calculateVisibleRows()
+}
+
+func (s *PluginsWidget) updateTableHeader() {
+	sortMode := s.sortBy
+
+	for _, c := range s.header.Columns {
+		colTitle := c.Text
+		var header SortableColumnHeader
+		if strings.Contains(colTitle, DownArrow) {
+			colTitle = colTitle[DownArrowLength:]
+		}
+		for _, h := range pluginsTableHeaders {
+			if colTitle == h.Title {
+				header = h
+				break
+			}
+		}
+		if header.Mode == sortMode {
+			c.Text = DownArrow + colTitle
+		} else {
+			c.Text = colTitle
+		}
+	}
+}
+
+func (s *PluginsWidget) sortRows() {
+	rows := s.totalRows
+	mode := s.sortBy
+	if mode == 0 {
+		return
+	}
+	var sortFunc func(i, j int) bool
+
+	switch mode {
+	case byPluginName:
+		sortFunc = func(i, j int) bool {
+			return rows[i].Name.Text < rows[j].Name.Text
+		}
+	case byPluginEnabled:
+		sortFunc = func(i, j int) bool {
+			return rows[i].plugin.Enabled && !rows[j].plugin.Enabled
+		}
+	case byPluginTypes:
+		sortFunc = func(i, j int) bool {
+			return rows[i].Types.Text < rows[j].Types.Text
+		}
+	}
+	sort.SliceStable(rows, sortFunc)
+}
+
+func (s *PluginsWidget) visibleRows() []*PluginRow {
+	return s.filteredRows[s.startIndex:s.endIndex]
+}
+
+func (s *PluginsWidget) calculateVisibleRows() {
+
+	height := s.screen.Bounds().Dy() - widgetHeaderLength
+
+	count := s.RowCount()
+	//no screen
+	if height < 0 || count == 0 {
+		s.startIndex = 0
+		s.endIndex = 0
+		return
+	}
+	selected := s.selectedIndex
+	//everything fits
+	if count <= height {
+		s.startIndex = 0
+		s.endIndex = count
+		return
+	}
+	//at the the start
+	if selected == 0 {
+		s.startIndex = 0
+		s.endIndex = height
+	} else if selected >= count-1 { //at the end
+		s.startIndex = count - height
+		s.endIndex = count
+	} else if selected == s.endIndex { //scroll down by one
+		s.startIndex++
+		s.endIndex++
+	} else if selected <= s.startIndex { //scroll up by one
+		s.startIndex--
+		s.endIndex--
+	} else if selected > s.endIndex { // scroll
+		s.startIndex = selected - height
+		s.endIndex = selected
+	}
+}
+
+func pluginsTableHeader() *termui.TableHeader {
+
+	header := termui.NewHeader(DryTheme)
+	header.ColumnSpacing = DefaultColumnSpacing
+	header.AddColumn(pluginsTableHeaders[1].Title)
+	header.AddFixedWidthColumn(pluginsTableHeaders[2].Title, 8)
+	header.AddColumn(pluginsTableHeaders[3].Title)
+	header.AddColumn(pluginsTableHeaders[4].Title)
+	return header
+}
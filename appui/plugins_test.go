@@ -0,0 +1,55 @@
+package appui
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/moncho/dry/ui"
+)
+
+type testPluginsService struct {
+	plugins []*types.Plugin
+}
+
+func (p testPluginsService) Plugins() ([]*types.Plugin, error) {
+	return p.plugins, nil
+}
+
+func TestPluginsWidget(t *testing.T) {
+	volumeDriver := &types.Plugin{Name: "vieux/sshfs:latest", Enabled: false}
+	volumeDriver.Config.Interface.Types = []types.PluginInterfaceType{{Capability: "volumedriver"}}
+	logDriver := &types.Plugin{Name: "grafana/loki-docker-driver:latest", Enabled: true}
+	logDriver.Config.Interface.Types = []types.PluginInterfaceType{{Capability: "logdriver"}}
+
+	w := NewPluginsWidget(
+		testPluginsService{plugins: []*types.Plugin{volumeDriver, logDriver}},
+		&testScreen{cursor: &ui.Cursor{}, x1: 100, y1: 10})
+	if err := w.Mount(); err != nil {
+		t.Fatalf("Unexpected error mounting the widget: %s", err)
+	}
+	w.Buffer()
+	if w.RowCount() != 2 {
+		t.Errorf("Unexpected number of rows, got %d, want 2", w.RowCount())
+	}
+	if selected := w.Selected(); selected != logDriver {
+		t.Errorf("Plugins are expected to be sorted by name, selected %v", selected)
+	}
+
+	w.Sort()
+	w.Buffer()
+	if selected := w.Selected(); selected != logDriver {
+		t.Errorf("Enabled plugins are expected to be first, selected %v", selected)
+	}
+
+	w.Sort()
+	w.Buffer()
+	if selected := w.Selected(); selected != logDriver {
+		t.Errorf("Plugins are expected to be sorted by type, selected %v", selected)
+	}
+
+	w.Filter("sshfs")
+	w.Buffer()
+	if w.RowCount() != 1 || w.Selected() != volumeDriver {
+		t.Errorf("Unexpected filtered rows, got %d rows, selected %v", w.RowCount(), w.Selected())
+	}
+}
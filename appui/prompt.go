@@ -13,12 +13,25 @@ type Prompt struct {
 
 //NewPrompt creates a new Prompt with the given title
 func NewPrompt(title string) *Prompt {
+	return NewPromptWithText(title, "")
+}
+
+//NewPromptWithText creates a new Prompt with the given title and whose
+//input is initialized with the given text
+func NewPromptWithText(title, text string) *Prompt {
 	w := &Prompt{
-		TextInput: *termui.NewTextInput(ui.ActiveScreen, ""),
+		TextInput: *termui.NewTextInput(ui.ActiveScreen, text),
 	}
+	screenWidth := ui.ActiveScreen.Dimensions().Width
 	w.Height = 3
 	w.Width = len(title) + 4
-	w.X = (ui.ActiveScreen.Dimensions().Width - w.Width) / 2
+	if len(text)+4 > w.Width {
+		w.Width = len(text) + 4
+	}
+	if w.Width > screenWidth {
+		w.Width = screenWidth
+	}
+	w.X = (screenWidth - w.Width) / 2
 	w.Y = ui.ActiveScreen.Dimensions().Height / 2
 	w.Bg = gtermui.Attribute(DryTheme.Bg)
 	w.TextBgColor = gtermui.Attribute(DryTheme.Bg)
@@ -29,6 +42,14 @@ func NewPrompt(title string) *Prompt {
 	return w
 }
 
+//NewPasswordPrompt creates a new Prompt with the given title that does not
+//show what is typed on it
+func NewPasswordPrompt(title string) *Prompt {
+	w := NewPromptWithText(title, "")
+	w.Mask = '*'
+	return w
+}
+
 //Mount callback
 func (w *Prompt) Mount() error {
 	return nil
@@ -42,6 +42,13 @@ func (row *Row) Buffer() termui.Buffer {
 	return buf
 }
 
+//Marked marks this row as being part of a selection, the background color is kept
+func (row *Row) Marked() {
+	for _, c := range row.ParColumns {
+		c.TextFgColor = termui.Attribute(DryTheme.Selected)
+	}
+}
+
 func (row *Row) changeTextColor(fg, bg termui.Attribute) {
 	for _, c := range row.ParColumns {
 		c.TextFgColor = fg
@@ -0,0 +1,132 @@
+package appui
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gizak/termui"
+	"github.com/moncho/dry/ui"
+)
+
+//changeHighlightDuration is how long rows that changed since the previous
+//refresh are highlighted
+const changeHighlightDuration = 3 * time.Second
+
+const (
+	//addedRowColor is the color of new rows and of rows that started running
+	addedRowColor = termui.Attribute(ui.Color82)
+	//removedRowColor is the color of removed rows and of rows that stopped running
+	removedRowColor = termui.Attribute(ui.Color196)
+	//changedRowColor is the color of rows whose state changed otherwise
+	changedRowColor = termui.Attribute(ui.Color220)
+)
+
+//rowChange is how a row changed since the previous refresh
+type rowChange int
+
+const (
+	rowUnchanged rowChange = iota
+	rowAdded
+	rowStarted
+	rowStopped
+	rowChanged
+	rowRemoved
+)
+
+//color returns the color rows with this change are highlighted with
+func (c rowChange) color() (termui.Attribute, bool) {
+	switch c {
+	case rowAdded, rowStarted:
+		return addedRowColor, true
+	case rowStopped, rowRemoved:
+		return removedRowColor, true
+	case rowChanged:
+		return changedRowColor, true
+	}
+	return 0, false
+}
+
+//rowState is the state of a row, as an id, a state and whether it is running
+type rowState struct {
+	state   string
+	running bool
+}
+
+//rowChanges tracks the changes on the rows of a list between refreshes,
+//changes are kept for changeHighlightDuration. The zero value is ready to use.
+type rowChanges struct {
+	states  map[string]rowState
+	changes map[string]rowChange
+	seen    map[string]time.Time
+	sync.Mutex
+}
+
+//update compares the given states, by row id, with the ones of the previous
+//update, returns whether any row changed and the ids of the rows removed
+//since then. Nothing changes on the first update, or the first after a reset.
+func (c *rowChanges) update(states map[string]rowState, now time.Time) (bool, []string) {
+	c.Lock()
+	defer c.Unlock()
+	previous := c.states
+	c.states = states
+	if previous == nil {
+		return false, nil
+	}
+	if c.changes == nil {
+		c.changes = make(map[string]rowChange)
+		c.seen = make(map[string]time.Time)
+	}
+	changed := false
+	track := func(id string, change rowChange) {
+		changed = true
+		c.changes[id] = change
+		c.seen[id] = now
+	}
+	for id, state := range states {
+		before, ok := previous[id]
+		switch {
+		case !ok:
+			track(id, rowAdded)
+		case before.running != state.running && state.running:
+			track(id, rowStarted)
+		case before.running != state.running:
+			track(id, rowStopped)
+		case before.state != state.state:
+			track(id, rowChanged)
+		}
+	}
+	var removed []string
+	for id := range previous {
+		if _, ok := states[id]; !ok {
+			track(id, rowRemoved)
+			removed = append(removed, id)
+		}
+	}
+	return changed, removed
+}
+
+//change returns how the row with the given id changed, changes seen more
+//than changeHighlightDuration ago are forgotten
+func (c *rowChanges) change(id string, now time.Time) rowChange {
+	c.Lock()
+	defer c.Unlock()
+	change, ok := c.changes[id]
+	if !ok {
+		return rowUnchanged
+	}
+	if now.Sub(c.seen[id]) >= changeHighlightDuration {
+		delete(c.changes, id)
+		delete(c.seen, id)
+		return rowUnchanged
+	}
+	return change
+}
+
+//reset forgets the states and changes seen, the next update is taken as the first one
+func (c *rowChanges) reset() {
+	c.Lock()
+	defer c.Unlock()
+	c.states = nil
+	c.changes = nil
+	c.seen = nil
+}
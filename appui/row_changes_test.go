@@ -0,0 +1,62 @@
+package appui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRowChanges(t *testing.T) {
+	c := &rowChanges{}
+	now := time.Now()
+	changed, removed := c.update(map[string]rowState{
+		"web":   {state: "running", running: true},
+		"db":    {state: "running", running: true},
+		"cache": {state: "exited"},
+		"batch": {state: "running", running: true},
+	}, now)
+	if changed || len(removed) != 0 || c.change("web", now) != rowUnchanged {
+		t.Fatal("Rows changed on the first update")
+	}
+
+	changed, removed = c.update(map[string]rowState{
+		"web":    {state: "running", running: true},
+		"db":     {state: "exited"},
+		"cache":  {state: "running", running: true},
+		"worker": {state: "running", running: true},
+		"batch":  {state: "paused", running: true},
+	}, now)
+	if !changed {
+		t.Error("Changes were not reported")
+	}
+	expected := map[string]rowChange{
+		"web":    rowUnchanged,
+		"db":     rowStopped,
+		"cache":  rowStarted,
+		"worker": rowAdded,
+		"batch":  rowChanged,
+	}
+	for id, change := range expected {
+		if got := c.change(id, now); got != change {
+			t.Errorf("Row %s change: got %d, expected %d", id, got, change)
+		}
+	}
+	if len(removed) != 0 {
+		t.Errorf("Unexpected removed rows: %v", removed)
+	}
+
+	changed, removed = c.update(map[string]rowState{
+		"web": {state: "running", running: true},
+	}, now.Add(time.Second))
+	if !changed || len(removed) != 4 || c.change("worker", now.Add(time.Second)) != rowRemoved {
+		t.Errorf("Removed rows were not tracked: %v", removed)
+	}
+	later := now.Add(time.Second + changeHighlightDuration)
+	if c.change("worker", later) != rowUnchanged {
+		t.Error("Changes are highlighted for too long")
+	}
+
+	c.reset()
+	if changed, _ := c.update(map[string]rowState{}, later); changed {
+		t.Error("Rows changed on the first update after a reset")
+	}
+}
@@ -2,7 +2,9 @@ package appui
 
 import (
 	"strings"
+	"unicode"
 
+	gtermui "github.com/gizak/termui"
 	"github.com/moncho/dry/ui/termui"
 )
 
@@ -29,3 +31,72 @@ func (rf RowFilter) ByPattern(pattern string) RowFilter {
 		return false
 	}
 }
+
+//ByFuzzyPattern filters rows with a column that fuzzy matches the given
+//pattern, highlighting on each column the characters that match it. An empty
+//pattern matches every row and removes any highlighting.
+func (rf RowFilter) ByFuzzyPattern(pattern string) RowFilter {
+	return func(row FilterableRow) bool {
+		matched := pattern == ""
+		fg := gtermui.Attribute(DryTheme.CurrentMatch) | gtermui.AttrBold
+		for _, column := range row.ColumnsForFilter() {
+			positions, ok := fuzzyMatch(pattern, column.Text)
+			column.Highlight(positions, fg)
+			matched = matched || ok
+		}
+		return matched
+	}
+}
+
+//fuzzyMatch returns true if the characters of the given pattern are found,
+//in the same order, on the given text ignoring case. The positions (rune
+//indexes) of the matching characters are also returned, the shortest
+//match that starts the latest is preferred to keep the matched characters
+//as close as possible.
+func fuzzyMatch(pattern, text string) ([]int, bool) {
+	p := []rune(strings.ToLower(pattern))
+	if len(p) == 0 {
+		return nil, false
+	}
+	t := []rune(text)
+	for i := range t {
+		t[i] = unicode.ToLower(t[i])
+	}
+	var best []int
+	for start := range t {
+		if t[start] != p[0] {
+			continue
+		}
+		positions := matchFrom(p, t, start)
+		if positions == nil {
+			//no later start can match either
+			break
+		}
+		if best == nil || span(positions) <= span(best) {
+			best = positions
+		}
+	}
+	return best, best != nil
+}
+
+//matchFrom returns the positions on the given text of the characters of the
+//given pattern, matched greedily from the given position, or nil if
+//they are not found
+func matchFrom(pattern, text []rune, start int) []int {
+	positions := make([]int, 0, len(pattern))
+	j := 0
+	for i := start; i < len(text) && j < len(pattern); i++ {
+		if text[i] == pattern[j] {
+			positions = append(positions, i)
+			j++
+		}
+	}
+	if j < len(pattern) {
+		return nil
+	}
+	return positions
+}
+
+func span(positions []int) int {
+	return positions[len(positions)-1] - positions[0]
+}
@@ -1,6 +1,7 @@
 package appui
 
 import (
+	"reflect"
 	"testing"
 
 	gizak "github.com/gizak/termui"
@@ -57,6 +58,52 @@ func TestRowFilter_ByPattern(t *testing.T) {
 	}
 }
 
+func Test_fuzzyMatch(t *testing.T) {
+	tests := []struct {
+		pattern   string
+		text      string
+		want      []int
+		wantMatch bool
+	}{
+		{"", "dry", nil, false},
+		{"dry", "dry", []int{0, 1, 2}, true},
+		{"DRY", "moncho/dry", []int{7, 8, 9}, true},
+		{"mdr", "moncho/dry", []int{0, 7, 8}, true},
+		{"ry", "rabbitmq-dry", []int{10, 11}, true},
+		{"yrd", "dry", nil, false},
+		{"nope", "yes", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+"->"+tt.text, func(t *testing.T) {
+			got, ok := fuzzyMatch(tt.pattern, tt.text)
+			if ok != tt.wantMatch {
+				t.Errorf("fuzzyMatch() match = %v, want %v", ok, tt.wantMatch)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fuzzyMatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRowFilter_ByFuzzyPattern(t *testing.T) {
+	row := filterable{
+		columns: []*termui.ParColumn{
+			termui.NewParColumn("moncho/dry"),
+			termui.NewParColumn("redis"),
+		},
+	}
+	if !RowFilters.ByFuzzyPattern("mdr")(row) {
+		t.Error("Row was expected to match")
+	}
+	if RowFilters.ByFuzzyPattern("xyz")(row) {
+		t.Error("Row was not expected to match")
+	}
+	if !RowFilters.ByFuzzyPattern("")(row) {
+		t.Error("Every row is expected to match an empty pattern")
+	}
+}
+
 type filterable struct {
 	columns []*termui.ParColumn
 }
@@ -47,11 +47,14 @@ func (w *ImageRunWidget) Name() string {
 	return "ImageRunWidget." + w.image.ID
 }
 
+//widgetTitle shows the options the widget takes, as docker run does, around
+//the image to run
 func widgetTitle(image *types.ImageSummary) string {
+	name := "<none>"
 	if len(image.RepoTags) > 0 {
-		return " docker run " + image.RepoTags[0]
+		name = image.RepoTags[0]
 	} else if len(image.RepoDigests) > 0 {
-		return " docker run " + image.RepoDigests[0]
+		name = image.RepoDigests[0]
 	}
-	return " docker run <none>"
+	return " docker run [--name NAME] [-p HOST:CONTAINER] [-e KEY=VALUE] [-d|-it] " + name + " [COMMAND] "
 }
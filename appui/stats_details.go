@@ -0,0 +1,114 @@
+package appui
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	units "github.com/docker/go-units"
+	"github.com/gizak/termui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+)
+
+//coresPerLine is the number of cores whose usage is shown on each line
+const coresPerLine = 8
+
+//NewContainerStatsDetails creates termui bufferer showing the breakdown of the
+//given container stats, returns the bufferer and the number of lines it uses
+func NewContainerStatsDetails(stats *docker.Stats, x, y, height, width int) (termui.Bufferer, int) {
+	if stats == nil || stats.Stats == nil {
+		return ui.NewPar("", DryTheme), 0
+	}
+	details := docker.NewStatsDetails(stats.Stats)
+	buf := bytes.NewBufferString("")
+	w := tabwriter.NewWriter(buf, 12, 1, 3, ' ', 0)
+	lines := 1 // top border
+
+	title := func(s string) {
+		fmt.Fprintf(w, "[%s](fg-red)\n", s)
+		lines++
+	}
+	row := func(columns ...string) {
+		fmt.Fprintf(w, "[%s](fg-white)\n", strings.Join(columns, "\t"))
+		lines++
+	}
+
+	if len(details.CPUPerCore) > 0 {
+		title("CPU PER CORE")
+		var cores []string
+		for i, percent := range details.CPUPerCore {
+			cores = append(cores, fmt.Sprintf("cpu%d %.2f%%", i, percent))
+			if len(cores) == coresPerLine || i == len(details.CPUPerCore)-1 {
+				row(cores...)
+				cores = nil
+			}
+		}
+		fmt.Fprintln(w)
+		lines++
+	}
+
+	title(strings.Join([]string{"MEM USAGE", "CACHE", "RSS", "MAX USAGE", "LIMIT", "PIDS"}, "\t"))
+	row(
+		units.BytesSize(float64(details.MemoryUsage)),
+		units.BytesSize(float64(details.MemoryCache)),
+		units.BytesSize(float64(details.MemoryRSS)),
+		units.BytesSize(float64(details.MemoryMaxUsage)),
+		units.BytesSize(float64(details.MemoryLimit)),
+		pidsUsage(details))
+
+	if len(details.Networks) > 0 {
+		fmt.Fprintln(w)
+		lines++
+		title(strings.Join([]string{
+			"INTERFACE", "RX BYTES", "RX PACKETS", "RX ERRORS", "RX DROPPED",
+			"TX BYTES", "TX PACKETS", "TX ERRORS", "TX DROPPED"}, "\t"))
+		for _, n := range details.Networks {
+			row(n.Name,
+				units.BytesSize(float64(n.RxBytes)),
+				fmt.Sprint(n.RxPackets),
+				fmt.Sprint(n.RxErrors),
+				fmt.Sprint(n.RxDropped),
+				units.BytesSize(float64(n.TxBytes)),
+				fmt.Sprint(n.TxPackets),
+				fmt.Sprint(n.TxErrors),
+				fmt.Sprint(n.TxDropped))
+		}
+	}
+
+	if len(details.BlockIO) > 0 {
+		fmt.Fprintln(w)
+		lines++
+		title(strings.Join([]string{"DEVICE", "READ", "WRITE"}, "\t"))
+		for _, d := range details.BlockIO {
+			row(d.Device(),
+				units.BytesSize(float64(d.Read)),
+				units.BytesSize(float64(d.Write)))
+		}
+	}
+	w.Flush()
+
+	p := ui.NewPar(buf.String(), DryTheme)
+	p.X = x
+	p.Y = y
+	p.Width = width
+	p.BorderLabel = " STATS "
+	p.Border = true
+	p.BorderBottom = false
+	p.BorderLeft = false
+	p.BorderRight = false
+	p.BorderTop = true
+	if height < lines {
+		lines = height
+	}
+	p.Height = lines
+	return p, lines
+}
+
+func pidsUsage(details *docker.StatsDetails) string {
+	if details.PidsLimit == 0 {
+		return fmt.Sprint(details.PidsCurrent)
+	}
+	return fmt.Sprintf("%d / %d", details.PidsCurrent, details.PidsLimit)
+}
@@ -0,0 +1,141 @@
+package appui
+
+import (
+	"sync"
+	"time"
+
+	"github.com/moncho/dry/docker"
+	drytermui "github.com/moncho/dry/ui/termui"
+)
+
+//maxMetricsSamples is the number of samples kept by a StatsHistory, stats
+//are sent every second so it is about an hour
+const maxMetricsSamples = 3600
+
+//MetricsSample is a sample of the stats of a container
+type MetricsSample struct {
+	Time             time.Time `json:"time"`
+	CPUPercentage    float64   `json:"cpu_percentage"`
+	Memory           float64   `json:"memory"`
+	MemoryLimit      float64   `json:"memory_limit"`
+	MemoryPercentage float64   `json:"memory_percentage"`
+	NetworkRx        float64   `json:"network_rx"`
+	NetworkTx        float64   `json:"network_tx"`
+	BlockRead        float64   `json:"block_read"`
+	BlockWrite       float64   `json:"block_write"`
+	Pids             uint64    `json:"pids"`
+}
+
+//StatsHistory keeps the recent CPU, memory and network usage of a container
+//as sparklines, so trends are visible at a glance. It outlives the rows
+//showing it, so the history is kept while moving between views.
+type StatsHistory struct {
+	CPU    *drytermui.SparklineColumn
+	Memory *drytermui.SparklineColumn
+	//Net shows the network rate (rx + tx) relative to the highest rate seen
+	Net *drytermui.SparklineColumn
+
+	netTotal float64
+	netPeak  float64
+	sampled  bool
+	samples  []MetricsSample
+	sync.Mutex
+}
+
+//NewStatsHistory creates an empty StatsHistory
+func NewStatsHistory() *StatsHistory {
+	return &StatsHistory{
+		CPU:    drytermui.NewThemedSparklineColumn(DryTheme),
+		Memory: drytermui.NewThemedSparklineColumn(DryTheme),
+		Net:    drytermui.NewThemedSparklineColumn(DryTheme),
+	}
+}
+
+//Add adds the given stats to the history
+func (h *StatsHistory) Add(stat *docker.Stats) {
+	h.Lock()
+	defer h.Unlock()
+	h.CPU.Add(int(stat.CPUPercentage))
+	h.Memory.Add(int(stat.MemoryPercentage))
+	h.addNet(stat.NetworkRx + stat.NetworkTx)
+	h.addSample(stat)
+}
+
+func (h *StatsHistory) addSample(stat *docker.Stats) {
+	sample := MetricsSample{
+		Time:             docker.Now(),
+		CPUPercentage:    stat.CPUPercentage,
+		Memory:           stat.Memory,
+		MemoryLimit:      stat.MemoryLimit,
+		MemoryPercentage: stat.MemoryPercentage,
+		NetworkRx:        stat.NetworkRx,
+		NetworkTx:        stat.NetworkTx,
+		BlockRead:        stat.BlockRead,
+		BlockWrite:       stat.BlockWrite,
+		Pids:             stat.PidsCurrent,
+	}
+	if stat.Stats != nil && !stat.Stats.Read.IsZero() {
+		sample.Time = stat.Stats.Read
+	}
+	if len(h.samples) == maxMetricsSamples {
+		h.samples = h.samples[1:]
+	}
+	h.samples = append(h.samples, sample)
+}
+
+//Samples returns the samples taken since the given time, if the given time
+//is zero only the latest sample is returned
+func (h *StatsHistory) Samples(since time.Time) []MetricsSample {
+	h.Lock()
+	defer h.Unlock()
+	if len(h.samples) == 0 {
+		return nil
+	}
+	if since.IsZero() {
+		return []MetricsSample{h.samples[len(h.samples)-1]}
+	}
+	var samples []MetricsSample
+	for _, sample := range h.samples {
+		if !sample.Time.Before(since) {
+			samples = append(samples, sample)
+		}
+	}
+	return samples
+}
+
+//addNet adds the network rate since the previous sample, network stats
+//are totals since the container started
+func (h *StatsHistory) addNet(total float64) {
+	if !h.sampled {
+		h.sampled = true
+		h.netTotal = total
+		return
+	}
+	rate := total - h.netTotal
+	h.netTotal = total
+	//Totals start again from zero if the container restarts
+	if rate < 0 {
+		rate = 0
+	}
+	if rate > h.netPeak {
+		h.netPeak = rate
+	}
+	percent := 0
+	if h.netPeak > 0 {
+		percent = int(rate * 100 / h.netPeak)
+	}
+	h.Net.Add(percent)
+}
+
+//Reset removes every sample from the history
+func (h *StatsHistory) Reset() {
+	h.Lock()
+	defer h.Unlock()
+	h.CPU.Reset()
+	h.Memory.Reset()
+	h.Net.Reset()
+	h.netTotal = 0
+	h.netPeak = 0
+	h.sampled = false
+	h.samples = nil
+}
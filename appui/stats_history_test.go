@@ -0,0 +1,48 @@
+package appui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+
+	"github.com/moncho/dry/docker"
+)
+
+func TestStatsHistory_NetRate(t *testing.T) {
+	h := NewStatsHistory()
+	for _, total := range []float64{100, 300, 350, 50} {
+		h.Add(&docker.Stats{NetworkRx: total / 2, NetworkTx: total / 2})
+	}
+	if h.netTotal != 50 {
+		t.Errorf("Unexpected network total. Got %f, expected 50", h.netTotal)
+	}
+	if h.netPeak != 200 {
+		t.Errorf("Unexpected network peak rate. Got %f, expected 200", h.netPeak)
+	}
+
+	h.Reset()
+	if h.sampled || h.netPeak != 0 || h.netTotal != 0 {
+		t.Error("Stats history was not reset")
+	}
+}
+
+func TestStatsHistory_Samples(t *testing.T) {
+	h := NewStatsHistory()
+	if h.Samples(time.Time{}) != nil {
+		t.Error("Expected no samples on an empty history")
+	}
+	now := time.Now()
+	for i := 3; i >= 0; i-- {
+		stats := &types.StatsJSON{}
+		stats.Read = now.Add(-time.Duration(i) * time.Minute)
+		h.Add(&docker.Stats{CPUPercentage: float64(i), Stats: stats})
+	}
+	current := h.Samples(time.Time{})
+	if len(current) != 1 || current[0].CPUPercentage != 0 {
+		t.Errorf("Unexpected current sample: %v", current)
+	}
+	if window := h.Samples(now.Add(-90 * time.Second)); len(window) != 2 {
+		t.Errorf("Unexpected number of samples on the window, got %d, expected 2", len(window))
+	}
+}
@@ -0,0 +1,143 @@
+package appui
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	units "github.com/docker/go-units"
+	"github.com/moncho/dry/docker"
+)
+
+//timelineWidth is the width of the bar showing the position of the sample
+//being played back among the recorded ones
+const timelineWidth = 60
+
+//statsSample are the records of the containers sampled at the same time
+type statsSample struct {
+	time    time.Time
+	records []docker.StatsRecord
+}
+
+//StatsPlayback renders the stats recorded on a stats file, one sample at a
+//time, moving back and forth between samples to see how the containers
+//behaved at some point in the past
+type StatsPlayback struct {
+	source  string
+	samples []statsSample
+	pos     int
+	sync.RWMutex
+}
+
+//NewStatsPlayback creates a StatsPlayback
+func NewStatsPlayback() *StatsPlayback {
+	return &StatsPlayback{}
+}
+
+//Load sets the records to be played back, read from the given source, the
+//latest sample is shown
+func (p *StatsPlayback) Load(source string, records []docker.StatsRecord) {
+	var samples []statsSample
+	for _, record := range records {
+		if n := len(samples); n > 0 && samples[n-1].time.Equal(record.Time) {
+			samples[n-1].records = append(samples[n-1].records, record)
+			continue
+		}
+		samples = append(samples, statsSample{record.Time, []docker.StatsRecord{record}})
+	}
+	for _, s := range samples {
+		sort.SliceStable(s.records, func(i, j int) bool {
+			return s.records[i].CPUPercentage > s.records[j].CPUPercentage
+		})
+	}
+	p.Lock()
+	defer p.Unlock()
+	p.source = source
+	p.samples = samples
+	p.pos = len(samples) - 1
+}
+
+//Move moves the given number of samples forward, or backwards if negative,
+//without going beyond the first and the last sample
+func (p *StatsPlayback) Move(n int) {
+	p.Lock()
+	defer p.Unlock()
+	p.pos += n
+	if p.pos >= len(p.samples) {
+		p.pos = len(p.samples) - 1
+	}
+	if p.pos < 0 {
+		p.pos = 0
+	}
+}
+
+//First moves to the oldest sample
+func (p *StatsPlayback) First() {
+	p.Lock()
+	defer p.Unlock()
+	p.pos = 0
+}
+
+//Last moves to the latest sample
+func (p *StatsPlayback) Last() {
+	p.Lock()
+	defer p.Unlock()
+	p.pos = len(p.samples) - 1
+}
+
+//Time returns the time of the sample being shown, false if there are no samples
+func (p *StatsPlayback) Time() (time.Time, bool) {
+	p.RLock()
+	defer p.RUnlock()
+	if len(p.samples) == 0 {
+		return time.Time{}, false
+	}
+	return p.samples[p.pos].time, true
+}
+
+//String renders the sample being shown
+func (p *StatsPlayback) String() string {
+	p.RLock()
+	defer p.RUnlock()
+	buffer := new(bytes.Buffer)
+	buffer.WriteString("<white>Recorded stats</>\n")
+	writeKV(buffer, "File", p.source)
+	if len(p.samples) == 0 {
+		buffer.WriteString("\n No stats have been recorded yet.\n")
+		return buffer.String()
+	}
+	sample := p.samples[p.pos]
+	first, last := p.samples[0].time, p.samples[len(p.samples)-1].time
+	writeKV(buffer, "Recorded", fmt.Sprintf("%s to %s",
+		first.Format("2006-01-02 15:04:05"), last.Format("2006-01-02 15:04:05")))
+	writeKV(buffer, "Sample", fmt.Sprintf("%s (%d of %d, %s ago)",
+		sample.time.Format("2006-01-02 15:04:05"), p.pos+1, len(p.samples),
+		units.HumanDuration(docker.Since(sample.time))))
+	buffer.WriteString(fmt.Sprintf(" %s\n\n", timeline(p.pos, len(p.samples))))
+
+	t := tabwriter.NewWriter(buffer, 20, 1, 3, ' ', 0)
+	fmt.Fprintln(t, " NAME\tCONTAINER\tCPU %\tMEM USAGE / LIMIT\tMEM %\tNET I/O\tBLOCK I/O\tPIDS")
+	for _, r := range sample.records {
+		fmt.Fprintf(t, " %s\t%s\t%.2f%%\t%s / %s\t%.2f%%\t%s / %s\t%s / %s\t%d\n",
+			r.Name, docker.TruncateID(r.ID), r.CPUPercentage,
+			units.BytesSize(r.Memory), units.BytesSize(r.MemoryLimit), r.MemoryPercentage,
+			units.BytesSize(r.NetworkRx), units.BytesSize(r.NetworkTx),
+			units.BytesSize(r.BlockRead), units.BytesSize(r.BlockWrite), r.Pids)
+	}
+	t.Flush()
+	return buffer.String()
+}
+
+//timeline renders the position of the given sample among the given number of samples
+func timeline(pos, samples int) string {
+	marker := 0
+	if samples > 1 {
+		marker = pos * (timelineWidth - 1) / (samples - 1)
+	}
+	return "[" + strings.Repeat("-", marker) + "<white>|</>" +
+		strings.Repeat("-", timelineWidth-1-marker) + "]"
+}
@@ -0,0 +1,59 @@
+package appui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moncho/dry/docker"
+)
+
+func TestStatsPlayback(t *testing.T) {
+	p := NewStatsPlayback()
+	if _, ok := p.Time(); ok {
+		t.Error("Empty playback has a sample")
+	}
+	if !strings.Contains(p.String(), "No stats have been recorded") {
+		t.Errorf("Unexpected rendering of an empty playback: %s", p.String())
+	}
+
+	start := time.Date(2019, 3, 1, 10, 0, 0, 0, time.UTC)
+	var records []docker.StatsRecord
+	for i := 0; i < 5; i++ {
+		at := start.Add(time.Duration(i) * time.Minute)
+		records = append(records,
+			docker.StatsRecord{Time: at, ID: "a", Name: "web", CPUPercentage: 10},
+			docker.StatsRecord{Time: at, ID: "b", Name: "db", CPUPercentage: float64(20 * i)})
+	}
+	p.Load("stats.log", records)
+
+	if at, _ := p.Time(); !at.Equal(start.Add(4 * time.Minute)) {
+		t.Errorf("Playback does not start on the latest sample: %s", at)
+	}
+	p.Move(-2)
+	if at, _ := p.Time(); !at.Equal(start.Add(2 * time.Minute)) {
+		t.Errorf("Unexpected sample after moving back: %s", at)
+	}
+	p.Move(-10)
+	if at, _ := p.Time(); !at.Equal(start) {
+		t.Errorf("Moving back went beyond the first sample: %s", at)
+	}
+	p.Last()
+	p.Move(10)
+	if at, _ := p.Time(); !at.Equal(start.Add(4 * time.Minute)) {
+		t.Errorf("Moving forward went beyond the last sample: %s", at)
+	}
+
+	rendered := p.String()
+	if !strings.Contains(rendered, "5 of 5") {
+		t.Errorf("Sample position not rendered: %s", rendered)
+	}
+	//the heaviest container is shown first
+	if strings.Index(rendered, "db") > strings.Index(rendered, "web") {
+		t.Errorf("Containers not sorted by CPU usage: %s", rendered)
+	}
+	p.First()
+	if rendered := p.String(); strings.Index(rendered, "web") > strings.Index(rendered, " db") {
+		t.Errorf("Containers not sorted by CPU usage: %s", rendered)
+	}
+}
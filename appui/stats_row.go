@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"image"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -18,6 +19,14 @@ import (
 const inactiveRowColor = termui.Attribute(ui.Color244)
 const inactiveRowText = "-"
 
+//eventMarkers are the colors used to mark container events on the CPU
+//history, keyed by event action prefix
+var eventMarkers = map[string]termui.Attribute{
+	"restart":       termui.Attribute(ui.Color214),
+	"oom":           termui.Attribute(ui.Color161),
+	"health_status": termui.Attribute(ui.Color33),
+}
+
 //ContainerStatsRow is a Grid row showing runtime information about a container
 type ContainerStatsRow struct {
 	container *docker.Container
@@ -25,13 +34,23 @@ type ContainerStatsRow struct {
 	Name      *drytermui.ParColumn
 	ID        *drytermui.ParColumn
 	CPU       *drytermui.GaugeColumn
+	CPUTrend  *drytermui.SparklineColumn
 	Memory    *drytermui.GaugeColumn
+	MemTrend  *drytermui.SparklineColumn
+	NetTrend  *drytermui.SparklineColumn
 	Net       *drytermui.ParColumn
 	Block     *drytermui.ParColumn
 	Pids      *drytermui.ParColumn
 	Uptime    *drytermui.ParColumn
 	PidsVal   uint64
 	UptimeVal time.Time
+	//CPUVal, MemVal, NetVal and BlockVal are the values used to sort rows
+	CPUVal   float64
+	MemVal   float64
+	NetVal   float64
+	BlockVal float64
+	history  *StatsHistory
+	alerting bool
 
 	drytermui.Row
 	sync.RWMutex
@@ -39,6 +58,12 @@ type ContainerStatsRow struct {
 
 //NewContainerStatsRow creats a new ContainerStatsRow widget
 func NewContainerStatsRow(container *docker.Container, table drytermui.Table) *ContainerStatsRow {
+	return NewContainerStatsRowWithHistory(container, table, NewStatsHistory())
+}
+
+//NewContainerStatsRowWithHistory creates a new ContainerStatsRow widget that
+//shows and adds to the given stats history
+func NewContainerStatsRowWithHistory(container *docker.Container, table drytermui.Table, history *StatsHistory) *ContainerStatsRow {
 	cf := formatter.NewContainerFormatter(container, true)
 	row := ContainerStatsRow{
 		container: container,
@@ -46,11 +71,15 @@ func NewContainerStatsRow(container *docker.Container, table drytermui.Table) *C
 		Name:      drytermui.NewThemedParColumn(DryTheme, cf.Names()),
 		ID:        drytermui.NewThemedParColumn(DryTheme, cf.ID()),
 		CPU:       drytermui.NewThemedGaugeColumn(DryTheme),
+		CPUTrend:  history.CPU,
 		Memory:    drytermui.NewThemedGaugeColumn(DryTheme),
+		MemTrend:  history.Memory,
+		NetTrend:  history.Net,
 		Net:       drytermui.NewThemedParColumn(DryTheme, inactiveRowText),
 		Block:     drytermui.NewThemedParColumn(DryTheme, inactiveRowText),
 		Pids:      drytermui.NewThemedParColumn(DryTheme, inactiveRowText),
 		Uptime:    drytermui.NewThemedParColumn(DryTheme, container.Status),
+		history:   history,
 	}
 	row.Height = 1
 	row.Table = table
@@ -60,8 +89,11 @@ func NewContainerStatsRow(container *docker.Container, table drytermui.Table) *C
 		row.ID,
 		row.Name,
 		row.CPU,
+		row.CPUTrend,
 		row.Memory,
+		row.MemTrend,
 		row.Net,
+		row.NetTrend,
 		row.Block,
 		row.Pids,
 		row.Uptime,
@@ -80,6 +112,7 @@ func (row *ContainerStatsRow) Highlighted() {
 	row.changeTextColor(
 		termui.Attribute(DryTheme.Fg),
 		termui.Attribute(DryTheme.CursorLineBg))
+	row.markAlerting()
 }
 
 //NotHighlighted marks this rows as being not highlighted
@@ -87,6 +120,7 @@ func (row *ContainerStatsRow) NotHighlighted() {
 	row.changeTextColor(
 		termui.Attribute(DryTheme.ListItem),
 		termui.Attribute(DryTheme.Bg))
+	row.markAlerting()
 }
 
 //Buffer returns this Row data as a termui.Buffer
@@ -121,10 +155,35 @@ func (row *ContainerStatsRow) changeTextColor(fg, bg termui.Attribute) {
 	row.Uptime.TextBgColor = bg
 }
 
+//markAlerting colors the container id and name if the row is alerting
+func (row *ContainerStatsRow) markAlerting() {
+	if row.Alerting() {
+		row.ID.TextFgColor = alertRowColor
+		row.Name.TextFgColor = alertRowColor
+	}
+}
+
+//Matches returns true if the name of the container of this row, or any of its
+//labels given as key=value, contains the given pattern
+func (row *ContainerStatsRow) Matches(pattern string) bool {
+	if strings.Contains(row.Name.Text, pattern) {
+		return true
+	}
+	for k, v := range row.container.Labels {
+		if strings.Contains(k+"="+v, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
 //Reset resets row content
 func (row *ContainerStatsRow) Reset() {
 	row.CPU.Reset()
 	row.Memory.Reset()
+	if row.history != nil {
+		row.history.Reset()
+	}
 	row.Net.Reset()
 	row.Pids.Reset()
 	row.Block.Reset()
@@ -142,8 +201,15 @@ func (row *ContainerStatsRow) Update(stat *docker.Stats) {
 	row.setCPU(stat.CPUPercentage)
 	row.setMem(stat.Memory, stat.MemoryLimit, stat.MemoryPercentage)
 	row.setBlockIO(stat.BlockRead, stat.BlockWrite)
+	row.CPUVal = stat.CPUPercentage
+	row.MemVal = stat.Memory
+	row.NetVal = stat.NetworkRx + stat.NetworkTx
+	row.BlockVal = stat.BlockRead + stat.BlockWrite
 	row.setPids(stat.PidsCurrent)
 	row.setUptime(row.container.ContainerJSON.State.StartedAt)
+	if row.history != nil {
+		row.history.Add(stat)
+	}
 }
 
 func (row *ContainerStatsRow) setNet(rx float64, tx float64) {
@@ -170,6 +236,19 @@ func (row *ContainerStatsRow) setCPU(val float64) {
 	row.CPU.BarColor = percentileToColor(cpu)
 }
 
+//MarkEvent marks the given container event action on the CPU history. Only
+//significant events (restarts, OOMs and health changes) are marked, returns
+//true if the event was marked.
+func (row *ContainerStatsRow) MarkEvent(action string) bool {
+	for prefix, color := range eventMarkers {
+		if strings.HasPrefix(action, prefix) {
+			row.CPUTrend.Mark(color)
+			return true
+		}
+	}
+	return false
+}
+
 func (row *ContainerStatsRow) setMem(val float64, limit float64, percent float64) {
 	row.Memory.Label = fmt.Sprintf("%s / %s", units.BytesSize(val), units.BytesSize(limit))
 	mem := int(percent)
@@ -185,7 +264,7 @@ func (row *ContainerStatsRow) setMem(val float64, limit float64, percent float64
 func (row *ContainerStatsRow) setUptime(startedAt string) {
 	if startTime, err := time.Parse(time.RFC3339, startedAt); err == nil {
 		row.UptimeVal = startTime
-		row.Uptime.Text = units.HumanDuration(time.Now().UTC().Sub(startTime))
+		row.Uptime.Text = units.HumanDuration(docker.Now().UTC().Sub(startTime))
 	} else {
 		row.Uptime.Text = ""
 	}
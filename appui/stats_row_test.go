@@ -25,8 +25,8 @@ func TestStatsRow(t *testing.T) {
 		t.Error("Stats row does not hold a reference to the container.")
 	}
 
-	if len(row.Columns) != 9 {
-		t.Errorf("Stats row does not have the expected number of columns. Got: %d, expected 9.", len(row.Columns))
+	if len(row.Columns) != 12 {
+		t.Errorf("Stats row does not have the expected number of columns. Got: %d, expected 12.", len(row.Columns))
 	}
 
 	if row.ID.Text != container.ID {
@@ -59,15 +59,16 @@ func TestStatsRow(t *testing.T) {
 
 func TestContainerStatsRow_Update(t *testing.T) {
 	type fields struct {
-		Status *drytermui.ParColumn
-		Name   *drytermui.ParColumn
-		ID     *drytermui.ParColumn
-		CPU    *drytermui.GaugeColumn
-		Memory *drytermui.GaugeColumn
-		Net    *drytermui.ParColumn
-		Block  *drytermui.ParColumn
-		Pids   *drytermui.ParColumn
-		Uptime *drytermui.ParColumn
+		Status   *drytermui.ParColumn
+		Name     *drytermui.ParColumn
+		ID       *drytermui.ParColumn
+		CPU      *drytermui.GaugeColumn
+		CPUTrend *drytermui.SparklineColumn
+		Memory   *drytermui.GaugeColumn
+		Net      *drytermui.ParColumn
+		Block    *drytermui.ParColumn
+		Pids     *drytermui.ParColumn
+		Uptime   *drytermui.ParColumn
 	}
 	type args struct {
 		container *docker.Container
@@ -81,15 +82,16 @@ func TestContainerStatsRow_Update(t *testing.T) {
 		{
 			"Update row, row has the expected values",
 			fields{
-				Status: drytermui.NewParColumn(""),
-				Name:   drytermui.NewParColumn(""),
-				ID:     drytermui.NewParColumn(""),
-				CPU:    &drytermui.GaugeColumn{},
-				Memory: &drytermui.GaugeColumn{},
-				Net:    drytermui.NewParColumn(""),
-				Block:  drytermui.NewParColumn(""),
-				Pids:   drytermui.NewParColumn(""),
-				Uptime: drytermui.NewParColumn(""),
+				Status:   drytermui.NewParColumn(""),
+				Name:     drytermui.NewParColumn(""),
+				ID:       drytermui.NewParColumn(""),
+				CPU:      &drytermui.GaugeColumn{},
+				CPUTrend: drytermui.NewSparklineColumn(),
+				Memory:   &drytermui.GaugeColumn{},
+				Net:      drytermui.NewParColumn(""),
+				Block:    drytermui.NewParColumn(""),
+				Pids:     drytermui.NewParColumn(""),
+				Uptime:   drytermui.NewParColumn(""),
 			},
 			args{
 				container: &docker.Container{
@@ -111,15 +113,16 @@ func TestContainerStatsRow_Update(t *testing.T) {
 		{
 			"Update row, no stats are passed, row does not change",
 			fields{
-				Status: drytermui.NewParColumn(""),
-				Name:   drytermui.NewParColumn(""),
-				ID:     drytermui.NewParColumn(""),
-				CPU:    &drytermui.GaugeColumn{},
-				Memory: &drytermui.GaugeColumn{},
-				Net:    drytermui.NewParColumn(""),
-				Block:  drytermui.NewParColumn(""),
-				Pids:   drytermui.NewParColumn(""),
-				Uptime: drytermui.NewParColumn(""),
+				Status:   drytermui.NewParColumn(""),
+				Name:     drytermui.NewParColumn(""),
+				ID:       drytermui.NewParColumn(""),
+				CPU:      &drytermui.GaugeColumn{},
+				CPUTrend: drytermui.NewSparklineColumn(),
+				Memory:   &drytermui.GaugeColumn{},
+				Net:      drytermui.NewParColumn(""),
+				Block:    drytermui.NewParColumn(""),
+				Pids:     drytermui.NewParColumn(""),
+				Uptime:   drytermui.NewParColumn(""),
 			},
 			args{
 				container: &docker.Container{
@@ -140,6 +143,7 @@ func TestContainerStatsRow_Update(t *testing.T) {
 				Name:      tt.fields.Name,
 				ID:        tt.fields.ID,
 				CPU:       tt.fields.CPU,
+				CPUTrend:  tt.fields.CPUTrend,
 				Memory:    tt.fields.Memory,
 				Net:       tt.fields.Net,
 				Block:     tt.fields.Block,
@@ -2,22 +2,189 @@ package appui
 
 import (
 	"io"
+	"strings"
+	"sync"
 
 	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/gdamore/tcell"
 	"github.com/moncho/dry/ui"
 )
 
-//Stream shows the content of the given stream on screen
-func Stream(stream io.ReadCloser, keyboardQueue <-chan *tcell.EventKey, done func()) {
+//LogsSource returns a stream of logs, with or without timestamps
+type LogsSource func(timestamps bool) (io.ReadCloser, error)
+
+//StreamLogs shows on screen the logs given by the source, if follow is set
+//the screen scrolls as new content arrives. Stderr content is
+//shown on a different color than stdout content. Timestamps can be toggled
+//with 't', logs are requested again to the source when that happens.
+//If the logs cannot be retrieved an error is returned and nothing is shown.
+func StreamLogs(source LogsSource, timestamps, follow bool, keyboardQueue <-chan *tcell.EventKey, done func()) error {
+	return streamLogs(source, timestamps, follow, keyboardQueue, done, nil)
+}
+
+//ServiceLogsSource returns a LogsSource of the logs of the given task of a
+//service, all of its tasks if none is given
+type ServiceLogsSource func(task string) LogsSource
+
+//StreamServiceLogs is StreamLogs for the logs of a service, whose lines are
+//prefixed with the task and node they come from, color-coded. 'T' asks for
+//the task, by slot or id, whose output is shown.
+func StreamServiceLogs(source ServiceLogsSource, timestamps, follow bool, keyboardQueue <-chan *tcell.EventKey, done func()) error {
+	return streamLogs(source(""), timestamps, follow, keyboardQueue, done,
+		func(v *ui.Less, reload func(LogsSource) error) {
+			v.RenderANSI(true)
+			v.BindInput('T', "Show the output of task (slot or id, blank for every task): ", func(task string) {
+				task = strings.TrimSpace(task)
+				if err := reload(source(task)); err != nil {
+					v.Message("Could not show the task logs: " + err.Error())
+					return
+				}
+				if task == "" {
+					v.SetStatus("")
+				} else {
+					v.SetStatus("Task: " + task)
+				}
+			})
+		})
+}
+
+//streamLogs shows the logs given by the source, the given func, if any, can
+//customize the view and bind keys to reload it with logs from another source
+func streamLogs(source LogsSource, timestamps, follow bool, keyboardQueue <-chan *tcell.EventKey, done func(),
+	customize func(v *ui.Less, reload func(LogsSource) error)) error {
+	stream, err := source(timestamps)
+	if err != nil {
+		return err
+	}
 	defer done()
 	ui.ActiveScreen.ClearAndFlush()
 	v := ui.NewLess(DryTheme)
-	//TODO do something with io errors
-	go stdcopy.StdCopy(v, v, stream)
+	v.Follow(follow)
+
+	var mutex sync.Mutex
+	copied := copyLogs(v, stream)
+	reload := func(newSource LogsSource, newTimestamps bool) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		newStream, err := newSource(newTimestamps)
+		if err != nil {
+			return err
+		}
+		stream.Close()
+		<-copied
+		source = newSource
+		timestamps = newTimestamps
+		stream = newStream
+		v.Clear()
+		copied = copyLogs(v, stream)
+		return nil
+	}
+	v.Bind('t', func() {
+		reload(source, !timestamps)
+	})
+	if customize != nil {
+		customize(v, func(newSource LogsSource) error {
+			return reload(newSource, timestamps)
+		})
+	}
 	v.Focus(keyboardQueue)
 
+	mutex.Lock()
 	stream.Close()
+	mutex.Unlock()
+	ui.ActiveScreen.HideCursor()
+	ui.ActiveScreen.ClearAndFlush()
+	ui.ActiveScreen.Sync()
+	return nil
+}
+
+//LogsPane is the logs shown on a pane of StreamLogsPanes
+type LogsPane struct {
+	//Title is shown on the status line of the pane
+	Title  string
+	Source LogsSource
+}
+
+//StreamLogsPanes shows on screen the logs of each of the given panes, stacked,
+//each pane is scrolled, followed and searched independently. Timestamps are
+//toggled with 't' on the focused pane. If the logs of any pane cannot be
+//retrieved an error is returned and nothing is shown.
+func StreamLogsPanes(panes []LogsPane, timestamps, follow bool, keyboardQueue <-chan *tcell.EventKey, done func()) error {
+	streams := make([]io.ReadCloser, len(panes))
+	for i, pane := range panes {
+		stream, err := pane.Source(timestamps)
+		if err != nil {
+			for _, opened := range streams[:i] {
+				opened.Close()
+			}
+			return err
+		}
+		streams[i] = stream
+	}
+	defer done()
+	ui.ActiveScreen.ClearAndFlush()
+	lp := ui.NewLessPanes(DryTheme, len(panes))
+
+	var mutex sync.Mutex
+	for i, pane := range panes {
+		i, source, paneTimestamps := i, pane.Source, timestamps
+		v := lp.Pane(i)
+		v.Follow(follow)
+		v.SetStatus(pane.Title)
+		copied := copyLogs(v, streams[i])
+		v.Bind('t', func() {
+			mutex.Lock()
+			defer mutex.Unlock()
+			newStream, err := source(!paneTimestamps)
+			if err != nil {
+				return
+			}
+			streams[i].Close()
+			<-copied
+			paneTimestamps = !paneTimestamps
+			streams[i] = newStream
+			v.Clear()
+			copied = copyLogs(v, newStream)
+		})
+	}
+	lp.Focus(keyboardQueue)
+
+	mutex.Lock()
+	for _, stream := range streams {
+		stream.Close()
+	}
+	mutex.Unlock()
+	ui.ActiveScreen.HideCursor()
+	ui.ActiveScreen.ClearAndFlush()
+	ui.ActiveScreen.Sync()
+	return nil
+}
+
+//copyLogs copies the given stream to the given view, the returned channel
+//is closed once done
+func copyLogs(v *ui.Less, stream io.Reader) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		//TODO do something with io errors
+		stdcopy.StdCopy(v, v.Stderr(), stream)
+	}()
+	return done
+}
+
+//StreamText shows on screen, with markup support, the text read from the given
+//reader as it arrives, the screen scrolls as new content arrives. The reader
+//is closed once the view is closed.
+func StreamText(r io.ReadCloser, keyboardQueue <-chan *tcell.EventKey, done func()) {
+	defer done()
+	ui.ActiveScreen.ClearAndFlush()
+	v := ui.NewLess(DryTheme)
+	v.MarkupSupport()
+	v.Follow(true)
+	go io.Copy(v, r)
+	v.Focus(keyboardQueue)
+
+	r.Close()
 	ui.ActiveScreen.HideCursor()
 	ui.ActiveScreen.ClearAndFlush()
 	ui.ActiveScreen.Sync()
@@ -0,0 +1,143 @@
+package swarm
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	units "github.com/docker/go-units"
+
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/moncho/dry/ui"
+)
+
+type nodeInfoRenderer struct {
+	node  *swarm.Node
+	tasks []swarm.Task
+}
+
+//NewNodeInfoRenderer creates a renderer for the engine description, plugins
+//and resources of the given node, along with the tasks running on it, much
+//like docker info does for the local engine
+func NewNodeInfoRenderer(node *swarm.Node, tasks []swarm.Task) fmt.Stringer {
+	return &nodeInfoRenderer{node: node, tasks: tasks}
+}
+
+func (r *nodeInfoRenderer) String() string {
+	node := r.node
+	description := node.Description
+	buffer := new(bytes.Buffer)
+	w := tabwriter.NewWriter(buffer, 0, 8, 1, ' ', 0)
+
+	section(w, "Node")
+	field(w, "Hostname", description.Hostname)
+	field(w, "ID", node.ID)
+	field(w, "Role", string(node.Spec.Role))
+	field(w, "Availability", string(node.Spec.Availability))
+	state := string(node.Status.State)
+	if node.Status.State != swarm.NodeStateReady {
+		state = ui.Red(state)
+	}
+	if node.Status.Message != "" {
+		state += " (" + node.Status.Message + ")"
+	}
+	field(w, "Status", state)
+	field(w, "Address", node.Status.Addr)
+	if ms := node.ManagerStatus; ms != nil {
+		manager := string(ms.Reachability)
+		if ms.Leader {
+			manager += ", leader"
+		}
+		field(w, "Manager", fmt.Sprintf("%s, %s", manager, ms.Addr))
+	}
+	running := 0
+	for _, task := range r.tasks {
+		if task.Status.State == swarm.TaskStateRunning {
+			running++
+		}
+	}
+	field(w, "Tasks", fmt.Sprintf("%d running, %d total", running, len(r.tasks)))
+	field(w, "Labels", labels(node.Spec.Labels))
+
+	section(w, "Platform")
+	field(w, "OS", description.Platform.OS)
+	field(w, "Architecture", description.Platform.Architecture)
+
+	section(w, "Resources")
+	field(w, "CPUs", fmt.Sprintf("%g", float64(description.Resources.NanoCPUs)/1e9))
+	field(w, "Total Memory", units.BytesSize(float64(description.Resources.MemoryBytes)))
+	for _, resource := range genericResources(description.Resources.GenericResources) {
+		field(w, "Generic Resource", resource)
+	}
+
+	section(w, "Engine")
+	field(w, "Version", description.Engine.EngineVersion)
+	field(w, "Labels", labels(description.Engine.Labels))
+	for _, plugins := range pluginsByType(description.Engine.Plugins) {
+		field(w, plugins[0], strings.Join(plugins[1:], " "))
+	}
+
+	if tls := description.TLSInfo; tls.CertIssuerSubject != nil {
+		section(w, "TLS")
+		field(w, "Certificate issuer subject", fmt.Sprintf("%x", tls.CertIssuerSubject))
+	}
+	w.Flush()
+	return buffer.String()
+}
+
+func section(w *tabwriter.Writer, name string) {
+	fmt.Fprintf(w, "\n%s\n", ui.Blue(name))
+}
+
+func field(w *tabwriter.Writer, name, value string) {
+	if value == "" {
+		value = "-"
+	}
+	fmt.Fprintf(w, "  %s\t%s\n", ui.White(name+":"), value)
+}
+
+func labels(l map[string]string) string {
+	var pairs []string
+	for k, v := range l {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
+
+//genericResources describes the given generic resources as kind=value
+func genericResources(resources []swarm.GenericResource) []string {
+	var result []string
+	for _, r := range resources {
+		if r.NamedResourceSpec != nil {
+			result = append(result, fmt.Sprintf("%s=%s", r.NamedResourceSpec.Kind, r.NamedResourceSpec.Value))
+		}
+		if r.DiscreteResourceSpec != nil {
+			result = append(result, fmt.Sprintf("%s=%d", r.DiscreteResourceSpec.Kind, r.DiscreteResourceSpec.Value))
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+//pluginsByType groups the given plugins by type, each group starts with
+//the type, followed by the plugin names. Groups are sorted by type.
+func pluginsByType(plugins []swarm.PluginDescription) [][]string {
+	names := make(map[string][]string)
+	for _, p := range plugins {
+		names[p.Type] = append(names[p.Type], p.Name)
+	}
+	var types []string
+	for t := range names {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	var result [][]string
+	for _, t := range types {
+		sort.Strings(names[t])
+		result = append(result, append([]string{t + " plugins"}, names[t]...))
+	}
+	return result
+}
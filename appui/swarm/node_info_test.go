@@ -0,0 +1,54 @@
+package swarm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/swarm"
+)
+
+func TestNodeInfoRenderer(t *testing.T) {
+	node := &swarm.Node{ID: "n1"}
+	node.Spec.Role = swarm.NodeRoleManager
+	node.Spec.Availability = swarm.NodeAvailabilityActive
+	node.Spec.Labels = map[string]string{"zone": "a"}
+	node.Status.State = swarm.NodeStateReady
+	node.ManagerStatus = &swarm.ManagerStatus{Leader: true, Reachability: swarm.ReachabilityReachable, Addr: "10.0.0.1:2377"}
+	node.Description = swarm.NodeDescription{
+		Hostname:  "manager-1",
+		Platform:  swarm.Platform{OS: "linux", Architecture: "x86_64"},
+		Resources: swarm.Resources{NanoCPUs: 4e9, MemoryBytes: 8 * 1024 * 1024 * 1024},
+		Engine: swarm.EngineDescription{
+			EngineVersion: "19.03.8",
+			Plugins: []swarm.PluginDescription{
+				{Type: "Volume", Name: "local"},
+				{Type: "Network", Name: "overlay"},
+				{Type: "Network", Name: "bridge"},
+			},
+		},
+	}
+	tasks := []swarm.Task{{}, {}}
+	tasks[0].Status.State = swarm.TaskStateRunning
+
+	info := NewNodeInfoRenderer(node, tasks).String()
+	for _, expected := range []string{
+		"manager-1",
+		"reachable, leader, 10.0.0.1:2377",
+		"1 running, 2 total",
+		"zone=a",
+		"linux",
+		"x86_64",
+		"4",
+		"8GiB",
+		"19.03.8",
+		"bridge overlay",
+		"local",
+	} {
+		if !strings.Contains(info, expected) {
+			t.Errorf("Node info does not contain %q:\n%s", expected, info)
+		}
+	}
+	if strings.Index(info, "Network plugins") > strings.Index(info, "Volume plugins") {
+		t.Errorf("Plugins are not sorted by type:\n%s", info)
+	}
+}
@@ -1,17 +1,31 @@
 package swarm
 
 import (
+	"fmt"
 	"image"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/docker/docker/api/types/swarm"
 	units "github.com/docker/go-units"
 	termui "github.com/gizak/termui"
 	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
 	"github.com/moncho/dry/docker/formatter"
+	"github.com/moncho/dry/ui"
 	drytermui "github.com/moncho/dry/ui/termui"
 )
 
+const (
+	//reservedWarning is the percentage of the CPU or memory of a node
+	//reserved from which its reservations are shown as a warning
+	reservedWarning = 75
+	//reservedCritical is the percentage of the CPU or memory of a node
+	//reserved from which the node is shown as about to be full
+	reservedCritical = 90
+)
+
 //NodeRow is a Grid row showing runtime information about a node
 type NodeRow struct {
 	node          swarm.Node
@@ -20,11 +34,22 @@ type NodeRow struct {
 	Labels        *drytermui.ParColumn
 	CPU           *drytermui.ParColumn
 	Memory        *drytermui.ParColumn
+	ReservedCPU   *drytermui.ParColumn
+	ReservedMem   *drytermui.ParColumn
+	Tasks         *drytermui.ParColumn
+	CertExpiry    *drytermui.ParColumn
 	Engine        *drytermui.ParColumn
 	IPAddress     *drytermui.ParColumn
 	Status        *drytermui.ParColumn
 	ManagerStatus *drytermui.ParColumn
 	Availability  *drytermui.ParColumn
+	//reservedFg, if set, is the color of the reservations of nodes with
+	//little room left for new tasks
+	reservedCPUFg termui.Attribute
+	reservedMemFg termui.Attribute
+	//certExpiresSoon is true if the certificate of the node has expired or
+	//is about to
+	certExpiresSoon bool
 
 	drytermui.Row
 }
@@ -38,6 +63,10 @@ func NewNodeRow(node swarm.Node, table drytermui.Table) *NodeRow {
 		Labels:        drytermui.NewThemedParColumn(appui.DryTheme, formatter.FormatLabels(node.Spec.Labels)),
 		CPU:           drytermui.NewThemedParColumn(appui.DryTheme, cpus(node)),
 		Memory:        drytermui.NewThemedParColumn(appui.DryTheme, units.BytesSize(float64(node.Description.Resources.MemoryBytes))),
+		ReservedCPU:   drytermui.NewThemedParColumn(appui.DryTheme, "-"),
+		ReservedMem:   drytermui.NewThemedParColumn(appui.DryTheme, "-"),
+		Tasks:         drytermui.NewThemedParColumn(appui.DryTheme, "-"),
+		CertExpiry:    drytermui.NewThemedParColumn(appui.DryTheme, "-"),
 		Engine:        drytermui.NewThemedParColumn(appui.DryTheme, node.Description.Engine.EngineVersion),
 		IPAddress:     drytermui.NewThemedParColumn(appui.DryTheme, node.Status.Addr),
 		Status:        drytermui.NewThemedParColumn(appui.DryTheme, string(node.Status.State)),
@@ -53,11 +82,19 @@ func NewNodeRow(node swarm.Node, table drytermui.Table) *NodeRow {
 		row.Labels,
 		row.CPU,
 		row.Memory,
+		row.ReservedCPU,
+		row.ReservedMem,
+		row.Tasks,
 		row.Engine,
 		row.IPAddress,
 		row.Status,
 		row.Availability,
 		row.ManagerStatus,
+		row.CertExpiry,
+	}
+	if expiry, err := docker.NodeCertExpiry(node); err == nil {
+		row.CertExpiry.Text = certExpiry(expiry)
+		row.certExpiresSoon = docker.CertExpiresSoon(expiry)
 	}
 	row.updateStatusColumn()
 
@@ -91,11 +128,35 @@ func (row *NodeRow) Highlighted() {
 		termui.Attribute(appui.DryTheme.CursorLineBg))
 }
 
-//NotHighlighted marks this rows as being not highlighted
+//NotHighlighted marks this rows as being not highlighted, nodes with
+//little room left for new tasks stand out
 func (row *NodeRow) NotHighlighted() {
 	row.changeTextColor(
 		termui.Attribute(appui.DryTheme.ListItem),
 		termui.Attribute(appui.DryTheme.Bg))
+	if row.reservedCPUFg != 0 {
+		row.ReservedCPU.TextFgColor = row.reservedCPUFg
+	}
+	if row.reservedMemFg != 0 {
+		row.ReservedMem.TextFgColor = row.reservedMemFg
+	}
+	if row.certExpiresSoon {
+		row.Name.TextFgColor = appui.NotRunning
+		row.CertExpiry.TextFgColor = appui.NotRunning
+	}
+}
+
+//setUsage shows the resources of the node reserved by the tasks scheduled
+//on it and how many of them are running
+func (row *NodeRow) setUsage(u docker.NodeUsage) {
+	resources := row.node.Description.Resources
+	cpuUsage := u.CPUUsage(resources.NanoCPUs)
+	memUsage := u.MemoryUsage(resources.MemoryBytes)
+	row.ReservedCPU.Text = reserved(fmt.Sprintf("%g", float64(u.ReservedNanoCPUs)/1e9), cpuUsage)
+	row.ReservedMem.Text = reserved(units.BytesSize(float64(u.ReservedMemory)), memUsage)
+	row.Tasks.Text = strconv.Itoa(u.RunningTasks)
+	row.reservedCPUFg = reservedColor(cpuUsage)
+	row.reservedMemFg = reservedColor(memUsage)
 }
 
 func (row *NodeRow) changeTextColor(fg, bg termui.Attribute) {
@@ -109,6 +170,14 @@ func (row *NodeRow) changeTextColor(fg, bg termui.Attribute) {
 	row.CPU.TextBgColor = bg
 	row.Memory.TextFgColor = fg
 	row.Memory.TextBgColor = bg
+	row.ReservedCPU.TextFgColor = fg
+	row.ReservedCPU.TextBgColor = bg
+	row.ReservedMem.TextFgColor = fg
+	row.ReservedMem.TextBgColor = bg
+	row.Tasks.TextFgColor = fg
+	row.Tasks.TextBgColor = bg
+	row.CertExpiry.TextFgColor = fg
+	row.CertExpiry.TextBgColor = bg
 	row.Engine.TextFgColor = fg
 	row.Engine.TextBgColor = bg
 	row.IPAddress.TextFgColor = fg
@@ -137,6 +206,37 @@ func cpus(node swarm.Node) string {
 	return strconv.Itoa(int(nano))
 }
 
+//reserved describes the given reservation of a resource along with the
+//percentage of the resource it is
+func reserved(reservation string, usage float64) string {
+	if usage < 0 {
+		return reservation
+	}
+	return fmt.Sprintf("%s %.0f%%", reservation, usage)
+}
+
+//reservedColor returns the color of a reservation of the given percentage
+//of a resource, none if there is room left
+func reservedColor(usage float64) termui.Attribute {
+	switch {
+	case usage >= reservedCritical:
+		return appui.NotRunning
+	case usage >= reservedWarning:
+		return termui.Attribute(ui.ColorYellow)
+	}
+	return 0
+}
+
+//certExpiry describes how long until a certificate expiring at the given
+//time expires
+func certExpiry(expiry time.Time) string {
+	left := expiry.Sub(docker.Now())
+	if left <= 0 {
+		return "expired"
+	}
+	return "in " + strings.ToLower(units.HumanDuration(left))
+}
+
 func managerStatus(node swarm.Node) string {
 	reachability := ""
 	if node.ManagerStatus != nil {
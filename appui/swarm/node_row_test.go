@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/docker/docker/api/types/swarm"
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
 )
 
 func TestNodeRow(t *testing.T) {
@@ -60,3 +62,29 @@ func TestNodeRow(t *testing.T) {
 		t.Errorf("Unexpected NodeRow availability, got %s, expected %s", row.Availability.Text, node.Spec.Availability)
 	}
 }
+
+func TestNodeRowUsage(t *testing.T) {
+	node := swarm.Node{
+		Description: swarm.NodeDescription{
+			Resources: swarm.Resources{
+				NanoCPUs:    2 * 1e9,
+				MemoryBytes: 1024 * 1024 * 1024,
+			},
+		},
+	}
+	row := NewNodeRow(node, nodeTableHeader())
+	row.setUsage(docker.NodeUsage{ReservedNanoCPUs: 19e8, ReservedMemory: 256 * 1024 * 1024, RunningTasks: 3})
+
+	if row.ReservedCPU.Text != "1.9 95%" {
+		t.Errorf("Unexpected reserved CPU, got %s", row.ReservedCPU.Text)
+	}
+	if row.ReservedMem.Text != "256MiB 25%" {
+		t.Errorf("Unexpected reserved memory, got %s", row.ReservedMem.Text)
+	}
+	if row.Tasks.Text != "3" {
+		t.Errorf("Unexpected running tasks, got %s", row.Tasks.Text)
+	}
+	if row.reservedCPUFg != appui.NotRunning || row.reservedMemFg != 0 {
+		t.Error("Only the CPU of the node was expected to stand out")
+	}
+}
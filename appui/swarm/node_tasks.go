@@ -46,13 +46,8 @@ func (s *NodeTasksWidget) Buffer() gizaktermui.Buffer {
 	}
 	y := s.screen.Bounds().Min.Y
 	s.prepareForRendering()
-	var filter string
-	if s.filterPattern != "" {
-		filter = fmt.Sprintf(
-			"<b><blue> | Active filter: </><yellow>%s</></> ", s.filterPattern)
-	}
 	s.tableTitle.Content(fmt.Sprintf(
-		"<b><blue>Node %s tasks: </><yellow>%d</></>", s.nodeName, s.RowCount()) + " " + filter)
+		"<b><blue>Node %s tasks: </><yellow>%d</></>", s.nodeName, s.RowCount()) + " " + s.filtersTitle())
 
 	s.tableTitle.Y = y
 	buf.Merge(s.tableTitle.Buffer())
@@ -2,6 +2,7 @@ package swarm
 
 import (
 	"errors"
+	"fmt"
 	"sort"
 	"strconv"
 	"strings"
@@ -26,11 +27,15 @@ var nodeTableFieldWidths = map[string]int{
 	"LABELS":         0,
 	"CPU":            4,
 	"MEMORY":         12,
+	"CPU RESERVED":   12,
+	"MEM RESERVED":   16,
+	"TASKS":          6,
 	"DOCKER ENGINE":  16,
 	"IP ADDRESS":     16,
 	"STATUS":         16,
 	"AVAILABILITY":   16,
-	"MANAGER STATUS": 0,
+	"MANAGER STATUS": 16,
+	"CERT EXPIRY":    0,
 }
 
 var nodeTableHeaders = []appui.SortableColumnHeader{
@@ -39,11 +44,15 @@ var nodeTableHeaders = []appui.SortableColumnHeader{
 	{Title: "LABELS", Mode: appui.SortMode(docker.NoSortNode)},
 	{Title: "CPU", Mode: appui.SortMode(docker.SortByNodeCPU)},
 	{Title: "MEMORY", Mode: appui.SortMode(docker.SortByNodeMem)},
+	{Title: "CPU RESERVED", Mode: appui.SortMode(docker.NoSortNode)},
+	{Title: "MEM RESERVED", Mode: appui.SortMode(docker.NoSortNode)},
+	{Title: "TASKS", Mode: appui.SortMode(docker.NoSortNode)},
 	{Title: "DOCKER ENGINE", Mode: appui.SortMode(docker.NoSortNode)},
 	{Title: "IP ADDRESS", Mode: appui.SortMode(docker.NoSortNode)},
 	{Title: "STATUS", Mode: appui.SortMode(docker.SortByNodeStatus)},
 	{Title: "AVAILABILITY", Mode: appui.SortMode(docker.NoSortNode)},
 	{Title: "MANAGER STATUS", Mode: appui.SortMode(docker.NoSortNode)},
+	{Title: "CERT EXPIRY", Mode: appui.SortMode(docker.NoSortNode)},
 }
 
 //NodesWidget presents Docker swarm information
@@ -60,6 +69,8 @@ type NodesWidget struct {
 	title                *termui.MarkupPar
 	totalMemory          int64
 	totalCPU             int
+	reservedMemory       int64
+	reservedCPU          int64
 
 	sync.RWMutex
 	mounted bool
@@ -126,6 +137,13 @@ func (s *NodesWidget) Filter(filter string) {
 	s.filterPattern = filter
 }
 
+//FilterPattern returns the filter applied to this widget
+func (s *NodesWidget) FilterPattern() string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.filterPattern
+}
+
 //Mount prepares this widget for rendering
 func (s *NodesWidget) Mount() error {
 	s.Lock()
@@ -137,11 +155,24 @@ func (s *NodesWidget) Mount() error {
 	swarmClient := s.swarmClient
 	if nodes, err := swarmClient.Nodes(); err == nil {
 		docker.SortNodes(nodes, s.sortMode)
+		//without tasks, reservations are not known and shown as such
+		var usage map[string]docker.NodeUsage
+		if tasks, err := swarmClient.ServiceTasks(); err == nil {
+			usage = docker.NodesUsage(tasks)
+		}
 		var rows []*NodeRow
 		s.totalCPU = 0
 		s.totalMemory = 0
+		s.reservedCPU = 0
+		s.reservedMemory = 0
 		for _, node := range nodes {
 			row := NewNodeRow(node, s.header)
+			if usage != nil {
+				u := usage[node.ID]
+				row.setUsage(u)
+				s.reservedCPU += u.ReservedNanoCPUs
+				s.reservedMemory += u.ReservedMemory
+			}
 			rows = append(rows, row)
 			if cpu, err := strconv.Atoi(row.CPU.Text); err == nil {
 				s.totalCPU += cpu
@@ -222,19 +253,14 @@ func (s *NodesWidget) align() {
 }
 
 func (s *NodesWidget) filterRows() {
-
-	if s.filterPattern != "" {
-		var rows []*NodeRow
-
-		for _, row := range s.totalRows {
-			if appui.RowFilters.ByPattern(s.filterPattern)(row) {
-				rows = append(rows, row)
-			}
+	filter := appui.RowFilters.ByFuzzyPattern(s.filterPattern)
+	var rows []*NodeRow
+	for _, row := range s.totalRows {
+		if filter(row) {
+			rows = append(rows, row)
 		}
-		s.filteredRows = rows
-	} else {
-		s.filteredRows = s.totalRows
 	}
+	s.filteredRows = rows
 }
 
 func (s *NodesWidget) calculateVisibleRows() {
@@ -377,6 +403,10 @@ func addSwarmSpecs(w *NodesWidget) {
 				ui.Yellow(strconv.Itoa(w.totalCPU)),
 				ui.Blue("Total Memory:"),
 				ui.Yellow(units.BytesSize(float64(w.totalMemory))),
+				ui.Blue("Reserved CPU:"),
+				ui.Yellow(fmt.Sprintf("%g", float64(w.reservedCPU)/1e9)),
+				ui.Blue("Reserved Memory:"),
+				ui.Yellow(units.BytesSize(float64(w.reservedMemory))),
 			}, " "))
 	par.BorderTop = false
 	par.BorderBottom = false
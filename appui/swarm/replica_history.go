@@ -0,0 +1,84 @@
+package swarm
+
+import (
+	"bytes"
+	"fmt"
+	"text/tabwriter"
+
+	units "github.com/docker/go-units"
+
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+)
+
+type replicaHistoryRenderer struct {
+	service *swarm.Service
+	tasks   []swarm.Task
+	changes []docker.ReplicaChange
+	presets string
+}
+
+//NewReplicaHistoryRenderer creates a renderer for the replica history of the
+//given service, along with its running tasks and scale presets, to help with
+//scaling decisions
+func NewReplicaHistoryRenderer(service *swarm.Service, tasks []swarm.Task, changes []docker.ReplicaChange, presets string) fmt.Stringer {
+	return &replicaHistoryRenderer{
+		service: service,
+		tasks:   tasks,
+		changes: changes,
+		presets: presets,
+	}
+}
+
+func (r *replicaHistoryRenderer) String() string {
+	service := r.service
+	buffer := new(bytes.Buffer)
+	fmt.Fprintf(buffer, "%s %s\n", ui.White("Service:"), service.Spec.Name)
+	if service.Spec.Mode.Replicated == nil || service.Spec.Mode.Replicated.Replicas == nil {
+		fmt.Fprintf(buffer, "%s global, it runs a task on every node and cannot be scaled\n", ui.White("Mode:"))
+		return buffer.String()
+	}
+	running := 0
+	for _, task := range r.tasks {
+		if task.ServiceID == service.ID && task.Status.State == swarm.TaskStateRunning {
+			running++
+		}
+	}
+	fmt.Fprintf(buffer, "%s %d\n", ui.White("Desired replicas:"), *service.Spec.Mode.Replicated.Replicas)
+	fmt.Fprintf(buffer, "%s %d\n", ui.White("Running tasks:"), running)
+	if previous := service.PreviousSpec; previous != nil && previous.Mode.Replicated != nil && previous.Mode.Replicated.Replicas != nil {
+		fmt.Fprintf(buffer, "%s %d\n", ui.White("Replicas before the last update:"), *previous.Mode.Replicated.Replicas)
+	}
+	if r.presets != "" {
+		fmt.Fprintf(buffer, "%s %s\n", ui.White("Scale presets:"), r.presets)
+	}
+	buffer.WriteString("\n")
+	if len(r.changes) == 0 {
+		buffer.WriteString(ui.Yellow("No replica changes seen since dry started"))
+		buffer.WriteString("\n")
+		return buffer.String()
+	}
+	buffer.WriteString(ui.Yellow("Replica changes seen since dry started, most recent first"))
+	buffer.WriteString("\n\n")
+	w := tabwriter.NewWriter(buffer, 0, 8, 3, ' ', 0)
+	fmt.Fprintf(w, "%s\t%s\t%s\n", ui.Blue("TIME"), ui.Blue("AGO"), ui.Blue("REPLICAS"))
+	for i := len(r.changes) - 1; i >= 0; i-- {
+		c := r.changes[i]
+		fmt.Fprintf(w, "%s\t%s ago\t%d -> %d %s\n",
+			c.Time.Format("2006-01-02 15:04:05"),
+			units.HumanDuration(docker.Since(c.Time)),
+			c.Old, c.New, replicaTrend(c))
+	}
+	w.Flush()
+	return buffer.String()
+}
+
+func replicaTrend(c docker.ReplicaChange) string {
+	if c.New > c.Old {
+		return "<green>▲</>"
+	} else if c.New < c.Old {
+		return ui.Red("▼")
+	}
+	return ""
+}
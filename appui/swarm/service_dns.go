@@ -0,0 +1,62 @@
+package swarm
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+)
+
+type serviceDNSRenderer struct {
+	report      *docker.ServiceDNSReport
+	networkName string
+}
+
+//NewServiceDNSReportRenderer creates a renderer for the result of resolving
+//the names of a service from the given network
+func NewServiceDNSReportRenderer(report *docker.ServiceDNSReport, networkName string) fmt.Stringer {
+	return &serviceDNSRenderer{report: report, networkName: networkName}
+}
+
+func (r *serviceDNSRenderer) String() string {
+	report := r.report
+	buffer := new(bytes.Buffer)
+	fmt.Fprintf(buffer, "%s %s\n", ui.White("Service:"), report.Service)
+	fmt.Fprintf(buffer, "%s %s\n", ui.White("Network:"), r.networkName)
+	fmt.Fprintf(buffer, "%s %s\n", ui.White("Endpoint mode:"), report.Mode)
+	if report.Mode != swarm.ResolutionModeDNSRR {
+		fmt.Fprintf(buffer, "%s %s\n", ui.White("VIPs:"), addresses(report.VIPs))
+	}
+	fmt.Fprintf(buffer, "%s %s\n", ui.White("Running task IPs:"), addresses(report.TaskIPs))
+
+	for _, lookup := range report.Lookups {
+		buffer.WriteString("\n")
+		status := "<green>OK</>"
+		if !lookup.OK() {
+			status = ui.Red("MISMATCH")
+		}
+		fmt.Fprintf(buffer, "%s %s\n", ui.Cyan("nslookup "+lookup.Name), status)
+		if lookup.Error != "" {
+			fmt.Fprintf(buffer, "  %s\n", ui.Red(lookup.Error))
+		}
+		fmt.Fprintf(buffer, "  Resolved:   %s\n", addresses(lookup.Addresses))
+		fmt.Fprintf(buffer, "  Expected:   %s\n", addresses(lookup.Expected))
+		if missing := lookup.Missing(); len(missing) > 0 {
+			fmt.Fprintf(buffer, "  Missing:    %s\n", ui.Red(strings.Join(missing, ", ")))
+		}
+		if unexpected := lookup.Unexpected(); len(unexpected) > 0 {
+			fmt.Fprintf(buffer, "  Unexpected: %s\n", ui.Yellow(strings.Join(unexpected, ", ")))
+		}
+	}
+	return buffer.String()
+}
+
+func addresses(addrs []string) string {
+	if len(addrs) == 0 {
+		return "-"
+	}
+	return strings.Join(addrs, ", ")
+}
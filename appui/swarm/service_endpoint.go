@@ -0,0 +1,125 @@
+package swarm
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/moncho/dry/ui"
+)
+
+//ServiceEndpoint renders the endpoint of the given service: its endpoint
+//mode, the virtual IP it has on each network, named as given, and its
+//published ports. The ports every service of the swarm publishes on the
+//ingress routing mesh are rendered too.
+func ServiceEndpoint(service swarm.Service, networkNames map[string]string, services []swarm.Service) string {
+	buffer := new(bytes.Buffer)
+	fmt.Fprintf(buffer, "%s %s\n", ui.White("Service:"), service.Spec.Name)
+	mode := swarm.ResolutionModeVIP
+	if service.Spec.EndpointSpec != nil && service.Spec.EndpointSpec.Mode != "" {
+		mode = service.Spec.EndpointSpec.Mode
+	}
+	fmt.Fprintf(buffer, "%s %s\n", ui.White("Endpoint mode:"), mode)
+
+	buffer.WriteString("\n" + ui.White("Virtual IPs") + "\n")
+	if len(service.Endpoint.VirtualIPs) == 0 {
+		if mode == swarm.ResolutionModeDNSRR {
+			buffer.WriteString("None, task IPs are resolved with DNS round robin\n")
+		} else {
+			buffer.WriteString("None\n")
+		}
+	} else {
+		w := tabwriter.NewWriter(buffer, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NETWORK\tVIP")
+		for _, vip := range service.Endpoint.VirtualIPs {
+			name := networkNames[vip.NetworkID]
+			if name == "" {
+				name = vip.NetworkID
+			}
+			fmt.Fprintf(w, "%s\t%s\n", name, vip.Addr)
+		}
+		w.Flush()
+	}
+
+	buffer.WriteString("\n" + ui.White("Published ports") + "\n")
+	if len(service.Endpoint.Ports) == 0 {
+		buffer.WriteString("None\n")
+	} else {
+		w := tabwriter.NewWriter(buffer, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "PUBLISHED\tTARGET\tPROTOCOL\tMODE\tNAME")
+		for _, p := range sortedPorts(service.Endpoint.Ports) {
+			fmt.Fprintf(w, "%d\t%d\t%s\t%s\t%s\n", p.PublishedPort, p.TargetPort, p.Protocol, publishMode(p), dash(p.Name))
+		}
+		w.Flush()
+	}
+
+	buffer.WriteString("\n" + ui.White("Ingress routing mesh") + "\n")
+	ingress := ingressPorts(services)
+	if len(ingress) == 0 {
+		buffer.WriteString("No service publishes ports on the routing mesh\n")
+		return buffer.String()
+	}
+	w := tabwriter.NewWriter(buffer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  PUBLISHED\tPROTOCOL\tSERVICE\tTARGET")
+	for _, p := range ingress {
+		//the ports of the service are marked
+		marker := " "
+		if p.service == service.Spec.Name {
+			marker = "*"
+		}
+		fmt.Fprintf(w, "%s %d\t%s\t%s\t%d\n", marker, p.PublishedPort, p.Protocol, p.service, p.TargetPort)
+	}
+	w.Flush()
+	return buffer.String()
+}
+
+//servicePort is a port published by a service
+type servicePort struct {
+	swarm.PortConfig
+	service string
+}
+
+//ingressPorts returns the ports the given services publish on the ingress
+//routing mesh, sorted by port
+func ingressPorts(services []swarm.Service) []servicePort {
+	var ports []servicePort
+	for _, s := range services {
+		for _, p := range s.Endpoint.Ports {
+			if publishMode(p) == swarm.PortConfigPublishModeIngress {
+				ports = append(ports, servicePort{p, s.Spec.Name})
+			}
+		}
+	}
+	sort.SliceStable(ports, func(i, j int) bool {
+		if ports[i].PublishedPort != ports[j].PublishedPort {
+			return ports[i].PublishedPort < ports[j].PublishedPort
+		}
+		return ports[i].Protocol < ports[j].Protocol
+	})
+	return ports
+}
+
+func sortedPorts(ports []swarm.PortConfig) []swarm.PortConfig {
+	sorted := append([]swarm.PortConfig(nil), ports...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].PublishedPort < sorted[j].PublishedPort
+	})
+	return sorted
+}
+
+//publishMode returns the publish mode of the given port, ingress if not set
+func publishMode(p swarm.PortConfig) swarm.PortConfigPublishMode {
+	if p.PublishMode == "" {
+		return swarm.PortConfigPublishModeIngress
+	}
+	return p.PublishMode
+}
+
+func dash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
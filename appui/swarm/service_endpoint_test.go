@@ -0,0 +1,51 @@
+package swarm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/swarm"
+)
+
+func TestIngressPorts(t *testing.T) {
+	services := []swarm.Service{
+		{
+			Spec: swarm.ServiceSpec{Annotations: swarm.Annotations{Name: "web"}},
+			Endpoint: swarm.Endpoint{Ports: []swarm.PortConfig{
+				{Protocol: swarm.PortConfigProtocolTCP, TargetPort: 80, PublishedPort: 8080},
+				{Protocol: swarm.PortConfigProtocolTCP, TargetPort: 443, PublishedPort: 443, PublishMode: swarm.PortConfigPublishModeHost},
+			}},
+		},
+		{
+			Spec: swarm.ServiceSpec{Annotations: swarm.Annotations{Name: "dns"}},
+			Endpoint: swarm.Endpoint{Ports: []swarm.PortConfig{
+				{Protocol: swarm.PortConfigProtocolUDP, TargetPort: 53, PublishedPort: 53, PublishMode: swarm.PortConfigPublishModeIngress},
+			}},
+		},
+	}
+	ports := ingressPorts(services)
+	if len(ports) != 2 {
+		t.Fatalf("expected 2 ingress ports, got %d", len(ports))
+	}
+	if ports[0].service != "dns" || ports[0].PublishedPort != 53 {
+		t.Errorf("unexpected first port %+v", ports[0])
+	}
+	if ports[1].service != "web" || ports[1].PublishedPort != 8080 {
+		t.Errorf("unexpected second port %+v", ports[1])
+	}
+}
+
+func TestServiceEndpoint(t *testing.T) {
+	service := swarm.Service{
+		Spec: swarm.ServiceSpec{
+			Annotations:  swarm.Annotations{Name: "web"},
+			EndpointSpec: &swarm.EndpointSpec{Mode: swarm.ResolutionModeDNSRR},
+		},
+	}
+	out := ServiceEndpoint(service, nil, []swarm.Service{service})
+	for _, expected := range []string{"dnsrr", "DNS round robin", "No service publishes ports"} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected %q on the service endpoint, got:\n%s", expected, out)
+		}
+	}
+}
@@ -19,12 +19,14 @@ type ServiceRow struct {
 	Replicas     *drytermui.ParColumn
 	Image        *drytermui.ParColumn
 	ServicePorts *drytermui.ParColumn
+	Labels       []*drytermui.ParColumn
 
 	appui.Row
 }
 
-//NewServiceRow creats a new ServiceRow widget
-func NewServiceRow(service swarm.Service, serviceInfo ServiceListInfo, table drytermui.Table) *ServiceRow {
+//NewServiceRow creats a new ServiceRow widget, the values of the given
+//labels are shown as extra columns
+func NewServiceRow(service swarm.Service, serviceInfo ServiceListInfo, table drytermui.Table, labels ...string) *ServiceRow {
 	row := &ServiceRow{
 		service:  service,
 		ID:       drytermui.NewThemedParColumn(appui.DryTheme, service.ID),
@@ -34,6 +36,7 @@ func NewServiceRow(service swarm.Service, serviceInfo ServiceListInfo, table dry
 		Image: drytermui.NewThemedParColumn(
 			appui.DryTheme, serviceImage(service)),
 		ServicePorts: drytermui.NewThemedParColumn(appui.DryTheme, dryformatter.FormatPorts(service.Spec.EndpointSpec.Ports)),
+		Labels:       appui.NewLabelColumns(service.Spec.Labels, labels),
 	}
 	row.Height = 1
 	row.Table = table
@@ -52,13 +55,17 @@ func NewServiceRow(service swarm.Service, serviceInfo ServiceListInfo, table dry
 		row.ServicePorts,
 		row.Image,
 	}
+	for _, c := range row.Labels {
+		row.Columns = append(row.Columns, c)
+		row.ParColumns = append(row.ParColumns, c)
+	}
 	return row
 
 }
 
 //ColumnsForFilter returns the columns that are used to filter
 func (row *ServiceRow) ColumnsForFilter() []*drytermui.ParColumn {
-	return []*drytermui.ParColumn{row.Name, row.Image, row.Mode}
+	return append([]*drytermui.ParColumn{row.Name, row.Image, row.Mode}, row.Labels...)
 }
 
 func serviceImage(service swarm.Service) string {
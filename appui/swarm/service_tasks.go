@@ -44,13 +44,8 @@ func (s *ServiceTasksWidget) Buffer() gizaktermui.Buffer {
 	s.info.SetY(y)
 	buf.Merge(s.info.Buffer())
 	y += s.info.GetHeight()
-	var filter string
-	if s.filterPattern != "" {
-		filter = fmt.Sprintf(
-			"<b><blue> | Active filter: </><yellow>%s</></> ", s.filterPattern)
-	}
 	s.tableTitle.Content(fmt.Sprintf(
-		"<b><blue>Service %s tasks: </><yellow>%d</></>", s.info.serviceName, s.RowCount()) + " " + filter)
+		"<b><blue>Service %s tasks: </><yellow>%d</></>", s.info.serviceName, s.RowCount()) + " " + s.filtersTitle())
 
 	s.tableTitle.Y = y
 	buf.Merge(s.tableTitle.Buffer())
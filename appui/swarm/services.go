@@ -37,6 +37,7 @@ type ServicesWidget struct {
 	sortMode             docker.SortMode
 	swarmClient          docker.SwarmAPI
 	totalRows            []*ServiceRow
+	labelColumns         []string
 
 	sync.RWMutex
 	mounted bool
@@ -101,6 +102,13 @@ func (s *ServicesWidget) Filter(filter string) {
 	s.filterPattern = filter
 }
 
+//FilterPattern returns the filter applied to this widget
+func (s *ServicesWidget) FilterPattern() string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.filterPattern
+}
+
 //Mount prepares this widget for rendering
 func (s *ServicesWidget) Mount() error {
 	s.Lock()
@@ -113,7 +121,7 @@ func (s *ServicesWidget) Mount() error {
 	var rows []*ServiceRow
 	if services, servicesInfo, err := getServiceInfo(s.swarmClient); err == nil {
 		for _, service := range services {
-			rows = append(rows, NewServiceRow(service, servicesInfo[service.ID], s.header))
+			rows = append(rows, NewServiceRow(service, servicesInfo[service.ID], s.header, s.labelColumns...))
 		}
 	}
 	s.totalRows = rows
@@ -134,6 +142,15 @@ func (s *ServicesWidget) OnEvent(event appui.EventCommand) error {
 	return nil
 }
 
+//SetLabelColumns sets the labels whose values are shown as extra columns
+func (s *ServicesWidget) SetLabelColumns(labels []string) {
+	s.Lock()
+	defer s.Unlock()
+	s.labelColumns = labels
+	s.header = serviceTableHeader(labels...)
+	s.mounted = false
+}
+
 //RowCount returns the number of rowns of this widget.
 func (s *ServicesWidget) RowCount() int {
 	return len(s.filteredRows)
@@ -178,19 +195,14 @@ func (s *ServicesWidget) align() {
 }
 
 func (s *ServicesWidget) filterRows() {
-
-	if s.filterPattern != "" {
-		var rows []*ServiceRow
-
-		for _, row := range s.totalRows {
-			if appui.RowFilters.ByPattern(s.filterPattern)(row) {
-				rows = append(rows, row)
-			}
+	filter := appui.RowFilters.ByFuzzyPattern(s.filterPattern)
+	var rows []*ServiceRow
+	for _, row := range s.totalRows {
+		if filter(row) {
+			rows = append(rows, row)
 		}
-		s.filteredRows = rows
-	} else {
-		s.filteredRows = s.totalRows
 	}
+	s.filteredRows = rows
 }
 
 func (s *ServicesWidget) calculateVisibleRows() {
@@ -297,7 +309,7 @@ func (s *ServicesWidget) sortRows() {
 	sort.SliceStable(rows, sortAlg)
 }
 
-func serviceTableHeader() *termui.TableHeader {
+func serviceTableHeader(labels ...string) *termui.TableHeader {
 
 	header := termui.NewHeader(appui.DryTheme)
 	header.ColumnSpacing = appui.DefaultColumnSpacing
@@ -306,6 +318,7 @@ func serviceTableHeader() *termui.TableHeader {
 	header.AddFixedWidthColumn(serviceTableHeaders[2].Title, 10)
 	header.AddColumn(serviceTableHeaders[3].Title)
 	header.AddColumn(serviceTableHeaders[4].Title)
+	appui.AddLabelColumns(header, labels)
 
 	return header
 }
@@ -41,13 +41,8 @@ func (s *StacksTasksWidget) Buffer() gizaktermui.Buffer {
 	y := s.screen.Bounds().Min.Y
 
 	s.prepareForRendering()
-	var filter string
-	if s.filterPattern != "" {
-		filter = fmt.Sprintf(
-			"<b><blue> | Active filter: </><yellow>%s</></> ", s.filterPattern)
-	}
 	s.tableTitle.Content(fmt.Sprintf(
-		"<b><blue>Stack %s tasks: </><yellow>%d</></>", s.stack, s.RowCount()) + " " + filter)
+		"<b><blue>Stack %s tasks: </><yellow>%d</></>", s.stack, s.RowCount()) + " " + s.filtersTitle())
 
 	s.tableTitle.Y = y
 	buf.Merge(s.tableTitle.Buffer())
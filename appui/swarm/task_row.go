@@ -1,6 +1,7 @@
 package swarm
 
 import (
+	"fmt"
 	"image"
 
 	"github.com/docker/docker/api/types/swarm"
@@ -37,7 +38,7 @@ func NewTaskRow(swarmClient docker.SwarmAPI, task swarm.Task, table drytermui.Ta
 		Node:         drytermui.NewThemedParColumn(appui.DryTheme, ts.NodeID()),
 		DesiredState: drytermui.NewThemedParColumn(appui.DryTheme, ts.DesiredState()),
 		CurrentState: drytermui.NewThemedParColumn(appui.DryTheme, ts.CurrentState()),
-		Error:        drytermui.NewThemedParColumn(appui.DryTheme, ts.Error()),
+		Error:        drytermui.NewThemedParColumn(appui.DryTheme, taskError(task, ts)),
 		Ports:        drytermui.NewThemedParColumn(appui.DryTheme, ts.Ports()),
 	}
 	row.Height = 1
@@ -100,12 +101,28 @@ func (row *TaskRow) changeTextColor(fg, bg termui.Attribute) {
 	row.Node.TextBgColor = bg
 	row.DesiredState.TextBgColor = bg
 	row.CurrentState.TextBgColor = bg
-	row.Error.TextFgColor = fg
+	if !failed(row.task) {
+		row.Error.TextFgColor = fg
+	}
 	row.Error.TextBgColor = bg
 	row.Ports.TextFgColor = fg
 	row.Ports.TextBgColor = bg
 }
 
+//taskError returns the error of the given task, failed and rejected tasks
+//show when they reached that state too
+func taskError(task swarm.Task, ts *formatter.TaskStringer) string {
+	if !failed(task) {
+		return ts.Error()
+	}
+	return fmt.Sprintf("%s %s", task.Status.Timestamp.Format("2006-01-02 15:04:05"), ts.Error())
+}
+
+//failed returns true if the given task failed or was rejected
+func failed(task swarm.Task) bool {
+	return task.Status.State == swarm.TaskStateFailed || task.Status.State == swarm.TaskStateRejected
+}
+
 //updateStateColumns changes the color of state-related column depending
 //on the task state
 func updateStateColumns(row *TaskRow) {
@@ -118,5 +135,9 @@ func updateStateColumns(row *TaskRow) {
 	row.DesiredState.TextFgColor = color
 	row.CurrentState.TextFgColor = color
 	row.Error.TextFgColor = color
+	if failed(row.task) {
+		row.CurrentState.TextFgColor = appui.NotRunning
+		row.Error.TextFgColor = appui.NotRunning
+	}
 
 }
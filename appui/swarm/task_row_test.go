@@ -2,6 +2,7 @@ package swarm
 
 import (
 	"testing"
+	"time"
 
 	"github.com/docker/docker/api/types/swarm"
 	"github.com/moncho/dry/docker/formatter"
@@ -50,3 +51,26 @@ func TestTaskRow(t *testing.T) {
 
 	}
 }
+
+func TestTaskRowFailedTaskShowsWhenItFailed(t *testing.T) {
+	timestamp := time.Date(2026, 10, 17, 7, 48, 17, 0, time.UTC)
+	task := swarm.Task{
+		ID:        "task1",
+		ServiceID: "1",
+		Spec: swarm.TaskSpec{
+			ContainerSpec: &swarm.ContainerSpec{},
+		},
+		NodeID: "1",
+		Status: swarm.TaskStatus{
+			State:     swarm.TaskStateRejected,
+			Timestamp: timestamp,
+			Err:       "no suitable node",
+		},
+	}
+	row := NewTaskRow(&mocks.SwarmDockerDaemon{}, task, taskTableHeader())
+
+	expected := "2026-10-17 07:48:17 \"no suitable node\""
+	if row.Error.Text != expected {
+		t.Errorf("Unexpected TaskRow error, got %s, expected %s", row.Error.Text, expected)
+	}
+}
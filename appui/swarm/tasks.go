@@ -1,22 +1,71 @@
 package swarm
 
 import (
+	"fmt"
 	"sort"
 	"strings"
 	"sync"
 
+	"github.com/docker/docker/api/types/swarm"
 	gizaktermui "github.com/gizak/termui"
 	"github.com/moncho/dry/appui"
 	"github.com/moncho/dry/docker"
 	"github.com/moncho/dry/ui/termui"
 )
 
+//TaskStateFilter selects the tasks listed by their desired and current state
+type TaskStateFilter int
+
+const (
+	//AllTasks lists every task
+	AllTasks TaskStateFilter = iota
+	//DesiredRunningTasks lists the tasks meant to be running, i.e. not the
+	//historical ones
+	DesiredRunningTasks
+	//RunningTasks lists the tasks that are running
+	RunningTasks
+	//FailedTasks lists the tasks that failed or were rejected
+	FailedTasks
+	//ShutdownTasks lists the tasks that were shut down or completed
+	ShutdownTasks
+)
+
+func (f TaskStateFilter) String() string {
+	switch f {
+	case DesiredRunningTasks:
+		return "desired running"
+	case RunningTasks:
+		return "running"
+	case FailedTasks:
+		return "failed"
+	case ShutdownTasks:
+		return "shutdown"
+	}
+	return "all"
+}
+
+//matches returns true if the given task is listed with this filter
+func (f TaskStateFilter) matches(task swarm.Task) bool {
+	switch f {
+	case DesiredRunningTasks:
+		return task.DesiredState == swarm.TaskStateRunning
+	case RunningTasks:
+		return task.Status.State == swarm.TaskStateRunning
+	case FailedTasks:
+		return failed(task)
+	case ShutdownTasks:
+		return task.Status.State == swarm.TaskStateShutdown || task.Status.State == swarm.TaskStateComplete
+	}
+	return true
+}
+
 //TasksWidget shows a service's task information
 type TasksWidget struct {
 	header               *termui.TableHeader
 	filteredRows         []*TaskRow
 	totalRows            []*TaskRow
 	filterPattern        string
+	stateFilter          TaskStateFilter
 	offset               int
 	screen               appui.Screen
 	selectedIndex        int
@@ -36,6 +85,16 @@ func (s *TasksWidget) Filter(filter string) {
 	s.filterPattern = filter
 }
 
+//CycleStateFilter rotates to the next state filter and returns it.
+//AllTasks -> DesiredRunningTasks -> RunningTasks -> FailedTasks -> ShutdownTasks -> AllTasks
+func (s *TasksWidget) CycleStateFilter() TaskStateFilter {
+	s.Lock()
+	defer s.Unlock()
+	s.stateFilter = (s.stateFilter + 1) % (ShutdownTasks + 1)
+	s.screen.Cursor().Reset()
+	return s.stateFilter
+}
+
 //OnEvent runs the given command
 func (s *TasksWidget) OnEvent(event appui.EventCommand) error {
 	if s.RowCount() > 0 {
@@ -96,11 +155,11 @@ func (s *TasksWidget) align() {
 
 func (s *TasksWidget) filterRows() {
 
-	if s.filterPattern != "" {
+	if s.filterPattern != "" || s.stateFilter != AllTasks {
 		var rows []*TaskRow
-
+		filter := appui.RowFilters.ByPattern(s.filterPattern)
 		for _, row := range s.totalRows {
-			if appui.RowFilters.ByPattern(s.filterPattern)(row) {
+			if s.stateFilter.matches(row.task) && (s.filterPattern == "" || filter(row)) {
 				rows = append(rows, row)
 			}
 		}
@@ -110,6 +169,21 @@ func (s *TasksWidget) filterRows() {
 	}
 }
 
+//filtersTitle returns the description of the filters applied to the task
+//list, shown on its title
+func (s *TasksWidget) filtersTitle() string {
+	var title string
+	if s.stateFilter != AllTasks {
+		title = fmt.Sprintf(
+			"<b><blue> | Showing: </><yellow>%s</></> ", s.stateFilter)
+	}
+	if s.filterPattern != "" {
+		title += fmt.Sprintf(
+			"<b><blue> | Active filter: </><yellow>%s</></> ", s.filterPattern)
+	}
+	return title
+}
+
 func (s *TasksWidget) calculateVisibleRows() {
 
 	height := s.screen.Bounds().Dy() - widgetHeaderLength
@@ -0,0 +1,40 @@
+package swarm
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/swarm"
+)
+
+func TestTaskStateFilter(t *testing.T) {
+	running := swarm.Task{DesiredState: swarm.TaskStateRunning, Status: swarm.TaskStatus{State: swarm.TaskStateRunning}}
+	starting := swarm.Task{DesiredState: swarm.TaskStateRunning, Status: swarm.TaskStatus{State: swarm.TaskStatePreparing}}
+	failedTask := swarm.Task{DesiredState: swarm.TaskStateShutdown, Status: swarm.TaskStatus{State: swarm.TaskStateFailed}}
+	rejected := swarm.Task{DesiredState: swarm.TaskStateShutdown, Status: swarm.TaskStatus{State: swarm.TaskStateRejected}}
+	shutdown := swarm.Task{DesiredState: swarm.TaskStateShutdown, Status: swarm.TaskStatus{State: swarm.TaskStateShutdown}}
+	tasks := []swarm.Task{running, starting, failedTask, rejected, shutdown}
+
+	tests := []struct {
+		filter TaskStateFilter
+		want   int
+	}{
+		{AllTasks, 5},
+		{DesiredRunningTasks, 2},
+		{RunningTasks, 1},
+		{FailedTasks, 2},
+		{ShutdownTasks, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter.String(), func(t *testing.T) {
+			got := 0
+			for _, task := range tasks {
+				if tt.filter.matches(task) {
+					got++
+				}
+			}
+			if got != tt.want {
+				t.Errorf("%s filter matched %d tasks, want %d", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
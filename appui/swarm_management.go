@@ -0,0 +1,120 @@
+package appui
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"sync"
+
+	dockerTypes "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/swarm"
+)
+
+//defaultSwarmPort is the port used by managers to listen for nodes joining the swarm
+const defaultSwarmPort = "2377"
+
+//SwarmManagementRenderer renders the state of the swarm the Docker host is
+//part of, including the commands to join new nodes to it
+type SwarmManagementRenderer struct {
+	info  dockerTypes.Info
+	swarm *swarm.Swarm
+	sync.RWMutex
+}
+
+//NewSwarmManagementRenderer creates a SwarmManagementRenderer
+func NewSwarmManagementRenderer() *SwarmManagementRenderer {
+	return &SwarmManagementRenderer{}
+}
+
+//PrepareToRender passes the data to be rendered, swarm information is only
+//available on managers, so it might be nil
+func (r *SwarmManagementRenderer) PrepareToRender(info dockerTypes.Info, sw *swarm.Swarm) {
+	r.Lock()
+	defer r.Unlock()
+	r.info = info
+	r.swarm = sw
+}
+
+//JoinCommand returns the command to run on a Docker host to join it to the
+//swarm, as a manager or as a worker. Returns false if the command cannot be
+//built, i.e. the Docker host is not a swarm manager.
+func (r *SwarmManagementRenderer) JoinCommand(manager bool) (string, bool) {
+	r.RLock()
+	defer r.RUnlock()
+	return r.joinCommand(manager)
+}
+
+//String renders the swarm state and the join commands
+func (r *SwarmManagementRenderer) String() string {
+	r.RLock()
+	defer r.RUnlock()
+	buffer := new(bytes.Buffer)
+	info := r.info.Swarm
+
+	buffer.WriteString("<white>Swarm</>\n")
+	writeKV(buffer, "Node state", info.LocalNodeState)
+	if info.LocalNodeState == swarm.LocalNodeStateInactive {
+		buffer.WriteString("\n This Docker host is not part of a swarm.\n")
+		buffer.WriteString(" Press <white>i</> to initialize a new swarm or <white>j</> to join an existing one.\n")
+		return buffer.String()
+	}
+	writeKVIfNotEmpty(buffer, "Node ID", info.NodeID)
+	writeKVIfNotEmpty(buffer, "Node address", info.NodeAddr)
+	writeKV(buffer, "Is manager", info.ControlAvailable)
+	if info.Error != "" {
+		writeKV(buffer, "Error", info.Error)
+	}
+	if !info.ControlAvailable {
+		buffer.WriteString("\n Join tokens are only available on managers.\n")
+		return buffer.String()
+	}
+	writeKVIfNotEmpty(buffer, "Cluster ID", info.Cluster.ID)
+	writeKV(buffer, "Managers", info.Managers)
+	writeKV(buffer, "Nodes", info.Nodes)
+
+	if worker, ok := r.joinCommand(false); ok {
+		buffer.WriteString("\n<white>To add a worker to this swarm, run:</>\n")
+		buffer.WriteString(fmt.Sprintf("   %s\n", worker))
+	}
+	if manager, ok := r.joinCommand(true); ok {
+		buffer.WriteString("\n<white>To add a manager to this swarm, run:</>\n")
+		buffer.WriteString(fmt.Sprintf("   %s\n", manager))
+	}
+	return buffer.String()
+}
+
+//joinCommand is JoinCommand without locking
+func (r *SwarmManagementRenderer) joinCommand(manager bool) (string, bool) {
+	if r.swarm == nil {
+		return "", false
+	}
+	token := r.swarm.JoinTokens.Worker
+	if manager {
+		token = r.swarm.JoinTokens.Manager
+	}
+	addr := managerAddr(r.info)
+	if token == "" || addr == "" {
+		return "", false
+	}
+	return SwarmJoinCommand(token, addr), true
+}
+
+//SwarmJoinCommand returns the docker command to join a swarm with the given
+//token through the manager on the given address
+func SwarmJoinCommand(token, addr string) string {
+	return fmt.Sprintf("docker swarm join --token %s %s", token, addr)
+}
+
+//managerAddr returns the address other nodes can use to reach the Docker host
+//as a swarm manager
+func managerAddr(info dockerTypes.Info) string {
+	for _, peer := range info.Swarm.RemoteManagers {
+		if peer.NodeID == info.Swarm.NodeID {
+			return peer.Addr
+		}
+	}
+	if info.Swarm.NodeAddr == "" {
+		return ""
+	}
+	return net.JoinHostPort(info.Swarm.NodeAddr, defaultSwarmPort)
+}
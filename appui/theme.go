@@ -76,7 +76,83 @@ var Light256 = &ui.ColorTheme{
 	Cursor:       ui.Color161,
 	Selected:     ui.Color168,
 	Header:       ui.Color31,
-	Footer:       ui.Color31}
+	Footer:       ui.Color31,
+	ListItem:     ui.Color238,
+	CursorLineBg: ui.Color153,
+	//Colors that fit a dark background are darkened
+	Markup: map[string]ui.Color{
+		"green":    ui.Color28,
+		"yellow":   ui.Color130,
+		"blue":     ui.Color25,
+		"cyan":     ui.Color30,
+		"cyan0":    ui.Color95,
+		"white":    ui.Color236,
+		"darkgrey": ui.Color250,
+	}}
+
+//Solarized256 solarized dark theme for 256-color mode
+var Solarized256 = &ui.ColorTheme{
+	Fg:           ui.Color244,
+	Bg:           ui.Color234,
+	DarkBg:       ui.Color235,
+	Prompt:       ui.Color33,
+	Key:          ui.Color37,
+	Current:      ui.Color245,
+	CurrentMatch: ui.Color64,
+	Spinner:      ui.Color136,
+	Info:         ui.Color240,
+	Cursor:       ui.Color160,
+	Selected:     ui.Color125,
+	Header:       ui.Color235,
+	Footer:       ui.Color235,
+	ListItem:     ui.Color244,
+	CursorLineBg: ui.Color236,
+	Markup: map[string]ui.Color{
+		"red":      ui.Color160,
+		"red00":    ui.Color160,
+		"green":    ui.Color64,
+		"yellow":   ui.Color136,
+		"blue":     ui.Color33,
+		"magenta":  ui.Color125,
+		"cyan":     ui.Color37,
+		"cyan0":    ui.Color37,
+		"white":    ui.Color245,
+		"grey":     ui.Color240,
+		"grey2":    ui.Color244,
+		"darkgrey": ui.Color235,
+	}}
+
+//Monochrome grayscale theme for 256-color mode
+var Monochrome = &ui.ColorTheme{
+	Fg:           ui.Color252,
+	Bg:           ui.ColorBlack,
+	DarkBg:       ui.ColorBlack,
+	Prompt:       ui.Color255,
+	Key:          ui.Color255,
+	Current:      ui.Color255,
+	CurrentMatch: ui.Color255,
+	Spinner:      ui.Color250,
+	Info:         ui.Color250,
+	Cursor:       ui.Color255,
+	Selected:     ui.Color250,
+	Header:       ui.Color238,
+	Footer:       ui.Color238,
+	ListItem:     ui.Color250,
+	CursorLineBg: ui.Color240,
+	Markup: map[string]ui.Color{
+		"red":      ui.Color255,
+		"red00":    ui.Color255,
+		"green":    ui.Color255,
+		"yellow":   ui.Color255,
+		"blue":     ui.Color250,
+		"magenta":  ui.Color255,
+		"cyan":     ui.Color250,
+		"cyan0":    ui.Color250,
+		"white":    ui.Color255,
+		"grey":     ui.Color245,
+		"grey2":    ui.Color250,
+		"darkgrey": ui.Color238,
+	}}
 
 //DryTheme is the active theme for dry
 var DryTheme = Dark256
@@ -84,6 +160,23 @@ var DryTheme = Dark256
 //ColorThemes holds the list of dry color themes
 var ColorThemes = []*ui.ColorTheme{Black256, Dark256}
 
+//colorThemeNames are the names dry color themes can be selected by
+var colorThemeNames = map[string]*ui.ColorTheme{
+	"16":         Default16,
+	"black":      Black256,
+	"dark":       Dark256,
+	"light":      Light256,
+	"solarized":  Solarized256,
+	"monochrome": Monochrome,
+}
+
+//ColorThemeByName returns the color theme with the given name, one of
+//16, black, dark, light, solarized or monochrome
+func ColorThemeByName(name string) (*ui.ColorTheme, bool) {
+	theme, ok := colorThemeNames[name]
+	return theme, ok
+}
+
 //RotateColorTheme changes the color theme to the next one in the
 //rotation order.
 func RotateColorTheme() {
@@ -15,6 +15,13 @@ type FilterableWidget interface {
 	Filter(filter string)
 }
 
+//IncrementalFilterableWidget interface defines how widgets filter as the
+//filter is typed
+type IncrementalFilterableWidget interface {
+	FilterableWidget
+	FilterPattern() string
+}
+
 //SortableWidget interface defines how widgets sort
 type SortableWidget interface {
 	Sort()
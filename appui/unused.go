@@ -0,0 +1,21 @@
+package appui
+
+import (
+	"time"
+
+	units "github.com/docker/go-units"
+	"github.com/moncho/dry/docker"
+)
+
+//unusedFor returns for how long the object with the given id has been
+//unused, if known.
+func unusedFor(unusedSince func(id string) (time.Time, bool), id string) string {
+	if unusedSince == nil {
+		return ""
+	}
+	since, ok := unusedSince(id)
+	if !ok {
+		return ""
+	}
+	return units.HumanDuration(docker.Since(since))
+}
@@ -8,9 +8,10 @@ import (
 
 // VolumeRow is a Grid row showing information about a Docker volume.
 type VolumeRow struct {
-	volume *types.Volume
-	Driver *drytermui.ParColumn
-	Name   *drytermui.ParColumn
+	volume    *types.Volume
+	Driver    *drytermui.ParColumn
+	Name      *drytermui.ParColumn
+	UnusedFor *drytermui.ParColumn
 	Row
 }
 
@@ -18,9 +19,10 @@ type VolumeRow struct {
 func NewVolumeRow(volume *types.Volume, table drytermui.Table) *VolumeRow {
 
 	row := &VolumeRow{
-		volume: volume,
-		Driver: drytermui.NewThemedParColumn(DryTheme, volume.Driver),
-		Name:   drytermui.NewThemedParColumn(DryTheme, volume.Name),
+		volume:    volume,
+		Driver:    drytermui.NewThemedParColumn(DryTheme, volume.Driver),
+		Name:      drytermui.NewThemedParColumn(DryTheme, volume.Name),
+		UnusedFor: drytermui.NewThemedParColumn(DryTheme, ""),
 	}
 	row.Height = 1
 	row.Table = table
@@ -28,10 +30,12 @@ func NewVolumeRow(volume *types.Volume, table drytermui.Table) *VolumeRow {
 	row.Columns = []termui.GridBufferer{
 		row.Driver,
 		row.Name,
+		row.UnusedFor,
 	}
 	row.ParColumns = []*drytermui.ParColumn{
 		row.Driver,
 		row.Name,
+		row.UnusedFor,
 	}
 
 	return row
@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/moncho/dry/ui/termui"
@@ -28,6 +29,7 @@ var volumesTableHeaders = []SortableColumnHeader{
 	{``, 0},
 	{`DRIVER`, byDriver},
 	{`VOLUME NAME`, byName},
+	{`UNUSED FOR`, 0},
 }
 
 //VolumesWidget shows information containers
@@ -41,6 +43,8 @@ type VolumesWidget struct {
 	startIndex, endIndex int
 	sortBy               SortMode
 	screen               Screen
+	//UnusedSince, if set, is used to show for how long volumes have been unused
+	UnusedSince func(id string) (time.Time, bool)
 
 	sync.RWMutex
 	mounted bool
@@ -122,7 +126,9 @@ func (s *VolumesWidget) Mount() error {
 		return fmt.Errorf("could not retrieve volumes: %s", err.Error())
 	}
 	for _, v := range vv {
-		rows = append(rows, NewVolumeRow(v, s.header))
+		row := NewVolumeRow(v, s.header)
+		row.UnusedFor.Text = unusedFor(s.UnusedSince, v.Name)
+		rows = append(rows, row)
 
 	}
 	s.totalRows = rows
@@ -309,5 +315,6 @@ func volumesTableHeader() *termui.TableHeader {
 	header.ColumnSpacing = DefaultColumnSpacing
 	header.AddColumn(volumesTableHeaders[1].Title)
 	header.AddColumn(volumesTableHeaders[2].Title)
+	header.AddFixedWidthColumn(volumesTableHeaders[3].Title, 12)
 	return header
 }
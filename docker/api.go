@@ -3,6 +3,7 @@ package docker
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	dockerTypes "github.com/docker/docker/api/types"
@@ -23,34 +24,55 @@ type ContainerDaemon interface {
 	ContainerAPI
 	ImageAPI
 	NetworkAPI
+	PluginAPI
 	VolumesAPI
 	SwarmAPI
 	ContainerRuntime
+	Cleanup(opts CleanupOptions) (CleanupReport, error)
+	Close() error
+	DaemonWarnings() ([]string, error)
 	DiskUsage() (types.DiskUsage, error)
 	DockerEnv() Env
 	Events() (<-chan events.Message, chan<- struct{}, error)
+	ContainerEvents(id string, since time.Time) ([]events.Message, error)
 	EventLog() *EventLog
 	Info() (types.Info, error)
 	InspectImage(id string) (types.ImageInspect, error)
 	Ok() (bool, error)
+	Ping() DaemonStatus
 	Prune() (*PruneReport, error)
+	PruneBuildCache(keepStorage int64) (*types.BuildCachePruneReport, error)
 	Rm(id string) error
 	Refresh(notify func(error))
+	RemoveBuildCache(id string) (uint64, error)
 	RemoveNetwork(id string) error
+	CreateNetwork(opts NetworkOptions) (string, error)
+	ConnectToNetwork(networkID, container string, aliases []string, ip string) error
+	DisconnectFromNetwork(networkID, container string) error
+	UnusedSince(source SourceType, id string) (time.Time, bool)
 	Version() (*types.Version, error)
 }
 
 //ContainerAPI is a subset of the Docker API to manage containers
 type ContainerAPI interface {
 	ContainerByID(id string) *Container
+	ContainerFileContent(id, file string, maxSize int64) (string, error)
+	CloneContainer(id, image string, opts RunOptions) (string, error)
+	ContainerFiles(id, dir string) ([]ContainerFile, error)
 	Containers(filter []ContainerFilter, mode SortMode) []*Container
 	Inspect(id string) (types.ContainerJSON, error)
 	IsContainerRunning(id string) bool
 	Kill(id string) error
-	Logs(id string, since string, withTimeStamp bool) (io.ReadCloser, error)
+	Logs(id string, opts LogsOptions) (io.ReadCloser, error)
+	RecreateContainer(id string, pull bool) (string, error)
 	RemoveAllStoppedContainers() (int, error)
 	RestartContainer(id string) error
+	RunHealthcheck(id string) (HealthcheckResult, error)
+	SampleStats() []StatsRecord
 	StopContainer(id string) error
+	StopContainers(ids []string, kill bool) (int, error)
+	UpdateResources(id string, r ContainerResources) error
+	UpdateRestartPolicy(id string, policy container.RestartPolicy) error
 }
 
 //ContainerRuntime is the subset of the Docker API to query container runtime information
@@ -61,13 +83,27 @@ type ContainerRuntime interface {
 
 //ImageAPI is a subset of the Docker API to manage images
 type ImageAPI interface {
+	CheckImageUpdates(images []types.ImageSummary) []ImageUpdate
+	ExportImages(refs []string, path string, format ImageExportFormat) error
 	History(id string) ([]image.HistoryResponseItem, error)
 	ImageByID(id string) (types.ImageSummary, error)
 	Images() ([]types.ImageSummary, error)
+	PullEstimate(image string) (PullEstimate, error)
+	PullImage(image string) error
+	PullImageWithAuth(image string, auth RegistryAuth) error
 	RemoveDanglingImages() (int, error)
+	RemoveImages(ids []string, force bool, removed func(id string, err error))
 	RemoveUnusedImages() (int, error)
 	Rmi(id string, force bool) ([]types.ImageDeleteResponseItem, error)
-	RunImage(image types.ImageSummary, command string) error
+	RunImage(image types.ImageSummary, opts RunOptions) (string, error)
+}
+
+//PluginAPI is a subset of the Docker API to manage plugins
+type PluginAPI interface {
+	Plugins() ([]*types.Plugin, error)
+	PluginInspect(name string) (*types.Plugin, error)
+	PluginEnable(name string) error
+	PluginDisable(name string, force bool) error
 }
 
 //NetworkAPI is a subset of the Docker API to manage networks
@@ -78,14 +114,21 @@ type NetworkAPI interface {
 
 //SwarmAPI defines the API for Docker Swarm
 type SwarmAPI interface {
+	ConfigContent(id string) ([]byte, error)
+	ImagePrePull(image, nodeLabel string, report func(PrePullResult)) error
 	Node(id string) (*swarm.Node, error)
 	NodeChangeAvailability(nodeID string, availability swarm.NodeAvailability) error
+	NodeChangeLabels(nodeID string, labels map[string]string) error
+	NodeChangeRole(nodeID string, role swarm.NodeRole) error
 	Nodes() ([]swarm.Node, error)
 	NodeTasks(nodeID string) ([]swarm.Task, error)
 	ResolveNode(id string) (string, error)
 	ResolveService(id string) (string, error)
 	Service(id string) (*swarm.Service, error)
-	ServiceLogs(id string, since string, withTimeStamps bool) (io.ReadCloser, error)
+	ServiceChangeLabels(id string, labels map[string]string) error
+	ServiceChangePlacement(id string, constraints []string, preferences []swarm.PlacementPreference) error
+	ServiceDNSLookup(serviceID, networkID string) (*ServiceDNSReport, error)
+	ServiceLogs(id string, opts LogsOptions) (io.ReadCloser, error)
 	Services() ([]swarm.Service, error)
 	ServiceRemove(id string) error
 	ServiceScale(id string, replicas uint64) error
@@ -97,7 +140,14 @@ type SwarmAPI interface {
 	StackRemove(id string) error
 	StackSecrets(stack string) ([]swarm.Secret, error)
 	StackTasks(stack string) ([]swarm.Task, error)
+	SwarmInit(advertiseAddr string) (string, error)
+	SwarmInspect() (swarm.Swarm, error)
+	SwarmJoin(token, managerAddr string) error
+	SwarmLeave(force bool) error
+	SwarmRotateCA() error
+	SwarmRotateJoinToken(manager bool) error
 	Task(id string) (swarm.Task, error)
+	TaskLogs(id string, opts LogsOptions) (io.ReadCloser, error)
 }
 
 //Stats holds runtime stats for a container
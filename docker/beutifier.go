@@ -16,7 +16,7 @@ const (
 //DurationForHumans returns a human-readable approximation of a duration
 //represented as an int64 nanosecond count.
 func DurationForHumans(duration int64) string {
-	return units.HumanDuration(time.Now().UTC().Sub(
+	return units.HumanDuration(Now().UTC().Sub(
 		time.Unix(duration, 0)))
 
 }
@@ -0,0 +1,134 @@
+package docker
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+//certCheckInterval is the time between checks for changes on the
+//certificates used to connect to the Docker daemon
+var certCheckInterval = 10 * time.Second
+
+//certWatcher keeps track of the TLS material used to connect to the
+//Docker daemon, reloading it when the certificate files change (i.e.
+//when certificates are rotated).
+type certWatcher struct {
+	files     []string
+	reload    func() (*tls.Config, error)
+	transport *http.Transport
+	done      chan struct{}
+	stopOnce  sync.Once
+
+	sync.RWMutex
+	config   *tls.Config
+	modTimes map[string]time.Time
+}
+
+func newCertWatcher(config *tls.Config, reload func() (*tls.Config, error), files ...string) *certWatcher {
+	w := &certWatcher{
+		config:   config,
+		files:    files,
+		reload:   reload,
+		modTimes: make(map[string]time.Time),
+		done:     make(chan struct{}),
+	}
+	w.changed()
+	return w
+}
+
+//changed returns true if any of the watched files has been modified
+//since the last time it was checked.
+func (w *certWatcher) changed() bool {
+	changed := false
+	for _, file := range w.files {
+		fi, err := os.Stat(file)
+		if err != nil {
+			//Files being rotated might be missing for a while
+			continue
+		}
+		if last, ok := w.modTimes[file]; !ok || !last.Equal(fi.ModTime()) {
+			w.modTimes[file] = fi.ModTime()
+			changed = true
+		}
+	}
+	return changed
+}
+
+//check reloads the TLS configuration if the certificates have changed. Idle
+//connections are closed so new requests use the new configuration, already
+//established connections (e.g. streams) are left untouched.
+func (w *certWatcher) check() error {
+	if !w.changed() {
+		return nil
+	}
+	config, err := w.reload()
+	if err != nil {
+		//Rotation might be in progress, the next check will try again
+		w.modTimes = make(map[string]time.Time)
+		return err
+	}
+	w.Lock()
+	w.config = config
+	w.Unlock()
+	if w.transport != nil {
+		w.transport.CloseIdleConnections()
+	}
+	return nil
+}
+
+//tlsConfig returns the TLS configuration currently in use
+func (w *certWatcher) tlsConfig() *tls.Config {
+	w.RLock()
+	defer w.RUnlock()
+	return w.config
+}
+
+//watch checks for certificate changes periodically, until stopped
+func (w *certWatcher) watch(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				w.check()
+			case <-w.done:
+				return
+			}
+		}
+	}()
+}
+
+//stop stops watching for certificate changes
+func (w *certWatcher) stop() {
+	w.stopOnce.Do(func() {
+		close(w.done)
+	})
+}
+
+//dialTLS returns a function to establish TLS connections to the given host
+//using the current TLS configuration.
+func (w *certWatcher) dialTLS(scheme, host string) func(network, addr string) (net.Conn, error) {
+	return func(network, addr string) (net.Conn, error) {
+		conn, err := net.DialTimeout(scheme, host, DefaultConnectionTimeout)
+		if err != nil {
+			return nil, err
+		}
+		config := w.tlsConfig().Clone()
+		if config.ServerName == "" {
+			if hostname, _, err := net.SplitHostPort(addr); err == nil {
+				config.ServerName = hostname
+			}
+		}
+		tlsConn := tls.Client(conn, config)
+		if err := tlsConn.Handshake(); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		return tlsConn, nil
+	}
+}
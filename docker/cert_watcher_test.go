@@ -0,0 +1,108 @@
+package docker
+
+import (
+	"crypto/tls"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCertWatcherReloadsOnChange(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dry-certs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cert := filepath.Join(dir, "cert.pem")
+	if err := ioutil.WriteFile(cert, []byte("cert"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	initial := &tls.Config{ServerName: "initial"}
+	reloaded := &tls.Config{ServerName: "reloaded"}
+	reloads := 0
+	w := newCertWatcher(initial, func() (*tls.Config, error) {
+		reloads++
+		return reloaded, nil
+	}, cert)
+
+	if err := w.check(); err != nil {
+		t.Errorf("Unexpected error checking unchanged certs: %s", err)
+	}
+	if reloads != 0 || w.tlsConfig() != initial {
+		t.Errorf("Unchanged certs must not be reloaded, reloads: %d", reloads)
+	}
+
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(cert, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.check(); err != nil {
+		t.Errorf("Unexpected error checking changed certs: %s", err)
+	}
+	if reloads != 1 || w.tlsConfig() != reloaded {
+		t.Errorf("Changed certs were not reloaded, reloads: %d", reloads)
+	}
+}
+
+func TestCertWatcherKeepsConfigOnReloadError(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dry-certs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cert := filepath.Join(dir, "cert.pem")
+	initial := &tls.Config{ServerName: "initial"}
+	w := newCertWatcher(initial, func() (*tls.Config, error) {
+		return nil, errors.New("half-written certificate")
+	}, cert)
+
+	if err := ioutil.WriteFile(cert, []byte("cert"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.check(); err == nil {
+		t.Error("Expected an error reloading certs")
+	}
+	if w.tlsConfig() != initial {
+		t.Error("TLS config must not change if certs cannot be reloaded")
+	}
+	if !w.changed() {
+		t.Error("Certs that failed to reload must be checked again")
+	}
+}
+
+func TestCertWatcherStops(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dry-certs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cert := filepath.Join(dir, "cert.pem")
+	if err := ioutil.WriteFile(cert, []byte("cert"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	var reloads int32
+	w := newCertWatcher(&tls.Config{}, func() (*tls.Config, error) {
+		atomic.AddInt32(&reloads, 1)
+		return &tls.Config{}, nil
+	}, cert)
+	w.watch(time.Millisecond)
+	w.stop()
+	w.stop()
+	time.Sleep(10 * time.Millisecond)
+
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(cert, later, later); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if n := atomic.LoadInt32(&reloads); n != 0 {
+		t.Errorf("Certs reloaded %d times once the watcher was stopped", n)
+	}
+}
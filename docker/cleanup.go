@@ -0,0 +1,81 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	dockerTypes "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+)
+
+//CleanupOptions selects what a cleanup prunes
+type CleanupOptions struct {
+	//Containers prunes stopped containers
+	Containers bool
+	//Images prunes dangling images, or every unused image if UnusedImages is set
+	Images       bool
+	UnusedImages bool
+	//BuildCache prunes the dangling build cache, as docker builder prune does
+	BuildCache bool
+	//OlderThan leaves out objects created more recently, zero prunes them no matter their age
+	OlderThan time.Duration
+}
+
+//CleanupReport is the result of a cleanup
+type CleanupReport struct {
+	Containers     int
+	Images         int
+	BuildCache     int
+	SpaceReclaimed uint64
+}
+
+//Cleanup prunes the objects selected by the given options, protected ones
+//are left out. Containers are pruned first, so the images they used can be
+//pruned too.
+func (daemon *DockerDaemon) Cleanup(opts CleanupOptions) (CleanupReport, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	var report CleanupReport
+	args := func() filters.Args {
+		args := withoutProtected(filters.NewArgs())
+		if opts.OlderThan > 0 {
+			args.Add("until", opts.OlderThan.String())
+		}
+		return args
+	}
+	if opts.Containers {
+		r, err := daemon.client.ContainersPrune(ctx, args())
+		if err != nil {
+			return report, fmt.Errorf("error pruning containers: %s", err)
+		}
+		report.Containers = len(r.ContainersDeleted)
+		report.SpaceReclaimed += r.SpaceReclaimed
+	}
+	if opts.Images {
+		imageArgs := args()
+		imageArgs.Add("dangling", fmt.Sprintf("%t", !opts.UnusedImages))
+		r, err := daemon.client.ImagesPrune(ctx, imageArgs)
+		if err != nil {
+			return report, fmt.Errorf("error pruning images: %s", err)
+		}
+		report.Images = len(r.ImagesDeleted)
+		report.SpaceReclaimed += r.SpaceReclaimed
+	}
+	if opts.BuildCache {
+		//Build cache records have no labels, the protection filter does not apply
+		cacheArgs := filters.NewArgs()
+		if opts.OlderThan > 0 {
+			cacheArgs.Add("until", opts.OlderThan.String())
+		}
+		r, err := daemon.client.BuildCachePrune(ctx,
+			dockerTypes.BuildCachePruneOptions{Filters: cacheArgs})
+		if err != nil {
+			return report, fmt.Errorf("error pruning build cache: %s", err)
+		}
+		report.BuildCache = len(r.CachesDeleted)
+		report.SpaceReclaimed += r.SpaceReclaimed
+	}
+	return report, nil
+}
@@ -0,0 +1,66 @@
+package docker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dockerTypes "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	dockerAPI "github.com/docker/docker/client"
+)
+
+type cleanupAPIClientMock struct {
+	dockerAPI.APIClient
+	pruned map[string]filters.Args
+}
+
+func (c cleanupAPIClientMock) ContainersPrune(ctx context.Context, f filters.Args) (dockerTypes.ContainersPruneReport, error) {
+	c.pruned["containers"] = f
+	return dockerTypes.ContainersPruneReport{ContainersDeleted: []string{"a"}, SpaceReclaimed: 10}, nil
+}
+
+func (c cleanupAPIClientMock) ImagesPrune(ctx context.Context, f filters.Args) (dockerTypes.ImagesPruneReport, error) {
+	c.pruned["images"] = f
+	return dockerTypes.ImagesPruneReport{
+		ImagesDeleted:  []dockerTypes.ImageDeleteResponseItem{{Deleted: "b"}, {Deleted: "c"}},
+		SpaceReclaimed: 100}, nil
+}
+
+func (c cleanupAPIClientMock) BuildCachePrune(ctx context.Context, opts dockerTypes.BuildCachePruneOptions) (*dockerTypes.BuildCachePruneReport, error) {
+	c.pruned["build cache"] = opts.Filters
+	return &dockerTypes.BuildCachePruneReport{SpaceReclaimed: 1000}, nil
+}
+
+func TestCleanup(t *testing.T) {
+	client := cleanupAPIClientMock{pruned: make(map[string]filters.Args)}
+	daemon := DockerDaemon{client: client}
+	report, err := daemon.Cleanup(CleanupOptions{Images: true, OlderThan: 72 * time.Hour})
+	if err != nil {
+		t.Fatalf("Unexpected cleanup error: %s", err)
+	}
+	if report.Images != 2 || report.Containers != 0 || report.SpaceReclaimed != 100 {
+		t.Errorf("Unexpected cleanup report: %+v", report)
+	}
+	images, ok := client.pruned["images"]
+	if !ok || len(client.pruned) != 1 {
+		t.Fatalf("Unexpected objects pruned: %v", client.pruned)
+	}
+	if !images.ExactMatch("dangling", "true") || !images.ExactMatch("until", "72h0m0s") || !images.Contains("label!") {
+		t.Errorf("Unexpected image prune filters: %v", images)
+	}
+
+	report, err = daemon.Cleanup(CleanupOptions{Containers: true, Images: true, UnusedImages: true, BuildCache: true})
+	if err != nil {
+		t.Fatalf("Unexpected cleanup error: %s", err)
+	}
+	if report.Containers != 1 || report.SpaceReclaimed != 1110 {
+		t.Errorf("Unexpected cleanup report: %+v", report)
+	}
+	if !client.pruned["images"].ExactMatch("dangling", "false") || client.pruned["images"].Contains("until") {
+		t.Errorf("Unexpected image prune filters: %v", client.pruned["images"])
+	}
+	if client.pruned["build cache"].Len() != 0 {
+		t.Errorf("Unexpected build cache prune filters: %v", client.pruned["build cache"])
+	}
+}
@@ -0,0 +1,38 @@
+package docker
+
+import (
+	"sync"
+	"time"
+)
+
+var clock = struct {
+	sync.RWMutex
+	now func() time.Time
+}{now: time.Now}
+
+//Now returns the current time as seen by dry, durations shown to the user
+//(uptimes, ages of images, etc) are relative to it
+func Now() time.Time {
+	clock.RLock()
+	defer clock.RUnlock()
+	return clock.now()
+}
+
+//Since returns the time elapsed since t, as seen by dry
+func Since(t time.Time) time.Duration {
+	return Now().Sub(t)
+}
+
+//SetClock changes the source of the current time, to make rendering
+//deterministic on tests. The returned func restores the previous clock.
+func SetClock(now func() time.Time) func() {
+	clock.Lock()
+	defer clock.Unlock()
+	previous := clock.now
+	clock.now = now
+	return func() {
+		clock.Lock()
+		defer clock.Unlock()
+		clock.now = previous
+	}
+}
@@ -0,0 +1,131 @@
+package docker
+
+import (
+	"context"
+	"strings"
+
+	dockerTypes "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+	pkgError "github.com/pkg/errors"
+)
+
+//cloneSuffix is appended to the name of a container to name its clones
+const cloneSuffix = "-clone"
+
+//CloneOptions returns the options to clone the given container as
+//ParseRunOptions expects them, followed by the image to run and the command.
+//Given the config of the container image, the settings the container
+//inherits from its image are left out.
+func CloneOptions(c dockerTypes.ContainerJSON, image *container.Config) string {
+	if image == nil {
+		image = &container.Config{}
+	}
+	var args []string
+	if name := strings.TrimPrefix(c.Name, "/"); name != "" {
+		args = append(args, "--name", name+cloneSuffix)
+	}
+	if c.HostConfig != nil {
+		for _, p := range portFlags(c.HostConfig) {
+			args = append(args, "-p", p)
+		}
+	}
+	if c.Config == nil {
+		return joinArgs(args)
+	}
+	for _, env := range notInherited(c.Config.Env, image.Env) {
+		args = append(args, "-e", env)
+	}
+	if c.Config.Tty && c.Config.OpenStdin {
+		args = append(args, "-it")
+	}
+	args = append(args, c.Config.Image)
+	if !equalArgs(c.Config.Cmd, image.Cmd) {
+		args = append(args, c.Config.Cmd...)
+	}
+	return joinArgs(args)
+}
+
+//CloneContainer creates and starts a copy of the container with the given
+//id running the given image, with the given options instead of the ones of
+//the container. Everything else, like mounts, restart policy, networks or
+//labels, is copied from the container. Returns the id of the copy.
+func (daemon *DockerDaemon) CloneContainer(id, image string, opts RunOptions) (string, error) {
+	c, err := daemon.Inspect(id)
+	if err != nil {
+		return "", pkgError.Wrap(err, "error inspecting container")
+	}
+	if c.Config == nil || c.HostConfig == nil {
+		return "", pkgError.Errorf("container %s has no configuration", id)
+	}
+	var imageConfig *container.Config
+	if i, err := daemon.InspectImage(c.Image); err == nil {
+		imageConfig = i.Config
+	}
+	config, hostConfig, endpoints := recreateConfig(c)
+	cloneConfig(config, imageConfig)
+	config.Image = image
+	config.Env = opts.Env
+	config.Cmd = opts.Command
+	config.OpenStdin = opts.Interactive
+	config.Tty = opts.Interactive
+	exposed, bindings, err := nat.ParsePortSpecs(opts.Ports)
+	if err != nil {
+		return "", pkgError.Wrap(err, "invalid ports")
+	}
+	for port := range config.ExposedPorts {
+		exposed[port] = struct{}{}
+	}
+	config.ExposedPorts = exposed
+	hostConfig.PortBindings = bindings
+
+	ctx := context.Background()
+	created, err := daemon.create(ctx, config, hostConfig, endpoints, opts.Name)
+	if err != nil {
+		return "", err
+	}
+	if err := daemon.client.ContainerStart(ctx, created, dockerTypes.ContainerStartOptions{}); err != nil {
+		daemon.refreshAndWait()
+		return created, pkgError.Wrap(err, "error starting the copy")
+	}
+	return created, daemon.refreshAndWait()
+}
+
+//cloneConfig leaves out of the given container config the settings it
+//inherits from the given image config, so they are taken from the image the
+//copy runs, and the labels Docker Compose uses to manage the container
+func cloneConfig(config *container.Config, image *container.Config) {
+	if image != nil {
+		if equalArgs(config.Entrypoint, image.Entrypoint) {
+			config.Entrypoint = nil
+		}
+		if config.User == image.User {
+			config.User = ""
+		}
+		if config.WorkingDir == image.WorkingDir {
+			config.WorkingDir = ""
+		}
+	}
+	labels := make(map[string]string)
+	for k, v := range config.Labels {
+		if strings.HasPrefix(k, "com.docker.compose.") {
+			continue
+		}
+		if image != nil {
+			if inherited, ok := image.Labels[k]; ok && inherited == v {
+				continue
+			}
+		}
+		labels[k] = v
+	}
+	config.Labels = labels
+}
+
+//joinArgs joins the given arguments, quoting them for a shell if needed
+func joinArgs(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = shellQuote(arg)
+	}
+	return strings.Join(quoted, " ")
+}
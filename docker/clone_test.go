@@ -0,0 +1,70 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+
+	dockerTypes "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+)
+
+func TestCloneOptions(t *testing.T) {
+	c := dockerTypes.ContainerJSON{
+		ContainerJSONBase: &dockerTypes.ContainerJSONBase{
+			Name: "/web",
+			HostConfig: &container.HostConfig{
+				PortBindings: nat.PortMap{"80/tcp": []nat.PortBinding{{HostPort: "8080"}}},
+			},
+		},
+		Config: &container.Config{
+			Image: "nginx:1.25",
+			Env:   []string{"PATH=/usr/bin", "GREETING=it's me"},
+			Cmd:   []string{"nginx", "-g", "daemon off;"},
+		},
+	}
+	image := &container.Config{Env: []string{"PATH=/usr/bin"}, Cmd: []string{"nginx"}}
+	options := CloneOptions(c, image)
+	expected := `--name web-clone -p 8080:80 -e 'GREETING=it'"'"'s me' nginx:1.25 nginx -g 'daemon off;'`
+	if options != expected {
+		t.Fatalf("Unexpected clone options, got:\n%s\nexpected:\n%s", options, expected)
+	}
+	opts, err := ParseRunOptions(options)
+	if err != nil {
+		t.Fatalf("Clone options cannot be parsed: %s", err)
+	}
+	want := RunOptions{
+		Name:    "web-clone",
+		Ports:   []string{"8080:80"},
+		Env:     []string{"GREETING=it's me"},
+		Command: []string{"nginx:1.25", "nginx", "-g", "daemon off;"},
+	}
+	if !reflect.DeepEqual(opts, want) {
+		t.Errorf("Unexpected clone options parsed: %+v", opts)
+	}
+}
+
+func TestCloneConfig(t *testing.T) {
+	config := &container.Config{
+		Entrypoint: []string{"/docker-entrypoint.sh"},
+		User:       "nginx",
+		WorkingDir: "/srv",
+		Labels: map[string]string{
+			"maintainer":        "nginx",
+			"team":              "web",
+			ComposeProjectLabel: "shop",
+		},
+	}
+	image := &container.Config{
+		Entrypoint: []string{"/docker-entrypoint.sh"},
+		User:       "nginx",
+		Labels:     map[string]string{"maintainer": "nginx"},
+	}
+	cloneConfig(config, image)
+	if config.Entrypoint != nil || config.User != "" || config.WorkingDir != "/srv" {
+		t.Errorf("Unexpected settings inherited from the image kept: %+v", config)
+	}
+	if !reflect.DeepEqual(config.Labels, map[string]string{"team": "web"}) {
+		t.Errorf("Unexpected labels kept: %v", config.Labels)
+	}
+}
@@ -22,17 +22,53 @@ const (
 	STATS
 	//STOP stop command
 	STOP
+	//FILES browse files command
+	FILES
+	//RESTART_POLICY set restart policy command
+	RESTART_POLICY
+	//NOTE edit the note attached to a container command
+	NOTE
+	//HEALTHCHECK run the container healthcheck command
+	HEALTHCHECK
+	//HEALTH_LOG show the last healthcheck results of a container command
+	HEALTH_LOG
+	//RUN_COMMAND show the docker run command of a container
+	RUN_COMMAND
+	//RECREATE recreate a container with the same configuration command
+	RECREATE
+	//CLONE create a copy of a container command
+	CLONE
+	//RESOURCES update the resource limits of a container command
+	RESOURCES
+	//PORTS show the port mappings of a container command
+	PORTS
+	//CONFIGURATION show the environment, mounts, devices and labels of a container command
+	CONFIGURATION
+	//EVENTS show the events of a container since it was created command
+	EVENTS
 )
 
 //ContainerCommands is the list of container commands
 var ContainerCommands = []CommandDescription{
 	{LOGS, "Fetch logs"},
 	{INSPECT, "Inspect container"},
+	{CONFIGURATION, "Show configuration"},
 	{KILL, "Kill container"},
 	{RM, "Remove container"},
 	{RESTART, "Restart"},
+	{RECREATE, "Recreate"},
+	{CLONE, "Clone"},
 	{HISTORY, "Show image history"},
 	{STATS, "Stats + Top"},
+	{FILES, "Browse files"},
+	{RESTART_POLICY, "Set restart policy"},
+	{RESOURCES, "Update resources"},
+	{NOTE, "Edit note"},
+	{HEALTHCHECK, "Run healthcheck"},
+	{HEALTH_LOG, "Show health log"},
+	{EVENTS, "Show event history"},
+	{RUN_COMMAND, "Show docker run command"},
+	{PORTS, "Show ports"},
 	{STOP, "Stop"},
 }
 
@@ -0,0 +1,180 @@
+package docker
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+)
+
+//composeFileVersion is the version of the compose files written, the first
+//one supporting names on networks and volumes
+const composeFileVersion = "3.5"
+
+//invalidServiceNameChars matches the characters not allowed on compose service names
+var invalidServiceNameChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+//ComposeFile returns a compose file defining a service for each of the given
+//containers, and the networks and volumes they use. Given the config of the
+//image of each container, by image id, the settings a container inherits from
+//its image are left out.
+func ComposeFile(containers []types.ContainerJSON, images map[string]*container.Config) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "version: %s\n", strconv.Quote(composeFileVersion))
+	buf.WriteString("services:\n")
+	networks := make(map[string]bool)
+	volumes := make(map[string]bool)
+	taken := make(map[string]bool)
+	for _, c := range containers {
+		name := composeServiceName(c, taken)
+		taken[name] = true
+		fmt.Fprintf(&buf, "  %s:\n", name)
+		writeComposeService(&buf, c, images[c.Image], networks, volumes)
+	}
+	writeComposeResources(&buf, "networks", networks)
+	writeComposeResources(&buf, "volumes", volumes)
+	return buf.Bytes()
+}
+
+//composeServiceName returns the name of the service of the given container:
+//its compose service, if created by Docker Compose, or its name otherwise.
+//Names already taken get a numeric suffix.
+func composeServiceName(c types.ContainerJSON, taken map[string]bool) string {
+	name := strings.TrimPrefix(c.Name, "/")
+	if c.Config != nil {
+		if service := c.Config.Labels[ComposeServiceLabel]; service != "" {
+			name = service
+		}
+	}
+	name = strings.Trim(invalidServiceNameChars.ReplaceAllString(name, "-"), "-")
+	if name == "" {
+		name = "service"
+	}
+	unique := name
+	for i := 2; taken[unique]; i++ {
+		unique = fmt.Sprintf("%s-%d", name, i)
+	}
+	return unique
+}
+
+func writeComposeService(buf *bytes.Buffer, c types.ContainerJSON, image *container.Config, networks, volumes map[string]bool) {
+	if image == nil {
+		image = &container.Config{}
+	}
+	cfg := c.Config
+	if cfg == nil {
+		cfg = &container.Config{}
+	}
+	hc := c.HostConfig
+	if hc == nil {
+		hc = &container.HostConfig{}
+	}
+	value := func(key, v string) {
+		if v != "" {
+			fmt.Fprintf(buf, "    %s: %s\n", key, strconv.Quote(v))
+		}
+	}
+	flag := func(key string, v bool) {
+		if v {
+			fmt.Fprintf(buf, "    %s: true\n", key)
+		}
+	}
+	list := func(key string, values []string) {
+		if len(values) == 0 {
+			return
+		}
+		fmt.Fprintf(buf, "    %s:\n", key)
+		for _, v := range values {
+			fmt.Fprintf(buf, "      - %s\n", strconv.Quote(v))
+		}
+	}
+
+	value("image", cfg.Image)
+	value("container_name", strings.TrimPrefix(c.Name, "/"))
+	entrypoint := !equalArgs(cfg.Entrypoint, image.Entrypoint)
+	if entrypoint {
+		list("entrypoint", cfg.Entrypoint)
+	}
+	if entrypoint || !equalArgs(cfg.Cmd, image.Cmd) {
+		list("command", cfg.Cmd)
+	}
+	if cfg.User != image.User {
+		value("user", cfg.User)
+	}
+	if cfg.WorkingDir != image.WorkingDir {
+		value("working_dir", cfg.WorkingDir)
+	}
+	if !hc.RestartPolicy.IsNone() && hc.RestartPolicy.Name != "" {
+		value("restart", FormatRestartPolicy(hc.RestartPolicy))
+	}
+	flag("privileged", hc.Privileged)
+	flag("tty", cfg.Tty)
+	flag("stdin_open", cfg.OpenStdin)
+	list("ports", portFlags(hc))
+	list("environment", notInherited(cfg.Env, image.Env))
+
+	var binds, tmpfs []string
+	for _, m := range mountFlags(c) {
+		if m[0] == "--tmpfs" {
+			tmpfs = append(tmpfs, m[1])
+			continue
+		}
+		binds = append(binds, m[1])
+		if source := strings.SplitN(m[1], ":", 2)[0]; !strings.HasPrefix(source, "/") {
+			volumes[source] = true
+		}
+	}
+	list("volumes", binds)
+	list("tmpfs", tmpfs)
+
+	var attached []string
+	for _, network := range networkFlags(c) {
+		switch {
+		case network == "host" || network == "none":
+			value("network_mode", network)
+		case strings.HasPrefix(network, "container:"):
+			value("network_mode", network)
+		default:
+			attached = append(attached, network)
+			networks[network] = true
+		}
+	}
+	list("networks", attached)
+	list("cap_add", hc.CapAdd)
+	list("cap_drop", hc.CapDrop)
+	list("extra_hosts", hc.ExtraHosts)
+
+	var labels []string
+	for k, v := range cfg.Labels {
+		if strings.HasPrefix(k, "com.docker.compose.") {
+			continue
+		}
+		if inherited, ok := image.Labels[k]; !ok || inherited != v {
+			labels = append(labels, k+"="+v)
+		}
+	}
+	sort.Strings(labels)
+	list("labels", labels)
+}
+
+//writeComposeResources writes the given networks or volumes, they keep
+//their names instead of being prefixed with the project name
+func writeComposeResources(buf *bytes.Buffer, kind string, resources map[string]bool) {
+	if len(resources) == 0 {
+		return
+	}
+	var names []string
+	for name := range resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintf(buf, "%s:\n", kind)
+	for _, name := range names {
+		fmt.Fprintf(buf, "  %s:\n    name: %s\n", strconv.Quote(name), strconv.Quote(name))
+	}
+}
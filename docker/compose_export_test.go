@@ -0,0 +1,105 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+)
+
+func TestComposeFile(t *testing.T) {
+	web := types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{
+			Name:  "/shop_web_1",
+			Image: "sha256:web",
+			HostConfig: &container.HostConfig{
+				NetworkMode:   "shop_default",
+				RestartPolicy: container.RestartPolicy{Name: "always"},
+				PortBindings:  nat.PortMap{"80/tcp": []nat.PortBinding{{HostPort: "8080"}}},
+			},
+		},
+		Mounts: []types.MountPoint{
+			{Type: mount.TypeVolume, Name: "shop_data", Destination: "/data", RW: true},
+			{Type: mount.TypeBind, Source: "/srv/conf", Destination: "/etc/nginx"},
+		},
+		Config: &container.Config{
+			Image: "nginx:1.25",
+			Env:   []string{"PATH=/usr/bin", `GREETING=say "hi"`},
+			Labels: map[string]string{
+				ComposeProjectLabel: "shop",
+				ComposeServiceLabel: "web",
+				"team":              "web",
+			},
+			Cmd: []string{"nginx"},
+		},
+		NetworkSettings: &types.NetworkSettings{
+			Networks: map[string]*network.EndpointSettings{"shop_default": {}},
+		},
+	}
+	cache := types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{
+			Name:       "/cache",
+			Image:      "sha256:redis",
+			HostConfig: &container.HostConfig{NetworkMode: "host"},
+		},
+		Config: &container.Config{
+			Image: "redis:7",
+			Cmd:   []string{"redis-server", "--save", ""},
+		},
+	}
+	images := map[string]*container.Config{
+		"sha256:web": {Env: []string{"PATH=/usr/bin"}, Cmd: []string{"nginx"}},
+	}
+	expected := `version: "3.5"
+services:
+  web:
+    image: "nginx:1.25"
+    container_name: "shop_web_1"
+    restart: "always"
+    ports:
+      - "8080:80"
+    environment:
+      - "GREETING=say \"hi\""
+    volumes:
+      - "shop_data:/data"
+      - "/srv/conf:/etc/nginx:ro"
+    networks:
+      - "shop_default"
+    labels:
+      - "team=web"
+  cache:
+    image: "redis:7"
+    container_name: "cache"
+    command:
+      - "redis-server"
+      - "--save"
+      - ""
+    network_mode: "host"
+networks:
+  "shop_default":
+    name: "shop_default"
+volumes:
+  "shop_data":
+    name: "shop_data"
+`
+	if compose := string(ComposeFile([]types.ContainerJSON{web, cache}, images)); compose != expected {
+		t.Errorf("Unexpected compose file, got:\n%s\nexpected:\n%s", compose, expected)
+	}
+}
+
+func TestComposeServiceName(t *testing.T) {
+	taken := map[string]bool{"web": true}
+	c := types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{Name: "/web"},
+	}
+	if name := composeServiceName(c, taken); name != "web-2" {
+		t.Errorf("Unexpected name of a service already taken: %s", name)
+	}
+	c.Name = "/my app!"
+	if name := composeServiceName(c, taken); name != "my-app" {
+		t.Errorf("Unexpected name of a service: %s", name)
+	}
+}
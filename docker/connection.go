@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/docker/cli/cli/connhelper"
 	"github.com/docker/cli/opts"
 	"github.com/docker/docker/client"
 	"github.com/docker/go-connections/sockets"
@@ -87,49 +88,113 @@ func newHTTPClient(host string, config *tls.Config) (*http.Client, error) {
 
 //ConnectToDaemon connects to a Docker daemon using the given properties.
 func ConnectToDaemon(env Env) (*DockerDaemon, error) {
+	client, watcher, env, err := newClient(env, true)
+	if err != nil {
+		return nil, err
+	}
+	d, err := connect(client, env)
+	if err != nil {
+		if watcher != nil {
+			watcher.stop()
+		}
+		return nil, err
+	}
+	d.certs = watcher
+	return d, nil
+}
+
+//newClient creates a client of the Docker daemon of the given environment,
+//the environment is returned with the defaults used to create the client.
+//If watch is set, TLS certificates are reloaded if they are rotated, by the
+//watcher returned, if any, which has to be stopped once the client is not used.
+func newClient(env Env, watch bool) (*client.Client, *certWatcher, Env, error) {
 
 	host, err := getServerHost(env)
 	if err != nil {
-		return nil, errors.Wrap(err, "Invalid Host")
+		return nil, nil, env, errors.Wrap(err, "Invalid Host")
 	}
-	var tlsConfig *tls.Config
-	//If a path to certificates is given use the path to read certificates from
-	if dockerCertPath := env.DockerCertPath; dockerCertPath != "" {
-		options := drytls.Options{
-			CAFile:             filepath.Join(dockerCertPath, "ca.pem"),
-			CertFile:           filepath.Join(dockerCertPath, "cert.pem"),
-			KeyFile:            filepath.Join(dockerCertPath, "key.pem"),
-			InsecureSkipVerify: env.DockerTLSVerify,
+	//ssh:// hosts are reached running docker system dial-stdio through ssh
+	helper, err := connhelper.GetConnectionHelper(host)
+	if err != nil {
+		return nil, nil, env, errors.Wrap(err, "Invalid Host")
+	}
+	if helper != nil {
+		httpClient := &http.Client{
+			Transport: &http.Transport{DialContext: helper.Dialer},
 		}
-		tlsConfig, err = drytls.Client(options)
+		client, err := client.NewClient(helper.Host, env.DockerAPIVersion, httpClient, headers)
 		if err != nil {
-			return nil, errors.Wrap(err, "TLS setup error")
+			return nil, nil, env, errors.Wrap(err, "Error creating client")
 		}
-	} else if env.DockerTLSVerify {
+		return client, nil, env, nil
+	}
+	var tlsConfig *tls.Config
+	var watcher *certWatcher
+	certPath := env.DockerCertPath
+	if certPath == "" && env.DockerTLSVerify {
 		//No cert path is given but TLS verify is set, default location for
 		//docker certs will be used.
 		//See https://docs.docker.com/engine/security/https/#secure-by-default
 		//Fixes #23
+		certPath = defaultDockerPath
+		env.DockerCertPath = defaultDockerPath
+	}
+	//If a path to certificates is given use the path to read certificates from
+	if certPath != "" {
 		options := drytls.Options{
-			CAFile:             filepath.Join(defaultDockerPath, "ca.pem"),
-			CertFile:           filepath.Join(defaultDockerPath, "cert.pem"),
-			KeyFile:            filepath.Join(defaultDockerPath, "key.pem"),
-			InsecureSkipVerify: env.DockerTLSVerify,
+			CAFile:             filepath.Join(certPath, "ca.pem"),
+			CertFile:           filepath.Join(certPath, "cert.pem"),
+			KeyFile:            filepath.Join(certPath, "key.pem"),
+			InsecureSkipVerify: env.DockerTLSVerify || env.TLSSkipVerify,
 		}
-		env.DockerCertPath = defaultDockerPath
 		tlsConfig, err = drytls.Client(options)
 		if err != nil {
-			return nil, errors.Wrap(err, "TLS setup error")
+			return nil, nil, env, errors.Wrap(err, "TLS setup error")
+		}
+		//Certificates might be rotated while dry is running
+		if watch {
+			watcher = newCertWatcher(
+				tlsConfig,
+				func() (*tls.Config, error) {
+					return drytls.Client(options)
+				},
+				options.CAFile, options.CertFile, options.KeyFile)
 		}
 	}
 	httpClient, err := newHTTPClient(host, tlsConfig)
 	if err != nil {
-		return nil, errors.Wrap(err, "HttpClient creation error")
+		return nil, nil, env, errors.Wrap(err, "HttpClient creation error")
+	}
+	if watcher != nil {
+		if err := watchCerts(httpClient, host, watcher); err != nil {
+			return nil, nil, env, errors.Wrap(err, "TLS setup error")
+		}
 	}
 
 	client, err := client.NewClient(host, env.DockerAPIVersion, httpClient, headers)
-	if err == nil {
-		return connect(client, env)
+	if err != nil {
+		if watcher != nil {
+			watcher.stop()
+		}
+		return nil, nil, env, errors.Wrap(err, "Error creating client")
+	}
+	return client, watcher, env, nil
+}
+
+//watchCerts configures the given client to establish TLS connections using
+//the configuration provided by the given watcher, which starts watching
+//for certificate changes.
+func watchCerts(httpClient *http.Client, host string, watcher *certWatcher) error {
+	transport, ok := httpClient.Transport.(*http.Transport)
+	if !ok {
+		return errors.Errorf("unexpected transport type: %T", httpClient.Transport)
+	}
+	url, err := client.ParseHostURL(host)
+	if err != nil {
+		return err
 	}
-	return nil, errors.Wrap(err, "Error creating client")
+	transport.DialTLS = watcher.dialTLS(url.Scheme, url.Host)
+	watcher.transport = transport
+	watcher.watch(certCheckInterval)
+	return nil
 }
@@ -62,3 +62,36 @@ func (cc *containerConfigBuilder) ports(portSet nat.PortSet) *containerConfigBui
 	}
 	return cc
 }
+
+func (cc *containerConfigBuilder) args(args []string) *containerConfigBuilder {
+	if len(args) > 0 {
+		cc.config.Cmd = strslice.StrSlice(args)
+	}
+	return cc
+}
+
+func (cc *containerConfigBuilder) env(env []string) *containerConfigBuilder {
+	cc.config.Env = env
+	return cc
+}
+
+func (cc *containerConfigBuilder) interactive(interactive bool) *containerConfigBuilder {
+	cc.config.OpenStdin = interactive
+	cc.config.Tty = interactive
+	return cc
+}
+
+//publish publishes the given ports, given as the docker run -p flag expects them
+func (cc *containerConfigBuilder) publish(specs []string) *containerConfigBuilder {
+	if len(specs) == 0 || cc.err != nil {
+		return cc
+	}
+	exposed, bindings, err := nat.ParsePortSpecs(specs)
+	if err != nil {
+		cc.err = err
+		return cc
+	}
+	cc.config.ExposedPorts = exposed
+	cc.hostConfig.PortBindings = bindings
+	return cc
+}
@@ -0,0 +1,163 @@
+package docker
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	units "github.com/docker/go-units"
+	pkgError "github.com/pkg/errors"
+)
+
+const (
+	//containerFilesTimeout is the timeout to retrieve files from a container,
+	//listing a directory requires reading its whole archive
+	containerFilesTimeout = 30 * time.Second
+	//maxArchiveEntries is the maximum number of archive entries read to list a directory
+	maxArchiveEntries = 100000
+)
+
+//ContainerFile describes a file found on the filesystem of a container
+type ContainerFile struct {
+	Name       string
+	Path       string
+	Size       int64
+	Mode       os.FileMode
+	ModTime    time.Time
+	LinkTarget string
+}
+
+//IsDir returns true if the file is a directory
+func (f ContainerFile) IsDir() bool {
+	return f.Mode.IsDir()
+}
+
+//ContainerFiles lists the files of the given directory of the container with the
+//given id, it uses the archive API so it works on containers that are not running
+//or do not have a shell. Directories are listed first, then files, by name.
+func (daemon *DockerDaemon) ContainerFiles(id, dir string) ([]ContainerFile, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), containerFilesTimeout)
+	defer cancel()
+	dir, err := daemon.resolveContainerPath(ctx, id, dir)
+	if err != nil {
+		return nil, err
+	}
+	reader, stat, err := daemon.client.CopyFromContainer(ctx, id, dir)
+	if err != nil {
+		return nil, pkgError.Wrapf(err, "Error reading directory %s", dir)
+	}
+	defer reader.Close()
+	if !stat.Mode.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+	files, err := readDirectory(tar.NewReader(reader), path.Join("/", dir))
+	if err != nil {
+		return nil, pkgError.Wrapf(err, "Error reading directory %s", dir)
+	}
+	return files, nil
+}
+
+//ContainerFileContent returns the content of the given text file of the
+//container with the given id, files bigger than maxSize or not looking like
+//text are not read
+func (daemon *DockerDaemon) ContainerFileContent(id, file string, maxSize int64) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), containerFilesTimeout)
+	defer cancel()
+	file, err := daemon.resolveContainerPath(ctx, id, file)
+	if err != nil {
+		return "", err
+	}
+	stat, err := daemon.client.ContainerStatPath(ctx, id, file)
+	if err != nil {
+		return "", pkgError.Wrapf(err, "Error reading file %s", file)
+	}
+	if !stat.Mode.IsRegular() {
+		return "", fmt.Errorf("%s is not a regular file", file)
+	}
+	if stat.Size > maxSize {
+		return "", fmt.Errorf("%s is too big to be shown (%s)", file, units.HumanSize(float64(stat.Size)))
+	}
+	reader, _, err := daemon.client.CopyFromContainer(ctx, id, file)
+	if err != nil {
+		return "", pkgError.Wrapf(err, "Error reading file %s", file)
+	}
+	defer reader.Close()
+	archive := tar.NewReader(reader)
+	if _, err := archive.Next(); err != nil {
+		return "", pkgError.Wrapf(err, "Error reading file %s", file)
+	}
+	var content strings.Builder
+	if _, err := io.Copy(&content, io.LimitReader(archive, maxSize)); err != nil {
+		return "", pkgError.Wrapf(err, "Error reading file %s", file)
+	}
+	if !isText(content.String()) {
+		return "", fmt.Errorf("%s is not a text file", file)
+	}
+	return content.String(), nil
+}
+
+//resolveContainerPath returns the path the given path resolves to if it is a
+//symbolic link, the archive API does not follow links
+func (daemon *DockerDaemon) resolveContainerPath(ctx context.Context, id, p string) (string, error) {
+	stat, err := daemon.client.ContainerStatPath(ctx, id, p)
+	if err != nil {
+		return "", pkgError.Wrapf(err, "Error reading %s", p)
+	}
+	if stat.Mode&os.ModeSymlink != 0 && stat.LinkTarget != "" {
+		return stat.LinkTarget, nil
+	}
+	return p, nil
+}
+
+//readDirectory reads the entries found directly under the given directory
+//from its archive, entries are named after the directory base name
+func readDirectory(archive *tar.Reader, dir string) ([]ContainerFile, error) {
+	prefix := strings.Trim(path.Base(dir), "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+	var files []ContainerFile
+	for i := 0; i < maxArchiveEntries; i++ {
+		header, err := archive.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		name := strings.TrimPrefix(strings.TrimPrefix(header.Name, "./"), "/")
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		name = strings.TrimSuffix(strings.TrimPrefix(name, prefix), "/")
+		if name == "" || strings.Contains(name, "/") {
+			continue
+		}
+		files = append(files, ContainerFile{
+			Name:       name,
+			Path:       path.Join(dir, name),
+			Size:       header.Size,
+			Mode:       header.FileInfo().Mode(),
+			ModTime:    header.ModTime,
+			LinkTarget: header.Linkname,
+		})
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].IsDir() != files[j].IsDir() {
+			return files[i].IsDir()
+		}
+		return files[i].Name < files[j].Name
+	})
+	return files, nil
+}
+
+//isText returns true if the given content looks like text
+func isText(content string) bool {
+	return utf8.ValidString(content) && !strings.ContainsRune(content, 0)
+}
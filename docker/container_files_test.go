@@ -0,0 +1,81 @@
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"testing"
+)
+
+func directoryArchive(t *testing.T, headers ...*tar.Header) *tar.Reader {
+	var buf bytes.Buffer
+	w := tar.NewWriter(&buf)
+	for _, h := range headers {
+		if err := w.WriteHeader(h); err != nil {
+			t.Fatal(err)
+		}
+		if h.Size > 0 {
+			w.Write(make([]byte, h.Size))
+		}
+	}
+	w.Close()
+	return tar.NewReader(&buf)
+}
+
+func TestReadDirectory(t *testing.T) {
+	archive := directoryArchive(t,
+		&tar.Header{Name: "etc/", Typeflag: tar.TypeDir, Mode: 0755},
+		&tar.Header{Name: "etc/passwd", Typeflag: tar.TypeReg, Mode: 0644, Size: 10},
+		&tar.Header{Name: "etc/ssl/", Typeflag: tar.TypeDir, Mode: 0755},
+		&tar.Header{Name: "etc/ssl/cert.pem", Typeflag: tar.TypeReg, Mode: 0644, Size: 3},
+		&tar.Header{Name: "etc/mtab", Typeflag: tar.TypeSymlink, Linkname: "/proc/mounts"},
+	)
+	files, err := readDirectory(archive, "/etc")
+	if err != nil {
+		t.Fatalf("Unexpected error reading directory: %s", err)
+	}
+	expected := []struct {
+		path string
+		dir  bool
+		link string
+	}{
+		{"/etc/ssl", true, ""},
+		{"/etc/mtab", false, "/proc/mounts"},
+		{"/etc/passwd", false, ""},
+	}
+	if len(files) != len(expected) {
+		t.Fatalf("Unexpected files, got %v", files)
+	}
+	for i, e := range expected {
+		if files[i].Path != e.path || files[i].IsDir() != e.dir || files[i].LinkTarget != e.link {
+			t.Errorf("Unexpected file on pos %d, got %+v, expected %+v", i, files[i], e)
+		}
+	}
+	if files[2].Size != 10 {
+		t.Errorf("Unexpected file size, got %d, expected 10", files[2].Size)
+	}
+}
+
+func TestReadRootDirectory(t *testing.T) {
+	archive := directoryArchive(t,
+		&tar.Header{Name: "./", Typeflag: tar.TypeDir, Mode: 0755},
+		&tar.Header{Name: "./bin/", Typeflag: tar.TypeDir, Mode: 0755},
+		&tar.Header{Name: "./bin/sh", Typeflag: tar.TypeReg, Mode: 0755},
+		&tar.Header{Name: "./.dockerenv", Typeflag: tar.TypeReg, Mode: 0755},
+	)
+	files, err := readDirectory(archive, "/")
+	if err != nil {
+		t.Fatalf("Unexpected error reading directory: %s", err)
+	}
+	if len(files) != 2 || files[0].Path != "/bin" || files[1].Path != "/.dockerenv" {
+		t.Errorf("Unexpected files, got %+v", files)
+	}
+}
+
+func TestIsText(t *testing.T) {
+	if !isText("key=value\n") {
+		t.Error("Text was not detected as text")
+	}
+	if isText("\x7fELF\x00\x01") {
+		t.Error("Binary content was detected as text")
+	}
+}
@@ -0,0 +1,108 @@
+package docker
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	dockerTypes "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+)
+
+//containerHistoryActions are the actions of the events that make the history
+//of a container, exec, attach and the like are left out
+var containerHistoryActions = []string{
+	"create", "start", "restart", "die", "kill", "oom", "stop",
+	"pause", "unpause", "health_status", "rename", "update", "destroy",
+}
+
+//ContainerHistoryFilter returns a filter for the events of the history of
+//the container with the given id: its creation, starts, restarts, deaths,
+//health status changes and the like
+func ContainerHistoryFilter(id string) EventFilter {
+	byAction := eventByAction(containerHistoryActions)
+	return func(event events.Message) bool {
+		return event.Type == events.ContainerEventType &&
+			eventActorID(event) == id &&
+			byAction(event)
+	}
+}
+
+//ContainerEvents returns the events of the history of the container with the
+//given id reported by the daemon since the given time, in chronological order.
+//The daemon only keeps the most recent events, older ones are lost.
+func (daemon *DockerDaemon) ContainerEvents(id string, since time.Time) ([]events.Message, error) {
+	args := filters.NewArgs()
+	args.Add("type", events.ContainerEventType)
+	args.Add("container", id)
+	for _, action := range containerHistoryActions {
+		args.Add("event", action)
+	}
+	options := dockerTypes.EventsOptions{
+		Since:   strconv.FormatInt(since.Unix(), 10),
+		Until:   strconv.FormatInt(time.Now().Unix(), 10),
+		Filters: args,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	messages, errs := daemon.client.Events(ctx, options)
+	var history []events.Message
+	for {
+		select {
+		case event := <-messages:
+			history = append(history, event)
+		case err := <-errs:
+			if err == io.EOF {
+				return MergeEvents(history), nil
+			}
+			return nil, err
+		}
+	}
+}
+
+//MergeEvents returns the events of the given lists, the same event found on
+//several lists only once, in chronological order
+func MergeEvents(lists ...[]events.Message) []events.Message {
+	seen := make(map[string]bool)
+	var merged []events.Message
+	for _, list := range lists {
+		for _, event := range list {
+			key := EventKey(event)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			merged = append(merged, event)
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return eventTime(merged[i]) < eventTime(merged[j])
+	})
+	return merged
+}
+
+//EventKey identifies the given event, the same event received from the
+//events stream and from the events API has the same key
+func EventKey(event events.Message) string {
+	return strconv.FormatInt(eventTime(event), 10) + " " + event.Type + " " + event.Action + " " + eventActorID(event)
+}
+
+//eventTime returns the time of the given event, in nanoseconds
+func eventTime(event events.Message) int64 {
+	if event.TimeNano != 0 {
+		return event.TimeNano
+	}
+	return event.Time * int64(time.Second)
+}
+
+//eventActorID returns the id of the object of the given event, older
+//daemons report it as the event id
+func eventActorID(event events.Message) string {
+	if event.Actor.ID != "" {
+		return event.Actor.ID
+	}
+	return event.ID
+}
@@ -0,0 +1,65 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/events"
+)
+
+func containerEvent(id, action string, timeNano int64) events.Message {
+	return events.Message{
+		Type:     events.ContainerEventType,
+		Action:   action,
+		Actor:    events.Actor{ID: id},
+		TimeNano: timeNano,
+	}
+}
+
+func TestContainerHistoryFilter(t *testing.T) {
+	filter := ContainerHistoryFilter("web")
+	tests := []struct {
+		event events.Message
+		want  bool
+	}{
+		{containerEvent("web", "start", 1), true},
+		{containerEvent("web", "health_status: unhealthy", 1), true},
+		{containerEvent("web", "exec_start: sh", 1), false},
+		{containerEvent("db", "start", 1), false},
+		{events.Message{Type: events.NetworkEventType, Action: "connect", Actor: events.Actor{ID: "web"}}, false},
+		//older daemons only report the id of the event
+		{events.Message{Type: events.ContainerEventType, Action: "die", ID: "web"}, true},
+	}
+	for _, tt := range tests {
+		if got := filter(tt.event); got != tt.want {
+			t.Errorf("%s %s of %s: got %v, want %v", tt.event.Type, tt.event.Action, eventActorID(tt.event), got, tt.want)
+		}
+	}
+}
+
+func TestMergeEvents(t *testing.T) {
+	fromDaemon := []events.Message{
+		containerEvent("web", "create", 1),
+		containerEvent("web", "start", 2),
+		containerEvent("web", "die", 4),
+	}
+	fromStream := []events.Message{
+		containerEvent("web", "start", 2),
+		containerEvent("web", "health_status: healthy", 3),
+		containerEvent("web", "die", 4),
+		containerEvent("web", "start", 5),
+	}
+	merged := MergeEvents(fromStream, fromDaemon)
+	expected := []string{"create", "start", "health_status: healthy", "die", "start"}
+	if len(merged) != len(expected) {
+		t.Fatalf("Expected %d events, got %d: %v", len(expected), len(merged), merged)
+	}
+	for i, event := range merged {
+		if event.Action != expected[i] {
+			t.Errorf("Event %d: expected %s, got %s", i, expected[i], event.Action)
+		}
+	}
+	//events only reported with a precision of seconds
+	if got := MergeEvents([]events.Message{{Type: "container", Action: "start", Time: 2}, {Type: "container", Action: "create", Time: 1}}); got[0].Action != "create" {
+		t.Errorf("Expected events in chronological order, got %v", got)
+	}
+}
@@ -0,0 +1,144 @@
+package docker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	pkgError "github.com/pkg/errors"
+)
+
+//DefaultContext is the name of the context that is not stored by the Docker
+//CLI, it connects to the Docker host given by the environment
+const DefaultContext = "default"
+
+//Context is a Docker CLI context, a named Docker endpoint created with
+//docker context create
+type Context struct {
+	Name        string
+	Description string
+	Host        string
+	//TLSPath is the directory with the TLS material to connect to the host, if any
+	TLSPath       string
+	SkipTLSVerify bool
+}
+
+//contextMeta is the metadata of a context, as stored by the Docker CLI
+type contextMeta struct {
+	Name     string
+	Metadata struct {
+		Description string
+	}
+	Endpoints map[string]struct {
+		Host          string
+		SkipTLSVerify bool
+	}
+}
+
+//Env returns the environment to connect to the Docker host of this context
+func (c Context) Env() Env {
+	env := NewEnv()
+	env.DockerHost = c.Host
+	env.DockerCertPath = c.TLSPath
+	env.TLSSkipVerify = c.SkipTLSVerify
+	return env
+}
+
+//ConfigDir returns the directory of the Docker CLI configuration
+func ConfigDir() string {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return dir
+	}
+	return defaultDockerPath
+}
+
+//CurrentContext returns the name of the context the Docker CLI uses with
+//the configuration on the given directory: the one given by DOCKER_CONTEXT
+//or, if not set, the one selected with docker context use
+func CurrentContext(configDir string) string {
+	if name := os.Getenv("DOCKER_CONTEXT"); name != "" {
+		return name
+	}
+	var config struct {
+		CurrentContext string `json:"currentContext"`
+	}
+	data, err := ioutil.ReadFile(filepath.Join(configDir, "config.json"))
+	if err == nil && json.Unmarshal(data, &config) == nil && config.CurrentContext != "" {
+		return config.CurrentContext
+	}
+	return DefaultContext
+}
+
+//Contexts returns the contexts stored on the given Docker CLI configuration
+//directory, sorted by name
+func Contexts(configDir string) ([]Context, error) {
+	dirs, err := ioutil.ReadDir(filepath.Join(configDir, "contexts", "meta"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, pkgError.Wrap(err, "error reading Docker contexts")
+	}
+	var contexts []Context
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		c, err := readContext(configDir, dir.Name())
+		if err != nil {
+			return nil, err
+		}
+		contexts = append(contexts, c)
+	}
+	sort.Slice(contexts, func(i, j int) bool {
+		return contexts[i].Name < contexts[j].Name
+	})
+	return contexts, nil
+}
+
+//ContextByName returns the context with the given name stored on the given
+//Docker CLI configuration directory
+func ContextByName(configDir, name string) (Context, error) {
+	c, err := readContext(configDir, contextID(name))
+	if os.IsNotExist(pkgError.Cause(err)) {
+		return c, fmt.Errorf("Docker context %q not found", name)
+	}
+	return c, err
+}
+
+//contextID returns the id of the context with the given name, the name of
+//the directories where the Docker CLI stores it
+func contextID(name string) string {
+	sum := sha256.Sum256([]byte(name))
+	return hex.EncodeToString(sum[:])
+}
+
+func readContext(configDir, id string) (Context, error) {
+	var meta contextMeta
+	data, err := ioutil.ReadFile(filepath.Join(configDir, "contexts", "meta", id, "meta.json"))
+	if err != nil {
+		return Context{}, pkgError.Wrap(err, "error reading Docker context")
+	}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return Context{}, pkgError.Wrapf(err, "invalid Docker context %s", id)
+	}
+	endpoint, ok := meta.Endpoints["docker"]
+	if !ok {
+		return Context{}, fmt.Errorf("Docker context %s has no Docker endpoint", meta.Name)
+	}
+	c := Context{
+		Name:          meta.Name,
+		Description:   meta.Metadata.Description,
+		Host:          endpoint.Host,
+		SkipTLSVerify: endpoint.SkipTLSVerify,
+	}
+	tlsPath := filepath.Join(configDir, "contexts", "tls", id, "docker")
+	if _, err := os.Stat(tlsPath); err == nil {
+		c.TLSPath = tlsPath
+	}
+	return c, nil
+}
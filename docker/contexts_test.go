@@ -0,0 +1,89 @@
+package docker
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeContext(t *testing.T, dir, name, meta string) string {
+	id := contextID(name)
+	metaDir := filepath.Join(dir, "contexts", "meta", id)
+	if err := os.MkdirAll(metaDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(metaDir, "meta.json"), []byte(meta), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+func TestContexts(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dry-contexts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if contexts, err := Contexts(dir); err != nil || len(contexts) != 0 {
+		t.Errorf("Unexpected contexts without a contexts directory: %v, %v", contexts, err)
+	}
+
+	writeContext(t, dir, "staging",
+		`{"Name":"staging","Metadata":{"Description":"Staging swarm"},"Endpoints":{"docker":{"Host":"ssh://deploy@staging","SkipTLSVerify":false}}}`)
+	id := writeContext(t, dir, "prod",
+		`{"Name":"prod","Metadata":{},"Endpoints":{"docker":{"Host":"tcp://prod:2376","SkipTLSVerify":true}}}`)
+	tlsPath := filepath.Join(dir, "contexts", "tls", id, "docker")
+	if err := os.MkdirAll(tlsPath, 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	contexts, err := Contexts(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error reading contexts: %s", err)
+	}
+	if len(contexts) != 2 || contexts[0].Name != "prod" || contexts[1].Name != "staging" {
+		t.Fatalf("Unexpected contexts: %+v", contexts)
+	}
+	if contexts[1].Description != "Staging swarm" || contexts[1].Host != "ssh://deploy@staging" || contexts[1].TLSPath != "" {
+		t.Errorf("Unexpected context: %+v", contexts[1])
+	}
+
+	prod, err := ContextByName(dir, "prod")
+	if err != nil {
+		t.Fatalf("Unexpected error reading a context: %s", err)
+	}
+	env := prod.Env()
+	if env.DockerHost != "tcp://prod:2376" || env.DockerCertPath != tlsPath || !env.TLSSkipVerify {
+		t.Errorf("Unexpected environment of a context: %+v", env)
+	}
+	if _, err := ContextByName(dir, "dev"); err == nil {
+		t.Error("Reading a missing context did not fail")
+	}
+}
+
+func TestCurrentContext(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dry-contexts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer os.Setenv("DOCKER_CONTEXT", os.Getenv("DOCKER_CONTEXT"))
+	os.Unsetenv("DOCKER_CONTEXT")
+
+	if current := CurrentContext(dir); current != DefaultContext {
+		t.Errorf("Unexpected current context without a configuration: %s", current)
+	}
+	config := `{"auths":{},"currentContext":"staging"}`
+	if err := ioutil.WriteFile(filepath.Join(dir, "config.json"), []byte(config), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if current := CurrentContext(dir); current != "staging" {
+		t.Errorf("Unexpected current context: %s", current)
+	}
+	os.Setenv("DOCKER_CONTEXT", "prod")
+	if current := CurrentContext(dir); current != "prod" {
+		t.Errorf("DOCKER_CONTEXT not taken as the current context: %s", current)
+	}
+}
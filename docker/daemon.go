@@ -45,6 +45,17 @@ type DockerDaemon struct {
 	storeLock sync.RWMutex
 	resolver  Resolver
 	eventLog  *EventLog
+	unused    *unusedTracker
+	certs     *certWatcher //watches the TLS certificates, if any, for rotations
+}
+
+//Close releases the resources used to connect to the Docker daemon, the
+//daemon must not be used once closed
+func (daemon *DockerDaemon) Close() error {
+	if daemon.certs != nil {
+		daemon.certs.stop()
+	}
+	return daemon.client.Close()
 }
 
 //Containers returns the containers known by the daemon
@@ -101,6 +112,7 @@ func (daemon *DockerDaemon) Events() (<-chan dockerEvents.Message, chan<- struct
 						event,
 						streamEvents(eventC),
 						logEvents(daemon.eventLog),
+						daemon.refreshContainers,
 						callbackNotifier); err != nil {
 						return
 					}
@@ -114,7 +126,7 @@ func (daemon *DockerDaemon) Events() (<-chan dockerEvents.Message, chan<- struct
 
 	}()
 
-	if daemon.swarmMode {
+	if daemon.inSwarmMode() {
 		args := filters.NewArgs()
 		args.Add("scope", "swarm")
 		options := dockerTypes.EventsOptions{
@@ -133,6 +145,7 @@ func (daemon *DockerDaemon) Events() (<-chan dockerEvents.Message, chan<- struct
 						event,
 						streamEvents(eventC),
 						logEvents(daemon.eventLog),
+						daemon.refreshContainers,
 						callbackNotifier); err != nil {
 						return
 					}
@@ -195,22 +208,39 @@ func (daemon *DockerDaemon) Kill(id string) error {
 	return daemon.refreshAndWait()
 }
 
+//LogsOptions defines which logs of a container or a service are retrieved
+type LogsOptions struct {
+	//Since is a timestamp or a relative time (e.g. 42m), only logs since then are retrieved
+	Since string
+	//Tail is the number of lines to retrieve from the end of the logs, "all" for every line
+	Tail string
+	//Follow keeps streaming new logs
+	Follow bool
+	//Timestamps shows the timestamp of every log line
+	Timestamps bool
+}
+
 //Logs shows the logs of the container with the given id
-func (daemon *DockerDaemon) Logs(id string, since string, withTimeStamps bool) (io.ReadCloser, error) {
+func (daemon *DockerDaemon) Logs(id string, opts LogsOptions) (io.ReadCloser, error) {
 	options := dockerTypes.ContainerLogsOptions{
 		ShowStdout: true,
 		ShowStderr: true,
-		Timestamps: withTimeStamps,
-		Follow:     true,
+		Timestamps: opts.Timestamps,
+		Follow:     opts.Follow,
 		Details:    false,
-		Since:      since,
+		Since:      opts.Since,
+		Tail:       opts.Tail,
 	}
 	return daemon.client.ContainerLogs(context.Background(), id, options)
 }
 
 //Networks returns the list of Docker networks
 func (daemon *DockerDaemon) Networks() ([]dockerTypes.NetworkResource, error) {
-	return networks(daemon.client)
+	networks, err := networks(daemon.client)
+	if err == nil {
+		daemon.trackUnusedNetworks(networks)
+	}
+	return networks, err
 }
 
 //NetworkInspect returns network detailed information
@@ -236,11 +266,11 @@ func (daemon *DockerDaemon) StatsChannel(container *Container) (*StatsChannel, e
 }
 
 //Prune requests the Docker daemon to prune unused containers, images
-//networks and volumes
+//networks and volumes, protected objects are not pruned
 func (daemon *DockerDaemon) Prune() (*PruneReport, error) {
 	c := context.Background()
 
-	args := filters.NewArgs()
+	args := withoutProtected(filters.NewArgs())
 	cReport, err := daemon.client.ContainersPrune(c, args)
 	if err != nil {
 		return nil, err
@@ -265,6 +295,17 @@ func (daemon *DockerDaemon) Prune() (*PruneReport, error) {
 
 }
 
+//PruneBuildCache removes the build cache not in use, the most recently used
+//records are kept as long as they take less than the given storage, in
+//bytes. Zero keeps nothing.
+func (daemon *DockerDaemon) PruneBuildCache(keepStorage int64) (*dockerTypes.BuildCachePruneReport, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+	defer cancel()
+
+	return daemon.client.BuildCachePrune(ctx,
+		dockerTypes.BuildCachePruneOptions{All: true, KeepStorage: keepStorage})
+}
+
 //RestartContainer restarts the container with the given id
 func (daemon *DockerDaemon) RestartContainer(id string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
@@ -304,7 +345,49 @@ func (daemon *DockerDaemon) refreshAndWait() error {
 	return refreshError
 }
 
-//RemoveAllStoppedContainers removes all stopped containers
+//StopContainers stops, or kills, the containers with the given ids, returns the
+//number of containers stopped
+func (daemon *DockerDaemon) StopContainers(ids []string, kill bool) (int, error) {
+	var count uint32
+	errs := make(chan error, 1)
+	defer close(errs)
+	var wg sync.WaitGroup
+	for _, id := range ids {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+			defer cancel()
+			var err error
+			if kill {
+				err = daemon.client.ContainerKill(ctx, id, "")
+			} else {
+				err = daemon.client.ContainerStop(ctx, id, &containerOpTimeout)
+			}
+			if err != nil {
+				select {
+				case errs <- err:
+				default:
+				}
+			} else {
+				atomic.AddUint32(&count, 1)
+			}
+		}(id)
+	}
+
+	wg.Wait()
+	stopped := int(atomic.LoadUint32(&count))
+	select {
+	case e := <-errs:
+		return stopped,
+			pkgError.Wrap(e,
+				fmt.Sprintf("There were errors stopping containers. Containers: %d, stopped: %d", len(ids), stopped))
+	default:
+	}
+	return stopped, daemon.refreshAndWait()
+}
+
+//RemoveAllStoppedContainers removes all stopped containers, except protected ones
 func (daemon *DockerDaemon) RemoveAllStoppedContainers() (int, error) {
 	containers := daemon.Containers([]ContainerFilter{ContainerFilters.NotRunning()}, NoSort)
 	var count uint32
@@ -312,6 +395,9 @@ func (daemon *DockerDaemon) RemoveAllStoppedContainers() (int, error) {
 	defer close(errs)
 	var wg sync.WaitGroup
 	for _, container := range containers {
+		if IsContainerProtected(container) {
+			continue
+		}
 		wg.Add(1)
 		go func(id string) {
 			defer wg.Done()
@@ -340,49 +426,40 @@ func (daemon *DockerDaemon) RemoveAllStoppedContainers() (int, error) {
 	return removed, err
 }
 
-//RemoveDanglingImages removes dangling images
+//RemoveDanglingImages removes dangling images, except protected ones
 func (daemon *DockerDaemon) RemoveDanglingImages() (int, error) {
 	danglingfilters := filters.NewArgs()
 	danglingfilters.Add("dangling", "true")
 	images, err := images(daemon.client,
 		dockerTypes.ImageListOptions{
 			Filters: danglingfilters})
-	var count uint32
-	errs := make(chan error, 1)
-	defer close(errs)
-	if err == nil {
-		var wg sync.WaitGroup
-		for _, image := range images {
-			wg.Add(1)
-			go func(id string) {
-				defer atomic.AddUint32(&count, 1)
-				defer wg.Done()
-				_, err = daemon.Rmi(id, true)
-				if err != nil {
-					select {
-					case errs <- err:
-					default:
-					}
-				}
-			}(image.ID)
-		}
-		wg.Wait()
-		select {
-		case e := <-errs:
-			return 0, e
-		default:
+	if err != nil {
+		return 0, err
+	}
+	var ids []string
+	for _, image := range images {
+		if !IsProtected(image.Labels) {
+			ids = append(ids, image.ID)
 		}
 	}
+	count := 0
+	daemon.RemoveImages(ids, true, func(id string, rmErr error) {
+		if rmErr == nil {
+			count++
+		} else if err == nil {
+			err = rmErr
+		}
+	})
 	daemon.Refresh(nil)
-	return int(atomic.LoadUint32(&count)), err
+	return count, err
 }
 
-//RemoveUnusedImages removes unused images
+//RemoveUnusedImages removes unused images, except protected ones
 func (daemon *DockerDaemon) RemoveUnusedImages() (int, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
 	defer cancel()
 
-	args := filters.NewArgs()
+	args := withoutProtected(filters.NewArgs())
 	args.Add("dangling", "false")
 
 	report, err := daemon.client.ImagesPrune(ctx, args)
@@ -390,6 +467,22 @@ func (daemon *DockerDaemon) RemoveUnusedImages() (int, error) {
 	return len(report.ImagesDeleted), err
 }
 
+//RemoveBuildCache removes the build cache record with the given id, it
+//returns the disk space reclaimed
+func (daemon *DockerDaemon) RemoveBuildCache(id string) (uint64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+	defer cancel()
+
+	args := filters.NewArgs()
+	args.Add("id", id)
+	report, err := daemon.client.BuildCachePrune(ctx,
+		dockerTypes.BuildCachePruneOptions{All: true, Filters: args})
+	if err != nil {
+		return 0, err
+	}
+	return report.SpaceReclaimed, nil
+}
+
 //RemoveNetwork removes the network with the given id
 func (daemon *DockerDaemon) RemoveNetwork(id string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
@@ -436,6 +529,18 @@ func (daemon *DockerDaemon) store() ContainerStore {
 	return daemon.s
 }
 
+func (daemon *DockerDaemon) setSwarmMode(swarmMode bool) {
+	daemon.storeLock.Lock()
+	defer daemon.storeLock.Unlock()
+	daemon.swarmMode = swarmMode
+}
+
+func (daemon *DockerDaemon) inSwarmMode() bool {
+	daemon.storeLock.RLock()
+	defer daemon.storeLock.RUnlock()
+	return daemon.swarmMode
+}
+
 //StopContainer stops the container with the given id
 func (daemon *DockerDaemon) StopContainer(id string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
@@ -470,6 +575,7 @@ func (daemon *DockerDaemon) VolumeList(ctx context.Context) ([]*dockerTypes.Volu
 	if err != nil {
 		return nil, err
 	}
+	daemon.trackUnusedVolumes(volumeOkBody.Volumes)
 	return volumeOkBody.Volumes, nil
 }
 
@@ -525,19 +631,28 @@ func (daemon *DockerDaemon) init() error {
 		return pkgError.Wrap(err, "Error retrieving Docker version")
 	}
 
-	if info, err := daemon.Info(); err == nil {
-		daemon.swarmMode = info.Swarm.LocalNodeState == swarm.LocalNodeStateActive
-	} else {
+	info, err := daemon.Info()
+	if err != nil {
 		return pkgError.Wrap(err, "Error retrieving Docker info")
 	}
-	GlobalRegistry.Register(
-		ContainerSource,
-		func(ctx context.Context, message dockerEvents.Message) error {
-			return daemon.refreshAndWait()
-		})
+	daemon.setSwarmMode(info.Swarm.LocalNodeState == swarm.LocalNodeStateActive)
+	//unused objects are kept by daemon, the host is used for daemons with no ID
+	host := info.ID
+	if host == "" {
+		host = daemon.dockerEnv.DockerHost
+	}
+	daemon.unused = newUnusedTracker(unusedObjectsFile, host)
 	return nil
 }
 
+//refreshContainers refreshes the containers known on container events
+func (daemon *DockerDaemon) refreshContainers(ctx context.Context, message dockerEvents.Message) error {
+	if SourceType(message.Type) != ContainerSource {
+		return nil
+	}
+	return daemon.refreshAndWait()
+}
+
 func containers(client dockerAPI.ContainerAPIClient) ([]*Container, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), DefaultConnectionTimeout)
 	defer cancel()
@@ -606,3 +721,14 @@ func IsContainerRunning(container *Container) bool {
 	}
 	return false
 }
+
+//ExitState returns the exit code of the given container and whether it was
+//killed for running out of memory, ok is false if the container has not exited
+func ExitState(container *Container) (exitCode int, oomKilled bool, ok bool) {
+	if container == nil || container.Container.State != "exited" ||
+		container.ContainerJSONBase == nil || container.ContainerJSON.State == nil {
+		return 0, false, false
+	}
+	state := container.ContainerJSON.State
+	return state.ExitCode, state.OOMKilled, true
+}
@@ -0,0 +1,92 @@
+package docker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/swarm"
+	units "github.com/docker/go-units"
+)
+
+//dataRootUsageLimit is the usage percentage of the data root filesystem
+//above which a warning is given
+const dataRootUsageLimit = 90.0
+
+//DaemonWarnings returns warnings about the daemon setup that are known to
+//cause trouble: devicemapper on loopback devices, an almost full data root or
+//live-restore being disabled. The data root usage is only checked on the
+//filesystem for local daemons or from the devicemapper status.
+func (daemon *DockerDaemon) DaemonWarnings() ([]string, error) {
+	info, err := daemon.Info()
+	if err != nil {
+		return nil, err
+	}
+	var usage fileSystemUsageFunc
+	if isLocalHost(daemon.DockerEnv().DockerHost) {
+		usage = fileSystemUsage
+	}
+	return daemonWarnings(info, usage), nil
+}
+
+//fileSystemUsageFunc returns the usage percentage of the filesystem of the given directory
+type fileSystemUsageFunc func(dir string) (float64, error)
+
+func daemonWarnings(info types.Info, usage fileSystemUsageFunc) []string {
+	var warnings []string
+	status := driverStatus(info)
+	if info.Driver == "devicemapper" && status["Data loop file"] != "" {
+		warnings = append(warnings,
+			"devicemapper is using loopback devices, use a thin pool block device (dm.thinpooldev) instead")
+	}
+	if percent, ok := dataRootUsage(info, status, usage); ok && percent > dataRootUsageLimit {
+		warnings = append(warnings,
+			fmt.Sprintf("the data root %s is %.0f%% full", info.DockerRootDir, percent))
+	}
+	//live-restore is not compatible with swarm mode
+	if !info.LiveRestoreEnabled && info.Swarm.LocalNodeState == swarm.LocalNodeStateInactive {
+		warnings = append(warnings,
+			"live-restore is disabled, containers stop when the daemon stops or restarts")
+	}
+	return warnings
+}
+
+//dataRootUsage returns the usage percentage of the data root, devicemapper
+//reports the usage of its pool, the filesystem is checked otherwise
+func dataRootUsage(info types.Info, status map[string]string, usage fileSystemUsageFunc) (float64, bool) {
+	if used, total := status["Data Space Used"], status["Data Space Total"]; used != "" && total != "" {
+		usedBytes, err := units.FromHumanSize(used)
+		if err != nil {
+			return 0, false
+		}
+		totalBytes, err := units.FromHumanSize(total)
+		if err != nil || totalBytes == 0 {
+			return 0, false
+		}
+		return float64(usedBytes) / float64(totalBytes) * 100, true
+	}
+	if usage == nil || info.DockerRootDir == "" {
+		return 0, false
+	}
+	percent, err := usage(info.DockerRootDir)
+	if err != nil {
+		return 0, false
+	}
+	return percent, true
+}
+
+func driverStatus(info types.Info) map[string]string {
+	status := make(map[string]string, len(info.DriverStatus))
+	for _, pair := range info.DriverStatus {
+		if len(pair) == 2 {
+			status[pair[0]] = pair[1]
+		}
+	}
+	return status
+}
+
+//isLocalHost returns true if the given Docker host is reached through a
+//local socket or pipe, so its filesystem is this host filesystem
+func isLocalHost(host string) bool {
+	return strings.HasPrefix(host, "unix://") || strings.HasPrefix(host, "npipe://")
+}
@@ -0,0 +1,85 @@
+package docker
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/swarm"
+)
+
+func TestDaemonWarnings(t *testing.T) {
+	usage := func(percent float64, err error) fileSystemUsageFunc {
+		return func(string) (float64, error) {
+			return percent, err
+		}
+	}
+	inactive := swarm.Info{LocalNodeState: swarm.LocalNodeStateInactive}
+	tests := []struct {
+		name     string
+		info     types.Info
+		usage    fileSystemUsageFunc
+		expected []string
+	}{
+		{
+			"no warnings",
+			types.Info{Driver: "overlay2", DockerRootDir: "/var/lib/docker", LiveRestoreEnabled: true, Swarm: inactive},
+			usage(50, nil),
+			nil,
+		},
+		{
+			"live-restore disabled",
+			types.Info{Driver: "overlay2", Swarm: inactive},
+			nil,
+			[]string{"live-restore"},
+		},
+		{
+			"live-restore disabled on swarm mode",
+			types.Info{Driver: "overlay2", Swarm: swarm.Info{LocalNodeState: swarm.LocalNodeStateActive}},
+			nil,
+			nil,
+		},
+		{
+			"data root almost full",
+			types.Info{Driver: "overlay2", DockerRootDir: "/var/lib/docker", LiveRestoreEnabled: true, Swarm: inactive},
+			usage(95, nil),
+			[]string{"/var/lib/docker is 95% full"},
+		},
+		{
+			"data root usage unknown",
+			types.Info{Driver: "overlay2", DockerRootDir: "/var/lib/docker", LiveRestoreEnabled: true, Swarm: inactive},
+			usage(95, errors.New("no statfs")),
+			nil,
+		},
+		{
+			"devicemapper on loopback with its pool almost full",
+			types.Info{
+				Driver: "devicemapper",
+				DriverStatus: [][2]string{
+					{"Data loop file", "/var/lib/docker/devicemapper/devicemapper/data"},
+					{"Data Space Used", "99 GB"},
+					{"Data Space Total", "100 GB"},
+				},
+				DockerRootDir:      "/var/lib/docker",
+				LiveRestoreEnabled: true,
+				Swarm:              inactive,
+			},
+			usage(10, nil),
+			[]string{"loopback", "99% full"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings := daemonWarnings(tt.info, tt.usage)
+			if len(warnings) != len(tt.expected) {
+				t.Fatalf("Unexpected warnings, got %v, expected %v", warnings, tt.expected)
+			}
+			for i, w := range warnings {
+				if !strings.Contains(w, tt.expected[i]) {
+					t.Errorf("Unexpected warning, got %s, expected to contain %s", w, tt.expected[i])
+				}
+			}
+		})
+	}
+}
@@ -10,6 +10,8 @@ type Env struct {
 	DockerTLSVerify  bool //tls must be verified
 	DockerCertPath   string
 	DockerAPIVersion string
+	//TLSSkipVerify skips verifying the certificate of the Docker daemon
+	TLSSkipVerify bool
 }
 
 //NewEnv creates a new docker environment struct
@@ -0,0 +1,136 @@
+package docker
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/docker/docker/api/types/events"
+)
+
+//EventFilter is a filter for docker events
+type EventFilter func(events.Message) bool
+
+//ParseEventFilterExpression parses a filter expression made of terms
+//separated by spaces, events must match every term. Terms are either
+//type=<type>[,<type>...], action=<pattern>[,<pattern>...], name=<pattern>,
+//image=<pattern>, label=<key> or label=<key>=<value>, a term without key is
+//taken as a name pattern. Patterns are shell patterns. An empty expression
+//returns a nil filter, meaning no filtering.
+func ParseEventFilterExpression(expression string) (EventFilter, error) {
+	var filters []EventFilter
+	for _, term := range strings.Fields(expression) {
+		key, value := "name", term
+		if i := strings.Index(term, "="); i >= 0 {
+			key, value = term[:i], term[i+1:]
+		}
+		if value == "" {
+			return nil, fmt.Errorf("no value given for %s", key)
+		}
+		switch key {
+		case "type":
+			filters = append(filters, eventByType(strings.Split(value, ",")))
+		case "action", "name", "image":
+			patterns := []string{value}
+			if key == "action" {
+				patterns = strings.Split(value, ",")
+			}
+			for _, pattern := range patterns {
+				if _, err := path.Match(pattern, ""); err != nil {
+					return nil, fmt.Errorf("invalid %s pattern: %q", key, pattern)
+				}
+			}
+			switch key {
+			case "action":
+				filters = append(filters, eventByAction(patterns))
+			case "name":
+				filters = append(filters, eventByAttribute("name", value))
+			default:
+				filters = append(filters, eventByImage(value))
+			}
+		case "label":
+			label := strings.SplitN(value, "=", 2)
+			labelValue := ""
+			if len(label) == 2 {
+				labelValue = label[1]
+			}
+			filters = append(filters, eventByLabel(label[0], labelValue))
+		default:
+			return nil, fmt.Errorf("unknown filter: %q", key)
+		}
+	}
+	if len(filters) == 0 {
+		return nil, nil
+	}
+	return func(event events.Message) bool {
+		for _, filter := range filters {
+			if !filter(event) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+//eventByType filters events of the given object types
+func eventByType(types []string) EventFilter {
+	return func(event events.Message) bool {
+		for _, t := range types {
+			if strings.EqualFold(event.Type, t) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+//eventByAction filters events whose action matches any of the given patterns,
+//actions with arguments, like exec_start: sh, are matched without them
+func eventByAction(patterns []string) EventFilter {
+	return func(event events.Message) bool {
+		action := event.Action
+		if i := strings.Index(action, ":"); i >= 0 {
+			action = action[:i]
+		}
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, action); ok {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+//eventByAttribute filters events with an actor attribute matching the given pattern
+func eventByAttribute(attribute, pattern string) EventFilter {
+	return func(event events.Message) bool {
+		value, ok := event.Actor.Attributes[attribute]
+		if !ok {
+			return false
+		}
+		match, _ := path.Match(pattern, value)
+		return match
+	}
+}
+
+//eventByImage filters events of containers created from an image matching the
+//given pattern, as well as events of the images matching it
+func eventByImage(pattern string) EventFilter {
+	byImage := eventByAttribute("image", pattern)
+	byName := eventByAttribute("name", pattern)
+	return func(event events.Message) bool {
+		if event.Type == events.ImageEventType {
+			return byName(event)
+		}
+		return byImage(event)
+	}
+}
+
+//eventByLabel filters events whose actor has the given label, with the given
+//value unless it is empty. Labels are reported as actor attributes.
+func eventByLabel(key, value string) EventFilter {
+	return func(event events.Message) bool {
+		v, ok := event.Actor.Attributes[key]
+		return ok && (value == "" || v == value)
+	}
+}
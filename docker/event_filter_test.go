@@ -0,0 +1,64 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/events"
+)
+
+func TestParseEventFilterExpression(t *testing.T) {
+	start := events.Message{Type: events.ContainerEventType, Action: "start",
+		Actor: events.Actor{ID: "1", Attributes: map[string]string{"name": "web-1", "image": "nginx:1.17", "env": "prod"}}}
+	exec := events.Message{Type: events.ContainerEventType, Action: "exec_start: sh",
+		Actor: events.Actor{ID: "2", Attributes: map[string]string{"name": "db", "image": "postgres:12", "env": "dev"}}}
+	pull := events.Message{Type: events.ImageEventType, Action: "pull",
+		Actor: events.Actor{ID: "nginx:1.17", Attributes: map[string]string{"name": "nginx:1.17"}}}
+	network := events.Message{Type: events.NetworkEventType, Action: "connect",
+		Actor: events.Actor{ID: "3", Attributes: map[string]string{"name": "bridge", "type": "bridge"}}}
+	all := []events.Message{start, exec, pull, network}
+
+	tests := []struct {
+		expression string
+		expected   []events.Message
+		wantErr    bool
+	}{
+		{"", all, false},
+		{"type=container", []events.Message{start, exec}, false},
+		{"type=image,network", []events.Message{pull, network}, false},
+		{"action=exec_start", []events.Message{exec}, false},
+		{"action=st*,pull", []events.Message{start, pull}, false},
+		{"web-*", []events.Message{start}, false},
+		{"image=nginx*", []events.Message{start, pull}, false},
+		{"label=env", []events.Message{start, exec}, false},
+		{"label=env=dev type=container", []events.Message{exec}, false},
+		{"type=volume", nil, false},
+		{"type=", nil, true},
+		{"name=[", nil, true},
+		{"status=running", nil, true},
+	}
+	for _, tt := range tests {
+		filter, err := ParseEventFilterExpression(tt.expression)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseEventFilterExpression(%q) error = %v, wantErr %v", tt.expression, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		var got []events.Message
+		for _, event := range all {
+			if filter == nil || filter(event) {
+				got = append(got, event)
+			}
+		}
+		if len(got) != len(tt.expected) {
+			t.Errorf("Filter %q kept %d events, expected %d", tt.expression, len(got), len(tt.expected))
+			continue
+		}
+		for i := range got {
+			if got[i].Actor.ID != tt.expected[i].Actor.ID {
+				t.Errorf("Filter %q kept an unexpected event: %s %s", tt.expression, got[i].Type, got[i].Action)
+			}
+		}
+	}
+}
@@ -42,6 +42,8 @@ const (
 //CallbackRegistry d
 type CallbackRegistry interface {
 	Register(actor SourceType, callback EventCallback)
+	//Reset unregisters every callback
+	Reset()
 }
 
 //GlobalRegistry is a globally available CallbackRegistry
@@ -69,6 +71,14 @@ func (r *registry) Register(source SourceType, callback EventCallback) {
 	r.actions[source] = append(r.actions[source], callback)
 }
 
+//Reset unregisters every callback
+func (r *registry) Reset() {
+	r.Lock()
+	defer r.Unlock()
+
+	r.actions = make(map[SourceType][]EventCallback)
+}
+
 func notifyCallbacks(r *registry) EventCallback {
 	return func(ctx context.Context, message events.Message) error {
 		r.RLock()
@@ -0,0 +1,42 @@
+package docker
+
+import (
+	"encoding/json"
+
+	"github.com/docker/docker/api/types/events"
+	pkgError "github.com/pkg/errors"
+)
+
+//DefaultEventsFileSize is the size, in bytes, an events file is rotated at by default
+const DefaultEventsFileSize = 10 * 1024 * 1024
+
+//EventsFile appends docker events, as JSON lines, to a file. Once the file
+//reaches its max size it is rotated, the rotated files are named as the file
+//plus a number, the higher the number the older the events.
+type EventsFile struct {
+	file *rotatingFile
+}
+
+//NewEventsFile opens, or creates, the events file on the given path, events
+//are appended to it, rotating it once it reaches the given size in bytes
+func NewEventsFile(path string, maxSize int64) (*EventsFile, error) {
+	file, err := newRotatingFile("events", path, maxSize)
+	if err != nil {
+		return nil, err
+	}
+	return &EventsFile{file: file}, nil
+}
+
+//Record appends the given event to the file, rotating it if needed
+func (f *EventsFile) Record(message events.Message) error {
+	line, err := json.Marshal(message)
+	if err != nil {
+		return pkgError.Wrap(err, "Error encoding event")
+	}
+	return f.file.write(append(line, '\n'))
+}
+
+//Close closes the file, no more events are recorded afterwards
+func (f *EventsFile) Close() error {
+	return f.file.close()
+}
@@ -0,0 +1,96 @@
+package docker
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/docker/docker/api/types/events"
+)
+
+func TestEventsFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dry-events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "events.log")
+
+	event := func(i int) events.Message {
+		return events.Message{Type: events.ContainerEventType, Action: "start",
+			Actor: events.Actor{ID: strconv.Itoa(i)}}
+	}
+	line, _ := json.Marshal(event(0))
+	//Room for two events on each file
+	f, err := NewEventsFile(path, int64(2*(len(line)+1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 9; i++ {
+		if err := f.Record(event(i)); err != nil {
+			t.Fatalf("Error recording event %d: %s", i, err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Record(event(9)); err == nil {
+		t.Error("Events were recorded on a closed file")
+	}
+
+	expected := map[string][]string{
+		path:                 {"8"},
+		rotatedFile(path, 1): {"6", "7"},
+		rotatedFile(path, 2): {"4", "5"},
+		rotatedFile(path, 3): {"2", "3"},
+		rotatedFile(path, 4): nil,
+	}
+	for file, ids := range expected {
+		got := recordedEvents(t, file)
+		if len(got) != len(ids) {
+			t.Errorf("File %s has events %v, expected %v", file, got, ids)
+			continue
+		}
+		for i := range ids {
+			if got[i] != ids[i] {
+				t.Errorf("File %s has events %v, expected %v", file, got, ids)
+				break
+			}
+		}
+	}
+
+	//Events are appended to an existing file
+	f, err = NewEventsFile(path, DefaultEventsFileSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Record(event(10))
+	f.Close()
+	if got := recordedEvents(t, path); len(got) != 2 || got[1] != "10" {
+		t.Errorf("Event was not appended to the existing file: %v", got)
+	}
+}
+
+func recordedEvents(t *testing.T, path string) []string {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	var ids []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var message events.Message
+		if err := json.Unmarshal(scanner.Bytes(), &message); err != nil {
+			t.Fatalf("Invalid event on %s: %s", path, err)
+		}
+		ids = append(ids, message.Actor.ID)
+	}
+	return ids
+}
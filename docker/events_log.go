@@ -19,6 +19,9 @@ type EventLog struct {
 	tail     int // the least recent value written
 	capacity int
 	messages []*events.Message
+	//listeners are notified of every event pushed to the log
+	listeners    map[int]func(events.Message)
+	nextListener int
 	sync.RWMutex
 }
 
@@ -83,6 +86,28 @@ func (el *EventLog) Push(message *events.Message) {
 		el.head++
 	}
 	el.tail++
+	for _, listener := range el.listeners {
+		listener(*message)
+	}
+}
+
+//Listen registers the given func to be called with every event pushed to
+//this log from now on, the returned func stops the notifications. Listeners
+//are called while the log is locked, so they must not block nor use the log.
+func (el *EventLog) Listen(listener func(events.Message)) func() {
+	el.Lock()
+	defer el.Unlock()
+	if el.listeners == nil {
+		el.listeners = make(map[int]func(events.Message))
+	}
+	id := el.nextListener
+	el.nextListener++
+	el.listeners[id] = listener
+	return func() {
+		el.Lock()
+		defer el.Unlock()
+		delete(el.listeners, id)
+	}
 }
 
 func (el *EventLog) rewind() {
@@ -67,3 +67,19 @@ func BenchmarkEventLog(b *testing.B) {
 		eventLog.Push(&events.Message{Action: strconv.Itoa(i)})
 	}
 }
+
+func TestEventLogListen(t *testing.T) {
+	eventLog := NewEventLog()
+	var actions []string
+	stop := eventLog.Listen(func(event events.Message) {
+		actions = append(actions, event.Action)
+	})
+	eventLog.Push(&events.Message{Action: "start"})
+	eventLog.Push(&events.Message{Action: "die"})
+	stop()
+	eventLog.Push(&events.Message{Action: "destroy"})
+
+	if len(actions) != 2 || actions[0] != "start" || actions[1] != "die" {
+		t.Errorf("Listener was notified of unexpected events: %v", actions)
+	}
+}
@@ -1,6 +1,10 @@
 package docker
 
-import "strings"
+import (
+	"fmt"
+	"path"
+	"strings"
+)
 
 //ContainerFilter defines a function to filter container
 type ContainerFilter func(*Container) bool
@@ -35,6 +39,95 @@ func (cf ContainerFilter) ByID(id string) ContainerFilter {
 	}
 }
 
+//ByNameGlob filters containers by name, using a shell pattern (i.e. web-*)
+func (cf ContainerFilter) ByNameGlob(pattern string) ContainerFilter {
+	return func(c *Container) bool {
+		for _, containerName := range c.Names {
+			if ok, _ := path.Match(pattern, strings.TrimPrefix(containerName, "/")); ok {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+//ByImageGlob filters containers by image, using a shell pattern (i.e. nginx:*)
+func (cf ContainerFilter) ByImageGlob(pattern string) ContainerFilter {
+	return func(c *Container) bool {
+		ok, _ := path.Match(pattern, c.Image)
+		return ok
+	}
+}
+
+//ByImageID filters containers by the id of the image they were created from
+func (cf ContainerFilter) ByImageID(id string) ContainerFilter {
+	return func(c *Container) bool {
+		return c.ImageID == id
+	}
+}
+
+//ByLabel filters containers by label, if value is empty containers having the
+//label are kept no matter its value
+func (cf ContainerFilter) ByLabel(key, value string) ContainerFilter {
+	return func(c *Container) bool {
+		v, ok := c.Labels[key]
+		return ok && (value == "" || v == value)
+	}
+}
+
+//And combines the given filters, containers must pass all of them
+func (cf ContainerFilter) And(filters ...ContainerFilter) ContainerFilter {
+	return func(c *Container) bool {
+		for _, f := range filters {
+			if !f(c) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+//ParseContainerFilterExpression parses a filter expression made of terms
+//separated by spaces, containers must match every term. Terms are either
+//name=<pattern>, image=<pattern>, label=<key> or label=<key>=<value>, a term
+//without key is taken as a name pattern. Patterns are shell patterns.
+func ParseContainerFilterExpression(expression string) (ContainerFilter, error) {
+	var filters []ContainerFilter
+	for _, term := range strings.Fields(expression) {
+		key, value := "name", term
+		if i := strings.Index(term, "="); i >= 0 {
+			key, value = term[:i], term[i+1:]
+		}
+		if value == "" {
+			return nil, fmt.Errorf("no value given for %s", key)
+		}
+		switch key {
+		case "name", "image":
+			if _, err := path.Match(value, ""); err != nil {
+				return nil, fmt.Errorf("invalid %s pattern: %q", key, value)
+			}
+			if key == "name" {
+				filters = append(filters, ContainerFilters.ByNameGlob(value))
+			} else {
+				filters = append(filters, ContainerFilters.ByImageGlob(value))
+			}
+		case "label":
+			label := strings.SplitN(value, "=", 2)
+			labelValue := ""
+			if len(label) == 2 {
+				labelValue = label[1]
+			}
+			filters = append(filters, ContainerFilters.ByLabel(label[0], labelValue))
+		default:
+			return nil, fmt.Errorf("unknown filter: %q", key)
+		}
+	}
+	if len(filters) == 0 {
+		return nil, fmt.Errorf("empty filter expression")
+	}
+	return ContainerFilters.And(filters...), nil
+}
+
 //ByRunningState filters containers by its running state
 func (cf ContainerFilter) ByRunningState(running bool) ContainerFilter {
 	return func(c *Container) bool {
@@ -62,3 +155,63 @@ func (cf ContainerFilter) Apply(c []*Container) []*Container {
 	}
 	return containers
 }
+
+//ComposeProjectLabel is the label Docker Compose sets on containers with the
+//name of the project they belong to
+const ComposeProjectLabel = "com.docker.compose.project"
+
+//ComposeServiceLabel is the label Docker Compose sets on containers with the
+//name of the service they run
+const ComposeServiceLabel = "com.docker.compose.service"
+
+//LabelFilter keeps containers having a label, if Value is empty containers
+//having the label are kept no matter its value
+type LabelFilter struct {
+	Key   string
+	Value string
+}
+
+//ParseLabelFilter parses a label filter given as key or key=value
+func ParseLabelFilter(s string) (LabelFilter, error) {
+	kv := strings.SplitN(strings.TrimSpace(s), "=", 2)
+	if !labelKeyRegexp.MatchString(kv[0]) {
+		return LabelFilter{}, fmt.Errorf("invalid label key: %q", kv[0])
+	}
+	filter := LabelFilter{Key: kv[0]}
+	if len(kv) == 2 {
+		filter.Value = kv[1]
+	}
+	return filter, nil
+}
+
+//ComposeProjectFilter returns a filter keeping the containers of the Docker
+//Compose project the given container belongs to, false is returned if the
+//container does not belong to any
+func ComposeProjectFilter(c *Container) (LabelFilter, bool) {
+	project, ok := c.Labels[ComposeProjectLabel]
+	if !ok || project == "" {
+		return LabelFilter{}, false
+	}
+	return LabelFilter{Key: ComposeProjectLabel, Value: project}, true
+}
+
+//ProjectOf returns the Docker Compose project or, if it belongs to none, the
+//stack the given container belongs to, empty if it belongs to none of them
+func ProjectOf(c *Container) string {
+	if project := c.Labels[ComposeProjectLabel]; project != "" {
+		return project
+	}
+	return c.Labels[LabelNamespace]
+}
+
+//Filter returns this filter as a ContainerFilter
+func (f LabelFilter) Filter() ContainerFilter {
+	return ContainerFilters.ByLabel(f.Key, f.Value)
+}
+
+func (f LabelFilter) String() string {
+	if f.Value == "" {
+		return f.Key
+	}
+	return f.Key + "=" + f.Value
+}
@@ -61,3 +61,121 @@ func TestFilterByID(t *testing.T) {
 	}
 
 }
+
+func TestFilterByImageID(t *testing.T) {
+
+	filter := ContainerFilters.ByImageID("sha256:bla")
+
+	c := &Container{
+		Container: dockerTypes.Container{ImageID: "sha256:bla"},
+	}
+	if !filter(c) {
+		t.Error("Filter by image ID is filtering out when it should not")
+	}
+
+	c = &Container{
+		Container: dockerTypes.Container{ImageID: "sha256:bla123"},
+	}
+	if filter(c) {
+		t.Error("Filter by image ID is not filtering")
+	}
+}
+
+func TestParseContainerFilterExpression(t *testing.T) {
+	web := &Container{Container: dockerTypes.Container{
+		Names:  []string{"/web-1"},
+		Image:  "nginx:1.17",
+		Labels: map[string]string{"env": "prod", "team": "core"}}}
+	db := &Container{Container: dockerTypes.Container{
+		Names:  []string{"/db"},
+		Image:  "postgres:12",
+		Labels: map[string]string{"env": "dev"}}}
+
+	tests := []struct {
+		expression string
+		expected   []*Container
+		wantErr    bool
+	}{
+		{"web-*", []*Container{web}, false},
+		{"name=*", []*Container{web, db}, false},
+		{"image=nginx:*", []*Container{web}, false},
+		{"label=env", []*Container{web, db}, false},
+		{"label=env=dev", []*Container{db}, false},
+		{"label=team name=db", nil, false},
+		{"", nil, true},
+		{"name=", nil, true},
+		{"name=[", nil, true},
+		{"status=running", nil, true},
+	}
+	for _, tt := range tests {
+		filter, err := ParseContainerFilterExpression(tt.expression)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseContainerFilterExpression(%q) error = %v, wantErr %v", tt.expression, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		got := filter.Apply([]*Container{web, db})
+		if len(got) != len(tt.expected) {
+			t.Errorf("Filter %q kept %d containers, expected %d", tt.expression, len(got), len(tt.expected))
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.expected[i] {
+				t.Errorf("Filter %q kept an unexpected container: %v", tt.expression, got[i].Names)
+			}
+		}
+	}
+}
+
+func TestLabelFilter(t *testing.T) {
+	web := &Container{Container: dockerTypes.Container{
+		Labels: map[string]string{ComposeProjectLabel: "shop", "env": "prod"}}}
+	db := &Container{Container: dockerTypes.Container{
+		Labels: map[string]string{ComposeProjectLabel: "billing", "env": "prod"}}}
+	standalone := &Container{Container: dockerTypes.Container{}}
+
+	tests := []struct {
+		filter   string
+		expected []*Container
+		wantErr  bool
+	}{
+		{"env", []*Container{web, db}, false},
+		{"env=prod", []*Container{web, db}, false},
+		{ComposeProjectLabel + "=shop", []*Container{web}, false},
+		{"env=dev", nil, false},
+		{"", nil, true},
+		{"=prod", nil, true},
+	}
+	for _, tt := range tests {
+		filter, err := ParseLabelFilter(tt.filter)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseLabelFilter(%q) error = %v, wantErr %v", tt.filter, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if filter.String() != tt.filter {
+			t.Errorf("ParseLabelFilter(%q) is shown as %q", tt.filter, filter.String())
+		}
+		got := filter.Filter().Apply([]*Container{web, db, standalone})
+		if len(got) != len(tt.expected) {
+			t.Errorf("Label filter %q kept %d containers, expected %d", tt.filter, len(got), len(tt.expected))
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.expected[i] {
+				t.Errorf("Label filter %q kept an unexpected container: %v", tt.filter, got[i].Labels)
+			}
+		}
+	}
+
+	if filter, ok := ComposeProjectFilter(db); !ok || filter.String() != ComposeProjectLabel+"=billing" {
+		t.Errorf("Unexpected compose project filter: %v, %v", filter, ok)
+	}
+	if _, ok := ComposeProjectFilter(standalone); ok {
+		t.Error("A container not created by compose has no compose project filter")
+	}
+}
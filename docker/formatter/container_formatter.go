@@ -100,7 +100,7 @@ func (c *ContainerFormatter) CreatedAt() string {
 func (c *ContainerFormatter) RunningFor() string {
 	c.addHeader(runningForHeader)
 	if createdAt, err := time.Parse(time.RFC3339, c.c.ContainerJSON.State.StartedAt); err == nil {
-		return units.HumanDuration(time.Now().UTC().Sub(createdAt))
+		return units.HumanDuration(docker.Now().UTC().Sub(createdAt))
 	}
 	return ""
 }
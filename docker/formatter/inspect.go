@@ -0,0 +1,39 @@
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/docker/cli/templates"
+	"github.com/pkg/errors"
+)
+
+// Inspect executes the given Go template on the given inspected object, as
+// docker inspect --format does. If executing it on the object fails, it is
+// executed on the object decoded from JSON, so templates written against the
+// JSON document, i.e. using keys not present on the Go type, also work.
+func Inspect(format string, inspected interface{}) (string, error) {
+	tmpl, err := templates.Parse(format)
+	if err != nil {
+		return "", errors.Errorf("Template parsing error: %v", err)
+	}
+	buffer := new(bytes.Buffer)
+	if err := tmpl.Execute(buffer, inspected); err != nil {
+		rawElement, jsonErr := json.Marshal(inspected)
+		if jsonErr != nil {
+			return "", errors.Errorf("Template parsing error: %v", err)
+		}
+		var raw interface{}
+		dec := json.NewDecoder(bytes.NewReader(rawElement))
+		dec.UseNumber()
+		if err := dec.Decode(&raw); err != nil {
+			return "", errors.Errorf("unable to read inspect data: %v", err)
+		}
+		buffer.Reset()
+		if err := tmpl.Option("missingkey=error").Execute(buffer, raw); err != nil {
+			return "", errors.Errorf("Template parsing error: %v", err)
+		}
+	}
+	return strings.TrimSuffix(buffer.String(), "\n"), nil
+}
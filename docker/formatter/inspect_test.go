@@ -0,0 +1,49 @@
+package formatter
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/network"
+)
+
+func TestInspect(t *testing.T) {
+	inspected := types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{
+			ID:    "0123456789abcdef",
+			Name:  "/web",
+			State: &types.ContainerState{Pid: 42},
+		},
+		NetworkSettings: &types.NetworkSettings{
+			Networks: map[string]*network.EndpointSettings{
+				"bridge": {IPAddress: "172.17.0.2"},
+			},
+		},
+	}
+	tests := []struct {
+		format   string
+		expected string
+		err      bool
+	}{
+		{"{{.Name}}", "/web", false},
+		{"{{.State.Pid}}", "42", false},
+		{"{{.NetworkSettings.Networks.bridge.IPAddress}}", "172.17.0.2", false},
+		{"{{range .NetworkSettings.Networks}}{{.IPAddress}}{{end}}", "172.17.0.2", false},
+		{"{{json .State.Pid}}", "42", false},
+		{"{{upper .Name}}\n", "/WEB", false},
+		//Id is the JSON key of the ID field
+		{"{{.Id}}", "0123456789abcdef", false},
+		{"{{.Missing}}", "", true},
+		{"{{.Name", "", true},
+	}
+	for _, test := range tests {
+		got, err := Inspect(test.format, inspected)
+		if test.err != (err != nil) {
+			t.Errorf("%s: unexpected error: %v", test.format, err)
+			continue
+		}
+		if got != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.format, test.expected, got)
+		}
+	}
+}
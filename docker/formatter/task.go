@@ -3,7 +3,6 @@ package formatter
 import (
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/docker/distribution/reference"
 	"github.com/docker/docker/api/types/swarm"
@@ -85,7 +84,7 @@ func (t *TaskStringer) DesiredState() string {
 func (t *TaskStringer) CurrentState() string {
 	return fmt.Sprintf("%s %s ago",
 		PrettyPrint(t.task.Status.State),
-		strings.ToLower(units.HumanDuration(time.Since(t.task.Status.Timestamp))),
+		strings.ToLower(units.HumanDuration(docker.Since(t.task.Status.Timestamp))),
 	)
 }
 
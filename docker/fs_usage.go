@@ -0,0 +1,21 @@
+//go:build !windows
+// +build !windows
+
+package docker
+
+import "syscall"
+
+//fileSystemUsage returns the usage percentage of the filesystem of the given
+//directory, as df does, space reserved for root is not considered available
+func fileSystemUsage(dir string) (float64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(dir, &stat); err != nil {
+		return 0, err
+	}
+	used := (uint64(stat.Blocks) - uint64(stat.Bfree)) * uint64(stat.Bsize)
+	available := uint64(stat.Bavail) * uint64(stat.Bsize)
+	if used+available == 0 {
+		return 0, nil
+	}
+	return float64(used) / float64(used+available) * 100, nil
+}
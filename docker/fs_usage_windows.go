@@ -0,0 +1,8 @@
+package docker
+
+import "errors"
+
+//fileSystemUsage is not supported on Windows
+func fileSystemUsage(dir string) (float64, error) {
+	return 0, errors.New("filesystem usage is not supported on Windows")
+}
@@ -0,0 +1,115 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	dockerTypes "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	pkgError "github.com/pkg/errors"
+)
+
+//defaultHealthcheckTimeout is the time given to a healthcheck to run when
+//the container does not configure a timeout, it is the Docker default
+const defaultHealthcheckTimeout = 30 * time.Second
+
+//HealthcheckResult is the outcome of running a container healthcheck
+type HealthcheckResult struct {
+	ExitCode int
+	Output   string
+	Duration time.Duration
+}
+
+//Healthy returns true if the healthcheck succeeded
+func (r HealthcheckResult) Healthy() bool {
+	return r.ExitCode == 0
+}
+
+//HealthcheckCommand returns the command to exec to run the given healthcheck,
+//as Docker does. An error is returned if no healthcheck is configured or if
+//it is disabled.
+func HealthcheckCommand(config *container.HealthConfig) ([]string, error) {
+	if config == nil || len(config.Test) == 0 {
+		return nil, errors.New("no healthcheck configured")
+	}
+	switch config.Test[0] {
+	case "NONE":
+		return nil, errors.New("healthcheck is disabled")
+	case "CMD":
+		if len(config.Test) == 1 {
+			return nil, errors.New("healthcheck has no command")
+		}
+		return config.Test[1:], nil
+	case "CMD-SHELL":
+		if len(config.Test) != 2 {
+			return nil, fmt.Errorf("invalid healthcheck: %q", config.Test)
+		}
+		return []string{"/bin/sh", "-c", config.Test[1]}, nil
+	default:
+		return nil, fmt.Errorf("unknown healthcheck type: %q", config.Test[0])
+	}
+}
+
+//RunHealthcheck execs the healthcheck configured on the container with the
+//given id and returns its output and exit code. The result is not recorded
+//on the container health status.
+func (daemon *DockerDaemon) RunHealthcheck(id string) (HealthcheckResult, error) {
+	c, err := daemon.Inspect(id)
+	if err != nil {
+		return HealthcheckResult{}, err
+	}
+	if c.Config == nil {
+		return HealthcheckResult{}, errors.New("no healthcheck configured")
+	}
+	cmd, err := HealthcheckCommand(c.Config.Healthcheck)
+	if err != nil {
+		return HealthcheckResult{}, err
+	}
+	timeout := c.Config.Healthcheck.Timeout
+	if timeout <= 0 {
+		timeout = defaultHealthcheckTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	start := time.Now()
+	exec, err := daemon.client.ContainerExecCreate(ctx, id, dockerTypes.ExecConfig{
+		Cmd:          cmd,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return HealthcheckResult{}, pkgError.Wrapf(err, "Error running the healthcheck of container %s", id)
+	}
+	resp, err := daemon.client.ContainerExecAttach(ctx, exec.ID, dockerTypes.ExecStartCheck{})
+	if err != nil {
+		return HealthcheckResult{}, pkgError.Wrapf(err, "Error running the healthcheck of container %s", id)
+	}
+	defer resp.Close()
+	var output bytes.Buffer
+	if _, err := stdcopy.StdCopy(&output, &output, resp.Reader); err != nil {
+		return HealthcheckResult{}, pkgError.Wrapf(err, "Error reading the healthcheck output of container %s", id)
+	}
+	inspect, err := daemon.client.ContainerExecInspect(ctx, exec.ID)
+	if err != nil {
+		return HealthcheckResult{}, pkgError.Wrapf(err, "Error running the healthcheck of container %s", id)
+	}
+	return HealthcheckResult{
+		ExitCode: inspect.ExitCode,
+		Output:   output.String(),
+		Duration: time.Since(start),
+	}, nil
+}
+
+//ContainerHealth returns the health of the given container, with the last
+//healthcheck results, nil if unknown
+func ContainerHealth(c *Container) *dockerTypes.Health {
+	if c == nil || c.ContainerJSONBase == nil || c.ContainerJSON.State == nil {
+		return nil
+	}
+	return c.ContainerJSON.State.Health
+}
@@ -0,0 +1,37 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+func TestHealthcheckCommand(t *testing.T) {
+	tests := []struct {
+		config  *container.HealthConfig
+		want    []string
+		wantErr bool
+	}{
+		{nil, nil, true},
+		{&container.HealthConfig{}, nil, true},
+		{&container.HealthConfig{Test: []string{"NONE"}}, nil, true},
+		{&container.HealthConfig{Test: []string{"CMD"}}, nil, true},
+		{&container.HealthConfig{Test: []string{"CMD", "curl", "-f", "http://localhost"}},
+			[]string{"curl", "-f", "http://localhost"}, false},
+		{&container.HealthConfig{Test: []string{"CMD-SHELL", "curl -f http://localhost || exit 1"}},
+			[]string{"/bin/sh", "-c", "curl -f http://localhost || exit 1"}, false},
+		{&container.HealthConfig{Test: []string{"CMD-SHELL"}}, nil, true},
+		{&container.HealthConfig{Test: []string{"SOMETHING", "else"}}, nil, true},
+	}
+	for _, tt := range tests {
+		got, err := HealthcheckCommand(tt.config)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("HealthcheckCommand(%v) error = %v, wantErr %v", tt.config, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("HealthcheckCommand(%v) = %q, want %q", tt.config, got, tt.want)
+		}
+	}
+}
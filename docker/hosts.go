@@ -0,0 +1,92 @@
+package docker
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+)
+
+//HostSummary is the state of a Docker host, as shown on the multi-host dashboard
+type HostSummary struct {
+	//Name is the name the host is known by, a context or a configured host
+	Name       string
+	Host       string
+	Version    string
+	Containers int
+	Running    int
+	Paused     int
+	Stopped    int
+	//Latency is the time the Docker daemon took to report its state
+	Latency time.Duration
+	//Err is the reason the Docker daemon could not be reached, if so
+	Err error
+}
+
+//Healthy returns true if the Docker daemon of the host could be reached
+func (s HostSummary) Healthy() bool {
+	return s.Err == nil
+}
+
+//infoAPI is the subset of the Docker API used to summarize a host
+type infoAPI interface {
+	Info(ctx context.Context) (types.Info, error)
+}
+
+//SummarizeHost connects to the Docker host of the given environment and
+//returns its state, a host that cannot be reached is not an error but an
+//unhealthy host
+func SummarizeHost(ctx context.Context, name string, env Env) HostSummary {
+	summary := HostSummary{Name: name, Host: env.DockerHost}
+	if summary.Host == "" {
+		summary.Host = DefaultDockerHost
+	}
+	client, _, _, err := newClient(env, false)
+	if err != nil {
+		summary.Err = err
+		return summary
+	}
+	defer client.Close()
+	return summarize(ctx, summary, client)
+}
+
+func summarize(ctx context.Context, summary HostSummary, api infoAPI) HostSummary {
+	start := Now()
+	info, err := api.Info(ctx)
+	summary.Latency = Since(start)
+	if err != nil {
+		summary.Err = err
+		return summary
+	}
+	summary.Version = info.ServerVersion
+	summary.Containers = info.Containers
+	summary.Running = info.ContainersRunning
+	summary.Paused = info.ContainersPaused
+	summary.Stopped = info.ContainersStopped
+	return summary
+}
+
+//SummarizeHosts returns the state of the Docker hosts of the given
+//environments, by name, sorted by name. Hosts are queried concurrently.
+func SummarizeHosts(ctx context.Context, envs map[string]Env) []HostSummary {
+	summaries := make([]HostSummary, 0, len(envs))
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	for name, env := range envs {
+		wg.Add(1)
+		go func(name string, env Env) {
+			defer wg.Done()
+			summary := SummarizeHost(ctx, name, env)
+			lock.Lock()
+			summaries = append(summaries, summary)
+			lock.Unlock()
+		}(name, env)
+	}
+	wg.Wait()
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Name < summaries[j].Name
+	})
+	return summaries
+}
@@ -0,0 +1,54 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+)
+
+type infoAPIMock struct {
+	info types.Info
+	err  error
+}
+
+func (m infoAPIMock) Info(ctx context.Context) (types.Info, error) {
+	return m.info, m.err
+}
+
+func TestSummarize(t *testing.T) {
+	info := types.Info{
+		ServerVersion:     "19.03.5",
+		Containers:        6,
+		ContainersRunning: 3,
+		ContainersPaused:  1,
+		ContainersStopped: 2,
+	}
+	summary := summarize(context.Background(), HostSummary{Name: "prod"}, infoAPIMock{info: info})
+	if !summary.Healthy() || summary.Name != "prod" || summary.Version != "19.03.5" ||
+		summary.Containers != 6 || summary.Running != 3 || summary.Paused != 1 || summary.Stopped != 2 {
+		t.Errorf("Unexpected summary of a host: %+v", summary)
+	}
+
+	summary = summarize(context.Background(), HostSummary{Name: "prod"}, infoAPIMock{err: errors.New("connection refused")})
+	if summary.Healthy() || summary.Containers != 0 {
+		t.Errorf("Unexpected summary of an unreachable host: %+v", summary)
+	}
+}
+
+func TestSummarizeHosts(t *testing.T) {
+	envs := map[string]Env{
+		"staging": {DockerHost: "not a host"},
+		"prod":    {DockerHost: "not a host either"},
+	}
+	summaries := SummarizeHosts(context.Background(), envs)
+	if len(summaries) != 2 || summaries[0].Name != "prod" || summaries[1].Name != "staging" {
+		t.Fatalf("Unexpected summaries: %+v", summaries)
+	}
+	for _, s := range summaries {
+		if s.Healthy() {
+			t.Errorf("Host with an invalid address reported as healthy: %+v", s)
+		}
+	}
+}
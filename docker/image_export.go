@@ -0,0 +1,264 @@
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+	pkgError "github.com/pkg/errors"
+)
+
+//ImageExportFormat is the format images are exported to
+type ImageExportFormat string
+
+const (
+	//DockerArchive is the tar archive format of docker save
+	DockerArchive ImageExportFormat = "docker"
+	//OCILayout is the OCI image-layout directory format
+	OCILayout ImageExportFormat = "oci"
+)
+
+const (
+	ociLayoutVersion          = "1.0.0"
+	mediaTypeOCIConfig        = "application/vnd.oci.image.config.v1+json"
+	mediaTypeOCILayer         = "application/vnd.oci.image.layer.v1.tar"
+	mediaTypeOCILayerGzip     = "application/vnd.oci.image.layer.v1.tar+gzip"
+	annotationOCIRefName      = "org.opencontainers.image.ref.name"
+	annotationContainerdImage = "io.containerd.image.name"
+)
+
+//ociDescriptor describes a blob of an OCI image layout
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type ociManifest struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	MediaType     string          `json:"mediaType"`
+	Config        ociDescriptor   `json:"config"`
+	Layers        []ociDescriptor `json:"layers"`
+}
+
+type ociIndex struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	MediaType     string          `json:"mediaType"`
+	Manifests     []ociDescriptor `json:"manifests"`
+}
+
+//dockerArchiveManifest is an entry of the manifest.json file of a docker save archive
+type dockerArchiveManifest struct {
+	Config   string
+	RepoTags []string
+	Layers   []string
+}
+
+//ExportImages exports the given images, given by reference or id, to the given
+//path. Docker archives are written to a tar file, OCI layouts to a directory
+//that must not exist or be empty.
+func (daemon *DockerDaemon) ExportImages(refs []string, path string, format ImageExportFormat) error {
+	switch format {
+	case DockerArchive, OCILayout:
+	default:
+		return fmt.Errorf("unknown image export format: %q", format)
+	}
+	archive, err := daemon.client.ImageSave(context.Background(), refs)
+	if err != nil {
+		return pkgError.Wrap(err, "Error exporting images")
+	}
+	defer archive.Close()
+	if format == OCILayout {
+		return pkgError.Wrap(writeOCILayout(archive, path), "Error exporting images")
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return pkgError.Wrap(err, "Error exporting images")
+	}
+	if _, err := io.Copy(f, archive); err != nil {
+		f.Close()
+		return pkgError.Wrap(err, "Error exporting images")
+	}
+	return pkgError.Wrap(f.Close(), "Error exporting images")
+}
+
+//writeOCILayout converts the given docker save archive to an OCI image layout
+//written on the given directory. Archive files are stored as blobs, named by
+//their digest, while reading the archive, blobs not used by any image (i.e.
+//legacy metadata files) are removed once done.
+func writeOCILayout(archive io.Reader, dir string) error {
+	if entries, err := ioutil.ReadDir(dir); err == nil && len(entries) > 0 {
+		return fmt.Errorf("%s is not empty", dir)
+	}
+	blobsDir := filepath.Join(dir, "blobs", "sha256")
+	if err := os.MkdirAll(blobsDir, 0755); err != nil {
+		return err
+	}
+	var manifests []dockerArchiveManifest
+	blobs := make(map[string]ociDescriptor)
+	links := make(map[string]string)
+	r := tar.NewReader(archive)
+	for {
+		header, err := r.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		name := path.Clean(header.Name)
+		switch header.Typeflag {
+		case tar.TypeSymlink:
+			//Layers shared by several images are linked
+			links[name] = path.Join(path.Dir(name), header.Linkname)
+		case tar.TypeReg:
+			if name == "manifest.json" {
+				if err := json.NewDecoder(r).Decode(&manifests); err != nil {
+					return pkgError.Wrap(err, "invalid archive manifest")
+				}
+				continue
+			}
+			blob, err := writeBlob(blobsDir, r)
+			if err != nil {
+				return err
+			}
+			blobs[name] = blob
+		}
+	}
+	if len(manifests) == 0 {
+		return fmt.Errorf("no images found on the archive")
+	}
+	blob := func(name string) (ociDescriptor, error) {
+		name = path.Clean(name)
+		if target, ok := links[name]; ok {
+			name = target
+		}
+		b, ok := blobs[name]
+		if !ok {
+			return b, fmt.Errorf("%s not found on the archive", name)
+		}
+		return b, nil
+	}
+
+	used := make(map[string]bool)
+	index := ociIndex{SchemaVersion: 2, MediaType: mediaTypeOCIIndex}
+	for _, m := range manifests {
+		config, err := blob(m.Config)
+		if err != nil {
+			return err
+		}
+		used[config.Digest] = true
+		config.MediaType = mediaTypeOCIConfig
+		manifest := ociManifest{SchemaVersion: 2, MediaType: mediaTypeOCIManifest, Config: config}
+		for _, l := range m.Layers {
+			layer, err := blob(l)
+			if err != nil {
+				return err
+			}
+			manifest.Layers = append(manifest.Layers, layer)
+			used[layer.Digest] = true
+		}
+		content, err := json.Marshal(manifest)
+		if err != nil {
+			return err
+		}
+		descriptor, err := writeBlob(blobsDir, bytes.NewReader(content))
+		if err != nil {
+			return err
+		}
+		descriptor.MediaType = mediaTypeOCIManifest
+		used[descriptor.Digest] = true
+		if len(m.RepoTags) == 0 {
+			index.Manifests = append(index.Manifests, descriptor)
+		}
+		for _, tag := range m.RepoTags {
+			d := descriptor
+			d.Annotations = refAnnotations(tag)
+			index.Manifests = append(index.Manifests, d)
+		}
+	}
+	for _, b := range blobs {
+		if !used[b.Digest] {
+			os.Remove(filepath.Join(blobsDir, strings.TrimPrefix(b.Digest, "sha256:")))
+		}
+	}
+	if err := writeJSON(filepath.Join(dir, "index.json"), index); err != nil {
+		return err
+	}
+	return writeJSON(filepath.Join(dir, "oci-layout"), map[string]string{"imageLayoutVersion": ociLayoutVersion})
+}
+
+//writeBlob writes the given content to the given blobs directory, named by
+//its digest. Content found to be gzipped is described as a gzipped layer.
+func writeBlob(blobsDir string, content io.Reader) (ociDescriptor, error) {
+	tmp, err := ioutil.TempFile(blobsDir, ".blob-")
+	if err != nil {
+		return ociDescriptor{}, err
+	}
+	defer os.Remove(tmp.Name())
+	hash := sha256.New()
+	var head bytes.Buffer
+	size, err := io.Copy(io.MultiWriter(tmp, hash, &limitedBuffer{&head, 2}), content)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return ociDescriptor{}, err
+	}
+	digest := hex.EncodeToString(hash.Sum(nil))
+	if err := os.Rename(tmp.Name(), filepath.Join(blobsDir, digest)); err != nil {
+		return ociDescriptor{}, err
+	}
+	mediaType := mediaTypeOCILayer
+	if bytes.Equal(head.Bytes(), []byte{0x1f, 0x8b}) {
+		mediaType = mediaTypeOCILayerGzip
+	}
+	return ociDescriptor{MediaType: mediaType, Digest: "sha256:" + digest, Size: size}, nil
+}
+
+//refAnnotations returns the annotations used by OCI tools to name an image
+func refAnnotations(tag string) map[string]string {
+	annotations := map[string]string{annotationContainerdImage: tag}
+	if named, err := reference.ParseNormalizedNamed(tag); err == nil {
+		annotations[annotationContainerdImage] = named.String()
+		if tagged, ok := named.(reference.Tagged); ok {
+			annotations[annotationOCIRefName] = tagged.Tag()
+		}
+	}
+	return annotations
+}
+
+func writeJSON(file string, v interface{}) error {
+	content, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, content, 0644)
+}
+
+//limitedBuffer keeps up to max bytes of what is written to it
+type limitedBuffer struct {
+	buf *bytes.Buffer
+	max int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if left := b.max - b.buf.Len(); left > 0 {
+		if len(p) < left {
+			left = len(p)
+		}
+		b.buf.Write(p[:left])
+	}
+	return len(p), nil
+}
@@ -0,0 +1,93 @@
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func digestOf(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func dockerSaveArchive(t *testing.T) *bytes.Buffer {
+	var buf bytes.Buffer
+	w := tar.NewWriter(&buf)
+	file := func(name, content string) {
+		w.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(content))})
+		w.Write([]byte(content))
+	}
+	file("l1/layer.tar", "layer one")
+	file("l1/json", "{}")
+	w.WriteHeader(&tar.Header{Name: "l2/layer.tar", Typeflag: tar.TypeSymlink, Linkname: "../l1/layer.tar"})
+	file("config.json", `{"architecture":"amd64"}`)
+	file("manifest.json", `[{"Config":"config.json","RepoTags":["nginx:1.19"],"Layers":["l1/layer.tar","l2/layer.tar"]}]`)
+	file("repositories", `{"nginx":{"1.19":"l1"}}`)
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return &buf
+}
+
+func TestWriteOCILayout(t *testing.T) {
+	dir, err := ioutil.TempDir("", "oci-layout")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := writeOCILayout(dockerSaveArchive(t), dir); err != nil {
+		t.Fatalf("Unexpected error writing OCI layout: %s", err)
+	}
+
+	var index ociIndex
+	readJSON(t, filepath.Join(dir, "index.json"), &index)
+	if len(index.Manifests) != 1 {
+		t.Fatalf("Unexpected index manifests: %+v", index.Manifests)
+	}
+	descriptor := index.Manifests[0]
+	if descriptor.Annotations[annotationOCIRefName] != "1.19" ||
+		descriptor.Annotations[annotationContainerdImage] != "docker.io/library/nginx:1.19" {
+		t.Errorf("Unexpected manifest annotations: %v", descriptor.Annotations)
+	}
+
+	var manifest ociManifest
+	readJSON(t, filepath.Join(dir, "blobs", "sha256", descriptor.Digest[len("sha256:"):]), &manifest)
+	if manifest.Config.Digest != digestOf(`{"architecture":"amd64"}`) || manifest.Config.MediaType != mediaTypeOCIConfig {
+		t.Errorf("Unexpected manifest config: %+v", manifest.Config)
+	}
+	layer := digestOf("layer one")
+	if len(manifest.Layers) != 2 || manifest.Layers[0].Digest != layer || manifest.Layers[1].Digest != layer {
+		t.Errorf("Unexpected manifest layers: %+v", manifest.Layers)
+	}
+
+	blobs, _ := ioutil.ReadDir(filepath.Join(dir, "blobs", "sha256"))
+	//layer, config and manifest, legacy files are not kept
+	if len(blobs) != 3 {
+		t.Errorf("Unexpected number of blobs, got %d, expected 3", len(blobs))
+	}
+	if _, err := os.Stat(filepath.Join(dir, "oci-layout")); err != nil {
+		t.Errorf("oci-layout file not written: %s", err)
+	}
+
+	if err := writeOCILayout(dockerSaveArchive(t), dir); err == nil {
+		t.Error("OCI layout was written on a non-empty directory")
+	}
+}
+
+func readJSON(t *testing.T, file string, v interface{}) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(content, v); err != nil {
+		t.Fatal(err)
+	}
+}
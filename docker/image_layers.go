@@ -0,0 +1,107 @@
+package docker
+
+import (
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/image"
+)
+
+//ImageLayer is an entry of the history of an image, with the filesystem
+//layer it created, if any
+type ImageLayer struct {
+	image.HistoryResponseItem
+	//DiffID is the digest of the content of the layer, empty if the entry
+	//created no layer or the layer could not be told
+	DiffID string
+	//SharedWith are the other local images having this layer, and every
+	//layer below it, so the layer is stored once for all of them
+	SharedWith []string
+}
+
+//metadataInstructions are the Dockerfile instructions that only change the
+//image configuration, creating no layer
+var metadataInstructions = map[string]bool{
+	"ARG": true, "CMD": true, "ENTRYPOINT": true, "ENV": true, "EXPOSE": true,
+	"HEALTHCHECK": true, "LABEL": true, "MAINTAINER": true, "ONBUILD": true,
+	"SHELL": true, "STOPSIGNAL": true, "USER": true, "VOLUME": true, "WORKDIR": true,
+}
+
+//createsLayer returns true if the given history entry most likely created
+//a layer, entries that create empty layers are not told apart from those
+//that only change the configuration
+func createsLayer(h image.HistoryResponseItem) bool {
+	if h.Size > 0 {
+		return true
+	}
+	createdBy := strings.TrimSpace(h.CreatedBy)
+	if i := strings.Index(createdBy, "#(nop)"); i >= 0 {
+		instruction := strings.Fields(createdBy[i+len("#(nop)"):])
+		return len(instruction) > 0 && (instruction[0] == "ADD" || instruction[0] == "COPY")
+	}
+	instruction := strings.Fields(createdBy)
+	return len(instruction) == 0 || !metadataInstructions[strings.ToUpper(instruction[0])]
+}
+
+//ImageLayers returns the history of the given image, newest entry first,
+//with the layer of every entry and the other images, of the given ones,
+//sharing it
+func ImageLayers(history []image.HistoryResponseItem, img types.ImageInspect, others []types.ImageInspect) []ImageLayer {
+	layers := make([]ImageLayer, len(history))
+	for i, h := range history {
+		layers[i] = ImageLayer{HistoryResponseItem: h}
+	}
+	diffIDs := img.RootFS.Layers
+	//history entries are matched with layers oldest first, using the
+	//entries that created a layer, or, if they do not add up, those with
+	//some content
+	for _, creates := range []func(image.HistoryResponseItem) bool{
+		createsLayer,
+		func(h image.HistoryResponseItem) bool { return h.Size > 0 }} {
+		var entries []int
+		for i := len(history) - 1; i >= 0; i-- {
+			if creates(history[i]) {
+				entries = append(entries, i)
+			}
+		}
+		if len(entries) != len(diffIDs) {
+			continue
+		}
+		for layer, entry := range entries {
+			layers[entry].DiffID = diffIDs[layer]
+			layers[entry].SharedWith = sharingLayers(diffIDs[:layer+1], img.ID, others)
+		}
+		break
+	}
+	return layers
+}
+
+//sharingLayers returns the names of the images, other than the one with
+//the given id, whose bottom layers are the given ones
+func sharingLayers(layers []string, id string, images []types.ImageInspect) []string {
+	var names []string
+	for _, img := range images {
+		if img.ID == id || len(img.RootFS.Layers) < len(layers) {
+			continue
+		}
+		shared := true
+		for i, layer := range layers {
+			if img.RootFS.Layers[i] != layer {
+				shared = false
+				break
+			}
+		}
+		if shared {
+			names = append(names, ImageName(img))
+		}
+	}
+	return names
+}
+
+//ImageName returns the first tag of the given image, its short id if untagged
+func ImageName(img types.ImageInspect) string {
+	if len(img.RepoTags) > 0 {
+		return img.RepoTags[0]
+	}
+	return ShortImageID(img.ID)
+}
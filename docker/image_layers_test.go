@@ -0,0 +1,77 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/image"
+)
+
+func inspectWithLayers(id, tag string, layers ...string) types.ImageInspect {
+	img := types.ImageInspect{ID: id}
+	if tag != "" {
+		img.RepoTags = []string{tag}
+	}
+	img.RootFS.Layers = layers
+	return img
+}
+
+func TestImageLayers(t *testing.T) {
+	//newest first, as returned by the Docker API
+	history := []image.HistoryResponseItem{
+		{ID: "sha256:app", CreatedBy: `/bin/sh -c #(nop)  CMD ["app"]`},
+		{ID: "<missing>", CreatedBy: `/bin/sh -c #(nop) COPY file:abc in /app`, Size: 2048},
+		{ID: "<missing>", CreatedBy: `RUN /bin/sh -c touch /tmp/empty`},
+		{ID: "<missing>", CreatedBy: `ENV PATH=/usr/bin`},
+		{ID: "<missing>", CreatedBy: `/bin/sh -c #(nop) ADD file:base in / `, Size: 5000000},
+	}
+	img := inspectWithLayers("sha256:app", "app:latest", "base", "empty", "copy")
+	others := []types.ImageInspect{
+		img,
+		inspectWithLayers("sha256:other", "other:1", "base", "empty", "other"),
+		inspectWithLayers("sha256:base", "", "base"),
+		inspectWithLayers("sha256:unrelated", "unrelated:1", "unrelated"),
+	}
+	layers := ImageLayers(history, img, others)
+	if len(layers) != len(history) {
+		t.Fatalf("Unexpected number of layers: %d", len(layers))
+	}
+	expected := []struct {
+		diffID string
+		shared []string
+	}{
+		{"", nil},
+		{"copy", nil},
+		{"empty", []string{"other:1"}},
+		{"", nil},
+		{"base", []string{"other:1", ShortImageID("sha256:base")}},
+	}
+	for i, e := range expected {
+		if layers[i].DiffID != e.diffID || !reflect.DeepEqual(layers[i].SharedWith, e.shared) {
+			t.Errorf("Unexpected layer %d, got %s shared with %v, expected %s shared with %v",
+				i, layers[i].DiffID, layers[i].SharedWith, e.diffID, e.shared)
+		}
+	}
+}
+
+func TestImageLayersBySize(t *testing.T) {
+	//a WORKDIR that created a layer is only told by its size
+	history := []image.HistoryResponseItem{
+		{CreatedBy: `WORKDIR /app`, Size: 10},
+		{CreatedBy: `RUN /bin/sh -c true`},
+		{CreatedBy: `ADD file:base in /`, Size: 100},
+	}
+	img := inspectWithLayers("sha256:app", "app:latest", "base", "workdir")
+	layers := ImageLayers(history, img, nil)
+	if layers[0].DiffID != "workdir" || layers[1].DiffID != "" || layers[2].DiffID != "base" {
+		t.Errorf("Unexpected layers: %+v", layers)
+	}
+
+	img.RootFS.Layers = []string{"base"}
+	for _, l := range ImageLayers(history, img, nil) {
+		if l.DiffID != "" {
+			t.Errorf("Layer guessed when history entries and layers do not add up: %+v", l)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package docker
+
+import (
+	"context"
+
+	"github.com/docker/distribution/reference"
+	dockerTypes "github.com/docker/docker/api/types"
+)
+
+//ImageUpdate is the result of checking if the tag of a local image points
+//to another image on the registry
+type ImageUpdate struct {
+	//Tag is the tag checked, i.e. nginx:latest
+	Tag     string
+	ImageID string
+	//LocalDigest is the digest the local image was pulled with
+	LocalDigest string
+	//RemoteDigest is the digest the tag points to on the registry
+	RemoteDigest string
+	Err          error
+}
+
+//Outdated returns true if the tag points to a newer image on the registry
+func (u ImageUpdate) Outdated() bool {
+	return u.Err == nil && u.RemoteDigest != "" && u.RemoteDigest != u.LocalDigest
+}
+
+//CheckImageUpdates checks, for every tag of the given images, if the tag
+//points to another image on the registry. Images not pulled from a
+//registry, those without a repository digest, are left out.
+func (daemon *DockerDaemon) CheckImageUpdates(images []dockerTypes.ImageSummary) []ImageUpdate {
+	return checkImageUpdates(images, func(domain string) *registryClient {
+		return newRegistryClient(domain)
+	})
+}
+
+func checkImageUpdates(images []dockerTypes.ImageSummary, clientFor func(domain string) *registryClient) []ImageUpdate {
+	var updates []ImageUpdate
+	for _, image := range images {
+		for _, tag := range image.RepoTags {
+			named, err := reference.ParseNormalizedNamed(tag)
+			if err != nil {
+				continue
+			}
+			tagged, ok := named.(reference.NamedTagged)
+			if !ok {
+				continue
+			}
+			local := repoDigest(image.RepoDigests, named.Name())
+			if local == "" {
+				continue
+			}
+			update := ImageUpdate{
+				Tag:         reference.FamiliarString(named),
+				ImageID:     image.ID,
+				LocalDigest: local,
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), defaultRegistryTimeout)
+			//Tokens are scoped to a repository, a client is needed for each one
+			update.RemoteDigest, update.Err = clientFor(reference.Domain(named)).digest(
+				ctx, reference.Path(named), tagged.Tag())
+			cancel()
+			updates = append(updates, update)
+		}
+	}
+	return updates
+}
+
+//repoDigest returns the digest, from the given repository digests, of the
+//repository with the given name, empty if there is none
+func repoDigest(repoDigests []string, name string) string {
+	for _, d := range repoDigests {
+		named, err := reference.ParseNormalizedNamed(d)
+		if err != nil {
+			continue
+		}
+		if canonical, ok := named.(reference.Canonical); ok && named.Name() == name {
+			return canonical.Digest().String()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,65 @@
+package docker
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	dockerTypes "github.com/docker/docker/api/types"
+)
+
+const (
+	oldDigest = "sha256:1111111111111111111111111111111111111111111111111111111111111111"
+	newDigest = "sha256:2222222222222222222222222222222222222222222222222222222222222222"
+)
+
+func TestCheckImageUpdates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Errorf("Unexpected request method: %s", r.Method)
+		}
+		switch r.URL.Path {
+		case "/v2/library/nginx/manifests/latest":
+			w.Header().Set("Docker-Content-Digest", newDigest)
+		case "/v2/library/redis/manifests/6":
+			w.Header().Set("Docker-Content-Digest", oldDigest)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	images := []dockerTypes.ImageSummary{
+		{ID: "nginx", RepoTags: []string{"nginx:latest"}, RepoDigests: []string{"nginx@" + oldDigest}},
+		{ID: "redis", RepoTags: []string{"redis:6"}, RepoDigests: []string{"redis@" + oldDigest}},
+		{ID: "local", RepoTags: []string{"app:dev"}},
+		{ID: "gone", RepoTags: []string{"example/gone:1"}, RepoDigests: []string{"example/gone@" + oldDigest}},
+	}
+	var domains []string
+	updates := checkImageUpdates(images, func(domain string) *registryClient {
+		domains = append(domains, domain)
+		return &registryClient{client: server.Client(), baseURL: server.URL}
+	})
+	if len(updates) != 3 || len(domains) != 3 || domains[0] != dockerHubDomain {
+		t.Fatalf("Unexpected image updates: %+v, registries: %v", updates, domains)
+	}
+	if !updates[0].Outdated() || updates[0].Tag != "nginx:latest" || updates[0].RemoteDigest != newDigest {
+		t.Errorf("Newer image not found: %+v", updates[0])
+	}
+	if updates[1].Outdated() {
+		t.Errorf("Up to date image found outdated: %+v", updates[1])
+	}
+	if updates[2].Err == nil || updates[2].Outdated() {
+		t.Errorf("Missing image on the registry not reported: %+v", updates[2])
+	}
+}
+
+func TestRepoDigest(t *testing.T) {
+	digests := []string{"mirror.example.com/nginx@" + newDigest, "nginx@" + oldDigest}
+	if d := repoDigest(digests, "docker.io/library/nginx"); d != oldDigest {
+		t.Errorf("Unexpected digest: %s", d)
+	}
+	if d := repoDigest(digests, "docker.io/library/redis"); d != "" {
+		t.Errorf("Unexpected digest of another repository: %s", d)
+	}
+}
@@ -1,7 +1,10 @@
 package docker
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"sync"
 
 	dockerTypes "github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/image"
@@ -39,13 +42,18 @@ func (daemon *DockerDaemon) ImageByID(id string) (dockerTypes.ImageSummary, erro
 //Images returns the list of Docker images
 func (daemon *DockerDaemon) Images() ([]dockerTypes.ImageSummary, error) {
 
-	return images(daemon.client, defaultImageListOptions)
-
+	images, err := images(daemon.client, defaultImageListOptions)
+	if err == nil {
+		daemon.trackUnusedImages(images)
+	}
+	return images, err
 }
 
-//RunImage creates a container based on the given image and runs the given command
-//Kind of like running "docker run $image $command" from the command line.
-func (daemon *DockerDaemon) RunImage(image dockerTypes.ImageSummary, command string) error {
+//RunImage creates a container based on the given image and starts it, with
+//the given options. Kind of like running "docker run $opts $image $command" from
+//the command line. Without ports given, the ports exposed by the image are
+//published on the same host ports. Returns the id of the container.
+func (daemon *DockerDaemon) RunImage(image dockerTypes.ImageSummary, opts RunOptions) (string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
 	defer cancel()
 
@@ -56,28 +64,156 @@ func (daemon *DockerDaemon) RunImage(image dockerTypes.ImageSummary, command str
 		imageName = image.RepoDigests[0]
 
 	} else {
-		return pkgError.New("Cannot run image, image has no tag or digest")
+		return "", pkgError.New("Cannot run image, image has no tag or digest")
 	}
 
 	imageDetails, err := daemon.InspectImage(imageName)
 	if err != nil {
-		return pkgError.Wrap(err, fmt.Sprintf("Cannot get image details %s", imageName))
+		return "", pkgError.Wrap(err, fmt.Sprintf("Cannot get image details %s", imageName))
 	}
 
-	cc, hc, err := newCCB().image(imageName).command(command).ports(imageDetails.ContainerConfig.ExposedPorts).build()
+	ccb := newCCB().image(imageName).args(opts.Command).env(opts.Env).interactive(opts.Interactive)
+	if len(opts.Ports) > 0 {
+		ccb = ccb.publish(opts.Ports)
+	} else if imageDetails.ContainerConfig != nil {
+		ccb = ccb.ports(imageDetails.ContainerConfig.ExposedPorts)
+	}
+	cc, hc, err := ccb.build()
 	if err != nil {
-		return pkgError.Wrap(err, "Error configuring container")
+		return "", pkgError.Wrap(err, "Error configuring container")
 	}
 
-	cCreated, err := daemon.client.ContainerCreate(ctx, &cc, &hc, nil, "")
+	cCreated, err := daemon.client.ContainerCreate(ctx, &cc, &hc, nil, opts.Name)
 
 	if err != nil {
-		return pkgError.Wrap(err, fmt.Sprintf("Cannot create container for image %s", imageName))
+		return "", pkgError.Wrap(err, fmt.Sprintf("Cannot create container for image %s", imageName))
 	}
 
 	if err := daemon.client.ContainerStart(ctx, cCreated.ID, dockerTypes.ContainerStartOptions{}); err != nil {
-		return err /*pkgError.Wrap(err, fmt.Sprintf("Cannot start container %s for image %s", cCreated.ID, imageName))*/
+		return cCreated.ID, err /*pkgError.Wrap(err, fmt.Sprintf("Cannot start container %s for image %s", cCreated.ID, imageName))*/
+
+	}
+	return cCreated.ID, nil
+}
+
+//PullEstimate estimates the download size of pulling the given image on the
+//Docker host, by looking at the image manifest on the registry
+func (daemon *DockerDaemon) PullEstimate(image string) (PullEstimate, error) {
+	v, err := daemon.Version()
+	if err != nil {
+		return PullEstimate{}, pkgError.Wrap(err, "error retrieving Docker host platform")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultRegistryTimeout)
+	defer cancel()
+	return estimatePull(ctx, image, v.Os, v.Arch)
+}
+
+//PullImage pulls the given image, using the credentials saved by docker login
+//for its registry, if any. It blocks until the image is pulled.
+func (daemon *DockerDaemon) PullImage(image string) error {
+	//without the saved credentials the pull is tried anonymously, as the
+	//registry might not need them
+	auth, _ := StoredRegistryAuth(image)
+	return daemon.PullImageWithAuth(image, auth)
+}
+
+//PullImageWithAuth pulls the given image authenticating on its registry with
+//the given credentials, it blocks until the image is pulled. If the registry
+//refuses access IsUnauthorized returns true for the error returned.
+func (daemon *DockerDaemon) PullImageWithAuth(image string, auth RegistryAuth) error {
+	registryAuth, err := auth.encode()
+	if err != nil {
+		return pkgError.Wrap(err, "error encoding registry credentials")
+	}
+	stream, err := daemon.client.ImagePull(context.Background(), image, dockerTypes.ImagePullOptions{
+		RegistryAuth: registryAuth,
+	})
+	if err != nil {
+		if IsUnauthorized(err) || isUnauthorizedMessage(err.Error()) {
+			err = unauthorizedError{err}
+		}
+		return pkgError.Wrap(err, fmt.Sprintf("error pulling image %s", image))
+	}
+	defer stream.Close()
+	//The pull is done once the progress stream ends, errors are reported on it
+	decoder := json.NewDecoder(stream)
+	for {
+		var msg struct {
+			Error string `json:"error"`
+		}
+		if err := decoder.Decode(&msg); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return pkgError.Wrap(err, fmt.Sprintf("error pulling image %s", image))
+		}
+		if msg.Error != "" {
+			err := fmt.Errorf("error pulling image %s: %s", image, msg.Error)
+			if isUnauthorizedMessage(msg.Error) {
+				return unauthorizedError{err}
+			}
+			return err
+		}
+	}
+}
 
+//imageRemovalWorkers is the number of images removed concurrently
+const imageRemovalWorkers = 4
+
+//RemoveImages removes the images with the given ids, several at a time. The
+//given func is called with the result of each removal, calls are not concurrent.
+func (daemon *DockerDaemon) RemoveImages(ids []string, force bool, removed func(id string, err error)) {
+	type result struct {
+		id  string
+		err error
+	}
+	pending := make(chan string)
+	results := make(chan result)
+	var wg sync.WaitGroup
+	for i := 0; i < imageRemovalWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range pending {
+				_, err := daemon.Rmi(id, force)
+				results <- result{id, err}
+			}
+		}()
+	}
+	go func() {
+		for _, id := range ids {
+			pending <- id
+		}
+		close(pending)
+		wg.Wait()
+		close(results)
+	}()
+	for r := range results {
+		if removed != nil {
+			removed(r.id, r.err)
+		}
+	}
+}
+
+//IsDangling returns true if the given image is dangling, it has no tag
+func IsDangling(image dockerTypes.ImageSummary) bool {
+	for _, tag := range image.RepoTags {
+		if tag != "<none>:<none>" {
+			return false
+		}
+	}
+	return true
+}
+
+//ReclaimableSize returns the disk space freed by removing the given images,
+//layers shared with other images are left out when the daemon reports them
+func ReclaimableSize(images []dockerTypes.ImageSummary) int64 {
+	var size int64
+	for _, image := range images {
+		if image.SharedSize > 0 {
+			size += image.Size - image.SharedSize
+		} else {
+			size += image.Size
+		}
 	}
-	return nil
+	return size
 }
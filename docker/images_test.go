@@ -9,11 +9,56 @@ import (
 
 func TestImageRun(t *testing.T) {
 	daemon := DockerDaemon{client: mock.ImageAPIClientMock{}}
-	err := daemon.RunImage(types.ImageSummary{
+	_, err := daemon.RunImage(types.ImageSummary{
 		RepoTags: []string{"nope:latest"},
-	}, "command")
+	}, RunOptions{Command: []string{"command"}})
 
 	if err != nil {
 		t.Errorf("Running an image resulted in error %s", err.Error())
 	}
 }
+
+func TestRemoveImages(t *testing.T) {
+	daemon := DockerDaemon{client: mock.ImageAPIClientMock{}}
+	ids := []string{"1", "2", "in-use", "3", "4", "5"}
+	removed := make(map[string]error)
+	daemon.RemoveImages(ids, false, func(id string, err error) {
+		removed[id] = err
+	})
+
+	if len(removed) != len(ids) {
+		t.Fatalf("Expected %d removal results, got %d", len(ids), len(removed))
+	}
+	for id, err := range removed {
+		if (err != nil) != (id == "in-use") {
+			t.Errorf("Unexpected removal result for image %s: %v", id, err)
+		}
+	}
+}
+
+func TestIsDangling(t *testing.T) {
+	tests := []struct {
+		tags []string
+		want bool
+	}{
+		{nil, true},
+		{[]string{"<none>:<none>"}, true},
+		{[]string{"nginx:latest"}, false},
+		{[]string{"<none>:<none>", "nginx:1"}, false},
+	}
+	for _, tt := range tests {
+		if got := IsDangling(types.ImageSummary{RepoTags: tt.tags}); got != tt.want {
+			t.Errorf("IsDangling(%v) = %v, want %v", tt.tags, got, tt.want)
+		}
+	}
+}
+
+func TestReclaimableSize(t *testing.T) {
+	images := []types.ImageSummary{
+		{Size: 300, SharedSize: 100},
+		{Size: 500, SharedSize: -1},
+	}
+	if size := ReclaimableSize(images); size != 700 {
+		t.Errorf("Unexpected reclaimable size: %d", size)
+	}
+}
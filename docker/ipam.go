@@ -0,0 +1,152 @@
+package docker
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+)
+
+//maxCountedHostBits is the number of host bits above which the size of a
+//subnet is not counted, IPv6 subnets are never exhausted
+const maxCountedHostBits = 32
+
+//SubnetUsage is the usage of the addresses of a subnet of a network
+type SubnetUsage struct {
+	Subnet string
+	//Size is the number of addresses that can be given to endpoints, -1 if
+	//the subnet is too big to be exhausted
+	Size int64
+	//Allocated is the number of addresses given to endpoints
+	Allocated int64
+}
+
+//Available returns the number of addresses left, -1 if the subnet is
+//too big to be exhausted
+func (u SubnetUsage) Available() int64 {
+	if u.Size < 0 {
+		return -1
+	}
+	if u.Allocated > u.Size {
+		return 0
+	}
+	return u.Size - u.Allocated
+}
+
+//Usage returns the percentage of the subnet in use
+func (u SubnetUsage) Usage() float64 {
+	if u.Size <= 0 {
+		return 0
+	}
+	return float64(u.Allocated) * 100 / float64(u.Size)
+}
+
+//String returns the usage as allocated/size
+func (u SubnetUsage) String() string {
+	if u.Size < 0 {
+		return fmt.Sprintf("%d/-", u.Allocated)
+	}
+	return fmt.Sprintf("%d/%d", u.Allocated, u.Size)
+}
+
+//IPAMUsage returns the usage of the addresses of every subnet of the given
+//network. The endpoints of containers, service tasks and service virtual IPs
+//are counted as allocated. The network and broadcast addresses and the
+//gateway are not counted as part of the subnet.
+func IPAMUsage(n types.NetworkResource) []SubnetUsage {
+	allocated := allocatedIPs(n)
+	var usage []SubnetUsage
+	for _, config := range n.IPAM.Config {
+		_, subnet, err := net.ParseCIDR(config.Subnet)
+		if err != nil {
+			continue
+		}
+		pool := subnet
+		if config.IPRange != "" {
+			if _, ipRange, err := net.ParseCIDR(config.IPRange); err == nil {
+				pool = ipRange
+			}
+		}
+		u := SubnetUsage{Subnet: config.Subnet, Size: poolSize(subnet, pool)}
+		if gw := net.ParseIP(config.Gateway); gw != nil && pool.Contains(gw) && u.Size > 0 {
+			u.Size--
+		}
+		for _, ip := range allocated {
+			if pool.Contains(ip) && !ip.Equal(net.ParseIP(config.Gateway)) {
+				u.Allocated++
+			}
+		}
+		usage = append(usage, u)
+	}
+	return usage
+}
+
+//poolSize returns the number of addresses of the given pool of the given
+//subnet that can be allocated, leaving out the network and broadcast
+//addresses of IPv4 subnets
+func poolSize(subnet, pool *net.IPNet) int64 {
+	ones, bits := pool.Mask.Size()
+	hostBits := bits - ones
+	if hostBits > maxCountedHostBits {
+		return -1
+	}
+	size := int64(1) << uint(hostBits)
+	if bits == net.IPv4len*8 {
+		subnetOnes, _ := subnet.Mask.Size()
+		if subnetOnes < 31 {
+			if pool.Contains(subnet.IP) {
+				size--
+			}
+			if pool.Contains(lastIP(subnet)) {
+				size--
+			}
+		}
+	}
+	if size < 0 {
+		size = 0
+	}
+	return size
+}
+
+//lastIP returns the last address of the given subnet, its broadcast
+//address for IPv4 subnets
+func lastIP(subnet *net.IPNet) net.IP {
+	ip := subnet.IP.To4()
+	if ip == nil {
+		ip = subnet.IP
+	}
+	last := make(net.IP, len(ip))
+	for i := range ip {
+		last[i] = ip[i] | ^subnet.Mask[i]
+	}
+	return last
+}
+
+//allocatedIPs returns the distinct addresses of the endpoints of the given network
+func allocatedIPs(n types.NetworkResource) []net.IP {
+	seen := make(map[string]bool)
+	var ips []net.IP
+	add := func(address string) {
+		if i := strings.Index(address, "/"); i >= 0 {
+			address = address[:i]
+		}
+		ip := net.ParseIP(address)
+		if ip == nil || seen[ip.String()] {
+			return
+		}
+		seen[ip.String()] = true
+		ips = append(ips, ip)
+	}
+	for _, endpoint := range n.Containers {
+		add(endpoint.IPv4Address)
+		add(endpoint.IPv6Address)
+	}
+	for _, service := range n.Services {
+		add(service.VIP)
+		for _, task := range service.Tasks {
+			add(task.EndpointIP)
+		}
+	}
+	return ips
+}
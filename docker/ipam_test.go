@@ -0,0 +1,47 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/network"
+)
+
+func TestIPAMUsage(t *testing.T) {
+	n := types.NetworkResource{
+		IPAM: network.IPAM{Config: []network.IPAMConfig{
+			{Subnet: "10.0.1.0/24", Gateway: "10.0.1.1"},
+			{Subnet: "172.30.0.0/16", IPRange: "172.30.5.0/28"},
+			{Subnet: "fd00:1::/64"},
+			{Subnet: "invalid"},
+		}},
+		Containers: map[string]types.EndpointResource{
+			"a":      {IPv4Address: "10.0.1.2/24", IPv6Address: "fd00:1::2/64"},
+			"b":      {IPv4Address: "10.0.1.3/24"},
+			"c":      {IPv4Address: "172.30.5.4/16"},
+			"lb-net": {IPv4Address: "10.0.1.4/24"},
+		},
+		Services: map[string]network.ServiceInfo{
+			"web": {VIP: "10.0.1.5", Tasks: []network.Task{{EndpointIP: "10.0.1.6"}, {EndpointIP: "10.0.1.3"}}},
+		},
+	}
+	usage := IPAMUsage(n)
+	if len(usage) != 3 {
+		t.Fatalf("Unexpected subnets: %+v", usage)
+	}
+	//256 addresses, minus the network, broadcast and gateway addresses
+	if u := usage[0]; u.Subnet != "10.0.1.0/24" || u.Size != 253 || u.Allocated != 5 || u.Available() != 248 || u.String() != "5/253" {
+		t.Errorf("Unexpected usage of an IPv4 subnet: %+v", u)
+	}
+	if u := usage[1]; u.Size != 16 || u.Allocated != 1 {
+		t.Errorf("Unexpected usage of an IP range: %+v", u)
+	}
+	if u := usage[2]; u.Size != -1 || u.Allocated != 1 || u.Available() != -1 || u.Usage() != 0 {
+		t.Errorf("Unexpected usage of an IPv6 subnet: %+v", u)
+	}
+
+	full := SubnetUsage{Size: 4, Allocated: 4}
+	if full.Usage() != 100 || full.Available() != 0 {
+		t.Errorf("Unexpected usage of an exhausted subnet: %+v", full)
+	}
+}
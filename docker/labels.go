@@ -0,0 +1,123 @@
+package docker
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+//labelKeyRegexp is what dry accepts as a label key, it is a bit more restrictive
+//than Docker, see https://docs.docker.com/config/labels-custom-metadata/#key-format-recommendations
+var labelKeyRegexp = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9._/-]*[a-zA-Z0-9])?$`)
+
+//ParseLabels parses the given space-separated list of key=value pairs as
+//labels. Values with spaces are double-quoted (i.e. key="a value"). A key
+//with no value (i.e. "key" or "key=") is a label with an empty value.
+func ParseLabels(s string) (map[string]string, error) {
+	fields, err := labelFields(s)
+	if err != nil {
+		return nil, err
+	}
+	labels := make(map[string]string)
+	for _, field := range fields {
+		kv := strings.SplitN(field, "=", 2)
+		key := kv[0]
+		if !labelKeyRegexp.MatchString(key) {
+			return nil, fmt.Errorf("invalid label key: %q", key)
+		}
+		if _, ok := labels[key]; ok {
+			return nil, fmt.Errorf("duplicated label key: %q", key)
+		}
+		value := ""
+		if len(kv) == 2 {
+			value = kv[1]
+		}
+		if strings.HasPrefix(value, `"`) {
+			if value, err = strconv.Unquote(value); err != nil {
+				return nil, fmt.Errorf("invalid value of label %q: %s", key, kv[1])
+			}
+		}
+		labels[key] = value
+	}
+	return labels, nil
+}
+
+//labelFields splits the given string on spaces, but for those between double quotes
+func labelFields(s string) ([]string, error) {
+	var fields []string
+	var field strings.Builder
+	quoted, escaped := false, false
+	for _, r := range s {
+		switch {
+		case escaped:
+			escaped = false
+		case quoted && r == '\\':
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+		case !quoted && unicode.IsSpace(r):
+			if field.Len() > 0 {
+				fields = append(fields, field.String())
+				field.Reset()
+			}
+			continue
+		}
+		field.WriteRune(r)
+	}
+	if quoted {
+		return nil, errors.New("unterminated quoted label value")
+	}
+	if field.Len() > 0 {
+		fields = append(fields, field.String())
+	}
+	return fields, nil
+}
+
+//LabelsString returns the given labels as a space-separated list of key=value
+//pairs, sorted by key, that can be parsed back with ParseLabels
+func LabelsString(labels map[string]string) string {
+	var kvs []string
+	for _, key := range sortedKeys(labels) {
+		value := labels[key]
+		if strings.IndexFunc(value, func(r rune) bool {
+			return unicode.IsSpace(r) || r == '"' || r == '\\' || !unicode.IsPrint(r)
+		}) >= 0 {
+			value = strconv.Quote(value)
+		}
+		kvs = append(kvs, key+"="+value)
+	}
+	return strings.Join(kvs, " ")
+}
+
+//LabelChanges describes the changes from the old set of labels to the new
+//one: added labels are prefixed with '+', removed labels with '-' and
+//modified labels with '~'.
+func LabelChanges(old, new map[string]string) []string {
+	var changes []string
+	for _, key := range sortedKeys(old) {
+		if value, ok := new[key]; !ok {
+			changes = append(changes, "-"+key)
+		} else if value != old[key] {
+			changes = append(changes, "~"+key+"="+value)
+		}
+	}
+	for _, key := range sortedKeys(new) {
+		if _, ok := old[key]; !ok {
+			changes = append(changes, "+"+key+"="+new[key])
+		}
+	}
+	return changes
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
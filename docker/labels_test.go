@@ -0,0 +1,71 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    map[string]string
+		wantErr bool
+	}{
+		{"empty", "  ", map[string]string{}, false},
+		{"key value pairs",
+			"zone=eu com.example.tier=front ssd",
+			map[string]string{"zone": "eu", "com.example.tier": "front", "ssd": ""},
+			false},
+		{"value with equal sign", "a=b=c", map[string]string{"a": "b=c"}, false},
+		{"quoted values",
+			`description="web front end" empty="" quote="say \"hi\""`,
+			map[string]string{"description": "web front end", "empty": "", "quote": `say "hi"`},
+			false},
+		{"unterminated quote", `description="web front`, nil, true},
+		{"invalid quoted value", `a="b"c`, nil, true},
+		{"empty key", "=value", nil, true},
+		{"invalid key", "-zone=eu", nil, true},
+		{"duplicated key", "zone=eu zone=us", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLabels(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseLabels() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseLabels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLabelsStringRoundTrip(t *testing.T) {
+	labels := map[string]string{"zone": "eu", "disk": "ssd", "owner": "ops team", "quote": `"\`}
+	s := LabelsString(labels)
+	if s != `disk=ssd owner="ops team" quote="\"\\" zone=eu` {
+		t.Errorf("LabelsString() = %s", s)
+	}
+	parsed, err := ParseLabels(s)
+	if err != nil || !reflect.DeepEqual(parsed, labels) {
+		t.Errorf("ParseLabels(LabelsString()) = %v, %v, want %v", parsed, err, labels)
+	}
+	if changes := LabelChanges(labels, parsed); len(changes) != 0 {
+		t.Errorf("Labels changed when typed back: %v", changes)
+	}
+}
+
+func TestLabelChanges(t *testing.T) {
+	old := map[string]string{"zone": "eu", "disk": "ssd", "gpu": "yes"}
+	new := map[string]string{"zone": "us", "gpu": "yes", "rack": "1"}
+
+	want := []string{"-disk", "~zone=us", "+rack=1"}
+	if got := LabelChanges(old, new); !reflect.DeepEqual(got, want) {
+		t.Errorf("LabelChanges() = %v, want %v", got, want)
+	}
+	if got := LabelChanges(old, old); len(got) != 0 {
+		t.Errorf("LabelChanges() = %v, want no changes", got)
+	}
+}
@@ -1,6 +1,7 @@
 package mock
 
 import (
+	"errors"
 	"strconv"
 
 	"golang.org/x/net/context"
@@ -77,3 +78,11 @@ func (mock ImageAPIClientMock) ImageInspectWithRaw(ctx context.Context, image st
 		ContainerConfig: &container.Config{},
 	}, nil, nil
 }
+
+//ImageRemove mock, images named "in-use" cannot be removed
+func (mock ImageAPIClientMock) ImageRemove(ctx context.Context, image string, options types.ImageRemoveOptions) ([]types.ImageDeleteResponseItem, error) {
+	if image == "in-use" {
+		return nil, errors.New("image is being used by a container")
+	}
+	return []types.ImageDeleteResponseItem{{Deleted: image}}, nil
+}
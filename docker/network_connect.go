@@ -0,0 +1,55 @@
+package docker
+
+import (
+	"context"
+	"net"
+
+	"github.com/docker/docker/api/types/network"
+	pkgError "github.com/pkg/errors"
+)
+
+//ConnectToNetwork connects the given container, by name or id, to the
+//network with the given id, with the given aliases and static IP address,
+//if any
+func (daemon *DockerDaemon) ConnectToNetwork(networkID, container string, aliases []string, ip string) error {
+	endpoint, err := endpointSettings(aliases, ip)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	if err := daemon.client.NetworkConnect(ctx, networkID, container, endpoint); err != nil {
+		return pkgError.Wrapf(err, "Error connecting container %s to network", container)
+	}
+	return daemon.refreshAndWait()
+}
+
+//DisconnectFromNetwork disconnects the given container, by name or id,
+//from the network with the given id
+func (daemon *DockerDaemon) DisconnectFromNetwork(networkID, container string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	if err := daemon.client.NetworkDisconnect(ctx, networkID, container, false); err != nil {
+		return pkgError.Wrapf(err, "Error disconnecting container %s from network", container)
+	}
+	return daemon.refreshAndWait()
+}
+
+//endpointSettings returns the settings of a network endpoint with the given
+//aliases and static IP address, either IPv4 or IPv6
+func endpointSettings(aliases []string, ip string) (*network.EndpointSettings, error) {
+	endpoint := &network.EndpointSettings{Aliases: aliases}
+	if ip == "" {
+		return endpoint, nil
+	}
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return nil, pkgError.Errorf("invalid IP address %q", ip)
+	}
+	if parsed.To4() != nil {
+		endpoint.IPAMConfig = &network.EndpointIPAMConfig{IPv4Address: ip}
+	} else {
+		endpoint.IPAMConfig = &network.EndpointIPAMConfig{IPv6Address: ip}
+	}
+	return endpoint, nil
+}
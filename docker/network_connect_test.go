@@ -0,0 +1,21 @@
+package docker
+
+import "testing"
+
+func TestEndpointSettings(t *testing.T) {
+	endpoint, err := endpointSettings([]string{"db", "postgres"}, "")
+	if err != nil || len(endpoint.Aliases) != 2 || endpoint.IPAMConfig != nil {
+		t.Errorf("Unexpected endpoint without IP: %+v, %v", endpoint, err)
+	}
+	endpoint, err = endpointSettings(nil, "172.20.0.5")
+	if err != nil || endpoint.IPAMConfig == nil || endpoint.IPAMConfig.IPv4Address != "172.20.0.5" {
+		t.Errorf("Unexpected endpoint with an IPv4 address: %+v, %v", endpoint, err)
+	}
+	endpoint, err = endpointSettings(nil, "fd00::5")
+	if err != nil || endpoint.IPAMConfig == nil || endpoint.IPAMConfig.IPv6Address != "fd00::5" {
+		t.Errorf("Unexpected endpoint with an IPv6 address: %+v, %v", endpoint, err)
+	}
+	if _, err := endpointSettings(nil, "172.20.0"); err == nil {
+		t.Error("An invalid IP address was accepted")
+	}
+}
@@ -0,0 +1,29 @@
+package docker
+
+import (
+	"sort"
+
+	"github.com/docker/docker/api/types"
+)
+
+//NetworkContainer is a container attached to a network
+type NetworkContainer struct {
+	ID string
+	types.EndpointResource
+}
+
+//NetworkContainers returns the containers attached to the given network,
+//sorted by name
+func NetworkContainers(n types.NetworkResource) []NetworkContainer {
+	containers := make([]NetworkContainer, 0, len(n.Containers))
+	for id, endpoint := range n.Containers {
+		containers = append(containers, NetworkContainer{ID: id, EndpointResource: endpoint})
+	}
+	sort.Slice(containers, func(i, j int) bool {
+		if containers[i].Name != containers[j].Name {
+			return containers[i].Name < containers[j].Name
+		}
+		return containers[i].ID < containers[j].ID
+	})
+	return containers
+}
@@ -0,0 +1,125 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/network"
+	pkgError "github.com/pkg/errors"
+)
+
+//NetworkOptions are the options to create a network
+type NetworkOptions struct {
+	Name string
+	//Driver is the network driver, Docker uses bridge if not given
+	Driver string
+	//Subnet, in CIDR format, and Gateway are optional
+	Subnet     string
+	Gateway    string
+	Internal   bool
+	Attachable bool
+	Labels     map[string]string
+}
+
+//ParseNetworkOptions parses the given options, given as docker network
+//create does: NAME [-d DRIVER] [--subnet CIDR] [--gateway IP] [--internal]
+//[--attachable] [--label KEY=VALUE]...
+func ParseNetworkOptions(s string) (NetworkOptions, error) {
+	var opts NetworkOptions
+	args, err := splitArgs(s)
+	if err != nil {
+		return opts, err
+	}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			if opts.Name != "" {
+				return opts, fmt.Errorf("only one network name can be given, found %s and %s", opts.Name, arg)
+			}
+			opts.Name = arg
+			continue
+		}
+		flag, value, hasValue := arg, "", false
+		if j := strings.Index(arg, "="); j > 0 && strings.HasPrefix(arg, "--") {
+			flag, value, hasValue = arg[:j], arg[j+1:], true
+		}
+		switch flag {
+		case "--internal":
+			opts.Internal = true
+		case "--attachable":
+			opts.Attachable = true
+		case "-d", "--driver", "--subnet", "--gateway", "--label":
+			if !hasValue {
+				if i+1 == len(args) {
+					return opts, fmt.Errorf("flag %s needs a value", flag)
+				}
+				i++
+				value = args[i]
+			}
+			switch flag {
+			case "-d", "--driver":
+				opts.Driver = value
+			case "--subnet":
+				if _, _, err := net.ParseCIDR(value); err != nil {
+					return opts, fmt.Errorf("invalid subnet %q, expected CIDR format", value)
+				}
+				opts.Subnet = value
+			case "--gateway":
+				if net.ParseIP(value) == nil {
+					return opts, fmt.Errorf("invalid gateway %q", value)
+				}
+				opts.Gateway = value
+			default:
+				kv := strings.SplitN(value, "=", 2)
+				if opts.Labels == nil {
+					opts.Labels = make(map[string]string)
+				}
+				if len(kv) == 2 {
+					opts.Labels[kv[0]] = kv[1]
+				} else {
+					opts.Labels[kv[0]] = ""
+				}
+			}
+		default:
+			return opts, fmt.Errorf("unknown flag: %s", arg)
+		}
+	}
+	if opts.Name == "" {
+		return opts, fmt.Errorf("a network name is needed")
+	}
+	if opts.Gateway != "" && opts.Subnet == "" {
+		return opts, fmt.Errorf("a gateway needs a subnet")
+	}
+	return opts, nil
+}
+
+//CreateNetwork creates a network with the given options, returns the id
+//of the network created
+func (daemon *DockerDaemon) CreateNetwork(opts NetworkOptions) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	created, err := daemon.client.NetworkCreate(ctx, opts.Name, networkCreateOptions(opts))
+	if err != nil {
+		return "", pkgError.Wrapf(err, "Error creating network %s", opts.Name)
+	}
+	return created.ID, nil
+}
+
+func networkCreateOptions(opts NetworkOptions) types.NetworkCreate {
+	create := types.NetworkCreate{
+		CheckDuplicate: true,
+		Driver:         opts.Driver,
+		Internal:       opts.Internal,
+		Attachable:     opts.Attachable,
+		Labels:         opts.Labels,
+	}
+	if opts.Subnet != "" {
+		create.IPAM = &network.IPAM{
+			Config: []network.IPAMConfig{{Subnet: opts.Subnet, Gateway: opts.Gateway}},
+		}
+	}
+	return create
+}
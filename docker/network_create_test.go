@@ -0,0 +1,37 @@
+package docker
+
+import "testing"
+
+func TestParseNetworkOptions(t *testing.T) {
+	opts, err := ParseNetworkOptions(`backend -d overlay --subnet 10.10.0.0/24 --gateway=10.10.0.1 --internal --attachable --label env=prod --label "team=data eng"`)
+	if err != nil {
+		t.Fatalf("Unexpected error parsing network options: %s", err)
+	}
+	if opts.Name != "backend" || opts.Driver != "overlay" || opts.Subnet != "10.10.0.0/24" ||
+		opts.Gateway != "10.10.0.1" || !opts.Internal || !opts.Attachable ||
+		opts.Labels["env"] != "prod" || opts.Labels["team"] != "data eng" {
+		t.Errorf("Unexpected network options: %+v", opts)
+	}
+	create := networkCreateOptions(opts)
+	if create.IPAM == nil || len(create.IPAM.Config) != 1 || create.IPAM.Config[0].Gateway != "10.10.0.1" {
+		t.Errorf("Unexpected IPAM configuration: %+v", create.IPAM)
+	}
+	if create := networkCreateOptions(NetworkOptions{Name: "plain"}); create.IPAM != nil {
+		t.Errorf("IPAM configured without a subnet: %+v", create.IPAM)
+	}
+
+	for _, invalid := range []string{
+		"",
+		"--internal",
+		"one two",
+		"backend --subnet 10.10.0.0",
+		"backend --gateway 10.10.0.1",
+		"backend --subnet 10.10.0.0/24 --gateway nope",
+		"backend -d",
+		"backend --ipv6",
+	} {
+		if _, err := ParseNetworkOptions(invalid); err == nil {
+			t.Errorf("Invalid network options %q were accepted", invalid)
+		}
+	}
+}
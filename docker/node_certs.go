@@ -0,0 +1,41 @@
+package docker
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"time"
+
+	"github.com/docker/docker/api/types/swarm"
+)
+
+//CertExpiryWarning is how long before its expiry a certificate is about to expire
+const CertExpiryWarning = 30 * 24 * time.Hour
+
+//NodeCertExpiry returns when the root CA certificate the given node trusts
+//expires. Docker does not report the expiry of the certificate of each node,
+//which is renewed automatically, but a node cannot be part of the swarm
+//once the root CA certificate it trusts expires.
+func NodeCertExpiry(node swarm.Node) (time.Time, error) {
+	return certExpiry(node.Description.TLSInfo.TrustRoot)
+}
+
+//CertExpiresSoon returns true if a certificate expiring at the given time
+//has expired or is about to
+func CertExpiresSoon(expiry time.Time) bool {
+	return expiry.Sub(Now()) < CertExpiryWarning
+}
+
+//certExpiry returns the expiry of the first certificate of the given PEM
+//encoded bundle
+func certExpiry(bundle string) (time.Time, error) {
+	block, _ := pem.Decode([]byte(bundle))
+	if block == nil {
+		return time.Time{}, errors.New("no certificate found")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return cert.NotAfter, nil
+}
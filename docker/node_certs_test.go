@@ -0,0 +1,62 @@
+package docker
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/swarm"
+)
+
+func TestNodeCertExpiry(t *testing.T) {
+	now := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	defer SetClock(func() time.Time { return now })()
+
+	notAfter := now.Add(10 * 24 * time.Hour).Truncate(time.Second)
+	node := swarm.Node{
+		Description: swarm.NodeDescription{
+			TLSInfo: swarm.TLSInfo{TrustRoot: selfSignedCert(t, notAfter)},
+		},
+	}
+	expiry, err := NodeCertExpiry(node)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !expiry.Equal(notAfter) {
+		t.Errorf("Unexpected expiry, got %s, expected %s", expiry, notAfter)
+	}
+	if !CertExpiresSoon(expiry) {
+		t.Error("A certificate expiring in 10 days was expected to expire soon")
+	}
+	if CertExpiresSoon(now.Add(90 * 24 * time.Hour)) {
+		t.Error("A certificate expiring in 90 days was not expected to expire soon")
+	}
+	if _, err := NodeCertExpiry(swarm.Node{}); err == nil {
+		t.Error("Expected an error for a node without trust root")
+	}
+}
+
+func selfSignedCert(t *testing.T, notAfter time.Time) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "swarm-ca"},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+		IsCA:         true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
@@ -0,0 +1,73 @@
+package docker
+
+import (
+	"github.com/docker/docker/api/types/swarm"
+)
+
+//NodeUsage is how much of the resources of a node is reserved by the tasks
+//scheduled on it
+type NodeUsage struct {
+	//ReservedNanoCPUs is the CPU, in units of 1e-9 CPUs, reserved by tasks
+	ReservedNanoCPUs int64
+	//ReservedMemory is the memory, in bytes, reserved by tasks
+	ReservedMemory int64
+	//RunningTasks is the number of tasks running on the node
+	RunningTasks int
+}
+
+//CPUUsage returns the percentage of the given CPUs, in units of 1e-9 CPUs,
+//reserved, -1 if the node does not report its CPUs
+func (u NodeUsage) CPUUsage(nanoCPUs int64) float64 {
+	return usagePercentage(u.ReservedNanoCPUs, nanoCPUs)
+}
+
+//MemoryUsage returns the percentage of the given memory reserved, -1 if the
+//node does not report its memory
+func (u NodeUsage) MemoryUsage(memory int64) float64 {
+	return usagePercentage(u.ReservedMemory, memory)
+}
+
+//NodesUsage returns, by node id, the resources of each node reserved by
+//the given tasks. As the scheduler does, only the tasks meant to be running
+//that have not finished yet reserve resources.
+func NodesUsage(tasks []swarm.Task) map[string]NodeUsage {
+	usage := make(map[string]NodeUsage)
+	for _, task := range tasks {
+		if task.NodeID == "" {
+			continue
+		}
+		u := usage[task.NodeID]
+		if task.Status.State == swarm.TaskStateRunning {
+			u.RunningTasks++
+		}
+		if reserves(task) {
+			if r := task.Spec.Resources; r != nil && r.Reservations != nil {
+				u.ReservedNanoCPUs += r.Reservations.NanoCPUs
+				u.ReservedMemory += r.Reservations.MemoryBytes
+			}
+		}
+		usage[task.NodeID] = u
+	}
+	return usage
+}
+
+//reserves returns true if the given task holds the resources it reserves
+//on its node
+func reserves(task swarm.Task) bool {
+	if task.DesiredState != swarm.TaskStateRunning {
+		return false
+	}
+	switch task.Status.State {
+	case swarm.TaskStateComplete, swarm.TaskStateShutdown, swarm.TaskStateFailed,
+		swarm.TaskStateRejected, swarm.TaskStateRemove, swarm.TaskStateOrphaned:
+		return false
+	}
+	return true
+}
+
+func usagePercentage(reserved, total int64) float64 {
+	if total <= 0 {
+		return -1
+	}
+	return float64(reserved) * 100 / float64(total)
+}
@@ -0,0 +1,45 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/swarm"
+)
+
+func TestNodesUsage(t *testing.T) {
+	reservations := &swarm.ResourceRequirements{
+		Reservations: &swarm.Resources{NanoCPUs: 5e8, MemoryBytes: 256 * 1024 * 1024},
+	}
+	task := func(node string, desired, current swarm.TaskState) swarm.Task {
+		return swarm.Task{
+			NodeID:       node,
+			DesiredState: desired,
+			Spec:         swarm.TaskSpec{Resources: reservations},
+			Status:       swarm.TaskStatus{State: current},
+		}
+	}
+	tasks := []swarm.Task{
+		task("node1", swarm.TaskStateRunning, swarm.TaskStateRunning),
+		task("node1", swarm.TaskStateRunning, swarm.TaskStatePreparing),
+		task("node1", swarm.TaskStateShutdown, swarm.TaskStateFailed),
+		task("node2", swarm.TaskStateRunning, swarm.TaskStateRunning),
+		{NodeID: "node2", DesiredState: swarm.TaskStateRunning, Status: swarm.TaskStatus{State: swarm.TaskStateRunning}},
+		task("", swarm.TaskStateRunning, swarm.TaskStatePending),
+	}
+	usage := NodesUsage(tasks)
+	if len(usage) != 2 {
+		t.Fatalf("Unexpected nodes: %+v", usage)
+	}
+	if u := usage["node1"]; u.ReservedNanoCPUs != 1e9 || u.ReservedMemory != 512*1024*1024 || u.RunningTasks != 1 {
+		t.Errorf("Unexpected usage of node1: %+v", u)
+	}
+	if u := usage["node2"]; u.ReservedNanoCPUs != 5e8 || u.RunningTasks != 2 {
+		t.Errorf("Unexpected usage of node2: %+v", u)
+	}
+	if cpu := usage["node1"].CPUUsage(4e9); cpu != 25 {
+		t.Errorf("Unexpected CPU usage of node1: %f", cpu)
+	}
+	if mem := usage["node1"].MemoryUsage(0); mem != -1 {
+		t.Errorf("Unexpected memory usage of a node without memory: %f", mem)
+	}
+}
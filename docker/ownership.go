@@ -0,0 +1,92 @@
+package docker
+
+import (
+	"sort"
+
+	"github.com/docker/docker/api/types"
+)
+
+//DefaultOwnerLabel is the label whose value is taken as the owner of
+//containers, images and volumes by default
+const DefaultOwnerLabel = "owner"
+
+//OwnerUsage is the resource usage of the containers, images and volumes of
+//an owner. Images share layers, so the disk used by images only counts the
+//layers not shared with other images.
+type OwnerUsage struct {
+	//Owner is the value of the owner label, empty for objects without it
+	Owner      string
+	Containers int
+	Running    int
+	//CPUSeconds is the CPU time used by the running containers since they started
+	CPUSeconds float64
+	//Memory is the memory used by the running containers, in bytes
+	Memory        float64
+	ContainerDisk int64
+	Images        int
+	ImageDisk     int64
+	Volumes       int
+	VolumeDisk    int64
+}
+
+//Disk returns the disk used by containers, images and volumes
+func (u OwnerUsage) Disk() int64 {
+	return u.ContainerDisk + u.ImageDisk + u.VolumeDisk
+}
+
+//OwnershipReport aggregates the given disk usage and stats by the value of the
+//given owner label. Objects without the label are aggregated under an empty
+//owner, which is the last one of the report, the rest are sorted by owner.
+func OwnershipReport(label string, du types.DiskUsage, stats []StatsRecord) []OwnerUsage {
+	usage := make(map[string]*OwnerUsage)
+	of := func(labels map[string]string) *OwnerUsage {
+		owner := labels[label]
+		u, ok := usage[owner]
+		if !ok {
+			u = &OwnerUsage{Owner: owner}
+			usage[owner] = u
+		}
+		return u
+	}
+	byID := make(map[string]StatsRecord)
+	for _, s := range stats {
+		byID[s.ID] = s
+	}
+	for _, c := range du.Containers {
+		u := of(c.Labels)
+		u.Containers++
+		u.ContainerDisk += c.SizeRw
+		if s, ok := byID[c.ID]; ok {
+			u.Running++
+			u.CPUSeconds += s.CPUSeconds
+			u.Memory += s.Memory
+		}
+	}
+	for _, i := range du.Images {
+		u := of(i.Labels)
+		u.Images++
+		if i.SharedSize > 0 {
+			u.ImageDisk += i.Size - i.SharedSize
+		} else {
+			u.ImageDisk += i.Size
+		}
+	}
+	for _, v := range du.Volumes {
+		u := of(v.Labels)
+		u.Volumes++
+		if v.UsageData != nil && v.UsageData.Size > 0 {
+			u.VolumeDisk += v.UsageData.Size
+		}
+	}
+	report := make([]OwnerUsage, 0, len(usage))
+	for _, u := range usage {
+		report = append(report, *u)
+	}
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].Owner == "" || report[j].Owner == "" {
+			return report[j].Owner == ""
+		}
+		return report[i].Owner < report[j].Owner
+	})
+	return report
+}
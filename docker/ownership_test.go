@@ -0,0 +1,46 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+)
+
+func TestOwnershipReport(t *testing.T) {
+	du := types.DiskUsage{
+		Containers: []*types.Container{
+			{ID: "web", Labels: map[string]string{"team": "frontend"}, SizeRw: 100},
+			{ID: "web-old", Labels: map[string]string{"team": "frontend"}, SizeRw: 50},
+			{ID: "db", Labels: map[string]string{"team": "backend"}, SizeRw: 1000},
+			{ID: "tmp", SizeRw: 10},
+		},
+		Images: []*types.ImageSummary{
+			{ID: "nginx", Labels: map[string]string{"team": "frontend"}, Size: 300, SharedSize: 100},
+			{ID: "postgres", Labels: map[string]string{"team": "backend"}, Size: 500, SharedSize: -1},
+		},
+		Volumes: []*types.Volume{
+			{Name: "data", Labels: map[string]string{"team": "backend"}, UsageData: &types.VolumeUsageData{Size: 2000}},
+			{Name: "cache", Labels: map[string]string{"team": "frontend"}, UsageData: &types.VolumeUsageData{Size: -1}},
+		},
+	}
+	stats := []StatsRecord{
+		{ID: "web", CPUSeconds: 30, Memory: 64},
+		{ID: "db", CPUSeconds: 120, Memory: 512},
+	}
+
+	want := []OwnerUsage{
+		{Owner: "backend", Containers: 1, Running: 1, CPUSeconds: 120, Memory: 512,
+			ContainerDisk: 1000, Images: 1, ImageDisk: 500, Volumes: 1, VolumeDisk: 2000},
+		{Owner: "frontend", Containers: 2, Running: 1, CPUSeconds: 30, Memory: 64,
+			ContainerDisk: 150, Images: 1, ImageDisk: 200, Volumes: 1},
+		{Owner: "", Containers: 1, ContainerDisk: 10},
+	}
+	got := OwnershipReport("team", du, stats)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Unexpected ownership report, got %+v, want %+v", got, want)
+	}
+	if disk := got[0].Disk(); disk != 3500 {
+		t.Errorf("Unexpected disk usage of backend: %d", disk)
+	}
+}
@@ -0,0 +1,37 @@
+package docker
+
+import (
+	"context"
+	"time"
+)
+
+//pingTimeout is how long a ping waits for the Docker daemon
+const pingTimeout = 5 * time.Second
+
+//DaemonStatus is the result of pinging the Docker daemon
+type DaemonStatus struct {
+	//APIVersion is the version of the API used to talk to the daemon
+	APIVersion string
+	//Latency is the round-trip time of the ping
+	Latency time.Duration
+	//Err is the reason the daemon did not answer, if so
+	Err error
+}
+
+//Reachable returns true if the daemon answered the ping
+func (s DaemonStatus) Reachable() bool {
+	return s.Err == nil
+}
+
+//Ping pings the Docker daemon
+func (daemon *DockerDaemon) Ping() DaemonStatus {
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
+	start := time.Now()
+	_, err := daemon.client.Ping(ctx)
+	return DaemonStatus{
+		APIVersion: daemon.client.ClientVersion(),
+		Latency:    time.Since(start),
+		Err:        err,
+	}
+}
@@ -0,0 +1,119 @@
+package docker
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/docker/docker/api/types/swarm"
+)
+
+const (
+	placementSeparator = ";"
+	spreadPrefix       = "spread="
+	nodeLabelsPrefix   = "node.labels."
+)
+
+//constraintRegexp matches placement constraints, e.g. node.role==manager
+var constraintRegexp = regexp.MustCompile(`^\s*([a-zA-Z0-9._/-]+)\s*(==|!=)\s*(\S+)\s*$`)
+
+//constraintKeys are the keys that can be used in placement constraints, besides
+//node and engine labels.
+//See https://docs.docker.com/engine/reference/commandline/service_create/#specify-service-constraints---constraint
+var constraintKeys = map[string]struct{}{
+	"node.id":            {},
+	"node.hostname":      {},
+	"node.role":          {},
+	"node.platform.os":   {},
+	"node.platform.arch": {},
+}
+
+//ParsePlacement parses the given list of placement constraints and preferences,
+//separated by ';'. Preferences are given as spread=<label descriptor>, i.e.
+//"node.role==manager; spread=node.labels.zone"
+func ParsePlacement(s string) ([]string, []swarm.PlacementPreference, error) {
+	var constraints []string
+	var preferences []swarm.PlacementPreference
+	for _, entry := range strings.Split(s, placementSeparator) {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if strings.HasPrefix(entry, spreadPrefix) {
+			descriptor := strings.TrimSpace(strings.TrimPrefix(entry, spreadPrefix))
+			if !isLabelKey(descriptor) {
+				return nil, nil, fmt.Errorf("invalid spread descriptor: %q", descriptor)
+			}
+			preferences = append(preferences, swarm.PlacementPreference{
+				Spread: &swarm.SpreadOver{SpreadDescriptor: descriptor}})
+			continue
+		}
+		m := constraintRegexp.FindStringSubmatch(entry)
+		if m == nil {
+			return nil, nil, fmt.Errorf("invalid constraint: %q", entry)
+		}
+		key := m[1]
+		if _, ok := constraintKeys[key]; !ok && !isLabelKey(key) {
+			return nil, nil, fmt.Errorf("invalid constraint key: %q", key)
+		}
+		constraints = append(constraints, key+m[2]+m[3])
+	}
+	return constraints, preferences, nil
+}
+
+//PlacementString returns the constraints and preferences of the given placement
+//in the format understood by ParsePlacement
+func PlacementString(placement *swarm.Placement) string {
+	if placement == nil {
+		return ""
+	}
+	var entries []string
+	entries = append(entries, placement.Constraints...)
+	for _, p := range placement.Preferences {
+		if p.Spread != nil {
+			entries = append(entries, spreadPrefix+p.Spread.SpreadDescriptor)
+		}
+	}
+	return strings.Join(entries, placementSeparator+" ")
+}
+
+//ValidatePlacement checks that the node labels used by the given constraints
+//and preferences are defined on at least one of the given nodes.
+func ValidatePlacement(constraints []string, preferences []swarm.PlacementPreference, nodes []swarm.Node) error {
+	labels := make(map[string]struct{})
+	for _, node := range nodes {
+		for label := range node.Spec.Labels {
+			labels[label] = struct{}{}
+		}
+	}
+	var keys []string
+	for _, c := range constraints {
+		if m := constraintRegexp.FindStringSubmatch(c); m != nil {
+			keys = append(keys, m[1])
+		}
+	}
+	for _, p := range preferences {
+		if p.Spread != nil {
+			keys = append(keys, p.Spread.SpreadDescriptor)
+		}
+	}
+	for _, key := range keys {
+		if !strings.HasPrefix(key, nodeLabelsPrefix) {
+			continue
+		}
+		label := strings.TrimPrefix(key, nodeLabelsPrefix)
+		if _, ok := labels[label]; !ok {
+			return fmt.Errorf("no node has label %q", label)
+		}
+	}
+	return nil
+}
+
+func isLabelKey(key string) bool {
+	for _, prefix := range []string{nodeLabelsPrefix, "engine.labels."} {
+		if strings.HasPrefix(key, prefix) && len(key) > len(prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types/swarm"
+)
+
+func TestParsePlacement(t *testing.T) {
+	constraints, preferences, err := ParsePlacement(
+		"node.role == manager; node.labels.zone!=eu ;spread=node.labels.rack;")
+	if err != nil {
+		t.Fatalf("Unexpected error parsing placement: %s", err)
+	}
+	wantConstraints := []string{"node.role==manager", "node.labels.zone!=eu"}
+	if !reflect.DeepEqual(constraints, wantConstraints) {
+		t.Errorf("ParsePlacement() constraints = %v, want %v", constraints, wantConstraints)
+	}
+	if len(preferences) != 1 || preferences[0].Spread.SpreadDescriptor != "node.labels.rack" {
+		t.Errorf("ParsePlacement() preferences = %v", preferences)
+	}
+
+	placement := &swarm.Placement{Constraints: constraints, Preferences: preferences}
+	if s := PlacementString(placement); s != "node.role==manager; node.labels.zone!=eu; spread=node.labels.rack" {
+		t.Errorf("PlacementString() = %s", s)
+	}
+
+	for _, invalid := range []string{"node.role=manager", "node.colour==blue", "spread=node.role", "spread=node.labels."} {
+		if _, _, err := ParsePlacement(invalid); err == nil {
+			t.Errorf("ParsePlacement(%q) expected to fail", invalid)
+		}
+	}
+}
+
+func TestValidatePlacement(t *testing.T) {
+	nodes := []swarm.Node{
+		{Spec: swarm.NodeSpec{Annotations: swarm.Annotations{Labels: map[string]string{"zone": "eu"}}}},
+	}
+	if err := ValidatePlacement([]string{"node.labels.zone==eu", "node.role==worker"}, nil, nodes); err != nil {
+		t.Errorf("Unexpected error validating placement: %s", err)
+	}
+	if err := ValidatePlacement([]string{"node.labels.rack==1"}, nil, nodes); err == nil {
+		t.Error("Expected an error for a constraint on a label no node has")
+	}
+	spread := []swarm.PlacementPreference{{Spread: &swarm.SpreadOver{SpreadDescriptor: "node.labels.rack"}}}
+	if err := ValidatePlacement(nil, spread, nodes); err == nil {
+		t.Error("Expected an error for a preference on a label no node has")
+	}
+}
@@ -0,0 +1,49 @@
+package docker
+
+import (
+	"context"
+
+	dockerTypes "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+)
+
+//Plugins returns the plugins installed on the Docker host
+func (daemon *DockerDaemon) Plugins() ([]*dockerTypes.Plugin, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	plugins, err := daemon.client.PluginList(ctx, filters.NewArgs())
+	return plugins, err
+}
+
+//PluginInspect returns the details of the plugin with the given name or ID
+func (daemon *DockerDaemon) PluginInspect(name string) (*dockerTypes.Plugin, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	plugin, _, err := daemon.client.PluginInspectWithRaw(ctx, name)
+	return plugin, err
+}
+
+//PluginEnable enables the plugin with the given name or ID
+func (daemon *DockerDaemon) PluginEnable(name string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	return daemon.client.PluginEnable(ctx, name, dockerTypes.PluginEnableOptions{})
+}
+
+//PluginDisable disables the plugin with the given name or ID, if force is
+//true the plugin is disabled even if it is in use
+func (daemon *DockerDaemon) PluginDisable(name string, force bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	return daemon.client.PluginDisable(ctx, name, dockerTypes.PluginDisableOptions{Force: force})
+}
+
+//PluginTypes returns the types of the given plugin (i.e. volumedriver,
+//networkdriver, logdriver)
+func PluginTypes(plugin *dockerTypes.Plugin) []string {
+	var types []string
+	for _, t := range plugin.Config.Interface.Types {
+		types = append(types, t.Capability)
+	}
+	return types
+}
@@ -0,0 +1,57 @@
+package docker
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+)
+
+//podmanEngine is the name of the component Podman reports on its version
+const podmanEngine = "Podman Engine"
+
+//PodmanVersion returns the version of Podman if the given version was
+//reported by the Docker-compatible API of Podman
+func PodmanVersion(v *types.Version) (string, bool) {
+	if v == nil {
+		return "", false
+	}
+	for _, c := range v.Components {
+		if c.Name == podmanEngine {
+			return c.Version, true
+		}
+	}
+	if strings.Contains(v.Platform.Name, "Podman") {
+		return v.Version, true
+	}
+	return "", false
+}
+
+//IsPodman returns true if the given daemon is Podman, through its
+//Docker-compatible API. Swarm and plugins are not available on Podman.
+func IsPodman(daemon ContainerDaemon) bool {
+	v, err := daemon.Version()
+	if err != nil {
+		return false
+	}
+	_, ok := PodmanVersion(v)
+	return ok
+}
+
+//PodmanSocket returns the address of the socket of the Docker-compatible
+//API of Podman, the one of the user if it exists, the system one otherwise.
+//Returns false if there is none.
+func PodmanSocket() (string, bool) {
+	var paths []string
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		paths = append(paths, filepath.Join(dir, "podman", "podman.sock"))
+	}
+	paths = append(paths, "/run/podman/podman.sock")
+	for _, path := range paths {
+		if _, err := os.Stat(path); err == nil {
+			return "unix://" + path, true
+		}
+	}
+	return "", false
+}
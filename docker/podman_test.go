@@ -0,0 +1,55 @@
+package docker
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+)
+
+func TestPodmanVersion(t *testing.T) {
+	podman := &types.Version{
+		Version: "3.0.1",
+		Components: []types.ComponentVersion{
+			{Name: "Podman Engine", Version: "3.0.1"},
+		},
+	}
+	if v, ok := PodmanVersion(podman); !ok || v != "3.0.1" {
+		t.Errorf("Podman not detected: %s, %v", v, ok)
+	}
+	docker := &types.Version{
+		Version: "19.03.5",
+		Components: []types.ComponentVersion{
+			{Name: "Engine", Version: "19.03.5"},
+		},
+	}
+	if _, ok := PodmanVersion(docker); ok {
+		t.Error("Docker detected as Podman")
+	}
+	if _, ok := PodmanVersion(nil); ok {
+		t.Error("Unknown version detected as Podman")
+	}
+}
+
+func TestPodmanSocket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dry-podman")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer os.Setenv("XDG_RUNTIME_DIR", os.Getenv("XDG_RUNTIME_DIR"))
+	os.Setenv("XDG_RUNTIME_DIR", dir)
+
+	socket := filepath.Join(dir, "podman", "podman.sock")
+	if err := os.MkdirAll(filepath.Dir(socket), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(socket, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if host, ok := PodmanSocket(); !ok || host != "unix://"+socket {
+		t.Errorf("Podman socket of the user not found: %s, %v", host, ok)
+	}
+}
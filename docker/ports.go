@@ -0,0 +1,201 @@
+package docker
+
+import (
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/go-connections/nat"
+)
+
+//PortMapping is a port of a container and the host address it is
+//published on, if any
+type PortMapping struct {
+	//Port is the port of the container, as port/protocol
+	Port     nat.Port
+	HostIP   string
+	HostPort string
+}
+
+//Published returns true if the port is published on the host
+func (p PortMapping) Published() bool {
+	return p.HostPort != ""
+}
+
+//HostAddress returns the address the port is published on, as host:port.
+//Ports published on every interface are reached on the host of the given
+//Docker endpoint, localhost if the endpoint is local.
+func (p PortMapping) HostAddress(dockerHost string) string {
+	if !p.Published() {
+		return ""
+	}
+	host := p.HostIP
+	if unspecifiedIP(host) {
+		host = endpointHost(dockerHost)
+	}
+	return net.JoinHostPort(host, p.HostPort)
+}
+
+//ContainerPortMappings returns the ports of the given container, sorted by
+//port: the ones published while it runs or, if it is not running, the ones
+//it publishes once started. Exposed ports that are not published are
+//returned too.
+func ContainerPortMappings(c types.ContainerJSON) []PortMapping {
+	var ports nat.PortMap
+	if c.NetworkSettings != nil && len(c.NetworkSettings.Ports) > 0 {
+		ports = c.NetworkSettings.Ports
+	} else if c.ContainerJSONBase != nil && c.HostConfig != nil {
+		ports = c.HostConfig.PortBindings
+	}
+	seen := make(map[nat.Port]bool)
+	var mappings []PortMapping
+	for port, bindings := range ports {
+		seen[port] = true
+		if len(bindings) == 0 {
+			mappings = append(mappings, PortMapping{Port: port})
+		}
+		for _, binding := range bindings {
+			mappings = append(mappings, PortMapping{
+				Port:     port,
+				HostIP:   binding.HostIP,
+				HostPort: binding.HostPort,
+			})
+		}
+	}
+	if c.Config != nil {
+		for port := range c.Config.ExposedPorts {
+			if !seen[port] {
+				mappings = append(mappings, PortMapping{Port: port})
+			}
+		}
+	}
+	sort.Slice(mappings, func(i, j int) bool {
+		a, b := mappings[i], mappings[j]
+		if a.Port.Int() != b.Port.Int() {
+			return a.Port.Int() < b.Port.Int()
+		}
+		if a.Port.Proto() != b.Port.Proto() {
+			return a.Port.Proto() < b.Port.Proto()
+		}
+		if a.HostPort != b.HostPort {
+			return a.HostPort < b.HostPort
+		}
+		return a.HostIP < b.HostIP
+	})
+	return mappings
+}
+
+//PortConflict is a host port published by a container that another
+//container publishes too
+type PortConflict struct {
+	Mapping PortMapping
+	//Container is the name of the other container publishing the port
+	Container string
+	//Running is false if the other container publishes the port once started
+	Running bool
+}
+
+//PortConflicts returns the host ports of the given mappings of a container
+//that are published by the other given containers, on the same protocol
+//and an overlapping address. Stopped containers are checked against the
+//ports they publish once started, if known.
+func PortConflicts(id string, mappings []PortMapping, others []*Container) []PortConflict {
+	type key struct {
+		hostPort  string
+		container string
+	}
+	seen := make(map[key]bool)
+	var conflicts []PortConflict
+	for _, other := range others {
+		if other == nil || other.ID == id {
+			continue
+		}
+		running := IsContainerRunning(other)
+		name := other.ID
+		if len(other.Names) > 0 {
+			name = strings.TrimPrefix(other.Names[0], "/")
+		}
+		for _, theirs := range publishedPorts(other, running) {
+			for _, mine := range mappings {
+				if !mine.Published() || mine.HostPort != theirs.HostPort ||
+					mine.Port.Proto() != theirs.Port.Proto() ||
+					!overlappingIPs(mine.HostIP, theirs.HostIP) {
+					continue
+				}
+				k := key{mine.Port.Proto() + "/" + mine.HostPort, name}
+				if seen[k] {
+					continue
+				}
+				seen[k] = true
+				conflicts = append(conflicts, PortConflict{Mapping: mine, Container: name, Running: running})
+			}
+		}
+	}
+	return conflicts
+}
+
+//publishedPorts returns the host ports published by the given container,
+//the ones from its configuration if it is not running
+func publishedPorts(c *Container, running bool) []PortMapping {
+	var mappings []PortMapping
+	if running {
+		for _, p := range c.Ports {
+			if p.PublicPort == 0 {
+				continue
+			}
+			port, err := nat.NewPort(p.Type, strconv.Itoa(int(p.PrivatePort)))
+			if err != nil {
+				continue
+			}
+			mappings = append(mappings, PortMapping{
+				Port:     port,
+				HostIP:   p.IP,
+				HostPort: strconv.Itoa(int(p.PublicPort)),
+			})
+		}
+		return mappings
+	}
+	if c.ContainerJSONBase == nil || c.ContainerJSON.HostConfig == nil {
+		return nil
+	}
+	for port, bindings := range c.ContainerJSON.HostConfig.PortBindings {
+		for _, binding := range bindings {
+			if binding.HostPort == "" {
+				continue
+			}
+			mappings = append(mappings, PortMapping{
+				Port:     port,
+				HostIP:   binding.HostIP,
+				HostPort: binding.HostPort,
+			})
+		}
+	}
+	return mappings
+}
+
+//overlappingIPs returns true if ports published on the given host
+//addresses would clash
+func overlappingIPs(a, b string) bool {
+	return a == b || unspecifiedIP(a) || unspecifiedIP(b)
+}
+
+func unspecifiedIP(ip string) bool {
+	return ip == "" || ip == "0.0.0.0" || ip == "::"
+}
+
+//endpointHost returns the host name of the given Docker endpoint,
+//localhost for local endpoints (unix sockets and named pipes)
+func endpointHost(dockerHost string) string {
+	u, err := url.Parse(dockerHost)
+	if err != nil || u.Hostname() == "" {
+		return "localhost"
+	}
+	switch u.Scheme {
+	case "tcp", "ssh", "http", "https":
+		return u.Hostname()
+	}
+	return "localhost"
+}
@@ -0,0 +1,103 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+)
+
+func TestContainerPortMappings(t *testing.T) {
+	c := types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{
+			HostConfig: &container.HostConfig{PortBindings: nat.PortMap{
+				"80/tcp": []nat.PortBinding{{HostPort: "8080"}},
+			}},
+		},
+		Config: &container.Config{ExposedPorts: nat.PortSet{"80/tcp": {}, "9090/tcp": {}}},
+		NetworkSettings: &types.NetworkSettings{NetworkSettingsBase: types.NetworkSettingsBase{Ports: nat.PortMap{
+			"443/tcp": []nat.PortBinding{{HostIP: "127.0.0.1", HostPort: "8443"}},
+			"53/udp":  nil,
+		}}},
+	}
+	mappings := ContainerPortMappings(c)
+	want := []PortMapping{
+		{Port: "53/udp"},
+		{Port: "80/tcp"},
+		{Port: "443/tcp", HostIP: "127.0.0.1", HostPort: "8443"},
+		{Port: "9090/tcp"},
+	}
+	if len(mappings) != len(want) {
+		t.Fatalf("Unexpected port mappings of a running container: %+v", mappings)
+	}
+	for i := range want {
+		if mappings[i] != want[i] {
+			t.Errorf("Unexpected port mapping %d: got %+v, want %+v", i, mappings[i], want[i])
+		}
+	}
+
+	//Not running, the ports published once started
+	c.NetworkSettings = nil
+	mappings = ContainerPortMappings(c)
+	if len(mappings) != 2 || mappings[0].HostPort != "8080" || mappings[1].Port != "9090/tcp" {
+		t.Errorf("Unexpected port mappings of a stopped container: %+v", mappings)
+	}
+}
+
+func TestPortMappingHostAddress(t *testing.T) {
+	tests := []struct {
+		mapping    PortMapping
+		dockerHost string
+		want       string
+	}{
+		{PortMapping{Port: "80/tcp", HostIP: "0.0.0.0", HostPort: "8080"}, "unix:///var/run/docker.sock", "localhost:8080"},
+		{PortMapping{Port: "80/tcp", HostIP: "0.0.0.0", HostPort: "8080"}, "tcp://prod:2376", "prod:8080"},
+		{PortMapping{Port: "80/tcp", HostIP: "::", HostPort: "8080"}, "ssh://deploy@staging", "staging:8080"},
+		{PortMapping{Port: "80/tcp", HostIP: "127.0.0.1", HostPort: "8080"}, "tcp://prod:2376", "127.0.0.1:8080"},
+		{PortMapping{Port: "80/tcp", HostIP: "fe80::1", HostPort: "8080"}, "", "[fe80::1]:8080"},
+		{PortMapping{Port: "80/tcp"}, "", ""},
+	}
+	for _, tt := range tests {
+		if got := tt.mapping.HostAddress(tt.dockerHost); got != tt.want {
+			t.Errorf("HostAddress(%q) of %+v: got %q, want %q", tt.dockerHost, tt.mapping, got, tt.want)
+		}
+	}
+}
+
+func TestPortConflicts(t *testing.T) {
+	mappings := []PortMapping{
+		{Port: "80/tcp", HostIP: "0.0.0.0", HostPort: "8080"},
+		{Port: "80/tcp", HostIP: "::", HostPort: "8080"},
+		{Port: "53/udp", HostIP: "127.0.0.1", HostPort: "5353"},
+		{Port: "9090/tcp"},
+	}
+	others := []*Container{
+		{Container: types.Container{ID: "self", Names: []string{"/self"}, Status: "Up 1 minute",
+			Ports: []types.Port{{IP: "0.0.0.0", PrivatePort: 80, PublicPort: 8080, Type: "tcp"}}}},
+		{Container: types.Container{ID: "web", Names: []string{"/web"}, Status: "Up 2 minutes",
+			Ports: []types.Port{
+				{IP: "0.0.0.0", PrivatePort: 8000, PublicPort: 8080, Type: "tcp"},
+				{IP: "::", PrivatePort: 8000, PublicPort: 8080, Type: "tcp"},
+				{PrivatePort: 9090, Type: "tcp"},
+			}}},
+		{Container: types.Container{ID: "dns", Names: []string{"/dns"}, Status: "Up 2 minutes",
+			Ports: []types.Port{{IP: "127.0.0.2", PrivatePort: 53, PublicPort: 5353, Type: "udp"}}}},
+		{Container: types.Container{ID: "old", Names: []string{"/old"}, Status: "Exited (0) 1 hour ago"},
+			ContainerJSON: types.ContainerJSON{ContainerJSONBase: &types.ContainerJSONBase{
+				HostConfig: &container.HostConfig{PortBindings: nat.PortMap{
+					"53/udp": []nat.PortBinding{{HostPort: "5353"}},
+				}},
+			}}},
+	}
+	conflicts := PortConflicts("self", mappings, others)
+	if len(conflicts) != 2 {
+		t.Fatalf("Unexpected port conflicts: %+v", conflicts)
+	}
+	if conflicts[0].Container != "web" || !conflicts[0].Running || conflicts[0].Mapping.HostPort != "8080" {
+		t.Errorf("Unexpected conflict with a running container: %+v", conflicts[0])
+	}
+	if conflicts[1].Container != "old" || conflicts[1].Running || conflicts[1].Mapping.HostPort != "5353" {
+		t.Errorf("Unexpected conflict with a stopped container: %+v", conflicts[1])
+	}
+}
@@ -0,0 +1,26 @@
+package docker
+
+import (
+	"github.com/docker/docker/api/types/filters"
+)
+
+//ProtectionLabel is the label that protects containers, images, networks
+//and volumes from being removed or killed by dry, i.e. dry.protect=true
+const ProtectionLabel = "dry.protect"
+
+//IsProtected returns true if the given labels mark a Docker object as protected
+func IsProtected(labels map[string]string) bool {
+	return labels[ProtectionLabel] == "true"
+}
+
+//IsContainerProtected returns true if the given container is protected
+func IsContainerProtected(c *Container) bool {
+	return c != nil && IsProtected(c.Labels)
+}
+
+//withoutProtected adds to the given prune filters a filter to leave
+//protected objects out
+func withoutProtected(args filters.Args) filters.Args {
+	args.Add("label!", ProtectionLabel+"=true")
+	return args
+}
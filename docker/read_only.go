@@ -0,0 +1,225 @@
+package docker
+
+import (
+	"context"
+	"errors"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/swarm"
+)
+
+//ErrReadOnly is returned by a read-only daemon when asked to change anything
+var ErrReadOnly = errors.New("dry is in read-only mode")
+
+//readOnlyDaemon is a ContainerDaemon that refuses to change the state of the
+//Docker host, any operation doing so returns ErrReadOnly
+type readOnlyDaemon struct {
+	ContainerDaemon
+}
+
+//ReadOnly returns a ContainerDaemon that only allows to observe the given one,
+//operations changing the state of the Docker host, like killing or removing
+//containers, return ErrReadOnly
+func ReadOnly(daemon ContainerDaemon) ContainerDaemon {
+	return &readOnlyDaemon{daemon}
+}
+
+//IsReadOnly returns true if the given daemon is read-only
+func IsReadOnly(daemon ContainerDaemon) bool {
+	_, ok := daemon.(*readOnlyDaemon)
+	return ok
+}
+
+func (d *readOnlyDaemon) Cleanup(opts CleanupOptions) (CleanupReport, error) {
+	return CleanupReport{}, ErrReadOnly
+}
+
+func (d *readOnlyDaemon) Kill(id string) error {
+	return ErrReadOnly
+}
+
+func (d *readOnlyDaemon) Prune() (*PruneReport, error) {
+	return nil, ErrReadOnly
+}
+
+func (d *readOnlyDaemon) PruneBuildCache(keepStorage int64) (*types.BuildCachePruneReport, error) {
+	return nil, ErrReadOnly
+}
+
+func (d *readOnlyDaemon) CloneContainer(id, image string, opts RunOptions) (string, error) {
+	return "", ErrReadOnly
+}
+
+func (d *readOnlyDaemon) RecreateContainer(id string, pull bool) (string, error) {
+	return "", ErrReadOnly
+}
+
+func (d *readOnlyDaemon) RemoveAllStoppedContainers() (int, error) {
+	return 0, ErrReadOnly
+}
+
+func (d *readOnlyDaemon) RestartContainer(id string) error {
+	return ErrReadOnly
+}
+
+func (d *readOnlyDaemon) RunHealthcheck(id string) (HealthcheckResult, error) {
+	return HealthcheckResult{}, ErrReadOnly
+}
+
+func (d *readOnlyDaemon) Rm(id string) error {
+	return ErrReadOnly
+}
+
+func (d *readOnlyDaemon) StopContainer(id string) error {
+	return ErrReadOnly
+}
+
+func (d *readOnlyDaemon) StopContainers(ids []string, kill bool) (int, error) {
+	return 0, ErrReadOnly
+}
+
+func (d *readOnlyDaemon) UpdateResources(id string, r ContainerResources) error {
+	return ErrReadOnly
+}
+
+func (d *readOnlyDaemon) UpdateRestartPolicy(id string, policy container.RestartPolicy) error {
+	return ErrReadOnly
+}
+
+func (d *readOnlyDaemon) PullImage(image string) error {
+	return ErrReadOnly
+}
+
+func (d *readOnlyDaemon) PullImageWithAuth(image string, auth RegistryAuth) error {
+	return ErrReadOnly
+}
+
+func (d *readOnlyDaemon) RemoveDanglingImages() (int, error) {
+	return 0, ErrReadOnly
+}
+
+func (d *readOnlyDaemon) RemoveImages(ids []string, force bool, removed func(id string, err error)) {
+	if removed == nil {
+		return
+	}
+	for _, id := range ids {
+		removed(id, ErrReadOnly)
+	}
+}
+
+func (d *readOnlyDaemon) RemoveUnusedImages() (int, error) {
+	return 0, ErrReadOnly
+}
+
+func (d *readOnlyDaemon) Rmi(id string, force bool) ([]types.ImageDeleteResponseItem, error) {
+	return nil, ErrReadOnly
+}
+
+func (d *readOnlyDaemon) RunImage(image types.ImageSummary, opts RunOptions) (string, error) {
+	return "", ErrReadOnly
+}
+
+func (d *readOnlyDaemon) RemoveBuildCache(id string) (uint64, error) {
+	return 0, ErrReadOnly
+}
+
+func (d *readOnlyDaemon) RemoveNetwork(id string) error {
+	return ErrReadOnly
+}
+
+func (d *readOnlyDaemon) CreateNetwork(opts NetworkOptions) (string, error) {
+	return "", ErrReadOnly
+}
+
+func (d *readOnlyDaemon) ConnectToNetwork(networkID, container string, aliases []string, ip string) error {
+	return ErrReadOnly
+}
+
+func (d *readOnlyDaemon) DisconnectFromNetwork(networkID, container string) error {
+	return ErrReadOnly
+}
+
+func (d *readOnlyDaemon) PluginEnable(name string) error {
+	return ErrReadOnly
+}
+
+func (d *readOnlyDaemon) PluginDisable(name string, force bool) error {
+	return ErrReadOnly
+}
+
+func (d *readOnlyDaemon) VolumePrune(ctx context.Context) (int, error) {
+	return 0, ErrReadOnly
+}
+
+func (d *readOnlyDaemon) VolumeRemove(ctx context.Context, volumeID string, force bool) error {
+	return ErrReadOnly
+}
+
+func (d *readOnlyDaemon) VolumeRemoveAll(ctx context.Context) (int, error) {
+	return 0, ErrReadOnly
+}
+
+func (d *readOnlyDaemon) ImagePrePull(image, nodeLabel string, report func(PrePullResult)) error {
+	return ErrReadOnly
+}
+
+func (d *readOnlyDaemon) NodeChangeAvailability(nodeID string, availability swarm.NodeAvailability) error {
+	return ErrReadOnly
+}
+
+func (d *readOnlyDaemon) NodeChangeLabels(nodeID string, labels map[string]string) error {
+	return ErrReadOnly
+}
+
+func (d *readOnlyDaemon) NodeChangeRole(nodeID string, role swarm.NodeRole) error {
+	return ErrReadOnly
+}
+
+func (d *readOnlyDaemon) ServiceChangeLabels(id string, labels map[string]string) error {
+	return ErrReadOnly
+}
+
+func (d *readOnlyDaemon) ServiceChangePlacement(id string, constraints []string, preferences []swarm.PlacementPreference) error {
+	return ErrReadOnly
+}
+
+func (d *readOnlyDaemon) ServiceDNSLookup(serviceID, networkID string) (*ServiceDNSReport, error) {
+	return nil, ErrReadOnly
+}
+
+func (d *readOnlyDaemon) ServiceRemove(id string) error {
+	return ErrReadOnly
+}
+
+func (d *readOnlyDaemon) ServiceScale(id string, replicas uint64) error {
+	return ErrReadOnly
+}
+
+func (d *readOnlyDaemon) ServiceUpdate(id string) error {
+	return ErrReadOnly
+}
+
+func (d *readOnlyDaemon) StackRemove(id string) error {
+	return ErrReadOnly
+}
+
+func (d *readOnlyDaemon) SwarmInit(advertiseAddr string) (string, error) {
+	return "", ErrReadOnly
+}
+
+func (d *readOnlyDaemon) SwarmJoin(token, managerAddr string) error {
+	return ErrReadOnly
+}
+
+func (d *readOnlyDaemon) SwarmLeave(force bool) error {
+	return ErrReadOnly
+}
+
+func (d *readOnlyDaemon) SwarmRotateCA() error {
+	return ErrReadOnly
+}
+
+func (d *readOnlyDaemon) SwarmRotateJoinToken(manager bool) error {
+	return ErrReadOnly
+}
@@ -0,0 +1,182 @@
+package docker
+
+import (
+	"context"
+	"strings"
+
+	dockerTypes "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	pkgError "github.com/pkg/errors"
+)
+
+//replacedSuffix is appended to the name of a container while it is being
+//replaced by a new one
+const replacedSuffix = "_dry_replaced"
+
+//RecreateContainer stops, removes and creates again the container with the
+//given id with the same configuration, optionally pulling its image first,
+//as Docker Compose does on updates. The new container is started if the
+//container was running. The container is kept if the new one cannot be
+//created. Returns the id of the new container.
+func (daemon *DockerDaemon) RecreateContainer(id string, pull bool) (string, error) {
+	c, err := daemon.Inspect(id)
+	if err != nil {
+		return "", pkgError.Wrap(err, "error inspecting container")
+	}
+	if c.Config == nil || c.HostConfig == nil {
+		return "", pkgError.Errorf("container %s has no configuration", id)
+	}
+	if pull {
+		if err := daemon.PullImage(c.Config.Image); err != nil {
+			return "", err
+		}
+	}
+	running := c.State != nil && c.State.Running
+	name := strings.TrimPrefix(c.Name, "/")
+	config, hostConfig, endpoints := recreateConfig(c)
+	//the data of anonymous volumes is kept, as Docker Compose does
+	keepAnonymousVolumes(c.Mounts, hostConfig)
+
+	ctx := context.Background()
+	if running {
+		if err := daemon.client.ContainerStop(ctx, id, &containerOpTimeout); err != nil {
+			return "", pkgError.Wrap(err, "error stopping container")
+		}
+	}
+	if err := daemon.client.ContainerRename(ctx, id, name+replacedSuffix); err != nil {
+		return "", pkgError.Wrap(err, "error renaming container")
+	}
+	//the container is restored as it was if the new one cannot be created
+	restore := func(err error) (string, error) {
+		daemon.client.ContainerRename(ctx, id, name)
+		if running {
+			daemon.client.ContainerStart(ctx, id, dockerTypes.ContainerStartOptions{})
+		}
+		daemon.refreshAndWait()
+		return "", err
+	}
+
+	created, err := daemon.create(ctx, config, hostConfig, endpoints, name)
+	if err != nil {
+		return restore(err)
+	}
+	if err := daemon.client.ContainerRemove(ctx, id, dockerTypes.ContainerRemoveOptions{Force: true}); err != nil {
+		daemon.client.ContainerRemove(ctx, created, dockerTypes.ContainerRemoveOptions{Force: true})
+		return restore(pkgError.Wrap(err, "error removing container"))
+	}
+	if running {
+		if err := daemon.client.ContainerStart(ctx, created, dockerTypes.ContainerStartOptions{}); err != nil {
+			daemon.refreshAndWait()
+			return created, pkgError.Wrap(err, "error starting the new container")
+		}
+	}
+	return created, daemon.refreshAndWait()
+}
+
+//create creates a container with the given configuration, connected to the
+//given networks. Nothing is left behind if it cannot be created.
+func (daemon *DockerDaemon) create(ctx context.Context, config *container.Config, hostConfig *container.HostConfig,
+	endpoints map[string]*network.EndpointSettings, name string) (string, error) {
+	var networking *network.NetworkingConfig
+	mode := string(hostConfig.NetworkMode)
+	if hostConfig.NetworkMode.IsDefault() {
+		mode = "bridge"
+	}
+	if endpoint, ok := endpoints[mode]; ok {
+		networking = &network.NetworkingConfig{
+			EndpointsConfig: map[string]*network.EndpointSettings{mode: endpoint},
+		}
+	}
+	created, err := daemon.client.ContainerCreate(ctx, config, hostConfig, networking, name)
+	if err != nil {
+		return "", pkgError.Wrap(err, "error creating container")
+	}
+	for name, endpoint := range endpoints {
+		if name == mode {
+			continue
+		}
+		if err := daemon.client.NetworkConnect(ctx, name, created.ID, endpoint); err != nil {
+			daemon.client.ContainerRemove(ctx, created.ID, dockerTypes.ContainerRemoveOptions{Force: true})
+			return "", pkgError.Wrapf(err, "error connecting container to network %s", name)
+		}
+	}
+	return created.ID, nil
+}
+
+//recreateConfig returns the configuration to create again the given
+//container, and the settings of the networks it is connected to, by name.
+//Settings Docker gives to the container once created are left out.
+func recreateConfig(c dockerTypes.ContainerJSON) (*container.Config, *container.HostConfig, map[string]*network.EndpointSettings) {
+	config := *c.Config
+	hostConfig := *c.HostConfig
+	shortID := TruncateID(c.ID)
+	//the hostname defaults to the container id
+	if config.Hostname == shortID {
+		config.Hostname = ""
+	}
+	endpoints := make(map[string]*network.EndpointSettings)
+	if c.NetworkSettings == nil {
+		return &config, &hostConfig, endpoints
+	}
+	for name, settings := range c.NetworkSettings.Networks {
+		if settings == nil {
+			continue
+		}
+		endpoint := &network.EndpointSettings{
+			IPAMConfig: settings.IPAMConfig,
+			Links:      settings.Links,
+			DriverOpts: settings.DriverOpts,
+		}
+		for _, alias := range settings.Aliases {
+			//the short id of the container is added as alias on user-defined networks
+			if alias != shortID {
+				endpoint.Aliases = append(endpoint.Aliases, alias)
+			}
+		}
+		endpoints[name] = endpoint
+	}
+	return &config, &hostConfig, endpoints
+}
+
+//keepAnonymousVolumes mounts the anonymous volumes of the given mounts of a
+//container, those of the VOLUMEs of its image and of -v /path, on the given
+//configuration, so a new container created with it keeps their data
+func keepAnonymousVolumes(mounts []dockerTypes.MountPoint, hostConfig *container.HostConfig) {
+	//a copy, so the configuration of the container is left as it is
+	hostConfig.Binds = append([]string(nil), hostConfig.Binds...)
+	hostConfig.Mounts = append([]mount.Mount(nil), hostConfig.Mounts...)
+	mounted := make(map[string]bool)
+	for _, bind := range hostConfig.Binds {
+		if parts := strings.Split(bind, ":"); len(parts) > 1 {
+			mounted[parts[1]] = true
+		}
+	}
+	for _, m := range hostConfig.Mounts {
+		if m.Source != "" || m.Type != mount.TypeVolume {
+			mounted[m.Target] = true
+		}
+	}
+	for _, m := range mounts {
+		if m.Type != mount.TypeVolume || m.Name == "" || mounted[m.Destination] {
+			continue
+		}
+		found := false
+		//volumes of --mount with no source are given a name once created
+		for i := range hostConfig.Mounts {
+			if hostConfig.Mounts[i].Target == m.Destination {
+				hostConfig.Mounts[i].Source = m.Name
+				found = true
+			}
+		}
+		if found {
+			continue
+		}
+		bind := m.Name + ":" + m.Destination
+		if !m.RW {
+			bind += ":ro"
+		}
+		hostConfig.Binds = append(hostConfig.Binds, bind)
+	}
+}
@@ -0,0 +1,156 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	dockerTypes "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	dockerAPI "github.com/docker/docker/client"
+)
+
+type recreateAPIClientMock struct {
+	dockerAPI.APIClient
+	container dockerTypes.ContainerJSON
+	createErr error
+	calls     *[]string
+}
+
+func (c recreateAPIClientMock) ContainerInspect(ctx context.Context, id string) (dockerTypes.ContainerJSON, error) {
+	return c.container, nil
+}
+
+func (c recreateAPIClientMock) ContainerStop(ctx context.Context, id string, timeout *time.Duration) error {
+	*c.calls = append(*c.calls, "stop "+id)
+	return nil
+}
+
+func (c recreateAPIClientMock) ContainerRename(ctx context.Context, id, name string) error {
+	*c.calls = append(*c.calls, "rename "+id+" "+name)
+	return nil
+}
+
+func (c recreateAPIClientMock) ContainerCreate(ctx context.Context, config *container.Config, hostConfig *container.HostConfig,
+	networking *network.NetworkingConfig, name string) (container.ContainerCreateCreatedBody, error) {
+	if c.createErr != nil {
+		return container.ContainerCreateCreatedBody{}, c.createErr
+	}
+	var networks []string
+	if networking != nil {
+		for name := range networking.EndpointsConfig {
+			networks = append(networks, name)
+		}
+	}
+	*c.calls = append(*c.calls, "create "+name+" hostname="+config.Hostname+" networks="+strings.Join(networks, ","))
+	return container.ContainerCreateCreatedBody{ID: "new"}, nil
+}
+
+func (c recreateAPIClientMock) NetworkConnect(ctx context.Context, network, id string, settings *network.EndpointSettings) error {
+	*c.calls = append(*c.calls, "connect "+id+" "+network+" aliases="+strings.Join(settings.Aliases, ","))
+	return nil
+}
+
+func (c recreateAPIClientMock) ContainerRemove(ctx context.Context, id string, opts dockerTypes.ContainerRemoveOptions) error {
+	*c.calls = append(*c.calls, "remove "+id)
+	return nil
+}
+
+func (c recreateAPIClientMock) ContainerStart(ctx context.Context, id string, opts dockerTypes.ContainerStartOptions) error {
+	*c.calls = append(*c.calls, "start "+id)
+	return nil
+}
+
+func (c recreateAPIClientMock) ContainerList(ctx context.Context, opts dockerTypes.ContainerListOptions) ([]dockerTypes.Container, error) {
+	return nil, nil
+}
+
+func TestRecreateContainer(t *testing.T) {
+	id := "0123456789abcdef"
+	c := dockerTypes.ContainerJSON{
+		ContainerJSONBase: &dockerTypes.ContainerJSONBase{
+			ID:         id,
+			Name:       "/web",
+			State:      &dockerTypes.ContainerState{Running: true},
+			HostConfig: &container.HostConfig{NetworkMode: "default"},
+		},
+		Config: &container.Config{Image: "nginx", Hostname: "0123456789ab"},
+		NetworkSettings: &dockerTypes.NetworkSettings{
+			Networks: map[string]*network.EndpointSettings{
+				"bridge":  {IPAddress: "172.17.0.2"},
+				"backend": {Aliases: []string{"0123456789ab", "api"}},
+			},
+		},
+	}
+	var calls []string
+	daemon := DockerDaemon{client: recreateAPIClientMock{container: c, calls: &calls}}
+	newID, err := daemon.RecreateContainer(id, false)
+	if err != nil {
+		t.Fatalf("Unexpected error recreating a container: %s", err)
+	}
+	expected := []string{
+		"stop " + id,
+		"rename " + id + " web" + replacedSuffix,
+		"create web hostname= networks=bridge",
+		"connect new backend aliases=api",
+		"remove " + id,
+		"start new",
+	}
+	if newID != "new" || !reflect.DeepEqual(calls, expected) {
+		t.Errorf("Unexpected container recreation: %s\n%v", newID, calls)
+	}
+
+	calls = nil
+	daemon = DockerDaemon{client: recreateAPIClientMock{container: c, calls: &calls, createErr: errors.New("no space left")}}
+	if _, err := daemon.RecreateContainer(id, false); err == nil {
+		t.Fatal("Expected an error recreating a container")
+	}
+	expected = []string{
+		"stop " + id,
+		"rename " + id + " web" + replacedSuffix,
+		"rename " + id + " web",
+		"start " + id,
+	}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("Container not restored once its recreation failed: %v", calls)
+	}
+}
+
+func TestKeepAnonymousVolumes(t *testing.T) {
+	anonymous := strings.Repeat("a", 64)
+	mounted := strings.Repeat("b", 64)
+	c := dockerTypes.ContainerJSON{
+		ContainerJSONBase: &dockerTypes.ContainerJSONBase{
+			ID: "0123456789abcdef",
+			HostConfig: &container.HostConfig{
+				Binds:  []string{"data:/data", "/etc/app:/etc/app:ro"},
+				Mounts: []mount.Mount{{Type: mount.TypeVolume, Target: "/cache"}},
+			},
+		},
+		Config: &container.Config{Image: "postgres"},
+		Mounts: []dockerTypes.MountPoint{
+			{Type: mount.TypeVolume, Name: "data", Destination: "/data", RW: true},
+			{Type: mount.TypeBind, Source: "/etc/app", Destination: "/etc/app"},
+			{Type: mount.TypeVolume, Name: anonymous, Destination: "/var/lib/postgresql/data", RW: true},
+			{Type: mount.TypeVolume, Name: mounted, Destination: "/cache", RW: true},
+		},
+	}
+	_, hostConfig, _ := recreateConfig(c)
+	keepAnonymousVolumes(c.Mounts, hostConfig)
+
+	expectedBinds := []string{"data:/data", "/etc/app:/etc/app:ro", anonymous + ":/var/lib/postgresql/data"}
+	if !reflect.DeepEqual(hostConfig.Binds, expectedBinds) {
+		t.Errorf("Unexpected binds of the new container: %v", hostConfig.Binds)
+	}
+	if len(hostConfig.Mounts) != 1 || hostConfig.Mounts[0].Source != mounted {
+		t.Errorf("Unexpected mounts of the new container: %v", hostConfig.Mounts)
+	}
+	if len(c.HostConfig.Binds) != 2 || c.HostConfig.Mounts[0].Source != "" {
+		t.Errorf("The configuration of the container was modified: %v", c.HostConfig)
+	}
+}
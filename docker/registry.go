@@ -0,0 +1,242 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/docker/distribution/reference"
+	pkgError "github.com/pkg/errors"
+)
+
+const (
+	defaultRegistryTimeout = 10 * time.Second
+	dockerHubDomain        = "docker.io"
+	dockerHubRegistry      = "registry-1.docker.io"
+
+	mediaTypeManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+	mediaTypeManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+	mediaTypeOCIIndex     = "application/vnd.oci.image.index.v1+json"
+	mediaTypeOCIManifest  = "application/vnd.oci.image.manifest.v1+json"
+)
+
+//authParamRegexp matches the parameters of a WWW-Authenticate header, i.e. realm="https://auth.docker.io/token"
+var authParamRegexp = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+//PullEstimate is the estimated download of an image pull, as described by the
+//image manifest found on the registry
+type PullEstimate struct {
+	Image  string
+	Layers int
+	//Size is the compressed size of the image layers, in bytes
+	Size int64
+}
+
+//registryManifest is the part of an image manifest, or manifest list, used
+//to estimate pulls
+type registryManifest struct {
+	MediaType string `json:"mediaType"`
+	Manifests []struct {
+		Digest   string `json:"digest"`
+		Platform struct {
+			Architecture string `json:"architecture"`
+			OS           string `json:"os"`
+		} `json:"platform"`
+	} `json:"manifests"`
+	Layers []struct {
+		Size int64 `json:"size"`
+	} `json:"layers"`
+}
+
+func (m registryManifest) isList() bool {
+	return m.MediaType == mediaTypeManifestList || m.MediaType == mediaTypeOCIIndex
+}
+
+//registryClient retrieves image manifests from a registry using anonymous access
+type registryClient struct {
+	client  *http.Client
+	baseURL string
+	token   string
+}
+
+func newRegistryClient(domain string) *registryClient {
+	if domain == dockerHubDomain {
+		domain = dockerHubRegistry
+	}
+	return &registryClient{
+		client:  &http.Client{Timeout: defaultRegistryTimeout},
+		baseURL: "https://" + domain,
+	}
+}
+
+//estimatePull estimates the download size of the given image for the given
+//platform, the image is given as it would be given to docker pull
+func estimatePull(ctx context.Context, image, os, arch string) (PullEstimate, error) {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return PullEstimate{}, pkgError.Wrap(err, "invalid image reference")
+	}
+	named = reference.TagNameOnly(named)
+	return newRegistryClient(reference.Domain(named)).estimate(ctx, named, os, arch)
+}
+
+func (r *registryClient) estimate(ctx context.Context, named reference.Named, os, arch string) (PullEstimate, error) {
+	estimate := PullEstimate{Image: reference.FamiliarString(named)}
+	var ref string
+	switch n := named.(type) {
+	case reference.Canonical:
+		ref = n.Digest().String()
+	case reference.Tagged:
+		ref = n.Tag()
+	}
+	repo := reference.Path(named)
+	manifest, err := r.manifest(ctx, repo, ref)
+	if err != nil {
+		return estimate, err
+	}
+	if manifest.isList() {
+		digest := ""
+		for _, m := range manifest.Manifests {
+			if m.Platform.OS == os && m.Platform.Architecture == arch {
+				digest = m.Digest
+				break
+			}
+		}
+		if digest == "" {
+			return estimate, fmt.Errorf("no image for platform %s/%s", os, arch)
+		}
+		if manifest, err = r.manifest(ctx, repo, digest); err != nil {
+			return estimate, err
+		}
+	}
+	estimate.Layers = len(manifest.Layers)
+	for _, layer := range manifest.Layers {
+		estimate.Size += layer.Size
+	}
+	return estimate, nil
+}
+
+//manifest retrieves the manifest of the given repository and reference (a tag or
+//a digest), requesting an anonymous token if the registry asks for one
+func (r *registryClient) manifest(ctx context.Context, repo, ref string) (registryManifest, error) {
+	var manifest registryManifest
+	resp, err := r.do(ctx, http.MethodGet, fmt.Sprintf("%s/v2/%s/manifests/%s", r.baseURL, repo, ref))
+	if err != nil {
+		return manifest, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return manifest, fmt.Errorf("registry returned %s for %s:%s", resp.Status, repo, ref)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&manifest); err != nil {
+		return manifest, pkgError.Wrap(err, "error decoding image manifest")
+	}
+	if manifest.MediaType == "" {
+		manifest.MediaType = resp.Header.Get("Content-Type")
+	}
+	return manifest, nil
+}
+
+//digest retrieves the digest of the manifest of the given repository and
+//reference, without downloading the manifest
+func (r *registryClient) digest(ctx context.Context, repo, ref string) (string, error) {
+	resp, err := r.do(ctx, http.MethodHead, fmt.Sprintf("%s/v2/%s/manifests/%s", r.baseURL, repo, ref))
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("registry returned %s for %s:%s", resp.Status, repo, ref)
+	}
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		return "", fmt.Errorf("registry returned no digest for %s:%s", repo, ref)
+	}
+	return digest, nil
+}
+
+//do sends a request with the given method to the given url, requesting an
+//anonymous token if the registry asks for one
+func (r *registryClient) do(ctx context.Context, method, url string) (*http.Response, error) {
+	resp, err := r.request(ctx, method, url)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized && r.token == "" {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+		if err := r.authenticate(ctx, challenge); err != nil {
+			return nil, err
+		}
+		return r.request(ctx, method, url)
+	}
+	return resp, nil
+}
+
+func (r *registryClient) request(ctx context.Context, method, url string) (*http.Response, error) {
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join([]string{
+		mediaTypeManifestList, mediaTypeManifest, mediaTypeOCIIndex, mediaTypeOCIManifest}, ", "))
+	if r.token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.token)
+	}
+	resp, err := r.client.Do(req.WithContext(ctx))
+	return resp, pkgError.Wrap(err, "error contacting registry")
+}
+
+//authenticate requests an anonymous token as described by the given
+//WWW-Authenticate challenge
+func (r *registryClient) authenticate(ctx context.Context, challenge string) error {
+	if !strings.HasPrefix(challenge, "Bearer ") {
+		return fmt.Errorf("registry requires unsupported authentication: %q", challenge)
+	}
+	params := make(map[string]string)
+	for _, m := range authParamRegexp.FindAllStringSubmatch(challenge, -1) {
+		params[m[1]] = m[2]
+	}
+	realm, ok := params["realm"]
+	if !ok {
+		return fmt.Errorf("no realm in authentication challenge: %q", challenge)
+	}
+	req, err := http.NewRequest(http.MethodGet, realm, nil)
+	if err != nil {
+		return err
+	}
+	q := req.URL.Query()
+	for _, key := range []string{"service", "scope"} {
+		if value, ok := params[key]; ok {
+			q.Set(key, value)
+		}
+	}
+	req.URL.RawQuery = q.Encode()
+	resp, err := r.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return pkgError.Wrap(err, "error requesting registry token")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("registry token request returned %s", resp.Status)
+	}
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return pkgError.Wrap(err, "error decoding registry token")
+	}
+	r.token = token.Token
+	if r.token == "" {
+		r.token = token.AccessToken
+	}
+	if r.token == "" {
+		return fmt.Errorf("registry returned an empty token")
+	}
+	return nil
+}
@@ -0,0 +1,287 @@
+package docker
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+	dockerTypes "github.com/docker/docker/api/types"
+	"github.com/docker/docker/errdefs"
+	pkgError "github.com/pkg/errors"
+)
+
+//dockerHubServerAddress is the address docker login uses for Docker Hub
+const dockerHubServerAddress = "https://index.docker.io/v1/"
+
+//RegistryAuth are the credentials used to authenticate on a registry
+type RegistryAuth struct {
+	ServerAddress string
+	Username      string
+	Password      string
+	//IdentityToken is used instead of the username and password if set, as
+	//saved by docker login for registries using OAuth
+	IdentityToken string
+}
+
+//encode returns the credentials as expected by the Docker API
+func (a RegistryAuth) encode() (string, error) {
+	if a.Username == "" && a.IdentityToken == "" {
+		return "", nil
+	}
+	buf, err := json.Marshal(dockerTypes.AuthConfig{
+		Username:      a.Username,
+		Password:      a.Password,
+		ServerAddress: a.ServerAddress,
+		IdentityToken: a.IdentityToken,
+	})
+	if err != nil {
+		return "", err
+	}
+	return base64.URLEncoding.EncodeToString(buf), nil
+}
+
+//unauthorizedError is returned when a registry refuses the credentials, or
+//the lack of them, used on a pull
+type unauthorizedError struct {
+	error
+}
+
+func (e unauthorizedError) Unauthorized() {}
+
+//IsUnauthorized returns true if the given error was caused by a registry
+//refusing access
+func IsUnauthorized(err error) bool {
+	return errdefs.IsUnauthorized(pkgError.Cause(err))
+}
+
+//isUnauthorizedMessage returns true if the given error message, as reported
+//by the Docker daemon, is about a registry refusing access
+func isUnauthorizedMessage(msg string) bool {
+	msg = strings.ToLower(msg)
+	for _, s := range []string{"unauthorized", "authentication required", "401", "docker login"} {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+//RegistryOf returns the address of the registry the given image is pulled
+//from, as it is known to docker login and to credential helpers
+func RegistryOf(image string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return "", pkgError.Wrap(err, "invalid image reference")
+	}
+	if domain := reference.Domain(named); domain != dockerHubDomain {
+		return domain, nil
+	}
+	return dockerHubServerAddress, nil
+}
+
+//dockerConfigFile returns the path of the Docker client configuration file
+func dockerConfigFile() string {
+	dir := os.Getenv("DOCKER_CONFIG")
+	if dir == "" {
+		dir = defaultDockerPath
+	}
+	return filepath.Join(dir, "config.json")
+}
+
+//readDockerConfig returns the settings of the Docker client configuration
+//file on the given path, none if there is no such file
+func readDockerConfig(path string) (map[string]json.RawMessage, error) {
+	config := make(map[string]json.RawMessage)
+	content, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, pkgError.Wrapf(err, "error reading Docker configuration %s", path)
+	}
+	if len(content) > 0 {
+		if err := json.Unmarshal(content, &config); err != nil {
+			return nil, pkgError.Wrapf(err, "error parsing Docker configuration %s", path)
+		}
+	}
+	return config, nil
+}
+
+//registryHostname returns the host of the given registry address, docker
+//login saves some of them as URLs
+func registryHostname(address string) string {
+	address = strings.TrimPrefix(strings.TrimPrefix(address, "https://"), "http://")
+	return strings.SplitN(address, "/", 2)[0]
+}
+
+//credentialHelper returns the credential helper configured for the given
+//registry on the given Docker client configuration, if any
+func credentialHelper(config map[string]json.RawMessage, serverAddress string) string {
+	var helpers map[string]string
+	if raw, ok := config["credHelpers"]; ok && json.Unmarshal(raw, &helpers) == nil {
+		if helper, ok := helpers[serverAddress]; ok {
+			return helper
+		}
+	}
+	var store string
+	if raw, ok := config["credsStore"]; ok {
+		json.Unmarshal(raw, &store)
+	}
+	return store
+}
+
+//StoreRegistryAuth saves the given credentials as docker login does, using
+//the credential helper configured on the Docker client configuration or, if
+//there is none, the configuration file itself. It returns the name of the
+//helper used, empty if the credentials were saved on the configuration file.
+func StoreRegistryAuth(auth RegistryAuth) (string, error) {
+	return storeRegistryAuth(dockerConfigFile(), auth, runCredentialHelper)
+}
+
+//StoredRegistryAuth returns the credentials saved, as docker login does, for
+//the registry of the given image, empty credentials if there are none
+func StoredRegistryAuth(image string) (RegistryAuth, error) {
+	registry, err := RegistryOf(image)
+	if err != nil {
+		return RegistryAuth{}, err
+	}
+	return storedRegistryAuth(dockerConfigFile(), registry, runCredentialHelper)
+}
+
+//credentialHelperRunner runs the given credential helper command with the
+//given input and returns its output
+type credentialHelperRunner func(helper, command string, input []byte) ([]byte, error)
+
+func storedRegistryAuth(path, serverAddress string, run credentialHelperRunner) (RegistryAuth, error) {
+	config, err := readDockerConfig(path)
+	if err != nil {
+		return RegistryAuth{}, err
+	}
+	if helper := credentialHelper(config, serverAddress); helper != "" {
+		out, err := run(helper, "get", []byte(serverAddress))
+		if err != nil {
+			if strings.Contains(err.Error(), "credentials not found") {
+				return RegistryAuth{}, nil
+			}
+			return RegistryAuth{}, pkgError.Wrapf(err, "error getting credentials with docker-credential-%s", helper)
+		}
+		var creds struct {
+			Username string
+			Secret   string
+		}
+		if err := json.Unmarshal(out, &creds); err != nil {
+			return RegistryAuth{}, pkgError.Wrapf(err, "error reading credentials from docker-credential-%s", helper)
+		}
+		auth := RegistryAuth{ServerAddress: serverAddress}
+		//helpers keep identity tokens with this username, as docker login does
+		if creds.Username == "<token>" {
+			auth.IdentityToken = creds.Secret
+		} else {
+			auth.Username, auth.Password = creds.Username, creds.Secret
+		}
+		return auth, nil
+	}
+	auths := make(map[string]struct {
+		Auth          string `json:"auth"`
+		IdentityToken string `json:"identitytoken"`
+	})
+	if raw, ok := config["auths"]; ok {
+		if err := json.Unmarshal(raw, &auths); err != nil {
+			return RegistryAuth{}, pkgError.Wrapf(err, "error parsing Docker configuration %s", path)
+		}
+	}
+	entry, ok := auths[serverAddress]
+	if !ok && serverAddress != dockerHubServerAddress {
+		for address, e := range auths {
+			if registryHostname(address) == serverAddress {
+				entry, ok = e, true
+				break
+			}
+		}
+	}
+	if !ok {
+		return RegistryAuth{}, nil
+	}
+	auth := RegistryAuth{ServerAddress: serverAddress, IdentityToken: entry.IdentityToken}
+	if entry.Auth != "" {
+		decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+		if err != nil {
+			return RegistryAuth{}, pkgError.Wrapf(err, "invalid credentials of %s on Docker configuration %s", serverAddress, path)
+		}
+		userAndPassword := strings.SplitN(string(decoded), ":", 2)
+		if len(userAndPassword) != 2 {
+			return RegistryAuth{}, fmt.Errorf("invalid credentials of %s on Docker configuration %s", serverAddress, path)
+		}
+		auth.Username, auth.Password = userAndPassword[0], userAndPassword[1]
+	}
+	return auth, nil
+}
+
+func storeRegistryAuth(path string, auth RegistryAuth, run credentialHelperRunner) (string, error) {
+	config, err := readDockerConfig(path)
+	if err != nil {
+		return "", err
+	}
+	if helper := credentialHelper(config, auth.ServerAddress); helper != "" {
+		input, err := json.Marshal(struct {
+			ServerURL string
+			Username  string
+			Secret    string
+		}{auth.ServerAddress, auth.Username, auth.Password})
+		if err != nil {
+			return "", err
+		}
+		if _, err := run(helper, "store", input); err != nil {
+			return "", pkgError.Wrapf(err, "error storing credentials with docker-credential-%s", helper)
+		}
+		return helper, nil
+	}
+	auths := make(map[string]json.RawMessage)
+	if raw, ok := config["auths"]; ok {
+		if err := json.Unmarshal(raw, &auths); err != nil {
+			return "", pkgError.Wrapf(err, "error parsing Docker configuration %s", path)
+		}
+	}
+	entry, err := json.Marshal(struct {
+		Auth string `json:"auth"`
+	}{base64.StdEncoding.EncodeToString([]byte(auth.Username + ":" + auth.Password))})
+	if err != nil {
+		return "", err
+	}
+	auths[auth.ServerAddress] = entry
+	if config["auths"], err = json.Marshal(auths); err != nil {
+		return "", err
+	}
+	content, err := json.MarshalIndent(config, "", "\t")
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return "", pkgError.Wrapf(err, "error creating Docker configuration directory")
+	}
+	if err := ioutil.WriteFile(path, content, 0600); err != nil {
+		return "", pkgError.Wrapf(err, "error writing Docker configuration %s", path)
+	}
+	return "", nil
+}
+
+//runCredentialHelper runs the given docker-credential-* command with the given input
+func runCredentialHelper(helper, command string, input []byte) ([]byte, error) {
+	cmd := exec.Command("docker-credential-"+helper, command)
+	cmd.Stdin = bytes.NewReader(input)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		//helpers report errors on stdout
+		if msg := strings.TrimSpace(stderr.String() + string(out)); msg != "" {
+			return nil, fmt.Errorf("%s: %s", err, msg)
+		}
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,201 @@
+package docker
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	dockerTypes "github.com/docker/docker/api/types"
+	pkgError "github.com/pkg/errors"
+)
+
+func TestRegistryOf(t *testing.T) {
+	tests := []struct {
+		image string
+		want  string
+	}{
+		{"alpine", dockerHubServerAddress},
+		{"moncho/dry:latest", dockerHubServerAddress},
+		{"docker.io/library/alpine", dockerHubServerAddress},
+		{"quay.io/coreos/etcd:v3.4", "quay.io"},
+		{"localhost:5000/app", "localhost:5000"},
+	}
+	for _, tt := range tests {
+		got, err := RegistryOf(tt.image)
+		if err != nil {
+			t.Errorf("RegistryOf(%q) returned an error: %s", tt.image, err)
+		}
+		if got != tt.want {
+			t.Errorf("RegistryOf(%q) = %q, want %q", tt.image, got, tt.want)
+		}
+	}
+	if _, err := RegistryOf("UPPER/case"); err == nil {
+		t.Error("Expected an error for an invalid image reference")
+	}
+}
+
+func TestIsUnauthorized(t *testing.T) {
+	if !IsUnauthorized(pkgError.Wrap(unauthorizedError{errors.New("denied")}, "error pulling")) {
+		t.Error("A wrapped unauthorized error was not detected")
+	}
+	if IsUnauthorized(errors.New("unauthorized")) {
+		t.Error("A plain error was taken as unauthorized")
+	}
+	if IsUnauthorized(nil) {
+		t.Error("A nil error was taken as unauthorized")
+	}
+	if !isUnauthorizedMessage("pull access denied for private/app, repository does not exist or may require 'docker login'") {
+		t.Error("A pull access denied message was not detected")
+	}
+	if isUnauthorizedMessage("manifest for alpine:nope not found") {
+		t.Error("A not found message was taken as unauthorized")
+	}
+}
+
+func TestRegistryAuthEncode(t *testing.T) {
+	if encoded, _ := (RegistryAuth{}).encode(); encoded != "" {
+		t.Errorf("Empty credentials encoded as %q", encoded)
+	}
+	auth := RegistryAuth{ServerAddress: "quay.io", Username: "user", Password: "secret"}
+	encoded, err := auth.encode()
+	if err != nil {
+		t.Fatalf("Unexpected error encoding credentials: %s", err)
+	}
+	buf, err := base64.URLEncoding.DecodeString(encoded)
+	if err != nil {
+		t.Fatalf("Credentials are not base64 encoded: %s", err)
+	}
+	var config dockerTypes.AuthConfig
+	if err := json.Unmarshal(buf, &config); err != nil {
+		t.Fatalf("Credentials are not JSON encoded: %s", err)
+	}
+	if config.Username != "user" || config.Password != "secret" || config.ServerAddress != "quay.io" {
+		t.Errorf("Unexpected encoded credentials: %v", config)
+	}
+}
+
+func TestStoreRegistryAuth(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dry-docker-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "config.json")
+	noHelper := func(helper, command string, input []byte) ([]byte, error) {
+		t.Errorf("Unexpected call to credential helper %s", helper)
+		return nil, nil
+	}
+
+	auth := RegistryAuth{ServerAddress: "quay.io", Username: "user", Password: "secret"}
+	helper, err := storeRegistryAuth(path, auth, noHelper)
+	if err != nil || helper != "" {
+		t.Fatalf("storeRegistryAuth() = %q, %v, want no helper and no error", helper, err)
+	}
+	content, _ := ioutil.ReadFile(path)
+	var config struct {
+		Auths map[string]struct {
+			Auth string `json:"auth"`
+		} `json:"auths"`
+		Other string `json:"other"`
+	}
+	if err := json.Unmarshal(content, &config); err != nil {
+		t.Fatalf("Unexpected error reading stored credentials: %s", err)
+	}
+	if got := config.Auths["quay.io"].Auth; got != base64.StdEncoding.EncodeToString([]byte("user:secret")) {
+		t.Errorf("Unexpected stored credentials: %q", got)
+	}
+
+	//existing settings are kept and the configured helper is used
+	ioutil.WriteFile(path, []byte(`{"other": "kept", "credsStore": "secretservice", "credHelpers": {"gcr.io": "gcloud"}}`), 0600)
+	var stored struct {
+		ServerURL string
+		Username  string
+		Secret    string
+	}
+	var used []string
+	run := func(helper, command string, input []byte) ([]byte, error) {
+		used = append(used, helper+" "+command)
+		return nil, json.Unmarshal(input, &stored)
+	}
+	if helper, err = storeRegistryAuth(path, auth, run); err != nil || helper != "secretservice" {
+		t.Errorf("storeRegistryAuth() = %q, %v, want the secretservice helper", helper, err)
+	}
+	auth.ServerAddress = "gcr.io"
+	if helper, err = storeRegistryAuth(path, auth, run); err != nil || helper != "gcloud" {
+		t.Errorf("storeRegistryAuth() = %q, %v, want the gcloud helper", helper, err)
+	}
+	if len(used) != 2 || used[0] != "secretservice store" || used[1] != "gcloud store" {
+		t.Errorf("Unexpected credential helper calls: %v", used)
+	}
+	if stored.ServerURL != "gcr.io" || stored.Username != "user" || stored.Secret != "secret" {
+		t.Errorf("Unexpected credentials given to the helper: %v", stored)
+	}
+	content, _ = ioutil.ReadFile(path)
+	if err := json.Unmarshal(content, &config); err != nil || config.Other != "kept" {
+		t.Errorf("Docker configuration was modified when using a helper: %s", content)
+	}
+}
+
+func TestStoredRegistryAuth(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dry-docker-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "config.json")
+	noHelper := func(helper, command string, input []byte) ([]byte, error) {
+		t.Errorf("Unexpected call to credential helper %s", helper)
+		return nil, nil
+	}
+
+	if auth, err := storedRegistryAuth(path, "quay.io", noHelper); err != nil || auth != (RegistryAuth{}) {
+		t.Errorf("storedRegistryAuth() = %v, %v, want no credentials without configuration", auth, err)
+	}
+
+	//credentials saved by storeRegistryAuth are found
+	saved := RegistryAuth{ServerAddress: "quay.io", Username: "user", Password: "se:cret"}
+	if _, err := storeRegistryAuth(path, saved, noHelper); err != nil {
+		t.Fatal(err)
+	}
+	if auth, err := storedRegistryAuth(path, "quay.io", noHelper); err != nil || auth != saved {
+		t.Errorf("storedRegistryAuth() = %v, %v, want %v", auth, err, saved)
+	}
+	if auth, _ := storedRegistryAuth(path, "gcr.io", noHelper); auth != (RegistryAuth{}) {
+		t.Errorf("Unexpected credentials for another registry: %v", auth)
+	}
+
+	//registries saved as URLs and identity tokens
+	ioutil.WriteFile(path, []byte(`{"auths": {"https://registry.example.com/v2/": {"identitytoken": "token"}}}`), 0600)
+	auth, err := storedRegistryAuth(path, "registry.example.com", noHelper)
+	if err != nil || auth.IdentityToken != "token" || auth.Username != "" {
+		t.Errorf("storedRegistryAuth() = %v, %v, want the identity token", auth, err)
+	}
+
+	//configured helpers are asked for the credentials
+	ioutil.WriteFile(path, []byte(`{"credsStore": "secretservice", "credHelpers": {"gcr.io": "gcloud"}}`), 0600)
+	run := func(helper, command string, input []byte) ([]byte, error) {
+		if command != "get" {
+			t.Errorf("Unexpected credential helper command %s", command)
+		}
+		switch string(input) {
+		case "gcr.io":
+			return []byte(`{"ServerURL": "gcr.io", "Username": "<token>", "Secret": "oauth"}`), nil
+		case "quay.io":
+			return []byte(`{"ServerURL": "quay.io", "Username": "user", "Secret": "secret"}`), nil
+		}
+		return nil, errors.New("exit status 1: credentials not found in native keychain")
+	}
+	if auth, err := storedRegistryAuth(path, "quay.io", run); err != nil || auth.Username != "user" || auth.Password != "secret" {
+		t.Errorf("storedRegistryAuth() = %v, %v, want the credentials of the secretservice helper", auth, err)
+	}
+	if auth, err := storedRegistryAuth(path, "gcr.io", run); err != nil || auth.IdentityToken != "oauth" {
+		t.Errorf("storedRegistryAuth() = %v, %v, want the identity token of the gcloud helper", auth, err)
+	}
+	if auth, err := storedRegistryAuth(path, "localhost:5000", run); err != nil || auth != (RegistryAuth{}) {
+		t.Errorf("storedRegistryAuth() = %v, %v, want no credentials", auth, err)
+	}
+}
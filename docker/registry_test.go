@@ -0,0 +1,57 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/docker/distribution/reference"
+)
+
+func TestRegistryClient_estimate(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			if r.URL.Query().Get("scope") != "repository:library/alpine:pull" {
+				t.Errorf("Unexpected token scope: %s", r.URL.RawQuery)
+			}
+			fmt.Fprint(w, `{"token": "secret"}`)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.Header().Set("WWW-Authenticate",
+				fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:library/alpine:pull"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/v2/library/alpine/manifests/latest":
+			w.Header().Set("Content-Type", mediaTypeManifestList)
+			fmt.Fprint(w, `{"manifests": [
+				{"digest": "sha256:arm", "platform": {"architecture": "arm64", "os": "linux"}},
+				{"digest": "sha256:amd", "platform": {"architecture": "amd64", "os": "linux"}}]}`)
+		case "/v2/library/alpine/manifests/sha256:amd":
+			fmt.Fprint(w, `{"mediaType": "`+mediaTypeManifest+`", "layers": [{"size": 100}, {"size": 23}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	named, _ := reference.ParseNormalizedNamed("alpine")
+	r := &registryClient{client: server.Client(), baseURL: server.URL}
+	estimate, err := r.estimate(context.Background(), reference.TagNameOnly(named), "linux", "amd64")
+	if err != nil {
+		t.Fatalf("Unexpected error estimating pull: %s", err)
+	}
+	want := PullEstimate{Image: "alpine:latest", Layers: 2, Size: 123}
+	if estimate != want {
+		t.Errorf("Unexpected pull estimate, got %v, want %v", estimate, want)
+	}
+
+	if _, err := r.estimate(context.Background(), reference.TagNameOnly(named), "windows", "amd64"); err == nil {
+		t.Error("Expected an error estimating a pull for a platform with no image")
+	}
+}
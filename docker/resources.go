@@ -0,0 +1,91 @@
+package docker
+
+import (
+	"context"
+
+	"github.com/docker/docker/api/types/container"
+	pkgError "github.com/pkg/errors"
+)
+
+//ContainerResources are the resource limits and restart policy of a container
+//that can be changed while it runs, zero means no limit, or the default value
+type ContainerResources struct {
+	//CPUShares is the CPU weight relative to other containers
+	CPUShares int64
+	//CPUQuota is the CPU time, in microseconds, the container can use on each CPUPeriod
+	CPUQuota  int64
+	CPUPeriod int64
+	//Memory is the memory limit, in bytes
+	Memory int64
+	//MemorySwap is the limit of memory plus swap, in bytes, -1 for unlimited swap
+	MemorySwap    int64
+	RestartPolicy container.RestartPolicy
+}
+
+//WithMemory returns the resources with the given memory limit, the swap
+//limit is changed with it to keep the amount of swap the container can use,
+//Docker refuses a memory limit above the swap limit
+func (r ContainerResources) WithMemory(memory int64) ContainerResources {
+	if memory == r.Memory {
+		return r
+	}
+	switch {
+	case r.MemorySwap < 0 || memory == 0:
+		//unlimited swap, or no memory limit to keep swap for
+	case r.Memory > 0 && r.MemorySwap > 0:
+		r.MemorySwap = memory + r.MemorySwap - r.Memory
+	default:
+		//as docker run does, the container can use as much swap as memory
+		r.MemorySwap = 2 * memory
+	}
+	r.Memory = memory
+	return r
+}
+
+//ResourcesOf returns the resources of the given container, if known
+func ResourcesOf(c *Container) (ContainerResources, bool) {
+	if c == nil || c.ContainerJSONBase == nil || c.ContainerJSON.HostConfig == nil {
+		return ContainerResources{}, false
+	}
+	hc := c.ContainerJSON.HostConfig
+	r := ContainerResources{
+		CPUShares:     hc.CPUShares,
+		CPUQuota:      hc.CPUQuota,
+		CPUPeriod:     hc.CPUPeriod,
+		Memory:        hc.Memory,
+		MemorySwap:    hc.MemorySwap,
+		RestartPolicy: hc.RestartPolicy,
+	}
+	//a removed quota is kept as -1
+	if r.CPUQuota < 0 {
+		r.CPUQuota = 0
+	}
+	return r, true
+}
+
+//UpdateResources changes the resources of the container with the given id
+//while it runs. Docker leaves unchanged the resources given as zero, but for
+//the CPU quota, which is removed. CPU shares and period, and the memory
+//limit, cannot be removed once set.
+func (daemon *DockerDaemon) UpdateResources(id string, r ContainerResources) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	quota := r.CPUQuota
+	if quota == 0 {
+		quota = -1
+	}
+	update := container.UpdateConfig{
+		Resources: container.Resources{
+			CPUShares:  r.CPUShares,
+			CPUQuota:   quota,
+			CPUPeriod:  r.CPUPeriod,
+			Memory:     r.Memory,
+			MemorySwap: r.MemorySwap,
+		},
+		RestartPolicy: r.RestartPolicy,
+	}
+	if _, err := daemon.client.ContainerUpdate(ctx, id, update); err != nil {
+		return pkgError.Wrapf(err, "Error updating the resources of container %s", id)
+	}
+	return daemon.refreshAndWait()
+}
@@ -0,0 +1,28 @@
+package docker
+
+import "testing"
+
+func TestContainerResourcesWithMemory(t *testing.T) {
+	const mb = 1024 * 1024
+	tests := []struct {
+		current ContainerResources
+		memory  int64
+		swap    int64
+	}{
+		//no limits, as much swap as memory
+		{ContainerResources{}, 512 * mb, 1024 * mb},
+		//the swap the container had is kept
+		{ContainerResources{Memory: 512 * mb, MemorySwap: 1024 * mb}, 2048 * mb, 2560 * mb},
+		{ContainerResources{Memory: 512 * mb, MemorySwap: 512 * mb}, 256 * mb, 256 * mb},
+		//unlimited swap
+		{ContainerResources{Memory: 512 * mb, MemorySwap: -1}, 2048 * mb, -1},
+		//unchanged
+		{ContainerResources{Memory: 512 * mb, MemorySwap: 600 * mb}, 512 * mb, 600 * mb},
+	}
+	for _, tt := range tests {
+		r := tt.current.WithMemory(tt.memory)
+		if r.Memory != tt.memory || r.MemorySwap != tt.swap {
+			t.Errorf("%+v.WithMemory(%d) = %+v, want swap %d", tt.current, tt.memory, r, tt.swap)
+		}
+	}
+}
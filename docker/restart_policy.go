@@ -0,0 +1,66 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	pkgError "github.com/pkg/errors"
+)
+
+//ParseRestartPolicy parses a restart policy given as the docker run --restart
+//flag does: no, always, unless-stopped or on-failure[:<max retries>]
+func ParseRestartPolicy(s string) (container.RestartPolicy, error) {
+	var policy container.RestartPolicy
+	s = strings.TrimSpace(s)
+	parts := strings.SplitN(s, ":", 2)
+	policy.Name = parts[0]
+	switch policy.Name {
+	case "no", "always", "unless-stopped":
+		if len(parts) == 2 {
+			return policy, fmt.Errorf("maximum retries can only be set with on-failure: %q", s)
+		}
+	case "on-failure":
+		if len(parts) == 2 {
+			retries, err := strconv.Atoi(parts[1])
+			if err != nil || retries < 0 {
+				return policy, fmt.Errorf("invalid maximum retries: %q", parts[1])
+			}
+			policy.MaximumRetryCount = retries
+		}
+	default:
+		return policy, fmt.Errorf("invalid restart policy %q, expected no, always, unless-stopped or on-failure[:<max retries>]", s)
+	}
+	return policy, nil
+}
+
+//FormatRestartPolicy formats the given restart policy as ParseRestartPolicy expects it
+func FormatRestartPolicy(policy container.RestartPolicy) string {
+	if policy.IsNone() {
+		return "no"
+	}
+	if policy.IsOnFailure() && policy.MaximumRetryCount > 0 {
+		return fmt.Sprintf("%s:%d", policy.Name, policy.MaximumRetryCount)
+	}
+	return policy.Name
+}
+
+//ContainerRestartPolicy returns the restart policy of the given container, if known
+func ContainerRestartPolicy(c *Container) (container.RestartPolicy, bool) {
+	if c == nil || c.ContainerJSONBase == nil || c.ContainerJSON.HostConfig == nil {
+		return container.RestartPolicy{}, false
+	}
+	return c.ContainerJSON.HostConfig.RestartPolicy, true
+}
+
+//UpdateRestartPolicy sets the restart policy of the container with the given id
+func (daemon *DockerDaemon) UpdateRestartPolicy(id string, policy container.RestartPolicy) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	if _, err := daemon.client.ContainerUpdate(ctx, id, container.UpdateConfig{RestartPolicy: policy}); err != nil {
+		return pkgError.Wrapf(err, "Error updating the restart policy of container %s", id)
+	}
+	return daemon.refreshAndWait()
+}
@@ -0,0 +1,53 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+func TestParseRestartPolicy(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    container.RestartPolicy
+		wantErr bool
+	}{
+		{"no", container.RestartPolicy{Name: "no"}, false},
+		{"always", container.RestartPolicy{Name: "always"}, false},
+		{" unless-stopped ", container.RestartPolicy{Name: "unless-stopped"}, false},
+		{"on-failure", container.RestartPolicy{Name: "on-failure"}, false},
+		{"on-failure:5", container.RestartPolicy{Name: "on-failure", MaximumRetryCount: 5}, false},
+		{"on-failure:-1", container.RestartPolicy{}, true},
+		{"on-failure:x", container.RestartPolicy{}, true},
+		{"always:3", container.RestartPolicy{}, true},
+		{"sometimes", container.RestartPolicy{}, true},
+		{"", container.RestartPolicy{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseRestartPolicy(tt.s)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseRestartPolicy(%q) error = %v, wantErr %v", tt.s, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("ParseRestartPolicy(%q) = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestFormatRestartPolicy(t *testing.T) {
+	tests := []struct {
+		policy container.RestartPolicy
+		want   string
+	}{
+		{container.RestartPolicy{}, "no"},
+		{container.RestartPolicy{Name: "always"}, "always"},
+		{container.RestartPolicy{Name: "on-failure"}, "on-failure"},
+		{container.RestartPolicy{Name: "on-failure", MaximumRetryCount: 3}, "on-failure:3"},
+	}
+	for _, tt := range tests {
+		if got := FormatRestartPolicy(tt.policy); got != tt.want {
+			t.Errorf("FormatRestartPolicy(%v) = %s, want %s", tt.policy, got, tt.want)
+		}
+	}
+}
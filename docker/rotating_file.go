@@ -0,0 +1,118 @@
+package docker
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	pkgError "github.com/pkg/errors"
+)
+
+//fileRotations is the number of rotated files kept
+const fileRotations = 3
+
+//rotatingFile appends lines to a file. Once the file reaches its max size it
+//is rotated, the rotated files are named as the file plus a number, the higher
+//the number the older the lines.
+type rotatingFile struct {
+	//name describes the content of the file on errors
+	name    string
+	path    string
+	maxSize int64
+	file    *os.File
+	size    int64
+	sync.Mutex
+}
+
+func newRotatingFile(name, path string, maxSize int64) (*rotatingFile, error) {
+	if maxSize <= 0 {
+		return nil, fmt.Errorf("invalid %s file size: %d", name, maxSize)
+	}
+	f := &rotatingFile{name: name, path: path, maxSize: maxSize}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+//write appends the given lines to the file, rotating it if needed, lines
+//written together are never split between files
+func (f *rotatingFile) write(lines []byte) error {
+	f.Lock()
+	defer f.Unlock()
+	if f.file == nil {
+		return fmt.Errorf("%s file %s is closed", f.name, f.path)
+	}
+	if f.size > 0 && f.size+int64(len(lines)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := f.file.Write(lines)
+	f.size += int64(n)
+	if err != nil {
+		return pkgError.Wrapf(err, "Error writing %s file", f.name)
+	}
+	return nil
+}
+
+func (f *rotatingFile) close() error {
+	f.Lock()
+	defer f.Unlock()
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return pkgError.Wrapf(err, "Error opening %s file", f.name)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return pkgError.Wrapf(err, "Error opening %s file", f.name)
+	}
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+//rotate moves the current file to the first rotated file, shifting
+//the rest and dropping the oldest one, and opens a new file
+func (f *rotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return pkgError.Wrapf(err, "Error rotating %s file", f.name)
+	}
+	f.file = nil
+	for i := fileRotations - 1; i > 0; i-- {
+		os.Rename(rotatedFile(f.path, i), rotatedFile(f.path, i+1))
+	}
+	if err := os.Rename(f.path, rotatedFile(f.path, 1)); err != nil {
+		return pkgError.Wrapf(err, "Error rotating %s file", f.name)
+	}
+	return f.open()
+}
+
+func rotatedFile(path string, i int) string {
+	return fmt.Sprintf("%s.%d", path, i)
+}
+
+//rotatedFiles returns the existing files of the given rotating file, the
+//oldest one first
+func rotatedFiles(path string) []string {
+	var files []string
+	for i := fileRotations; i > 0; i-- {
+		if _, err := os.Stat(rotatedFile(path, i)); err == nil {
+			files = append(files, rotatedFile(path, i))
+		}
+	}
+	if _, err := os.Stat(path); err == nil {
+		files = append(files, path)
+	}
+	return files
+}
@@ -0,0 +1,237 @@
+package docker
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+)
+
+//unquotedArg matches the arguments that need no quoting on a shell
+var unquotedArg = regexp.MustCompile(`^[a-zA-Z0-9_@%+=:,./-]+$`)
+
+//anonymousVolume matches the names Docker gives to anonymous volumes
+var anonymousVolume = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+//RunCommand returns the docker run command that creates a container like
+//the given one: name, ports, environment, mounts, restart policy, networks
+//and the like. Given the config of the container image, the settings the
+//container inherits from its image are left out.
+func RunCommand(c types.ContainerJSON, image *container.Config) string {
+	if image == nil {
+		image = &container.Config{}
+	}
+	args := [][]string{{"docker", "run", "-d"}}
+	add := func(arg ...string) {
+		args = append(args, arg)
+	}
+	if c.Name != "" {
+		add("--name", strings.TrimPrefix(c.Name, "/"))
+	}
+	if c.HostConfig != nil {
+		hc := c.HostConfig
+		if !hc.RestartPolicy.IsNone() && hc.RestartPolicy.Name != "" {
+			add("--restart", FormatRestartPolicy(hc.RestartPolicy))
+		}
+		if hc.AutoRemove {
+			add("--rm")
+		}
+		if hc.Privileged {
+			add("--privileged")
+		}
+		for _, p := range portFlags(hc) {
+			add("-p", p)
+		}
+		if hc.PublishAllPorts {
+			add("-P")
+		}
+		for _, cap := range hc.CapAdd {
+			add("--cap-add", cap)
+		}
+		for _, cap := range hc.CapDrop {
+			add("--cap-drop", cap)
+		}
+		for _, host := range hc.ExtraHosts {
+			add("--add-host", host)
+		}
+		if hc.Memory > 0 {
+			add("--memory", fmt.Sprintf("%d", hc.Memory))
+		}
+		if hc.NanoCPUs > 0 {
+			add("--cpus", fmt.Sprintf("%g", float64(hc.NanoCPUs)/1e9))
+		}
+		if hc.LogConfig.Type != "" && hc.LogConfig.Type != "json-file" {
+			add("--log-driver", hc.LogConfig.Type)
+		}
+	}
+	for _, network := range networkFlags(c) {
+		add("--network", network)
+	}
+	for _, m := range mountFlags(c) {
+		add(m...)
+	}
+	if c.Config != nil {
+		cfg := c.Config
+		if cfg.User != "" && cfg.User != image.User {
+			add("-u", cfg.User)
+		}
+		if cfg.WorkingDir != "" && cfg.WorkingDir != image.WorkingDir {
+			add("-w", cfg.WorkingDir)
+		}
+		if cfg.Tty {
+			add("-t")
+		}
+		if cfg.OpenStdin {
+			add("-i")
+		}
+		for _, env := range notInherited(cfg.Env, image.Env) {
+			add("-e", env)
+		}
+		var labels []string
+		for k, v := range cfg.Labels {
+			if inherited, ok := image.Labels[k]; !ok || inherited != v {
+				labels = append(labels, k+"="+v)
+			}
+		}
+		sort.Strings(labels)
+		for _, label := range labels {
+			add("-l", label)
+		}
+		entrypoint := !equalArgs(cfg.Entrypoint, image.Entrypoint)
+		if entrypoint && len(cfg.Entrypoint) > 0 {
+			add("--entrypoint", cfg.Entrypoint[0])
+		}
+		imageArgs := []string{cfg.Image}
+		if entrypoint && len(cfg.Entrypoint) > 1 {
+			imageArgs = append(imageArgs, cfg.Entrypoint[1:]...)
+		}
+		if entrypoint || !equalArgs(cfg.Cmd, image.Cmd) {
+			imageArgs = append(imageArgs, cfg.Cmd...)
+		}
+		add(imageArgs...)
+	}
+
+	lines := make([]string, len(args))
+	for i, arg := range args {
+		lines[i] = joinArgs(arg)
+	}
+	return strings.Join(lines, " \\\n  ")
+}
+
+//portFlags returns the published ports of the given host config as the
+//docker run -p flag expects them, sorted
+func portFlags(hc *container.HostConfig) []string {
+	var ports []string
+	for port, bindings := range hc.PortBindings {
+		for _, b := range bindings {
+			p := port.Port()
+			if port.Proto() != "tcp" {
+				p += "/" + port.Proto()
+			}
+			switch {
+			case b.HostIP != "":
+				p = b.HostIP + ":" + b.HostPort + ":" + p
+			case b.HostPort != "":
+				p = b.HostPort + ":" + p
+			}
+			ports = append(ports, p)
+		}
+	}
+	sort.Strings(ports)
+	return ports
+}
+
+//networkFlags returns the networks of the given container as the docker run
+//--network flag expects them, the network the container was created on first
+func networkFlags(c types.ContainerJSON) []string {
+	var mode string
+	if c.HostConfig != nil {
+		mode = string(c.HostConfig.NetworkMode)
+	}
+	var networks []string
+	if mode != "" && mode != "default" && mode != "bridge" {
+		networks = append(networks, mode)
+	}
+	if c.NetworkSettings == nil {
+		return networks
+	}
+	var others []string
+	for name := range c.NetworkSettings.Networks {
+		if name != mode && name != "bridge" {
+			others = append(others, name)
+		}
+	}
+	sort.Strings(others)
+	return append(networks, others...)
+}
+
+//mountFlags returns the mounts of the given container as docker run flags,
+//anonymous volumes are left out as docker run creates them
+func mountFlags(c types.ContainerJSON) [][]string {
+	var flags [][]string
+	for _, m := range c.Mounts {
+		var source string
+		switch m.Type {
+		case mount.TypeBind:
+			source = m.Source
+		case mount.TypeVolume:
+			if anonymousVolume.MatchString(m.Name) {
+				continue
+			}
+			source = m.Name
+		case mount.TypeTmpfs:
+			flags = append(flags, []string{"--tmpfs", m.Destination})
+			continue
+		default:
+			continue
+		}
+		v := source + ":" + m.Destination
+		if !m.RW {
+			v += ":ro"
+		}
+		flags = append(flags, []string{"-v", v})
+	}
+	return flags
+}
+
+//notInherited returns the values not found on the inherited ones
+func notInherited(values, inherited []string) []string {
+	var result []string
+	for _, v := range values {
+		found := false
+		for _, i := range inherited {
+			if v == i {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func equalArgs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+//shellQuote quotes the given argument for a POSIX shell, if needed
+func shellQuote(arg string) string {
+	if unquotedArg.MatchString(arg) {
+		return arg
+	}
+	return "'" + strings.Replace(arg, "'", `'"'"'`, -1) + "'"
+}
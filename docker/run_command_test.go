@@ -0,0 +1,67 @@
+package docker
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+)
+
+func TestRunCommand(t *testing.T) {
+	c := types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{
+			Name: "/web",
+			HostConfig: &container.HostConfig{
+				NetworkMode:   "backend",
+				RestartPolicy: container.RestartPolicy{Name: "on-failure", MaximumRetryCount: 3},
+				PortBindings: nat.PortMap{
+					"80/tcp": []nat.PortBinding{{HostPort: "8080"}},
+					"53/udp": []nat.PortBinding{{HostIP: "127.0.0.1", HostPort: "5353"}},
+				},
+			},
+		},
+		Mounts: []types.MountPoint{
+			{Type: mount.TypeBind, Source: "/srv/conf", Destination: "/etc/nginx", RW: false},
+			{Type: mount.TypeVolume, Name: "data", Destination: "/data", RW: true},
+			{Type: mount.TypeVolume, Name: strings.Repeat("ab", 32), Destination: "/cache", RW: true},
+		},
+		Config: &container.Config{
+			Image:  "nginx:1.25",
+			Env:    []string{"PATH=/usr/bin", "GREETING=hello world"},
+			Labels: map[string]string{"maintainer": "nginx", "team": "web"},
+			Cmd:    []string{"nginx", "-g", "daemon off;"},
+		},
+		NetworkSettings: &types.NetworkSettings{
+			Networks: map[string]*network.EndpointSettings{"backend": {}, "frontend": {}},
+		},
+	}
+	image := &container.Config{
+		Env:    []string{"PATH=/usr/bin"},
+		Labels: map[string]string{"maintainer": "nginx"},
+		Cmd:    []string{"nginx", "-g", "daemon off;"},
+	}
+	expected := `docker run -d \
+  --name web \
+  --restart on-failure:3 \
+  -p 127.0.0.1:5353:53/udp \
+  -p 8080:80 \
+  --network backend \
+  --network frontend \
+  -v /srv/conf:/etc/nginx:ro \
+  -v data:/data \
+  -e 'GREETING=hello world' \
+  -l team=web \
+  nginx:1.25`
+	if cmd := RunCommand(c, image); cmd != expected {
+		t.Errorf("Unexpected run command, got:\n%s\nexpected:\n%s", cmd, expected)
+	}
+
+	c.Config.Cmd = []string{"nginx", "-g", "daemon off;", "-c", "/etc/nginx/it's.conf"}
+	if cmd := RunCommand(c, image); !strings.HasSuffix(cmd, `nginx:1.25 nginx -g 'daemon off;' -c '/etc/nginx/it'"'"'s.conf'`) {
+		t.Errorf("Command not given as it differs from the image one: %s", cmd)
+	}
+}
@@ -0,0 +1,111 @@
+package docker
+
+import (
+	"fmt"
+	"strings"
+)
+
+//RunOptions are the options to run a container from an image
+type RunOptions struct {
+	Name string
+	//Ports to publish, as the docker run -p flag expects them
+	Ports []string
+	//Env are the environment variables, as KEY=VALUE
+	Env []string
+	//Command overrides the command of the image, if given
+	Command []string
+	//Interactive keeps stdin open and allocates a TTY, so the container
+	//can be attached to
+	Interactive bool
+}
+
+//ParseRunOptions parses the given options, given as docker run does:
+//[--name NAME] [-p HOST:CONTAINER]... [-e KEY=VALUE]... [-d|-it] [COMMAND [ARG...]]
+//Single and double quotes group words as a shell does.
+func ParseRunOptions(s string) (RunOptions, error) {
+	var opts RunOptions
+	args, err := splitArgs(s)
+	if err != nil {
+		return opts, err
+	}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			opts.Command = args[i+1:]
+			break
+		}
+		if !strings.HasPrefix(arg, "-") {
+			opts.Command = args[i:]
+			break
+		}
+		flag, value, hasValue := arg, "", false
+		if j := strings.Index(arg, "="); j > 0 && strings.HasPrefix(arg, "--") {
+			flag, value, hasValue = arg[:j], arg[j+1:], true
+		}
+		switch flag {
+		case "-d", "--detach":
+		case "-i", "-t", "-it", "-ti", "--interactive", "--tty":
+			opts.Interactive = true
+		case "--name", "-p", "--publish", "-e", "--env":
+			if !hasValue {
+				if i+1 == len(args) {
+					return opts, fmt.Errorf("flag %s needs a value", flag)
+				}
+				i++
+				value = args[i]
+			}
+			switch flag {
+			case "--name":
+				opts.Name = value
+			case "-p", "--publish":
+				opts.Ports = append(opts.Ports, value)
+			default:
+				if !strings.Contains(value, "=") {
+					return opts, fmt.Errorf("invalid environment variable %q, expected KEY=VALUE", value)
+				}
+				opts.Env = append(opts.Env, value)
+			}
+		default:
+			return opts, fmt.Errorf("unknown flag: %s", arg)
+		}
+	}
+	return opts, nil
+}
+
+//splitArgs splits the given text on whitespace, single and double quotes
+//group words
+func splitArgs(s string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+	var quote rune
+	for _, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote: %q", s)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
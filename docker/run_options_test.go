@@ -0,0 +1,56 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRunOptions(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    RunOptions
+		wantErr bool
+	}{
+		{"", RunOptions{}, false},
+		{"-d", RunOptions{}, false},
+		{
+			`--name web -p 8080:80 --publish=8443:443 -e GREETING="hello world" -it sh -c 'echo $GREETING'`,
+			RunOptions{
+				Name:        "web",
+				Ports:       []string{"8080:80", "8443:443"},
+				Env:         []string{"GREETING=hello world"},
+				Command:     []string{"sh", "-c", "echo $GREETING"},
+				Interactive: true,
+			},
+			false,
+		},
+		{"-- -v", RunOptions{Command: []string{"-v"}}, false},
+		{"--name", RunOptions{}, true},
+		{"-e GREETING", RunOptions{}, true},
+		{"--rm", RunOptions{}, true},
+		{"echo 'unterminated", RunOptions{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseRunOptions(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseRunOptions(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseRunOptions(%q) = %+v, want %+v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestContainerConfigBuilderPublish(t *testing.T) {
+	cc, hc, err := newCCB().image("nginx").publish([]string{"127.0.0.1:8080:80", "53/udp"}).build()
+	if err != nil {
+		t.Fatalf("Unexpected error publishing ports: %s", err)
+	}
+	if len(cc.ExposedPorts) != 2 || len(hc.PortBindings["80/tcp"]) != 1 || hc.PortBindings["80/tcp"][0].HostIP != "127.0.0.1" {
+		t.Errorf("Unexpected ports published: %v, %v", cc.ExposedPorts, hc.PortBindings)
+	}
+	if _, _, err := newCCB().publish([]string{"http"}).build(); err == nil {
+		t.Error("Expected an error publishing an invalid port")
+	}
+}
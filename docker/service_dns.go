@@ -0,0 +1,313 @@
+package docker
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/docker/docker/pkg/stdcopy"
+	pkgError "github.com/pkg/errors"
+)
+
+const (
+	//dnsToolImage is the image used by the helper task to run DNS lookups
+	dnsToolImage = "busybox:latest"
+	//dnsToolLabel labels helper services with the name of the service being resolved
+	dnsToolLabel = "dry.dns-lookup"
+	//dnsLookupTimeout is how long to wait for the helper task, it includes
+	//pulling the image
+	dnsLookupTimeout = 2 * time.Minute
+	//dnsLookupMarker separates the output of each lookup made by the helper task
+	dnsLookupMarker = "### "
+)
+
+//dnsLookupScript looks up every name given as argument
+const dnsLookupScript = `for n in "$@"; do echo "` + dnsLookupMarker + `$n"; nslookup "$n" 2>&1; done`
+
+//ServiceDNSReport is the result of resolving the names of a service from
+//within one of the networks the service is attached to
+type ServiceDNSReport struct {
+	Service string
+	Network string
+	Mode    swarm.ResolutionMode
+	//VIPs are the virtual IPs of the service on the network
+	VIPs []string
+	//TaskIPs are the addresses of the running tasks of the service on the network
+	TaskIPs []string
+	Lookups []DNSLookup
+}
+
+//DNSLookup is the result of looking up a name
+type DNSLookup struct {
+	Name      string
+	Addresses []string
+	Expected  []string
+	Error     string
+}
+
+//Missing returns the expected addresses that were not resolved
+func (l DNSLookup) Missing() []string {
+	return difference(l.Expected, l.Addresses)
+}
+
+//Unexpected returns the resolved addresses that were not expected
+func (l DNSLookup) Unexpected() []string {
+	return difference(l.Addresses, l.Expected)
+}
+
+//OK returns true if the name resolves to the expected addresses
+func (l DNSLookup) OK() bool {
+	return len(l.Missing()) == 0 && len(l.Unexpected()) == 0
+}
+
+//ServiceDNSLookup resolves the service name and the tasks.<service name>
+//name from a helper task attached to the given network, which must be one
+//of the networks of the service. If no network is given the first network of
+//the service is used. The helper service is removed once done.
+func (daemon *DockerDaemon) ServiceDNSLookup(serviceID, networkID string) (*ServiceDNSReport, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dnsLookupTimeout)
+	defer cancel()
+	service, _, err := daemon.client.ServiceInspectWithRaw(ctx, serviceID, types.ServiceInspectOptions{})
+	if err != nil {
+		return nil, pkgError.Wrapf(err, "Error retrieving service with id %s", serviceID)
+	}
+	networkID, err = serviceNetwork(service, networkID)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := daemon.client.TaskList(ctx, types.TaskListOptions{
+		Filters: filters.NewArgs(
+			filters.Arg("service", service.ID),
+			filters.Arg("desired-state", string(swarm.TaskStateRunning)))})
+	if err != nil {
+		return nil, pkgError.Wrap(err, "Error retrieving service tasks")
+	}
+
+	report := newServiceDNSReport(service, networkID, tasks)
+	names := []string{service.Spec.Name, "tasks." + service.Spec.Name}
+	output, err := daemon.runDNSLookups(ctx, service.Spec.Name, networkID, names)
+	if err != nil {
+		return nil, err
+	}
+	lookups := parseDNSLookups(output)
+	for _, name := range names {
+		lookup := lookups[name]
+		lookup.Name = name
+		lookup.Expected = report.expected(name)
+		report.Lookups = append(report.Lookups, lookup)
+	}
+	return report, nil
+}
+
+//runDNSLookups runs a helper service, with a single task, that looks up the
+//given names from the given network, returns the output of the lookups
+func (daemon *DockerDaemon) runDNSLookups(ctx context.Context, service, networkID string, names []string) (string, error) {
+	replicas := uint64(1)
+	spec := swarm.ServiceSpec{
+		Annotations: swarm.Annotations{
+			Name:   "dry-dns-lookup-" + service,
+			Labels: map[string]string{dnsToolLabel: service},
+		},
+		TaskTemplate: swarm.TaskSpec{
+			ContainerSpec: &swarm.ContainerSpec{
+				Image: dnsToolImage,
+				Args:  append([]string{"sh", "-c", dnsLookupScript, "dry"}, names...),
+			},
+			RestartPolicy: &swarm.RestartPolicy{Condition: swarm.RestartPolicyConditionNone},
+			Networks:      []swarm.NetworkAttachmentConfig{{Target: networkID}},
+		},
+		Mode: swarm.ServiceMode{Replicated: &swarm.ReplicatedService{Replicas: &replicas}},
+	}
+	created, err := daemon.client.ServiceCreate(ctx, spec, types.ServiceCreateOptions{})
+	if err != nil {
+		return "", pkgError.Wrap(err, "Error creating DNS lookup helper service")
+	}
+	defer func() {
+		//The lookup context might be done already
+		ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+		defer cancel()
+		daemon.client.ServiceRemove(ctx, created.ID)
+	}()
+
+	if err := daemon.waitForHelperTask(ctx, created.ID); err != nil {
+		return "", err
+	}
+	logs, err := daemon.client.ServiceLogs(ctx, created.ID, types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+	})
+	if err != nil {
+		return "", pkgError.Wrap(err, "Error retrieving DNS lookup results")
+	}
+	defer logs.Close()
+	var output bytes.Buffer
+	if _, err := stdcopy.StdCopy(&output, &output, logs); err != nil {
+		return "", pkgError.Wrap(err, "Error reading DNS lookup results")
+	}
+	return output.String(), nil
+}
+
+//waitForHelperTask waits until the task of the given helper service is done
+func (daemon *DockerDaemon) waitForHelperTask(ctx context.Context, serviceID string) error {
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		tasks, err := daemon.client.TaskList(ctx, types.TaskListOptions{
+			Filters: filters.NewArgs(filters.Arg("service", serviceID))})
+		if err != nil {
+			return pkgError.Wrap(err, "Error retrieving DNS lookup helper task")
+		}
+		for _, task := range tasks {
+			switch task.Status.State {
+			case swarm.TaskStateComplete:
+				return nil
+			case swarm.TaskStateFailed, swarm.TaskStateRejected:
+				return fmt.Errorf("DNS lookup helper task %s: %s", task.Status.State, task.Status.Err)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return errors.New("timed out waiting for the DNS lookup helper task")
+		case <-ticker.C:
+		}
+	}
+}
+
+//serviceNetwork returns the given network if the service is attached to it,
+//or the first network of the service if no network is given
+func serviceNetwork(service swarm.Service, networkID string) (string, error) {
+	networks := service.Spec.TaskTemplate.Networks
+	if len(networks) == 0 {
+		networks = service.Spec.Networks
+	}
+	if len(networks) == 0 {
+		return "", fmt.Errorf("service %s is not attached to any network", service.Spec.Name)
+	}
+	if networkID == "" {
+		return networks[0].Target, nil
+	}
+	for _, n := range networks {
+		if n.Target == networkID {
+			return networkID, nil
+		}
+	}
+	return "", fmt.Errorf("service %s is not attached to network %s", service.Spec.Name, networkID)
+}
+
+func newServiceDNSReport(service swarm.Service, networkID string, tasks []swarm.Task) *ServiceDNSReport {
+	report := &ServiceDNSReport{
+		Service: service.Spec.Name,
+		Network: networkID,
+		Mode:    swarm.ResolutionModeVIP,
+	}
+	if service.Spec.EndpointSpec != nil && service.Spec.EndpointSpec.Mode != "" {
+		report.Mode = service.Spec.EndpointSpec.Mode
+	}
+	for _, vip := range service.Endpoint.VirtualIPs {
+		if vip.NetworkID == networkID {
+			report.VIPs = append(report.VIPs, stripPrefixLength(vip.Addr))
+		}
+	}
+	for _, task := range tasks {
+		if task.Status.State != swarm.TaskStateRunning {
+			continue
+		}
+		for _, attachment := range task.NetworksAttachments {
+			if attachment.Network.ID != networkID {
+				continue
+			}
+			for _, addr := range attachment.Addresses {
+				report.TaskIPs = append(report.TaskIPs, stripPrefixLength(addr))
+			}
+		}
+	}
+	sort.Strings(report.VIPs)
+	sort.Strings(report.TaskIPs)
+	return report
+}
+
+//expected returns the addresses the given name is expected to resolve to,
+//the service name resolves to its VIPs unless DNS round-robin is used
+func (r *ServiceDNSReport) expected(name string) []string {
+	if name == r.Service && r.Mode != swarm.ResolutionModeDNSRR {
+		return r.VIPs
+	}
+	return r.TaskIPs
+}
+
+//parseDNSLookups parses the output of the helper task, as written by
+//nslookup, returns the lookups found by name
+func parseDNSLookups(output string) map[string]DNSLookup {
+	lookups := make(map[string]DNSLookup)
+	var current *DNSLookup
+	//answer is true once past the DNS server section of the current lookup
+	answer := false
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, dnsLookupMarker):
+			if current != nil {
+				lookups[current.Name] = *current
+			}
+			current = &DNSLookup{Name: strings.TrimPrefix(line, dnsLookupMarker)}
+			answer = false
+		case current == nil:
+		case strings.HasPrefix(line, "Name:"):
+			answer = true
+		case strings.HasPrefix(line, "Address") && answer:
+			//Either "Address: 10.0.0.2" or "Address 1: 10.0.0.2 name"
+			if i := strings.Index(line, ":"); i >= 0 {
+				if fields := strings.Fields(line[i+1:]); len(fields) > 0 {
+					current.Addresses = append(current.Addresses, fields[0])
+				}
+			}
+		case strings.HasPrefix(line, "*** Can't find"),
+			strings.HasPrefix(line, "** server can't find"),
+			strings.HasPrefix(line, "nslookup:"):
+			current.Error = line
+		}
+	}
+	if current != nil {
+		lookups[current.Name] = *current
+	}
+	for name, lookup := range lookups {
+		if len(lookup.Addresses) > 0 {
+			//Lookups of AAAA records fail for services, only IPv4 is used
+			lookup.Error = ""
+		}
+		sort.Strings(lookup.Addresses)
+		lookups[name] = lookup
+	}
+	return lookups
+}
+
+func stripPrefixLength(addr string) string {
+	if i := strings.Index(addr, "/"); i >= 0 {
+		return addr[:i]
+	}
+	return addr
+}
+
+//difference returns the elements of a that are not in b
+func difference(a, b []string) []string {
+	in := make(map[string]bool, len(b))
+	for _, s := range b {
+		in[s] = true
+	}
+	var diff []string
+	for _, s := range a {
+		if !in[s] {
+			diff = append(diff, s)
+		}
+	}
+	return diff
+}
@@ -0,0 +1,84 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types/swarm"
+)
+
+const nslookupOutput = `### web
+Server:		127.0.0.11
+Address:	127.0.0.11:53
+
+Non-authoritative answer:
+Name:	web
+Address: 10.0.1.2
+
+*** Can't find web: No answer
+
+### tasks.web
+Server:    127.0.0.11
+Address 1: 127.0.0.11
+
+Name:      tasks.web
+Address 1: 10.0.1.4 web.1.abc.net
+Address 2: 10.0.1.3 web.2.def.net
+### tasks.db
+Server:		127.0.0.11
+Address:	127.0.0.11:53
+
+** server can't find tasks.db: NXDOMAIN
+`
+
+func TestParseDNSLookups(t *testing.T) {
+	lookups := parseDNSLookups(nslookupOutput)
+	tests := []DNSLookup{
+		{Name: "web", Addresses: []string{"10.0.1.2"}},
+		{Name: "tasks.web", Addresses: []string{"10.0.1.3", "10.0.1.4"}},
+		{Name: "tasks.db", Error: "** server can't find tasks.db: NXDOMAIN"},
+	}
+	for _, want := range tests {
+		if got := lookups[want.Name]; !reflect.DeepEqual(got, want) {
+			t.Errorf("Unexpected lookup of %s, got %+v, want %+v", want.Name, got, want)
+		}
+	}
+}
+
+func TestServiceDNSReport_Expected(t *testing.T) {
+	service := swarm.Service{
+		Spec: swarm.ServiceSpec{Annotations: swarm.Annotations{Name: "web"}},
+		Endpoint: swarm.Endpoint{VirtualIPs: []swarm.EndpointVirtualIP{
+			{NetworkID: "net1", Addr: "10.0.1.2/24"},
+			{NetworkID: "net2", Addr: "10.0.2.2/24"},
+		}},
+	}
+	tasks := []swarm.Task{{
+		Status: swarm.TaskStatus{State: swarm.TaskStateRunning},
+		NetworksAttachments: []swarm.NetworkAttachment{
+			{Network: swarm.Network{ID: "net1"}, Addresses: []string{"10.0.1.3/24"}},
+			{Network: swarm.Network{ID: "net2"}, Addresses: []string{"10.0.2.3/24"}},
+		},
+	}}
+	report := newServiceDNSReport(service, "net1", tasks)
+	if got := report.expected("web"); !reflect.DeepEqual(got, []string{"10.0.1.2"}) {
+		t.Errorf("Unexpected addresses for the service name: %v", got)
+	}
+	if got := report.expected("tasks.web"); !reflect.DeepEqual(got, []string{"10.0.1.3"}) {
+		t.Errorf("Unexpected addresses for the tasks name: %v", got)
+	}
+
+	service.Spec.EndpointSpec = &swarm.EndpointSpec{Mode: swarm.ResolutionModeDNSRR}
+	report = newServiceDNSReport(service, "net1", tasks)
+	if got := report.expected("web"); !reflect.DeepEqual(got, []string{"10.0.1.3"}) {
+		t.Errorf("Unexpected addresses for the service name using DNSRR: %v", got)
+	}
+
+	lookup := DNSLookup{Addresses: []string{"10.0.1.3", "10.0.1.9"}, Expected: []string{"10.0.1.3", "10.0.1.4"}}
+	if lookup.OK() {
+		t.Error("Lookup with missing and unexpected addresses is OK")
+	}
+	if !reflect.DeepEqual(lookup.Missing(), []string{"10.0.1.4"}) || !reflect.DeepEqual(lookup.Unexpected(), []string{"10.0.1.9"}) {
+		t.Errorf("Unexpected lookup differences, missing: %v, unexpected: %v", lookup.Missing(), lookup.Unexpected())
+	}
+}
@@ -0,0 +1,194 @@
+package docker
+
+import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+const (
+	taskIDAttr = "com.docker.swarm.task.id"
+	nodeIDAttr = "com.docker.swarm.node.id"
+)
+
+//taskColors are the ANSI colors the prefixes of the lines of each task are
+//shown with
+var taskColors = []int{32, 33, 34, 35, 36, 91, 92, 93, 94, 95, 96}
+
+//ServiceLogsPrefixer prefixes the lines of the logs of a service with the
+//slot of the task and the name of the node they come from
+type ServiceLogsPrefixer struct {
+	service string
+	tasks   map[string]swarm.Task
+	nodes   map[string]string
+}
+
+//NewServiceLogsPrefixer creates a ServiceLogsPrefixer for the logs of the
+//given service, made of the given tasks, that run on the given nodes
+func NewServiceLogsPrefixer(service string, tasks []swarm.Task, nodes []swarm.Node) *ServiceLogsPrefixer {
+	p := &ServiceLogsPrefixer{
+		service: service,
+		tasks:   make(map[string]swarm.Task),
+		nodes:   make(map[string]string),
+	}
+	for _, task := range tasks {
+		p.tasks[task.ID] = task
+	}
+	for _, node := range nodes {
+		p.nodes[node.ID] = node.Description.Hostname
+	}
+	return p
+}
+
+//Prefix returns the given logs of the service, requested with details, with
+//each line prefixed, color-coded by task, with its task and node instead of
+//the details. If a task is given, by slot or id, only its lines are kept.
+func (p *ServiceLogsPrefixer) Prefix(logs io.ReadCloser, task string) io.ReadCloser {
+	r, w := io.Pipe()
+	stdout := &prefixWriter{prefixer: p, task: task, w: stdcopy.NewStdWriter(w, stdcopy.Stdout)}
+	stderr := &prefixWriter{prefixer: p, task: task, w: stdcopy.NewStdWriter(w, stdcopy.Stderr)}
+	go func() {
+		_, err := stdcopy.StdCopy(stdout, stderr, logs)
+		stdout.flush()
+		stderr.flush()
+		w.CloseWithError(err)
+	}()
+	return &prefixedLogs{PipeReader: r, logs: logs}
+}
+
+//prefixLine returns the given line prefixed with its task and node, false
+//if the line is not from the given task
+func (p *ServiceLogsPrefixer) prefixLine(line, task string) (string, bool) {
+	var timestamp string
+	if i := strings.IndexByte(line, ' '); i > 0 {
+		if _, err := time.Parse(time.RFC3339Nano, line[:i]); err == nil {
+			timestamp, line = line[:i+1], line[i+1:]
+		}
+	}
+	attrs, msg := line, ""
+	if i := strings.IndexByte(line, ' '); i >= 0 {
+		attrs, msg = line[:i], line[i+1:]
+	}
+	details := parseLogDetails(attrs)
+	taskID, ok := details[taskIDAttr]
+	if !ok {
+		//not a line with details, kept as is
+		return timestamp + line, task == ""
+	}
+	t := p.tasks[taskID]
+	if task != "" && task != strconv.Itoa(t.Slot) && !strings.HasPrefix(taskID, task) {
+		return "", false
+	}
+	nodeID := details[nodeIDAttr]
+	if t.NodeID != "" {
+		nodeID = t.NodeID
+	}
+	node, ok := p.nodes[nodeID]
+	if !ok || node == "" {
+		node = shortID(nodeID)
+	}
+	name := fmt.Sprintf("%s.%s", p.service, shortID(taskID))
+	if t.Slot > 0 {
+		name = fmt.Sprintf("%s.%d", p.service, t.Slot)
+	}
+	return fmt.Sprintf("%s\x1b[%dm%s@%s\x1b[0m | %s", timestamp, taskColor(taskID, t.Slot), name, node, msg), true
+}
+
+//parseLogDetails parses the details of a log line, given as comma separated
+//key=value pairs with escaped keys and values
+func parseLogDetails(s string) map[string]string {
+	details := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		i := strings.IndexByte(pair, '=')
+		if i < 0 {
+			continue
+		}
+		key, err := url.QueryUnescape(pair[:i])
+		if err != nil {
+			continue
+		}
+		value, err := url.QueryUnescape(pair[i+1:])
+		if err != nil {
+			continue
+		}
+		details[key] = value
+	}
+	return details
+}
+
+//taskColor returns the ANSI color of the task with the given id and slot,
+//tasks of replicated services are colored by slot, so the color of a slot
+//does not change when its task is replaced
+func taskColor(taskID string, slot int) int {
+	if slot > 0 {
+		return taskColors[(slot-1)%len(taskColors)]
+	}
+	h := fnv.New32a()
+	h.Write([]byte(taskID))
+	return taskColors[h.Sum32()%uint32(len(taskColors))]
+}
+
+func shortID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
+
+//prefixWriter prefixes every complete line written to it
+type prefixWriter struct {
+	prefixer *ServiceLogsPrefixer
+	task     string
+	w        io.Writer
+	buf      bytes.Buffer
+}
+
+func (pw *prefixWriter) Write(b []byte) (int, error) {
+	pw.buf.Write(b)
+	for {
+		i := bytes.IndexByte(pw.buf.Bytes(), '\n')
+		if i < 0 {
+			return len(b), nil
+		}
+		line := string(pw.buf.Next(i + 1))
+		if err := pw.writeLine(line[:len(line)-1]); err != nil {
+			return 0, err
+		}
+	}
+}
+
+//flush writes what is left of the last line, if anything
+func (pw *prefixWriter) flush() {
+	if pw.buf.Len() > 0 {
+		pw.writeLine(pw.buf.String())
+		pw.buf.Reset()
+	}
+}
+
+func (pw *prefixWriter) writeLine(line string) error {
+	prefixed, ok := pw.prefixer.prefixLine(line, pw.task)
+	if !ok {
+		return nil
+	}
+	_, err := io.WriteString(pw.w, prefixed+"\n")
+	return err
+}
+
+//prefixedLogs closes the logs being prefixed when closed
+type prefixedLogs struct {
+	*io.PipeReader
+	logs io.Closer
+}
+
+func (l *prefixedLogs) Close() error {
+	l.PipeReader.Close()
+	return l.logs.Close()
+}
@@ -0,0 +1,60 @@
+package docker
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+func TestServiceLogsPrefixer(t *testing.T) {
+	tasks := []swarm.Task{
+		{ID: "task1", Slot: 1, NodeID: "node1"},
+		{ID: "task2", Slot: 2, NodeID: "node2"},
+	}
+	nodes := []swarm.Node{
+		{ID: "node1", Description: swarm.NodeDescription{Hostname: "worker1"}},
+	}
+	p := NewServiceLogsPrefixer("web", tasks, nodes)
+
+	logs := func() *bytes.Buffer {
+		b := new(bytes.Buffer)
+		stdout := stdcopy.NewStdWriter(b, stdcopy.Stdout)
+		stderr := stdcopy.NewStdWriter(b, stdcopy.Stderr)
+		stdout.Write([]byte("com.docker.swarm.node.id=node1,com.docker.swarm.service.id=s,com.docker.swarm.task.id=task1 hello\n"))
+		stderr.Write([]byte("2026-10-17T07:48:17.000000000Z com.docker.swarm.node.id=node2,com.docker.swarm.service.id=s,com.docker.swarm.task.id=task2 oops\n"))
+		return b
+	}
+
+	tests := []struct {
+		task           string
+		stdout, stderr string
+	}{
+		{
+			"",
+			"\x1b[32mweb.1@worker1\x1b[0m | hello\n",
+			"2026-10-17T07:48:17.000000000Z \x1b[33mweb.2@node2\x1b[0m | oops\n",
+		},
+		{"2", "", "2026-10-17T07:48:17.000000000Z \x1b[33mweb.2@node2\x1b[0m | oops\n"},
+		{"task1", "\x1b[32mweb.1@worker1\x1b[0m | hello\n", ""},
+	}
+	for _, tt := range tests {
+		t.Run("task "+tt.task, func(t *testing.T) {
+			prefixed := p.Prefix(ioutil.NopCloser(logs()), tt.task)
+			defer prefixed.Close()
+			var stdout, stderr strings.Builder
+			if _, err := stdcopy.StdCopy(&stdout, &stderr, prefixed); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if stdout.String() != tt.stdout {
+				t.Errorf("Unexpected stdout, got %q, expected %q", stdout.String(), tt.stdout)
+			}
+			if stderr.String() != tt.stderr {
+				t.Errorf("Unexpected stderr, got %q, expected %q", stderr.String(), tt.stderr)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package docker
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/events"
+)
+
+//maxReplicaChanges is the number of replica changes kept for each service
+const maxReplicaChanges = 100
+
+//ReplicaChange is a change of the number of replicas of a service
+type ReplicaChange struct {
+	Time time.Time
+	Old  uint64
+	New  uint64
+}
+
+//ReplicaHistory keeps the replica changes of services seen on service
+//update events
+type ReplicaHistory struct {
+	changes map[string][]ReplicaChange
+	sync.RWMutex
+}
+
+//NewReplicaHistory creates an empty ReplicaHistory
+func NewReplicaHistory() *ReplicaHistory {
+	return &ReplicaHistory{changes: make(map[string][]ReplicaChange)}
+}
+
+//Record records the replica change, if any, of the given service event, it
+//can be registered as an EventCallback
+func (h *ReplicaHistory) Record(ctx context.Context, message events.Message) error {
+	if message.Type != events.ServiceEventType || message.Action != "update" {
+		return nil
+	}
+	newReplicas, ok := message.Actor.Attributes["replicas.new"]
+	if !ok {
+		return nil
+	}
+	change := ReplicaChange{Time: time.Unix(0, message.TimeNano)}
+	var err error
+	if change.New, err = strconv.ParseUint(newReplicas, 10, 64); err != nil {
+		return nil
+	}
+	change.Old, _ = strconv.ParseUint(message.Actor.Attributes["replicas.old"], 10, 64)
+
+	h.Lock()
+	defer h.Unlock()
+	changes := append(h.changes[message.Actor.ID], change)
+	if len(changes) > maxReplicaChanges {
+		changes = changes[len(changes)-maxReplicaChanges:]
+	}
+	h.changes[message.Actor.ID] = changes
+	return nil
+}
+
+//Changes returns the replica changes of the service with the given id, oldest first
+func (h *ReplicaHistory) Changes(serviceID string) []ReplicaChange {
+	h.RLock()
+	defer h.RUnlock()
+	changes := make([]ReplicaChange, len(h.changes[serviceID]))
+	copy(changes, h.changes[serviceID])
+	return changes
+}
@@ -0,0 +1,35 @@
+package docker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/api/types/events"
+)
+
+func TestReplicaHistory_Record(t *testing.T) {
+	h := NewReplicaHistory()
+	event := func(action string, attributes map[string]string) events.Message {
+		return events.Message{
+			Type:     events.ServiceEventType,
+			Action:   action,
+			Actor:    events.Actor{ID: "web", Attributes: attributes},
+			TimeNano: 1000,
+		}
+	}
+	h.Record(context.Background(), event("update", map[string]string{"replicas.old": "1", "replicas.new": "3"}))
+	h.Record(context.Background(), event("update", map[string]string{"image.new": "nginx:latest"}))
+	h.Record(context.Background(), event("create", map[string]string{"replicas.new": "1"}))
+	h.Record(context.Background(), event("update", map[string]string{"replicas.old": "3", "replicas.new": "2"}))
+
+	changes := h.Changes("web")
+	if len(changes) != 2 {
+		t.Fatalf("Unexpected number of replica changes, got %d, expected 2", len(changes))
+	}
+	if changes[0].Old != 1 || changes[0].New != 3 || changes[1].New != 2 {
+		t.Errorf("Unexpected replica changes: %v", changes)
+	}
+	if len(h.Changes("db")) != 0 {
+		t.Error("Expected no replica changes for a service without events")
+	}
+}
@@ -0,0 +1,137 @@
+package docker
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+)
+
+//StatsDetails is a breakdown of the stats of a container, as opposed to the
+//aggregated values found on Stats
+type StatsDetails struct {
+	//CPUPerCore is the usage percentage of each core, it is empty if the
+	//daemon does not report per-core usage (i.e. cgroup v2)
+	CPUPerCore     []float64
+	MemoryUsage    uint64
+	MemoryMaxUsage uint64
+	MemoryLimit    uint64
+	MemoryCache    uint64
+	MemoryRSS      uint64
+	Networks       []InterfaceStats
+	BlockIO        []DeviceIO
+	PidsCurrent    uint64
+	PidsLimit      uint64
+}
+
+//InterfaceStats are the network counters of a network interface
+type InterfaceStats struct {
+	Name string
+	types.NetworkStats
+}
+
+//DeviceIO is the I/O done on a block device
+type DeviceIO struct {
+	Major, Minor uint64
+	Read, Write  uint64
+}
+
+//Device returns the device number as major:minor
+func (d DeviceIO) Device() string {
+	return fmt.Sprintf("%d:%d", d.Major, d.Minor)
+}
+
+//NewStatsDetails builds the breakdown of the given stats
+func NewStatsDetails(stats *types.StatsJSON) *StatsDetails {
+	if stats == nil {
+		return &StatsDetails{}
+	}
+	mem := stats.MemoryStats
+	details := &StatsDetails{
+		CPUPerCore:     calculateCPUPercentPerCore(stats),
+		MemoryUsage:    mem.Usage,
+		MemoryMaxUsage: mem.MaxUsage,
+		MemoryLimit:    mem.Limit,
+		MemoryCache:    memoryStat(mem, "cache", "file"),
+		MemoryRSS:      memoryStat(mem, "rss", "anon"),
+		BlockIO:        calculateBlockIOPerDevice(stats.BlkioStats),
+		PidsCurrent:    stats.PidsStats.Current,
+		PidsLimit:      stats.PidsStats.Limit,
+	}
+	for name, network := range stats.Networks {
+		details.Networks = append(details.Networks, InterfaceStats{Name: name, NetworkStats: network})
+	}
+	sort.Slice(details.Networks, func(i, j int) bool {
+		return details.Networks[i].Name < details.Networks[j].Name
+	})
+	return details
+}
+
+//calculateCPUPercentPerCore calculates the usage of each core between the
+//current and the previous reading, percentages are relative to a single core
+func calculateCPUPercentPerCore(stats *types.StatsJSON) []float64 {
+	current := stats.CPUStats.CPUUsage.PercpuUsage
+	previous := stats.PreCPUStats.CPUUsage.PercpuUsage
+	if len(current) == 0 {
+		return nil
+	}
+	onlineCPUs := float64(stats.CPUStats.OnlineCPUs)
+	if onlineCPUs == 0.0 {
+		onlineCPUs = float64(len(current))
+	}
+	//the system delta covers every core, per core it is a fraction of it
+	systemDelta := float64(stats.CPUStats.SystemUsage) - float64(stats.PreCPUStats.SystemUsage)
+	coreDelta := systemDelta / onlineCPUs
+	percents := make([]float64, len(current))
+	if coreDelta <= 0.0 || len(previous) != len(current) {
+		return percents
+	}
+	for i := range current {
+		if delta := float64(current[i]) - float64(previous[i]); delta > 0.0 {
+			percents[i] = delta / coreDelta * 100.0
+		}
+	}
+	return percents
+}
+
+//calculateBlockIOPerDevice sums reads and writes by device, sorted by device number
+func calculateBlockIOPerDevice(blkio types.BlkioStats) []DeviceIO {
+	devices := make(map[string]*DeviceIO)
+	var result []DeviceIO
+	for _, entry := range blkio.IoServiceBytesRecursive {
+		key := fmt.Sprintf("%d:%d", entry.Major, entry.Minor)
+		device, ok := devices[key]
+		if !ok {
+			device = &DeviceIO{Major: entry.Major, Minor: entry.Minor}
+			devices[key] = device
+		}
+		switch strings.ToLower(entry.Op) {
+		case "read":
+			device.Read += entry.Value
+		case "write":
+			device.Write += entry.Value
+		}
+	}
+	for _, device := range devices {
+		result = append(result, *device)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Major != result[j].Major {
+			return result[i].Major < result[j].Major
+		}
+		return result[i].Minor < result[j].Minor
+	})
+	return result
+}
+
+//memoryStat returns the first of the given memory stats found, cgroup v1 and
+//v2 use different names for the same stat
+func memoryStat(mem types.MemoryStats, names ...string) uint64 {
+	for _, name := range names {
+		if v, ok := mem.Stats[name]; ok {
+			return v
+		}
+	}
+	return 0
+}
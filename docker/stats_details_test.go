@@ -0,0 +1,68 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+)
+
+func TestNewStatsDetails(t *testing.T) {
+	stats := &types.StatsJSON{
+		Stats: types.Stats{
+			CPUStats: types.CPUStats{
+				CPUUsage:    types.CPUUsage{PercpuUsage: []uint64{300, 100}},
+				SystemUsage: 2000,
+				OnlineCPUs:  2,
+			},
+			PreCPUStats: types.CPUStats{
+				CPUUsage:    types.CPUUsage{PercpuUsage: []uint64{100, 100}},
+				SystemUsage: 1000,
+			},
+			MemoryStats: types.MemoryStats{
+				Usage: 100,
+				Limit: 1000,
+				Stats: map[string]uint64{"file": 20, "anon": 80},
+			},
+			BlkioStats: types.BlkioStats{
+				IoServiceBytesRecursive: []types.BlkioStatEntry{
+					{Major: 8, Minor: 16, Op: "Write", Value: 5},
+					{Major: 8, Minor: 0, Op: "Read", Value: 10},
+					{Major: 8, Minor: 0, Op: "Write", Value: 1},
+					{Major: 8, Minor: 0, Op: "Total", Value: 11},
+				},
+			},
+			PidsStats: types.PidsStats{Current: 3, Limit: 100},
+		},
+		Networks: map[string]types.NetworkStats{
+			"eth1": {RxBytes: 1},
+			"eth0": {TxBytes: 2},
+		},
+	}
+
+	details := NewStatsDetails(stats)
+
+	if !reflect.DeepEqual(details.CPUPerCore, []float64{40, 0}) {
+		t.Errorf("Unexpected per core usage: %v", details.CPUPerCore)
+	}
+	if details.MemoryCache != 20 || details.MemoryRSS != 80 || details.MemoryLimit != 1000 {
+		t.Errorf("Unexpected memory breakdown: %+v", details)
+	}
+	if len(details.Networks) != 2 || details.Networks[0].Name != "eth0" || details.Networks[0].TxBytes != 2 {
+		t.Errorf("Unexpected networks: %+v", details.Networks)
+	}
+	expectedIO := []DeviceIO{{Major: 8, Minor: 0, Read: 10, Write: 1}, {Major: 8, Minor: 16, Write: 5}}
+	if !reflect.DeepEqual(details.BlockIO, expectedIO) {
+		t.Errorf("Unexpected block I/O: %+v", details.BlockIO)
+	}
+	if details.PidsCurrent != 3 || details.PidsLimit != 100 {
+		t.Errorf("Unexpected pids: %d/%d", details.PidsCurrent, details.PidsLimit)
+	}
+}
+
+func TestNewStatsDetails_NoPerCoreUsage(t *testing.T) {
+	details := NewStatsDetails(&types.StatsJSON{})
+	if len(details.CPUPerCore) != 0 {
+		t.Errorf("Expected no per core usage, got %v", details.CPUPerCore)
+	}
+}
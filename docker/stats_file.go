@@ -0,0 +1,187 @@
+package docker
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	jsoniter "github.com/json-iterator/go"
+	pkgError "github.com/pkg/errors"
+)
+
+const (
+	//DefaultStatsFileSize is the size, in bytes, a stats file is rotated at by default
+	DefaultStatsFileSize = 10 * 1024 * 1024
+	//DefaultStatsInterval is the time between stats samples by default
+	DefaultStatsInterval = 10 * time.Second
+	//statsSamplingWorkers is the number of containers sampled concurrently
+	statsSamplingWorkers = 4
+)
+
+//StatsRecord is the resource usage of a container at some point in time
+type StatsRecord struct {
+	Time          time.Time `json:"time"`
+	ID            string    `json:"id"`
+	Name          string    `json:"name"`
+	CPUPercentage float64   `json:"cpu_percentage"`
+	//CPUSeconds is the CPU time used by the container since it started
+	CPUSeconds       float64 `json:"cpu_seconds"`
+	Memory           float64 `json:"memory"`
+	MemoryLimit      float64 `json:"memory_limit"`
+	MemoryPercentage float64 `json:"memory_percentage"`
+	NetworkRx        float64 `json:"network_rx"`
+	NetworkTx        float64 `json:"network_tx"`
+	BlockRead        float64 `json:"block_read"`
+	BlockWrite       float64 `json:"block_write"`
+	Pids             uint64  `json:"pids"`
+}
+
+//StatsFile appends stats samples, as JSON lines, to a file. It is rotated
+//as an EventsFile is, so the retention is bounded by the size of the files.
+type StatsFile struct {
+	file *rotatingFile
+}
+
+//NewStatsFile opens, or creates, the stats file on the given path, samples
+//are appended to it, rotating it once it reaches the given size in bytes
+func NewStatsFile(path string, maxSize int64) (*StatsFile, error) {
+	file, err := newRotatingFile("stats", path, maxSize)
+	if err != nil {
+		return nil, err
+	}
+	return &StatsFile{file: file}, nil
+}
+
+//Record appends the given sample to the file, rotating it if needed. The
+//records of a sample are kept on the same file.
+func (f *StatsFile) Record(sample []StatsRecord) error {
+	var lines []byte
+	for _, record := range sample {
+		line, err := json.Marshal(record)
+		if err != nil {
+			return pkgError.Wrap(err, "Error encoding stats")
+		}
+		lines = append(append(lines, line...), '\n')
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	return f.file.write(lines)
+}
+
+//Close closes the file, no more samples are recorded afterwards
+func (f *StatsFile) Close() error {
+	return f.file.close()
+}
+
+//ReadStatsFile reads the samples recorded on the stats file on the given
+//path, and on its rotated files, sorted by time. Lines that cannot be read,
+//like the last one if dry was killed while writing it, are skipped.
+func ReadStatsFile(path string) ([]StatsRecord, error) {
+	files := rotatedFiles(path)
+	if len(files) == 0 {
+		return nil, pkgError.Errorf("No stats recorded on %s", path)
+	}
+	var records []StatsRecord
+	for _, name := range files {
+		file, err := os.Open(name)
+		if err != nil {
+			return nil, pkgError.Wrap(err, "Error reading stats file")
+		}
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			var record StatsRecord
+			if err := json.Unmarshal(scanner.Bytes(), &record); err == nil {
+				records = append(records, record)
+			}
+		}
+		err = scanner.Err()
+		file.Close()
+		if err != nil {
+			return nil, pkgError.Wrap(err, "Error reading stats file")
+		}
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Time.Before(records[j].Time)
+	})
+	return records, nil
+}
+
+//SampleStats returns the current stats of every running container, all of
+//them with the same time. Containers whose stats cannot be retrieved, i.e.
+//because they stopped meanwhile, are left out.
+func (daemon *DockerDaemon) SampleStats() []StatsRecord {
+	containers := daemon.Containers([]ContainerFilter{ContainerFilters.Running()}, NoSort)
+	now := time.Now()
+	pending := make(chan *Container)
+	var (
+		records []StatsRecord
+		mutex   sync.Mutex
+		wg      sync.WaitGroup
+	)
+	for i := 0; i < statsSamplingWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for c := range pending {
+				if record, err := daemon.sampleStats(c); err == nil {
+					record.Time = now
+					mutex.Lock()
+					records = append(records, record)
+					mutex.Unlock()
+				}
+			}
+		}()
+	}
+	for _, c := range containers {
+		pending <- c
+	}
+	close(pending)
+	wg.Wait()
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Name < records[j].Name
+	})
+	return records
+}
+
+func (daemon *DockerDaemon) sampleStats(c *Container) (StatsRecord, error) {
+	version, err := daemon.Version()
+	if err != nil {
+		return StatsRecord{}, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	response, err := daemon.client.ContainerStats(ctx, c.ID, false)
+	if err != nil {
+		return StatsRecord{}, err
+	}
+	defer response.Body.Close()
+	var statsJSON types.StatsJSON
+	if err := jsoniter.NewDecoder(response.Body).Decode(&statsJSON); err != nil {
+		return StatsRecord{}, err
+	}
+	stats := buildStats(version, c, &statsJSON, nil)
+	record := StatsRecord{
+		ID:               c.ID,
+		CPUPercentage:    stats.CPUPercentage,
+		CPUSeconds:       float64(statsJSON.CPUStats.CPUUsage.TotalUsage) / float64(time.Second),
+		Memory:           stats.Memory,
+		MemoryLimit:      stats.MemoryLimit,
+		MemoryPercentage: stats.MemoryPercentage,
+		NetworkRx:        stats.NetworkRx,
+		NetworkTx:        stats.NetworkTx,
+		BlockRead:        stats.BlockRead,
+		BlockWrite:       stats.BlockWrite,
+		Pids:             stats.PidsCurrent,
+	}
+	if len(c.Names) > 0 {
+		record.Name = strings.TrimPrefix(c.Names[0], "/")
+	}
+	return record, nil
+}
@@ -0,0 +1,65 @@
+package docker
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestStatsFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dry-stats")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "stats.log")
+
+	if _, err := ReadStatsFile(path); err == nil {
+		t.Error("Reading a missing stats file did not fail")
+	}
+
+	start := time.Date(2019, 3, 1, 10, 0, 0, 0, time.UTC)
+	sample := func(i int) []StatsRecord {
+		at := start.Add(time.Duration(i) * time.Second)
+		return []StatsRecord{
+			{Time: at, ID: "a", Name: "web", CPUPercentage: float64(i)},
+			{Time: at, ID: "b", Name: "db", CPUPercentage: float64(i)},
+		}
+	}
+	line, _ := json.Marshal(sample(0)[0])
+	//Room for two samples on each file
+	f, err := NewStatsFile(path, int64(4*(len(line)+1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		if err := f.Record(sample(i)); err != nil {
+			t.Fatalf("Error recording sample %d: %s", i, err)
+		}
+	}
+	f.Close()
+
+	//a line cut short is skipped
+	file, _ := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	file.WriteString(`{"time":"2019-03-01T10:00:`)
+	file.Close()
+
+	records, err := ReadStatsFile(path)
+	if err != nil {
+		t.Fatalf("Unexpected error reading stats: %s", err)
+	}
+	//the current file and 3 rotated files, with the last 8 samples
+	if len(records) != 16 {
+		t.Fatalf("Unexpected number of records read: %d", len(records))
+	}
+	for i, r := range records {
+		want := strconv.Itoa(2 + i/2)
+		if got := strconv.Itoa(int(r.CPUPercentage)); got != want {
+			t.Errorf("Record %d is from sample %s, expected sample %s", i, got, want)
+		}
+	}
+}
@@ -46,6 +46,29 @@ func (daemon *DockerDaemon) NodeChangeAvailability(nodeID string, availability s
 	return pkgError.Wrapf(err, "Error changing node %s availability", nodeID)
 }
 
+//NodeChangeLabels replaces the labels of the given node
+func (daemon *DockerDaemon) NodeChangeLabels(nodeID string, labels map[string]string) error {
+	err := daemon.updateNode(nodeID, func(spec *swarm.NodeSpec) {
+		spec.Labels = labels
+	})
+	if err == nil {
+		return nil
+	}
+	return pkgError.Wrapf(err, "Error changing node %s labels", nodeID)
+}
+
+//NodeChangeRole changes the role of the given node, promoting it to manager
+//or demoting it to worker
+func (daemon *DockerDaemon) NodeChangeRole(nodeID string, role swarm.NodeRole) error {
+	err := daemon.updateNode(nodeID, func(spec *swarm.NodeSpec) {
+		spec.Role = role
+	})
+	if err == nil {
+		return nil
+	}
+	return pkgError.Wrapf(err, "Error changing node %s role", nodeID)
+}
+
 //Nodes returns the nodes that are part of the Swarm
 func (daemon *DockerDaemon) Nodes() ([]swarm.Node, error) {
 
@@ -102,19 +125,36 @@ func (daemon *DockerDaemon) Service(id string) (*swarm.Service, error) {
 }
 
 //ServiceLogs returns logs of the service with the given id
-func (daemon *DockerDaemon) ServiceLogs(id string, since string, withTimestamps bool) (io.ReadCloser, error) {
+func (daemon *DockerDaemon) ServiceLogs(id string, opts LogsOptions) (io.ReadCloser, error) {
 
 	options := types.ContainerLogsOptions{
 		ShowStdout: true,
 		ShowStderr: true,
-		Timestamps: withTimestamps,
-		Follow:     true,
+		Timestamps: opts.Timestamps,
+		Follow:     opts.Follow,
 		Details:    true,
-		Since:      since,
+		Since:      opts.Since,
+		Tail:       opts.Tail,
 	}
 	return daemon.client.ServiceLogs(context.Background(), id, options)
 }
 
+//TaskLogs returns the logs of the container of the given task, the manager
+//retrieves them from the node the task runs on
+func (daemon *DockerDaemon) TaskLogs(id string, opts LogsOptions) (io.ReadCloser, error) {
+
+	options := types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Timestamps: opts.Timestamps,
+		Follow:     opts.Follow,
+		Details:    true,
+		Since:      opts.Since,
+		Tail:       opts.Tail,
+	}
+	return daemon.client.TaskLogs(context.Background(), id, options)
+}
+
 //Services returns the services known by the Swarm
 func (daemon *DockerDaemon) Services() ([]swarm.Service, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
@@ -178,6 +218,33 @@ func (daemon *DockerDaemon) ServiceUpdate(id string) error {
 
 }
 
+//ServiceChangeLabels replaces the labels of the given service
+func (daemon *DockerDaemon) ServiceChangeLabels(id string, labels map[string]string) error {
+	err := daemon.updateService(id, func(spec *swarm.ServiceSpec) {
+		spec.Labels = labels
+	})
+	if err == nil {
+		return nil
+	}
+	return pkgError.Wrapf(err, "Error changing service %s labels", id)
+}
+
+//ServiceChangePlacement replaces the placement constraints and preferences
+//of the given service
+func (daemon *DockerDaemon) ServiceChangePlacement(id string, constraints []string, preferences []swarm.PlacementPreference) error {
+	err := daemon.updateService(id, func(spec *swarm.ServiceSpec) {
+		if spec.TaskTemplate.Placement == nil {
+			spec.TaskTemplate.Placement = &swarm.Placement{}
+		}
+		spec.TaskTemplate.Placement.Constraints = constraints
+		spec.TaskTemplate.Placement.Preferences = preferences
+	})
+	if err == nil {
+		return nil
+	}
+	return pkgError.Wrapf(err, "Error changing service %s placement", id)
+}
+
 //ServiceTasks returns the tasks being run that belong to the given list of services
 func (daemon *DockerDaemon) ServiceTasks(services ...string) ([]swarm.Task, error) {
 
@@ -263,6 +330,18 @@ func (daemon *DockerDaemon) StackConfigs(stack string) ([]swarm.Config, error) {
 		types.ConfigListOptions{Filters: buildStackFilter(stack)})
 }
 
+//ConfigContent returns the content of the config with the given id, secret
+//contents, on the other hand, are never returned by Docker
+func (daemon *DockerDaemon) ConfigContent(id string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	config, _, err := daemon.client.ConfigInspectWithRaw(ctx, id)
+	if err != nil {
+		return nil, pkgError.Wrapf(err, "Error inspecting config %s", id)
+	}
+	return config.Spec.Data, nil
+}
+
 //StackNetworks returns the networks created for the given stack
 func (daemon *DockerDaemon) StackNetworks(stack string) ([]types.NetworkResource, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
@@ -322,6 +401,37 @@ func (daemon *DockerDaemon) Task(id string) (swarm.Task, error) {
 	return swarm.Task{}, pkgError.Wrapf(err, "Error retrieving task with ID: %s", id)
 
 }
+
+//updateNode updates the spec of the given node using the given func
+func (daemon *DockerDaemon) updateNode(nodeID string, update func(spec *swarm.NodeSpec)) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	node, _, err := daemon.client.NodeInspectWithRaw(ctx, nodeID)
+	if err != nil {
+		return err
+	}
+	update(&node.Spec)
+	return daemon.client.NodeUpdate(ctx, nodeID, node.Version, node.Spec)
+}
+
+//updateService updates the spec of the given service using the given func
+func (daemon *DockerDaemon) updateService(id string, update func(spec *swarm.ServiceSpec)) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	service, _, err := daemon.client.ServiceInspectWithRaw(ctx, id, types.ServiceInspectOptions{})
+	if err != nil {
+		return err
+	}
+	update(&service.Spec)
+	_, err = daemon.client.ServiceUpdate(
+		ctx,
+		id,
+		service.Version,
+		service.Spec,
+		types.ServiceUpdateOptions{})
+	return err
+}
+
 func buildStackFilter(stack string) filters.Args {
 	filter := filters.NewArgs()
 	filter.Add("label", "com.docker.stack.namespace="+stack)
@@ -333,6 +443,11 @@ func getAllStacksFilter() filters.Args {
 	return filter
 }
 
+//NewNodeRole builds NodeRole from the given string
+func NewNodeRole(role string) swarm.NodeRole {
+	return swarm.NodeRole(role)
+}
+
 //NewNodeAvailability builds NodeAvailability from the given string
 func NewNodeAvailability(availability string) swarm.NodeAvailability {
 	return swarm.NodeAvailability(availability)
@@ -0,0 +1,102 @@
+package docker
+
+import (
+	"context"
+
+	"github.com/docker/docker/api/types/swarm"
+	pkgError "github.com/pkg/errors"
+)
+
+//defaultSwarmListenAddr is the listen address used when initializing or joining a swarm
+const defaultSwarmListenAddr = "0.0.0.0:2377"
+
+//SwarmInit initializes a new swarm on the Docker host, using the given
+//address as the address advertised to other nodes. If no address is given
+//Docker will try to figure out one.
+func (daemon *DockerDaemon) SwarmInit(advertiseAddr string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	nodeID, err := daemon.client.SwarmInit(ctx, swarm.InitRequest{
+		ListenAddr:    defaultSwarmListenAddr,
+		AdvertiseAddr: advertiseAddr,
+	})
+	if err != nil {
+		return "", pkgError.Wrap(err, "Error initializing swarm")
+	}
+	daemon.setSwarmMode(true)
+	return nodeID, nil
+}
+
+//SwarmInspect returns the swarm the Docker host is part of, only works
+//on managers
+func (daemon *DockerDaemon) SwarmInspect() (swarm.Swarm, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	sw, err := daemon.client.SwarmInspect(ctx)
+	if err != nil {
+		return sw, pkgError.Wrap(err, "Error inspecting swarm")
+	}
+	return sw, nil
+}
+
+//SwarmJoin joins the Docker host to the swarm managed by the given manager,
+//as a worker or as a manager depending on the given token
+func (daemon *DockerDaemon) SwarmJoin(token, managerAddr string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	err := daemon.client.SwarmJoin(ctx, swarm.JoinRequest{
+		ListenAddr:  defaultSwarmListenAddr,
+		RemoteAddrs: []string{managerAddr},
+		JoinToken:   token,
+	})
+	if err != nil {
+		return pkgError.Wrap(err, "Error joining swarm")
+	}
+	daemon.setSwarmMode(true)
+	return nil
+}
+
+//SwarmLeave makes the Docker host leave the swarm
+func (daemon *DockerDaemon) SwarmLeave(force bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	if err := daemon.client.SwarmLeave(ctx, force); err != nil {
+		return pkgError.Wrap(err, "Error leaving swarm")
+	}
+	daemon.setSwarmMode(false)
+	return nil
+}
+
+//SwarmRotateJoinToken rotates the worker or the manager join token
+func (daemon *DockerDaemon) SwarmRotateJoinToken(manager bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	sw, err := daemon.client.SwarmInspect(ctx)
+	if err != nil {
+		return pkgError.Wrap(err, "Error inspecting swarm")
+	}
+	flags := swarm.UpdateFlags{
+		RotateWorkerToken:  !manager,
+		RotateManagerToken: manager,
+	}
+	if err := daemon.client.SwarmUpdate(ctx, sw.Version, sw.Spec, flags); err != nil {
+		return pkgError.Wrap(err, "Error rotating join token")
+	}
+	return nil
+}
+
+//SwarmRotateCA rotates the root CA of the swarm, a new root CA certificate
+//and key are generated and the certificates of every node are renewed
+func (daemon *DockerDaemon) SwarmRotateCA() error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	sw, err := daemon.client.SwarmInspect(ctx)
+	if err != nil {
+		return pkgError.Wrap(err, "Error inspecting swarm")
+	}
+	sw.Spec.CAConfig.ForceRotate++
+	if err := daemon.client.SwarmUpdate(ctx, sw.Version, sw.Spec, swarm.UpdateFlags{}); err != nil {
+		return pkgError.Wrap(err, "Error rotating swarm CA")
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/swarm"
+	pkgError "github.com/pkg/errors"
+)
+
+const (
+	//prePullLabel labels the helper services created to pre-pull images with the image pulled
+	prePullLabel = "dry.prepull"
+	//prePullTimeout is how long nodes are waited for to pull an image
+	prePullTimeout = 15 * time.Minute
+	//prePullPollInterval is how often the tasks pulling an image are checked
+	prePullPollInterval = time.Second
+)
+
+//PrePullResult is the result of pulling an image on a swarm node
+type PrePullResult struct {
+	Node string
+	Err  error
+}
+
+//ImagePrePull pulls the given image on the swarm nodes with the given node
+//label, given as <key>=<value>, or on every node if no label is given. The
+//image is pulled by a helper global service, the result on each node is
+//given to report as the node completes. It returns once every node has
+//completed, removing the service.
+func (daemon *DockerDaemon) ImagePrePull(image, nodeLabel string, report func(PrePullResult)) error {
+	constraints, err := prePullConstraints(nodeLabel)
+	if err != nil {
+		return err
+	}
+	nodes, err := daemon.Nodes()
+	if err != nil {
+		return err
+	}
+	expected := prePullNodes(nodes, nodeLabel)
+	if len(expected) == 0 {
+		return errors.New("no ready and active nodes to pull the image on")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), prePullTimeout)
+	defer cancel()
+	spec := swarm.ServiceSpec{
+		Annotations: swarm.Annotations{
+			Name:   fmt.Sprintf("dry-prepull-%d", time.Now().Unix()),
+			Labels: map[string]string{prePullLabel: image},
+		},
+		TaskTemplate: swarm.TaskSpec{
+			ContainerSpec: &swarm.ContainerSpec{Image: image},
+			RestartPolicy: &swarm.RestartPolicy{Condition: swarm.RestartPolicyConditionNone},
+			Placement:     &swarm.Placement{Constraints: constraints},
+		},
+		Mode: swarm.ServiceMode{Global: &swarm.GlobalService{}},
+	}
+	service, err := daemon.client.ServiceCreate(ctx, spec, types.ServiceCreateOptions{QueryRegistry: true})
+	if err != nil {
+		return pkgError.Wrap(err, "Error creating pre-pull helper service")
+	}
+	defer daemon.ServiceRemove(service.ID)
+
+	ticker := time.NewTicker(prePullPollInterval)
+	defer ticker.Stop()
+	for len(expected) > 0 {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out, %d nodes did not pull the image", len(expected))
+		case <-ticker.C:
+		}
+		tasks, err := daemon.ServiceTasks(service.ID)
+		if err != nil {
+			return err
+		}
+		for _, task := range tasks {
+			node, ok := expected[task.NodeID]
+			if !ok {
+				continue
+			}
+			if done, err := prePullTaskResult(task); done {
+				delete(expected, task.NodeID)
+				report(PrePullResult{Node: node, Err: err})
+			}
+		}
+	}
+	return nil
+}
+
+//prePullConstraints returns the placement constraints of a pre-pull
+//service for the given node label
+func prePullConstraints(nodeLabel string) ([]string, error) {
+	if nodeLabel == "" {
+		return nil, nil
+	}
+	label := strings.SplitN(nodeLabel, "=", 2)
+	if len(label) != 2 || label[0] == "" {
+		return nil, fmt.Errorf("invalid node label %q, expected <key>=<value>", nodeLabel)
+	}
+	return []string{fmt.Sprintf("node.labels.%s==%s", label[0], label[1])}, nil
+}
+
+//prePullNodes returns the hostnames, by node id, of the ready and active
+//nodes with the given label
+func prePullNodes(nodes []swarm.Node, nodeLabel string) map[string]string {
+	key, value := nodeLabel, ""
+	if i := strings.Index(nodeLabel, "="); i >= 0 {
+		key, value = nodeLabel[:i], nodeLabel[i+1:]
+	}
+	result := make(map[string]string)
+	for _, node := range nodes {
+		if node.Status.State != swarm.NodeStateReady || node.Spec.Availability != swarm.NodeAvailabilityActive {
+			continue
+		}
+		if v, ok := node.Spec.Labels[key]; nodeLabel != "" && (!ok || v != value) {
+			continue
+		}
+		result[node.ID] = node.Description.Hostname
+	}
+	return result
+}
+
+//prePullTaskResult returns whether the given pre-pull task is done, either
+//because the image was pulled, and a container created from it, or because
+//the task failed before that
+func prePullTaskResult(task swarm.Task) (bool, error) {
+	if cs := task.Status.ContainerStatus; cs != nil && cs.ContainerID != "" {
+		return true, nil
+	}
+	switch task.Status.State {
+	case swarm.TaskStateStarting, swarm.TaskStateRunning, swarm.TaskStateComplete:
+		return true, nil
+	case swarm.TaskStateFailed, swarm.TaskStateRejected,
+		swarm.TaskStateShutdown, swarm.TaskStateOrphaned, swarm.TaskStateRemove:
+		if task.Status.Err != "" {
+			return true, errors.New(task.Status.Err)
+		}
+		return true, fmt.Errorf("task %s", task.Status.State)
+	}
+	return false, nil
+}
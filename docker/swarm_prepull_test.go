@@ -0,0 +1,73 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/swarm"
+)
+
+func TestPrePullNodes(t *testing.T) {
+	node := func(id string, state swarm.NodeState, availability swarm.NodeAvailability, labels map[string]string) swarm.Node {
+		n := swarm.Node{ID: id}
+		n.Description.Hostname = "host-" + id
+		n.Status.State = state
+		n.Spec.Availability = availability
+		n.Spec.Labels = labels
+		return n
+	}
+	nodes := []swarm.Node{
+		node("1", swarm.NodeStateReady, swarm.NodeAvailabilityActive, map[string]string{"zone": "a"}),
+		node("2", swarm.NodeStateReady, swarm.NodeAvailabilityActive, map[string]string{"zone": "b"}),
+		node("3", swarm.NodeStateDown, swarm.NodeAvailabilityActive, map[string]string{"zone": "a"}),
+		node("4", swarm.NodeStateReady, swarm.NodeAvailabilityDrain, nil),
+	}
+	if got := prePullNodes(nodes, ""); len(got) != 2 || got["1"] != "host-1" || got["2"] != "host-2" {
+		t.Errorf("Unexpected nodes to pre-pull on: %v", got)
+	}
+	if got := prePullNodes(nodes, "zone=a"); len(got) != 1 || got["1"] != "host-1" {
+		t.Errorf("Unexpected nodes to pre-pull on with label zone=a: %v", got)
+	}
+}
+
+func TestPrePullConstraints(t *testing.T) {
+	if c, err := prePullConstraints(""); err != nil || c != nil {
+		t.Errorf("Unexpected constraints without label: %v, %v", c, err)
+	}
+	if c, err := prePullConstraints("zone=a"); err != nil || len(c) != 1 || c[0] != "node.labels.zone==a" {
+		t.Errorf("Unexpected constraints with label: %v, %v", c, err)
+	}
+	if _, err := prePullConstraints("zone"); err == nil {
+		t.Error("A label without value is not a valid constraint")
+	}
+}
+
+func TestPrePullTaskResult(t *testing.T) {
+	task := func(state swarm.TaskState, err string, containerID string) swarm.Task {
+		t := swarm.Task{}
+		t.Status.State = state
+		t.Status.Err = err
+		if containerID != "" {
+			t.Status.ContainerStatus = &swarm.ContainerStatus{ContainerID: containerID}
+		}
+		return t
+	}
+	tests := []struct {
+		task    swarm.Task
+		done    bool
+		wantErr bool
+	}{
+		{task(swarm.TaskStatePreparing, "", ""), false, false},
+		{task(swarm.TaskStateRunning, "", ""), true, false},
+		{task(swarm.TaskStateComplete, "", ""), true, false},
+		{task(swarm.TaskStateFailed, "starting container failed", "abc"), true, false},
+		{task(swarm.TaskStateRejected, "No such image: nginx:nope", ""), true, true},
+		{task(swarm.TaskStateShutdown, "", ""), true, true},
+	}
+	for _, tt := range tests {
+		done, err := prePullTaskResult(tt.task)
+		if done != tt.done || (err != nil) != tt.wantErr {
+			t.Errorf("Task %s: got done %v, error %v, expected done %v, error %v",
+				tt.task.Status.State, done, err, tt.done, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package docker
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	dockerTypes "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/mount"
+	homedir "github.com/mitchellh/go-homedir"
+)
+
+//unusedObjectsFile is where the time Docker objects were first seen unused is stored
+var unusedObjectsFile string
+
+func init() {
+	unusedObjectsFile, _ = homedir.Expand("~/.dry/unused.json")
+}
+
+//unusedObjects are the objects seen unused on a Docker host, by source and
+//object id, with the time each one was first seen unused
+type unusedObjects map[SourceType]map[string]time.Time
+
+//unusedTracker keeps track of when Docker objects (images, volumes, networks)
+//of a Docker host were first observed as unused. Observations are persisted,
+//along with those of other hosts, so they survive dry restarts.
+type unusedTracker struct {
+	path string
+	//host identifies the Docker host on the file
+	host string
+	sync.RWMutex
+	seen unusedObjects
+}
+
+func newUnusedTracker(path, host string) *unusedTracker {
+	t := &unusedTracker{
+		path: path,
+		host: host,
+		seen: make(unusedObjects),
+	}
+	if seen, ok := readUnusedObjects(path)[host]; ok {
+		t.seen = seen
+	}
+	return t
+}
+
+//readUnusedObjects returns the unused objects on the given file, by Docker host
+func readUnusedObjects(path string) map[string]unusedObjects {
+	hosts := make(map[string]unusedObjects)
+	if path == "" {
+		return hosts
+	}
+	if data, err := ioutil.ReadFile(path); err == nil {
+		json.Unmarshal(data, &hosts)
+	}
+	return hosts
+}
+
+//track registers the current state of the objects of the given source. Objects
+//not found in the given map are forgotten.
+func (t *unusedTracker) track(source SourceType, unused map[string]bool) {
+	t.Lock()
+	defer t.Unlock()
+	now := time.Now()
+	previous := t.seen[source]
+	current := make(map[string]time.Time)
+	changed := false
+	for id, isUnused := range unused {
+		if !isUnused {
+			continue
+		}
+		if since, ok := previous[id]; ok {
+			current[id] = since
+		} else {
+			current[id] = now
+			changed = true
+		}
+	}
+	if !changed && len(previous) == len(current) {
+		return
+	}
+	t.seen[source] = current
+	t.save()
+}
+
+//unusedSince returns the time the given object was first seen unused, false
+//if it is being used.
+func (t *unusedTracker) unusedSince(source SourceType, id string) (time.Time, bool) {
+	t.RLock()
+	defer t.RUnlock()
+	since, ok := t.seen[source][id]
+	return since, ok
+}
+
+//save saves the objects seen unused, the file is read again to keep what
+//other trackers saved for other Docker hosts
+func (t *unusedTracker) save() {
+	if t.path == "" {
+		return
+	}
+	hosts := readUnusedObjects(t.path)
+	hosts[t.host] = t.seen
+	data, err := json.Marshal(hosts)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(t.path), 0700); err != nil {
+		return
+	}
+	ioutil.WriteFile(t.path, data, 0600)
+}
+
+//UnusedSince returns the time the object with the given id, from the given
+//source, was first seen unused by dry. Returns false if the object is being
+//used or it is unknown.
+func (daemon *DockerDaemon) UnusedSince(source SourceType, id string) (time.Time, bool) {
+	if daemon.unused == nil {
+		return time.Time{}, false
+	}
+	return daemon.unused.unusedSince(source, id)
+}
+
+func (daemon *DockerDaemon) trackUnusedImages(images []dockerTypes.ImageSummary) {
+	if daemon.unused == nil || daemon.store() == nil {
+		return
+	}
+	used := make(map[string]bool)
+	for _, c := range daemon.store().List() {
+		used[c.ImageID] = true
+	}
+	unused := make(map[string]bool)
+	for _, image := range images {
+		unused[image.ID] = !used[image.ID]
+	}
+	daemon.unused.track(ImageSource, unused)
+}
+
+func (daemon *DockerDaemon) trackUnusedNetworks(networks []dockerTypes.NetworkResource) {
+	if daemon.unused == nil {
+		return
+	}
+	unused := make(map[string]bool)
+	for _, network := range networks {
+		if isPredefinedNetwork(network.Name) {
+			continue
+		}
+		unused[network.ID] = len(network.Containers) == 0
+	}
+	daemon.unused.track(NetworkSource, unused)
+}
+
+func (daemon *DockerDaemon) trackUnusedVolumes(volumes []*dockerTypes.Volume) {
+	if daemon.unused == nil || daemon.store() == nil {
+		return
+	}
+	used := make(map[string]bool)
+	for _, c := range daemon.store().List() {
+		for _, m := range c.Container.Mounts {
+			if m.Type == mount.TypeVolume {
+				used[m.Name] = true
+			}
+		}
+	}
+	unused := make(map[string]bool)
+	for _, volume := range volumes {
+		unused[volume.Name] = !used[volume.Name]
+	}
+	daemon.unused.track(VolumeSource, unused)
+}
+
+//isPredefinedNetwork returns true for networks created by Docker, which are
+//never removed
+func isPredefinedNetwork(name string) bool {
+	return name == "bridge" || name == "host" || name == "none"
+}
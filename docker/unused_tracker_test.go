@@ -0,0 +1,58 @@
+package docker
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUnusedTracker(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dry-unused")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "unused.json")
+
+	tracker := newUnusedTracker(path, "daemon")
+	tracker.track(ImageSource, map[string]bool{"used": false, "unused": true})
+
+	if _, ok := tracker.unusedSince(ImageSource, "used"); ok {
+		t.Error("Used image reported as unused")
+	}
+	since, ok := tracker.unusedSince(ImageSource, "unused")
+	if !ok {
+		t.Fatal("Unused image not reported as unused")
+	}
+	if _, ok := tracker.unusedSince(VolumeSource, "unused"); ok {
+		t.Error("Sources must be tracked independently")
+	}
+
+	reloaded := newUnusedTracker(path, "daemon")
+	if reloadedSince, ok := reloaded.unusedSince(ImageSource, "unused"); !ok || !reloadedSince.Equal(since) {
+		t.Errorf("Unused time was not persisted, expected %s, got %s", since, reloadedSince)
+	}
+
+	other := newUnusedTracker(path, "other")
+	if _, ok := other.unusedSince(ImageSource, "unused"); ok {
+		t.Error("Docker hosts must be tracked independently")
+	}
+	other.track(ImageSource, map[string]bool{"elsewhere": true})
+	if _, ok := newUnusedTracker(path, "daemon").unusedSince(ImageSource, "unused"); !ok {
+		t.Error("Unused objects of a Docker host were lost once another host was tracked")
+	}
+
+	reloaded.track(ImageSource, map[string]bool{"unused": true})
+	if again, _ := reloaded.unusedSince(ImageSource, "unused"); !again.Equal(since) {
+		t.Errorf("Unused time must not change while the image is unused, expected %s, got %s", since, again)
+	}
+
+	reloaded.track(ImageSource, map[string]bool{"unused": false})
+	if _, ok := reloaded.unusedSince(ImageSource, "unused"); ok {
+		t.Error("Image being used again is still reported as unused")
+	}
+	if _, ok := newUnusedTracker(path, "other").unusedSince(ImageSource, "elsewhere"); !ok {
+		t.Error("Unused objects of a Docker host were overwritten by another host")
+	}
+}
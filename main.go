@@ -6,6 +6,7 @@ import (
 	"os"
 	"runtime/debug"
 	"strconv"
+	"strings"
 	"time"
 
 	"net/http"
@@ -52,31 +53,113 @@ type options struct {
 	// enable profiling
 	Profile bool `short:"p" long:"profile" description:"Enable profiling"`
 	Version bool `short:"v" long:"version" description:"Dry version"`
+	//Configuration file, flags take precedence over its settings
+	ConfigFile string `long:"config" description:"Reads the configuration from the given file, ~/.config/dry/config.yaml is read if it exists and no file is given"`
 	//Docker-related properties
-	DockerHost       string `short:"H" long:"docker_host" description:"Docker Host"`
-	DockerCertPath   string `short:"c" long:"docker_certpath" description:"Docker cert path"`
-	DockerTLSVerifiy string `short:"t" long:"docker_tls" description:"Docker TLS verify"`
+	DockerHost       string   `short:"H" long:"docker_host" description:"Docker Host"`
+	DockerCertPath   string   `short:"c" long:"docker_certpath" description:"Docker cert path"`
+	DockerTLSVerifiy string   `short:"t" long:"docker_tls" description:"Docker TLS verify"`
+	Context          string   `long:"context" description:"Docker context to connect to, as listed by docker context ls, the current context of the Docker CLI is used if no Docker host is given"`
+	Endpoints        []string `long:"endpoint" description:"Docker host dry can switch to, and shows on the hosts dashboard, as <name>=<host> (i.e. staging=ssh://deploy@staging), can be repeated"`
 	//Whale
 	Whale uint `short:"w" long:"whale" description:"Show whale for w seconds"`
+	//Label values shown as extra columns
+	LabelColumns []string `long:"label-column" description:"Shows the value of the given label as a column of the container and service lists, can be repeated"`
+	//Monitor alerts
+	CPUAlert    float64 `long:"cpu-alert" description:"Highlights monitored containers using more than the given CPU percentage and shows an alert"`
+	MemoryAlert float64 `long:"mem-alert" description:"Highlights monitored containers using more than the given memory percentage and shows an alert"`
+	AlertBell   bool    `long:"alert-bell" description:"Rings the terminal bell on monitor alerts"`
+	//Service scale presets
+	ScalePresets []string `long:"scale-preset" description:"Named numbers of replicas a service can be scaled to, as <service>=<preset>:<replicas>,... (i.e. web=night:2,day:10), can be repeated"`
+	//Events persistence
+	EventsFile     string `long:"events-file" description:"Appends the Docker events received to the given file, as JSON lines"`
+	EventsFileSize int64  `long:"events-file-size" description:"Size, in MB, the events file is rotated at, the last 3 rotated files are kept" default:"10"`
+	//Stats recording
+	StatsFile     string `long:"stats-file" description:"Appends the stats of every running container to the given file, as JSON lines, they can be played back from the monitor"`
+	StatsFileSize int64  `long:"stats-file-size" description:"Size, in MB, the stats file is rotated at, the last 3 rotated files are kept" default:"10"`
+	StatsInterval uint   `long:"stats-interval" description:"Seconds between the stats samples recorded on the stats file" default:"10"`
+	//Event hooks
+	Hooks []string `long:"hook" description:"Runs an action when a Docker event matching a filter arrives, as <filter> => <action>, actions are refresh, notify or run <command> (i.e. 'type=container action=die label=env=prod => run ~/notify.sh'), can be repeated"`
+	//Critical event notifications
+	Notifications []string `long:"notify" description:"Notifies a critical event, one of die (a container died unexpectedly), oom or node-down, as <event>[=<method>,...], methods are bell (the default) and desktop, can be repeated"`
+	//Terminal integration
+	TmuxStatus bool `long:"tmux" description:"Shows the Docker host and the active view on the tmux status line, as #{@dry_status}"`
+	//Usage by owner
+	OwnerLabel string `long:"owner-label" description:"Label giving the owner of containers, images and volumes on the usage by owner report, owner by default"`
+	//Read-only mode
+	ReadOnly bool `long:"read-only" description:"Disables every action changing the state of the Docker host (kill, remove, prune, pull, service updates, etc.), to only observe it"`
+	//Refresh while not focused
+	RefreshUnfocused bool `long:"refresh-unfocused" description:"Keeps refreshing lists and the monitor as usual while the terminal is not focused, by default refreshes are reduced until it is focused again (on terminals reporting focus changes)"`
+	//Image update checks
+	UpdateCheck uint `long:"update-check" description:"Hours between checks of the registry for newer images of the local tags, images and containers using an outdated tag are marked, 0 only checks when asked to (O on the image list)"`
+	//Removal undo
+	UndoWindow uint `long:"undo-window" description:"Seconds container and image removals wait before being done, they can be undone meanwhile, 0 removes them right away" default:"5"`
 }
 
 func config(opts options) (app.Config, error) {
-	var cfg app.Config
-	if opts.DockerHost == "" {
-		if os.Getenv("DOCKER_HOST") == "" {
+	cfg, err := readConfigFile(opts.ConfigFile)
+	if err != nil {
+		return cfg, err
+	}
+	if opts.DockerHost != "" {
+		cfg.DockerHost = opts.DockerHost
+		cfg.DockerTLSVerify = docker.GetBool(opts.DockerTLSVerifiy)
+		cfg.DockerCertPath = opts.DockerCertPath
+		cfg.Context = ""
+	} else if opts.Context != "" {
+		cfg.Context = opts.Context
+	} else if os.Getenv("DOCKER_HOST") != "" {
+		cfg.DockerHost = os.Getenv("DOCKER_HOST")
+		cfg.DockerTLSVerify = docker.GetBool(os.Getenv("DOCKER_TLS_VERIFY"))
+		cfg.DockerCertPath = os.Getenv("DOCKER_CERT_PATH")
+		cfg.Context = ""
+	} else if cfg.Context == "" && cfg.DockerHost == "" {
+		if current := docker.CurrentContext(docker.ConfigDir()); current != docker.DefaultContext {
+			cfg.Context = current
+		} else if podman, ok := docker.PodmanSocket(); ok && !dockerSocketExists() {
+			log.Printf(
+				"No DOCKER_HOST env variable found, no Host parameter was given and there is no Docker socket, connecting to Podman on %s",
+				podman)
+			cfg.DockerHost = podman
+		} else {
 			log.Printf(
 				"No DOCKER_HOST env variable found and no Host parameter was given, connecting to %s",
 				docker.DefaultDockerHost)
 			cfg.DockerHost = docker.DefaultDockerHost
-		} else {
-			cfg.DockerHost = os.Getenv("DOCKER_HOST")
-			cfg.DockerTLSVerify = docker.GetBool(os.Getenv("DOCKER_TLS_VERIFY"))
-			cfg.DockerCertPath = os.Getenv("DOCKER_CERT_PATH")
 		}
-	} else {
-		cfg.DockerHost = opts.DockerHost
-		cfg.DockerTLSVerify = docker.GetBool(opts.DockerTLSVerifiy)
-		cfg.DockerCertPath = opts.DockerCertPath
+	}
+
+	for _, h := range opts.Endpoints {
+		name, host, err := app.ParseNamedHost(h)
+		if err != nil {
+			return cfg, err
+		}
+		if cfg.Hosts == nil {
+			cfg.Hosts = make(map[string]string)
+		}
+		cfg.Hosts[name] = host
+	}
+	cfg.TmuxStatus = opts.TmuxStatus
+	cfg.ReadOnly = cfg.ReadOnly || opts.ReadOnly
+	if opts.OwnerLabel != "" {
+		cfg.OwnerLabel = opts.OwnerLabel
+	}
+	cfg.LabelColumns = opts.LabelColumns
+	cfg.CPUAlertThreshold = opts.CPUAlert
+	cfg.MemoryAlertThreshold = opts.MemoryAlert
+	cfg.AlertBell = opts.AlertBell
+	cfg.ScalePresets = opts.ScalePresets
+	cfg.EventsFile = opts.EventsFile
+	cfg.EventsFileSize = opts.EventsFileSize * 1024 * 1024
+	cfg.StatsFile = opts.StatsFile
+	cfg.StatsFileSize = opts.StatsFileSize * 1024 * 1024
+	cfg.StatsInterval = time.Duration(opts.StatsInterval) * time.Second
+	cfg.Hooks = opts.Hooks
+	cfg.UndoWindow = time.Duration(opts.UndoWindow) * time.Second
+	cfg.RefreshUnfocused = opts.RefreshUnfocused
+	cfg.Notifications = opts.Notifications
+	if opts.UpdateCheck > 0 {
+		cfg.UpdateCheckInterval = time.Duration(opts.UpdateCheck) * time.Hour
 	}
 
 	if opts.MonitorMode != "" {
@@ -90,13 +173,34 @@ func config(opts options) (app.Config, error) {
 	return cfg, nil
 }
 
+//dockerSocketExists returns true if the socket of the default Docker host exists
+func dockerSocketExists() bool {
+	_, err := os.Stat(strings.TrimPrefix(docker.DefaultDockerHost, "unix://"))
+	return err == nil
+}
+
+//readConfigFile reads the given config file or, if none is given, the
+//default one if it exists
+func readConfigFile(path string) (app.Config, error) {
+	if path != "" {
+		return app.ReadConfigFile(path)
+	}
+	cfg, err := app.ReadConfigFile(app.DefaultConfigFile)
+	if os.IsNotExist(errors.Cause(err)) {
+		return app.Config{}, nil
+	}
+	return cfg, err
+}
+
 func showLoadingScreen(ctx context.Context, screen *ui.Screen, cfg app.Config) {
 	screen.Clear()
 	midscreen := screen.Dimensions().Width / 2
 	height := screen.Dimensions().Height
 	screen.RenderAtColumn(midscreen-len(connecting)/2, 1, ui.White(connecting))
 	screen.RenderLine(2, height-2, fmt.Sprintf("<blue>Dry Version:</> %s", ui.White(version.VERSION)))
-	if cfg.DockerHost != "" {
+	if cfg.Context != "" {
+		screen.RenderLine(2, height-1, fmt.Sprintf("<blue>Docker Context:</> %s", ui.White(cfg.Context)))
+	} else if cfg.DockerHost != "" {
 		screen.RenderLine(2, height-1, fmt.Sprintf("<blue>Docker Host:</> %s", ui.White(cfg.DockerHost)))
 	} else {
 		screen.RenderLine(2, height-1, ui.White("No Docker host"))
@@ -172,16 +276,22 @@ func main() {
 			log.Fatal(http.ListenAndServe("localhost:6060", nil))
 		}()
 	}
-	screen, err := ui.NewScreen(appui.DryTheme)
+	cfg, err := config(opts)
 	if err != nil {
-		log.Printf("Dry could not start: %s", err)
+		log.Println(err.Error())
 		return
 	}
-	cfg, err := config(opts)
+	theme, err := cfg.ColorTheme()
 	if err != nil {
 		log.Println(err.Error())
 		return
 	}
+	appui.DryTheme = theme
+	screen, err := ui.NewScreen(appui.DryTheme)
+	if err != nil {
+		log.Printf("Dry could not start: %s", err)
+		return
+	}
 
 	start := time.Now()
 	ctx, cancel := context.WithCancel(context.Background())
@@ -203,4 +313,7 @@ func main() {
 	}
 	app.RenderLoop(dry)
 	screen.Close()
+	if err := dry.SaveUIState(); err != nil {
+		log.Printf("Dry could not save the UI state: %s", err)
+	}
 }
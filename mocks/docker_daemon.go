@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"io"
 	"strconv"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
@@ -23,6 +24,16 @@ func (_m *DockerDaemonMock) ContainerByID(id string) *drydocker.Container {
 	return nil
 }
 
+//ContainerFileContent mock
+func (_m *DockerDaemonMock) ContainerFileContent(id, file string, maxSize int64) (string, error) {
+	return "", nil
+}
+
+//ContainerFiles mock
+func (_m *DockerDaemonMock) ContainerFiles(id, dir string) ([]drydocker.ContainerFile, error) {
+	return nil, nil
+}
+
 //Containers mock
 func (_m *DockerDaemonMock) Containers(filters []drydocker.ContainerFilter, mode drydocker.SortMode) []*drydocker.Container {
 
@@ -45,6 +56,34 @@ func (_m *DockerDaemonMock) Containers(filters []drydocker.ContainerFilter, mode
 	return containers
 }
 
+//CheckImageUpdates mock
+func (_m *DockerDaemonMock) CheckImageUpdates(images []types.ImageSummary) []drydocker.ImageUpdate {
+	return nil
+}
+
+//Cleanup mock
+func (_m *DockerDaemonMock) Cleanup(opts drydocker.CleanupOptions) (drydocker.CleanupReport, error) {
+	return drydocker.CleanupReport{}, nil
+}
+
+//Close mock
+func (_m *DockerDaemonMock) Close() error {
+	return nil
+}
+
+//DaemonWarnings mock
+func (_m *DockerDaemonMock) DaemonWarnings() ([]string, error) {
+	return nil, nil
+}
+
+//Ping mock
+func (_m *DockerDaemonMock) Ping() drydocker.DaemonStatus {
+	return drydocker.DaemonStatus{
+		APIVersion: "1.27",
+		Latency:    time.Millisecond,
+	}
+}
+
 //DiskUsage mock
 func (_m *DockerDaemonMock) DiskUsage() (types.DiskUsage, error) {
 	return types.DiskUsage{}, nil
@@ -61,6 +100,11 @@ func (_m *DockerDaemonMock) Events() (<-chan events.Message, chan<- struct{}, er
 	return nil, nil, nil
 }
 
+//ContainerEvents mock
+func (_m *DockerDaemonMock) ContainerEvents(id string, since time.Time) ([]events.Message, error) {
+	return nil, nil
+}
+
 //EventLog mock
 func (_m *DockerDaemonMock) EventLog() *drydocker.EventLog {
 	return nil
@@ -168,7 +212,7 @@ func (_m *DockerDaemonMock) Kill(id string) error {
 }
 
 // Logs provides a mock function with given fields: id
-func (_m *DockerDaemonMock) Logs(id, since string, ts bool) (io.ReadCloser, error) {
+func (_m *DockerDaemonMock) Logs(id string, opts drydocker.LogsOptions) (io.ReadCloser, error) {
 	return nil, nil
 }
 
@@ -192,6 +236,31 @@ func (_m *DockerDaemonMock) NetworkInspect(id string) (types.NetworkResource, er
 	return types.NetworkResource{}, nil
 }
 
+//Plugins mock
+func (_m *DockerDaemonMock) Plugins() ([]*types.Plugin, error) {
+	return nil, nil
+}
+
+//PluginInspect mock
+func (_m *DockerDaemonMock) PluginInspect(name string) (*types.Plugin, error) {
+	return &types.Plugin{}, nil
+}
+
+//PluginEnable mock
+func (_m *DockerDaemonMock) PluginEnable(name string) error {
+	return nil
+}
+
+//PluginDisable mock
+func (_m *DockerDaemonMock) PluginDisable(name string, force bool) error {
+	return nil
+}
+
+//ImagePrePull mock
+func (_m *DockerDaemonMock) ImagePrePull(image, nodeLabel string, report func(drydocker.PrePullResult)) error {
+	return nil
+}
+
 //Node mock
 func (_m *DockerDaemonMock) Node(id string) (*swarm.Node, error) {
 	return nil, nil
@@ -202,6 +271,16 @@ func (_m *DockerDaemonMock) NodeChangeAvailability(nodeID string, availability s
 	return nil
 }
 
+//NodeChangeLabels mock
+func (_m *DockerDaemonMock) NodeChangeLabels(nodeID string, labels map[string]string) error {
+	return nil
+}
+
+//NodeChangeRole mock
+func (_m *DockerDaemonMock) NodeChangeRole(nodeID string, role swarm.NodeRole) error {
+	return nil
+}
+
 //Nodes mock
 func (_m *DockerDaemonMock) Nodes() ([]swarm.Node, error) {
 	return nil, nil
@@ -228,17 +307,64 @@ func (_m *DockerDaemonMock) Prune() (*drydocker.PruneReport, error) {
 	return nil, nil
 }
 
+//CloneContainer mock
+func (_m *DockerDaemonMock) CloneContainer(id, image string, opts drydocker.RunOptions) (string, error) {
+	return "", nil
+}
+
+//RecreateContainer mock
+func (_m *DockerDaemonMock) RecreateContainer(id string, pull bool) (string, error) {
+	return id, nil
+}
+
 // RestartContainer provides a mock function with given fields: id
 func (_m *DockerDaemonMock) RestartContainer(id string) error {
 
 	return nil
 }
 
+//RunHealthcheck mock
+func (_m *DockerDaemonMock) RunHealthcheck(id string) (drydocker.HealthcheckResult, error) {
+	return drydocker.HealthcheckResult{}, nil
+}
+
+//SampleStats mock
+func (_m *DockerDaemonMock) SampleStats() []drydocker.StatsRecord {
+	return nil
+}
+
+//UpdateResources mock
+func (_m *DockerDaemonMock) UpdateResources(id string, r drydocker.ContainerResources) error {
+	return nil
+}
+
+//UpdateRestartPolicy mock
+func (_m *DockerDaemonMock) UpdateRestartPolicy(id string, policy container.RestartPolicy) error {
+	return nil
+}
+
 // Rm provides a mock function with given fields: id
 func (_m *DockerDaemonMock) Rm(id string) error {
 	return nil
 }
 
+//RemoveImages mock
+func (_m *DockerDaemonMock) RemoveImages(ids []string, force bool, removed func(id string, err error)) {
+	for _, id := range ids {
+		removed(id, nil)
+	}
+}
+
+//PruneBuildCache mock
+func (_m *DockerDaemonMock) PruneBuildCache(keepStorage int64) (*types.BuildCachePruneReport, error) {
+	return &types.BuildCachePruneReport{}, nil
+}
+
+//RemoveBuildCache mock
+func (_m *DockerDaemonMock) RemoveBuildCache(id string) (uint64, error) {
+	return 0, nil
+}
+
 // Rmi mock
 func (_m *DockerDaemonMock) Rmi(id string, force bool) ([]types.ImageDeleteResponseItem, error) {
 	return nil, nil
@@ -275,6 +401,21 @@ func (_m *DockerDaemonMock) RemoveNetwork(id string) error {
 	return nil
 }
 
+//CreateNetwork mock
+func (_m *DockerDaemonMock) CreateNetwork(opts drydocker.NetworkOptions) (string, error) {
+	return "", nil
+}
+
+//ConnectToNetwork mock
+func (_m *DockerDaemonMock) ConnectToNetwork(networkID, container string, aliases []string, ip string) error {
+	return nil
+}
+
+//DisconnectFromNetwork mock
+func (_m *DockerDaemonMock) DisconnectFromNetwork(networkID, container string) error {
+	return nil
+}
+
 //RemoveUnusedImages mock
 func (_m *DockerDaemonMock) RemoveUnusedImages() (int, error) {
 	return 0, nil
@@ -290,18 +431,53 @@ func (_m *DockerDaemonMock) ResolveService(id string) (string, error) {
 	return "", nil
 }
 
-//RunImage mock
-func (_m *DockerDaemonMock) RunImage(image types.ImageSummary, command string) error {
+//ExportImages mock
+func (_m *DockerDaemonMock) ExportImages(refs []string, path string, format drydocker.ImageExportFormat) error {
 	return nil
 }
 
+//PullEstimate mock
+func (_m *DockerDaemonMock) PullEstimate(image string) (drydocker.PullEstimate, error) {
+	return drydocker.PullEstimate{Image: image}, nil
+}
+
+//PullImage mock
+func (_m *DockerDaemonMock) PullImage(image string) error {
+	return nil
+}
+
+//PullImageWithAuth mock
+func (_m *DockerDaemonMock) PullImageWithAuth(image string, auth drydocker.RegistryAuth) error {
+	return nil
+}
+
+//RunImage mock
+func (_m *DockerDaemonMock) RunImage(image types.ImageSummary, opts drydocker.RunOptions) (string, error) {
+	return "", nil
+}
+
 //Service mock
 func (_m *DockerDaemonMock) Service(id string) (*swarm.Service, error) {
 	return nil, nil
 }
 
+//ServiceChangeLabels mock
+func (_m *DockerDaemonMock) ServiceChangeLabels(id string, labels map[string]string) error {
+	return nil
+}
+
+//ServiceChangePlacement mock
+func (_m *DockerDaemonMock) ServiceChangePlacement(id string, constraints []string, preferences []swarm.PlacementPreference) error {
+	return nil
+}
+
+//ServiceDNSLookup mock
+func (_m *DockerDaemonMock) ServiceDNSLookup(serviceID, networkID string) (*drydocker.ServiceDNSReport, error) {
+	return nil, nil
+}
+
 //ServiceLogs mock
-func (_m *DockerDaemonMock) ServiceLogs(id, since string, ts bool) (io.ReadCloser, error) {
+func (_m *DockerDaemonMock) ServiceLogs(id string, opts drydocker.LogsOptions) (io.ReadCloser, error) {
 	return nil, nil
 }
 
@@ -335,6 +511,11 @@ func (_m *DockerDaemonMock) StopContainer(id string) error {
 	return nil
 }
 
+//StopContainers mock
+func (_m *DockerDaemonMock) StopContainers(ids []string, kill bool) (int, error) {
+	return len(ids), nil
+}
+
 // Sort provides a mock function with given fields: sortMode
 func (_m *DockerDaemonMock) Sort(sortMode drydocker.SortMode) {
 
@@ -353,6 +534,11 @@ func (_m *DockerDaemonMock) Stacks() ([]drydocker.Stack, error) {
 	return nil, nil
 }
 
+//ConfigContent mock
+func (_m *DockerDaemonMock) ConfigContent(id string) ([]byte, error) {
+	return nil, nil
+}
+
 //StackConfigs mock
 func (_m *DockerDaemonMock) StackConfigs(stack string) ([]swarm.Config, error) {
 	return nil, nil
@@ -383,17 +569,57 @@ func (_m *DockerDaemonMock) StackTasks(stack string) ([]swarm.Task, error) {
 	return nil, nil
 }
 
+//SwarmInit mock
+func (_m *DockerDaemonMock) SwarmInit(advertiseAddr string) (string, error) {
+	return "", nil
+}
+
+//SwarmInspect mock
+func (_m *DockerDaemonMock) SwarmInspect() (swarm.Swarm, error) {
+	return swarm.Swarm{}, nil
+}
+
+//SwarmJoin mock
+func (_m *DockerDaemonMock) SwarmJoin(token, managerAddr string) error {
+	return nil
+}
+
+//SwarmLeave mock
+func (_m *DockerDaemonMock) SwarmLeave(force bool) error {
+	return nil
+}
+
+//SwarmRotateCA mock
+func (_m *DockerDaemonMock) SwarmRotateCA() error {
+	return nil
+}
+
+//SwarmRotateJoinToken mock
+func (_m *DockerDaemonMock) SwarmRotateJoinToken(manager bool) error {
+	return nil
+}
+
 //Task empty mock
 func (_m *DockerDaemonMock) Task(id string) (swarm.Task, error) {
 	return swarm.Task{}, nil
 }
 
+//TaskLogs mock
+func (_m *DockerDaemonMock) TaskLogs(id string, opts drydocker.LogsOptions) (io.ReadCloser, error) {
+	return nil, nil
+}
+
 //Top function mock
 func (_m *DockerDaemonMock) Top(ctx context.Context, id string) (container.ContainerTopOKBody, error) {
 
 	return container.ContainerTopOKBody{}, nil
 }
 
+//UnusedSince mock
+func (_m *DockerDaemonMock) UnusedSince(source drydocker.SourceType, id string) (time.Time, bool) {
+	return time.Time{}, false
+}
+
 // Version provides a mock function with given fields:
 func (_m *DockerDaemonMock) Version() (*types.Version, error) {
 	return &types.Version{
@@ -34,6 +34,27 @@ func NewSearch(text [][]rune, pattern string) (*Result, error) {
 	return nil, errors.New("Nothing to search in an empty text")
 }
 
+//Add adds the line with the given number to the search, if the line matches
+//the search pattern it is added to the hits. Lines are expected to be added
+//in order, a line already found as a hit is ignored.
+func (result *Result) Add(lineNumber int, line []rune) bool {
+	if n := len(result.Lines); n > 0 && result.Lines[n-1] >= lineNumber {
+		return false
+	}
+	if !strings.Contains(string(line), result.Pattern) {
+		return false
+	}
+	result.Hits++
+	result.Lines = append(result.Lines, lineNumber)
+	return true
+}
+
+//Position returns the position, starting at 1, of the current hit while
+//iterating the search results, 0 if the iteration has not started
+func (result *Result) Position() int {
+	return result.index + 1
+}
+
 func (result *Result) String() string {
 	if result.Hits > 0 {
 		return fmt.Sprintf("Pattern %s found %d times", result.Pattern, result.Hits)
@@ -113,3 +113,20 @@ func testText() [][]rune {
 		[]rune("line 10"),
 		[]rune("lin 11")}
 }
+
+//TestResultAdd tests adding lines to a search result
+func TestResultAdd(t *testing.T) {
+	rs, _ := NewSearch(testText(), searchPattern)
+	if !rs.Add(10, []rune("another line")) {
+		t.Error("Expected line to be a hit")
+	}
+	if rs.Add(10, []rune("another line")) {
+		t.Error("Expected an already added line to be ignored")
+	}
+	if rs.Add(11, []rune("nope")) {
+		t.Error("Expected line not to be a hit")
+	}
+	if rs.Hits != 6 || rs.Lines[5] != 10 {
+		t.Errorf("Unexpected search result after adding lines: %v", rs.Lines)
+	}
+}
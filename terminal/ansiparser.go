@@ -19,12 +19,40 @@ type ansiParser struct {
 	instructions         []string
 	instructionStartedAt int
 	buffer               buffer
+	text                 []byte
+	withStyles           bool
+	style                Style
+	styled               []styledRunes
+}
+
+//styledRunes is StyledText while it is being parsed
+type styledRunes struct {
+	text  []rune
+	style Style
 }
 
 //RemoveANSIEscapeCharacters removes from the given string ANSI escape codes
 func RemoveANSIEscapeCharacters(s string) [][]rune {
 	p := ansiParser{mode: modeNormal} //, ansi: ansi}
+	p.parse(s)
+	return p.buffer.content
+}
+
+//ParseANSI splits the given line in pieces of text with the style set by the
+//SGR escape codes found on it, other ANSI escape codes are removed
+func ParseANSI(s string) []StyledText {
+	p := ansiParser{mode: modeNormal, withStyles: true, style: DefaultStyle}
+	p.parse(s)
+	styled := make([]StyledText, len(p.styled))
+	for i, s := range p.styled {
+		styled[i] = StyledText{Text: string(s.text), Style: s.style}
+	}
+	return styled
+}
+
+func (p *ansiParser) parse(s string) {
 	text := []byte(s)
+	p.text = text
 	length := len(text)
 	for p.cursor = 0; p.cursor < length; {
 		char, charLen := utf8.DecodeRune(text[p.cursor:])
@@ -43,18 +71,29 @@ func RemoveANSIEscapeCharacters(s string) [][]rune {
 
 		p.cursor += charLen
 	}
-	return p.buffer.content
 }
 
 func (p *ansiParser) handleEscape(char rune) {
+	if char == 'm' {
+		//SGR, the instructions are the text since the escape sequence started
+		if p.withStyles {
+			p.style = p.style.apply(string(p.text[p.escapeStartedAt+2 : p.cursor]))
+		}
+		p.mode = modeNormal
+		return
+	}
 	char = unicode.ToUpper(char)
-	switch char {
-	case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+	switch {
+	case char >= '0' && char <= '9', char == '?', char == '<', char == '=', char == '>':
 		// Part of an instruction
-	case ';':
+	case char == ';':
 		//p.addInstruction()
 		p.instructionStartedAt = p.cursor + utf8.RuneLen(';')
-	case 'Q', 'K', 'G', 'A', 'B', 'C', 'D', 'M':
+	case char >= ' ' && char <= '/':
+		// Intermediate bytes
+	case char >= '@' && char <= '~':
+		// Final byte, this parser does not support cursor movements so
+		// the escape sequence is just removed
 		//p.addInstruction()
 		//p.applyEscape(char, p.instructions)
 		p.mode = modeNormal
@@ -74,8 +113,22 @@ func (p *ansiParser) handleNormal(char rune) {
 		p.escapeStartedAt = p.cursor
 		p.mode = modePreEscape
 	default:
-		p.buffer.append(char)
+		if p.withStyles {
+			p.appendStyled(char)
+		} else {
+			p.buffer.append(char)
+		}
+	}
+}
+
+//appendStyled adds the given char to the styled text, using the current style
+func (p *ansiParser) appendStyled(char rune) {
+	last := len(p.styled) - 1
+	if last >= 0 && p.styled[last].style == p.style {
+		p.styled[last].text = append(p.styled[last].text, char)
+		return
 	}
+	p.styled = append(p.styled, styledRunes{text: []rune{char}, style: p.style})
 }
 
 func (p *ansiParser) handlePreEscape(char rune) {
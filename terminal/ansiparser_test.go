@@ -41,3 +41,33 @@ func BenchmarkRemoveEscapeCharacters(b *testing.B) {
 	}
 
 }
+
+func TestParseANSI(t *testing.T) {
+	text := "plain " + string([]byte{27, 91, 49, 59, 51, 49, 109}) + "red" + reset + " " +
+		string([]byte{27, 91, 51, 56, 59, 53, 59, 50, 48, 56, 109}) + "orange" +
+		string([]byte{27, 91, 50, 74}) + reset
+
+	want := []StyledText{
+		{"plain ", DefaultStyle},
+		{"red", Style{Fg: 1, Bg: ColorDefault, Bold: true}},
+		{" ", DefaultStyle},
+		{"orange", Style{Fg: 208, Bg: ColorDefault}},
+	}
+	got := ParseANSI(text)
+	if len(got) != len(want) {
+		t.Fatalf("Parsing returned wrong number of styled texts, expected: %d, got: %d (%v)", len(want), len(got), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Unexpected styled text at %d, expected: %v, got: %v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestRemoveUnsupportedEscapeCharacters(t *testing.T) {
+	text := string([]byte{27, 91, 50, 74}) + testText + string([]byte{27, 91, 63, 50, 53, 108})
+	parsed := RemoveANSIEscapeCharacters(text)
+	if len(parsed) != 1 || string(parsed[0]) != testText {
+		t.Errorf("Parsing did not work, result: %q", parsed)
+	}
+}
@@ -0,0 +1,121 @@
+package terminal
+
+import (
+	"strconv"
+	"strings"
+)
+
+//Color is a color set by an ANSI escape sequence, either one of the 256 colors
+//of the terminal palette, a RGB color or the terminal default
+type Color int32
+
+//ColorDefault is the default terminal color
+const ColorDefault Color = -1
+
+//rgbFlag marks colors given as RGB values
+const rgbFlag = 1 << 24
+
+//RGB returns the color with the given red, green and blue values
+func RGB(r, g, b int) Color {
+	return Color(rgbFlag | (r&0xff)<<16 | (g&0xff)<<8 | b&0xff)
+}
+
+//IsRGB returns true if the color is given as RGB values
+func (c Color) IsRGB() bool {
+	return c >= 0 && c&rgbFlag != 0
+}
+
+//RGB returns the red, green and blue values of a RGB color
+func (c Color) RGB() (int, int, int) {
+	return int(c>>16) & 0xff, int(c>>8) & 0xff, int(c) & 0xff
+}
+
+//Style is the style set by SGR (Select Graphic Rendition) escape sequences
+type Style struct {
+	Fg        Color
+	Bg        Color
+	Bold      bool
+	Underline bool
+	Reverse   bool
+}
+
+//DefaultStyle is the style of text not affected by any SGR escape sequence
+var DefaultStyle = Style{Fg: ColorDefault, Bg: ColorDefault}
+
+//StyledText is a piece of text with the style it is shown with
+type StyledText struct {
+	Text  string
+	Style Style
+}
+
+//apply applies the given SGR parameters to the style
+func (s Style) apply(params string) Style {
+	codes := strings.Split(params, ";")
+	for i := 0; i < len(codes); i++ {
+		code, err := strconv.Atoi(codes[i])
+		if err != nil {
+			//An empty parameter is a reset
+			if codes[i] != "" {
+				continue
+			}
+			code = 0
+		}
+		switch {
+		case code == 0:
+			s = DefaultStyle
+		case code == 1:
+			s.Bold = true
+		case code == 4:
+			s.Underline = true
+		case code == 7:
+			s.Reverse = true
+		case code == 22:
+			s.Bold = false
+		case code == 24:
+			s.Underline = false
+		case code == 27:
+			s.Reverse = false
+		case code >= 30 && code <= 37:
+			s.Fg = Color(code - 30)
+		case code == 38:
+			var c Color
+			c, i = extendedColor(codes, i)
+			s.Fg = c
+		case code == 39:
+			s.Fg = ColorDefault
+		case code >= 40 && code <= 47:
+			s.Bg = Color(code - 40)
+		case code == 48:
+			var c Color
+			c, i = extendedColor(codes, i)
+			s.Bg = c
+		case code == 49:
+			s.Bg = ColorDefault
+		case code >= 90 && code <= 97:
+			s.Fg = Color(code - 90 + 8)
+		case code >= 100 && code <= 107:
+			s.Bg = Color(code - 100 + 8)
+		}
+	}
+	return s
+}
+
+//extendedColor parses the 256 (5;n) or RGB (2;r;g;b) color that follows
+//the parameter at the given index, returns the color and the index of the
+//last parameter used
+func extendedColor(codes []string, i int) (Color, int) {
+	atoi := func(i int) int {
+		if i >= len(codes) {
+			return 0
+		}
+		n, _ := strconv.Atoi(codes[i])
+		return n
+	}
+	switch atoi(i + 1) {
+	case 5:
+		return Color(atoi(i+2) & 0xff), i + 2
+	case 2:
+		return RGB(atoi(i+2), atoi(i+3), atoi(i+4)), i + 4
+	}
+	return ColorDefault, i + 1
+}
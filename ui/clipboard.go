@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+//clipboardCommands are the commands tried, in order, to copy text to the
+//system clipboard
+var clipboardCommands = [][]string{
+	{"pbcopy"},
+	{"wl-copy"},
+	{"xclip", "-selection", "clipboard"},
+	{"xsel", "--clipboard", "--input"},
+	{"clip.exe"},
+}
+
+//clipboardOutput is where OSC 52 escape sequences are written
+var clipboardOutput io.Writer = activeScreenWriter{}
+
+//getenv is used to look for the environment variables of SSH and tmux sessions
+var getenv = os.Getenv
+
+//CopyToClipboard copies the given text to the system clipboard. On SSH
+//sessions, or if no clipboard utility copies it, i.e. xclip without a
+//display, the text is copied using the OSC 52 escape sequence, so the
+//terminal copies it to the clipboard of the machine it runs on.
+func CopyToClipboard(text string) error {
+	if sshSession() {
+		return copyWithOSC52(text)
+	}
+	for _, command := range clipboardCommands {
+		path, err := exec.LookPath(command[0])
+		if err != nil {
+			continue
+		}
+		cmd := exec.Command(path, command[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		if err := cmd.Run(); err == nil {
+			return nil
+		}
+	}
+	return copyWithOSC52(text)
+}
+
+//sshSession returns true if dry runs on an SSH session, the clipboard
+//utilities found, if any, would copy to the clipboard of the remote host
+func sshSession() bool {
+	return getenv("SSH_TTY") != "" || getenv("SSH_CONNECTION") != ""
+}
+
+//copyWithOSC52 asks the terminal to copy the given text to the clipboard,
+//terminals not supporting OSC 52 ignore it
+func copyWithOSC52(text string) error {
+	_, err := io.WriteString(clipboardOutput, osc52(text, getenv("TMUX") != ""))
+	return err
+}
+
+//osc52 returns the OSC 52 escape sequence that copies the given text to the
+//clipboard, wrapped to be passed through tmux to the terminal if needed
+func osc52(text string, tmux bool) string {
+	seq := fmt.Sprintf("\033]52;c;%s\a", base64.StdEncoding.EncodeToString([]byte(text)))
+	if tmux {
+		return "\033Ptmux;\033" + seq + "\033\\"
+	}
+	return seq
+}
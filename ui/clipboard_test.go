@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestCopyToClipboardOnSSHSessionsUsesOSC52(t *testing.T) {
+	var out bytes.Buffer
+	defer func(w io.Writer, env func(string) string) {
+		clipboardOutput = w
+		getenv = env
+	}(clipboardOutput, getenv)
+	clipboardOutput = &out
+	getenv = func(key string) string {
+		if key == "SSH_TTY" {
+			return "/dev/pts/0"
+		}
+		return ""
+	}
+
+	if err := CopyToClipboard("dry"); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if out.String() != "\033]52;c;ZHJ5\a" {
+		t.Errorf("Unexpected escape sequence: %q", out.String())
+	}
+}
+
+func TestOSC52ThroughTmux(t *testing.T) {
+	if seq := osc52("dry", true); seq != "\033Ptmux;\033\033]52;c;ZHJ5\a\033\\" {
+		t.Errorf("Unexpected escape sequence: %q", seq)
+	}
+}
+
+func TestCopyToClipboardTriesEveryUtility(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not found")
+	}
+	dir, err := ioutil.TempDir("", "dry-clipboard")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	copied := filepath.Join(dir, "clipboard")
+
+	var out bytes.Buffer
+	defer func(w io.Writer, env func(string) string, commands [][]string) {
+		clipboardOutput = w
+		getenv = env
+		clipboardCommands = commands
+	}(clipboardOutput, getenv, clipboardCommands)
+	clipboardOutput = &out
+	getenv = func(string) string { return "" }
+
+	clipboardCommands = [][]string{{"sh", "-c", "exit 1"}, {"sh", "-c", "cat > " + copied}}
+	if err := CopyToClipboard("dry"); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if text, _ := ioutil.ReadFile(copied); string(text) != "dry" || out.Len() > 0 {
+		t.Errorf("Text not copied by the second utility, got %q, OSC 52: %q", text, out.String())
+	}
+
+	clipboardCommands = [][]string{{"sh", "-c", "exit 1"}}
+	if err := CopyToClipboard("dry"); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if out.String() != "\033]52;c;ZHJ5\a" {
+		t.Errorf("Failed utility did not fall back to OSC 52, got %q", out.String())
+	}
+}
@@ -1,5 +1,12 @@
 package ui
 
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
 //Color representation
 type Color uint32
 
@@ -548,3 +555,15 @@ var colorNames = map[string]Color{
 func ColorFromName(name string) Color {
 	return colorNames[name]
 }
+
+//ParseColor returns the Color with the given name or palette number (0-255)
+func ParseColor(s string) (Color, error) {
+	if c, ok := colorNames[strings.ToLower(s)]; ok {
+		return c, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 || n > 255 {
+		return 0, errors.Errorf("invalid color %q, expected a color name or a number between 0 and 255", s)
+	}
+	return Color(n), nil
+}
@@ -94,12 +94,18 @@ func (cursor *Cursor) Top() {
 
 }
 
-//Max sets the max position allowed to this cursor
+//Max sets the max position allowed to this cursor, the cursor is moved back
+//to max if it is beyond it
 func (cursor *Cursor) Max(max int) {
 	cursor.Lock()
 	defer cursor.Unlock()
 	cursor.max = max
 	cursor.unlimited = false
+	if max < 0 {
+		cursor.pos = 0
+	} else if cursor.pos > max {
+		cursor.pos = max
+	}
 }
 
 func (cursor *Cursor) String() string {
@@ -49,6 +49,16 @@ func TestScrollingLimits(t *testing.T) {
 		t.Errorf("Cursor is not at expected position after trying to scroll further than the max, %s", c.String())
 	}
 
+	c.Max(1)
+	if c.Position() != 1 {
+		t.Errorf("Cursor is not moved back to a lower max, %s", c.String())
+	}
+
+	c.Max(-1)
+	if c.Position() != 0 {
+		t.Errorf("Cursor is not moved to the top when there is nothing to move over, %s", c.String())
+	}
+
 }
 
 func TestScrolling(t *testing.T) {
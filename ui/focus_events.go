@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell"
+)
+
+//EventFocus is sent when the terminal gains or loses the focus, only on
+//terminals reporting focus changes once EnableFocusReporting is called
+type EventFocus struct {
+	t       time.Time
+	Focused bool
+}
+
+//When returns the time when this event was created
+func (ev *EventFocus) When() time.Time {
+	return ev.t
+}
+
+//EnableFocusReporting asks the terminal to report when it gains or loses the focus
+func EnableFocusReporting() {
+	fmt.Fprint(titleOutput, "\033[?1004h")
+}
+
+//DisableFocusReporting asks the terminal to stop reporting focus changes
+func DisableFocusReporting() {
+	fmt.Fprint(titleOutput, "\033[?1004l")
+}
+
+//focusParser turns the focus reports of the terminal (ESC [ I and ESC [ O),
+//that tcell does not know about and delivers as Alt+[ followed by I or O,
+//into focus events
+type focusParser struct {
+	//pending is an Alt+[ key that might start a focus report
+	pending tcell.Event
+}
+
+//parse returns the events to deliver for the given event
+func (p *focusParser) parse(ev tcell.Event) []tcell.Event {
+	key, isKey := ev.(*tcell.EventKey)
+	if p.pending != nil {
+		pending := p.pending
+		p.pending = nil
+		if isKey && key.Key() == tcell.KeyRune && key.Modifiers() == tcell.ModNone {
+			switch key.Rune() {
+			case 'I':
+				return []tcell.Event{&EventFocus{t: key.When(), Focused: true}}
+			case 'O':
+				return []tcell.Event{&EventFocus{t: key.When(), Focused: false}}
+			}
+		}
+		return append([]tcell.Event{pending}, p.parse(ev)...)
+	}
+	if isKey && key.Key() == tcell.KeyRune && key.Rune() == '[' && key.Modifiers() == tcell.ModAlt {
+		p.pending = ev
+		return nil
+	}
+	return []tcell.Event{ev}
+}
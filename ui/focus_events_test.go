@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell"
+)
+
+func TestFocusParser(t *testing.T) {
+	altBracket := tcell.NewEventKey(tcell.KeyRune, '[', tcell.ModAlt)
+	key := func(r rune) *tcell.EventKey {
+		return tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone)
+	}
+	var p focusParser
+
+	if evs := p.parse(key('I')); len(evs) != 1 || evs[0].(*tcell.EventKey).Rune() != 'I' {
+		t.Errorf("A key was not delivered as it is: %v", evs)
+	}
+	if evs := p.parse(altBracket); len(evs) != 0 {
+		t.Errorf("The start of a focus report was delivered: %v", evs)
+	}
+	evs := p.parse(key('O'))
+	if len(evs) != 1 {
+		t.Fatalf("Unexpected events for a focus out report: %v", evs)
+	}
+	if focus, ok := evs[0].(*EventFocus); !ok || focus.Focused {
+		t.Errorf("Focus out report not parsed: %v", evs[0])
+	}
+	p.parse(altBracket)
+	evs = p.parse(key('I'))
+	if focus, ok := evs[0].(*EventFocus); len(evs) != 1 || !ok || !focus.Focused {
+		t.Errorf("Focus in report not parsed: %v", evs)
+	}
+
+	//Alt+[ not followed by I or O is delivered along with the next key
+	p.parse(altBracket)
+	evs = p.parse(key('x'))
+	if len(evs) != 2 || evs[0] != altBracket || evs[1].(*tcell.EventKey).Rune() != 'x' {
+		t.Errorf("Keys not delivered: %v", evs)
+	}
+	p.parse(altBracket)
+	evs = p.parse(altBracket)
+	if len(evs) != 1 || evs[0] != altBracket {
+		t.Errorf("Unexpected events for Alt+[ pressed twice: %v", evs)
+	}
+	if evs := p.parse(key('O')); len(evs) != 1 {
+		t.Errorf("Unexpected events after Alt+[ pressed twice: %v", evs)
+	}
+}
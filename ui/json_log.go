@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//jsonLogKeys are the keys shown first when rendering JSON logs, in this order
+var jsonLogKeys = []string{"@timestamp", "timestamp", "time", "ts", "level", "lvl", "severity", "msg", "message"}
+
+//jsonField is a field of a JSON log line, keys of nested objects are joined with dots
+type jsonField struct {
+	key   string
+	value string
+	//quoted is true if the value is a JSON string
+	quoted bool
+}
+
+//jsonLog is a log line holding a JSON object, the object might be prefixed
+//by something else, i.e. a timestamp
+type jsonLog struct {
+	prefix string
+	fields []jsonField
+}
+
+//parseJSONLog parses the given line as a JSON log line, returns false if the
+//line does not end with a JSON object
+func parseJSONLog(line string) (jsonLog, bool) {
+	start := strings.Index(line, "{")
+	if start < 0 {
+		return jsonLog{}, false
+	}
+	decoder := json.NewDecoder(strings.NewReader(line[start:]))
+	decoder.UseNumber()
+	var object map[string]interface{}
+	if err := decoder.Decode(&object); err != nil {
+		return jsonLog{}, false
+	}
+	if strings.TrimSpace(line[start+int(decoder.InputOffset()):]) != "" {
+		return jsonLog{}, false
+	}
+	log := jsonLog{prefix: line[:start]}
+	log.fields = flattenJSON("", object, log.fields)
+	sort.SliceStable(log.fields, func(i, j int) bool {
+		pi, pj := jsonKeyPriority(log.fields[i].key), jsonKeyPriority(log.fields[j].key)
+		if pi != pj {
+			return pi < pj
+		}
+		return log.fields[i].key < log.fields[j].key
+	})
+	return log, true
+}
+
+func flattenJSON(prefix string, object map[string]interface{}, fields []jsonField) []jsonField {
+	for key, value := range object {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		switch v := value.(type) {
+		case map[string]interface{}:
+			fields = flattenJSON(key, v, fields)
+		case string:
+			fields = append(fields, jsonField{key: key, value: v, quoted: true})
+		case nil:
+			fields = append(fields, jsonField{key: key, value: "null"})
+		case []interface{}:
+			b, _ := json.Marshal(v)
+			fields = append(fields, jsonField{key: key, value: string(b)})
+		default:
+			fields = append(fields, jsonField{key: key, value: fmt.Sprint(v)})
+		}
+	}
+	return fields
+}
+
+func jsonKeyPriority(key string) int {
+	for i, k := range jsonLogKeys {
+		if strings.EqualFold(k, key) {
+			return i
+		}
+	}
+	return len(jsonLogKeys)
+}
+
+//field returns the field with the given key
+func (l jsonLog) field(key string) (jsonField, bool) {
+	for _, f := range l.fields {
+		if f.key == key {
+			return f, true
+		}
+	}
+	return jsonField{}, false
+}
+
+//String renders the field as key=value, values are quoted if needed
+func (f jsonField) String() string {
+	value := f.value
+	if f.quoted && (value == "" || strings.ContainsAny(value, " \t\"=")) {
+		value = fmt.Sprintf("%q", value)
+	}
+	return f.key + "=" + value
+}
+
+//jsonFilter filters JSON log lines by the value of one of their fields
+type jsonFilter struct {
+	key   string
+	value string
+}
+
+//parseJSONFilter parses a filter given as field=value
+func parseJSONFilter(s string) (*jsonFilter, error) {
+	i := strings.Index(s, "=")
+	if i <= 0 {
+		return nil, fmt.Errorf("invalid JSON filter %q, expected field=value", s)
+	}
+	return &jsonFilter{key: strings.TrimSpace(s[:i]), value: strings.TrimSpace(s[i+1:])}, nil
+}
+
+//matches returns true if the given log has the field of the filter with the
+//filter value, values are compared ignoring case
+func (f *jsonFilter) matches(l jsonLog) bool {
+	field, ok := l.field(f.key)
+	return ok && strings.EqualFold(field.value, f.value)
+}
+
+func (f *jsonFilter) String() string {
+	return f.key + "=" + f.value
+}
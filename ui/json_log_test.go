@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"testing"
+)
+
+func TestParseJSONLog(t *testing.T) {
+	log, ok := parseJSONLog(`2020-04-01T10:00:00Z {"msg": "started server", "port": 80, "level": "info", "http": {"tls": false}}`)
+	if !ok {
+		t.Fatal("Expected line to be parsed as a JSON log")
+	}
+	if log.prefix != "2020-04-01T10:00:00Z " {
+		t.Errorf("Unexpected prefix: %q", log.prefix)
+	}
+	want := []string{`level=info`, `msg="started server"`, `http.tls=false`, `port=80`}
+	if len(log.fields) != len(want) {
+		t.Fatalf("Expected %d fields, got %v", len(want), log.fields)
+	}
+	for i, field := range log.fields {
+		if field.String() != want[i] {
+			t.Errorf("Unexpected field %d, got %s, want %s", i, field.String(), want[i])
+		}
+	}
+
+	for _, line := range []string{"plain text", `{"msg": "truncated`, `{"msg": "a"} trailing`} {
+		if _, ok := parseJSONLog(line); ok {
+			t.Errorf("Line %q should not be parsed as a JSON log", line)
+		}
+	}
+}
+
+func TestJSONFilter(t *testing.T) {
+	log, _ := parseJSONLog(`{"level": "ERROR", "msg": "failed"}`)
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{"level=error", true},
+		{"level = error", true},
+		{"level=info", false},
+		{"code=500", false},
+	}
+	for _, tt := range tests {
+		f, err := parseJSONFilter(tt.filter)
+		if err != nil {
+			t.Fatalf("Unexpected error parsing filter %q: %s", tt.filter, err)
+		}
+		if got := f.matches(log); got != tt.want {
+			t.Errorf("Filter %q matches: %t, want %t", tt.filter, got, tt.want)
+		}
+	}
+	if _, err := parseJSONFilter("level"); err == nil {
+		t.Error("Expected an error parsing a filter without value")
+	}
+}
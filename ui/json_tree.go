@@ -0,0 +1,739 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gdamore/tcell"
+	"github.com/gdamore/tcell/termbox"
+	"github.com/mattn/go-runewidth"
+)
+
+type jsonKind int
+
+const (
+	jsonObject jsonKind = iota
+	jsonArray
+	jsonString
+	jsonScalar
+)
+
+//identifierKey matches the keys that can be used on a path without quoting
+var identifierKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+//jsonNode is a value of a JSON document shown on a JSONTree
+type jsonNode struct {
+	key string
+	//path is the path to the node from the root of the document, i.e. .NetworkSettings.Networks.bridge.IPAddress
+	path      string
+	kind      jsonKind
+	value     string
+	children  []*jsonNode
+	parent    *jsonNode
+	depth     int
+	collapsed bool
+}
+
+func (n *jsonNode) container() bool {
+	return n.kind == jsonObject || n.kind == jsonArray
+}
+
+//Path returns the path to the node, "." for the root of the document
+func (n *jsonNode) Path() string {
+	if n.path == "" {
+		return "."
+	}
+	return n.path
+}
+
+//text returns the value of the node, as shown on the tree
+func (n *jsonNode) text() string {
+	switch n.kind {
+	case jsonObject:
+		return fmt.Sprintf("{%d}", len(n.children))
+	case jsonArray:
+		return fmt.Sprintf("[%d]", len(n.children))
+	case jsonString:
+		return quote(n.value)
+	}
+	return n.value
+}
+
+//matches returns true if the key or the value of the node contain the given pattern
+func (n *jsonNode) matches(pattern string) bool {
+	return strings.Contains(n.key, pattern) || (!n.container() && strings.Contains(n.value, pattern))
+}
+
+//JSON returns the node as indented JSON, strings are returned unquoted
+func (n *jsonNode) JSON() string {
+	if n.kind == jsonString {
+		return n.value
+	}
+	var compact, indented bytes.Buffer
+	n.writeJSON(&compact)
+	if err := json.Indent(&indented, compact.Bytes(), "", "    "); err != nil {
+		return compact.String()
+	}
+	return indented.String()
+}
+
+func (n *jsonNode) writeJSON(buf *bytes.Buffer) {
+	switch n.kind {
+	case jsonObject:
+		buf.WriteByte('{')
+		for i, child := range n.children {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(quote(child.key))
+			buf.WriteByte(':')
+			child.writeJSON(buf)
+		}
+		buf.WriteByte('}')
+	case jsonArray:
+		buf.WriteByte('[')
+		for i, child := range n.children {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			child.writeJSON(buf)
+		}
+		buf.WriteByte(']')
+	case jsonString:
+		buf.WriteString(quote(n.value))
+	default:
+		buf.WriteString(n.value)
+	}
+}
+
+//walk calls the given func on the node and every node below it, in document order
+func (n *jsonNode) walk(f func(*jsonNode)) {
+	f(n)
+	for _, child := range n.children {
+		child.walk(f)
+	}
+}
+
+//quote returns the given string as a JSON string
+func quote(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+//parseJSONTree parses the given JSON document, keeping the order of the keys
+//of its objects. Only the root is expanded.
+func parseJSONTree(data []byte) (*jsonNode, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	root, err := parseJSONNode(dec, nil, "", "")
+	if err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, errors.New("invalid JSON: more than one value found")
+	}
+	root.walk(func(n *jsonNode) {
+		n.collapsed = n.depth > 0
+	})
+	return root, nil
+}
+
+func parseJSONNode(dec *json.Decoder, parent *jsonNode, key, path string) (*jsonNode, error) {
+	token, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	n := &jsonNode{key: key, path: path, parent: parent}
+	if parent != nil {
+		n.depth = parent.depth + 1
+	}
+	switch v := token.(type) {
+	case json.Delim:
+		switch v {
+		case '{':
+			n.kind = jsonObject
+			for dec.More() {
+				token, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				k, _ := token.(string)
+				child, err := parseJSONNode(dec, n, k, keyPath(path, k))
+				if err != nil {
+					return nil, err
+				}
+				n.children = append(n.children, child)
+			}
+		case '[':
+			n.kind = jsonArray
+			for i := 0; dec.More(); i++ {
+				child, err := parseJSONNode(dec, n, fmt.Sprintf("[%d]", i), indexPath(path, i))
+				if err != nil {
+					return nil, err
+				}
+				n.children = append(n.children, child)
+			}
+		default:
+			return nil, fmt.Errorf("invalid JSON: unexpected %s", v)
+		}
+		//the closing delimiter
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+	case string:
+		n.kind = jsonString
+		n.value = v
+	case json.Number:
+		n.kind = jsonScalar
+		n.value = v.String()
+	case bool:
+		n.kind = jsonScalar
+		n.value = strconv.FormatBool(v)
+	case nil:
+		n.kind = jsonScalar
+		n.value = "null"
+	}
+	return n, nil
+}
+
+//keyPath returns the path to the given key of the object on the given path,
+//keys that are not identifiers are quoted, i.e. .Config.Labels["com.docker.compose.project"]
+func keyPath(path, key string) string {
+	if identifierKey.MatchString(key) {
+		return path + "." + key
+	}
+	if path == "" {
+		path = "."
+	}
+	return path + "[" + quote(key) + "]"
+}
+
+//indexPath returns the path to the given element of the array on the given path
+func indexPath(path string, i int) string {
+	if path == "" {
+		path = "."
+	}
+	return fmt.Sprintf("%s[%d]", path, i)
+}
+
+//JSONTree shows a JSON document as a tree whose objects and arrays can be
+//expanded and collapsed. Keys and values can be searched, and the path to
+//the selected value, the value itself or the whole document can be copied
+//to the clipboard.
+type JSONTree struct {
+	root *jsonNode
+	//visible are the nodes shown, in document order
+	visible  []*jsonNode
+	header   []string
+	footer   []string
+	selected int
+	offset   int
+	pattern  string
+	message  string
+	width    int
+	height   int
+
+	screen         *Screen
+	theme          *ColorTheme
+	markup         *Markup
+	renderer       ScreenTextRenderer
+	defaultStyle   tcell.Style
+	keyStyle       tcell.Style
+	stringStyle    tcell.Style
+	scalarStyle    tcell.Style
+	searchHitStyle tcell.Style
+
+	inputBindings map[rune]inputBinding
+	refresh       chan struct{}
+	inputMode     bool
+	onInput       func(string)
+	inputEvents   chan *tcell.EventKey
+	inputOutput   chan string
+
+	sync.Mutex
+}
+
+//NewJSONTree creates a tree showing the given JSON document below the given
+//header, that might contain markup
+func NewJSONTree(theme *ColorTheme, header string, data []byte) (*JSONTree, error) {
+	root, err := parseJSONTree(data)
+	if err != nil {
+		return nil, err
+	}
+	sd := ActiveScreen.Dimensions()
+	t := &JSONTree{
+		root:   root,
+		width:  sd.Width,
+		height: sd.Height,
+		screen: ActiveScreen,
+		theme:  theme,
+		markup: NewMarkup(theme),
+	}
+	if header = strings.TrimRight(header, "\n"); header != "" {
+		t.header = strings.Split(header, "\n")
+		//at least half of the screen is kept for the tree
+		if max := t.height / 2; len(t.header) > max {
+			t.header = t.header[:max]
+		}
+		t.header = append(t.header, "")
+	}
+	bg := termbox.Attribute(theme.Bg)
+	t.renderer = NewRenderer(screenStyledRuneRenderer{ActiveScreen}).WithWidth(sd.Width)
+	t.defaultStyle = mkStyle(termbox.ColorWhite, bg)
+	t.keyStyle = mkStyle(termbox.Attribute(theme.Key), bg)
+	t.stringStyle = mkStyle(termbox.ColorGreen, bg)
+	t.scalarStyle = mkStyle(termbox.ColorCyan, bg)
+	t.searchHitStyle = mkStyle(termbox.ColorYellow, bg)
+	t.updateVisible()
+	return t, nil
+}
+
+//Focus sets the tree as active, so it starts handling terminal events
+//and user actions, it returns once the tree is closed with Esc
+func (t *JSONTree) Focus(events <-chan *tcell.EventKey) error {
+	refresh := make(chan struct{}, 1)
+	t.refresh = refresh
+	t.inputEvents = make(chan *tcell.EventKey)
+	t.inputOutput = make(chan string, 1)
+	t.refreshTree()
+
+	go func() {
+		for {
+			select {
+			case input := <-t.inputOutput:
+				t.inputMode = false
+				t.Lock()
+				t.onInput(input)
+				t.Unlock()
+				t.refreshTree()
+			case event := <-events:
+				if !t.handle(event) {
+					close(t.inputOutput)
+					close(t.inputEvents)
+					close(refresh)
+					return
+				}
+			}
+		}
+	}()
+
+	for range refresh {
+		//while input is read the only UI changes happen on the input box
+		if !t.inputMode {
+			t.screen.Clear()
+			t.render()
+			t.screen.Flush()
+		}
+	}
+	return nil
+}
+
+//handle handles the given key event, it returns false if the event closes the tree
+func (t *JSONTree) handle(event *tcell.EventKey) bool {
+	if t.inputMode {
+		t.inputEvents <- event
+		return true
+	}
+	if event.Key() == tcell.KeyEsc {
+		return false
+	}
+	t.Lock()
+	t.message = ""
+	switch event.Key() {
+	case tcell.KeyDown:
+		t.moveSelection(1)
+	case tcell.KeyUp:
+		t.moveSelection(-1)
+	case tcell.KeyPgDn:
+		t.moveSelection(t.rows())
+	case tcell.KeyPgUp:
+		t.moveSelection(-t.rows())
+	case tcell.KeyHome:
+		t.moveSelection(-len(t.visible))
+	case tcell.KeyEnd:
+		t.moveSelection(len(t.visible))
+	case tcell.KeyRight:
+		t.expand()
+	case tcell.KeyLeft:
+		t.collapse()
+	case tcell.KeyEnter:
+		t.toggle()
+	case tcell.KeyRune:
+		if binding, ok := t.inputBindings[event.Rune()]; ok {
+			t.readInputFor(binding.prompt, binding.action)
+			break
+		}
+		switch event.Rune() {
+		case 'j':
+			t.moveSelection(1)
+		case 'k':
+			t.moveSelection(-1)
+		case 'g':
+			t.moveSelection(-len(t.visible))
+		case 'G':
+			t.moveSelection(len(t.visible))
+		case 'l':
+			t.expand()
+		case 'h':
+			t.collapse()
+		case ' ':
+			t.toggle()
+		case 'E':
+			t.expandAll(true)
+		case 'C':
+			t.expandAll(false)
+		case '/':
+			t.readInputFor(searchPrompt, t.search)
+		case 'n':
+			t.gotoNextMatch(true)
+		case 'N':
+			t.gotoNextMatch(false)
+		case 'p':
+			t.copy("Path", t.selectedNode().Path())
+		case 'v':
+			t.copy("Value", t.selectedNode().JSON())
+		case 'y':
+			t.copy("JSON", t.root.JSON())
+		}
+	}
+	t.Unlock()
+	t.refreshTree()
+	return true
+}
+
+//BindInput binds the given key to an input box with the given prompt, the
+//text typed is given to the action. Bindings take precedence over the keys
+//handled by the tree.
+func (t *JSONTree) BindInput(key rune, prompt string, action func(input string)) {
+	if t.inputBindings == nil {
+		t.inputBindings = make(map[rune]inputBinding)
+	}
+	t.inputBindings[key] = inputBinding{prompt: prompt, action: action}
+}
+
+//Message shows the given message on the status line until a key is pressed.
+//It must only be called from the action of a binding.
+func (t *JSONTree) Message(message string) {
+	t.message = message
+}
+
+//SetFooter shows the given text below the tree, an empty text removes it.
+//It must only be called from the action of a binding.
+func (t *JSONTree) SetFooter(footer string) {
+	t.footer = nil
+	if footer = strings.TrimRight(footer, "\n"); footer == "" {
+		return
+	}
+	t.footer = strings.Split(footer, "\n")
+	//the footer takes up to a third of the screen
+	max := t.height / 3
+	if max < 1 {
+		max = 1
+	}
+	if len(t.footer) > max {
+		t.footer = append(t.footer[:max-1], fmt.Sprintf("… %d more lines", len(t.footer)-max+1))
+	}
+	//the selected node might be below the footer now
+	t.moveSelection(0)
+}
+
+//readInputFor reads input using an input box with the given prompt, the
+//text typed is given to the given action
+func (t *JSONTree) readInputFor(prompt string, action func(string)) {
+	t.inputMode = true
+	t.onInput = action
+	go NewInputBox(0, t.height-1, prompt, t.inputOutput, t.inputEvents, t.theme, t.screen).Focus()
+}
+
+//copy copies the given value to the clipboard, the result is shown on the status line
+func (t *JSONTree) copy(what, value string) {
+	if err := CopyToClipboard(value); err != nil {
+		t.message = "Could not copy to clipboard: " + err.Error()
+		return
+	}
+	t.message = what + " copied to clipboard"
+}
+
+func (t *JSONTree) selectedNode() *jsonNode {
+	return t.visible[t.selected]
+}
+
+//moveSelection moves the selection by the given number of nodes, keeping
+//the selected node on the screen
+func (t *JSONTree) moveSelection(n int) {
+	t.selected += n
+	if t.selected >= len(t.visible) {
+		t.selected = len(t.visible) - 1
+	}
+	if t.selected < 0 {
+		t.selected = 0
+	}
+	rows := t.rows()
+	if t.selected < t.offset {
+		t.offset = t.selected
+	} else if t.selected >= t.offset+rows {
+		t.offset = t.selected - rows + 1
+	}
+}
+
+//expand expands the selected node, if already expanded its first child is selected
+func (t *JSONTree) expand() {
+	n := t.selectedNode()
+	if !n.container() || len(n.children) == 0 {
+		return
+	}
+	if n.collapsed {
+		n.collapsed = false
+		t.updateVisible()
+		return
+	}
+	t.moveSelection(1)
+}
+
+//collapse collapses the selected node, if already collapsed its parent is selected
+func (t *JSONTree) collapse() {
+	n := t.selectedNode()
+	if n.container() && !n.collapsed && n.parent != nil {
+		n.collapsed = true
+		t.updateVisible()
+		return
+	}
+	if n.parent != nil {
+		t.selectNode(n.parent)
+	}
+}
+
+//toggle expands the selected node if collapsed, and collapses it if expanded
+func (t *JSONTree) toggle() {
+	n := t.selectedNode()
+	if !n.container() || n.parent == nil {
+		return
+	}
+	n.collapsed = !n.collapsed
+	t.updateVisible()
+}
+
+//expandAll expands, or collapses, every node but the root
+func (t *JSONTree) expandAll(expand bool) {
+	selected := t.selectedNode()
+	t.root.walk(func(n *jsonNode) {
+		n.collapsed = !expand && n.parent != nil
+	})
+	//the selected node might be hidden now, its top level ancestor is selected then
+	for selected.parent != nil && selected.parent.collapsed {
+		selected = selected.parent
+	}
+	t.updateVisible()
+	t.selectNode(selected)
+}
+
+//search looks for the given pattern on keys and values, the first match
+//from the selected node is selected
+func (t *JSONTree) search(pattern string) {
+	t.pattern = pattern
+	if pattern == "" {
+		return
+	}
+	if !t.selectedNode().matches(pattern) {
+		t.gotoNextMatch(true)
+	}
+}
+
+//gotoNextMatch selects the next, or the previous, node matching the search
+//pattern, expanding the nodes above it
+func (t *JSONTree) gotoNextMatch(forward bool) {
+	if t.pattern == "" {
+		return
+	}
+	var nodes []*jsonNode
+	current := 0
+	selected := t.selectedNode()
+	t.root.walk(func(n *jsonNode) {
+		if n == selected {
+			current = len(nodes)
+		}
+		nodes = append(nodes, n)
+	})
+	for i := 1; i <= len(nodes); i++ {
+		j := current + i
+		if !forward {
+			j = current - i
+		}
+		n := nodes[(j%len(nodes)+len(nodes))%len(nodes)]
+		if !n.matches(t.pattern) {
+			continue
+		}
+		for p := n.parent; p != nil; p = p.parent {
+			p.collapsed = false
+		}
+		t.updateVisible()
+		t.selectNode(n)
+		return
+	}
+	t.message = "Pattern not found: " + t.pattern
+}
+
+//matchCount returns the number of nodes matching the search pattern and the
+//position of the selected node among them, 0 if it does not match
+func (t *JSONTree) matchCount() (position, count int) {
+	selected := t.selectedNode()
+	t.root.walk(func(n *jsonNode) {
+		if n.matches(t.pattern) {
+			count++
+			if n == selected {
+				position = count
+			}
+		}
+	})
+	return
+}
+
+//selectNode selects the given node, that must be visible
+func (t *JSONTree) selectNode(n *jsonNode) {
+	for i, v := range t.visible {
+		if v == n {
+			t.moveSelection(i - t.selected)
+			return
+		}
+	}
+}
+
+//updateVisible updates the list of visible nodes, the selected node is kept
+//if still visible
+func (t *JSONTree) updateVisible() {
+	var selected *jsonNode
+	if t.selected < len(t.visible) {
+		selected = t.visible[t.selected]
+	}
+	t.visible = t.visible[:0]
+	var add func(n *jsonNode)
+	add = func(n *jsonNode) {
+		t.visible = append(t.visible, n)
+		if n.collapsed {
+			return
+		}
+		for _, child := range n.children {
+			add(child)
+		}
+	}
+	add(t.root)
+	t.selected = 0
+	if selected != nil {
+		t.selectNode(selected)
+	}
+}
+
+//rows returns the number of screen rows available to show nodes
+func (t *JSONTree) rows() int {
+	rows := t.height - len(t.header) - 1
+	if len(t.footer) > 0 {
+		//footer lines and the line separating them from the tree
+		rows -= len(t.footer) + 1
+	}
+	if rows < 1 {
+		return 1
+	}
+	return rows
+}
+
+func (t *JSONTree) refreshTree() {
+	select {
+	case t.refresh <- struct{}{}:
+	default:
+	}
+}
+
+func (t *JSONTree) render() {
+	t.Lock()
+	defer t.Unlock()
+	for y, line := range t.header {
+		renderLineWithMarkup(0, y, t.width, line, t.markup)
+	}
+	y := len(t.header)
+	for i := t.offset; i < len(t.visible) && i < t.offset+t.rows(); i++ {
+		t.renderNode(y, t.visible[i], i == t.selected)
+		y++
+	}
+	if len(t.footer) > 0 {
+		y = t.height - len(t.footer) - 2
+		t.renderer.On(0, y).WithStyle(t.keyStyle).Render(strings.Repeat("─", t.width))
+		for i, line := range t.footer {
+			t.renderer.On(0, y+1+i).WithStyle(t.defaultStyle).Render(runewidth.Truncate(line, t.width, "…"))
+		}
+	}
+	t.renderStatusLine()
+}
+
+//renderNode renders the given node on the given row, truncated to the screen width
+func (t *JSONTree) renderNode(y int, n *jsonNode, selected bool) {
+	marker := "  "
+	if n.container() && len(n.children) > 0 {
+		if n.collapsed {
+			marker = "▸ "
+		} else {
+			marker = "▾ "
+		}
+	}
+	valueStyle := t.scalarStyle
+	if n.kind == jsonString {
+		valueStyle = t.stringStyle
+	} else if n.container() {
+		valueStyle = t.defaultStyle
+	}
+	keyStyle := t.keyStyle
+	if t.pattern != "" && n.matches(t.pattern) {
+		keyStyle, valueStyle = t.searchHitStyle, t.searchHitStyle
+	}
+	key := ""
+	if n.parent != nil {
+		key = n.key + ": "
+	}
+	x := 0
+	for _, part := range []struct {
+		text  string
+		style tcell.Style
+	}{
+		{strings.Repeat("  ", n.depth) + marker, t.defaultStyle},
+		{key, keyStyle},
+		{n.text(), valueStyle},
+	} {
+		if x >= t.width {
+			return
+		}
+		text := runewidth.Truncate(part.text, t.width-x, "…")
+		style := part.style
+		if selected {
+			style = style.Reverse(true)
+		}
+		t.renderer.On(x, y).WithStyle(style).Render(text)
+		x += runewidth.StringWidth(text)
+	}
+}
+
+func (t *JSONTree) renderStatusLine() {
+	start := t.message
+	if start == "" {
+		start = t.selectedNode().Path()
+	}
+	var end string
+	if t.pattern != "" {
+		position, count := t.matchCount()
+		end = fmt.Sprintf("%s (%d/%d) ", t.pattern, position, count)
+	}
+	end += "[p]:Copy path [v]:Copy value [y]:Copy JSON"
+	padding := t.width - runewidth.StringWidth(start) - len(end)
+	if padding < 1 {
+		padding = 1
+	}
+	t.renderer.On(0, t.height-1).WithStyle(t.defaultStyle.Reverse(true)).Render(
+		runewidth.Truncate(start+strings.Repeat(" ", padding)+end, t.width, ""))
+}
@@ -0,0 +1,180 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+const inspected = `{"Id":"abc","Config":{"Labels":{"com.docker.compose.project":"dry"}},` +
+	`"NetworkSettings":{"Networks":{"bridge":{"IPAddress":"172.17.0.2","Aliases":null}}},` +
+	`"Mounts":[{"Source":"/tmp"}],"Running":true,"Pid":42}`
+
+func newTestTree(t *testing.T, height int) *JSONTree {
+	root, err := parseJSONTree([]byte(inspected))
+	if err != nil {
+		t.Fatalf("unexpected error parsing JSON: %s", err)
+	}
+	tree := &JSONTree{root: root, width: 80, height: height}
+	tree.updateVisible()
+	return tree
+}
+
+func visiblePaths(tree *JSONTree) []string {
+	var paths []string
+	for _, n := range tree.visible {
+		paths = append(paths, n.Path())
+	}
+	return paths
+}
+
+func TestParseJSONTreeKeepsKeyOrderAndPaths(t *testing.T) {
+	tree := newTestTree(t, 20)
+
+	expected := []string{".", ".Id", ".Config", ".NetworkSettings", ".Mounts", ".Running", ".Pid"}
+	got := visiblePaths(tree)
+	if len(got) != len(expected) {
+		t.Fatalf("expected visible nodes %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("expected node %d to be %s, got %s", i, expected[i], got[i])
+		}
+	}
+
+	var paths []string
+	tree.root.walk(func(n *jsonNode) {
+		paths = append(paths, n.Path())
+	})
+	for _, path := range []string{
+		`.Config.Labels["com.docker.compose.project"]`,
+		".NetworkSettings.Networks.bridge.IPAddress",
+		".Mounts[0].Source",
+	} {
+		found := false
+		for _, p := range paths {
+			found = found || p == path
+		}
+		if !found {
+			t.Errorf("expected path %s not found in %v", path, paths)
+		}
+	}
+}
+
+func TestJSONTreeNodeJSON(t *testing.T) {
+	tree := newTestTree(t, 20)
+
+	if got := tree.root.JSON(); got != mustIndent(t, inspected) {
+		t.Errorf("unexpected JSON of the document: %s", got)
+	}
+	tree.search("172.17")
+	if got := tree.selectedNode().JSON(); got != "172.17.0.2" {
+		t.Errorf("expected the string value unquoted, got %s", got)
+	}
+	tree.search("Pid")
+	if got := tree.selectedNode().JSON(); got != "42" {
+		t.Errorf("expected number 42, got %s", got)
+	}
+}
+
+func TestJSONTreeSearchExpandsMatches(t *testing.T) {
+	tree := newTestTree(t, 20)
+
+	tree.search("IPAddress")
+	if got := tree.selectedNode().Path(); got != ".NetworkSettings.Networks.bridge.IPAddress" {
+		t.Errorf("expected the IPAddress to be selected, got %s", got)
+	}
+	if position, count := tree.matchCount(); position != 1 || count != 1 {
+		t.Errorf("expected match 1 of 1, got %d of %d", position, count)
+	}
+
+	tree.search("o")
+	first := tree.selectedNode().Path()
+	tree.gotoNextMatch(true)
+	if tree.selectedNode().Path() == first {
+		t.Error("expected the next match to be selected")
+	}
+	tree.gotoNextMatch(false)
+	if got := tree.selectedNode().Path(); got != first {
+		t.Errorf("expected the previous match to be %s, got %s", first, got)
+	}
+
+	tree.search("not there")
+	if tree.message == "" {
+		t.Error("expected a message when the pattern is not found")
+	}
+}
+
+func TestJSONTreeExpandAndCollapse(t *testing.T) {
+	tree := newTestTree(t, 5)
+
+	tree.moveSelection(2)
+	if got := tree.selectedNode().Path(); got != ".Config" {
+		t.Fatalf("expected .Config to be selected, got %s", got)
+	}
+	tree.expand()
+	tree.expand()
+	if got := tree.selectedNode().Path(); got != ".Config.Labels" {
+		t.Errorf("expected .Config.Labels to be selected, got %s", got)
+	}
+	tree.collapse()
+	if got := tree.selectedNode().Path(); got != ".Config" {
+		t.Errorf("expected .Config to be selected, got %s", got)
+	}
+	tree.collapse()
+	if len(tree.visible) != 7 {
+		t.Errorf("expected 7 visible nodes once collapsed, got %v", visiblePaths(tree))
+	}
+
+	tree.expandAll(true)
+	tree.moveSelection(len(tree.visible))
+	if got := tree.selectedNode().Path(); got != ".Pid" {
+		t.Errorf("expected .Pid to be selected, got %s", got)
+	}
+	if tree.offset != tree.selected-tree.rows()+1 {
+		t.Errorf("expected the selected node to be on the last row, offset %d", tree.offset)
+	}
+
+	tree.search("Source")
+	tree.expandAll(false)
+	if got := tree.selectedNode().Path(); got != ".Mounts" {
+		t.Errorf("expected .Mounts to be selected once collapsed, got %s", got)
+	}
+}
+
+func TestParseJSONTreeErrors(t *testing.T) {
+	for _, doc := range []string{"", "{", `{"a":1}{}`, `[1,`} {
+		if _, err := parseJSONTree([]byte(doc)); err == nil {
+			t.Errorf("expected an error parsing %q", doc)
+		}
+	}
+}
+
+func mustIndent(t *testing.T, s string) string {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(s), "", "    "); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestJSONTreeFooter(t *testing.T) {
+	tree := newTestTree(t, 12)
+
+	tree.moveSelection(len(tree.visible))
+	tree.SetFooter("172.17.0.2\n")
+	if len(tree.footer) != 1 || tree.rows() != 9 {
+		t.Errorf("expected one footer line and 9 rows for the tree, got %v and %d rows", tree.footer, tree.rows())
+	}
+	tree.SetFooter("1\n2\n3\n4\n5\n6")
+	if len(tree.footer) != 4 || tree.footer[3] != "… 3 more lines" {
+		t.Errorf("expected the footer to be cut to 4 lines, got %v", tree.footer)
+	}
+	if tree.selected-tree.offset >= tree.rows() {
+		t.Errorf("expected the selected node to be shown above the footer, selected %d, offset %d", tree.selected, tree.offset)
+	}
+	tree.SetFooter("")
+	if tree.footer != nil || tree.rows() != 11 {
+		t.Errorf("expected the footer to be removed, got %v", tree.footer)
+	}
+}
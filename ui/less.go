@@ -1,18 +1,31 @@
 package ui
 
 import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 
 	"github.com/gdamore/tcell"
 	"github.com/gdamore/tcell/termbox"
+	"github.com/mattn/go-runewidth"
 
 	"github.com/moncho/dry/search"
+	"github.com/moncho/dry/terminal"
 )
 
 const (
-	endtext   = "(end)"
-	starttext = "(start)"
+	endtext      = "(end)"
+	starttext    = "(start)"
+	searchPrompt = ">>> "
+	savePrompt   = "Save to file: "
+	gotoPrompt   = "Go to line (or percentage, e.g. 50%): "
+	jsonPrompt   = "Show JSON lines with field=value (empty to show all): "
 )
 
 //Less is a View specialization with less-like behavior and characteristics, meaning:
@@ -21,24 +34,50 @@ const (
 // * Basic search is supported.
 type Less struct {
 	*View
-	searchResult   *search.Result
-	filtering      bool
-	following      bool
-	refresh        chan struct{}
-	screen         *Screen
-	renderer       ScreenTextRenderer
-	searchHitStyle tcell.Style
-	defaultStyle   tcell.Style
+	searchResult    *search.Result
+	indexedLines    int
+	filtering       bool
+	following       bool
+	renderANSI      bool
+	lineNumbers     bool
+	jsonMode        bool
+	jsonFilter      *jsonFilter
+	jsonRows        jsonRows
+	message         string
+	refresh         chan struct{}
+	screen          *Screen
+	renderer        ScreenTextRenderer
+	searchHitStyle  tcell.Style
+	defaultStyle    tcell.Style
+	lineNumberStyle tcell.Style
+	stderrStyle     tcell.Style
+	stderrLines     map[int]struct{}
+	bindings        map[rune]func()
+	inputBindings   map[rune]inputBinding
+	status          string
+	//inputMode is true while text is read on the input box
+	inputMode   bool
+	onInput     func(string)
+	inputEvents chan *tcell.EventKey
+	inputOutput chan string
+	//highlightStatus renders the status line highlighted, used to tell the
+	//focused view when several are shown
+	highlightStatus bool
 
 	sync.Mutex
 }
 
 //NewLess creates a view that partially simulates less.
 func NewLess(theme *ColorTheme) *Less {
+	return newLessAt(theme, 0, ActiveScreen.Dimensions().Height)
+}
+
+//newLessAt creates a less view that uses the screen rows from y0 to y1
+func newLessAt(theme *ColorTheme, y0, y1 int) *Less {
 	sd := ActiveScreen.Dimensions()
 
-	view := NewView("", 0, 0, sd.Width, sd.Height, true, theme)
-	view.cursorY = sd.Height - 1 //Last line is at height -1
+	view := NewView("", 0, y0, sd.Width, y1, true, theme)
+	view.cursorY = y1 - 1 //Last line is at y1 -1
 	less := &Less{
 		View:   view,
 		screen: ActiveScreen,
@@ -46,6 +85,8 @@ func NewLess(theme *ColorTheme) *Less {
 	less.renderer = NewRenderer(screenStyledRuneRenderer{ActiveScreen}).WithWidth(sd.Width)
 	less.searchHitStyle = mkStyle(termbox.ColorYellow, termbox.Attribute(less.View.theme.Bg))
 	less.defaultStyle = mkStyle(termbox.ColorWhite, termbox.Attribute(less.View.theme.Bg))
+	less.stderrStyle = mkStyle(termbox.ColorRed, termbox.Attribute(less.View.theme.Bg))
+	less.lineNumberStyle = mkStyle(termbox.Attribute(less.View.theme.Key), termbox.Attribute(less.View.theme.Bg))
 	return less
 }
 
@@ -53,112 +94,270 @@ func NewLess(theme *ColorTheme) *Less {
 //and user actions
 func (less *Less) Focus(events <-chan *tcell.EventKey) error {
 	refreshChan := make(chan struct{}, 1)
+	less.activate(refreshChan)
 
-	less.refresh = refreshChan
-	less.newLineCallback = func() {
-		if less.following {
-			//ScrollToBottom refreshes the buffer as well
-			less.ScrollToBottom()
-		} else {
-			less.refreshBuffer()
-		}
-	}
-	inputMode := false
-
-	//This ensures at least one refresh
-	less.refreshBuffer()
-
-	go func(inputMode *bool) {
-
-		inputBoxEventChan := make(chan *tcell.EventKey)
-		inputBoxOutput := make(chan string, 1)
-		defer close(inputBoxOutput)
-		defer close(inputBoxEventChan)
-
+	go func() {
 		for {
 			select {
-
-			case input := <-inputBoxOutput:
-				*inputMode = false
-				less.search(input)
-				less.refreshBuffer()
+			case input := <-less.inputOutput:
+				less.endInput(input)
 			case event := <-events:
-				if !*inputMode {
-					if event.Key() == tcell.KeyEsc {
-						less.newLineCallback = func() {}
-						close(refreshChan)
-						return
-					} else if event.Key() == tcell.KeyDown { //cursor down
-						less.ScrollDown()
-					} else if event.Key() == tcell.KeyUp { // cursor up
-						less.ScrollUp()
-					} else if event.Key() == tcell.KeyPgDn { //cursor one page down
-						less.ScrollPageDown()
-					} else if event.Key() == tcell.KeyPgUp { // cursor one page up
-						less.ScrollPageUp()
-					} else if event.Rune() == 'f' { //toggle follow
-						less.flipFollow()
-					} else if event.Rune() == 'F' {
-						*inputMode = true
-						less.filtering = true
-						go less.readInput(inputBoxEventChan, inputBoxOutput)
-					} else if event.Rune() == 'g' { //to the top of the view
-						less.ScrollToTop()
-					} else if event.Rune() == 'G' { //to the bottom of the view
-						less.ScrollToBottom()
-					} else if event.Rune() == 'N' { //to the top of the view
-						less.gotoPreviousSearchHit()
-					} else if event.Rune() == 'n' { //to the bottom of the view
-						less.gotoNextSearchHit()
-					} else if event.Rune() == '/' {
-						*inputMode = true
-						less.filtering = false
-						go less.readInput(inputBoxEventChan, inputBoxOutput)
-					}
-				} else {
-					inputBoxEventChan <- event
+				if !less.handle(event) {
+					less.deactivate()
+					close(refreshChan)
+					return
 				}
-
 			}
 		}
-	}(&inputMode)
+	}()
 
 	for range less.refresh {
 		//If input is being read, refresh events are ignore
 		//the only UI changes are happening on the input bar
 		//and are done by the InputBox
-		if !inputMode {
+		if !less.inputMode {
 			less.screen.Clear()
 			less.render()
+			less.drawCursor()
 			less.screen.Flush()
 		}
 	}
 	return nil
 }
 
+//activate prepares the view to handle events, refreshes are requested
+//on the given channel
+func (less *Less) activate(refresh chan struct{}) {
+	less.refresh = refresh
+	less.newLineCallback = func() {
+		less.indexNewLines()
+		if less.following {
+			//ScrollToBottom refreshes the buffer as well
+			less.ScrollToBottom()
+		} else {
+			less.refreshBuffer()
+		}
+	}
+	less.inputEvents = make(chan *tcell.EventKey)
+	less.inputOutput = make(chan string, 1)
+	//onInput handles the text typed on the input box, either a search or a file to save the buffer to
+	less.onInput = func(input string) {
+		less.search(input)
+	}
+	//This ensures at least one refresh
+	less.refreshBuffer()
+}
+
+//deactivate releases the resources used to handle events
+func (less *Less) deactivate() {
+	less.newLineCallback = func() {}
+	close(less.inputOutput)
+	close(less.inputEvents)
+}
+
+//endInput hands the text typed on the input box to the action that asked for it
+func (less *Less) endInput(input string) {
+	less.inputMode = false
+	less.onInput(input)
+	less.refreshBuffer()
+}
+
+//readInputFor reads input using an input box with the given prompt, the
+//text typed is given to the given action
+func (less *Less) readInputFor(prompt string, action func(string)) {
+	less.inputMode = true
+	less.onInput = action
+	go less.readInput(prompt, less.inputEvents, less.inputOutput)
+}
+
+//handle handles the given key event, it returns false if the event closes the view
+func (less *Less) handle(event *tcell.EventKey) bool {
+	if less.inputMode {
+		less.inputEvents <- event
+		return true
+	}
+	less.message = ""
+	if action, ok := less.bindings[event.Rune()]; ok && event.Key() == tcell.KeyRune {
+		action()
+	} else if binding, ok := less.inputBindings[event.Rune()]; ok && event.Key() == tcell.KeyRune {
+		less.readInputFor(binding.prompt, binding.action)
+	} else if event.Key() == tcell.KeyEsc {
+		return false
+	} else if event.Key() == tcell.KeyDown { //cursor down
+		less.ScrollDown()
+	} else if event.Key() == tcell.KeyUp { // cursor up
+		less.ScrollUp()
+	} else if event.Key() == tcell.KeyPgDn { //cursor one page down
+		less.ScrollPageDown()
+	} else if event.Key() == tcell.KeyPgUp { // cursor one page up
+		less.ScrollPageUp()
+	} else if event.Rune() == 'f' { //toggle follow
+		less.flipFollow()
+	} else if event.Rune() == 'a' { //toggle ANSI colors
+		less.flipANSI()
+	} else if event.Rune() == 'j' { //toggle JSON pretty-printing
+		less.flipJSON()
+	} else if event.Rune() == 'J' { //filter JSON lines
+		less.readInputFor(jsonPrompt, less.setJSONFilter)
+	} else if event.Rune() == '#' { //toggle line numbers
+		less.flipLineNumbers()
+	} else if event.Rune() == ':' { //go to line
+		less.readInputFor(gotoPrompt, less.gotoLine)
+	} else if event.Rune() == 'F' {
+		less.filtering = true
+		less.readInputFor(searchPrompt, func(input string) { less.search(input) })
+	} else if event.Rune() == 'g' { //to the top of the view
+		less.ScrollToTop()
+	} else if event.Rune() == 'G' { //to the bottom of the view
+		less.ScrollToBottom()
+	} else if event.Rune() == 'N' { //to the top of the view
+		less.gotoPreviousSearchHit()
+	} else if event.Rune() == 'n' { //to the bottom of the view
+		less.gotoNextSearchHit()
+	} else if event.Rune() == '/' {
+		less.filtering = false
+		less.readInputFor(searchPrompt, func(input string) { less.search(input) })
+	} else if event.Rune() == 'w' { //save the buffer to a file
+		less.readInputFor(savePrompt, less.saveTo)
+	}
+	return true
+}
+
 //Search searches in the view buffer for the given pattern
 func (less *Less) search(pattern string) error {
 	if pattern != "" {
 		searchResult, err := search.NewSearch(less.lines, pattern)
 		if err == nil {
+			less.Lock()
 			less.searchResult = searchResult
+			//the last line might still be being written, it is indexed again when completed
+			less.indexedLines = len(less.lines) - 1
+			less.Unlock()
 			if searchResult.Hits > 0 {
 				_, y := less.Position()
-				searchResult.InitialLine(y)
+				searchResult.InitialLine(less.lineOf(y))
 				less.gotoNextSearchHit()
 			}
 		} else {
 			return err
 		}
 	} else {
+		less.Lock()
 		less.searchResult = nil
+		less.Unlock()
 	}
 	return nil
 }
 
-func (less *Less) readInput(inputBoxEventChan chan *tcell.EventKey, inputBoxOutput chan string) error {
+//indexNewLines adds the lines completed since the last search to the search
+//result, so matches on streamed content can also be navigated
+func (less *Less) indexNewLines() {
+	less.Lock()
+	defer less.Unlock()
+	if less.searchResult == nil {
+		return
+	}
+	//The last line is still being written
+	completed := len(less.lines) - 1
+	for i := less.indexedLines; i < completed; i++ {
+		less.searchResult.Add(i, less.lines[i])
+	}
+	if completed > less.indexedLines {
+		less.indexedLines = completed
+	}
+}
+
+//gotoLine moves the buffer position to the line or percentage of the buffer
+//given, errors are shown on the status line
+func (less *Less) gotoLine(input string) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return
+	}
+	line, err := parseLinePosition(input, len(less.lines))
+	if err != nil {
+		less.message = err.Error()
+		return
+	}
+	less.GotoLine(line)
+}
+
+//GotoLine moves the buffer position to the given line, line numbers start at 1
+func (less *Less) GotoLine(line int) {
+	y := less.rowOf(line - 1)
+	//Same limit as when scrolling to the bottom
+	if maxY := less.bufferSize() - (less.y1 - less.y0); y > maxY {
+		y = maxY
+	}
+	if y < 0 {
+		y = 0
+	}
+	x, _ := less.Position()
+	less.setPosition(x, y)
+	less.refreshBuffer()
+}
+
+//parseLinePosition parses a line number or a percentage of a buffer with the
+//given number of lines, returns the line number
+func parseLinePosition(s string, lines int) (int, error) {
+	if strings.HasSuffix(s, "%") {
+		percentage, err := strconv.Atoi(strings.TrimSuffix(s, "%"))
+		if err != nil || percentage < 0 || percentage > 100 {
+			return 0, fmt.Errorf("invalid percentage: %q", s)
+		}
+		return 1 + (lines-1)*percentage/100, nil
+	}
+	line, err := strconv.Atoi(s)
+	if err != nil || line < 1 {
+		return 0, fmt.Errorf("invalid line number: %q", s)
+	}
+	return line, nil
+}
+
+//saveTo saves the buffer to the file on the given path, the result is shown
+//on the status line
+func (less *Less) saveTo(path string) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return
+	}
+	if err := less.Save(path); err != nil {
+		less.message = "Could not save: " + err.Error()
+	} else {
+		less.message = "Saved to " + path
+	}
+}
+
+//Save writes the content of the view buffer to the file on the given path,
+//as it is on the buffer: lines are saved whether they are shown or not by the
+//search or JSON filters, without the JSON pretty-printing and with their ANSI
+//escape sequences. Lines longer than the view width are written as several lines.
+func (less *Less) Save(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	less.Lock()
+	w := bufio.NewWriter(f)
+	for i, line := range less.lines {
+		//The buffer ends with an empty line after the last newline
+		if i == len(less.lines)-1 && len(line) == 0 {
+			break
+		}
+		w.WriteString(string(line))
+		w.WriteByte('\n')
+	}
+	err = w.Flush()
+	less.Unlock()
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func (less *Less) readInput(prompt string, inputBoxEventChan chan *tcell.EventKey, inputBoxOutput chan string) error {
 	_, height := less.ViewSize()
-	eb := NewInputBox(0, height, ">>> ", inputBoxOutput, inputBoxEventChan, less.theme, less.screen)
+	eb := NewInputBox(0, less.y0+height, prompt, inputBoxOutput, inputBoxEventChan, less.theme, less.screen)
 	eb.Focus()
 	return nil
 }
@@ -174,17 +373,358 @@ func (less *Less) render() {
 	if less.bufferY < less.bufferSize() && less.bufferY > 0 {
 		bufferStart = less.bufferY
 	}
-	for _, line := range less.lines[bufferStart:] {
+	numberWidth := len(strconv.Itoa(len(less.lines)))
+	renderNumber := func(line int) int {
+		if !less.lineNumbers {
+			return 0
+		}
+		number := fmt.Sprintf("%*d ", numberWidth, line+1)
+		less.renderer.On(0, less.y0+y).WithStyle(less.lineNumberStyle).Render(number)
+		return len(number)
+	}
+	if less.filteringJSON() {
+		rows := less.filteredRows()
+		for row := bufferStart; row < len(rows) && y <= maxY; row++ {
+			line, _ := less.logicalLine(rows[row])
+			log, _ := parseJSONLog(line)
+			x := renderNumber(rows[row])
+			less.renderJSONLog(x, less.y0+y, log, less.isStderr(rows[row]))
+			y++
+		}
+		less.renderStatusLine()
+		return
+	}
+	for i := bufferStart; i < len(less.lines) && y <= maxY; i++ {
+		first := i
+		var log jsonLog
+		isJSON := false
+		if less.jsonMode {
+			line, last := less.logicalLine(i)
+			if log, isJSON = parseJSONLog(line); isJSON {
+				//Wrapped JSON lines are rendered as one line
+				i = last
+			}
+		}
+		x := renderNumber(first)
+		lines := 1
+		if isJSON {
+			less.renderJSONLog(x, less.y0+y, log, less.isStderr(first))
+		} else {
+			lines, _ = less.renderLine(x, less.y0+y, string(less.lines[i]), less.isStderr(i))
+		}
+		y += lines
+	}
+
+	less.renderStatusLine()
+}
+
+//Bind binds the given key to the given action, bindings take precedence
+//over the keys handled by Less
+func (less *Less) Bind(key rune, action func()) {
+	if less.bindings == nil {
+		less.bindings = make(map[rune]func())
+	}
+	less.bindings[key] = action
+}
+
+//inputBinding is an action that is given the text typed on an input box
+type inputBinding struct {
+	prompt string
+	action func(string)
+}
+
+//BindInput binds the given key to an input box with the given prompt, the
+//text typed is given to the action. Bindings take precedence over the keys
+//handled by Less.
+func (less *Less) BindInput(key rune, prompt string, action func(input string)) {
+	if less.inputBindings == nil {
+		less.inputBindings = make(map[rune]inputBinding)
+	}
+	less.inputBindings[key] = inputBinding{prompt: prompt, action: action}
+}
+
+//ReadInput reads input using an input box with the given prompt, the text
+//typed is given to the action. It must only be called from the action of a
+//binding, calling it from an input action asks for another input.
+func (less *Less) ReadInput(prompt string, action func(input string)) {
+	less.readInputFor(prompt, action)
+}
+
+//SetStatus sets a text to be shown on the status line, next to the state of Less.
+//It must only be called while Less has the focus.
+func (less *Less) SetStatus(status string) {
+	less.status = status
+	less.refreshBuffer()
+}
+
+//Message shows the given message on the status line until a key is pressed.
+//It must only be called while Less has the focus.
+func (less *Less) Message(message string) {
+	less.message = message
+	less.refreshBuffer()
+}
+
+//Stderr returns a writer to add content to the buffer as written to stderr,
+//lines written to it are rendered with a different style
+func (less *Less) Stderr() io.Writer {
+	return stderrWriter{less}
+}
+
+//Clear empties the buffer, the search pattern, if any, is kept and searched
+//on the content added afterwards
+func (less *Less) Clear() {
+	less.Lock()
+	defer less.Unlock()
+	//The screen is cleared when rendering
+	less.lines = nil
+	less.stderrLines = nil
+	less.indexedLines = 0
+	less.jsonRows.Lock()
+	less.jsonRows.rows, less.jsonRows.checked = nil, 0
+	less.jsonRows.Unlock()
+	if less.searchResult != nil {
+		less.searchResult, _ = search.NewSearch([][]rune{}, less.searchResult.Pattern)
+	}
+	less.bufferX, less.bufferY = 0, 0
+	less.refreshBuffer()
+}
+
+func (less *Less) isStderr(line int) bool {
+	_, ok := less.stderrLines[line]
+	return ok
+}
+
+type stderrWriter struct {
+	less *Less
+}
+
+//Write writes to the buffer, marking the lines written as stderr lines
+func (w stderrWriter) Write(p []byte) (int, error) {
+	less := w.less
+	first := len(less.lines) - 1
+	if first < 0 {
+		first = 0
+	}
+	n, err := less.View.Write(p)
+	last := len(less.lines) - 1
+	//After a newline the last line is empty and it might be written to stdout
+	if bytes.HasSuffix(p, []byte("\n")) {
+		last--
+	}
+	less.Lock()
+	if less.stderrLines == nil {
+		less.stderrLines = make(map[int]struct{})
+	}
+	for i := first; i <= last; i++ {
+		less.stderrLines[i] = struct{}{}
+	}
+	less.Unlock()
+	return n, err
+}
+
+//Follow sets whether the view scrolls to the bottom as new lines are added
+func (less *Less) Follow(follow bool) {
+	less.following = follow
+}
+
+//RenderANSI sets whether ANSI colors found on the buffer are rendered, if not
+//ANSI escape codes are removed
+func (less *Less) RenderANSI(render bool) {
+	less.renderANSI = render
+}
+
+//JSON sets whether lines with JSON objects are pretty-printed
+func (less *Less) JSON(pretty bool) {
+	less.jsonMode = pretty
+}
+
+func (less *Less) flipJSON() {
+	filtering := less.filteringJSON()
+	less.jsonMode = !less.jsonMode
+	if filtering != less.filteringJSON() {
+		less.resetPosition()
+		return
+	}
+	less.refreshBuffer()
+}
+
+//setJSONFilter sets the filter of JSON lines, an empty filter removes it.
+//Filtering also turns on pretty-printing.
+func (less *Less) setJSONFilter(filter string) {
+	filter = strings.TrimSpace(filter)
+	if filter == "" {
+		if less.jsonFilter != nil {
+			less.jsonFilter = nil
+			less.resetPosition()
+		}
+		return
+	}
+	f, err := parseJSONFilter(filter)
+	if err != nil {
+		less.message = err.Error()
+		return
+	}
+	less.jsonFilter = f
+	less.jsonMode = true
+	less.resetPosition()
+}
+
+//resetPosition moves the buffer position to the top of the buffer, or to the
+//bottom if following, used once the rows of the buffer change
+func (less *Less) resetPosition() {
+	if less.following {
+		less.ScrollToBottom()
+	} else {
+		less.ScrollToTop()
+	}
+}
+
+//filteringJSON returns true if only the JSON lines matching the JSON filter
+//are shown
+func (less *Less) filteringJSON() bool {
+	return less.jsonMode && less.jsonFilter != nil
+}
 
-		if y > maxY {
+//jsonRows keeps the first buffer line of the lines shown by a JSON filter,
+//so each line is checked only once
+type jsonRows struct {
+	filter *jsonFilter
+	rows   []int
+	//checked is the number of buffer lines already checked
+	checked int
+	sync.Mutex
+}
+
+//filteredRows returns the first buffer line of the lines matching the JSON
+//filter, the rows on which the view buffer is shown while filtering
+func (less *Less) filteredRows() []int {
+	r := &less.jsonRows
+	r.Lock()
+	defer r.Unlock()
+	if r.filter != less.jsonFilter || r.checked > len(less.lines) {
+		r.filter, r.rows, r.checked = less.jsonFilter, nil, 0
+	}
+	matches := func(i int) (bool, int) {
+		line, last := less.logicalLine(i)
+		log, ok := parseJSONLog(line)
+		return ok && r.filter.matches(log), last
+	}
+	i := r.checked
+	for i < len(less.lines) {
+		match, last := matches(i)
+		if last == len(less.lines)-1 {
+			//The last line might still be written, it is checked again
 			break
 		}
-		less.renderLine(0, y, string(line))
-		y++
+		if match {
+			r.rows = append(r.rows, i)
+		}
+		i = last + 1
+	}
+	r.checked = i
+	rows := r.rows[:len(r.rows):len(r.rows)]
+	if i < len(less.lines) {
+		if match, _ := matches(i); match {
+			rows = append(rows, i)
+		}
 	}
+	return rows
+}
 
-	less.renderStatusLine()
-	less.drawCursor()
+//rowOf returns the row on which the given buffer line is shown, or the next
+//row shown if the line is filtered out
+func (less *Less) rowOf(line int) int {
+	if !less.filteringJSON() {
+		return line
+	}
+	return sort.SearchInts(less.filteredRows(), line)
+}
+
+//lineOf returns the buffer line shown on the given row
+func (less *Less) lineOf(row int) int {
+	if !less.filteringJSON() {
+		return row
+	}
+	if rows := less.filteredRows(); row < len(rows) {
+		return rows[row]
+	}
+	return len(less.lines)
+}
+
+//logicalLine returns the line starting on the given buffer line, lines
+//longer than the view width are split on several buffer lines when written.
+//Returns the index of the last buffer line of the line.
+func (less *Less) logicalLine(i int) (string, int) {
+	var builder strings.Builder
+	for ; i < len(less.lines); i++ {
+		builder.WriteString(string(less.lines[i]))
+		if len(less.lines[i]) < less.width || i == len(less.lines)-1 {
+			break
+		}
+	}
+	return builder.String(), i
+}
+
+//renderJSONLog renders the given JSON log as a list of key=value fields,
+//clipped to the view width
+func (less *Less) renderJSONLog(x, y int, log jsonLog, stderr bool) {
+	maxWidth, _ := less.renderableArea()
+	render := func(text string, style tcell.Style) {
+		if x >= maxWidth {
+			return
+		}
+		text = runewidth.Truncate(text, maxWidth-x, "")
+		less.renderer.On(x, y).WithStyle(style).Render(text)
+		x += runewidth.StringWidth(text)
+	}
+	style := less.lineStyle(stderr)
+	if log.prefix != "" {
+		render(log.prefix, style)
+	}
+	for i, field := range log.fields {
+		if i > 0 {
+			render(" ", style)
+		}
+		render(field.key+"=", less.lineNumberStyle)
+		value := strings.TrimPrefix(field.String(), field.key+"=")
+		render(value, less.jsonValueStyle(field, style))
+	}
+}
+
+//jsonValueStyle returns the style of the value of the given field, log levels
+//are rendered using a color for each severity
+func (less *Less) jsonValueStyle(field jsonField, style tcell.Style) tcell.Style {
+	switch strings.ToLower(field.key) {
+	case "level", "lvl", "severity":
+	default:
+		return style
+	}
+	switch strings.ToLower(field.value) {
+	case "error", "err", "fatal", "panic", "critical", "crit":
+		return mkStyle(termbox.ColorRed, termbox.Attribute(less.View.theme.Bg))
+	case "warn", "warning":
+		return mkStyle(termbox.ColorYellow, termbox.Attribute(less.View.theme.Bg))
+	case "info":
+		return mkStyle(termbox.ColorGreen, termbox.Attribute(less.View.theme.Bg))
+	case "debug", "trace":
+		return mkStyle(termbox.ColorCyan, termbox.Attribute(less.View.theme.Bg))
+	}
+	return style
+}
+
+//LineNumbers sets whether line numbers are shown
+func (less *Less) LineNumbers(show bool) {
+	less.lineNumbers = show
+}
+
+func (less *Less) flipLineNumbers() {
+	less.lineNumbers = !less.lineNumbers
+	less.refreshBuffer()
+}
+
+func (less *Less) flipANSI() {
+	less.renderANSI = !less.renderANSI
+	less.refreshBuffer()
 }
 
 func (less *Less) flipFollow() {
@@ -226,7 +766,11 @@ func (less *Less) ScrollPageUp() {
 
 //ScrollToBottom moves the cursor to the bottom of the view buffer
 func (less *Less) ScrollToBottom() {
-	less.bufferY = less.bufferSize() - less.y1
+	less.bufferY = less.bufferSize() - (less.y1 - less.y0)
+	//Buffers shorter than the view are shown from the top
+	if less.bufferY < 0 {
+		less.bufferY = 0
+	}
 	less.refreshBuffer()
 
 }
@@ -249,7 +793,12 @@ func (less *Less) atTheEndOfBuffer() bool {
 	return y+height >= viewLength-1
 }
 
+//bufferSize returns the number of rows of the view buffer, only the lines
+//matching the JSON filter are counted while filtering
 func (less *Less) bufferSize() int {
+	if less.filteringJSON() {
+		return len(less.filteredRows())
+	}
 	return len(less.lines)
 }
 
@@ -258,7 +807,7 @@ func (less *Less) gotoPreviousSearchHit() {
 	if sr != nil {
 		x, _ := less.Position()
 		if newy, err := sr.PreviousLine(); err == nil {
-			less.setPosition(x, newy)
+			less.setPosition(x, less.rowOf(newy))
 		}
 	}
 	less.refreshBuffer()
@@ -268,7 +817,7 @@ func (less *Less) gotoNextSearchHit() {
 	if sr != nil {
 		x, _ := less.Position()
 		if newY, err := sr.NextLine(); err == nil {
-			less.setPosition(x, newY)
+			less.setPosition(x, less.rowOf(newY))
 		}
 	}
 	less.refreshBuffer()
@@ -290,9 +839,9 @@ func (less *Less) renderableArea() (int, int) {
 	return maxX, maxY - 1
 }
 
-func (less *Less) renderLine(x int, y int, line string) (int, error) {
+//renderLine renders the given line, lines written to stderr are rendered with a different style
+func (less *Less) renderLine(x int, y int, line string, stderr bool) (int, error) {
 	var lines = 1
-	maxWidth, _ := less.renderableArea()
 	if less.searchResult != nil {
 		//If markup support is active then it might happen that tags are present in the line
 		//but since we are searching, markups are ignored and coloring output is
@@ -307,19 +856,114 @@ func (less *Less) renderLine(x int, y int, line string) (int, error) {
 					builder.WriteString(token)
 				}
 				line = builder.String()
+			} else if ansiClean := terminal.RemoveANSIEscapeCharacters(line); len(ansiClean) > 0 {
+				line = string(ansiClean[0])
 			}
-			lines = less.renderer.On(x, y).WithStyle(less.searchHitStyle).WithWidth(maxWidth).Render(line)
+			lines = less.renderSearchHits(x, y, line, less.lineStyle(stderr))
 
 		} else if !less.filtering {
-			return less.View.renderLine(x, y, line)
+			return less.renderPlainLine(x, y, line, stderr)
 		}
 
 	} else {
-		return less.View.renderLine(x, y, line)
+		return less.renderPlainLine(x, y, line, stderr)
 	}
 	return lines, nil
 }
 
+//renderPlainLine renders a line that is not a search hit
+func (less *Less) renderPlainLine(x int, y int, line string, stderr bool) (int, error) {
+	var spans []textSpan
+	switch {
+	case less.markup != nil:
+		for _, token := range Tokenize(line, SupportedTags) {
+			if less.markup.IsTag(token) {
+				continue
+			}
+			spans = append(spans, textSpan{token, mkStyle(less.markup.Foreground, less.markup.Background)})
+		}
+	case less.renderANSI:
+		style := less.lineStyle(stderr)
+		for _, styled := range terminal.ParseANSI(line) {
+			spans = append(spans, textSpan{styled.Text, ansiStyle(style, styled.Style)})
+		}
+	default:
+		style := less.lineStyle(stderr)
+		if !stderr {
+			style = mkStyle(termbox.Attribute(less.View.theme.Fg), termbox.Attribute(less.View.theme.Bg))
+		}
+		if ansiClean := terminal.RemoveANSIEscapeCharacters(line); len(ansiClean) > 0 {
+			spans = append(spans, textSpan{string(ansiClean[0]), style})
+		}
+	}
+	return less.renderSpans(x, y, spans), nil
+}
+
+func (less *Less) lineStyle(stderr bool) tcell.Style {
+	if stderr {
+		return less.stderrStyle
+	}
+	return less.defaultStyle
+}
+
+//renderSearchHits renders the given line highlighting every match of the
+//search pattern, the rest of the line is rendered with the given style.
+//Returns the number of screen lines used.
+func (less *Less) renderSearchHits(x, y int, line string, style tcell.Style) int {
+	pattern := less.searchResult.Pattern
+	parts := strings.Split(line, pattern)
+	spans := make([]textSpan, 0, 2*len(parts))
+	for i, part := range parts {
+		spans = append(spans, textSpan{part, style})
+		if i < len(parts)-1 {
+			spans = append(spans, textSpan{pattern, less.searchHitStyle})
+		}
+	}
+	return less.renderSpans(x, y, spans)
+}
+
+//textSpan is a piece of text rendered with the same style
+type textSpan struct {
+	text  string
+	style tcell.Style
+}
+
+//renderSpans renders the given spans one after the other starting on the
+//given position, text not fitting in the view width continues on the next
+//screen line, starting again on the given column. Screen lines below the
+//renderable area are not rendered.
+//Returns the number of screen lines used.
+func (less *Less) renderSpans(x, y int, spans []textSpan) int {
+	maxWidth, maxY := less.renderableArea()
+	width := maxWidth - x
+	if width <= 0 {
+		return 1
+	}
+	lines, column := 1, 0
+	render := func(text []rune, start int, style tcell.Style) {
+		if row := y + lines - 1; len(text) > 0 && row <= less.y0+maxY {
+			less.renderer.On(x+start, row).WithStyle(style).WithWidth(width).Render(string(text))
+		}
+	}
+	for _, span := range spans {
+		var text []rune
+		start := column
+		for _, r := range span.text {
+			w := runewidth.RuneWidth(r)
+			if column+w > width && column > 0 {
+				render(text, start, span.style)
+				text = text[:0]
+				lines++
+				start, column = 0, 0
+			}
+			text = append(text, r)
+			column += w
+		}
+		render(text, start, span.style)
+	}
+	return lines
+}
+
 //scrollDown moves the buffer position down by the given number of lines
 func (less *Less) scrollDown(lines int) {
 	_, height := less.ViewSize()
@@ -362,8 +1006,12 @@ func (less *Less) renderStatusLine() {
 	} else if less.atTheStartOfBuffer() {
 		cursorX = len(starttext)
 	}
-	less.renderer.On(0, maxLength).WithStyle(
-		less.defaultStyle).WithWidth(maxWidth).Render(status)
+	style := less.defaultStyle
+	if less.highlightStatus {
+		style = style.Reverse(true)
+	}
+	less.renderer.On(0, less.y0+maxLength).WithStyle(
+		style).WithWidth(maxWidth).Render(status)
 	less.cursorX = cursorX
 }
 
@@ -379,12 +1027,22 @@ func (less *Less) statusLine() string {
 	default:
 		start = ":"
 	}
+	if less.message != "" {
+		start = less.message
+	}
 
 	var end string
+	if less.status != "" {
+		end = less.status + " "
+	}
+	if less.searchResult != nil {
+		end += fmt.Sprintf("%s (%d/%d) ",
+			less.searchResult.String(), less.searchResult.Position(), less.searchResult.Hits)
+	}
 	if less.filtering && less.searchResult != nil {
-		end = strings.Join([]string{less.searchResult.String(), "Filter: On"}, " ")
+		end += "Filter: On"
 	} else {
-		end = "Filter: Off"
+		end += "Filter: Off"
 	}
 
 	if less.following {
@@ -393,9 +1051,25 @@ func (less *Less) statusLine() string {
 		end += " Follow: Off"
 	}
 
+	if less.renderANSI {
+		end += " ANSI: On"
+	} else {
+		end += " ANSI: Off"
+	}
+
+	if less.jsonFilter != nil {
+		end += " JSON: " + less.jsonFilter.String()
+	} else if less.jsonMode {
+		end += " JSON: On"
+	}
+
+	padding := maxWidth - len(start) - len(end)
+	if padding < 1 {
+		padding = 1
+	}
 	return strings.Join(
 		[]string{start, end},
-		strings.Repeat(" ", maxWidth-len(start)-len(end)))
+		strings.Repeat(" ", padding))
 }
 
 func (less *Less) drawCursor() {
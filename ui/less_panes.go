@@ -0,0 +1,108 @@
+package ui
+
+import (
+	"sync"
+
+	"github.com/gdamore/tcell"
+)
+
+//LessPanes shows several Less views stacked on the screen. Each pane keeps
+//its own buffer, position and state, keys are handled by the focused pane
+//and Tab (or Shift+Tab) moves the focus to the next (or previous) pane.
+type LessPanes struct {
+	panes   []*Less
+	focused int
+	screen  *Screen
+	sync.Mutex
+}
+
+//NewLessPanes creates the given number of Less views splitting the screen
+//height between them
+func NewLessPanes(theme *ColorTheme, n int) *LessPanes {
+	height := ActiveScreen.Dimensions().Height
+	p := &LessPanes{screen: ActiveScreen}
+	for i := 0; i < n; i++ {
+		p.panes = append(p.panes, newLessAt(theme, i*height/n, (i+1)*height/n))
+	}
+	return p
+}
+
+//Pane returns the pane on the given position, counting from the top
+func (p *LessPanes) Pane(i int) *Less {
+	return p.panes[i]
+}
+
+//Len returns the number of panes
+func (p *LessPanes) Len() int {
+	return len(p.panes)
+}
+
+//Focus sets the panes as active, so they start handling terminal events
+//and user actions, it returns once the panes are closed with Esc
+func (p *LessPanes) Focus(events <-chan *tcell.EventKey) error {
+	refresh := make(chan struct{}, 1)
+	for _, pane := range p.panes {
+		pane.activate(refresh)
+	}
+	p.focus(0)
+
+	go func() {
+		for {
+			focused := p.focusedPane()
+			select {
+			case input := <-focused.inputOutput:
+				focused.endInput(input)
+			case event := <-events:
+				if !focused.inputMode && event.Key() == tcell.KeyTab {
+					p.focus(p.focused + 1)
+					continue
+				}
+				if !focused.inputMode && event.Key() == tcell.KeyBacktab {
+					p.focus(p.focused - 1)
+					continue
+				}
+				if !focused.handle(event) {
+					for _, pane := range p.panes {
+						pane.deactivate()
+					}
+					close(refresh)
+					return
+				}
+			}
+		}
+	}()
+
+	for range refresh {
+		focused := p.focusedPane()
+		//While input is read the only UI changes happen on the input box
+		if focused.inputMode {
+			continue
+		}
+		p.screen.Clear()
+		for _, pane := range p.panes {
+			pane.render()
+		}
+		focused.drawCursor()
+		p.screen.Flush()
+	}
+	return nil
+}
+
+//focus moves the focus to the pane on the given position, wrapping around
+func (p *LessPanes) focus(i int) {
+	p.Lock()
+	n := len(p.panes)
+	p.focused = (i%n + n) % n
+	for j, pane := range p.panes {
+		pane.highlightStatus = n > 1 && j == p.focused
+	}
+	focused := p.panes[p.focused]
+	p.Unlock()
+	focused.refreshBuffer()
+}
+
+func (p *LessPanes) focusedPane() *Less {
+	p.Lock()
+	defer p.Unlock()
+	return p.panes[p.focused]
+}
@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gdamore/tcell"
+)
+
+//newLessPane creates a less view using the screen rows from y0 to y1
+func newLessPane(width, y0, y1 int) *Less {
+	less := newLess(width, y1-y0)
+	less.y0, less.y1 = y0, y1
+	less.cursorY = y1 - 1
+	return less
+}
+
+func TestLessPanesFocus(t *testing.T) {
+	p := &LessPanes{panes: []*Less{newLessPane(10, 0, 10), newLessPane(10, 10, 20), newLessPane(10, 20, 30)}}
+	tests := []struct {
+		focus int
+		want  int
+	}{
+		{0, 0}, {1, 1}, {3, 0}, {-1, 2},
+	}
+	for _, tt := range tests {
+		p.focus(tt.focus)
+		if p.focusedPane() != p.panes[tt.want] {
+			t.Errorf("focus(%d) focused pane %d, want %d", tt.focus, p.focused, tt.want)
+		}
+		for i, pane := range p.panes {
+			if pane.highlightStatus != (i == tt.want) {
+				t.Errorf("focus(%d): pane %d highlighted: %t", tt.focus, i, pane.highlightStatus)
+			}
+		}
+	}
+}
+
+func TestLessPaneScrolling(t *testing.T) {
+	top := newLessPane(10, 0, 10)
+	bottom := newLessPane(10, 10, 20)
+	for i := 0; i < 20; i++ {
+		fmt.Fprintf(top, "Line %d\n", i)
+		fmt.Fprintf(bottom, "Line %d\n", i)
+	}
+	top.ScrollToBottom()
+	bottom.ScrollToBottom()
+	testLessBufferPosition(t, bottom, 0, 11)
+	testLessBufferPosition(t, top, 0, 11)
+	bottom.GotoLine(100)
+	testLessBufferPosition(t, bottom, 0, 11)
+	bottom.ScrollToTop()
+	testLessBufferPosition(t, bottom, 0, 0)
+	testLessBufferPosition(t, top, 0, 11)
+}
+
+func TestLessHandle(t *testing.T) {
+	less := newLess(10, 10)
+	if !less.handle(tcell.NewEventKey(tcell.KeyRune, 'f', tcell.ModNone)) || !less.following {
+		t.Error("'f' did not toggle follow mode")
+	}
+	if !less.handle(tcell.NewEventKey(tcell.KeyTab, 0, tcell.ModNone)) {
+		t.Error("An unbound key closed the view")
+	}
+	if less.handle(tcell.NewEventKey(tcell.KeyEsc, 0, tcell.ModNone)) {
+		t.Error("Esc did not close the view")
+	}
+}
@@ -156,3 +156,156 @@ func newLess(width int, height int) *Less {
 		refresh: make(chan struct{}, 10),
 	}
 }
+
+func TestLessSearchOnStreamedLines(t *testing.T) {
+	less := newLess(10, 10)
+	less.newLineCallback = less.indexNewLines
+
+	for i := 0; i < 5; i++ {
+		fmt.Fprintf(less, "Line %d\n", i)
+	}
+	if err := less.search("Line"); err != nil {
+		t.Fatal(err)
+	}
+	for i := 5; i < 20; i++ {
+		fmt.Fprintf(less, "Line %d\n", i)
+	}
+	fmt.Fprint(less, "Other\n")
+
+	if less.searchResult.Hits != 20 {
+		t.Errorf("Expected to find %d occurrences, got: %d", 20, less.searchResult.Hits)
+	}
+}
+
+func TestParseLinePosition(t *testing.T) {
+	tests := []struct {
+		input   string
+		lines   int
+		want    int
+		wantErr bool
+	}{
+		{"12", 100, 12, false},
+		{"0%", 101, 1, false},
+		{"50%", 101, 51, false},
+		{"100%", 101, 101, false},
+		{"150%", 101, 0, true},
+		{"0", 100, 0, true},
+		{"top", 100, 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseLinePosition(tt.input, tt.lines)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseLinePosition(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseLinePosition(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestLessGotoLine(t *testing.T) {
+	less := newLess(10, 10)
+
+	for i := 0; i < 20; i++ {
+		fmt.Fprintf(less, "Line %d\n", i)
+	}
+	less.GotoLine(5)
+	testLessBufferPosition(t, less, 0, 4)
+	less.GotoLine(100)
+	testLessBufferPosition(t, less, 0, 11)
+}
+
+func TestLessStderr(t *testing.T) {
+	less := newLess(20, 10)
+
+	fmt.Fprint(less, "out 1\n")
+	fmt.Fprint(less.Stderr(), "err 1\nerr 2\n")
+	fmt.Fprint(less, "out 2\n")
+
+	for line, want := range []bool{false, true, true, false, false} {
+		if got := less.isStderr(line); got != want {
+			t.Errorf("Line %d written to stderr: %t, expected %t", line, got, want)
+		}
+	}
+
+	less.Clear()
+	if less.isStderr(1) || less.bufferSize() != 0 {
+		t.Error("Less buffer was not cleared")
+	}
+}
+
+type cellsMock map[[2]int]rune
+
+func (s cellsMock) Dimensions() *Dimensions {
+	return &Dimensions{Width: 10, Height: 10}
+}
+func (s cellsMock) Render(x int, y int, r rune, style tcell.Style) {
+	s[[2]int{x, y}] = r
+}
+func (s cellsMock) Style() tcell.Style {
+	return tcell.StyleDefault
+}
+
+func TestLessSearchHitsWrapping(t *testing.T) {
+	less := newLess(10, 10)
+	cells := cellsMock{}
+	less.renderer = NewRenderer(cells)
+	fmt.Fprint(less, "xxabxxabxx\n")
+	if err := less.search("ab"); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := less.renderSearchHits(3, 0, "xxabxxabxx", tcell.StyleDefault)
+	if lines != 2 {
+		t.Errorf("Expected the line to use 2 screen lines, got %d", lines)
+	}
+	for pos := range cells {
+		if pos[0] < 3 || pos[0] >= 10 {
+			t.Errorf("Rune %q rendered outside of the view, on column %d", cells[pos], pos[0])
+		}
+	}
+	if r := cells[[2]int{9, 0}]; r != 'a' {
+		t.Errorf("Expected 'a' at the end of the first line, got %q", r)
+	}
+	if r := cells[[2]int{3, 1}]; r != 'b' {
+		t.Errorf("Expected the line to continue on column 3, got %q", r)
+	}
+}
+
+func TestLessJSONFilter(t *testing.T) {
+	less := newLess(40, 10)
+
+	for i := 0; i < 20; i++ {
+		level := "info"
+		if i%4 == 0 {
+			level = "error"
+		}
+		fmt.Fprintf(less, "{\"level\": %q, \"n\": %d}\n", level, i)
+		fmt.Fprintf(less, "plain %d\n", i)
+	}
+	less.setJSONFilter("level=error")
+
+	if rows := less.filteredRows(); len(rows) != 5 || rows[1] != 8 {
+		t.Fatalf("Unexpected rows shown with the JSON filter: %v", rows)
+	}
+	if less.bufferSize() != 5 {
+		t.Errorf("Expected 5 lines to scroll through, got %d", less.bufferSize())
+	}
+	less.ScrollPageDown()
+	testLessBufferPosition(t, less, 0, 0)
+
+	fmt.Fprint(less, `{"level": "error", "n": 20}`)
+	if less.bufferSize() != 6 {
+		t.Errorf("Expected the line being written to be shown, got %d lines", less.bufferSize())
+	}
+	fmt.Fprint(less, "\nplain 20\n")
+	if less.bufferSize() != 6 {
+		t.Errorf("Expected 6 lines once written, got %d", less.bufferSize())
+	}
+
+	less.setJSONFilter("")
+	if less.bufferSize() != len(less.lines) {
+		t.Errorf("Expected every line to be shown without filter, got %d", less.bufferSize())
+	}
+}
@@ -3,6 +3,7 @@ package ui
 import (
 	"regexp"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/gdamore/tcell/termbox"
 )
@@ -28,9 +29,9 @@ func tags() map[string]termbox.Attribute {
 	//the same for all color tags (the magic number is 5, because white) to avoid,
 	//text alignment problems, hence the strange tag names.
 
-	//TODO Figure out a way to use markups and the ColorTheme. Right now a tag
-	//value corresponds to an specific color, and there are cases might now even
-	//fit the description (so green is not really green but something that fits the DarkTheme).
+	//These are the default colors of the tags, themes can change them, so a tag
+	//value might not even fit its name (so green is not really green but something
+	//that fits the theme).
 	tags := make(map[string]termbox.Attribute)
 	tags[`/`] = termbox.Attribute(ColorWhite)
 	tags[`black`] = termbox.ColorBlack
@@ -90,6 +91,9 @@ func (markup *Markup) IsTag(str string) bool {
 func (markup *Markup) process(tag string, open bool) bool {
 	if attribute, ok := tagsToAttributeMap[tag]; ok {
 		if open {
+			if color, ok := markup.theme.Markup[tag]; ok {
+				attribute = termbox.Attribute(color)
+			}
 			markup.Foreground = attribute
 		} else {
 			markup.Foreground = termbox.Attribute(markup.theme.Fg)
@@ -120,6 +124,18 @@ func extractTagName(str string) string {
 	return `/`
 }
 
+//TextWidth returns the number of characters the given string takes when
+//rendered, markup tags take none
+func TextWidth(str string) int {
+	width := 0
+	for _, token := range Tokenize(str, SupportedTags) {
+		if !SupportedTags.MatchString(token) {
+			width += utf8.RuneCountInString(token)
+		}
+	}
+	return width
+}
+
 // Tokenize works just like strings.Split() except the resulting array includes
 // the delimiters. For example, the "<green>Hello, <red>world!</>" string when
 // tokenized by tags produces the following:
@@ -5,6 +5,8 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+
+	"github.com/gdamore/tcell/termbox"
 )
 
 func TestTokenize(t *testing.T) {
@@ -31,6 +33,15 @@ func TestTokenize(t *testing.T) {
 	}
 }
 
+func TestTextWidth(t *testing.T) {
+	if width := TextWidth("<green>●</> API 1.40 <white>12ms</>"); width != 15 {
+		t.Errorf("Unexpected width of markup text: %d", width)
+	}
+	if width := TextWidth("plain"); width != 5 {
+		t.Errorf("Unexpected width of plain text: %d", width)
+	}
+}
+
 func TestTokenizeEmptyString(t *testing.T) {
 	result := Tokenize("", regexp.MustCompile(" "))
 	if len(result) != 0 {
@@ -51,3 +62,17 @@ func TestTokenizeNil(t *testing.T) {
 			len(result))
 	}
 }
+
+func TestMarkupThemeColors(t *testing.T) {
+	theme := &ColorTheme{Fg: Color252, Markup: map[string]Color{"blue": Color33}}
+	markup := NewMarkup(theme)
+	if !markup.IsTag("<blue>") || markup.Foreground != termbox.Attribute(Color33) {
+		t.Errorf("Tag color not taken from the theme, got %v", markup.Foreground)
+	}
+	if !markup.IsTag("</>") || markup.Foreground != termbox.Attribute(Color252) {
+		t.Errorf("Closing tag did not restore the theme foreground, got %v", markup.Foreground)
+	}
+	if !markup.IsTag("<red>") || markup.Foreground != termbox.ColorRed {
+		t.Errorf("Tag not in the theme does not have its default color, got %v", markup.Foreground)
+	}
+}
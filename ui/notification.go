@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+//ErrNoDesktopNotifications is returned when desktop notifications are not
+//available on this system
+var ErrNoDesktopNotifications = errors.New("desktop notifications are not available")
+
+//DesktopNotification shows a desktop notification with the given title and
+//body, using notify-send on Linux and the BSDs and osascript on macOS
+func DesktopNotification(title, body string) error {
+	name, args := desktopNotificationCommand(runtime.GOOS, title, body)
+	if name == "" {
+		return ErrNoDesktopNotifications
+	}
+	if _, err := exec.LookPath(name); err != nil {
+		return ErrNoDesktopNotifications
+	}
+	return exec.Command(name, args...).Run()
+}
+
+//desktopNotificationCommand returns the command, and its arguments, that shows
+//a desktop notification on the given OS, no command if there is none
+func desktopNotificationCommand(goos, title, body string) (string, []string) {
+	switch goos {
+	case "darwin":
+		quote := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+		return "osascript", []string{"-e", fmt.Sprintf(`display notification "%s" with title "%s"`,
+			quote.Replace(body), quote.Replace(title))}
+	case "linux", "freebsd", "openbsd", "netbsd":
+		return "notify-send", []string{title, body}
+	}
+	return "", nil
+}
@@ -0,0 +1,18 @@
+package ui
+
+import "testing"
+
+func Test_desktopNotificationCommand(t *testing.T) {
+	name, args := desktopNotificationCommand("linux", "dry", "web died")
+	if name != "notify-send" || len(args) != 2 || args[0] != "dry" || args[1] != "web died" {
+		t.Errorf("Unexpected Linux notification command: %s %v", name, args)
+	}
+	name, args = desktopNotificationCommand("darwin", "dry", `web "api" died`)
+	if name != "osascript" || len(args) != 2 ||
+		args[1] != `display notification "web \"api\" died" with title "dry"` {
+		t.Errorf("Unexpected macOS notification command: %s %v", name, args)
+	}
+	if name, _ := desktopNotificationCommand("windows", "dry", "web died"); name != "" {
+		t.Errorf("Unexpected Windows notification command: %s", name)
+	}
+}
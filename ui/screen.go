@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"os"
 	"strings"
 	"sync"
 
@@ -21,6 +22,8 @@ type Screen struct {
 	theme      *ColorTheme
 	screen     tcell.Screen
 	themeStyle tcell.Style
+	//tty is the terminal the screen is drawn on
+	tty *os.File
 
 	sync.RWMutex
 	closing    bool
@@ -39,6 +42,7 @@ func NewScreen(theme *ColorTheme) (*Screen, error) {
 	screen.theme = theme
 	screen.dimensions = screenDimensions(s)
 	screen.screen = s
+	screen.tty = terminalOutput()
 	screen.themeStyle = mkStyle(
 		screen.markup.Foreground,
 		screen.markup.Background)
@@ -53,9 +57,21 @@ func (screen *Screen) Close() *Screen {
 	screen.closing = true
 	screen.Unlock()
 	screen.screen.Fini()
+	if screen.tty != os.Stdout {
+		screen.tty.Close()
+	}
 	return screen
 }
 
+//Write writes the given escape sequence to the terminal the screen is drawn
+//on. It is written holding the screen lock, so it does not end up in the
+//middle of the screen content being flushed.
+func (screen *Screen) Write(seq []byte) (int, error) {
+	screen.Lock()
+	defer screen.Unlock()
+	return screen.tty.Write(seq)
+}
+
 // Closing returns true if this this screen is closing
 func (screen *Screen) Closing() bool {
 	screen.RLock()
@@ -5,8 +5,28 @@ import (
 
 	"github.com/gdamore/tcell"
 	"github.com/gdamore/tcell/termbox"
+	"github.com/moncho/dry/terminal"
 )
 
+//terminalOutput returns the terminal tcell draws on, tcell does not
+//expose it to write the escape sequences it does not know about
+func terminalOutput() *os.File {
+	if tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0); err == nil {
+		return tty
+	}
+	return os.Stdout
+}
+
+//activeScreenWriter writes escape sequences through the active screen
+type activeScreenWriter struct{}
+
+func (activeScreenWriter) Write(p []byte) (int, error) {
+	if ActiveScreen == nil {
+		return os.Stdout.Write(p)
+	}
+	return ActiveScreen.Write(p)
+}
+
 type styledRuneRenderer interface {
 	Dimensions() *Dimensions
 	Render(x int, y int, r rune, style tcell.Style)
@@ -95,3 +115,26 @@ func screenDimensions(s tcell.Screen) *Dimensions {
 	w, h := s.Size()
 	return &Dimensions{Width: w, Height: h}
 }
+
+//ansiStyle returns the given style with the changes set by the given ANSI style
+func ansiStyle(base tcell.Style, style terminal.Style) tcell.Style {
+	st := base
+	if fg := ansiColor(style.Fg); fg != tcell.ColorDefault {
+		st = st.Foreground(fg)
+	}
+	if bg := ansiColor(style.Bg); bg != tcell.ColorDefault {
+		st = st.Background(bg)
+	}
+	return st.Bold(style.Bold).Underline(style.Underline).Reverse(style.Reverse)
+}
+
+func ansiColor(c terminal.Color) tcell.Color {
+	switch {
+	case c == terminal.ColorDefault:
+		return tcell.ColorDefault
+	case c.IsRGB():
+		r, g, b := c.RGB()
+		return tcell.NewRGBColor(int32(r), int32(g), int32(b))
+	}
+	return tcell.Color(c)
+}
@@ -73,8 +73,11 @@ func EventChannel() (<-chan tcell.Event, chan struct{}) {
 	go func() {
 		defer func() { close(events) }()
 
+		var focus focusParser
 		for {
-			events <- ActiveScreen.screen.PollEvent()
+			for _, ev := range focus.parse(ActiveScreen.screen.PollEvent()) {
+				events <- ev
+			}
 			select {
 			case <-done:
 				return
@@ -2,12 +2,15 @@ package termui
 
 import (
 	termui "github.com/gizak/termui"
+	runewidth "github.com/mattn/go-runewidth"
 	"github.com/moncho/dry/ui"
 )
 
 //ParColumn is a termui.Par that can be used in a grid to show text
 type ParColumn struct {
 	termui.Paragraph
+	highlighted   map[int]bool
+	highlightedFg termui.Attribute
 }
 
 //NewThemedParColumn creates a new paragraph column with the given text using the given color theme
@@ -24,7 +27,7 @@ func NewParColumn(s string) *ParColumn {
 	p := termui.NewParagraph(s)
 	p.Border = false
 
-	return &ParColumn{*p}
+	return &ParColumn{Paragraph: *p}
 }
 
 //Reset resets the text on this Par
@@ -47,3 +50,47 @@ func (w *ParColumn) SetWidth(width int) {
 		w.Width = width
 	}
 }
+
+//Highlight shows the characters of the text of this Par on the given
+//positions (rune indexes) using the given foreground color, nil positions
+//remove any highlighting
+func (w *ParColumn) Highlight(positions []int, fg termui.Attribute) {
+	if len(positions) == 0 {
+		w.highlighted = nil
+		return
+	}
+	w.highlighted = make(map[int]bool, len(positions))
+	for _, p := range positions {
+		w.highlighted[p] = true
+	}
+	w.highlightedFg = fg
+}
+
+//Buffer returns this Par buffer, with its highlighted characters, if any,
+//shown on their color
+func (w *ParColumn) Buffer() termui.Buffer {
+	buf := w.Paragraph.Buffer()
+	if len(w.highlighted) == 0 {
+		return buf
+	}
+	area := w.InnerBounds()
+	maxX := area.Max.X
+	//the last cell shows an ellipsis if the text does not fit
+	if runewidth.StringWidth(w.Text) > area.Dx() {
+		maxX--
+	}
+	x := area.Min.X
+	for i, r := range []rune(w.Text) {
+		width := runewidth.RuneWidth(r)
+		if x+width > maxX {
+			break
+		}
+		if w.highlighted[i] {
+			cell := buf.At(x, area.Min.Y)
+			cell.Fg = w.highlightedFg
+			buf.Set(x, area.Min.Y, cell)
+		}
+		x += width
+	}
+	return buf
+}
@@ -1,6 +1,10 @@
 package termui
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/gizak/termui"
+)
 
 const text = "Move along, nothing to see here"
 
@@ -18,3 +22,22 @@ func TestParColumn(t *testing.T) {
 		t.Error("ParColumn has a border")
 	}
 }
+
+func TestParColumn_Highlight(t *testing.T) {
+	p := NewParColumn("dry")
+	p.Height = 1
+	p.SetWidth(10)
+	p.Highlight([]int{0, 2}, termui.ColorRed)
+
+	buf := p.Buffer()
+	for i, want := range []termui.Attribute{termui.ColorRed, p.TextFgColor, termui.ColorRed} {
+		if got := buf.At(i, 0).Fg; got != want {
+			t.Errorf("Unexpected color of character %d, got %v, want %v", i, got, want)
+		}
+	}
+
+	p.Highlight(nil, termui.ColorRed)
+	if got := p.Buffer().At(0, 0).Fg; got != p.TextFgColor {
+		t.Errorf("Highlighting was not removed, got %v, want %v", got, p.TextFgColor)
+	}
+}
@@ -0,0 +1,121 @@
+package termui
+
+import (
+	"sync"
+
+	termui "github.com/gizak/termui"
+	"github.com/moncho/dry/ui"
+)
+
+//sparklineTicks are the characters used to draw a sparkline, from lowest to highest
+var sparklineTicks = []rune("▁▂▃▄▅▆▇█")
+
+//sparklineMarker is the character used to mark values on a sparkline
+const sparklineMarker = '┃'
+
+//maxSparklineValues is the maximum number of values kept by a SparklineColumn
+const maxSparklineValues = 256
+
+//SparklineColumn shows a series of percentages as a one line sparkline, to be
+//used as a Grid column. Values can be marked to make them stand out, i.e. to
+//correlate a value with something that happened when it was taken.
+type SparklineColumn struct {
+	termui.Block
+	LineColor   termui.Attribute
+	values      []int
+	markers     map[int]termui.Attribute
+	pendingMark *termui.Attribute
+	added       int
+	sync.RWMutex
+}
+
+//NewThemedSparklineColumn creates a new SparklineColumn using the given theme
+func NewThemedSparklineColumn(theme *ui.ColorTheme) *SparklineColumn {
+	c := NewSparklineColumn()
+	c.Bg = termui.Attribute(theme.Bg)
+	c.LineColor = termui.Attribute(theme.Info)
+	return c
+}
+
+//NewSparklineColumn creates a new SparklineColumn
+func NewSparklineColumn() *SparklineColumn {
+	b := termui.NewBlock()
+	b.Height = 1
+	b.Border = false
+	return &SparklineColumn{
+		Block:   *b,
+		markers: make(map[int]termui.Attribute),
+	}
+}
+
+//Add adds the given percentage to the sparkline
+func (w *SparklineColumn) Add(percent int) {
+	w.Lock()
+	defer w.Unlock()
+	if percent < 0 {
+		percent = 0
+	} else if percent > 100 {
+		percent = 100
+	}
+	w.values = append(w.values, percent)
+	if w.pendingMark != nil {
+		w.markers[w.added] = *w.pendingMark
+		w.pendingMark = nil
+	}
+	w.added++
+	if len(w.values) > maxSparklineValues {
+		w.values = w.values[len(w.values)-maxSparklineValues:]
+		for i := range w.markers {
+			if i < w.added-maxSparklineValues {
+				delete(w.markers, i)
+			}
+		}
+	}
+}
+
+//Mark marks the next value added to the sparkline using the given color
+func (w *SparklineColumn) Mark(color termui.Attribute) {
+	w.Lock()
+	defer w.Unlock()
+	w.pendingMark = &color
+}
+
+//Reset removes all values and marks from the sparkline
+func (w *SparklineColumn) Reset() {
+	w.Lock()
+	defer w.Unlock()
+	w.values = nil
+	w.markers = make(map[int]termui.Attribute)
+	w.pendingMark = nil
+	w.added = 0
+}
+
+//Buffer returns the content of this sparkline as a termui.Buffer, the most
+//recent values are shown on the right
+func (w *SparklineColumn) Buffer() termui.Buffer {
+	w.RLock()
+	defer w.RUnlock()
+	buf := w.Block.Buffer()
+	width := w.InnerWidth()
+	values := w.values
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+	//index, as counted by added, of the first value shown
+	first := w.added - len(values)
+	x := w.InnerX() + width - len(values)
+	y := w.InnerY()
+	for i, v := range values {
+		cell := termui.Cell{
+			Ch: sparklineTicks[v*(len(sparklineTicks)-1)/100],
+			Fg: w.LineColor,
+			Bg: w.Bg,
+		}
+		if color, ok := w.markers[first+i]; ok {
+			cell.Ch = sparklineMarker
+			cell.Fg = color
+		}
+		buf.Set(x+i, y, cell)
+	}
+	return buf
+}
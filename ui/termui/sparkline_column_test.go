@@ -0,0 +1,32 @@
+package termui
+
+import (
+	"testing"
+
+	termui "github.com/gizak/termui"
+)
+
+func TestSparklineColumn(t *testing.T) {
+	c := NewSparklineColumn()
+	c.SetWidth(4)
+	c.Add(0)
+	c.Mark(termui.ColorRed)
+	c.Add(100)
+	c.Add(100)
+
+	buf := c.Buffer()
+	expected := []rune{' ', '▁', sparklineMarker, '█'}
+	for x, want := range expected {
+		if got := buf.At(x, 0).Ch; got != want {
+			t.Errorf("Unexpected sparkline char at %d, got %q, want %q", x, got, want)
+		}
+	}
+	if fg := buf.At(2, 0).Fg; fg != termui.ColorRed {
+		t.Errorf("Unexpected marker color, got %v", fg)
+	}
+
+	c.Reset()
+	if got := c.Buffer().At(3, 0).Ch; got != ' ' {
+		t.Errorf("Sparkline was not reset, got %q", got)
+	}
+}
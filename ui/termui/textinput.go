@@ -2,6 +2,7 @@ package termui
 
 import (
 	"errors"
+	"strings"
 	"sync"
 
 	"github.com/gdamore/tcell"
@@ -21,7 +22,9 @@ type TextInput struct {
 	TextFgColor   termui.Attribute
 	TextBgColor   termui.Attribute
 	TextBuilder   termui.TextBuilder
-	c             cursor
+	//Mask, if set, is shown instead of each of the runes of the input
+	Mask rune
+	c    cursor
 
 	sync.RWMutex
 	isCapturing bool
@@ -201,6 +204,9 @@ func (i *TextInput) Buffer() termui.Buffer {
 	buffer := i.Block.Buffer()
 	innerArea := i.InnerBounds()
 	text := string(i.input)
+	if i.Mask != 0 {
+		text = strings.Repeat(string(i.Mask), len(i.input))
+	}
 
 	fg, bg := i.TextFgColor, i.TextBgColor
 	cells := i.TextBuilder.Build(text, fg, bg)
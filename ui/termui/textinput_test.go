@@ -288,6 +288,22 @@ func Test_TextInput_Buffer(t *testing.T) {
 	}
 }
 
+func Test_TextInput_MaskedBuffer(t *testing.T) {
+	input := NewTextInput(cursorMock{}, "hey")
+	input.Mask = '*'
+	input.Width = 5
+	input.Height = 3
+	buffer := input.Buffer()
+	for x := 1; x <= 3; x++ {
+		if c := buffer.At(x, 1); c.Ch != '*' {
+			t.Errorf("Masked input shows %q at %d", c.Ch, x)
+		}
+	}
+	if text, _ := input.Text(); text != "hey" {
+		t.Errorf("Masked input text = %q, want %q", text, "hey")
+	}
+}
+
 func Test_TextInput_RemoveCharsFromInput(t *testing.T) {
 
 	type arg struct {
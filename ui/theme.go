@@ -17,4 +17,7 @@ type ColorTheme struct {
 	Footer       Color
 	ListItem     Color
 	CursorLineBg Color
+	//Markup are the colors of markup tags (i.e. <blue>), by tag name, tags
+	//not found keep their default color
+	Markup map[string]Color
 }
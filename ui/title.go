@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+)
+
+//tmuxStatusOption is the tmux window option where the dry status is set, it
+//can be shown on the tmux status line using #{@dry_status}
+const tmuxStatusOption = "@dry_status"
+
+//titleOutput is where terminal title escape sequences are written
+var titleOutput io.Writer = activeScreenWriter{}
+
+//SetTerminalTitle sets the title of the terminal window
+func SetTerminalTitle(title string) {
+	fmt.Fprintf(titleOutput, "\033]0;%s\007", title)
+}
+
+//SaveTerminalTitle saves the current terminal title, so it can be restored
+//later with RestoreTerminalTitle. Not every terminal supports it.
+func SaveTerminalTitle() {
+	fmt.Fprint(titleOutput, "\033[22;0t")
+}
+
+//RestoreTerminalTitle restores the terminal title saved with SaveTerminalTitle
+func RestoreTerminalTitle() {
+	fmt.Fprint(titleOutput, "\033[23;0t")
+}
+
+//InTmux returns true if dry is running inside a tmux session
+func InTmux() bool {
+	return os.Getenv("TMUX") != ""
+}
+
+//SetTmuxStatus sets the given status on the current tmux window, an empty
+//status unsets it
+func SetTmuxStatus(status string) error {
+	args := []string{"set-option", "-wq", tmuxStatusOption, status}
+	if status == "" {
+		args = []string{"set-option", "-wqu", tmuxStatusOption}
+	}
+	return exec.Command("tmux", args...).Run()
+}
+
+//Bell rings the terminal bell
+func Bell() {
+	fmt.Fprint(titleOutput, "\a")
+}